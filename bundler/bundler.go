@@ -17,6 +17,9 @@ import (
 // ErrInvalidModel is returned when the model is not usable.
 var ErrInvalidModel = errors.New("invalid model")
 
+// ErrSharedTrees is returned when the model shares node trees with other documents, which bundling would change.
+var ErrSharedTrees = errors.New("the model shares node trees with other documents, bundle it from bytes instead")
+
 // BundleBytes will take a byte slice of an OpenAPI specification and return a bundled version of it.
 // This is useful for when you want to take a specification with external references, and you want to bundle it
 // into a single document.
@@ -24,8 +27,14 @@ var ErrInvalidModel = errors.New("invalid model")
 // This function will 'resolve' all references in the specification and return a single document. The resulting
 // document will be a valid OpenAPI specification, containing no references.
 //
-// Circular references will not be resolved and will be skipped.
+// Circular references will not be resolved and will be skipped. Bundling changes the node trees of the files, so they
+// are not shared, even if the configuration has a SharedResolutionCache.
 func BundleBytes(bytes []byte, configuration *datamodel.DocumentConfiguration) ([]byte, error) {
+	if configuration != nil && configuration.SharedResolutionCache != nil {
+		copied := *configuration
+		copied.SharedResolutionCache = nil
+		configuration = &copied
+	}
 	doc, err := libopenapi.NewDocumentWithConfiguration(bytes, configuration)
 	if err != nil {
 		return nil, err
@@ -49,7 +58,8 @@ func BundleBytes(bytes []byte, configuration *datamodel.DocumentConfiguration) (
 // This function will 'resolve' all references in the specification and return a single document. The resulting
 // document will be a valid OpenAPI specification, containing no references.
 //
-// Circular references will not be resolved and will be skipped.
+// Circular references will not be resolved and will be skipped. A model that shares node trees with other documents
+// (see datamodel.SharedResolutionCache) cannot be bundled, ErrSharedTrees is returned.
 func BundleDocument(model *v3.Document) ([]byte, error) {
 	return bundle(model, false)
 }
@@ -90,6 +100,11 @@ func bundle(model *v3.Document, inline bool) ([]byte, error) {
 	}
 
 	indexes := rolodex.GetIndexes()
+	for _, idx := range indexes {
+		if idx.SharesTree() {
+			return nil, ErrSharedTrees
+		}
+	}
 	for _, idx := range indexes {
 		compact(idx, false)
 	}
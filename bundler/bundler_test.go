@@ -125,6 +125,32 @@ func TestBundleDocument_MinimalRemoteRefsBundledLocally(t *testing.T) {
 	assert.Contains(t, string(bytes), "Name of the account", "should contain all reference targets")
 }
 
+func TestBundleDocument_SharedResolutionCache(t *testing.T) {
+	specBytes, err := os.ReadFile("../test_specs/minimal_remote_refs/openapi.yaml")
+	require.NoError(t, err)
+
+	config := &datamodel.DocumentConfiguration{
+		AllowFileReferences:   true,
+		BasePath:              "../test_specs/minimal_remote_refs",
+		SharedResolutionCache: datamodel.NewSharedResolutionCache(),
+	}
+
+	// bundling bytes does not share the trees, as bundling changes them.
+	bundled, e := BundleBytes(specBytes, config)
+	assert.NoError(t, e)
+	assert.Contains(t, string(bundled), "Name of the account")
+	assert.Equal(t, 0, config.SharedResolutionCache.Len())
+
+	// a model that shares its trees cannot be bundled.
+	doc, err := libopenapi.NewDocumentWithConfiguration(specBytes, config)
+	require.NoError(t, err)
+	v3Doc, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Greater(t, config.SharedResolutionCache.Len(), 0)
+	_, e = BundleDocument(&v3Doc.Model)
+	assert.ErrorIs(t, e, ErrSharedTrees)
+}
+
 func TestBundleDocument_MinimalRemoteRefsBundledRemotely(t *testing.T) {
 	baseURL, err := url.Parse("https://raw.githubusercontent.com/felixjung/libopenapi/authed-remote/test_specs/minimal_remote_refs")
 
@@ -275,7 +301,7 @@ components:
 	assert.Len(t, bytes, 537)
 
 	logEntries := strings.Split(byteBuf.String(), "\n")
-	assert.Len(t, logEntries, 10)
+	assert.Len(t, logEntries, 11)
 }
 
 func TestBundleBytes_CircularFile(t *testing.T) {
@@ -317,7 +343,7 @@ components:
 	assert.Len(t, bytes, 458)
 
 	logEntries := strings.Split(byteBuf.String(), "\n")
-	assert.Len(t, logEntries, 13)
+	assert.Len(t, logEntries, 14)
 }
 
 func TestBundleBytes_Bad(t *testing.T) {
@@ -0,0 +1,24 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"github.com/pb33f/libopenapi/utils"
+)
+
+func (d *document) Clone() Document {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	c := &document{version: d.version, parseDuration: d.parseDuration}
+	if d.config != nil {
+		config := *d.config
+		c.config = &config
+	}
+	if d.info != nil {
+		info := *d.info
+		info.RootNode = utils.CloneNode(d.info.RootNode)
+		c.info = &info
+	}
+	return c
+}
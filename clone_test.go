@@ -0,0 +1,94 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDocument_Clone(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocumentWithConfiguration(burgerShop, &datamodel.DocumentConfiguration{AllowFileReferences: true})
+	require.NoError(t, err)
+	original, _ := yaml.Marshal(doc.GetSpecInfo().RootNode)
+
+	clone := doc.Clone()
+	assert.Equal(t, doc.GetVersion(), clone.GetVersion())
+	assert.Equal(t, doc.GetConfiguration(), clone.GetConfiguration())
+	assert.NotSame(t, doc.GetConfiguration(), clone.GetConfiguration())
+	assert.NotSame(t, doc.GetSpecInfo().RootNode, clone.GetSpecInfo().RootNode)
+	cloned, _ := yaml.Marshal(clone.GetSpecInfo().RootNode)
+	assert.Equal(t, string(original), string(cloned))
+
+	// resolving the references of the clone changes its node tree, but not the tree of the document.
+	m, errs := clone.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Empty(t, m.Index.GetResolver().Resolve())
+	resolved, _ := yaml.Marshal(clone.GetSpecInfo().RootNode)
+	assert.NotEqual(t, string(original), string(resolved))
+	after, _ := yaml.Marshal(doc.GetSpecInfo().RootNode)
+	assert.Equal(t, string(original), string(after))
+
+	// the document builds its own model and index.
+	docModel, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.NotSame(t, m.Index, docModel.Index)
+	assert.NotSame(t, m, docModel)
+
+	// a clone of a document with a model does not share it.
+	clone = doc.Clone()
+	cloneModel, _ := clone.BuildV3Model()
+	assert.NotSame(t, docModel.Index, cloneModel.Index)
+}
+
+func TestDocument_Clone_Anchors(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/yaml-anchor.yaml")
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+
+	clone := doc.Clone()
+	original, _ := yaml.Marshal(doc.GetSpecInfo().RootNode)
+	cloned, _ := yaml.Marshal(clone.GetSpecInfo().RootNode)
+	assert.Equal(t, string(original), string(cloned))
+
+	// every alias of the clone points into the clone.
+	nodes := make(map[*yaml.Node]bool)
+	var collect func(n *yaml.Node)
+	collect = func(n *yaml.Node) {
+		nodes[n] = true
+		for _, c := range n.Content {
+			collect(c)
+		}
+	}
+	collect(clone.GetSpecInfo().RootNode)
+	aliases := 0
+	var check func(n *yaml.Node)
+	check = func(n *yaml.Node) {
+		if n.Kind == yaml.AliasNode {
+			aliases++
+			assert.True(t, nodes[n.Alias])
+		}
+		for _, c := range n.Content {
+			check(c)
+		}
+	}
+	check(clone.GetSpecInfo().RootNode)
+	assert.Greater(t, aliases, 0)
+
+	m, errs := clone.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, []string{"Examples"}, m.Model.Paths.PathItems.GetOrZero("/system/examples/{id}").Get.Tags)
+}
+
+func TestDocument_Clone_Empty(t *testing.T) {
+	clone := new(document).Clone()
+	assert.Nil(t, clone.GetSpecInfo())
+	assert.Nil(t, clone.GetConfiguration())
+}
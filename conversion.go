@@ -0,0 +1,92 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/convert"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/json"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is a version of the specification a Document can be converted to, see Document.ConvertTo.
+type Version string
+
+const (
+	// Version2 is Swagger (OpenAPI 2.0). Documents cannot be converted into Swagger yet.
+	Version2 Version = "2.0"
+
+	// Version30 is OpenAPI 3.0, Swagger documents are converted into OpenAPI 3.0.3
+	Version30 Version = "3.0"
+
+	// Version31 is OpenAPI 3.1, Swagger and OpenAPI 3.0 documents are converted into OpenAPI 3.1.0
+	Version31 Version = "3.1"
+)
+
+func (d *document) ConvertTo(version Version) (Document, *convert.Report, error) {
+	if d.info == nil || d.info.RootNode == nil {
+		return nil, nil, datamodel.NewError(ErrNoSpecification, nil, "unable to convert document, no specification has been loaded")
+	}
+	var source Version
+	switch d.info.SpecFormat {
+	case datamodel.OAS2:
+		source = Version2
+	case datamodel.OAS3:
+		source = Version30
+	case datamodel.OAS31:
+		source = Version31
+	default:
+		return nil, nil, fmt.Errorf("unable to convert document, '%s' documents cannot be converted",
+			d.info.SpecType)
+	}
+	if source == version {
+		return d, &convert.Report{SourceVersion: d.version, TargetVersion: d.version}, nil
+	}
+
+	var converted *yaml.Node
+	var report *convert.Report
+	var err error
+	switch {
+	case source == Version2 && (version == Version30 || version == Version31):
+		converted, report, err = convert.SwaggerToOpenAPI3(d.info.RootNode)
+		if err == nil && version == Version31 {
+			var report31 *convert.Report
+			converted, report31, err = convert.OpenAPI3ToOpenAPI31(converted)
+			if err == nil {
+				report.TargetVersion = report31.TargetVersion
+				report.Issues = append(report.Issues, report31.Issues...)
+			}
+		}
+	case source == Version30 && version == Version31:
+		converted, report, err = convert.OpenAPI3ToOpenAPI31(d.info.RootNode)
+	default:
+		return nil, nil, fmt.Errorf("unable to convert document, converting from %s to %s is not supported",
+			source, version)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, err := d.renderNode(converted)
+	if err != nil {
+		return nil, report, fmt.Errorf("unable to render converted document: %w", err)
+	}
+	newDoc, err := NewDocumentWithConfiguration(b, d.GetConfiguration())
+	if err != nil {
+		return nil, report, fmt.Errorf("unable to read converted document: %w", err)
+	}
+	return newDoc, report, nil
+}
+
+// renderNode renders a node in the format (YAML or JSON) and indentation of the specification.
+func (d *document) renderNode(node *yaml.Node) ([]byte, error) {
+	indent := max(d.info.OriginalIndentation, 2)
+	if d.info.SpecFileType == datamodel.JSONFileType {
+		return json.YAMLNodeToJSON(node, strings.Repeat(" ", indent))
+	}
+	return d.GetConfiguration().GetYAMLEngine().Marshal(node, indent)
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/convert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_ConvertTo_OpenAPI31(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: pizza
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pizza:
+      type: string
+      nullable: true
+`
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+
+	converted, report, err := doc.ConvertTo(Version31)
+	require.NoError(t, err)
+	assert.False(t, report.HasIssues())
+	assert.Equal(t, "3.0.3", report.SourceVersion)
+	assert.Equal(t, "3.1.0", report.TargetVersion)
+	assert.Equal(t, "3.1.0", converted.GetVersion())
+
+	m, errs := converted.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, []string{"string", "null"}, m.Model.Components.Schemas.GetOrZero("Pizza").Schema().Type)
+}
+
+func TestDocument_ConvertTo_Swagger(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	converted, report, err := doc.ConvertTo(Version31)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0", report.SourceVersion)
+	assert.Equal(t, "3.1.0", report.TargetVersion)
+	assert.Equal(t, "3.1.0", converted.GetVersion())
+
+	// the converted specification is written as JSON, like the original.
+	rendered := string(*converted.GetSpecInfo().SpecBytes)
+	assert.True(t, strings.HasPrefix(rendered, "{\n  \"openapi\": \"3.1.0\""))
+
+	m, errs := converted.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, 14, m.Model.Paths.PathItems.Len())
+
+	converted, report, err = doc.ConvertTo(Version30)
+	require.NoError(t, err)
+	assert.Equal(t, convert.OpenAPI3Version, report.TargetVersion)
+	assert.Equal(t, convert.OpenAPI3Version, converted.GetVersion())
+}
+
+func TestDocument_ConvertTo_Unsupported(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(burgerShop)
+	require.NoError(t, err)
+
+	_, _, err = doc.ConvertTo(Version30)
+	assert.EqualError(t, err, "unable to convert document, converting from 3.1 to 3.0 is not supported")
+
+	_, _, err = doc.ConvertTo(Version2)
+	assert.Error(t, err)
+
+	same, report, err := doc.ConvertTo(Version31)
+	require.NoError(t, err)
+	assert.Same(t, doc, same)
+	assert.False(t, report.HasIssues())
+
+	_, _, err = (&document{}).ConvertTo(Version31)
+	assert.Error(t, err)
+}
@@ -0,0 +1,203 @@
+// Copyright 2023-2024 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPI31Version is the version of OpenAPI that OpenAPI 3.0 documents are converted into.
+const OpenAPI31Version = "3.1.0"
+
+// OpenAPI3ToOpenAPI31 converts the root node of an OpenAPI 3.0 document into the root node of an equivalent
+// OpenAPI 3.1 document. The supplied node is not modified.
+//
+// The following changes are made to every schema:
+//   - nullable becomes a 'null' type (and a null enum value, if the schema has an enum).
+//   - boolean exclusiveMinimum and exclusiveMaximum become numbers, replacing minimum and maximum.
+//   - example becomes examples.
+//   - the binary and base64 string formats become contentMediaType and contentEncoding.
+//
+// Anything that cannot be represented in OpenAPI 3.1 is recorded on the returned Report.
+func OpenAPI3ToOpenAPI31(root *yaml.Node) (*yaml.Node, *Report, error) {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	root = utils.NodeAlias(root)
+	if root == nil || !utils.IsNodeMap(root) {
+		return nil, nil, errors.New("unable to convert document, the root node is not a map")
+	}
+	version := mapGet(root, "openapi")
+	if version == nil || !strings.HasPrefix(version.Value, "3.0") {
+		return nil, nil, errors.New("unable to convert document, it is not an OpenAPI 3.0 document")
+	}
+
+	c := &openAPI31Converter{report: &Report{SourceVersion: version.Value, TargetVersion: OpenAPI31Version}}
+	out := cloneNode(root)
+	for i := 0; i < len(out.Content)-1; i += 2 {
+		k, v := out.Content[i], out.Content[i+1]
+		switch k.Value {
+		case "openapi":
+			v.Value = OpenAPI31Version
+			v.Style = 0
+		case "components":
+			for j := 0; j < len(v.Content)-1; j += 2 {
+				if v.Content[j].Value == "schemas" {
+					for _, s := range mapValues(v.Content[j+1]) {
+						c.schema(s)
+					}
+					continue
+				}
+				c.walk(v.Content[j+1])
+			}
+		default:
+			c.walk(v)
+		}
+	}
+	return out, c.report, nil
+}
+
+type openAPI31Converter struct {
+	report *Report
+}
+
+// walk looks through any object of the document that is not a schema, converting every schema found.
+func (c *openAPI31Converter) walk(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i < len(n.Content)-1; i += 2 {
+			k := n.Content[i].Value
+			switch {
+			case k == "schema":
+				c.schema(n.Content[i+1])
+			case k == "example", k == "examples", strings.HasPrefix(k, "x-"):
+				// examples and extensions are values, not objects of the document.
+			default:
+				c.walk(n.Content[i+1])
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			c.walk(item)
+		}
+	}
+}
+
+// schema converts an OpenAPI 3.0 schema into an OpenAPI 3.1 (JSON Schema 2020-12) schema, in place.
+func (c *openAPI31Converter) schema(s *yaml.Node) {
+	if !utils.IsNodeMap(s) {
+		return
+	}
+	if n := mapGet(s, "nullable"); n != nil {
+		nullable := mapKey(s, "nullable")
+		mapDelete(s, "nullable")
+		if n.Value == "true" {
+			c.nullable(s, nullable)
+		}
+	}
+	c.exclusive(s, "exclusiveMinimum", "minimum")
+	c.exclusive(s, "exclusiveMaximum", "maximum")
+
+	for i := 0; i < len(s.Content)-1; i += 2 {
+		k, v := s.Content[i], s.Content[i+1]
+		switch k.Value {
+		case "example":
+			if mapGet(s, "examples") != nil {
+				c.report.addIssue(k, "the example of a schema with examples has been dropped")
+				s.Content = slices.Delete(s.Content, i, i+2)
+				i -= 2
+				continue
+			}
+			k.Value = "examples"
+			examples := utils.CreateEmptySequenceNode()
+			examples.Content = []*yaml.Node{v}
+			s.Content[i+1] = examples
+		case "format":
+			switch v.Value {
+			case "binary":
+				k.Value, v.Value = "contentMediaType", "application/octet-stream"
+			case "base64":
+				k.Value = "contentEncoding"
+			}
+		case "properties":
+			for _, p := range mapValues(v) {
+				c.schema(p)
+			}
+		case "items", "additionalProperties", "not":
+			c.schema(v)
+		case "allOf", "anyOf", "oneOf":
+			for _, item := range v.Content {
+				c.schema(item)
+			}
+		}
+	}
+}
+
+// nullable adds 'null' to the types (and enum) of a nullable schema.
+func (c *openAPI31Converter) nullable(s, key *yaml.Node) {
+	t := mapGet(s, "type")
+	if t == nil || t.Kind != yaml.ScalarNode {
+		c.report.addIssue(key, "nullable has no effect without a type and has been dropped")
+		return
+	}
+	types := utils.CreateEmptySequenceNode()
+	types.Content = []*yaml.Node{utils.CreateStringNode(t.Value), utils.CreateStringNode("null")}
+	types.Line, types.Column = t.Line, t.Column
+	*t = *types
+	if enum := mapGet(s, "enum"); enum != nil && enum.Kind == yaml.SequenceNode {
+		for _, e := range enum.Content {
+			if e.Tag == "!!null" {
+				return
+			}
+		}
+		enum.Content = append(enum.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"})
+	}
+}
+
+// exclusive converts a boolean exclusiveMinimum (or exclusiveMaximum) into the number of its bound.
+func (c *openAPI31Converter) exclusive(s *yaml.Node, exclusiveKey, boundKey string) {
+	exclusive := mapGet(s, exclusiveKey)
+	if exclusive == nil || exclusive.Tag != "!!bool" {
+		return
+	}
+	bound := mapGet(s, boundKey)
+	switch {
+	case exclusive.Value != "true":
+		mapDelete(s, exclusiveKey)
+	case bound == nil:
+		c.report.addIssue(mapKey(s, exclusiveKey), "%s has no effect without %s and has been dropped",
+			exclusiveKey, boundKey)
+		mapDelete(s, exclusiveKey)
+	default:
+		*exclusive = *bound
+		mapDelete(s, boundKey)
+	}
+}
+
+// mapValues returns the values of a map.
+func mapValues(m *yaml.Node) []*yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	values := make([]*yaml.Node, 0, len(m.Content)/2)
+	for i := 1; i < len(m.Content); i += 2 {
+		values = append(values, m.Content[i])
+	}
+	return values
+}
+
+// mapKey returns the key node of a map entry.
+func mapKey(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(m.Content)-1; i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2023-2024 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func convertOpenAPI3(t *testing.T, spec string) (*yaml.Node, *Report, string) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	converted, report, err := OpenAPI3ToOpenAPI31(&root)
+	require.NoError(t, err)
+	b, err := yaml.Marshal(converted)
+	require.NoError(t, err)
+	return converted, report, string(b)
+}
+
+func TestOpenAPI3ToOpenAPI31(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                photo:
+                  type: string
+                  format: binary
+                dough:
+                  type: string
+                  format: base64
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+              example:
+                nullable: true
+components:
+  schemas:
+    Pizza:
+      type: object
+      x-nullable-thing:
+        nullable: true
+      properties:
+        size:
+          type: string
+          nullable: true
+          enum: [small, large]
+        price:
+          type: number
+          minimum: 1
+          exclusiveMinimum: true
+          maximum: 100
+          exclusiveMaximum: false
+          example: 9.99
+        toppings:
+          type: array
+          items:
+            type: string
+            nullable: false`
+
+	_, report, out := convertOpenAPI3(t, spec)
+	assert.False(t, report.HasIssues())
+	assert.Equal(t, "3.0.3", report.SourceVersion)
+	assert.Equal(t, OpenAPI31Version, report.TargetVersion)
+
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: pizza
+    version: 1.0.0
+paths:
+    /pizza:
+        post:
+            requestBody:
+                content:
+                    multipart/form-data:
+                        schema:
+                            type: object
+                            properties:
+                                photo:
+                                    type: string
+                                    contentMediaType: application/octet-stream
+                                dough:
+                                    type: string
+                                    contentEncoding: base64
+            responses:
+                "200":
+                    description: OK
+                    content:
+                        application/json:
+                            schema:
+                                $ref: '#/components/schemas/Pizza'
+                            example:
+                                nullable: true
+components:
+    schemas:
+        Pizza:
+            type: object
+            x-nullable-thing:
+                nullable: true
+            properties:
+                size:
+                    type:
+                        - string
+                        - "null"
+                    enum: [small, large, null]
+                price:
+                    type: number
+                    exclusiveMinimum: 1
+                    maximum: 100
+                    examples:
+                        - 9.99
+                toppings:
+                    type: array
+                    items:
+                        type: string
+`, out)
+}
+
+func TestOpenAPI3ToOpenAPI31_SourceUntouched(t *testing.T) {
+	spec := `openapi: 3.0.1
+components:
+  schemas:
+    Pet:
+      type: string
+      nullable: true`
+
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	before, _ := yaml.Marshal(&root)
+	_, _, err := OpenAPI3ToOpenAPI31(&root)
+	require.NoError(t, err)
+	after, _ := yaml.Marshal(&root)
+	assert.Equal(t, string(before), string(after))
+}
+
+func TestOpenAPI3ToOpenAPI31_Issues(t *testing.T) {
+	spec := `openapi: 3.0.3
+components:
+  schemas:
+    Pet:
+      nullable: true
+      allOf:
+        - type: object
+    Age:
+      type: integer
+      exclusiveMaximum: true
+    Name:
+      type: string
+      example: fluffy
+      examples: [fluffy]`
+
+	_, report, out := convertOpenAPI3(t, spec)
+	require.Len(t, report.Issues, 3)
+	assert.Equal(t, "nullable has no effect without a type and has been dropped, line 5, column 7",
+		report.Issues[0].String())
+	assert.Equal(t, "exclusiveMaximum has no effect without maximum and has been dropped, line 10, column 7",
+		report.Issues[1].String())
+	assert.Equal(t, "the example of a schema with examples has been dropped, line 13, column 7",
+		report.Issues[2].String())
+	assert.NotContains(t, out, "nullable")
+	assert.NotContains(t, out, "exclusiveMaximum")
+	assert.NotContains(t, out, "example:")
+}
+
+func TestOpenAPI3ToOpenAPI31_NotOpenAPI3(t *testing.T) {
+	for _, spec := range []string{`openapi: 3.1.0`, `swagger: "2.0"`, `- pizza`} {
+		var root yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+		_, _, err := OpenAPI3ToOpenAPI31(&root)
+		assert.Error(t, err, spec)
+	}
+	_, _, err := OpenAPI3ToOpenAPI31(nil)
+	assert.Error(t, err)
+}
+
+func TestOpenAPI3ToOpenAPI31_Petstore(t *testing.T) {
+	spec, err := os.ReadFile("../test_specs/petstorev3.json")
+	require.NoError(t, err)
+
+	converted, report, _ := convertOpenAPI3(t, string(spec))
+	assert.False(t, report.HasIssues())
+	assert.Equal(t, OpenAPI31Version, mapGet(converted, "openapi").Value)
+}
@@ -0,0 +1,50 @@
+// Copyright 2023-2024 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package convert migrates specifications from one version of OpenAPI to another.
+//
+// The conversion operates on the document tree (*yaml.Node), not on the model. The converted tree can then be
+// used to build a model for the target version. Not everything can be carried across versions without loss,
+// so every conversion returns a Report that explains anything that was changed in a lossy way, or dropped.
+package convert
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Issue describes something that could not be converted faithfully. The line and column refer to the
+// node in the source document.
+type Issue struct {
+	Message string     `json:"message" yaml:"message"`
+	Line    int        `json:"line" yaml:"line"`
+	Column  int        `json:"column" yaml:"column"`
+	Node    *yaml.Node `json:"-" yaml:"-"`
+}
+
+// String returns the issue message along with the line and column the issue relates to.
+func (i *Issue) String() string {
+	return fmt.Sprintf("%s, line %d, column %d", i.Message, i.Line, i.Column)
+}
+
+// Report is the result of a conversion. It records the source and target versions, along with every issue
+// found while converting.
+type Report struct {
+	SourceVersion string   `json:"sourceVersion" yaml:"sourceVersion"`
+	TargetVersion string   `json:"targetVersion" yaml:"targetVersion"`
+	Issues        []*Issue `json:"issues,omitempty" yaml:"issues,omitempty"`
+}
+
+// HasIssues returns true if anything was changed in a lossy way or dropped during the conversion.
+func (r *Report) HasIssues() bool {
+	return r != nil && len(r.Issues) > 0
+}
+
+func (r *Report) addIssue(node *yaml.Node, format string, args ...any) {
+	i := &Issue{Message: fmt.Sprintf(format, args...), Node: node}
+	if node != nil {
+		i.Line, i.Column = node.Line, node.Column
+	}
+	r.Issues = append(r.Issues, i)
+}
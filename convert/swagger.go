@@ -0,0 +1,914 @@
+// Copyright 2023-2024 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPI3Version is the version of OpenAPI that Swagger documents are converted into.
+const OpenAPI3Version = "3.0.3"
+
+const (
+	swaggerVersion  = "2.0"
+	defaultMimeType = "application/json"
+	multipartForm   = "multipart/form-data"
+	urlEncodedForm  = "application/x-www-form-urlencoded"
+)
+
+var swaggerMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// schemaKeys are the keys of a Swagger parameter, header or items object that describe the value, and are moved
+// into a schema in OpenAPI 3.
+var schemaKeys = []string{
+	"type", "format", "items", "default", "maximum", "exclusiveMaximum", "minimum",
+	"exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "enum",
+	"multipleOf",
+}
+
+// SwaggerToOpenAPI3 converts the root node of a Swagger (OpenAPI 2) document into the root node of an equivalent
+// OpenAPI 3 document. The supplied node is not modified.
+//
+// The following changes are made:
+//   - host, basePath and schemes become servers.
+//   - definitions, parameters, responses and securityDefinitions move into components.
+//   - body and formData parameters become request bodies, using the effective consumes of the operation.
+//   - response schemas and examples become content, using the effective produces of the operation.
+//   - collectionFormat becomes style and explode.
+//   - all local references are re-pointed at the new locations.
+//
+// Anything that cannot be represented in OpenAPI 3 is recorded on the returned Report.
+func SwaggerToOpenAPI3(root *yaml.Node) (*yaml.Node, *Report, error) {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	root = utils.NodeAlias(root)
+	if root == nil || !utils.IsNodeMap(root) {
+		return nil, nil, errors.New("unable to convert document, the root node is not a map")
+	}
+	if v := mapGet(root, "swagger"); v == nil || v.Value != swaggerVersion {
+		return nil, nil, errors.New("unable to convert document, it is not a Swagger 2.0 document")
+	}
+
+	c := &swaggerConverter{
+		report:     &Report{SourceVersion: swaggerVersion, TargetVersion: OpenAPI3Version},
+		root:       root,
+		params:     make(map[string]*yaml.Node),
+		components: utils.CreateEmptyMapNode(),
+	}
+	c.consumes = stringValues(mapGet(root, "consumes"))
+	c.produces = stringValues(mapGet(root, "produces"))
+	if params := mapGet(root, "parameters"); utils.IsNodeMap(params) {
+		for i := 0; i < len(params.Content)-1; i += 2 {
+			c.params[params.Content[i].Value] = utils.NodeAlias(params.Content[i+1])
+		}
+	}
+
+	out := utils.CreateEmptyMapNode()
+	serversDone, componentsDone := false, false
+	for i := 0; i < len(root.Content)-1; i += 2 {
+		k, v := root.Content[i], utils.NodeAlias(root.Content[i+1])
+		switch k.Value {
+		case "swagger":
+			mapSet(out, "openapi", utils.CreateStringNode(OpenAPI3Version))
+		case "host", "basePath", "schemes":
+			if !serversDone {
+				serversDone = true
+				if servers := c.servers(mapGet(root, "schemes")); servers != nil {
+					mapSet(out, "servers", servers)
+				}
+			}
+		case "consumes", "produces":
+			// consumes and produces are applied to every request body and response that needs them.
+		case "paths":
+			mapSet(out, "paths", c.paths(v))
+		case "definitions", "parameters", "responses", "securityDefinitions":
+			if !componentsDone {
+				// reserve the position, the components are filled in once everything has been converted.
+				componentsDone = true
+				mapSet(out, "components", c.components)
+			}
+		case "info", "tags", "externalDocs", "security":
+			mapSet(out, k.Value, cloneNode(v))
+		default:
+			if strings.HasPrefix(k.Value, "x-") {
+				mapSet(out, k.Value, cloneNode(v))
+				continue
+			}
+			c.report.addIssue(k, "'%s' is not a valid Swagger 2.0 property and has been dropped", k.Value)
+		}
+	}
+	c.buildComponents()
+	if componentsDone && len(c.components.Content) == 0 {
+		mapDelete(out, "components")
+	}
+	return out, c.report, nil
+}
+
+type swaggerConverter struct {
+	report     *Report
+	root       *yaml.Node
+	consumes   []string
+	produces   []string
+	params     map[string]*yaml.Node
+	components *yaml.Node
+}
+
+// servers builds the servers of the document (or an operation) from the host, basePath and supplied schemes.
+func (c *swaggerConverter) servers(schemes *yaml.Node) *yaml.Node {
+	var host, basePath string
+	if h := mapGet(c.root, "host"); h != nil {
+		host = strings.TrimSuffix(h.Value, "/")
+	}
+	if b := mapGet(c.root, "basePath"); b != nil {
+		basePath = b.Value
+	}
+	if host == "" && basePath == "" {
+		return nil
+	}
+	if basePath != "" && !strings.HasPrefix(basePath, "/") {
+		basePath = "/" + basePath
+	}
+	servers := utils.CreateEmptySequenceNode()
+	addServer := func(url string) {
+		s := utils.CreateEmptyMapNode()
+		mapSet(s, "url", utils.CreateStringNode(url))
+		servers.Content = append(servers.Content, s)
+	}
+	if host == "" {
+		addServer(basePath)
+		return servers
+	}
+	list := stringValues(schemes)
+	if len(list) == 0 {
+		addServer("//" + host + basePath)
+		return servers
+	}
+	for _, scheme := range list {
+		addServer(fmt.Sprintf("%s://%s%s", scheme, host, basePath))
+	}
+	return servers
+}
+
+// buildComponents fills in the components of the converted document from the definitions, parameters,
+// responses and securityDefinitions of the Swagger document.
+func (c *swaggerConverter) buildComponents() {
+	if defs := mapGet(c.root, "definitions"); utils.IsNodeMap(defs) {
+		schemas := utils.CreateEmptyMapNode()
+		for i := 0; i < len(defs.Content)-1; i += 2 {
+			mapSetKey(schemas, defs.Content[i], c.schema(defs.Content[i+1]))
+		}
+		mapSet(c.components, "schemas", schemas)
+	}
+	if resps := mapGet(c.root, "responses"); utils.IsNodeMap(resps) {
+		responses := utils.CreateEmptyMapNode()
+		for i := 0; i < len(resps.Content)-1; i += 2 {
+			mapSetKey(responses, resps.Content[i], c.response(resps.Content[i+1], c.produces))
+		}
+		mapSet(c.components, "responses", responses)
+	}
+	if params := mapGet(c.root, "parameters"); utils.IsNodeMap(params) {
+		parameters, bodies := utils.CreateEmptyMapNode(), utils.CreateEmptyMapNode()
+		for i := 0; i < len(params.Content)-1; i += 2 {
+			k, p := params.Content[i], utils.NodeAlias(params.Content[i+1])
+			switch parameterIn(p) {
+			case "body":
+				mapSetKey(bodies, k, c.requestBody(p, c.consumes))
+			case "formData":
+				// form parameters cannot exist on their own in OpenAPI 3, they are inlined into the request
+				// bodies of the operations that reference them.
+			default:
+				mapSetKey(parameters, k, c.parameter(p))
+			}
+		}
+		if len(parameters.Content) > 0 {
+			mapSet(c.components, "parameters", parameters)
+		}
+		if len(bodies.Content) > 0 {
+			mapSet(c.components, "requestBodies", bodies)
+		}
+	}
+	if defs := mapGet(c.root, "securityDefinitions"); utils.IsNodeMap(defs) {
+		schemes := utils.CreateEmptyMapNode()
+		for i := 0; i < len(defs.Content)-1; i += 2 {
+			mapSetKey(schemes, defs.Content[i], c.securityScheme(defs.Content[i+1]))
+		}
+		mapSet(c.components, "securitySchemes", schemes)
+	}
+}
+
+func (c *swaggerConverter) paths(paths *yaml.Node) *yaml.Node {
+	out := utils.CreateEmptyMapNode()
+	if !utils.IsNodeMap(paths) {
+		return out
+	}
+	for i := 0; i < len(paths.Content)-1; i += 2 {
+		k, v := paths.Content[i], utils.NodeAlias(paths.Content[i+1])
+		if strings.HasPrefix(k.Value, "x-") {
+			mapSetKey(out, k, cloneNode(v))
+			continue
+		}
+		mapSetKey(out, k, c.pathItem(v))
+	}
+	return out
+}
+
+func (c *swaggerConverter) pathItem(item *yaml.Node) *yaml.Node {
+	out := utils.CreateEmptyMapNode()
+	if !utils.IsNodeMap(item) {
+		return out
+	}
+
+	// body and form parameters defined for the whole path item, are pushed down into each operation.
+	var shared []*yaml.Node
+	if params := mapGet(item, "parameters"); params != nil {
+		var plain []*yaml.Node
+		for _, p := range params.Content {
+			if in := c.resolvedParameterIn(p); in == "body" || in == "formData" {
+				shared = append(shared, p)
+				continue
+			}
+			plain = append(plain, p)
+		}
+		if len(plain) > 0 {
+			seq := utils.CreateEmptySequenceNode()
+			for _, p := range plain {
+				seq.Content = append(seq.Content, c.parameter(p))
+			}
+			mapSet(out, "parameters", seq)
+		}
+	}
+
+	for i := 0; i < len(item.Content)-1; i += 2 {
+		k, v := item.Content[i], utils.NodeAlias(item.Content[i+1])
+		switch {
+		case k.Value == "$ref":
+			mapSetKey(out, k, c.refValue(v))
+		case k.Value == "parameters":
+			// handled above.
+		case slices.Contains(swaggerMethods, k.Value):
+			mapSetKey(out, k, c.operation(v, shared))
+		case strings.HasPrefix(k.Value, "x-"):
+			mapSetKey(out, k, cloneNode(v))
+		default:
+			c.report.addIssue(k, "'%s' is not a valid path item property and has been dropped", k.Value)
+		}
+	}
+	return out
+}
+
+func (c *swaggerConverter) operation(op *yaml.Node, shared []*yaml.Node) *yaml.Node {
+	out := utils.CreateEmptyMapNode()
+	if !utils.IsNodeMap(op) {
+		return out
+	}
+	consumes, produces := c.consumes, c.produces
+	if v := mapGet(op, "consumes"); v != nil {
+		consumes = stringValues(v)
+	}
+	if v := mapGet(op, "produces"); v != nil {
+		produces = stringValues(v)
+	}
+
+	var params []*yaml.Node
+	if v := mapGet(op, "parameters"); v != nil {
+		params = v.Content
+	}
+	// an operation overrides the body of the path item, if it defines its own.
+	ownBody := slices.ContainsFunc(params, func(p *yaml.Node) bool {
+		in := c.resolvedParameterIn(p)
+		return in == "body" || in == "formData"
+	})
+	if !ownBody {
+		params = append(slices.Clone(params), shared...)
+	}
+
+	paramsDone := false
+	writeParams := func() {
+		if paramsDone {
+			return
+		}
+		paramsDone = true
+		seq := utils.CreateEmptySequenceNode()
+		var body *yaml.Node
+		var form []*yaml.Node
+		for _, p := range params {
+			switch c.resolvedParameterIn(p) {
+			case "body":
+				if body != nil {
+					c.report.addIssue(p, "an operation can only have a single body parameter, extra body dropped")
+					continue
+				}
+				if mapGet(p, "$ref") != nil {
+					body = c.refMap(utils.NodeAlias(p))
+					continue
+				}
+				body = c.requestBody(p, consumes)
+			case "formData":
+				form = append(form, c.resolveParameter(p))
+			default:
+				seq.Content = append(seq.Content, c.parameter(p))
+			}
+		}
+		if len(seq.Content) > 0 {
+			mapSet(out, "parameters", seq)
+		}
+		if body != nil && len(form) > 0 {
+			c.report.addIssue(op, "an operation cannot have both body and formData parameters, formData dropped")
+			form = nil
+		}
+		if len(form) > 0 {
+			body = c.formRequestBody(form, consumes)
+		}
+		if body != nil {
+			mapSet(out, "requestBody", body)
+		}
+	}
+
+	for i := 0; i < len(op.Content)-1; i += 2 {
+		k, v := op.Content[i], utils.NodeAlias(op.Content[i+1])
+		switch k.Value {
+		case "consumes", "produces":
+			// applied to the request body and responses.
+		case "parameters":
+			writeParams()
+		case "responses":
+			writeParams()
+			mapSetKey(out, k, c.responses(v, produces))
+		case "schemes":
+			if servers := c.servers(v); servers != nil {
+				mapSet(out, "servers", servers)
+			}
+		case "tags", "summary", "description", "externalDocs", "operationId", "deprecated", "security":
+			mapSetKey(out, k, cloneNode(v))
+		default:
+			if strings.HasPrefix(k.Value, "x-") {
+				mapSetKey(out, k, cloneNode(v))
+				continue
+			}
+			c.report.addIssue(k, "'%s' is not a valid operation property and has been dropped", k.Value)
+		}
+	}
+	writeParams()
+	return out
+}
+
+// resolveParameter returns the global parameter a parameter references, or the parameter itself.
+func (c *swaggerConverter) resolveParameter(p *yaml.Node) *yaml.Node {
+	p = utils.NodeAlias(p)
+	if ref := mapGet(p, "$ref"); ref != nil {
+		if name, ok := strings.CutPrefix(ref.Value, "#/parameters/"); ok {
+			if resolved := c.params[unescapePointer(name)]; resolved != nil {
+				return resolved
+			}
+		}
+	}
+	return p
+}
+
+func (c *swaggerConverter) resolvedParameterIn(p *yaml.Node) string {
+	return parameterIn(c.resolveParameter(p))
+}
+
+// parameter converts a query, header or path parameter.
+func (c *swaggerConverter) parameter(p *yaml.Node) *yaml.Node {
+	p = utils.NodeAlias(p)
+	if ref := mapGet(p, "$ref"); ref != nil {
+		return c.refMap(p)
+	}
+	out := utils.CreateEmptyMapNode()
+	if !utils.IsNodeMap(p) {
+		return out
+	}
+	in := parameterIn(p)
+	schemaDone := false
+	for i := 0; i < len(p.Content)-1; i += 2 {
+		k, v := p.Content[i], utils.NodeAlias(p.Content[i+1])
+		switch {
+		case k.Value == "name" || k.Value == "in" || k.Value == "description" || k.Value == "required" ||
+			k.Value == "allowEmptyValue" || strings.HasPrefix(k.Value, "x-"):
+			mapSetKey(out, k, cloneNode(v))
+		case k.Value == "collectionFormat":
+			c.collectionFormat(out, v, in)
+		case slices.Contains(schemaKeys, k.Value):
+			if !schemaDone {
+				schemaDone = true
+				mapSet(out, "schema", c.itemsSchema(p))
+			}
+		default:
+			c.report.addIssue(k, "'%s' is not a valid parameter property and has been dropped", k.Value)
+		}
+	}
+	return out
+}
+
+// collectionFormat converts the collectionFormat of a parameter into the matching style and explode values.
+func (c *swaggerConverter) collectionFormat(out, format *yaml.Node, in string) {
+	switch format.Value {
+	case "csv":
+		if in == "query" || in == "cookie" {
+			mapSet(out, "style", utils.CreateStringNode("form"))
+			mapSet(out, "explode", utils.CreateBoolNode("false"))
+		}
+	case "multi":
+		mapSet(out, "style", utils.CreateStringNode("form"))
+		mapSet(out, "explode", utils.CreateBoolNode("true"))
+	case "ssv":
+		mapSet(out, "style", utils.CreateStringNode("spaceDelimited"))
+	case "pipes":
+		mapSet(out, "style", utils.CreateStringNode("pipeDelimited"))
+	default:
+		c.report.addIssue(format, "collectionFormat '%s' cannot be represented in OpenAPI 3 and has been dropped",
+			format.Value)
+	}
+}
+
+// itemsSchema builds a schema from the value keys of a parameter, header or items object.
+func (c *swaggerConverter) itemsSchema(n *yaml.Node) *yaml.Node {
+	out := utils.CreateEmptyMapNode()
+	n = utils.NodeAlias(n)
+	if !utils.IsNodeMap(n) {
+		return out
+	}
+	for i := 0; i < len(n.Content)-1; i += 2 {
+		k, v := n.Content[i], utils.NodeAlias(n.Content[i+1])
+		switch {
+		case k.Value == "type" && v.Value == "file":
+			mapSetKey(out, k, utils.CreateStringNode("string"))
+			mapSet(out, "format", utils.CreateStringNode("binary"))
+		case k.Value == "format" && mapGet(out, "format") != nil:
+			// already set for a file.
+		case k.Value == "items":
+			if ref := mapGet(v, "$ref"); ref != nil {
+				mapSetKey(out, k, c.refMap(v))
+				continue
+			}
+			mapSetKey(out, k, c.itemsSchema(v))
+			if f := mapGet(v, "collectionFormat"); f != nil && f.Value != "csv" {
+				c.report.addIssue(f, "nested collectionFormat '%s' cannot be represented in OpenAPI 3 and "+
+					"has been dropped", f.Value)
+			}
+		case slices.Contains(schemaKeys, k.Value) || strings.HasPrefix(k.Value, "x-"):
+			mapSetKey(out, k, cloneNode(v))
+		}
+	}
+	return out
+}
+
+// requestBody converts a body parameter into a request body, with content for every consumed media type.
+func (c *swaggerConverter) requestBody(p *yaml.Node, consumes []string) *yaml.Node {
+	out := utils.CreateEmptyMapNode()
+	if d := mapGet(p, "description"); d != nil {
+		mapSet(out, "description", cloneNode(d))
+	}
+	content := utils.CreateEmptyMapNode()
+	schema := mapGet(p, "schema")
+	for _, mt := range mediaTypes(consumes) {
+		media := utils.CreateEmptyMapNode()
+		if schema != nil {
+			mapSet(media, "schema", c.schema(schema))
+		}
+		mapSet(content, mt, media)
+	}
+	mapSet(out, "content", content)
+	if r := mapGet(p, "required"); r != nil {
+		mapSet(out, "required", cloneNode(r))
+	}
+	if name := mapGet(p, "name"); name != nil {
+		mapSet(out, "x-codegen-request-body-name", cloneNode(name))
+	}
+	for i := 0; i < len(p.Content)-1; i += 2 {
+		if strings.HasPrefix(p.Content[i].Value, "x-") {
+			mapSetKey(out, p.Content[i], cloneNode(p.Content[i+1]))
+		}
+	}
+	return out
+}
+
+// formRequestBody collects formData parameters into a single object schema, used by a request body.
+func (c *swaggerConverter) formRequestBody(form []*yaml.Node, consumes []string) *yaml.Node {
+	schema := utils.CreateEmptyMapNode()
+	mapSet(schema, "type", utils.CreateStringNode("object"))
+	props := utils.CreateEmptyMapNode()
+	required := utils.CreateEmptySequenceNode()
+	hasFile := false
+	for _, p := range form {
+		name := mapGet(p, "name")
+		if name == nil {
+			c.report.addIssue(p, "formData parameter has no name and has been dropped")
+			continue
+		}
+		if t := mapGet(p, "type"); t != nil && t.Value == "file" {
+			hasFile = true
+		}
+		prop := c.itemsSchema(p)
+		if d := mapGet(p, "description"); d != nil {
+			mapSet(prop, "description", cloneNode(d))
+		}
+		mapSet(props, name.Value, prop)
+		if r := mapGet(p, "required"); r != nil && r.Value == "true" {
+			required.Content = append(required.Content, utils.CreateStringNode(name.Value))
+		}
+	}
+	mapSet(schema, "properties", props)
+	if len(required.Content) > 0 {
+		mapSet(schema, "required", required)
+	}
+
+	var forms []string
+	for _, mt := range consumes {
+		if mt == multipartForm || mt == urlEncodedForm {
+			forms = append(forms, mt)
+		}
+	}
+	if len(forms) == 0 {
+		if hasFile {
+			forms = []string{multipartForm}
+		} else {
+			forms = []string{urlEncodedForm}
+		}
+	}
+
+	out := utils.CreateEmptyMapNode()
+	content := utils.CreateEmptyMapNode()
+	for _, mt := range forms {
+		media := utils.CreateEmptyMapNode()
+		mapSet(media, "schema", cloneNode(schema))
+		mapSet(content, mt, media)
+	}
+	mapSet(out, "content", content)
+	if len(required.Content) > 0 {
+		mapSet(out, "required", utils.CreateBoolNode("true"))
+	}
+	return out
+}
+
+func (c *swaggerConverter) responses(resps *yaml.Node, produces []string) *yaml.Node {
+	out := utils.CreateEmptyMapNode()
+	if !utils.IsNodeMap(resps) {
+		return out
+	}
+	for i := 0; i < len(resps.Content)-1; i += 2 {
+		k, v := resps.Content[i], resps.Content[i+1]
+		if strings.HasPrefix(k.Value, "x-") {
+			mapSetKey(out, k, cloneNode(v))
+			continue
+		}
+		mapSetKey(out, k, c.response(v, produces))
+	}
+	return out
+}
+
+// response converts a response, moving the schema and examples into content for every produced media type.
+func (c *swaggerConverter) response(r *yaml.Node, produces []string) *yaml.Node {
+	r = utils.NodeAlias(r)
+	if ref := mapGet(r, "$ref"); ref != nil {
+		return c.refMap(r)
+	}
+	out := utils.CreateEmptyMapNode()
+	if !utils.IsNodeMap(r) {
+		return out
+	}
+	contentDone := false
+	writeContent := func() {
+		if contentDone {
+			return
+		}
+		contentDone = true
+		schema, examples := mapGet(r, "schema"), mapGet(r, "examples")
+		if schema == nil && examples == nil {
+			return
+		}
+		mts := mediaTypes(produces)
+		if examples != nil {
+			for i := 0; i < len(examples.Content)-1; i += 2 {
+				if !slices.Contains(mts, examples.Content[i].Value) {
+					mts = append(mts, examples.Content[i].Value)
+				}
+			}
+		}
+		content := utils.CreateEmptyMapNode()
+		for _, mt := range mts {
+			media := utils.CreateEmptyMapNode()
+			if schema != nil {
+				mapSet(media, "schema", c.schema(schema))
+			}
+			if ex := mapGet(examples, mt); ex != nil {
+				mapSet(media, "example", cloneNode(ex))
+			}
+			mapSet(content, mt, media)
+		}
+		mapSet(out, "content", content)
+	}
+	for i := 0; i < len(r.Content)-1; i += 2 {
+		k, v := r.Content[i], utils.NodeAlias(r.Content[i+1])
+		switch {
+		case k.Value == "description" || strings.HasPrefix(k.Value, "x-"):
+			mapSetKey(out, k, cloneNode(v))
+		case k.Value == "headers":
+			headers := utils.CreateEmptyMapNode()
+			for j := 0; j < len(v.Content)-1; j += 2 {
+				mapSetKey(headers, v.Content[j], c.header(v.Content[j+1]))
+			}
+			mapSetKey(out, k, headers)
+		case k.Value == "schema" || k.Value == "examples":
+			writeContent()
+		default:
+			c.report.addIssue(k, "'%s' is not a valid response property and has been dropped", k.Value)
+		}
+	}
+	if mapGet(out, "description") == nil {
+		c.report.addIssue(r, "response has no description, an empty description has been added")
+		mapSet(out, "description", utils.CreateStringNode(""))
+	}
+	return out
+}
+
+func (c *swaggerConverter) header(h *yaml.Node) *yaml.Node {
+	h = utils.NodeAlias(h)
+	out := utils.CreateEmptyMapNode()
+	if !utils.IsNodeMap(h) {
+		return out
+	}
+	schemaDone := false
+	for i := 0; i < len(h.Content)-1; i += 2 {
+		k, v := h.Content[i], utils.NodeAlias(h.Content[i+1])
+		switch {
+		case k.Value == "description" || strings.HasPrefix(k.Value, "x-"):
+			mapSetKey(out, k, cloneNode(v))
+		case k.Value == "collectionFormat":
+			if v.Value != "csv" {
+				c.report.addIssue(v, "header collectionFormat '%s' cannot be represented in OpenAPI 3 and "+
+					"has been dropped", v.Value)
+			}
+		case slices.Contains(schemaKeys, k.Value):
+			if !schemaDone {
+				schemaDone = true
+				mapSet(out, "schema", c.itemsSchema(h))
+			}
+		default:
+			c.report.addIssue(k, "'%s' is not a valid header property and has been dropped", k.Value)
+		}
+	}
+	return out
+}
+
+var oauthFlows = map[string]string{
+	"implicit":    "implicit",
+	"password":    "password",
+	"application": "clientCredentials",
+	"accessCode":  "authorizationCode",
+}
+
+func (c *swaggerConverter) securityScheme(s *yaml.Node) *yaml.Node {
+	s = utils.NodeAlias(s)
+	out := utils.CreateEmptyMapNode()
+	if !utils.IsNodeMap(s) {
+		return out
+	}
+	t := mapGet(s, "type")
+	if t == nil {
+		c.report.addIssue(s, "security scheme has no type")
+		return cloneNode(s)
+	}
+	switch t.Value {
+	case "basic":
+		mapSet(out, "type", utils.CreateStringNode("http"))
+		mapSet(out, "scheme", utils.CreateStringNode("basic"))
+	case "apiKey":
+		mapSet(out, "type", cloneNode(t))
+		if n := mapGet(s, "name"); n != nil {
+			mapSet(out, "name", cloneNode(n))
+		}
+		if in := mapGet(s, "in"); in != nil {
+			mapSet(out, "in", cloneNode(in))
+		}
+	case "oauth2":
+		mapSet(out, "type", cloneNode(t))
+		flowName := ""
+		if f := mapGet(s, "flow"); f != nil {
+			flowName = oauthFlows[f.Value]
+		}
+		if flowName == "" {
+			c.report.addIssue(s, "oauth2 security scheme has an unknown or missing flow, it has been dropped")
+			break
+		}
+		flow := utils.CreateEmptyMapNode()
+		for _, key := range []string{"authorizationUrl", "tokenUrl"} {
+			if v := mapGet(s, key); v != nil {
+				mapSet(flow, key, cloneNode(v))
+			}
+		}
+		if scopes := mapGet(s, "scopes"); scopes != nil {
+			mapSet(flow, "scopes", cloneNode(scopes))
+		} else {
+			mapSet(flow, "scopes", utils.CreateEmptyMapNode())
+		}
+		flows := utils.CreateEmptyMapNode()
+		mapSet(flows, flowName, flow)
+		mapSet(out, "flows", flows)
+	default:
+		c.report.addIssue(t, "security scheme type '%s' is unknown and has been copied as is", t.Value)
+		mapSet(out, "type", cloneNode(t))
+	}
+	for i := 0; i < len(s.Content)-1; i += 2 {
+		k := s.Content[i].Value
+		if k == "description" || strings.HasPrefix(k, "x-") {
+			mapSetKey(out, s.Content[i], cloneNode(s.Content[i+1]))
+		}
+	}
+	return out
+}
+
+// schema converts a Swagger schema into an OpenAPI 3 schema, re-pointing references, and converting
+// the 'file' type, 'x-nullable' and string discriminators.
+func (c *swaggerConverter) schema(s *yaml.Node) *yaml.Node {
+	s = utils.NodeAlias(s)
+	if !utils.IsNodeMap(s) {
+		return cloneNode(s)
+	}
+	out := utils.CreateEmptyMapNode()
+	for i := 0; i < len(s.Content)-1; i += 2 {
+		k, v := s.Content[i], utils.NodeAlias(s.Content[i+1])
+		switch k.Value {
+		case "$ref":
+			mapSetKey(out, k, c.refValue(v))
+		case "type":
+			if v.Value == "file" {
+				mapSetKey(out, k, utils.CreateStringNode("string"))
+				mapSet(out, "format", utils.CreateStringNode("binary"))
+				continue
+			}
+			mapSetKey(out, k, cloneNode(v))
+		case "format":
+			if mapGet(out, "format") == nil {
+				mapSetKey(out, k, cloneNode(v))
+			}
+		case "x-nullable":
+			mapSet(out, "nullable", cloneNode(v))
+		case "discriminator":
+			if v.Kind == yaml.ScalarNode {
+				d := utils.CreateEmptyMapNode()
+				mapSet(d, "propertyName", cloneNode(v))
+				mapSetKey(out, k, d)
+				continue
+			}
+			mapSetKey(out, k, cloneNode(v))
+		case "properties":
+			props := utils.CreateEmptyMapNode()
+			for j := 0; j < len(v.Content)-1; j += 2 {
+				mapSetKey(props, v.Content[j], c.schema(v.Content[j+1]))
+			}
+			mapSetKey(out, k, props)
+		case "items":
+			if v.Kind == yaml.SequenceNode {
+				c.report.addIssue(k, "tuple items cannot be represented in OpenAPI 3.0, only the first item "+
+					"schema has been kept")
+				if len(v.Content) == 0 {
+					continue
+				}
+				v = v.Content[0]
+			}
+			mapSetKey(out, k, c.schema(v))
+		case "additionalProperties", "not":
+			mapSetKey(out, k, c.schema(v))
+		case "allOf", "anyOf", "oneOf":
+			seq := utils.CreateEmptySequenceNode()
+			for _, item := range v.Content {
+				seq.Content = append(seq.Content, c.schema(item))
+			}
+			mapSetKey(out, k, seq)
+		default:
+			mapSetKey(out, k, cloneNode(v))
+		}
+	}
+	return out
+}
+
+// refMap clones a map holding a reference, re-pointing the reference at the new location.
+func (c *swaggerConverter) refMap(n *yaml.Node) *yaml.Node {
+	out := utils.CreateEmptyMapNode()
+	for i := 0; i < len(n.Content)-1; i += 2 {
+		if n.Content[i].Value == "$ref" {
+			mapSetKey(out, n.Content[i], c.refValue(n.Content[i+1]))
+			continue
+		}
+		mapSetKey(out, n.Content[i], cloneNode(n.Content[i+1]))
+	}
+	return out
+}
+
+// refValue clones a reference value, re-pointing the reference at the new location.
+func (c *swaggerConverter) refValue(v *yaml.Node) *yaml.Node {
+	out := cloneNode(v)
+	out.Value = c.rewriteRef(v.Value)
+	return out
+}
+
+// rewriteRef moves a Swagger reference to the location of the same component in OpenAPI 3. References to
+// other documents are moved as well, as those documents are expected to be converted too.
+func (c *swaggerConverter) rewriteRef(ref string) string {
+	file, fragment, found := strings.Cut(ref, "#")
+	if !found {
+		return ref
+	}
+	switch {
+	case strings.HasPrefix(fragment, "/definitions/"):
+		fragment = "/components/schemas/" + strings.TrimPrefix(fragment, "/definitions/")
+	case strings.HasPrefix(fragment, "/responses/"):
+		fragment = "/components/responses/" + strings.TrimPrefix(fragment, "/responses/")
+	case strings.HasPrefix(fragment, "/parameters/"):
+		name := strings.TrimPrefix(fragment, "/parameters/")
+		if file == "" && parameterIn(c.params[unescapePointer(name)]) == "body" {
+			fragment = "/components/requestBodies/" + name
+		} else {
+			fragment = "/components/parameters/" + name
+		}
+	}
+	return file + "#" + fragment
+}
+
+func parameterIn(p *yaml.Node) string {
+	if in := mapGet(p, "in"); in != nil {
+		return in.Value
+	}
+	return ""
+}
+
+func mediaTypes(types []string) []string {
+	if len(types) == 0 {
+		return []string{defaultMimeType}
+	}
+	return slices.Clone(types)
+}
+
+func unescapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+}
+
+func stringValues(n *yaml.Node) []string {
+	n = utils.NodeAlias(n)
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return nil
+	}
+	values := make([]string, 0, len(n.Content))
+	for _, v := range n.Content {
+		values = append(values, v.Value)
+	}
+	return values
+}
+
+func mapGet(m *yaml.Node, key string) *yaml.Node {
+	m = utils.NodeAlias(m)
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(m.Content)-1; i += 2 {
+		if m.Content[i].Value == key {
+			return utils.NodeAlias(m.Content[i+1])
+		}
+	}
+	return nil
+}
+
+func mapSet(m *yaml.Node, key string, value *yaml.Node) {
+	m.Content = append(m.Content, utils.CreateStringNode(key), value)
+}
+
+// mapSetKey adds a value to a map using a copy of the original key node, so comments and positions are kept.
+func mapSetKey(m, key, value *yaml.Node) {
+	m.Content = append(m.Content, cloneNode(key), value)
+}
+
+func mapDelete(m *yaml.Node, key string) {
+	for i := 0; i < len(m.Content)-1; i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = slices.Delete(m.Content, i, i+2)
+			return
+		}
+	}
+}
+
+// cloneNode creates a deep copy of a node, resolving aliases as it goes so the copy can be placed anywhere.
+func cloneNode(n *yaml.Node) *yaml.Node {
+	if n == nil {
+		return nil
+	}
+	n = utils.NodeAlias(n)
+	c := *n
+	c.Anchor = ""
+	if n.Content != nil {
+		c.Content = make([]*yaml.Node, len(n.Content))
+		for i, child := range n.Content {
+			c.Content[i] = cloneNode(child)
+		}
+	}
+	return &c
+}
@@ -0,0 +1,382 @@
+// Copyright 2023-2024 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func convertSwagger(t *testing.T, spec string) (*yaml.Node, *Report, string) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	converted, report, err := SwaggerToOpenAPI3(&root)
+	require.NoError(t, err)
+	b, err := yaml.Marshal(converted)
+	require.NoError(t, err)
+	return converted, report, string(b)
+}
+
+func TestSwaggerToOpenAPI3(t *testing.T) {
+	spec := `swagger: "2.0"
+info:
+  title: pets
+  version: 1.0.0
+host: pets.com
+basePath: /v1
+schemes: [https, http]
+consumes: [application/json]
+produces: [application/json, application/xml]
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: tags
+          in: query
+          type: array
+          items:
+            type: string
+          collectionFormat: multi
+        - $ref: '#/parameters/limit'
+      responses:
+        "200":
+          description: pets
+          schema:
+            type: array
+            items:
+              $ref: '#/definitions/Pet'
+          headers:
+            X-Rate-Limit:
+              type: integer
+              description: calls left
+        default:
+          $ref: '#/responses/Error'
+    post:
+      consumes: [application/json]
+      parameters:
+        - $ref: '#/parameters/petBody'
+      responses:
+        "201":
+          description: created
+  /pets/{id}/photo:
+    post:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          type: string
+        - name: photo
+          in: formData
+          type: file
+          required: true
+        - name: caption
+          in: formData
+          type: string
+      responses:
+        "204":
+          description: uploaded
+definitions:
+  Pet:
+    type: object
+    discriminator: kind
+    properties:
+      kind:
+        type: string
+      owner:
+        $ref: '#/definitions/Owner'
+      nickname:
+        type: string
+        x-nullable: true
+  Owner:
+    type: object
+parameters:
+  limit:
+    name: limit
+    in: query
+    type: integer
+    maximum: 100
+  petBody:
+    name: pet
+    in: body
+    required: true
+    schema:
+      $ref: '#/definitions/Pet'
+responses:
+  Error:
+    description: problem
+    schema:
+      type: string
+securityDefinitions:
+  basic:
+    type: basic
+  oauth:
+    type: oauth2
+    flow: accessCode
+    authorizationUrl: https://pets.com/auth
+    tokenUrl: https://pets.com/token
+    scopes:
+      read:pets: read pets
+x-owner: pets-team`
+
+	_, report, rendered := convertSwagger(t, spec)
+	assert.False(t, report.HasIssues())
+	assert.Equal(t, "2.0", report.SourceVersion)
+	assert.Equal(t, OpenAPI3Version, report.TargetVersion)
+
+	expected := `openapi: 3.0.3
+info:
+    title: pets
+    version: 1.0.0
+servers:
+    - url: https://pets.com/v1
+    - url: http://pets.com/v1
+paths:
+    /pets:
+        get:
+            operationId: listPets
+            parameters:
+                - name: tags
+                  in: query
+                  schema:
+                    type: array
+                    items:
+                        type: string
+                  style: form
+                  explode: true
+                - $ref: '#/components/parameters/limit'
+            responses:
+                "200":
+                    description: pets
+                    content:
+                        application/json:
+                            schema:
+                                type: array
+                                items:
+                                    $ref: '#/components/schemas/Pet'
+                        application/xml:
+                            schema:
+                                type: array
+                                items:
+                                    $ref: '#/components/schemas/Pet'
+                    headers:
+                        X-Rate-Limit:
+                            schema:
+                                type: integer
+                            description: calls left
+                default:
+                    $ref: '#/components/responses/Error'
+        post:
+            requestBody:
+                $ref: '#/components/requestBodies/petBody'
+            responses:
+                "201":
+                    description: created
+    /pets/{id}/photo:
+        post:
+            parameters:
+                - name: id
+                  in: path
+                  required: true
+                  schema:
+                    type: string
+            requestBody:
+                content:
+                    multipart/form-data:
+                        schema:
+                            type: object
+                            properties:
+                                photo:
+                                    type: string
+                                    format: binary
+                                caption:
+                                    type: string
+                            required:
+                                - photo
+                required: true
+            responses:
+                "204":
+                    description: uploaded
+components:
+    schemas:
+        Pet:
+            type: object
+            discriminator:
+                propertyName: kind
+            properties:
+                kind:
+                    type: string
+                owner:
+                    $ref: '#/components/schemas/Owner'
+                nickname:
+                    type: string
+                    nullable: true
+        Owner:
+            type: object
+    responses:
+        Error:
+            description: problem
+            content:
+                application/json:
+                    schema:
+                        type: string
+                application/xml:
+                    schema:
+                        type: string
+    parameters:
+        limit:
+            name: limit
+            in: query
+            schema:
+                type: integer
+                maximum: 100
+    requestBodies:
+        petBody:
+            content:
+                application/json:
+                    schema:
+                        $ref: '#/components/schemas/Pet'
+            required: true
+            x-codegen-request-body-name: pet
+    securitySchemes:
+        basic:
+            type: http
+            scheme: basic
+        oauth:
+            type: oauth2
+            flows:
+                authorizationCode:
+                    authorizationUrl: https://pets.com/auth
+                    tokenUrl: https://pets.com/token
+                    scopes:
+                        read:pets: read pets
+x-owner: pets-team
+`
+	assert.Equal(t, expected, rendered)
+}
+
+func TestSwaggerToOpenAPI3_SourceUntouched(t *testing.T) {
+	spec := `swagger: "2.0"
+definitions:
+  Pet:
+    type: file`
+
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	before, _ := yaml.Marshal(&root)
+	_, _, err := SwaggerToOpenAPI3(&root)
+	require.NoError(t, err)
+	after, _ := yaml.Marshal(&root)
+	assert.Equal(t, string(before), string(after))
+}
+
+func TestSwaggerToOpenAPI3_PathLevelBody(t *testing.T) {
+	spec := `swagger: "2.0"
+paths:
+  /pets:
+    parameters:
+      - name: trace
+        in: header
+        type: string
+      - name: pet
+        in: body
+        schema:
+          type: object
+    put:
+      responses:
+        "200":
+          description: ok
+    post:
+      parameters:
+        - name: name
+          in: formData
+          type: string
+      responses:
+        "200":
+          description: ok`
+
+	converted, _, _ := convertSwagger(t, spec)
+	item := mapGet(mapGet(converted, "paths"), "/pets")
+	require.NotNil(t, mapGet(item, "parameters"))
+	assert.Len(t, mapGet(item, "parameters").Content, 1)
+
+	put := mapGet(item, "put")
+	assert.NotNil(t, mapGet(mapGet(mapGet(put, "requestBody"), "content"), "application/json"))
+
+	post := mapGet(item, "post")
+	assert.NotNil(t, mapGet(mapGet(mapGet(post, "requestBody"), "content"), urlEncodedForm))
+	assert.Nil(t, mapGet(mapGet(mapGet(post, "requestBody"), "content"), "application/json"))
+}
+
+func TestSwaggerToOpenAPI3_Issues(t *testing.T) {
+	spec := `swagger: "2.0"
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: ids
+          in: query
+          type: array
+          collectionFormat: tsv
+          items:
+            type: string
+      responses:
+        "200":
+          schema:
+            type: string
+securityDefinitions:
+  broken:
+    type: oauth2
+    flow: magic
+cheese: burger`
+
+	_, report, _ := convertSwagger(t, spec)
+	require.True(t, report.HasIssues())
+
+	var messages []string
+	for _, i := range report.Issues {
+		messages = append(messages, i.Message)
+	}
+	all := strings.Join(messages, "\n")
+	assert.Contains(t, all, "collectionFormat 'tsv' cannot be represented")
+	assert.Contains(t, all, "response has no description")
+	assert.Contains(t, all, "oauth2 security scheme has an unknown or missing flow")
+	assert.Contains(t, all, "'cheese' is not a valid Swagger 2.0 property")
+
+	for _, i := range report.Issues {
+		assert.NotZero(t, i.Line)
+	}
+	assert.Equal(t, "'cheese' is not a valid Swagger 2.0 property and has been dropped, line 20, column 1",
+		report.Issues[2].String())
+}
+
+func TestSwaggerToOpenAPI3_NotSwagger(t *testing.T) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`openapi: 3.1.0`), &root))
+	_, _, err := SwaggerToOpenAPI3(&root)
+	assert.Error(t, err)
+
+	_, _, err = SwaggerToOpenAPI3(nil)
+	assert.Error(t, err)
+}
+
+func TestSwaggerToOpenAPI3_Petstore(t *testing.T) {
+	spec, err := os.ReadFile("../test_specs/petstorev2.json")
+	require.NoError(t, err)
+
+	converted, report, _ := convertSwagger(t, string(spec))
+	assert.False(t, report.HasIssues())
+
+	upload := mapGet(mapGet(mapGet(converted, "paths"), "/pet/{petId}/uploadImage"), "post")
+	require.NotNil(t, upload)
+	assert.NotNil(t, mapGet(mapGet(mapGet(upload, "requestBody"), "content"), multipartForm))
+
+	schemes := mapGet(mapGet(converted, "components"), "securitySchemes")
+	assert.NotNil(t, mapGet(mapGet(mapGet(schemes, "petstore_auth"), "flows"), "implicit"))
+}
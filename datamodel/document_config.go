@@ -4,10 +4,13 @@
 package datamodel
 
 import (
+	"context"
 	"io/fs"
 	"log/slog"
 	"net/url"
 	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/pb33f/libopenapi/utils"
 )
@@ -104,8 +107,18 @@ type DocumentConfiguration struct {
 	// indexed later on.
 	SkipCircularReferenceCheck bool
 
+	// SkipUnknownKeyWarnings will skip checking the document for unknown keys (keys that are not a part of the
+	// specification, and are not extensions) when it is built. Checking walks every model in the document, so
+	// skipping it makes building large documents a little faster. This is disabled by default, which means every
+	// unknown key is reported as a build warning.
+	SkipUnknownKeyWarnings bool
+
 	// Logger is a structured logger that will be used for logging errors and warnings. If not set, a default logger
 	// will be used, set to the Error level.
+	//
+	// The same logger is used by everything that builds a document: the rolodex (and its local and remote file
+	// systems), every index, the resolver and the low-level models, so the level and handler of this logger
+	// control every log written while building a document.
 	Logger *slog.Logger
 
 	// ExtractRefsSequentially will extract all references sequentially, which means the index will look up references
@@ -117,8 +130,203 @@ type DocumentConfiguration struct {
 	// BundleInlineRefs is used by the bundler module. If set to true, all references will be inlined, including
 	// local references (to the root document) as well as all external references. This is false by default.
 	BundleInlineRefs bool
+
+	// UpgradeSwaggerDocuments will allow BuildV3Model() to be used with a Swagger (OpenAPI 2) document. The document
+	// is converted into OpenAPI 3 first, and the V3 model is built from the converted document. This is disabled by
+	// default, which means BuildV3Model() will return an error when used with a Swagger document.
+	//
+	// Anything that could not be converted faithfully is explained by the conversion report, available from the
+	// model via GetConversionReport().
+	UpgradeSwaggerDocuments bool
+
+	// MemoryAccounting adds the approximate memory held by a document (by its bytes, node trees, indexes and models)
+	// to its stats, see Document.GetStats, so services holding many documents can find the ones using the most
+	// memory. Measuring walks everything the document holds, so it takes time in proportion to the size of the
+	// document, and is off by default.
+	MemoryAccounting bool
+
+	// LazySpecJSON keeps only one copy of the specification in the SpecInfo of a document, its bytes (SpecBytes).
+	// SpecJSONBytes shares them when the specification is JSON and is nil when it is YAML, SpecJSON is nil. The JSON
+	// is worked out when it is needed by SpecInfo.GetSpecJSONBytes and SpecInfo.GetSpecJSON. This matters when
+	// holding many documents, and is off by default, as SpecJSON and SpecJSONBytes are otherwise always set.
+	LazySpecJSON bool
+
+	// SharedResolutionCache is an opt-in cache shared by documents, which shares the node trees of the files they
+	// reference, and the subtrees their references resolve to, when the files are the same, so services that load
+	// many specifications referencing the same common files only hold one copy of them. The low level values of
+	// models built from shared trees cannot be changed in place (see ErrSharedNode). See SharedResolutionCache.
+	SharedResolutionCache *SharedResolutionCache
+
+	// BulkAllocation allocates the low-level models of a document (and the references and node maps they hold) in
+	// slabs of models of the same type, instead of one at a time, which lowers the pressure on the garbage collector of
+	// services building many big documents (see low.Allocator). The slabs are held until the document is closed (see
+	// Document.Close), and each one is only freed once none of the models allocated from it are used any more.
+	// This is disabled by default.
+	BulkAllocation bool
+
+	// CacheHashes keeps the hashes of the low-level models of a document cached between calls to Hash(), instead of
+	// only while the document is compared, which makes hashing big documents over and over again a lot cheaper. The
+	// document must then only be changed through SetValue or ReplaceNode of the low-level references, which drop the
+	// cached hashes, or low.HashScope.Invalidate must be called after changing a model directly (see
+	// low.HashScope.Keep). Hashes are kept until the document is closed (see Document.Close).
+	// This is disabled by default.
+	CacheHashes bool
+
+	// MaxDocumentSize is the maximum number of bytes that will be read from an io.Reader by NewDocumentFromReader.
+	// If the reader holds more than this, reading stops and an error is returned, instead of loading an unbounded
+	// amount of data into memory. Specifications larger than this are also rejected by NewDocumentWithConfiguration
+	// and ExtractSpecInfoWithConfig (ErrDocumentTooLarge). Zero (the default) means there is no limit.
+	MaxDocumentSize int64
+
+	// MaxNodeCount is the maximum number of YAML nodes (every key, value and item) a specification may hold, once
+	// parsed. Larger specifications are rejected with ErrTooManyNodes. Zero (the default) means there is no limit.
+	MaxNodeCount int
+
+	// MaxAliasExpansion is the maximum number of YAML nodes that the aliases of a specification may expand into.
+	// Aliases of aliases multiply, so a small document can expand into billions of nodes ('billion laughs'),
+	// specifications that do are rejected with ErrAliasExpansionTooLarge, before any alias is expanded. Zero (the
+	// default) means there is no limit.
+	MaxAliasExpansion int
+
+	// MaxNestingDepth is the maximum depth that maps and sequences may be nested in a specification, including the
+	// depth added by expanding aliases. Deeper specifications are rejected with ErrNestingTooDeep. Zero (the default)
+	// means there is no limit.
+	MaxNestingDepth int
+
+	// MaxResolveDepth is the deepest the resolver walks into a reference (counting every map and sequence, including
+	// those of the references it follows) before it gives up on it, rather than risk the stack. References nested
+	// deeper are reported with index.ErrTooDeep, which like a circular reference, does not stop the model from being
+	// built. Zero (the default) means index.DefaultMaxResolveDepth (500).
+	MaxResolveDepth int
+
+	// Context governs building a document with this configuration, including remote lookups made by the rolodex,
+	// building the index and resolving references. When the context is cancelled, or its deadline is exceeded, no
+	// more references are looked up and building stops with the context error. If not set, context.Background()
+	// is used.
+	Context context.Context
+
+	// Timeout limits how long building a document with this configuration can take, as a deadline added to Context.
+	// Zero (the default) means there is no timeout. Building against slow remote references can then never take
+	// longer than the timeout.
+	Timeout time.Duration
+
+	// BuildPaths limits the path items that are built into the model, to the paths listed. Every other path item is
+	// skipped, and is not part of the model, so reading a few operations of a very large specification does not
+	// require building all of them. Components are still built lazily as they are used, and the whole specification
+	// is still indexed, so references can be resolved. If not set (the default), every path item is built.
+	BuildPaths []string
+
+	// WarmComponentSchemas builds every schema in the components of a model, in parallel, as the model is built by
+	// BuildV3Model, instead of lazily the first time each one is used. This makes building the model slower, but
+	// reading schemas afterward never pays the cost of building them. Schemas that could not be built are added to
+	// the errors of building the model. This is disabled by default.
+	WarmComponentSchemas bool
+
+	// OnSpecInfoExtracted is called with the SpecInfo of a specification as soon as it has been parsed, by
+	// ExtractSpecInfoWithConfig (and so by NewDocumentWithConfiguration). Returning an error rejects the
+	// specification, the error is returned instead of the SpecInfo.
+	OnSpecInfoExtracted func(info *SpecInfo) error
+
+	// OnIndexBuilt is called with the rolodex (an *index.Rolodex) of a document being built, once every file it
+	// holds has been indexed, before references are checked for circular references and the model is built. A
+	// returned error is added to the errors of building the model.
+	OnIndexBuilt func(rolodex any) error
+
+	// OnResolved is called with the rolodex (an *index.Rolodex) of a document being built, once its references have
+	// been resolved and checked for circular references, before the model is built. A returned error is added to the
+	// errors of building the model.
+	OnResolved func(rolodex any) error
+
+	// OnError is called with every error hit extracting the SpecInfo of a specification, or building its model,
+	// including errors returned by the other hooks.
+	//
+	// Hooks are called while the document is being built, and must not call methods of that document.
+	OnError func(err error)
+
+	// YAMLEngine parses specifications, and renders them as YAML. If not set, DefaultYAMLEngine (gopkg.in/yaml.v3) is
+	// used. The engine is used by ExtractSpecInfoWithConfig, by the rolodex to parse every file the specification
+	// references, and by documents to render and serialize YAML.
+	YAMLEngine YAMLEngine
+}
+
+// BuildContext returns the context that governs building a document with this configuration. It is derived from
+// ctx, or from Context if ctx is nil, or from context.Background() if neither are set, and applies Timeout if one is
+// set. The returned cancel function must be called once the document has been built.
+func (c *DocumentConfiguration) BuildContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil && c != nil {
+		ctx = c.Context
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c != nil && c.Timeout > 0 {
+		return context.WithTimeout(ctx, c.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// IndexContext returns the context for the rolodex (and the indexes) of a document being built with ctx. Until
+// release is called, it is done when ctx is done, so building the document can be cancelled. Once released, it is
+// never done: the rolodex outlives building the document, so lookups made later on are not bound by the context
+// the document was built with. Values of ctx are kept either way.
+//
+// Release must be called once the document has been built, before ctx is cancelled.
+func IndexContext(ctx context.Context) (indexCtx context.Context, release func()) {
+	c := &indexContext{Context: ctx}
+	return c, func() { c.released.Store(true) }
+}
+
+type indexContext struct {
+	context.Context
+	released atomic.Bool
 }
 
+func (c *indexContext) Deadline() (time.Time, bool) {
+	if c.released.Load() {
+		return time.Time{}, false
+	}
+	return c.Context.Deadline()
+}
+
+func (c *indexContext) Done() <-chan struct{} {
+	if c.released.Load() {
+		return nil
+	}
+	return c.Context.Done()
+}
+
+func (c *indexContext) Err() error {
+	if c.released.Load() {
+		return nil
+	}
+	return c.Context.Err()
+}
+
+// ReportErrors calls the OnError hook of the configuration with every error, if the hook is set.
+func (c *DocumentConfiguration) ReportErrors(errs ...error) {
+	if c == nil || c.OnError == nil {
+		return
+	}
+	for _, err := range errs {
+		if err != nil {
+			c.OnError(err)
+		}
+	}
+}
+
+// GetYAMLEngine returns the YAMLEngine of the configuration, or DefaultYAMLEngine if it's not set.
+func (c *DocumentConfiguration) GetYAMLEngine() YAMLEngine {
+	if c == nil || c.YAMLEngine == nil {
+		return DefaultYAMLEngine
+	}
+	return c.YAMLEngine
+}
+
+// defaultLogger is used when a configuration does not set a Logger.
+var defaultLogger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	Level: slog.LevelError,
+}))
+
 func NewDocumentConfiguration() *DocumentConfiguration {
 	return &DocumentConfiguration{
 		Logger: slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
@@ -126,3 +334,12 @@ func NewDocumentConfiguration() *DocumentConfiguration {
 		})),
 	}
 }
+
+// GetLogger returns the Logger of the configuration, or if it's not set, a default logger that writes JSON to
+// stdout at the Error level.
+func (c *DocumentConfiguration) GetLogger() *slog.Logger {
+	if c == nil || c.Logger == nil {
+		return defaultLogger
+	}
+	return c.Logger
+}
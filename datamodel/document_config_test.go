@@ -4,11 +4,76 @@
 package datamodel
 
 import (
-	"github.com/stretchr/testify/assert"
+	"context"
+	"io"
+	"log/slog"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewClosedDocumentConfiguration(t *testing.T) {
 	cfg := NewDocumentConfiguration()
 	assert.NotNil(t, cfg)
 }
+
+func TestDocumentConfiguration_BuildContext(t *testing.T) {
+	var cfg *DocumentConfiguration
+	ctx, cancel := cfg.BuildContext(nil)
+	assert.NoError(t, ctx.Err())
+	cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	type key struct{}
+	cfg = &DocumentConfiguration{Context: context.WithValue(context.Background(), key{}, "pizza")}
+	ctx, cancel = cfg.BuildContext(nil)
+	defer cancel()
+	assert.Equal(t, "pizza", ctx.Value(key{}))
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+
+	// a supplied context wins over the configured one.
+	ctx, cancel = cfg.BuildContext(context.Background())
+	defer cancel()
+	assert.Nil(t, ctx.Value(key{}))
+
+	cfg.Timeout = time.Minute
+	ctx, cancel = cfg.BuildContext(nil)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+}
+
+func TestDocumentConfiguration_GetLogger(t *testing.T) {
+	var cfg *DocumentConfiguration
+	assert.NotNil(t, cfg.GetLogger())
+	assert.Same(t, cfg.GetLogger(), (&DocumentConfiguration{}).GetLogger())
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg = &DocumentConfiguration{Logger: logger}
+	assert.Same(t, logger, cfg.GetLogger())
+}
+
+func TestIndexContext(t *testing.T) {
+	type key struct{}
+	parent, cancel := context.WithTimeout(context.WithValue(context.Background(), key{}, "pizza"), time.Minute)
+	ctx, release := IndexContext(parent)
+	assert.Equal(t, "pizza", ctx.Value(key{}))
+	_, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.NotNil(t, ctx.Done())
+
+	// until released, the context is done when the parent is.
+	cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	// once released, it is never done.
+	release()
+	assert.NoError(t, ctx.Err())
+	assert.Nil(t, ctx.Done())
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+	assert.Equal(t, "pizza", ctx.Value(key{}))
+}
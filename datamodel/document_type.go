@@ -0,0 +1,80 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnsupportedDocumentType is matched by every UnsupportedDocumentType error.
+var ErrUnsupportedDocumentType = NewCodedError("unsupported-document-type", "spec type not supported by libopenapi")
+
+// UnsupportedDocumentType is returned when a document is recognized as something other than an OpenAPI or Swagger
+// specification, like an AsyncAPI, Arazzo or JSON Schema document. The detected type is also set as the SpecType
+// of the SpecInfo.
+type UnsupportedDocumentType struct {
+	// Type is the detected type of the document, one of utils.AsyncApi, utils.Arazzo or utils.JSONSchema.
+	Type string
+
+	// Version is the version of the document, if one could be found.
+	Version string
+}
+
+func (e *UnsupportedDocumentType) Error() string {
+	name := documentTypeName(e.Type)
+	if e.Version != "" {
+		name += " " + e.Version
+	}
+	return fmt.Sprintf("spec type not supported by libopenapi, the document is %s, not OpenAPI or Swagger", name)
+}
+
+// Unwrap returns ErrUnsupportedDocumentType, so every UnsupportedDocumentType matches it with errors.Is.
+func (e *UnsupportedDocumentType) Unwrap() error {
+	return ErrUnsupportedDocumentType
+}
+
+func documentTypeName(documentType string) string {
+	switch documentType {
+	case utils.AsyncApi:
+		return "AsyncAPI"
+	case utils.Arazzo:
+		return "Arazzo"
+	case utils.JSONSchema:
+		return "JSON Schema"
+	}
+	return documentType
+}
+
+var jsonSchemaVersion = regexp.MustCompile(`draft[-/](\d{4}-\d{2}|\d+)`)
+
+// detectDocumentType looks for the keys that identify documents that are not OpenAPI or Swagger, and returns the
+// type and version of the document, or an empty type if the document is not recognized.
+func detectDocumentType(root *yaml.Node) (string, string) {
+	if root == nil || len(root.Content) == 0 || !utils.IsNodeMap(root.Content[0]) {
+		return "", ""
+	}
+	top := root.Content[0].Content
+	if _, arazzo := utils.FindKeyNodeTop(utils.Arazzo, top); arazzo != nil {
+		return utils.Arazzo, arazzo.Value
+	}
+	if _, schema := utils.FindKeyNodeTop("$schema", top); schema != nil {
+		var version string
+		if m := jsonSchemaVersion.FindStringSubmatch(schema.Value); m != nil {
+			version = "draft " + m[1]
+		}
+		return utils.JSONSchema, version
+	}
+	_, id := utils.FindKeyNodeTop("$id", top)
+	_, defs := utils.FindKeyNodeTop("$defs", top)
+	_, schemaType := utils.FindKeyNodeTop("type", top)
+	_, properties := utils.FindKeyNodeTop("properties", top)
+	if id != nil || defs != nil || (schemaType != nil && properties != nil) {
+		return utils.JSONSchema, ""
+	}
+	return "", ""
+}
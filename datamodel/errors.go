@@ -0,0 +1,76 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode identifies a kind of error, so errors can be handled without matching their messages.
+type ErrorCode string
+
+// CodedError is a sentinel error for a kind of error. Every error of that kind matches it with errors.Is, and
+// ErrorCodeOf returns its Code, no matter how the error has been wrapped or joined.
+type CodedError struct {
+	Code    ErrorCode
+	Message string
+}
+
+func (e *CodedError) Error() string {
+	return e.Message
+}
+
+// NewCodedError creates a sentinel error for a kind of error.
+func NewCodedError(code ErrorCode, message string) *CodedError {
+	return &CodedError{Code: code, Message: message}
+}
+
+// ErrorCodeOf returns the code of the first CodedError that err matches, or an empty code if it doesn't match any.
+func ErrorCodeOf(err error) ErrorCode {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return ""
+}
+
+// NewError returns an error of a kind, with its own message. The error matches kind, and cause if there is one,
+// with errors.Is and errors.As. If format is empty, the message of the cause is used.
+func NewError(kind *CodedError, cause error, format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+	if format == "" && cause != nil {
+		msg = cause.Error()
+	}
+	return &kindError{msg: msg, kind: kind, cause: cause}
+}
+
+// kindError is an error of a kind, that may have been caused by another error.
+type kindError struct {
+	msg   string
+	kind  *CodedError
+	cause error
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+// Is matches the kind of the error, errors.Is matches the cause through Unwrap.
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+// As sets a *CodedError target to the kind of the error.
+func (e *kindError) As(target any) bool {
+	if coded, ok := target.(**CodedError); ok {
+		*coded = e.kind
+		return true
+	}
+	return false
+}
+
+func (e *kindError) Unwrap() error {
+	return e.cause
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewError(t *testing.T) {
+	errPizza := NewCodedError("pizza", "no pizza")
+	assert.Equal(t, "no pizza", errPizza.Error())
+	assert.Equal(t, ErrorCode("pizza"), ErrorCodeOf(errPizza))
+
+	err := NewError(errPizza, nil, "no pizza with %s", "pineapple")
+	assert.EqualError(t, err, "no pizza with pineapple")
+	assert.ErrorIs(t, err, errPizza)
+	assert.NotErrorIs(t, err, ErrDocumentTooLarge)
+	assert.Equal(t, ErrorCode("pizza"), ErrorCodeOf(err))
+
+	// the kind and the cause are found when wrapped, or joined.
+	cause := errors.New("the oven is broken")
+	err = NewError(errPizza, cause, "")
+	assert.EqualError(t, err, "the oven is broken")
+	wrapped := errors.Join(errors.New("cheese"), fmt.Errorf("dinner: %w", err))
+	assert.ErrorIs(t, wrapped, errPizza)
+	assert.ErrorIs(t, wrapped, cause)
+	assert.Equal(t, ErrorCode("pizza"), ErrorCodeOf(wrapped))
+
+	var coded *CodedError
+	assert.ErrorAs(t, err, &coded)
+	assert.Same(t, errPizza, coded)
+
+	assert.Equal(t, ErrorCode(""), ErrorCodeOf(cause))
+	assert.Equal(t, ErrorCode(""), ErrorCodeOf(nil))
+	assert.Equal(t, ErrorCode("unsupported-document-type"),
+		ErrorCodeOf(&UnsupportedDocumentType{Type: "arazzo"}))
+}
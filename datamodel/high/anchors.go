@@ -0,0 +1,81 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RestoreAnchors will re-apply anchors and aliases (found using low.FindAnchors) to a rendered yaml.Node tree.
+//
+// An anchor is only restored if the rendered node found at the same location is unmodified (it is equal to the
+// original anchored node). An alias is only restored if the anchor has been restored earlier in the rendered
+// tree, and the rendered node found at the location of the alias is also unmodified. Anything that has changed
+// is left rendered out in full.
+//
+// The rendered tree is modified in place and returned.
+func RestoreAnchors(rendered *yaml.Node, anchors []*low.Anchor) *yaml.Node {
+	if rendered == nil || len(anchors) == 0 {
+		return rendered
+	}
+	ar := &anchorRestorer{
+		anchorsByPath: make(map[string]*low.Anchor),
+		aliasesByPath: make(map[string]*low.Anchor),
+		restored:      make(map[*low.Anchor]*yaml.Node),
+	}
+	for _, a := range anchors {
+		if a.Path != "" {
+			ar.anchorsByPath[a.Path] = a
+		}
+		for _, al := range a.Aliases {
+			ar.aliasesByPath[al.Path] = a
+		}
+	}
+	root := rendered
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	ar.walk(root, "$")
+	return rendered
+}
+
+// unmodified compares a rendered node with an original node, styles, comments and positions are ignored, aliases in
+// the original are followed.
+var unmodified = utils.NodesEqualOptions{IgnoreStyle: true, IgnoreComments: true, IgnoreKeyOrder: true}
+
+type anchorRestorer struct {
+	anchorsByPath map[string]*low.Anchor
+	aliasesByPath map[string]*low.Anchor
+	restored      map[*low.Anchor]*yaml.Node
+}
+
+// walk visits the rendered tree in the order it will be written out, anchors must be written before aliases.
+func (ar *anchorRestorer) walk(node *yaml.Node, path string) *yaml.Node {
+	if a, ok := ar.aliasesByPath[path]; ok && ar.restored[a] != nil {
+		if equal, _ := utils.NodesEqual(node, a.Node, unmodified); equal {
+			return &yaml.Node{Kind: yaml.AliasNode, Value: a.Name, Alias: ar.restored[a]}
+		}
+	}
+	if a, ok := ar.anchorsByPath[path]; ok && ar.restored[a] == nil {
+		if equal, _ := utils.NodesEqual(node, a.Node, unmodified); equal {
+			node.Anchor = a.Name
+			ar.restored[a] = node
+		}
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			node.Content[i+1] = ar.walk(node.Content[i+1], low.AnchorPath(path, node.Content[i].Value))
+		}
+	case yaml.SequenceNode:
+		for i := range node.Content {
+			node.Content[i] = ar.walk(node.Content[i], fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+	return node
+}
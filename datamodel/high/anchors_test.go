@@ -0,0 +1,69 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRestoreAnchors(t *testing.T) {
+	original := `base: &base
+    name: pizza
+things:
+    - &first one
+    - *first
+copy: *base
+other: *base`
+
+	var source yaml.Node
+	_ = yaml.Unmarshal([]byte(original), &source)
+	anchors := low.FindAnchors(&source)
+
+	// a rendered version of the document, with aliases resolved and 'other' modified.
+	expanded := `base:
+    name: pizza
+things:
+    - one
+    - one
+copy:
+    name: pizza
+other:
+    name: cake`
+
+	var rendered yaml.Node
+	_ = yaml.Unmarshal([]byte(expanded), &rendered)
+
+	RestoreAnchors(&rendered, anchors)
+	out, _ := yaml.Marshal(&rendered)
+
+	expected := `base: &base
+    name: pizza
+things:
+    - &first one
+    - *first
+copy: *base
+other:
+    name: cake`
+	assert.Equal(t, expected, strings.TrimSpace(string(out)))
+}
+
+func TestRestoreAnchors_ModifiedAnchor(t *testing.T) {
+	var source yaml.Node
+	_ = yaml.Unmarshal([]byte("a: &a\n  b: c\nd: *a"), &source)
+
+	var rendered yaml.Node
+	_ = yaml.Unmarshal([]byte("a:\n  b: changed\nd:\n  b: c"), &rendered)
+
+	RestoreAnchors(&rendered, low.FindAnchors(&source))
+	out, _ := yaml.Marshal(&rendered)
+
+	// the anchor was modified, so nothing can be restored.
+	assert.Equal(t, "a:\n    b: changed\nd:\n    b: c", strings.TrimSpace(string(out)))
+	assert.Nil(t, RestoreAnchors(nil, nil))
+}
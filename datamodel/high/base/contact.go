@@ -43,6 +43,16 @@ func (c *Contact) GoLowUntyped() any {
 	return c.low
 }
 
+// GetPosition returns the position of the Contact in the source specification, or nil if it is not available.
+func (c *Contact) GetPosition() *high.Position {
+	return high.GetPosition(c.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Contact is defined under, or nil if it is not available.
+func (c *Contact) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(c.GoLow())
+}
+
 func (c *Contact) Render() ([]byte, error) {
 	return yaml.Marshal(c)
 }
@@ -21,8 +21,9 @@ import (
 //
 //	v3 - https://spec.openapis.org/oas/v3.1.0#discriminator-object
 type Discriminator struct {
-	PropertyName string                          `json:"propertyName,omitempty" yaml:"propertyName,omitempty"`
-	Mapping      *orderedmap.Map[string, string] `json:"mapping,omitempty" yaml:"mapping,omitempty"`
+	PropertyName string                              `json:"propertyName,omitempty" yaml:"propertyName,omitempty"`
+	Mapping      *orderedmap.Map[string, string]     `json:"mapping,omitempty" yaml:"mapping,omitempty"`
+	Extensions   *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low          *lowBase.Discriminator
 }
 
@@ -32,6 +33,7 @@ func NewDiscriminator(disc *lowBase.Discriminator) *Discriminator {
 	d.low = disc
 	d.PropertyName = disc.PropertyName.Value
 	d.Mapping = low.FromReferenceMap(disc.Mapping.Value)
+	d.Extensions = high.ExtractExtensions(disc.Extensions)
 	return d
 }
 
@@ -45,6 +47,16 @@ func (d *Discriminator) GoLowUntyped() any {
 	return d.low
 }
 
+// GetPosition returns the position of the Discriminator in the source specification, or nil if it is not available.
+func (d *Discriminator) GetPosition() *high.Position {
+	return high.GetPosition(d.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Discriminator is defined under, or nil if it is not available.
+func (d *Discriminator) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(d.GoLow())
+}
+
 // Render will return a YAML representation of the Discriminator object as a byte slice.
 func (d *Discriminator) Render() ([]byte, error) {
 	return yaml.Marshal(d)
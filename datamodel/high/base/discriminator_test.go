@@ -39,6 +39,38 @@ mapping:
 	assert.Equal(t, strings.TrimSpace(string(rendered)), yml)
 }
 
+func TestNewDiscriminator_OrderedMappingAndExtensions(t *testing.T) {
+	var cNode yaml.Node
+
+	yml := `propertyName: coffee
+mapping:
+    zebra: stripes
+    apple: pie
+    mango: chutney
+x-coffee: strong`
+
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	var lowDiscriminator lowbase.Discriminator
+	_ = lowmodel.BuildModel(cNode.Content[0], &lowDiscriminator)
+	lowDiscriminator.Extensions = lowmodel.ExtractExtensions(cNode.Content[0])
+
+	highDiscriminator := NewDiscriminator(&lowDiscriminator)
+
+	var keys []string
+	for k := range highDiscriminator.Mapping.KeysFromOldest() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"zebra", "apple", "mango"}, keys)
+
+	var ext string
+	_ = highDiscriminator.Extensions.GetOrZero("x-coffee").Decode(&ext)
+	assert.Equal(t, "strong", ext)
+
+	rendered, _ := highDiscriminator.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(rendered)))
+}
+
 func ExampleNewDiscriminator() {
 	// create a yaml representation of a discriminator (can be JSON, doesn't matter)
 	yml := `propertyName: coffee
@@ -47,6 +47,16 @@ func (e *Example) GoLowUntyped() any {
 	return e.low
 }
 
+// GetPosition returns the position of the Example in the source specification, or nil if it is not available.
+func (e *Example) GetPosition() *high.Position {
+	return high.GetPosition(e.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Example is defined under, or nil if it is not available.
+func (e *Example) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(e.GoLow())
+}
+
 // Render will return a YAML representation of the Example object as a byte slice.
 func (e *Example) Render() ([]byte, error) {
 	return yaml.Marshal(e)
@@ -0,0 +1,88 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// ExampleViolation represents a single problem found when validating an example value against a Schema.
+type ExampleViolation struct {
+	// Message is a human-readable description of the violation.
+	Message string
+
+	// Location is the JSON path to the example in the document, for example
+	// $.paths['/pets'].get.parameters[0].example
+	Location string
+
+	// Path is the JSON path to the offending value, inside the example, for example $.pets[0].name
+	Path string
+
+	// Node is the yaml.Node of the example value that violated the schema.
+	Node *yaml.Node
+
+	// Line and Column of the offending node in the original document.
+	Line   int
+	Column int
+}
+
+// Error returns a readable version of the violation, so it can be used as an error.
+func (v *ExampleViolation) Error() string {
+	return fmt.Sprintf("example at %s is invalid: %s (%s, line %d, col %d)",
+		v.Location, v.Message, v.Path, v.Line, v.Column)
+}
+
+// ValidateExample will validate an example value (as a *yaml.Node) against a Schema, and return every violation
+// found. The example is validated by a datamodel.SchemaValidator (the same validator that checks specifications
+// against the OpenAPI meta-schemas), which understands the JSON Schema keywords used by OpenAPI and does not perform
+// format validation. References in the schema are resolved with the index the schema was built with.
+//
+// A `false` boolean schema (see Schema.IsBooleanFalse) rejects every example.
+//
+// The location argument is used to populate the Location of each violation, it's the path to the example in the
+// document.
+func ValidateExample(schema *Schema, example *yaml.Node, location string) []*ExampleViolation {
+	if schema == nil || example == nil {
+		return nil
+	}
+	var node *yaml.Node
+	var idx *index.SpecIndex
+	if schema.IsBooleanFalse() {
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "false"}
+	} else if low := schema.GoLow(); low != nil && low.RootNode != nil {
+		node, idx = low.RootNode, low.GetIndex()
+	} else {
+		// a schema built from scratch has no low-level model (or index), so it is rendered instead.
+		node = high.NewNodeBuilder(schema, nil).Render()
+	}
+	if node == nil {
+		return nil
+	}
+	v := datamodel.NewSchemaValidator(node)
+	if idx != nil {
+		v.Resolve = func(ref string) *yaml.Node {
+			if r := idx.FindComponent(ref); r != nil {
+				return r.Node
+			}
+			return nil
+		}
+	}
+	var violations []*ExampleViolation
+	for _, e := range v.Validate(node, example) {
+		violations = append(violations, &ExampleViolation{
+			Message:  e.Message,
+			Location: location,
+			Path:     e.Path,
+			Node:     e.Node,
+			Line:     e.Line,
+			Column:   e.Column,
+		})
+	}
+	return violations
+}
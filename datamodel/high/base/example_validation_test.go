@@ -0,0 +1,110 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func buildExampleTestSchema(spec string) *Schema {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &node)
+	sp := new(lowbase.SchemaProxy)
+	_ = sp.Build(context.Background(), nil, node.Content[0], nil)
+	return NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{
+		Value:     sp,
+		ValueNode: node.Content[0],
+	}).Schema()
+}
+
+func TestValidateExample(t *testing.T) {
+	schema := buildExampleTestSchema(`type: object
+required: [id]
+properties:
+  id:
+    type: string
+    pattern: ^[a-z]+$
+    maxLength: 4
+  tags:
+    type: array
+    maxItems: 1
+    items:
+      type: string
+  meta:
+    type: object
+    additionalProperties:
+      type: integer`)
+
+	var example yaml.Node
+	_ = yaml.Unmarshal([]byte(`id: ABCDEF
+tags: [a, 2]
+meta:
+  count: many`), &example)
+
+	violations := ValidateExample(schema, &example, "$.example")
+	var paths []string
+	for _, v := range violations {
+		assert.Equal(t, "$.example", v.Location)
+		paths = append(paths, v.Path+": "+v.Message)
+	}
+	assert.Equal(t, []string{
+		"$.id: string length 6 is greater than maxLength 4",
+		"$.id: value 'ABCDEF' does not match pattern '^[a-z]+$'",
+		"$.tags: array has 2 items, more than maxItems 1",
+		"$.tags[1]: expected type 'string', but got 'integer'",
+		"$.meta.count: expected type 'integer', but got 'string'",
+	}, paths)
+}
+
+func TestValidateExample_Nil(t *testing.T) {
+	assert.Nil(t, ValidateExample(nil, nil, ""))
+}
+
+func TestValidateExample_IntegerAsFloat(t *testing.T) {
+	schema := buildExampleTestSchema(`type: integer`)
+	var example yaml.Node
+	_ = yaml.Unmarshal([]byte(`2.0`), &example)
+	assert.Empty(t, ValidateExample(schema, &example, ""))
+
+	_ = yaml.Unmarshal([]byte(`2.5`), &example)
+	assert.Len(t, ValidateExample(schema, &example, ""), 1)
+}
+
+func TestValidateExample_BooleanSchema(t *testing.T) {
+	var example yaml.Node
+	_ = yaml.Unmarshal([]byte(`pizza: 1`), &example)
+
+	// a `false` schema rejects every example, whether it was parsed or built from scratch.
+	parsed := buildExampleTestSchema(`false`)
+	assert.True(t, parsed.IsBooleanFalse())
+	created := CreateBooleanSchemaProxy(false).Schema()
+	assert.True(t, created.IsBooleanFalse())
+	for _, schema := range []*Schema{parsed, created} {
+		violations := ValidateExample(schema, &example, "$.example")
+		if assert.Len(t, violations, 1) {
+			assert.Equal(t, "$: value is not allowed", violations[0].Path+": "+violations[0].Message)
+		}
+	}
+
+	// a `true` schema allows anything.
+	assert.False(t, buildExampleTestSchema(`true`).IsBooleanFalse())
+	assert.False(t, CreateBooleanSchemaProxy(true).Schema().IsBooleanFalse())
+	assert.Empty(t, ValidateExample(CreateBooleanSchemaProxy(true).Schema(), &example, ""))
+	assert.False(t, (&Schema{}).IsBooleanFalse())
+
+	// `false` schemas below a schema that was built from scratch are rendered and checked too.
+	props := orderedmap.New[string, *SchemaProxy]()
+	props.Set("pizza", CreateBooleanSchemaProxy(false))
+	violations := ValidateExample(&Schema{Properties: props}, &example, "")
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "$.pizza: value is not allowed", violations[0].Path+": "+violations[0].Message)
+	}
+}
@@ -47,6 +47,16 @@ func (e *ExternalDoc) GoLowUntyped() any {
 	return e.low
 }
 
+// GetPosition returns the position of the ExternalDoc in the source specification, or nil if it is not available.
+func (e *ExternalDoc) GetPosition() *high.Position {
+	return high.GetPosition(e.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the ExternalDoc is defined under, or nil if it is not available.
+func (e *ExternalDoc) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(e.GoLow())
+}
+
 func (e *ExternalDoc) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
 	return e.Extensions
 }
@@ -18,8 +18,8 @@ import (
 //	v2 - https://swagger.io/specification/v2/#infoObject
 //	v3 - https://spec.openapis.org/oas/v3.1.0#info-object
 type Info struct {
-	Summary        string                              `json:"summary,omitempty" yaml:"summary,omitempty"`
 	Title          string                              `json:"title,omitempty" yaml:"title,omitempty"`
+	Summary        string                              `json:"summary,omitempty" yaml:"summary,omitempty"`
 	Description    string                              `json:"description,omitempty" yaml:"description,omitempty"`
 	TermsOfService string                              `json:"termsOfService,omitempty" yaml:"termsOfService,omitempty"`
 	Contact        *Contact                            `json:"contact,omitempty" yaml:"contact,omitempty"`
@@ -70,6 +70,16 @@ func (i *Info) GoLowUntyped() any {
 	return i.low
 }
 
+// GetPosition returns the position of the Info in the source specification, or nil if it is not available.
+func (i *Info) GetPosition() *high.Position {
+	return high.GetPosition(i.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Info is defined under, or nil if it is not available.
+func (i *Info) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(i.GoLow())
+}
+
 // Render will return a YAML representation of the Info object as a byte slice.
 func (i *Info) Render() ([]byte, error) {
 	return yaml.Marshal(i)
@@ -210,3 +210,33 @@ x-cake:
 	bytes, _ := highInfo.Render()
 	assert.Len(t, bytes, 275)
 }
+
+func TestInfo_Render_Summary(t *testing.T) {
+	yml := `title: hey
+summary: a short summary
+description: there you
+version: 1.2.3
+`
+
+	// unmarshal yaml into a *yaml.Node instance
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	// build low
+	var lowInfo lowbase.Info
+	_ = lowmodel.BuildModel(cNode.Content[0], &lowInfo)
+	_ = lowInfo.Build(context.Background(), nil, cNode.Content[0], nil)
+
+	// build high
+	highInfo := NewInfo(&lowInfo)
+	assert.Equal(t, "a short summary", highInfo.Summary)
+
+	// round trip, nothing lost, nothing moved.
+	bytes, _ := highInfo.Render()
+	assert.Equal(t, yml, string(bytes))
+
+	// a summary added to a new info is rendered after the title.
+	highI := &Info{Title: "hey", Version: "1.2.3", Summary: "a short summary"}
+	bytes, _ = highI.Render()
+	assert.Equal(t, "title: hey\nsummary: a short summary\nversion: 1.2.3\n", string(bytes))
+}
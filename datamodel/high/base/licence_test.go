@@ -98,3 +98,36 @@ func TestLicense_Render_IdentifierAndURL_Error(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestLicense_Validate(t *testing.T) {
+
+	assert.NoError(t, (&License{Name: "MIT", Identifier: "MIT"}).Validate())
+	assert.NoError(t, (&License{Name: "MIT", URL: "https://pb33f.io"}).Validate())
+	assert.Error(t, (&License{Name: "MIT", Identifier: "MIT", URL: "https://pb33f.io"}).Validate())
+
+	assert.NoError(t, (&License{Name: "MIT"}).ValidateIdentifier())
+	assert.NoError(t, (&License{Identifier: "MIT OR Apache-2.0"}).ValidateIdentifier())
+	assert.Error(t, (&License{Identifier: "MIT OR"}).ValidateIdentifier())
+}
+
+func TestLicense_Render_Identifier_RoundTrip(t *testing.T) {
+
+	yml := `name: Apache 2.0
+identifier: Apache-2.0
+x-pizza: cake
+`
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	var lowLicense lowbase.License
+	_ = lowmodel.BuildModel(cNode.Content[0], &lowLicense)
+	_ = lowLicense.Build(context.Background(), nil, cNode.Content[0], nil)
+
+	highLicense := NewLicense(&lowLicense)
+	assert.Equal(t, "Apache-2.0", highLicense.Identifier)
+	assert.NoError(t, highLicense.Validate())
+	assert.NoError(t, highLicense.ValidateIdentifier())
+
+	bytes, _ := highLicense.Render()
+	assert.Equal(t, yml, string(bytes))
+}
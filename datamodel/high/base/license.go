@@ -4,6 +4,8 @@
 package base
 
 import (
+	"errors"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	low "github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/orderedmap"
@@ -39,6 +41,24 @@ func NewLicense(license *low.License) *License {
 	return l
 }
 
+// Validate will check the License is valid, a License cannot have both a URL and an Identifier (3.1), they are
+// mutually exclusive.
+func (l *License) Validate() error {
+	if l.URL != "" && l.Identifier != "" {
+		return errors.New("license cannot have both a 'url' and an 'identifier', they are mutually exclusive")
+	}
+	return nil
+}
+
+// ValidateIdentifier will check the Identifier is a well-formed SPDX license expression. An empty Identifier
+// is valid, it is optional.
+func (l *License) ValidateIdentifier() error {
+	if l.Identifier == "" {
+		return nil
+	}
+	return low.ValidateSPDXExpression(l.Identifier)
+}
+
 // GoLow will return the low-level License used to create the high-level one.
 func (l *License) GoLow() *low.License {
 	return l.low
@@ -49,6 +69,16 @@ func (l *License) GoLowUntyped() any {
 	return l.low
 }
 
+// GetPosition returns the position of the License in the source specification, or nil if it is not available.
+func (l *License) GetPosition() *high.Position {
+	return high.GetPosition(l.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the License is defined under, or nil if it is not available.
+func (l *License) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(l.GoLow())
+}
+
 // Render will return a YAML representation of the License object as a byte slice.
 func (l *License) Render() ([]byte, error) {
 	return yaml.Marshal(l)
@@ -4,12 +4,18 @@
 package base
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
 
 	"github.com/pb33f/libopenapi/datamodel/high"
 	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -74,6 +80,9 @@ type Schema struct {
 	// 3.1 only, part of the JSON Schema spec provides a way to identify a sub-schema
 	Anchor string `json:"$anchor,omitempty" yaml:"$anchor,omitempty"`
 
+	// 3.1 only, re-usable schemas local to this schema, referenced by `#/$defs/name` from within it.
+	Defs *orderedmap.Map[string, *SchemaProxy] `json:"$defs,omitempty" yaml:"$defs,omitempty"`
+
 	// Compatible with all versions
 	Not                  *SchemaProxy                          `json:"not,omitempty" yaml:"not,omitempty"`
 	Properties           *orderedmap.Map[string, *SchemaProxy] `json:"properties,omitempty" yaml:"properties,omitempty"`
@@ -373,6 +382,8 @@ func NewSchema(schema *base.Schema) *Schema {
 			s.DependentSchemas = props
 		case 2:
 			s.PatternProperties = props
+		case 3:
+			s.Defs = props
 		}
 	}
 
@@ -391,6 +402,11 @@ func NewSchema(schema *base.Schema) *Schema {
 		buildProps(name, schemaProxy, patternProps, 2)
 	}
 
+	defs := orderedmap.New[string, *SchemaProxy]()
+	for name, schemaProxy := range schema.Defs.Value.FromOldest() {
+		buildProps(name, schemaProxy, defs, 3)
+	}
+
 	var allOf []*SchemaProxy
 	var oneOf []*SchemaProxy
 	var anyOf []*SchemaProxy
@@ -457,6 +473,139 @@ func NewSchema(schema *base.Schema) *Schema {
 	return s
 }
 
+// hasRefSuffix returns true if ref ends with suffix, and the suffix starts at a path boundary, so 'foo/bar.yaml'
+// ends with 'bar.yaml', but not with 'ar.yaml'.
+func hasRefSuffix(ref, suffix string) bool {
+	if suffix == "" || !strings.HasSuffix(ref, suffix) {
+		return false
+	}
+	if len(ref) == len(suffix) || strings.ContainsAny(suffix[:1], "/\\#") {
+		return true
+	}
+	return strings.ContainsAny(ref[len(ref)-len(suffix)-1:len(ref)-len(suffix)], "/\\#")
+}
+
+// GetDiscriminatorMapping will return an ordered map of every discriminator value to the concrete SchemaProxy
+// it selects. Explicit entries in the discriminator mapping are returned first (in document order), followed by
+// any implicit mappings derived from the names of referenced oneOf / anyOf schemas.
+//
+// Mapping values can be either a reference (e.g. '#/components/schemas/Cat') or a bare schema name (e.g. 'Cat'),
+// bare names are treated as references to '#/components/schemas/<name>'. Values are references if they contain
+// a '#' or a '/', or end with a '.yaml', '.yml' or '.json' file extension. If a mapping value points to a schema
+// that is not one of the oneOf / anyOf candidates, a new SchemaProxy is created for the reference, using the
+// index of the schema to resolve it. If the schema has no index, the value is left out of the mapping.
+//
+// Returns nil if the schema has no discriminator.
+func (s *Schema) GetDiscriminatorMapping() *orderedmap.Map[string, *SchemaProxy] {
+	if s == nil || s.Discriminator == nil {
+		return nil
+	}
+	candidates := append(append([]*SchemaProxy{}, s.OneOf...), s.AnyOf...)
+
+	findCandidate := func(ref string) *SchemaProxy {
+		for _, c := range candidates {
+			if c == nil || !c.IsReference() {
+				continue
+			}
+			cRef := c.GetReference()
+			if cRef == ref || hasRefSuffix(cRef, ref) || hasRefSuffix(ref, cRef) {
+				return c
+			}
+		}
+		return nil
+	}
+
+	var idx *index.SpecIndex
+	ctx := context.Background()
+	if s.low != nil {
+		idx = s.low.GetIndex()
+		if s.low.GetContext() != nil {
+			ctx = s.low.GetContext()
+		}
+	}
+
+	mapped := orderedmap.New[string, *SchemaProxy]()
+	targeted := make(map[*SchemaProxy]bool)
+
+	for value, target := range s.Discriminator.Mapping.FromOldest() {
+		ref := target
+		if !isMappingReference(target) {
+			ref = fmt.Sprintf("#/components/schemas/%s", target)
+		}
+		if c := findCandidate(ref); c != nil {
+			targeted[c] = true
+			mapped.Set(value, c)
+			continue
+		}
+		if sp := createDiscriminatorProxy(ctx, ref, idx); sp != nil {
+			mapped.Set(value, sp)
+		}
+	}
+
+	// implicit mappings use the name of the schema being referenced.
+	for _, c := range candidates {
+		if c == nil || !c.IsReference() || targeted[c] {
+			continue
+		}
+		ref := c.GetReference()
+		name := ref[strings.LastIndex(ref, "/")+1:]
+		if name == "" {
+			continue
+		}
+		if _, ok := mapped.Get(name); !ok {
+			mapped.Set(name, c)
+		}
+	}
+	return mapped
+}
+
+// isMappingReference returns true if a discriminator mapping value is a reference, rather than the bare name of a
+// schema. References contain a '#' or a '/', or end with the extension of a file, bare names like 'Pet.v2' may
+// contain dots.
+func isMappingReference(target string) bool {
+	if strings.ContainsAny(target, "#/") {
+		return true
+	}
+	switch strings.ToLower(path.Ext(target)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// createDiscriminatorProxy builds a new SchemaProxy for a discriminator mapping reference that is not
+// present in the oneOf / anyOf candidates of the parent schema. Returns nil if there is no index to resolve
+// the reference with.
+func createDiscriminatorProxy(ctx context.Context, ref string, idx *index.SpecIndex) *SchemaProxy {
+	if idx == nil {
+		return nil
+	}
+	refNode := utils.CreateRefNode(ref)
+	sp := new(base.SchemaProxy)
+	_ = sp.Build(ctx, nil, refNode, idx)
+	n := &lowmodel.NodeReference[*base.SchemaProxy]{
+		Value:     sp,
+		ValueNode: refNode,
+	}
+	n.SetReference(ref, refNode)
+	return NewSchemaProxy(n)
+}
+
+// IsBooleanFalse returns true if the Schema is the (empty) Schema of a `false` boolean schema, which allows
+// nothing. Boolean schemas have no properties, so validators must check this before validating against the Schema.
+func (s *Schema) IsBooleanFalse() bool {
+	if s == nil {
+		return false
+	}
+	if s.ParentProxy != nil && s.ParentProxy.IsBoolean() {
+		return !s.ParentProxy.GetBoolean()
+	}
+	if s.low != nil && s.low.ParentProxy != nil && s.low.ParentProxy.IsBoolean() {
+		return !s.low.ParentProxy.GetBoolean()
+	}
+	return false
+}
+
 // GoLow will return the low-level instance of Schema that was used to create the high level one.
 func (s *Schema) GoLow() *base.Schema {
 	return s.low
@@ -467,6 +616,16 @@ func (s *Schema) GoLowUntyped() any {
 	return s.low
 }
 
+// GetPosition returns the position of the Schema in the source specification, or nil if it is not available.
+func (s *Schema) GetPosition() *high.Position {
+	return high.GetPosition(s.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Schema is defined under, or nil if it is not available.
+func (s *Schema) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(s.GoLow())
+}
+
 // Render will return a YAML representation of the Schema object as a byte slice.
 func (s *Schema) Render() ([]byte, error) {
 	return yaml.Marshal(s)
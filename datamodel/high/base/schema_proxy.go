@@ -5,6 +5,7 @@ package base
 
 import (
 	"fmt"
+	"strconv"
 	"sync"
 
 	"github.com/pb33f/libopenapi/datamodel/high"
@@ -53,6 +54,7 @@ type SchemaProxy struct {
 	buildError error
 	rendered   *Schema
 	refStr     string
+	boolean    *bool
 	lock       *sync.Mutex
 }
 
@@ -73,6 +75,14 @@ func CreateSchemaProxyRef(ref string) *SchemaProxy {
 	return &SchemaProxy{refStr: ref, lock: &sync.Mutex{}}
 }
 
+// CreateBooleanSchemaProxy will create a new high-level SchemaProxy holding a boolean schema (`true` or `false`),
+// this is used only when building out new models from scratch (3.1 only).
+func CreateBooleanSchemaProxy(value bool) *SchemaProxy {
+	sp := &SchemaProxy{boolean: &value, rendered: &Schema{}, lock: &sync.Mutex{}}
+	sp.rendered.ParentProxy = sp
+	return sp
+}
+
 // GetValueNode returns the value node of the SchemaProxy.
 func (sp *SchemaProxy) GetValueNode() *yaml.Node {
 	if sp.schema != nil {
@@ -84,59 +94,90 @@ func (sp *SchemaProxy) GetValueNode() *yaml.Node {
 // Schema will create a new Schema instance using NewSchema from the low-level SchemaProxy backing this high-level one.
 // If there is a problem building the Schema, then this method will return nil. Use GetBuildError to gain access
 // to that building error.
+//
+// Schema is safe to call from multiple goroutines. The Schema is only built once, goroutines that call Schema while
+// it's being built wait for it, and then receive the same Schema. A failed build is not retried either, every call
+// returns nil and the same build error.
 func (sp *SchemaProxy) Schema() *Schema {
 	if sp == nil || sp.lock == nil {
 		return nil
 	}
 	sp.lock.Lock()
-	if sp.rendered == nil {
-
-		//check the high-level cache first.
-		idx := sp.schema.Value.GetIndex()
-		if idx != nil && sp.schema.Value != nil {
-			if sp.schema.Value.IsReference() && sp.schema.Value.GetReferenceNode() != nil && sp.schema.GetValueNode() != nil {
-				loc := fmt.Sprintf("%s:%d:%d", idx.GetSpecAbsolutePath(), sp.schema.GetValueNode().Line, sp.schema.GetValueNode().Column)
-				if seen, ok := idx.GetHighCache().Load(loc); ok {
-					sp.lock.Unlock()
-					idx.HighCacheHit()
-					return seen.(*Schema)
-				} else {
-					idx.HighCacheMiss()
-				}
+	defer sp.lock.Unlock()
+	if sp.rendered != nil || sp.buildError != nil {
+		return sp.rendered
+	}
+
+	//check the high-level cache first.
+	idx := sp.schema.Value.GetIndex()
+	if idx != nil && sp.schema.Value != nil {
+		if sp.schema.Value.IsReference() && sp.schema.Value.GetReferenceNode() != nil && sp.schema.GetValueNode() != nil {
+			loc := fmt.Sprintf("%s:%d:%d", idx.GetSpecAbsolutePath(), sp.schema.GetValueNode().Line, sp.schema.GetValueNode().Column)
+			if seen, ok := idx.GetHighCache().Load(loc); ok {
+				idx.HighCacheHit()
+				sp.rendered = seen.(*Schema)
+				return sp.rendered
+			} else {
+				idx.HighCacheMiss()
 			}
 		}
+	}
 
-		s := sp.schema.Value.Schema()
-		if s == nil {
-			sp.buildError = sp.schema.Value.GetBuildError()
-			sp.lock.Unlock()
-			return nil
-		}
-		sch := NewSchema(s)
+	s := sp.schema.Value.Schema()
+	if s == nil {
+		sp.buildError = sp.schema.Value.GetBuildError()
+		return nil
+	}
+	sch := NewSchema(s)
 
-		if idx != nil {
+	if idx != nil {
 
-			// only store the schema in the cache if is a reference!
-			if sp.IsReference() && sp.GetReferenceNode() != nil && sp.schema != nil && sp.schema.GetValueNode() != nil {
-				//if sp.schema.GetValueNode() != nil {
-				loc := fmt.Sprintf("%s:%d:%d", idx.GetSpecAbsolutePath(), sp.schema.GetValueNode().Line, sp.schema.GetValueNode().Column)
+		// only store the schema in the cache if is a reference!
+		if sp.IsReference() && sp.GetReferenceNode() != nil && sp.schema != nil && sp.schema.GetValueNode() != nil {
+			loc := fmt.Sprintf("%s:%d:%d", idx.GetSpecAbsolutePath(), sp.schema.GetValueNode().Line, sp.schema.GetValueNode().Column)
 
-				// caching is only performed on traditional $ref nodes with a reference and a value node, any 3.1 additional
-				// will not be cached as libopenapi does not yet support them.
-				if len(sp.GetReferenceNode().Content) == 2 {
-					idx.GetHighCache().Store(loc, sch)
-				}
+			// caching is only performed on traditional $ref nodes with a reference and a value node, any 3.1 additional
+			// will not be cached as libopenapi does not yet support them.
+			if len(sp.GetReferenceNode().Content) == 2 {
+				idx.GetHighCache().Store(loc, sch)
 			}
 		}
+	}
 
-		sch.ParentProxy = sp
-		sp.rendered = sch
-		sp.lock.Unlock()
-		return sch
-	} else {
-		sp.lock.Unlock()
-		return sp.rendered
+	sch.ParentProxy = sp
+	sp.rendered = sch
+	return sch
+}
+
+// IsBoolean returns true if the SchemaProxy holds a boolean schema (`true` or `false`) instead of a Schema. A `true`
+// schema allows anything, a `false` schema allows nothing. Boolean schemas have no properties, calling Schema()
+// on a boolean SchemaProxy returns an empty Schema, use IsBooleanFalse on it to tell a `false` schema apart.
+func (sp *SchemaProxy) IsBoolean() bool {
+	if sp == nil {
+		return false
+	}
+	if sp.boolean != nil {
+		return true
 	}
+	if sp.schema != nil && sp.schema.Value != nil {
+		return sp.schema.Value.IsBoolean()
+	}
+	return false
+}
+
+// GetBoolean returns the value of a boolean schema, false is returned if the SchemaProxy does not hold a
+// boolean schema, use IsBoolean to check first.
+func (sp *SchemaProxy) GetBoolean() bool {
+	if sp == nil {
+		return false
+	}
+	if sp.boolean != nil {
+		return *sp.boolean
+	}
+	if sp.schema != nil && sp.schema.Value != nil {
+		return sp.schema.Value.GetBoolean()
+	}
+	return false
 }
 
 // IsReference returns true if the SchemaProxy is a reference to another Schema.
@@ -187,16 +228,17 @@ func (sp *SchemaProxy) GetReferenceOrigin() *index.NodeOrigin {
 
 // BuildSchema operates the same way as Schema, except it will return any error along with the *Schema
 func (sp *SchemaProxy) BuildSchema() (*Schema, error) {
-	if sp.rendered != nil {
-		return sp.rendered, sp.buildError
-	}
 	schema := sp.Schema()
-	er := sp.buildError
-	return schema, er
+	return schema, sp.GetBuildError()
 }
 
 // GetBuildError returns any error that was thrown when calling Schema()
 func (sp *SchemaProxy) GetBuildError() error {
+	if sp == nil || sp.lock == nil {
+		return nil
+	}
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
 	return sp.buildError
 }
 
@@ -214,6 +256,16 @@ func (sp *SchemaProxy) GoLowUntyped() any {
 	return sp.schema.Value
 }
 
+// GetPosition returns the position of the SchemaProxy in the source specification, or nil if it is not available.
+func (sp *SchemaProxy) GetPosition() *high.Position {
+	return high.GetPosition(sp.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the SchemaProxy is defined under, or nil if it is not available.
+func (sp *SchemaProxy) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(sp.GoLow())
+}
+
 // Render will return a YAML representation of the Schema object as a byte slice.
 func (sp *SchemaProxy) Render() ([]byte, error) {
 	return yaml.Marshal(sp)
@@ -225,6 +277,9 @@ func (sp *SchemaProxy) MarshalYAML() (interface{}, error) {
 	var err error
 	// if this schema isn't a reference, then build it out.
 	if !sp.IsReference() {
+		if sp.IsBoolean() {
+			return utils.CreateBoolNode(strconv.FormatBool(sp.GetBoolean())), nil
+		}
 		s, err = sp.BuildSchema()
 		if err != nil {
 			return nil, err
@@ -245,6 +300,9 @@ func (sp *SchemaProxy) MarshalYAML() (interface{}, error) {
 // MarshalYAMLInline will create a ready to render YAML representation of the SchemaProxy object. The
 // $ref values will be inlined instead of kept as is.
 func (sp *SchemaProxy) MarshalYAMLInline() (interface{}, error) {
+	if sp.IsBoolean() {
+		return utils.CreateBoolNode(strconv.FormatBool(sp.GetBoolean())), nil
+	}
 	var s *Schema
 	var err error
 	s, err = sp.BuildSchema()
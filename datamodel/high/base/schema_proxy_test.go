@@ -6,6 +6,7 @@ package base
 import (
 	"context"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -188,3 +189,119 @@ components:
 	rend, _ := sp.MarshalYAMLInline()
 	assert.NotNil(t, rend)
 }
+
+func TestSchemaProxy_Boolean(t *testing.T) {
+	yml := `type: object
+properties:
+  anything: true
+  nothing: false
+allOf:
+  - true
+  - type: string
+not: false
+items: true`
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &node)
+
+	var lowSchema lowbase.Schema
+	_ = low.BuildModel(node.Content[0], &lowSchema)
+	require.NoError(t, lowSchema.Build(context.Background(), node.Content[0], nil))
+	sch := NewSchema(&lowSchema)
+
+	anything := sch.Properties.GetOrZero("anything")
+	assert.True(t, anything.IsBoolean())
+	assert.True(t, anything.GetBoolean())
+	assert.NotNil(t, anything.Schema())
+
+	nothing := sch.Properties.GetOrZero("nothing")
+	assert.True(t, nothing.IsBoolean())
+	assert.False(t, nothing.GetBoolean())
+
+	assert.True(t, sch.AllOf[0].IsBoolean())
+	assert.False(t, sch.AllOf[1].IsBoolean())
+	assert.True(t, sch.Not.IsBoolean())
+
+	rendered, err := sch.Render()
+	require.NoError(t, err)
+	assert.Equal(t, `type: object
+properties:
+    anything: true
+    nothing: false
+allOf:
+    - true
+    - type: string
+not: false
+items: true`, strings.TrimSpace(string(rendered)))
+
+	inline, err := sch.RenderInline()
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(rendered)), strings.TrimSpace(string(inline)))
+}
+
+func TestCreateBooleanSchemaProxy(t *testing.T) {
+	sp := CreateBooleanSchemaProxy(false)
+	assert.True(t, sp.IsBoolean())
+	assert.False(t, sp.GetBoolean())
+	assert.False(t, sp.IsReference())
+	assert.NotNil(t, sp.Schema())
+
+	rendered, err := sp.Render()
+	require.NoError(t, err)
+	assert.Equal(t, "false", strings.TrimSpace(string(rendered)))
+
+	parent := CreateSchemaProxy(&Schema{Not: sp, AllOf: []*SchemaProxy{CreateBooleanSchemaProxy(true)}})
+	rendered, err = parent.Render()
+	require.NoError(t, err)
+	assert.Equal(t, "allOf:\n    - true\nnot: false", strings.TrimSpace(string(rendered)))
+
+	assert.False(t, CreateSchemaProxy(&Schema{}).IsBoolean())
+	assert.False(t, CreateSchemaProxyRef("#/pizza").GetBoolean())
+	var empty *SchemaProxy
+	assert.False(t, empty.IsBoolean())
+	assert.False(t, empty.GetBoolean())
+}
+
+func TestSchemaProxy_Schema_Concurrent(t *testing.T) {
+	const ymlComponents = `components:
+  schemas:
+    rice:
+      type: string
+    nice:
+      properties:
+        rice:
+          $ref: '#/components/schemas/rice'`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(ymlComponents), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(`$ref: '#/components/schemas/nice'`), &node)
+
+	lowProxy := new(lowbase.SchemaProxy)
+	require.NoError(t, lowProxy.Build(context.Background(), nil, node.Content[0], idx))
+	sp := NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{
+		Value:     lowProxy,
+		ValueNode: node.Content[0],
+	})
+
+	var wg sync.WaitGroup
+	schemas := make([]*Schema, 20)
+	for i := range schemas {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			schemas[i], _ = sp.BuildSchema()
+		}(i)
+	}
+	wg.Wait()
+
+	// every goroutine receives the same schema, which was only built once.
+	require.NotNil(t, schemas[0])
+	for _, s := range schemas {
+		assert.Same(t, schemas[0], s)
+	}
+	assert.Same(t, sp, schemas[0].ParentProxy)
+	assert.NoError(t, sp.GetBuildError())
+}
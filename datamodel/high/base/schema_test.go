@@ -14,6 +14,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/low"
 	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
@@ -844,6 +845,41 @@ allOf:
 	assert.Equal(t, testSpec, string(schemaBytes))
 }
 
+func TestNewSchemaProxy_RenderSchema_Defs(t *testing.T) {
+	testSpec := `type: object
+properties:
+    pet:
+        $ref: '#/$defs/Pet'
+$defs:
+    Pet:
+        type: string
+        description: a pet
+`
+
+	var compNode yaml.Node
+	_ = yaml.Unmarshal([]byte(testSpec), &compNode)
+	idx := index.NewSpecIndex(&compNode)
+
+	sp := new(lowbase.SchemaProxy)
+	err := sp.Build(context.Background(), nil, compNode.Content[0], idx)
+	assert.NoError(t, err)
+
+	lowproxy := low.NodeReference[*lowbase.SchemaProxy]{
+		Value:     sp,
+		ValueNode: compNode.Content[0],
+	}
+
+	compiled := NewSchemaProxy(&lowproxy).Schema()
+	assert.NotNil(t, compiled)
+	assert.Equal(t, 1, compiled.Defs.Len())
+	assert.Equal(t, "a pet", compiled.Defs.GetOrZero("Pet").Schema().Description)
+	assert.Equal(t, "#/$defs/Pet", compiled.Properties.GetOrZero("pet").GetReference())
+
+	// now render it out, it should be identical.
+	schemaBytes, _ := compiled.Render()
+	assert.Equal(t, testSpec, string(schemaBytes))
+}
+
 func TestNewSchemaProxy_RenderSchema_JSON(t *testing.T) {
 	testSpec := `type: object
 description: something object
@@ -1372,3 +1408,135 @@ func TestSchema_RenderProxyWithConfig_Corrected_3(t *testing.T) {
 	schemaBytes, _ = compiled.RenderInline()
 	assert.Equal(t, testSpecCorrect, strings.TrimSpace(string(schemaBytes)))
 }
+
+func TestSchema_GetDiscriminatorMapping(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Lizard'
+      discriminator:
+        propertyName: petType
+        mapping:
+          dog: '#/components/schemas/Dog'
+          kitty: Cat
+          fish: '#/components/schemas/Fish'
+    Cat:
+      type: object
+      description: meow
+    Dog:
+      type: object
+      description: woof
+    Lizard:
+      type: object
+      description: hiss
+    Fish:
+      type: object
+      description: blub`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	pet := idx.FindComponent("#/components/schemas/Pet")
+	assert.NotNil(t, pet)
+
+	sp := new(lowbase.SchemaProxy)
+	err := sp.Build(context.Background(), nil, pet.Node, idx)
+	assert.NoError(t, err)
+
+	schema := NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{
+		Value:     sp,
+		ValueNode: pet.Node,
+	}).Schema()
+	assert.NotNil(t, schema)
+
+	mapping := schema.GetDiscriminatorMapping()
+	assert.Equal(t, 4, mapping.Len())
+
+	var keys []string
+	for k := range mapping.KeysFromOldest() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"dog", "kitty", "fish", "Lizard"}, keys)
+
+	assert.Same(t, schema.OneOf[1], mapping.GetOrZero("dog"))
+	assert.Same(t, schema.OneOf[0], mapping.GetOrZero("kitty"))
+	assert.Same(t, schema.OneOf[2], mapping.GetOrZero("Lizard"))
+
+	fish := mapping.GetOrZero("fish")
+	assert.Equal(t, "#/components/schemas/Fish", fish.GetReference())
+	assert.Equal(t, "blub", fish.Schema().Description)
+	assert.Equal(t, "woof", mapping.GetOrZero("dog").Schema().Description)
+}
+
+func TestSchema_GetDiscriminatorMapping_NoDiscriminator(t *testing.T) {
+	s := &Schema{}
+	assert.Nil(t, s.GetDiscriminatorMapping())
+}
+
+func TestSchema_GetDiscriminatorMapping_NoIndex(t *testing.T) {
+	s := &Schema{
+		AnyOf: []*SchemaProxy{CreateSchemaProxyRef("#/components/schemas/Cat")},
+		Discriminator: &Discriminator{
+			PropertyName: "petType",
+			Mapping: orderedmap.ToOrderedMap(map[string]string{
+				"dog": "Dog",
+			}),
+		},
+	}
+	// without an index, 'Dog' cannot be resolved, so it is left out.
+	mapping := s.GetDiscriminatorMapping()
+	assert.Equal(t, 1, mapping.Len())
+	_, ok := mapping.Get("dog")
+	assert.False(t, ok)
+	assert.Same(t, s.AnyOf[0], mapping.GetOrZero("Cat"))
+}
+
+func TestSchema_GetDiscriminatorMapping_DottedName(t *testing.T) {
+	s := &Schema{
+		OneOf: []*SchemaProxy{
+			CreateSchemaProxyRef("#/components/schemas/Pet.v2"),
+			CreateSchemaProxyRef("pets/cat.yaml"),
+		},
+		Discriminator: &Discriminator{
+			PropertyName: "petType",
+			Mapping: orderedmap.ToOrderedMap(map[string]string{
+				"pet": "Pet.v2",
+				"cat": "cat.yaml",
+			}),
+		},
+	}
+	mapping := s.GetDiscriminatorMapping()
+	assert.Equal(t, 2, mapping.Len())
+	assert.Same(t, s.OneOf[0], mapping.GetOrZero("pet"))
+	assert.Same(t, s.OneOf[1], mapping.GetOrZero("cat"))
+
+	assert.False(t, isMappingReference("Pet.v2"))
+	assert.True(t, isMappingReference("cat.YML"))
+	assert.True(t, isMappingReference("#/components/schemas/Pet"))
+}
+
+func TestSchema_GetDiscriminatorMapping_PathBoundary(t *testing.T) {
+	s := &Schema{
+		OneOf: []*SchemaProxy{CreateSchemaProxyRef("xbar.yaml"), CreateSchemaProxyRef("models/bar.yaml")},
+		Discriminator: &Discriminator{
+			PropertyName: "petType",
+			Mapping: orderedmap.ToOrderedMap(map[string]string{
+				"bar": "foo/bar.yaml",
+			}),
+		},
+	}
+	// 'foo/bar.yaml' is not a candidate, and there is no index to resolve it with.
+	mapping := s.GetDiscriminatorMapping()
+	_, ok := mapping.Get("bar")
+	assert.False(t, ok)
+
+	assert.True(t, hasRefSuffix("models/bar.yaml", "bar.yaml"))
+	assert.True(t, hasRefSuffix("bar.yaml#/Cat", "#/Cat"))
+	assert.False(t, hasRefSuffix("foo/xbar.yaml", "bar.yaml"))
+	assert.False(t, hasRefSuffix("bar.yaml", ""))
+}
@@ -4,6 +4,7 @@
 package base
 
 import (
+	"github.com/pb33f/libopenapi/datamodel/high"
 	"sort"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -54,6 +55,16 @@ func (s *SecurityRequirement) GoLowUntyped() any {
 	return s.low
 }
 
+// GetPosition returns the position of the SecurityRequirement in the source specification, or nil if it is not available.
+func (s *SecurityRequirement) GetPosition() *high.Position {
+	return high.GetPosition(s.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the SecurityRequirement is defined under, or nil if it is not available.
+func (s *SecurityRequirement) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(s.GoLow())
+}
+
 // Render will return a YAML representation of the SecurityRequirement object as a byte slice.
 func (s *SecurityRequirement) Render() ([]byte, error) {
 	return yaml.Marshal(s)
@@ -51,6 +51,16 @@ func (t *Tag) GoLowUntyped() any {
 	return t.low
 }
 
+// GetPosition returns the position of the Tag in the source specification, or nil if it is not available.
+func (t *Tag) GetPosition() *high.Position {
+	return high.GetPosition(t.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Tag is defined under, or nil if it is not available.
+func (t *Tag) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(t.GoLow())
+}
+
 // Render will return a YAML representation of the Info object as a byte slice.
 func (t *Tag) Render() ([]byte, error) {
 	return yaml.Marshal(t)
@@ -4,6 +4,9 @@
 package base
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	low "github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/orderedmap"
@@ -21,15 +24,27 @@ import (
 //	v2 - https://swagger.io/specification/v2/#xmlObject
 //	v3 - https://swagger.io/specification/#xml-object
 type XML struct {
-	Name       string `json:"name,omitempty" yaml:"name,omitempty"`
-	Namespace  string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
-	Prefix     string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
-	Attribute  bool   `json:"attribute,omitempty" yaml:"attribute,omitempty"`
-	Wrapped    bool   `json:"wrapped,omitempty" yaml:"wrapped,omitempty"`
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Prefix    string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Attribute bool   `json:"attribute,omitempty" yaml:"attribute,omitempty"`
+	Wrapped   bool   `json:"wrapped,omitempty" yaml:"wrapped,omitempty"`
+	// NodeType is one of `element`, `attribute`, `text`, `cdata` or `none` (3.2+), it replaces Attribute and
+	// Wrapped, use ResolveNodeType to take those into account for older documents.
+	NodeType   string `json:"nodeType,omitempty" yaml:"nodeType,omitempty"`
 	Extensions *orderedmap.Map[string, *yaml.Node]
 	low        *low.XML
 }
 
+// XML node types, used by XML.NodeType
+const (
+	XMLNodeTypeElement   = "element"
+	XMLNodeTypeAttribute = "attribute"
+	XMLNodeTypeText      = "text"
+	XMLNodeTypeCDATA     = "cdata"
+	XMLNodeTypeNone      = "none"
+)
+
 // NewXML creates a new high-level XML instance from a low-level one.
 func NewXML(xml *low.XML) *XML {
 	x := new(XML)
@@ -39,10 +54,28 @@ func NewXML(xml *low.XML) *XML {
 	x.Prefix = xml.Prefix.Value
 	x.Attribute = xml.Attribute.Value
 	x.Wrapped = xml.Wrapped.Value
+	x.NodeType = xml.NodeType.Value
 	x.Extensions = high.ExtractExtensions(xml.Extensions)
 	return x
 }
 
+// ResolveNodeType returns the type of XML node the schema holding this XML object maps to. If NodeType is set,
+// it is returned. Otherwise, it is worked out from Attribute and Wrapped, which is how documents before 3.2
+// describe the same thing: an attribute is an `attribute`, a wrapped array is an `element` and an array that
+// is not wrapped is `none` (its items are not wrapped by an element). Everything else is an `element`.
+func (x *XML) ResolveNodeType(isArray bool) string {
+	if x.NodeType != "" {
+		return x.NodeType
+	}
+	if x.Attribute {
+		return XMLNodeTypeAttribute
+	}
+	if isArray && !x.Wrapped {
+		return XMLNodeTypeNone
+	}
+	return XMLNodeTypeElement
+}
+
 // GoLow returns the low level XML reference used to create the high level one.
 func (x *XML) GoLow() *low.XML {
 	return x.low
@@ -53,13 +86,49 @@ func (x *XML) GoLowUntyped() any {
 	return x.low
 }
 
+// GetPosition returns the position of the XML in the source specification, or nil if it is not available.
+func (x *XML) GetPosition() *high.Position {
+	return high.GetPosition(x.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the XML is defined under, or nil if it is not available.
+func (x *XML) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(x.GoLow())
+}
+
 // Render will return a YAML representation of the XML object as a byte slice.
 func (x *XML) Render() ([]byte, error) {
 	return yaml.Marshal(x)
 }
 
-// MarshalYAML will create a ready to render YAML representation of the XML object.
+// MarshalYAML will create a ready to render YAML representation of the XML object. NodeType is only rendered
+// for OpenAPI 3.2+ documents (or when the version of the document is not known).
 func (x *XML) MarshalYAML() (interface{}, error) {
+	if x.NodeType != "" && !x.supportsNodeType() {
+		c := *x
+		c.NodeType = ""
+		return high.NewNodeBuilder(&c, x.low).Render(), nil
+	}
 	nb := high.NewNodeBuilder(x, x.low)
 	return nb.Render(), nil
 }
+
+// supportsNodeType returns false if the XML object belongs to a document older than OpenAPI 3.2, which has no
+// nodeType. If the version of the document is not known (the model was not built from a document), it returns true.
+func (x *XML) supportsNodeType() bool {
+	if x.low == nil || x.low.GetIndex() == nil {
+		return true
+	}
+	config := x.low.GetIndex().GetConfig()
+	if config == nil || config.SpecInfo == nil || config.SpecInfo.Version == "" {
+		return true
+	}
+	major, rest, _ := strings.Cut(config.SpecInfo.Version, ".")
+	minor, _, _ := strings.Cut(rest, ".")
+	ma, err := strconv.Atoi(major)
+	if err != nil {
+		return true
+	}
+	mi, _ := strconv.Atoi(minor)
+	return ma > 3 || (ma == 3 && mi >= 2)
+}
@@ -5,8 +5,10 @@ package base
 
 import (
 	"fmt"
+	"github.com/pb33f/libopenapi/datamodel"
 	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
 	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 	"strings"
@@ -72,3 +74,70 @@ wrapped: true`
 	assert.NotEqual(t, yml, strings.TrimSpace(string(highXMLBytes)))
 
 }
+
+func TestXML_Render_NodeType(t *testing.T) {
+
+	yml := `name: pizza
+namespace: https://pb33f.io/schema
+nodeType: cdata`
+
+	// unmarshal raw bytes
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &node)
+
+	// build out the low-level model
+	var lowXML lowbase.XML
+	_ = lowmodel.BuildModel(node.Content[0], &lowXML)
+	_ = lowXML.Build(node.Content[0], nil)
+
+	highXML := NewXML(&lowXML)
+	assert.Equal(t, XMLNodeTypeCDATA, highXML.NodeType)
+	assert.Equal(t, 3, lowXML.NodeType.ValueNode.Line)
+
+	highXMLBytes, _ := highXML.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(highXMLBytes)))
+
+	// new models render the node type too.
+	highXML = &XML{Name: "pizza", NodeType: XMLNodeTypeText}
+	highXMLBytes, _ = highXML.Render()
+	assert.Equal(t, "name: pizza\nnodeType: text", strings.TrimSpace(string(highXMLBytes)))
+}
+
+func TestXML_Render_NodeType_Version(t *testing.T) {
+	yml := `name: pizza
+nodeType: cdata`
+
+	build := func(version string) *XML {
+		var node yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &node)
+		config := index.CreateOpenAPIIndexConfig()
+		config.SpecInfo = &datamodel.SpecInfo{Version: version}
+		idx := index.NewSpecIndexWithConfig(&node, config)
+		var lowXML lowbase.XML
+		_ = lowmodel.BuildModel(node.Content[0], &lowXML)
+		_ = lowXML.Build(node.Content[0], idx)
+		return NewXML(&lowXML)
+	}
+
+	// nodeType is not a part of documents before 3.2
+	for _, version := range []string{"2.0", "3.0.3", "3.1.0"} {
+		highXML := build(version)
+		assert.Equal(t, XMLNodeTypeCDATA, highXML.NodeType)
+		highXMLBytes, _ := highXML.Render()
+		assert.Equal(t, "name: pizza", strings.TrimSpace(string(highXMLBytes)), version)
+	}
+
+	for _, version := range []string{"3.2.0", "3.2", ""} {
+		highXMLBytes, _ := build(version).Render()
+		assert.Equal(t, yml, strings.TrimSpace(string(highXMLBytes)), version)
+	}
+}
+
+func TestXML_ResolveNodeType(t *testing.T) {
+	assert.Equal(t, XMLNodeTypeElement, (&XML{}).ResolveNodeType(false))
+	assert.Equal(t, XMLNodeTypeNone, (&XML{}).ResolveNodeType(true))
+	assert.Equal(t, XMLNodeTypeElement, (&XML{Wrapped: true}).ResolveNodeType(true))
+	assert.Equal(t, XMLNodeTypeAttribute, (&XML{Attribute: true}).ResolveNodeType(false))
+	assert.Equal(t, XMLNodeTypeText, (&XML{Attribute: true, NodeType: XMLNodeTypeText}).ResolveNodeType(false))
+	assert.Equal(t, XMLNodeTypeElement, (&XML{NodeType: XMLNodeTypeElement}).ResolveNodeType(true))
+}
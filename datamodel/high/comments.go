@@ -0,0 +1,97 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// RestoreComments will re-apply the head, line and foot comments of an original yaml.Node tree to a rendered tree.
+//
+// Nodes are matched by their location, mapping keys by name and sequence items by position. Comments of a key are
+// restored if the key is still rendered. Comments of a scalar value are only restored if the value is unmodified,
+// and sequence items are only matched if the sequence still holds the same number of items. Nodes that already
+// have a comment keep it.
+//
+// If the original is a document node with comments and the rendered tree is not, the rendered tree is wrapped in a
+// document node holding them. The rendered tree is modified in place and returned.
+func RestoreComments(rendered, original *yaml.Node) *yaml.Node {
+	if rendered == nil || original == nil {
+		return rendered
+	}
+	root := rendered
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return rendered
+		}
+		root = root.Content[0]
+	}
+	if original.Kind == yaml.DocumentNode {
+		if len(original.Content) == 0 {
+			return rendered
+		}
+		if original.HeadComment != "" || original.FootComment != "" {
+			if rendered.Kind != yaml.DocumentNode {
+				rendered = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}
+			}
+			copyComments(rendered, original)
+		}
+		original = original.Content[0]
+	}
+	restoreComments(root, original)
+	return rendered
+}
+
+func restoreComments(rendered, original *yaml.Node) {
+	if rendered == nil || original == nil {
+		return
+	}
+	if original.Kind == yaml.AliasNode {
+		// the comments of the anchored node belong to the anchor, not to every alias of it.
+		if rendered.Kind == yaml.AliasNode {
+			copyComments(rendered, original)
+		}
+		return
+	}
+	if rendered.Kind != original.Kind {
+		return
+	}
+	switch rendered.Kind {
+	case yaml.ScalarNode:
+		if rendered.Value == original.Value {
+			copyComments(rendered, original)
+		}
+	case yaml.MappingNode:
+		copyComments(rendered, original)
+		for i := 0; i+1 < len(rendered.Content); i += 2 {
+			for j := 0; j+1 < len(original.Content); j += 2 {
+				if rendered.Content[i].Value == original.Content[j].Value {
+					copyComments(rendered.Content[i], original.Content[j])
+					restoreComments(rendered.Content[i+1], original.Content[j+1])
+					break
+				}
+			}
+		}
+	case yaml.SequenceNode:
+		copyComments(rendered, original)
+		if len(rendered.Content) != len(original.Content) {
+			return
+		}
+		for i := range rendered.Content {
+			restoreComments(rendered.Content[i], original.Content[i])
+		}
+	}
+}
+
+func copyComments(rendered, original *yaml.Node) {
+	if rendered.HeadComment == "" {
+		rendered.HeadComment = original.HeadComment
+	}
+	if rendered.LineComment == "" {
+		rendered.LineComment = original.LineComment
+	}
+	if rendered.FootComment == "" {
+		rendered.FootComment = original.FootComment
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRestoreComments(t *testing.T) {
+	original := `# the top
+name: pizza # the name
+# the toppings
+toppings:
+    - cheese # cheesy
+    - ham
+size: large # changed
+# the end`
+
+	var source yaml.Node
+	_ = yaml.Unmarshal([]byte(original), &source)
+
+	// a rendered version of the document, without comments and with 'size' modified.
+	var rendered yaml.Node
+	_ = yaml.Unmarshal([]byte("name: pizza\ntoppings:\n    - cheese\n    - ham\nsize: small"), &rendered)
+
+	out, _ := yaml.Marshal(RestoreComments(rendered.Content[0], &source))
+
+	expected := `# the top
+name: pizza # the name
+# the toppings
+toppings:
+    - cheese # cheesy
+    - ham
+size: small
+# the end`
+	assert.Equal(t, expected, strings.TrimSpace(string(out)))
+}
+
+func TestRestoreComments_ModifiedSequence(t *testing.T) {
+	var source yaml.Node
+	_ = yaml.Unmarshal([]byte("a: &a\n  - b # first\nc: *a # alias"), &source)
+
+	var rendered yaml.Node
+	_ = yaml.Unmarshal([]byte("a:\n  - b\n  - d\nc:\n  - b"), &rendered)
+
+	RestoreComments(&rendered, &source)
+	out, _ := yaml.Marshal(&rendered)
+
+	// the sequence was modified, so the items can't be matched. The alias was expanded, so its comment is dropped.
+	assert.Equal(t, "a:\n    - b\n    - d\nc:\n    - b", strings.TrimSpace(string(out)))
+	assert.Nil(t, RestoreComments(nil, nil))
+}
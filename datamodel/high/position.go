@@ -0,0 +1,69 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"reflect"
+
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// Position represents the location of an object in the source specification.
+type Position struct {
+	// File is the absolute path (or URL) of the file the object was read from, if known.
+	File   string
+	Line   int
+	Column int
+}
+
+// GetPosition returns the Position of the root (value) node of a low-level model, or nil if the model has no root
+// node available. All high-level models use this to implement GetPosition.
+func GetPosition(lowModel any) *Position {
+	if isNil(lowModel) {
+		return nil
+	}
+	var node *yaml.Node
+	switch l := lowModel.(type) {
+	case interface{ GetRootNode() *yaml.Node }:
+		node = l.GetRootNode()
+	case interface{ GetValueNode() *yaml.Node }:
+		node = l.GetValueNode()
+	}
+	return newPosition(lowModel, node)
+}
+
+// GetKeyPosition returns the Position of the key node of a low-level model (the key the object is defined under)
+// or nil if the model has no key node available. All high-level models use this to implement GetKeyPosition.
+func GetKeyPosition(lowModel any) *Position {
+	if isNil(lowModel) {
+		return nil
+	}
+	var node *yaml.Node
+	if l, ok := lowModel.(interface{ GetKeyNode() *yaml.Node }); ok {
+		node = l.GetKeyNode()
+	}
+	return newPosition(lowModel, node)
+}
+
+func newPosition(lowModel any, node *yaml.Node) *Position {
+	if node == nil {
+		return nil
+	}
+	p := &Position{Line: node.Line, Column: node.Column}
+	if l, ok := lowModel.(interface{ GetIndex() *index.SpecIndex }); ok {
+		if idx := l.GetIndex(); idx != nil {
+			p.File = idx.GetSpecAbsolutePath()
+		}
+	}
+	return p
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
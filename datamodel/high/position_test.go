@@ -0,0 +1,57 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type positionTestModel struct {
+	key, root *yaml.Node
+	idx       *index.SpecIndex
+}
+
+func (p *positionTestModel) GetKeyNode() *yaml.Node     { return p.key }
+func (p *positionTestModel) GetRootNode() *yaml.Node    { return p.root }
+func (p *positionTestModel) GetIndex() *index.SpecIndex { return p.idx }
+
+type positionValueTestModel struct {
+	value *yaml.Node
+}
+
+func (p *positionValueTestModel) GetValueNode() *yaml.Node { return p.value }
+
+func TestGetPosition(t *testing.T) {
+	idx := index.NewSpecIndexWithConfig(&yaml.Node{}, &index.SpecIndexConfig{SpecAbsolutePath: "/tmp/spec.yaml"})
+	m := &positionTestModel{
+		key:  &yaml.Node{Line: 3, Column: 5},
+		root: &yaml.Node{Line: 4, Column: 7},
+		idx:  idx,
+	}
+
+	assert.Equal(t, &Position{File: "/tmp/spec.yaml", Line: 4, Column: 7}, GetPosition(m))
+	assert.Equal(t, &Position{File: "/tmp/spec.yaml", Line: 3, Column: 5}, GetKeyPosition(m))
+
+	m.idx = nil
+	m.key = nil
+	assert.Equal(t, &Position{Line: 4, Column: 7}, GetPosition(m))
+	assert.Nil(t, GetKeyPosition(m))
+
+	v := &positionValueTestModel{value: &yaml.Node{Line: 9, Column: 1}}
+	assert.Equal(t, &Position{Line: 9, Column: 1}, GetPosition(v))
+	assert.Nil(t, GetKeyPosition(v))
+}
+
+func TestGetPosition_Nil(t *testing.T) {
+	var m *positionTestModel
+	assert.Nil(t, GetPosition(m))
+	assert.Nil(t, GetKeyPosition(m))
+	assert.Nil(t, GetPosition(nil))
+	assert.Nil(t, GetKeyPosition(nil))
+	assert.Nil(t, GetPosition("not a model"))
+}
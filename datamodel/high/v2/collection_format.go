@@ -0,0 +1,208 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Collection formats that can be used by a Swagger Parameter or Items object with a type of 'array'.
+//   - https://swagger.io/specification/v2/#parameterCollectionFormat
+const (
+	CollectionFormatCSV   = "csv"   // comma separated values foo,bar.
+	CollectionFormatSSV   = "ssv"   // space separated values foo bar.
+	CollectionFormatTSV   = "tsv"   // tab separated values foo\tbar.
+	CollectionFormatPipes = "pipes" // pipe separated values foo|bar.
+	CollectionFormatMulti = "multi" // multiple parameter instances foo=bar&foo=baz.
+)
+
+var collectionSeparators = map[string]string{
+	CollectionFormatCSV:   ",",
+	CollectionFormatSSV:   " ",
+	CollectionFormatTSV:   "\t",
+	CollectionFormatPipes: "|",
+}
+
+// ValidateCollectionFormat will check a collectionFormat can be used by a parameter in the supplied location
+// ('query', 'formData', 'path' or 'header'). An empty format is valid and means 'csv'. The 'multi' format is
+// only valid for query and formData parameters, an empty location is used for an Items object, where 'multi'
+// is never valid.
+func ValidateCollectionFormat(format, in string) error {
+	switch format {
+	case "", CollectionFormatCSV, CollectionFormatSSV, CollectionFormatTSV, CollectionFormatPipes:
+		return nil
+	case CollectionFormatMulti:
+		if in == "query" || in == "formData" {
+			return nil
+		}
+		if in == "" {
+			return errors.New("collectionFormat 'multi' cannot be used by items, only by query or formData parameters")
+		}
+		return fmt.Errorf("collectionFormat 'multi' cannot be used by a '%s' parameter, "+
+			"only by query or formData parameters", in)
+	}
+	return fmt.Errorf("collectionFormat '%s' is not valid, it must be one of csv, ssv, tsv, pipes or multi", format)
+}
+
+// SerializeCollection will serialize array values using a collectionFormat. Every format other than 'multi' joins
+// the values into a single value, 'multi' keeps each value separate, to be sent as a parameter instance each.
+func SerializeCollection(format string, values []string) ([]string, error) {
+	if format == CollectionFormatMulti {
+		return append([]string(nil), values...), nil
+	}
+	sep, err := collectionSeparator(format)
+	if err != nil {
+		return nil, err
+	}
+	return []string{strings.Join(values, sep)}, nil
+}
+
+// DeserializeCollection will split raw parameter values back into array values using a collectionFormat. Every
+// format other than 'multi' expects a single raw value, 'multi' expects one raw value per parameter instance.
+func DeserializeCollection(format string, raw []string) ([]string, error) {
+	if format == CollectionFormatMulti {
+		return append([]string(nil), raw...), nil
+	}
+	sep, err := collectionSeparator(format)
+	if err != nil {
+		return nil, err
+	}
+	switch len(raw) {
+	case 0:
+		return nil, nil
+	case 1:
+		if raw[0] == "" {
+			return []string{}, nil
+		}
+		return strings.Split(raw[0], sep), nil
+	}
+	return nil, fmt.Errorf("collectionFormat '%s' expects a single value, %d values supplied, "+
+		"only 'multi' supports multiple values", effectiveCollectionFormat(format), len(raw))
+}
+
+// CollectionFormatStyle returns the OpenAPI 3 style and explode values that serialize array values the same way
+// as a collectionFormat does, for a parameter in the supplied location. Form parameters are serialized as they would
+// be in a query. There is no equivalent of 'tsv' in OpenAPI 3, so an error is returned for it.
+func CollectionFormatStyle(format, in string) (style string, explode bool, err error) {
+	if err = ValidateCollectionFormat(format, in); err != nil {
+		return "", false, err
+	}
+	switch format {
+	case CollectionFormatMulti:
+		return "form", true, nil
+	case CollectionFormatSSV:
+		return "spaceDelimited", false, nil
+	case CollectionFormatPipes:
+		return "pipeDelimited", false, nil
+	case CollectionFormatTSV:
+		return "", false, errors.New("collectionFormat 'tsv' has no equivalent style in OpenAPI 3")
+	}
+	if in == "path" || in == "header" {
+		return "simple", false, nil
+	}
+	return "form", false, nil
+}
+
+func collectionSeparator(format string) (string, error) {
+	sep, ok := collectionSeparators[effectiveCollectionFormat(format)]
+	if !ok {
+		return "", ValidateCollectionFormat(format, "")
+	}
+	return sep, nil
+}
+
+func effectiveCollectionFormat(format string) string {
+	if format == "" {
+		return CollectionFormatCSV
+	}
+	return format
+}
+
+// EffectiveCollectionFormat returns the collectionFormat used by the Parameter, which is 'csv' when none is set.
+func (p *Parameter) EffectiveCollectionFormat() string {
+	return effectiveCollectionFormat(p.CollectionFormat)
+}
+
+// ValidateCollectionFormat will check the collectionFormat of the Parameter, and every level of Items it holds,
+// can be used. All problems found are returned.
+func (p *Parameter) ValidateCollectionFormat() error {
+	var errs []error
+	if p.CollectionFormat != "" && p.Type != "array" {
+		errs = append(errs, fmt.Errorf("parameter '%s' has a collectionFormat, but is not an array", p.Name))
+	}
+	if err := ValidateCollectionFormat(p.CollectionFormat, p.In); err != nil {
+		errs = append(errs, fmt.Errorf("parameter '%s': %w", p.Name, err))
+	}
+	if p.Items != nil {
+		if err := p.Items.ValidateCollectionFormat(); err != nil {
+			errs = append(errs, fmt.Errorf("parameter '%s' items: %w", p.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SerializeValues will serialize array values for the Parameter using its collectionFormat.
+// See SerializeCollection.
+func (p *Parameter) SerializeValues(values []string) ([]string, error) {
+	return SerializeCollection(p.CollectionFormat, values)
+}
+
+// DeserializeValues will split raw values sent for the Parameter using its collectionFormat.
+// See DeserializeCollection.
+func (p *Parameter) DeserializeValues(raw []string) ([]string, error) {
+	return DeserializeCollection(p.CollectionFormat, raw)
+}
+
+// StyleAndExplode returns the OpenAPI 3 style and explode values equivalent to the collectionFormat of the
+// Parameter. See CollectionFormatStyle.
+func (p *Parameter) StyleAndExplode() (string, bool, error) {
+	return CollectionFormatStyle(p.CollectionFormat, p.In)
+}
+
+// EffectiveCollectionFormat returns the collectionFormat used by the Items, which is 'csv' when none is set.
+func (i *Items) EffectiveCollectionFormat() string {
+	return effectiveCollectionFormat(i.CollectionFormat)
+}
+
+// ValidateCollectionFormat will check the collectionFormat of the Items, and every level of Items it holds,
+// can be used. Items can never use 'multi'. All problems found are returned.
+func (i *Items) ValidateCollectionFormat() error {
+	var errs []error
+	if i.CollectionFormat != "" && i.Type != "array" {
+		errs = append(errs, errors.New("items have a collectionFormat, but are not an array"))
+	}
+	if err := ValidateCollectionFormat(i.CollectionFormat, ""); err != nil {
+		errs = append(errs, err)
+	}
+	if i.Items != nil {
+		if err := i.Items.ValidateCollectionFormat(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SerializeValues will serialize nested array values for the Items using its collectionFormat.
+// See SerializeCollection.
+func (i *Items) SerializeValues(values []string) (string, error) {
+	if err := ValidateCollectionFormat(i.CollectionFormat, ""); err != nil {
+		return "", err
+	}
+	s, err := SerializeCollection(i.CollectionFormat, values)
+	if err != nil {
+		return "", err
+	}
+	return s[0], nil
+}
+
+// DeserializeValues will split a single value of a parameter into nested array values, using the
+// collectionFormat of the Items. See DeserializeCollection.
+func (i *Items) DeserializeValues(raw string) ([]string, error) {
+	if err := ValidateCollectionFormat(i.CollectionFormat, ""); err != nil {
+		return nil, err
+	}
+	return DeserializeCollection(i.CollectionFormat, []string{raw})
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializeCollection(t *testing.T) {
+	values := []string{"a", "b", "c"}
+	for format, expected := range map[string][]string{
+		"":                    {"a,b,c"},
+		CollectionFormatCSV:   {"a,b,c"},
+		CollectionFormatSSV:   {"a b c"},
+		CollectionFormatTSV:   {"a\tb\tc"},
+		CollectionFormatPipes: {"a|b|c"},
+		CollectionFormatMulti: {"a", "b", "c"},
+	} {
+		serialized, err := SerializeCollection(format, values)
+		require.NoError(t, err)
+		assert.Equal(t, expected, serialized, format)
+
+		deserialized, err := DeserializeCollection(format, serialized)
+		require.NoError(t, err)
+		assert.Equal(t, values, deserialized, format)
+	}
+
+	_, err := SerializeCollection("cheese", values)
+	assert.EqualError(t, err, "collectionFormat 'cheese' is not valid, it must be one of csv, ssv, tsv, pipes or multi")
+}
+
+func TestDeserializeCollection(t *testing.T) {
+	v, err := DeserializeCollection(CollectionFormatCSV, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = DeserializeCollection(CollectionFormatCSV, []string{""})
+	assert.NoError(t, err)
+	assert.Empty(t, v)
+
+	_, err = DeserializeCollection("", []string{"a", "b"})
+	assert.EqualError(t, err, "collectionFormat 'csv' expects a single value, 2 values supplied, "+
+		"only 'multi' supports multiple values")
+}
+
+func TestCollectionFormatStyle(t *testing.T) {
+	tests := []struct {
+		format, in, style string
+		explode           bool
+	}{
+		{"", "query", "form", false},
+		{CollectionFormatCSV, "formData", "form", false},
+		{CollectionFormatCSV, "path", "simple", false},
+		{CollectionFormatCSV, "header", "simple", false},
+		{CollectionFormatMulti, "query", "form", true},
+		{CollectionFormatSSV, "query", "spaceDelimited", false},
+		{CollectionFormatPipes, "query", "pipeDelimited", false},
+	}
+	for _, tc := range tests {
+		style, explode, err := CollectionFormatStyle(tc.format, tc.in)
+		require.NoError(t, err)
+		assert.Equal(t, tc.style, style, tc.format)
+		assert.Equal(t, tc.explode, explode, tc.format)
+	}
+
+	_, _, err := CollectionFormatStyle(CollectionFormatTSV, "query")
+	assert.EqualError(t, err, "collectionFormat 'tsv' has no equivalent style in OpenAPI 3")
+	_, _, err = CollectionFormatStyle(CollectionFormatMulti, "path")
+	assert.EqualError(t, err, "collectionFormat 'multi' cannot be used by a 'path' parameter, "+
+		"only by query or formData parameters")
+}
+
+func TestParameter_CollectionFormat(t *testing.T) {
+	p := &Parameter{
+		Name:             "ids",
+		In:               "query",
+		Type:             "array",
+		CollectionFormat: CollectionFormatMulti,
+		Items: &Items{
+			Type:             "array",
+			CollectionFormat: CollectionFormatPipes,
+			Items:            &Items{Type: "integer"},
+		},
+	}
+	assert.NoError(t, p.ValidateCollectionFormat())
+	assert.Equal(t, CollectionFormatMulti, p.EffectiveCollectionFormat())
+
+	raw, err := p.SerializeValues([]string{"1|2", "3"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1|2", "3"}, raw)
+
+	values, err := p.DeserializeValues(raw)
+	require.NoError(t, err)
+	nested, err := p.Items.DeserializeValues(values[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, nested)
+
+	joined, err := p.Items.SerializeValues(nested)
+	require.NoError(t, err)
+	assert.Equal(t, "1|2", joined)
+
+	style, explode, err := p.StyleAndExplode()
+	require.NoError(t, err)
+	assert.Equal(t, "form", style)
+	assert.True(t, explode)
+
+	p.In = "header"
+	p.Items.CollectionFormat = CollectionFormatMulti
+	p.Items.Items.CollectionFormat = CollectionFormatCSV
+	assert.EqualError(t, p.ValidateCollectionFormat(), "parameter 'ids': collectionFormat 'multi' cannot be "+
+		"used by a 'header' parameter, only by query or formData parameters\n"+
+		"parameter 'ids' items: collectionFormat 'multi' cannot be used by items, only by query or formData "+
+		"parameters\nitems have a collectionFormat, but are not an array")
+
+	_, err = p.Items.SerializeValues([]string{"a"})
+	assert.Error(t, err)
+	_, err = p.Items.DeserializeValues("a")
+	assert.Error(t, err)
+}
+
+func TestItems_EffectiveCollectionFormat(t *testing.T) {
+	i := &Items{Type: "array"}
+	assert.Equal(t, CollectionFormatCSV, i.EffectiveCollectionFormat())
+	assert.Equal(t, CollectionFormatCSV, (&Parameter{}).EffectiveCollectionFormat())
+	assert.NoError(t, i.ValidateCollectionFormat())
+}
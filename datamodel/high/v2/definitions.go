@@ -5,11 +5,13 @@ package v2
 
 import (
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high"
 	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
 	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
 	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
 	low "github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
 )
 
 // Definitions is a high-level represents of a Swagger / OpenAPI 2 Definitions object, backed by a low-level one.
@@ -18,7 +20,7 @@ import (
 // arrays or models.
 //   - https://swagger.io/specification/v2/#definitionsObject
 type Definitions struct {
-	Definitions *orderedmap.Map[string, *highbase.SchemaProxy]
+	Definitions *orderedmap.Map[string, *highbase.SchemaProxy] `json:"-" yaml:"-"`
 	low         *low.Definitions
 }
 
@@ -48,3 +50,24 @@ func NewDefinitions(definitions *low.Definitions) *Definitions {
 func (d *Definitions) GoLow() *low.Definitions {
 	return d.low
 }
+
+// GetPosition returns the position of the Definitions in the source specification, or nil if it is not available.
+func (d *Definitions) GetPosition() *high.Position {
+	return high.GetPosition(d.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Definitions is defined under, or nil if it is not available.
+func (d *Definitions) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(d.GoLow())
+}
+
+// Render will return a YAML representation of the Definitions object as a byte slice.
+func (d *Definitions) Render() ([]byte, error) {
+	return yaml.Marshal(d)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Definitions object.
+func (d *Definitions) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(d, d.low)
+	return renderMap(nb, d.Definitions, d.low), nil
+}
@@ -4,6 +4,7 @@
 package v2
 
 import (
+	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	lowv2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/orderedmap"
@@ -14,7 +15,7 @@ import (
 // Allows sharing examples for operation responses
 //   - https://swagger.io/specification/v2/#exampleObject
 type Example struct {
-	Values *orderedmap.Map[string, *yaml.Node]
+	Values *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low    *lowv2.Examples
 }
 
@@ -32,3 +33,24 @@ func NewExample(examples *lowv2.Examples) *Example {
 func (e *Example) GoLow() *lowv2.Examples {
 	return e.low
 }
+
+// GetPosition returns the position of the Example in the source specification, or nil if it is not available.
+func (e *Example) GetPosition() *high.Position {
+	return high.GetPosition(e.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Example is defined under, or nil if it is not available.
+func (e *Example) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(e.GoLow())
+}
+
+// Render will return a YAML representation of the Example object as a byte slice.
+func (e *Example) Render() ([]byte, error) {
+	return yaml.Marshal(e)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Example object.
+func (e *Example) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(e, e.low)
+	return renderMap(nb, e.Values, e.low), nil
+}
@@ -14,25 +14,25 @@ import (
 // A Header is essentially identical to a Parameter, except it does not contain 'name' or 'in' properties.
 //   - https://swagger.io/specification/v2/#headerObject
 type Header struct {
-	Type             string
-	Format           string
-	Description      string
-	Items            *Items
-	CollectionFormat string
-	Default          any
-	Maximum          int
-	ExclusiveMaximum bool
-	Minimum          int
-	ExclusiveMinimum bool
-	MaxLength        int
-	MinLength        int
-	Pattern          string
-	MaxItems         int
-	MinItems         int
-	UniqueItems      bool
-	Enum             []any
-	MultipleOf       int
-	Extensions       *orderedmap.Map[string, *yaml.Node]
+	Type             string                              `json:"type,omitempty" yaml:"type,omitempty"`
+	Format           string                              `json:"format,omitempty" yaml:"format,omitempty"`
+	Description      string                              `json:"description,omitempty" yaml:"description,omitempty"`
+	Items            *Items                              `json:"items,omitempty" yaml:"items,omitempty"`
+	CollectionFormat string                              `json:"collectionFormat,omitempty" yaml:"collectionFormat,omitempty"`
+	Default          any                                 `json:"default,omitempty" yaml:"default,omitempty"`
+	Maximum          *int                                `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	ExclusiveMaximum *bool                               `json:"exclusiveMaximum,renderZero,omitempty" yaml:"exclusiveMaximum,renderZero,omitempty"`
+	Minimum          *int                                `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	ExclusiveMinimum *bool                               `json:"exclusiveMinimum,renderZero,omitempty" yaml:"exclusiveMinimum,renderZero,omitempty"`
+	MaxLength        *int                                `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinLength        *int                                `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	Pattern          string                              `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	MaxItems         *int                                `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	MinItems         *int                                `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	UniqueItems      *bool                               `json:"uniqueItems,renderZero,omitempty" yaml:"uniqueItems,renderZero,omitempty"`
+	Enum             []*yaml.Node                        `json:"enum,omitempty" yaml:"enum,omitempty"`
+	MultipleOf       *int                                `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	Extensions       *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low              *low.Header
 }
 
@@ -45,7 +45,7 @@ func NewHeader(header *low.Header) *Header {
 		h.Type = header.Type.Value
 	}
 	if !header.Format.IsEmpty() {
-		h.Format = header.Type.Value
+		h.Format = header.Format.Value
 	}
 	if !header.Description.IsEmpty() {
 		h.Description = header.Description.Value
@@ -60,44 +60,44 @@ func NewHeader(header *low.Header) *Header {
 		h.Default = header.Default.Value
 	}
 	if !header.Maximum.IsEmpty() {
-		h.Maximum = header.Maximum.Value
+		h.Maximum = &header.Maximum.Value
 	}
 	if !header.ExclusiveMaximum.IsEmpty() {
-		h.ExclusiveMaximum = header.ExclusiveMaximum.Value
+		h.ExclusiveMaximum = &header.ExclusiveMaximum.Value
 	}
 	if !header.Minimum.IsEmpty() {
-		h.Minimum = header.Minimum.Value
+		h.Minimum = &header.Minimum.Value
 	}
-	if !header.ExclusiveMinimum.Value {
-		h.ExclusiveMinimum = header.ExclusiveMinimum.Value
+	if !header.ExclusiveMinimum.IsEmpty() {
+		h.ExclusiveMinimum = &header.ExclusiveMinimum.Value
 	}
 	if !header.MaxLength.IsEmpty() {
-		h.MaxLength = header.MaxLength.Value
+		h.MaxLength = &header.MaxLength.Value
 	}
 	if !header.MinLength.IsEmpty() {
-		h.MinLength = header.MinLength.Value
+		h.MinLength = &header.MinLength.Value
 	}
 	if !header.Pattern.IsEmpty() {
 		h.Pattern = header.Pattern.Value
 	}
 	if !header.MinItems.IsEmpty() {
-		h.MinItems = header.MinItems.Value
+		h.MinItems = &header.MinItems.Value
 	}
 	if !header.MaxItems.IsEmpty() {
-		h.MaxItems = header.MaxItems.Value
+		h.MaxItems = &header.MaxItems.Value
 	}
 	if !header.UniqueItems.IsEmpty() {
-		h.UniqueItems = header.UniqueItems.IsEmpty()
+		h.UniqueItems = &header.UniqueItems.Value
 	}
 	if !header.Enum.IsEmpty() {
-		var enums []any
+		var enums []*yaml.Node
 		for e := range header.Enum.Value {
 			enums = append(enums, header.Enum.Value[e].Value)
 		}
 		h.Enum = enums
 	}
 	if !header.MultipleOf.IsEmpty() {
-		h.MultipleOf = header.MultipleOf.Value
+		h.MultipleOf = &header.MultipleOf.Value
 	}
 	return h
 }
@@ -106,3 +106,24 @@ func NewHeader(header *low.Header) *Header {
 func (h *Header) GoLow() *low.Header {
 	return h.low
 }
+
+// GetPosition returns the position of the Header in the source specification, or nil if it is not available.
+func (h *Header) GetPosition() *high.Position {
+	return high.GetPosition(h.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Header is defined under, or nil if it is not available.
+func (h *Header) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(h.GoLow())
+}
+
+// Render will return a YAML representation of the Header object as a byte slice.
+func (h *Header) Render() ([]byte, error) {
+	return yaml.Marshal(h)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Header object.
+func (h *Header) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(h, h.low)
+	return nb.Render(), nil
+}
@@ -4,7 +4,9 @@
 package v2
 
 import (
+	"github.com/pb33f/libopenapi/datamodel/high"
 	low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"gopkg.in/yaml.v3"
 )
 
@@ -13,23 +15,24 @@ import (
 // located in "body"
 //   - https://swagger.io/specification/v2/#itemsObject
 type Items struct {
-	Type             string
-	Format           string
-	CollectionFormat string
-	Items            *Items
-	Default          *yaml.Node
-	Maximum          int
-	ExclusiveMaximum bool
-	Minimum          int
-	ExclusiveMinimum bool
-	MaxLength        int
-	MinLength        int
-	Pattern          string
-	MaxItems         int
-	MinItems         int
-	UniqueItems      bool
-	Enum             []*yaml.Node
-	MultipleOf       int
+	Type             string                              `json:"type,omitempty" yaml:"type,omitempty"`
+	Format           string                              `json:"format,omitempty" yaml:"format,omitempty"`
+	CollectionFormat string                              `json:"collectionFormat,omitempty" yaml:"collectionFormat,omitempty"`
+	Items            *Items                              `json:"items,omitempty" yaml:"items,omitempty"`
+	Default          *yaml.Node                          `json:"default,omitempty" yaml:"default,omitempty"`
+	Maximum          *int                                `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	ExclusiveMaximum *bool                               `json:"exclusiveMaximum,renderZero,omitempty" yaml:"exclusiveMaximum,renderZero,omitempty"`
+	Minimum          *int                                `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	ExclusiveMinimum *bool                               `json:"exclusiveMinimum,renderZero,omitempty" yaml:"exclusiveMinimum,renderZero,omitempty"`
+	MaxLength        *int                                `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinLength        *int                                `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	Pattern          string                              `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	MaxItems         *int                                `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	MinItems         *int                                `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	UniqueItems      *bool                               `json:"uniqueItems,renderZero,omitempty" yaml:"uniqueItems,renderZero,omitempty"`
+	Enum             []*yaml.Node                        `json:"enum,omitempty" yaml:"enum,omitempty"`
+	MultipleOf       *int                                `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	Extensions       *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low              *low.Items
 }
 
@@ -37,6 +40,7 @@ type Items struct {
 func NewItems(items *low.Items) *Items {
 	i := new(Items)
 	i.low = items
+	i.Extensions = high.ExtractExtensions(items.Extensions)
 	if !items.Type.IsEmpty() {
 		i.Type = items.Type.Value
 	}
@@ -53,34 +57,34 @@ func NewItems(items *low.Items) *Items {
 		i.Default = items.Default.Value
 	}
 	if !items.Maximum.IsEmpty() {
-		i.Maximum = items.Maximum.Value
+		i.Maximum = &items.Maximum.Value
 	}
 	if !items.ExclusiveMaximum.IsEmpty() {
-		i.ExclusiveMaximum = items.ExclusiveMaximum.Value
+		i.ExclusiveMaximum = &items.ExclusiveMaximum.Value
 	}
 	if !items.Minimum.IsEmpty() {
-		i.Minimum = items.Minimum.Value
+		i.Minimum = &items.Minimum.Value
 	}
 	if !items.ExclusiveMinimum.IsEmpty() {
-		i.ExclusiveMinimum = items.ExclusiveMinimum.Value
+		i.ExclusiveMinimum = &items.ExclusiveMinimum.Value
 	}
 	if !items.MaxLength.IsEmpty() {
-		i.MaxLength = items.MaxLength.Value
+		i.MaxLength = &items.MaxLength.Value
 	}
 	if !items.MinLength.IsEmpty() {
-		i.MinLength = items.MinLength.Value
+		i.MinLength = &items.MinLength.Value
 	}
 	if !items.Pattern.IsEmpty() {
 		i.Pattern = items.Pattern.Value
 	}
 	if !items.MinItems.IsEmpty() {
-		i.MinItems = items.MinItems.Value
+		i.MinItems = &items.MinItems.Value
 	}
 	if !items.MaxItems.IsEmpty() {
-		i.MaxItems = items.MaxItems.Value
+		i.MaxItems = &items.MaxItems.Value
 	}
 	if !items.UniqueItems.IsEmpty() {
-		i.UniqueItems = items.UniqueItems.Value
+		i.UniqueItems = &items.UniqueItems.Value
 	}
 	if !items.Enum.IsEmpty() {
 		var enums []*yaml.Node
@@ -90,7 +94,7 @@ func NewItems(items *low.Items) *Items {
 		i.Enum = enums
 	}
 	if !items.MultipleOf.IsEmpty() {
-		i.MultipleOf = items.MultipleOf.Value
+		i.MultipleOf = &items.MultipleOf.Value
 	}
 	return i
 }
@@ -99,3 +103,24 @@ func NewItems(items *low.Items) *Items {
 func (i *Items) GoLow() *low.Items {
 	return i.low
 }
+
+// GetPosition returns the position of the Items in the source specification, or nil if it is not available.
+func (i *Items) GetPosition() *high.Position {
+	return high.GetPosition(i.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Items is defined under, or nil if it is not available.
+func (i *Items) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(i.GoLow())
+}
+
+// Render will return a YAML representation of the Items object as a byte slice.
+func (i *Items) Render() ([]byte, error) {
+	return yaml.Marshal(i)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Items object.
+func (i *Items) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(i, i.low)
+	return nb.Render(), nil
+}
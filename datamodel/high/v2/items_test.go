@@ -0,0 +1,86 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowV2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewItems(t *testing.T) {
+	yml := `type: integer
+format: int32
+collectionFormat: csv
+default: 0
+maximum: 0
+exclusiveMaximum: false
+minimum: 1
+exclusiveMinimum: true
+maxLength: 10
+minLength: 0
+pattern: '^[0-9]+$'
+maxItems: 5
+minItems: 0
+uniqueItems: false
+multipleOf: 2
+enum: [0, 2, 4]
+x-pizza: party
+items:
+  type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n lowV2.Items
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	i := NewItems(&n)
+	assert.Equal(t, "integer", i.Type)
+	assert.Equal(t, "int32", i.Format)
+	assert.Equal(t, "csv", i.CollectionFormat)
+	assert.Equal(t, "!!int", i.Default.Tag)
+	assert.Equal(t, 0, *i.Maximum)
+	assert.False(t, *i.ExclusiveMaximum)
+	assert.Equal(t, 1, *i.Minimum)
+	assert.True(t, *i.ExclusiveMinimum)
+	assert.Equal(t, 10, *i.MaxLength)
+	assert.Equal(t, 0, *i.MinLength)
+	assert.Equal(t, "^[0-9]+$", i.Pattern)
+	assert.Equal(t, 5, *i.MaxItems)
+	assert.Equal(t, 0, *i.MinItems)
+	assert.False(t, *i.UniqueItems)
+	assert.Equal(t, 2, *i.MultipleOf)
+	assert.Len(t, i.Enum, 3)
+	assert.Equal(t, "string", i.Items.Type)
+	assert.Equal(t, "party", i.Extensions.GetOrZero("x-pizza").Value)
+	assert.Equal(t, &n, i.GoLow())
+}
+
+func TestNewItems_Absent(t *testing.T) {
+	yml := `type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n lowV2.Items
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	i := NewItems(&n)
+	assert.Nil(t, i.Maximum)
+	assert.Nil(t, i.ExclusiveMaximum)
+	assert.Nil(t, i.MultipleOf)
+	assert.Nil(t, i.UniqueItems)
+	assert.Nil(t, i.Default)
+	assert.Nil(t, i.Items)
+}
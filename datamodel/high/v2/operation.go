@@ -15,19 +15,19 @@ import (
 // It describes a single API operation on a path.
 //   - https://swagger.io/specification/v2/#operationObject
 type Operation struct {
-	Tags         []string
-	Summary      string
-	Description  string
-	ExternalDocs *base.ExternalDoc
-	OperationId  string
-	Consumes     []string
-	Produces     []string
-	Parameters   []*Parameter
-	Responses    *Responses
-	Schemes      []string
-	Deprecated   bool
-	Security     []*base.SecurityRequirement
-	Extensions   *orderedmap.Map[string, *yaml.Node]
+	Tags         []string                            `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Summary      string                              `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description  string                              `json:"description,omitempty" yaml:"description,omitempty"`
+	ExternalDocs *base.ExternalDoc                   `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	OperationId  string                              `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Consumes     []string                            `json:"consumes,omitempty" yaml:"consumes,omitempty"`
+	Produces     []string                            `json:"produces,omitempty" yaml:"produces,omitempty"`
+	Parameters   []*Parameter                        `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Responses    *Responses                          `json:"responses,omitempty" yaml:"responses,omitempty"`
+	Schemes      []string                            `json:"schemes,omitempty" yaml:"schemes,omitempty"`
+	Deprecated   bool                                `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Security     []*base.SecurityRequirement         `json:"security,omitempty" yaml:"security,omitempty"`
+	Extensions   *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low          *low.Operation
 }
 
@@ -56,14 +56,14 @@ func NewOperation(operation *low.Operation) *Operation {
 		o.OperationId = operation.OperationId.Value
 	}
 	if !operation.Consumes.IsEmpty() {
-		var cons []string
+		cons := make([]string, 0, len(operation.Consumes.Value))
 		for c := range operation.Consumes.Value {
 			cons = append(cons, operation.Consumes.Value[c].Value)
 		}
 		o.Consumes = cons
 	}
 	if !operation.Produces.IsEmpty() {
-		var prods []string
+		prods := make([]string, 0, len(operation.Produces.Value))
 		for p := range operation.Produces.Value {
 			prods = append(prods, operation.Produces.Value[p].Value)
 		}
@@ -103,3 +103,24 @@ func NewOperation(operation *low.Operation) *Operation {
 func (o *Operation) GoLow() *low.Operation {
 	return o.low
 }
+
+// GetPosition returns the position of the Operation in the source specification, or nil if it is not available.
+func (o *Operation) GetPosition() *high.Position {
+	return high.GetPosition(o.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Operation is defined under, or nil if it is not available.
+func (o *Operation) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(o.GoLow())
+}
+
+// Render will return a YAML representation of the Operation object as a byte slice.
+func (o *Operation) Render() ([]byte, error) {
+	return yaml.Marshal(o)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Operation object.
+func (o *Operation) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(o, o.low)
+	return nb.Render(), nil
+}
@@ -53,30 +53,30 @@ import (
 //
 // https://swagger.io/specification/v2/#parameterObject
 type Parameter struct {
-	Name             string
-	In               string
-	Type             string
-	Format           string
-	Description      string
-	Required         *bool
-	AllowEmptyValue  *bool
-	Schema           *base.SchemaProxy
-	Items            *Items
-	CollectionFormat string
-	Default          *yaml.Node
-	Maximum          *int
-	ExclusiveMaximum *bool
-	Minimum          *int
-	ExclusiveMinimum *bool
-	MaxLength        *int
-	MinLength        *int
-	Pattern          string
-	MaxItems         *int
-	MinItems         *int
-	UniqueItems      *bool
-	Enum             []*yaml.Node
-	MultipleOf       *int
-	Extensions       *orderedmap.Map[string, *yaml.Node]
+	Name             string                              `json:"name,omitempty" yaml:"name,omitempty"`
+	In               string                              `json:"in,omitempty" yaml:"in,omitempty"`
+	Type             string                              `json:"type,omitempty" yaml:"type,omitempty"`
+	Format           string                              `json:"format,omitempty" yaml:"format,omitempty"`
+	Description      string                              `json:"description,omitempty" yaml:"description,omitempty"`
+	Required         *bool                               `json:"required,renderZero,omitempty" yaml:"required,renderZero,omitempty"`
+	AllowEmptyValue  *bool                               `json:"allowEmptyValue,renderZero,omitempty" yaml:"allowEmptyValue,renderZero,omitempty"`
+	Schema           *base.SchemaProxy                   `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Items            *Items                              `json:"items,omitempty" yaml:"items,omitempty"`
+	CollectionFormat string                              `json:"collectionFormat,omitempty" yaml:"collectionFormat,omitempty"`
+	Default          *yaml.Node                          `json:"default,omitempty" yaml:"default,omitempty"`
+	Maximum          *int                                `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	ExclusiveMaximum *bool                               `json:"exclusiveMaximum,renderZero,omitempty" yaml:"exclusiveMaximum,renderZero,omitempty"`
+	Minimum          *int                                `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	ExclusiveMinimum *bool                               `json:"exclusiveMinimum,renderZero,omitempty" yaml:"exclusiveMinimum,renderZero,omitempty"`
+	MaxLength        *int                                `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinLength        *int                                `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	Pattern          string                              `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	MaxItems         *int                                `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	MinItems         *int                                `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	UniqueItems      *bool                               `json:"uniqueItems,renderZero,omitempty" yaml:"uniqueItems,renderZero,omitempty"`
+	Enum             []*yaml.Node                        `json:"enum,omitempty" yaml:"enum,omitempty"`
+	MultipleOf       *int                                `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	Extensions       *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low              *low.Parameter
 }
 
@@ -165,3 +165,24 @@ func NewParameter(parameter *low.Parameter) *Parameter {
 func (p *Parameter) GoLow() *low.Parameter {
 	return p.low
 }
+
+// GetPosition returns the position of the Parameter in the source specification, or nil if it is not available.
+func (p *Parameter) GetPosition() *high.Position {
+	return high.GetPosition(p.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Parameter is defined under, or nil if it is not available.
+func (p *Parameter) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(p.GoLow())
+}
+
+// Render will return a YAML representation of the Parameter object as a byte slice.
+func (p *Parameter) Render() ([]byte, error) {
+	return yaml.Marshal(p)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Parameter object.
+func (p *Parameter) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(p, p.low)
+	return nb.Render(), nil
+}
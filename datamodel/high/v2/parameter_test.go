@@ -0,0 +1,76 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowV2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewParameter_EnumTypes(t *testing.T) {
+	yml := `name: thing
+in: query
+type: integer
+enum:
+  - 1
+  - true
+  - hello
+  - pizza: party
+  - [1, 2]
+items:
+  type: integer
+  enum: [1, 2.5, false]`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n lowV2.Parameter
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	p := NewParameter(&n)
+	assert.Len(t, p.Enum, 5)
+	assert.Equal(t, "!!int", p.Enum[0].Tag)
+	assert.Equal(t, "!!bool", p.Enum[1].Tag)
+	assert.Equal(t, "!!str", p.Enum[2].Tag)
+	assert.Equal(t, yaml.MappingNode, p.Enum[3].Kind)
+	assert.Equal(t, yaml.SequenceNode, p.Enum[4].Kind)
+
+	var obj map[string]string
+	_ = p.Enum[3].Decode(&obj)
+	assert.Equal(t, "party", obj["pizza"])
+
+	assert.Len(t, p.Items.Enum, 3)
+	assert.Equal(t, "!!float", p.Items.Enum[1].Tag)
+	assert.Equal(t, "!!bool", p.Items.Enum[2].Tag)
+}
+
+func TestNewHeader_EnumTypes(t *testing.T) {
+	yml := `type: integer
+enum:
+  - 1
+  - false
+  - pizza: party`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n lowV2.Header
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	h := NewHeader(&n)
+	assert.Len(t, h.Enum, 3)
+	assert.Equal(t, "1", h.Enum[0].Value)
+	assert.Equal(t, "!!bool", h.Enum[1].Tag)
+	assert.Equal(t, yaml.MappingNode, h.Enum[2].Kind)
+}
@@ -14,8 +14,8 @@ import (
 
 // Paths represents a high-level Swagger / OpenAPI Paths object, backed by a low-level one.
 type Paths struct {
-	PathItems  *orderedmap.Map[string, *PathItem]
-	Extensions *orderedmap.Map[string, *yaml.Node]
+	PathItems  *orderedmap.Map[string, *PathItem]  `json:"-" yaml:"-"`
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low        *v2low.Paths
 }
 
@@ -47,3 +47,24 @@ func NewPaths(paths *v2low.Paths) *Paths {
 func (p *Paths) GoLow() *v2low.Paths {
 	return p.low
 }
+
+// GetPosition returns the position of the Paths in the source specification, or nil if it is not available.
+func (p *Paths) GetPosition() *high.Position {
+	return high.GetPosition(p.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Paths is defined under, or nil if it is not available.
+func (p *Paths) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(p.GoLow())
+}
+
+// Render will return a YAML representation of the Paths object as a byte slice.
+func (p *Paths) Render() ([]byte, error) {
+	return yaml.Marshal(p)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Paths object.
+func (p *Paths) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(p, p.low)
+	return renderMap(nb, p.PathItems, p.low), nil
+}
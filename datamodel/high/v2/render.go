@@ -0,0 +1,61 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+type hasValueNode interface {
+	GetValueNode() *yaml.Node
+}
+
+// renderMap will render an ordered map held by a Swagger container object (paths, definitions, responses etc.)
+// as a YAML mapping node with no wrapping key. Anything else the NodeBuilder holds for the container, such as
+// extensions or a default response, is rendered alongside the map entries. Entries that exist in the original
+// document keep their original position, new entries are added to the end.
+func renderMap[V any](nb *high.NodeBuilder, m *orderedmap.Map[string, V], l any) *yaml.Node {
+	if l != nil && reflect.ValueOf(l).IsNil() {
+		l = nil
+	}
+	rendered := m.ToYamlNode(nb, l)
+	rendered.Content = append(rendered.Content, nb.Render().Content...)
+
+	var root *yaml.Node
+	if vn, ok := l.(hasValueNode); ok {
+		root = vn.GetValueNode()
+	}
+	if root == nil || root.Kind != yaml.MappingNode {
+		return rendered
+	}
+	positions := make(map[string]int, len(root.Content)/2)
+	for i := 0; i < len(root.Content)-1; i += 2 {
+		positions[root.Content[i].Value] = i
+	}
+	type entry struct {
+		key, value *yaml.Node
+		pos        int
+	}
+	entries := make([]entry, 0, len(rendered.Content)/2)
+	for i := 0; i < len(rendered.Content)-1; i += 2 {
+		pos, ok := positions[rendered.Content[i].Value]
+		if !ok {
+			pos = len(root.Content)
+		}
+		entries = append(entries, entry{rendered.Content[i], rendered.Content[i+1], pos})
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		return a.pos - b.pos
+	})
+	rendered.Content = rendered.Content[:0]
+	for _, e := range entries {
+		rendered.Content = append(rendered.Content, e.key, e.value)
+	}
+	return rendered
+}
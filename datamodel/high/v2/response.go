@@ -16,11 +16,11 @@ import (
 // Response describes a single response from an API Operation
 //   - https://swagger.io/specification/v2/#responseObject
 type Response struct {
-	Description string
-	Schema      *base.SchemaProxy
-	Headers     *orderedmap.Map[string, *Header]
-	Examples    *Example
-	Extensions  *orderedmap.Map[string, *yaml.Node]
+	Description string                              `json:"description,omitempty" yaml:"description,omitempty"`
+	Schema      *base.SchemaProxy                   `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Headers     *orderedmap.Map[string, *Header]    `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Examples    *Example                            `json:"examples,omitempty" yaml:"examples,omitempty"`
+	Extensions  *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low         *lowv2.Response
 }
 
@@ -48,3 +48,24 @@ func NewResponse(response *lowv2.Response) *Response {
 func (r *Response) GoLow() *lowv2.Response {
 	return r.low
 }
+
+// GetPosition returns the position of the Response in the source specification, or nil if it is not available.
+func (r *Response) GetPosition() *high.Position {
+	return high.GetPosition(r.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Response is defined under, or nil if it is not available.
+func (r *Response) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(r.GoLow())
+}
+
+// Render will return a YAML representation of the Response object as a byte slice.
+func (r *Response) Render() ([]byte, error) {
+	return yaml.Marshal(r)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Response object.
+func (r *Response) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(r, r.low)
+	return nb.Render(), nil
+}
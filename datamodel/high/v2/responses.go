@@ -4,19 +4,23 @@
 package v2
 
 import (
+	"errors"
+	"strconv"
+
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high"
 	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
 	low "github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
 
 // Responses is a high-level representation of a Swagger / OpenAPI 2 Responses object, backed by a low level one.
 type Responses struct {
-	Codes      *orderedmap.Map[string, *Response]
-	Default    *Response
-	Extensions *orderedmap.Map[string, *yaml.Node]
+	Codes      *orderedmap.Map[string, *Response]  `json:"-" yaml:"-"`
+	Default    *Response                           `json:"default,omitempty" yaml:"default,omitempty"`
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low        *low.Responses
 }
 
@@ -30,8 +34,9 @@ func NewResponses(responses *low.Responses) *Responses {
 		r.Default = NewResponse(responses.Default.Value)
 	}
 
+	resp := orderedmap.New[string, *Response]()
+	r.Codes = resp
 	if orderedmap.Len(responses.Codes) > 0 {
-		resp := orderedmap.New[string, *Response]()
 		translateFunc := func(pair orderedmap.Pair[lowmodel.KeyReference[string], lowmodel.ValueReference[*low.Response]]) (asyncResult[*Response], error) {
 			return asyncResult[*Response]{
 				key:    pair.Key().Value,
@@ -43,13 +48,67 @@ func NewResponses(responses *low.Responses) *Responses {
 			return nil
 		}
 		_ = datamodel.TranslateMapParallel(responses.Codes, translateFunc, resultFunc)
-		r.Codes = resp
 	}
 
 	return r
 }
 
+// FindResponseByCode is a shortcut for looking up code by an integer vs. a string
+func (r *Responses) FindResponseByCode(code int) *Response {
+	return r.Codes.GetOrZero(strconv.Itoa(code))
+}
+
+// FindResponseForCode will locate the Response that describes an HTTP status code. An exact code (like 204) is
+// used first, then the Default. If no response applies, nil is returned.
+func (r *Responses) FindResponseForCode(status int) *Response {
+	var found *Response
+	rank := 0
+	for code, resp := range r.Codes.FromOldest() {
+		if m := utils.MatchResponseCode(code, status); m > rank {
+			found = resp
+			rank = m
+		}
+	}
+	if found == nil {
+		return r.Default
+	}
+	return found
+}
+
+// ValidateCodes will check every response code is well-formed (see low.ValidateResponseCode), all problems
+// found are returned.
+func (r *Responses) ValidateCodes() error {
+	var errs []error
+	for code := range r.Codes.KeysFromOldest() {
+		if err := low.ValidateResponseCode(code); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // GoLow will return the low-level object used to create the high-level one.
 func (r *Responses) GoLow() *low.Responses {
 	return r.low
 }
+
+// GetPosition returns the position of the Responses in the source specification, or nil if it is not available.
+func (r *Responses) GetPosition() *high.Position {
+	return high.GetPosition(r.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Responses is defined under, or nil if it is not available.
+func (r *Responses) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(r.GoLow())
+}
+
+// Render will return a YAML representation of the Responses object as a byte slice.
+func (r *Responses) Render() ([]byte, error) {
+	return yaml.Marshal(r)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Responses object.
+func (r *Responses) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(r, r.low)
+	return renderMap(nb, r.Codes, r.low), nil
+}
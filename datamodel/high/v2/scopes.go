@@ -4,9 +4,11 @@
 package v2
 
 import (
+	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	lowv2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
 )
 
 // Scopes is a high-level representation of a Swagger / OpenAPI 2 OAuth2 Scopes object, that is backed by a low-level one.
@@ -14,8 +16,9 @@ import (
 // Scopes lists the available scopes for an OAuth2 security scheme.
 //   - https://swagger.io/specification/v2/#scopesObject
 type Scopes struct {
-	Values *orderedmap.Map[string, string]
-	low    *lowv2.Scopes
+	Values     *orderedmap.Map[string, string]     `json:"-" yaml:"-"`
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low        *lowv2.Scopes
 }
 
 // NewScopes creates a new high-level instance of Scopes from a low-level one.
@@ -23,6 +26,7 @@ func NewScopes(scopes *lowv2.Scopes) *Scopes {
 	s := new(Scopes)
 	s.low = scopes
 	s.Values = low.FromReferenceMap(scopes.Values)
+	s.Extensions = high.ExtractExtensions(scopes.Extensions)
 	return s
 }
 
@@ -30,3 +34,24 @@ func NewScopes(scopes *lowv2.Scopes) *Scopes {
 func (s *Scopes) GoLow() *lowv2.Scopes {
 	return s.low
 }
+
+// GetPosition returns the position of the Scopes in the source specification, or nil if it is not available.
+func (s *Scopes) GetPosition() *high.Position {
+	return high.GetPosition(s.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Scopes is defined under, or nil if it is not available.
+func (s *Scopes) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(s.GoLow())
+}
+
+// Render will return a YAML representation of the Scopes object as a byte slice.
+func (s *Scopes) Render() ([]byte, error) {
+	return yaml.Marshal(s)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Scopes object.
+func (s *Scopes) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(s, s.low)
+	return renderMap(nb, s.Values, s.low), nil
+}
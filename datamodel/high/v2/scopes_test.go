@@ -0,0 +1,46 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowv2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewScopes_OrderAndExtensions(t *testing.T) {
+	yml := `zebra: stripes
+apple: pie
+mango: chutney
+x-scope-owner: pets-team`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n lowv2.Scopes
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	s := NewScopes(&n)
+
+	var keys []string
+	for k := range s.Values.KeysFromOldest() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"zebra", "apple", "mango"}, keys)
+	require.NotNil(t, s.Extensions.GetOrZero("x-scope-owner"))
+	assert.Equal(t, "pets-team", s.Extensions.GetOrZero("x-scope-owner").Value)
+
+	rend, err := s.Render()
+	require.NoError(t, err)
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+}
@@ -12,9 +12,14 @@
 package v2
 
 import (
+	"io"
+	"iter"
+	"slices"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/json"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"gopkg.in/yaml.v3"
 )
@@ -22,76 +27,76 @@ import (
 // Swagger represents a high-level Swagger / OpenAPI 2 document. An instance of Swagger is the root of the specification.
 type Swagger struct {
 	// Swagger is the version of Swagger / OpenAPI being used, extracted from the 'swagger: 2.x' definition.
-	Swagger string
+	Swagger string `json:"swagger,omitempty" yaml:"swagger,omitempty"`
 
 	// Info represents a specification Info definition.
 	// Provides metadata about the API. The metadata can be used by the clients if needed.
 	// - https://swagger.io/specification/v2/#infoObject
-	Info *base.Info
+	Info *base.Info `json:"info,omitempty" yaml:"info,omitempty"`
 
 	// Host is The host (name or ip) serving the API. This MUST be the host only and does not include the scheme nor
 	// sub-paths. It MAY include a port. If the host is not included, the host serving the documentation is to be used
 	// (including the port). The host does not support path templating.
-	Host string
+	Host string `json:"host,omitempty" yaml:"host,omitempty"`
 
 	// BasePath is The base path on which the API is served, which is relative to the host. If it is not included, the API is
 	// served directly under the host. The value MUST start with a leading slash (/).
 	// The basePath does not support path templating.
-	BasePath string
+	BasePath string `json:"basePath,omitempty" yaml:"basePath,omitempty"`
 
 	// Schemes represents the transfer protocol of the API. Requirements MUST be from the list: "http", "https", "ws", "wss".
 	// If the schemes is not included, the default scheme to be used is the one used to access
 	// the Swagger definition itself.
-	Schemes []string
+	Schemes []string `json:"schemes,omitempty" yaml:"schemes,omitempty"`
 
 	// Consumes is a list of MIME types the APIs can consume. This is global to all APIs but can be overridden on
 	// specific API calls. Value MUST be as described under Mime Types.
-	Consumes []string
+	Consumes []string `json:"consumes,omitempty" yaml:"consumes,omitempty"`
 
 	// Produces is a list of MIME types the APIs can produce. This is global to all APIs but can be overridden on
 	// specific API calls. Value MUST be as described under Mime Types.
-	Produces []string
+	Produces []string `json:"produces,omitempty" yaml:"produces,omitempty"`
 
 	// Paths are the paths and operations for the API. Perhaps the most important part of the specification.
 	//  - https://swagger.io/specification/v2/#pathsObject
-	Paths *Paths
+	Paths *Paths `json:"paths,omitempty" yaml:"paths,omitempty"`
 
 	// Definitions is an object to hold data types produced and consumed by operations. It's composed of Schema instances
 	//  - https://swagger.io/specification/v2/#definitionsObject
-	Definitions *Definitions
+	Definitions *Definitions `json:"definitions,omitempty" yaml:"definitions,omitempty"`
 
 	// Parameters is an object to hold parameters that can be used across operations.
 	// This property does not define global parameters for all operations.
 	//  - https://swagger.io/specification/v2/#parametersDefinitionsObject
-	Parameters *ParameterDefinitions
+	Parameters *ParameterDefinitions `json:"parameters,omitempty" yaml:"parameters,omitempty"`
 
 	// Responses is an object to hold responses that can be used across operations.
 	// This property does not define global responses for all operations.
 	//  - https://swagger.io/specification/v2/#responsesDefinitionsObject
-	Responses *ResponsesDefinitions
+	Responses *ResponsesDefinitions `json:"responses,omitempty" yaml:"responses,omitempty"`
 
 	// SecurityDefinitions represents security scheme definitions that can be used across the specification.
 	//  - https://swagger.io/specification/v2/#securityDefinitionsObject
-	SecurityDefinitions *SecurityDefinitions
+	SecurityDefinitions *SecurityDefinitions `json:"securityDefinitions,omitempty" yaml:"securityDefinitions,omitempty"`
 
 	// Security is a declaration of which security schemes are applied for the API as a whole. The list of values
 	// describes alternative security schemes that can be used (that is, there is a logical OR between the security
 	// requirements). Individual operations can override this definition.
 	//  - https://swagger.io/specification/v2/#securityRequirementObject
-	Security []*base.SecurityRequirement
+	Security []*base.SecurityRequirement `json:"security,omitempty" yaml:"security,omitempty"`
 
 	// Tags are A list of tags used by the specification with additional metadata.
 	// The order of the tags can be used to reflect on their order by the parsing tools. Not all tags that are used
 	// by the Operation Object must be declared. The tags that are not declared may be organized randomly or based
 	// on the tools' logic. Each tag name in the list MUST be unique.
 	//  - https://swagger.io/specification/v2/#tagObject
-	Tags []*base.Tag
+	Tags []*base.Tag `json:"tags,omitempty" yaml:"tags,omitempty"`
 
 	// ExternalDocs is an instance of base.ExternalDoc for.. well, obvious really, innit.
-	ExternalDocs *base.ExternalDoc
+	ExternalDocs *base.ExternalDoc `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
 
 	// Extensions contains all custom extensions defined for the top-level document.
-	Extensions *orderedmap.Map[string, *yaml.Node]
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low        *low.Swagger
 }
 
@@ -174,3 +179,104 @@ func NewSwaggerDocument(document *low.Swagger) *Swagger {
 func (s *Swagger) GoLow() *low.Swagger {
 	return s.low
 }
+
+// GetPosition returns the position of the Swagger in the source specification, or nil if it is not available.
+func (s *Swagger) GetPosition() *high.Position {
+	return high.GetPosition(s.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Swagger is defined under, or nil if it is not available.
+func (s *Swagger) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(s.GoLow())
+}
+
+// PathOperation is an Operation, along with the path and the HTTP method it is defined under.
+type PathOperation struct {
+	Path      string
+	Method    string
+	PathItem  *PathItem
+	Operation *Operation
+}
+
+// Operations returns an iterator over every operation defined in the document's paths, in the order they
+// were defined. Each operation is yielded along with the path and method it belongs to.
+func (s *Swagger) Operations() iter.Seq[*PathOperation] {
+	return func(yield func(*PathOperation) bool) {
+		if s.Paths == nil {
+			return
+		}
+		for path, pi := range s.Paths.PathItems.FromOldest() {
+			if pi == nil {
+				continue
+			}
+			for method, op := range pi.GetOperations().FromOldest() {
+				if !yield(&PathOperation{Path: path, Method: method, PathItem: pi, Operation: op}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// EffectiveConsumes returns the MIME types the operation can consume. An operation inherits the global consumes
+// definition of the document, unless it defines its own, which replaces the global definition entirely. An operation
+// that defines an empty consumes list (consumes: []) clears the global definition, and nil is returned.
+//   - https://swagger.io/specification/v2/#operationObject
+func (s *Swagger) EffectiveConsumes(op *Operation) []string {
+	if op != nil && op.Consumes != nil {
+		return effectiveMediaTypes(op.Consumes)
+	}
+	return effectiveMediaTypes(s.Consumes)
+}
+
+// EffectiveProduces returns the MIME types the operation can produce. An operation inherits the global produces
+// definition of the document, unless it defines its own, which replaces the global definition entirely. An operation
+// that defines an empty produces list (produces: []) clears the global definition, and nil is returned.
+//   - https://swagger.io/specification/v2/#operationObject
+func (s *Swagger) EffectiveProduces(op *Operation) []string {
+	if op != nil && op.Produces != nil {
+		return effectiveMediaTypes(op.Produces)
+	}
+	return effectiveMediaTypes(s.Produces)
+}
+
+func effectiveMediaTypes(mediaTypes []string) []string {
+	if len(mediaTypes) == 0 {
+		return nil
+	}
+	return slices.Clone(mediaTypes)
+}
+
+// Render will return a YAML representation of the Swagger object as a byte slice.
+func (s *Swagger) Render() ([]byte, error) {
+	return yaml.Marshal(s)
+}
+
+// RenderJSON will return a JSON representation of the Swagger object as a byte slice.
+func (s *Swagger) RenderJSON(indention string) ([]byte, error) {
+	nb := high.NewNodeBuilder(s, s.low)
+	return json.YAMLNodeToJSON(nb.Render(), indention)
+}
+
+// RenderTo writes the same YAML as Render to w as it is encoded, so the complete output is never held in memory as
+// a byte slice. The rendered yaml.Node tree of the Swagger is still built in full before anything is written.
+func (s *Swagger) RenderTo(w io.Writer) error {
+	yamlEncoder := yaml.NewEncoder(w)
+	if err := yamlEncoder.Encode(s); err != nil {
+		return err
+	}
+	return yamlEncoder.Close()
+}
+
+// RenderJSONTo writes the same JSON as RenderJSON to w as it is encoded, so the complete output is never held in
+// memory as a byte slice. The rendered yaml.Node tree of the Swagger is still built in full before anything is written.
+func (s *Swagger) RenderJSONTo(w io.Writer, indention string) error {
+	nb := high.NewNodeBuilder(s, s.low)
+	return json.YAMLNodeToJSONWriter(w, nb.Render(), indention)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Swagger object.
+func (s *Swagger) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(s, s.low)
+	return nb.Render(), nil
+}
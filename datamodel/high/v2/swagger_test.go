@@ -4,13 +4,17 @@
 package v2
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 var doc *v2.Swagger
@@ -189,15 +193,15 @@ func TestNewSwaggerDocument_Definitions_Responses(t *testing.T) {
 	assert.Equal(t, "array", x.Type)
 	assert.Equal(t, "csv", x.CollectionFormat)
 	assert.Equal(t, "cake", def)
-	assert.Equal(t, 10, x.Maximum)
-	assert.Equal(t, 1, x.Minimum)
-	assert.True(t, x.ExclusiveMaximum)
-	assert.True(t, x.ExclusiveMinimum)
-	assert.Equal(t, 5, x.MaxLength)
-	assert.Equal(t, 1, x.MinLength)
+	assert.Equal(t, 10, *x.Maximum)
+	assert.Equal(t, 1, *x.Minimum)
+	assert.True(t, *x.ExclusiveMaximum)
+	assert.True(t, *x.ExclusiveMinimum)
+	assert.Equal(t, 5, *x.MaxLength)
+	assert.Equal(t, 1, *x.MinLength)
 	assert.Equal(t, "hi!", x.Pattern)
-	assert.Equal(t, 1, x.MinItems)
-	assert.True(t, x.UniqueItems)
+	assert.Equal(t, 1, *x.MinItems)
+	assert.True(t, *x.UniqueItems)
 	assert.Len(t, x.Enum, 2)
 
 	wentQuiteLow := y.GoLow()
@@ -303,3 +307,232 @@ func TestNewSwaggerDocument_Responses(t *testing.T) {
 	assert.Equal(t, 107, wentLower.Schema.KeyNode.Line)
 	assert.Equal(t, 11, wentLower.Schema.KeyNode.Column)
 }
+
+func newPetstoreV2Document(t *testing.T) *Swagger {
+	data, _ := os.ReadFile("../../../test_specs/petstorev2.json")
+	info, _ := datamodel.ExtractSpecInfo(data)
+	lowDoc, err := v2.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+	return NewSwaggerDocument(lowDoc)
+}
+
+func TestSwagger_Render(t *testing.T) {
+	highDoc := newPetstoreV2Document(t)
+
+	rendered, err := highDoc.Render()
+	assert.NoError(t, err)
+
+	info, _ := datamodel.ExtractSpecInfo(rendered)
+	reDoc, err := v2.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+
+	reHigh := NewSwaggerDocument(reDoc)
+	assert.Equal(t, highDoc.Swagger, reHigh.Swagger)
+	assert.Equal(t, highDoc.Host, reHigh.Host)
+	assert.Equal(t, highDoc.Schemes, reHigh.Schemes)
+	assert.Equal(t, orderedmap.Len(highDoc.Paths.PathItems), orderedmap.Len(reHigh.Paths.PathItems))
+	assert.Equal(t, orderedmap.Len(highDoc.Definitions.Definitions), orderedmap.Len(reHigh.Definitions.Definitions))
+	assert.Equal(t, orderedmap.Len(highDoc.SecurityDefinitions.Definitions),
+		orderedmap.Len(reHigh.SecurityDefinitions.Definitions))
+	assert.Equal(t, highDoc.Definitions.GoLow().Hash(), reHigh.Definitions.GoLow().Hash())
+}
+
+func TestSwagger_Render_Mutated(t *testing.T) {
+	highDoc := newPetstoreV2Document(t)
+
+	highDoc.Host = "pizza.example.com"
+	upload := highDoc.Paths.PathItems.GetOrZero("/pet/{petId}/uploadImage").Post
+	upload.Summary = "upload a tasty image"
+	required := false
+	upload.Parameters[0].Required = &required
+
+	rendered, err := highDoc.Render()
+	assert.NoError(t, err)
+
+	info, _ := datamodel.ExtractSpecInfo(rendered)
+	reDoc, err := v2.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+
+	reHigh := NewSwaggerDocument(reDoc)
+	assert.Equal(t, "pizza.example.com", reHigh.Host)
+	reUpload := reHigh.Paths.PathItems.GetOrZero("/pet/{petId}/uploadImage").Post
+	assert.Equal(t, "upload a tasty image", reUpload.Summary)
+	assert.False(t, *reUpload.Parameters[0].Required)
+}
+
+func TestSwagger_RenderJSON(t *testing.T) {
+	initTest()
+	highDoc := NewSwaggerDocument(doc)
+
+	rendered, err := highDoc.RenderJSON("  ")
+	assert.NoError(t, err)
+	assert.Contains(t, string(rendered), `"swagger": "2.0"`)
+}
+
+func TestParameter_Render_New(t *testing.T) {
+	maximum := 0
+	required := true
+	p := &Parameter{
+		Name:     "limit",
+		In:       "query",
+		Type:     "integer",
+		Required: &required,
+		Maximum:  &maximum,
+	}
+	rendered, err := p.Render()
+	assert.NoError(t, err)
+	assert.Equal(t, "name: limit\nin: query\ntype: integer\nrequired: true\nmaximum: 0\n", string(rendered))
+}
+
+func TestResponses_Render(t *testing.T) {
+	ok := &Response{Description: "all good"}
+	codes := orderedmap.New[string, *Response]()
+	codes.Set("200", ok)
+	ext := orderedmap.New[string, *yaml.Node]()
+	ext.Set("x-pizza", utils.CreateStringNode("party"))
+
+	r := &Responses{
+		Codes:      codes,
+		Default:    &Response{Description: "not so good"},
+		Extensions: ext,
+	}
+	rendered, err := r.Render()
+	assert.NoError(t, err)
+	assert.Equal(t, `"200":
+    description: all good
+default:
+    description: not so good
+x-pizza: party
+`, string(rendered))
+}
+
+func TestSwagger_EffectiveMediaTypes(t *testing.T) {
+	yml := `swagger: "2.0"
+consumes:
+  - application/json
+produces:
+  - application/json
+  - application/xml
+paths:
+  /inherit:
+    get:
+      operationId: inherit
+  /override:
+    post:
+      operationId: override
+      consumes:
+        - multipart/form-data
+      produces:
+        - text/plain
+  /clear:
+    delete:
+      operationId: clear
+      consumes: []
+      produces: []`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, err := v2.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+	highDoc := NewSwaggerDocument(lowDoc)
+
+	ops := make(map[string]*PathOperation)
+	for po := range highDoc.Operations() {
+		ops[po.Operation.OperationId] = po
+	}
+	assert.Len(t, ops, 3)
+	assert.Equal(t, "/override", ops["override"].Path)
+	assert.Equal(t, "post", ops["override"].Method)
+
+	inherit := ops["inherit"].Operation
+	assert.Equal(t, []string{"application/json"}, highDoc.EffectiveConsumes(inherit))
+	assert.Equal(t, []string{"application/json", "application/xml"}, highDoc.EffectiveProduces(inherit))
+
+	override := ops["override"].Operation
+	assert.Equal(t, []string{"multipart/form-data"}, highDoc.EffectiveConsumes(override))
+	assert.Equal(t, []string{"text/plain"}, highDoc.EffectiveProduces(override))
+
+	clear := ops["clear"].Operation
+	assert.NotNil(t, clear.Consumes)
+	assert.Nil(t, highDoc.EffectiveConsumes(clear))
+	assert.Nil(t, highDoc.EffectiveProduces(clear))
+
+	rendered, _ := clear.Render()
+	assert.Contains(t, string(rendered), "consumes: []")
+}
+
+func TestResponses_RenderKeepsPositions(t *testing.T) {
+	for _, yml := range []string{
+		"default:\n    description: oops\nx-pizza: party\n",
+		"x-first: 1\n\"200\":\n    description: ok\nx-second: 2\ndefault:\n    description: oops\nx-third: 3\n",
+		"x-only: true\n",
+	} {
+		var n yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &n)
+		var r v2.Responses
+		_ = low.BuildModel(n.Content[0], &r)
+		assert.NoError(t, r.Build(context.Background(), nil, n.Content[0], nil))
+
+		h := NewResponses(&r)
+		rendered, err := h.Render()
+		assert.NoError(t, err)
+		assert.Equal(t, yml, string(rendered))
+	}
+}
+
+func TestResponses_Render_AddedCode(t *testing.T) {
+	yml := `default:
+  description: oops
+x-pizza: party`
+
+	var n yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &n)
+	var r v2.Responses
+	_ = low.BuildModel(n.Content[0], &r)
+	_ = r.Build(context.Background(), nil, n.Content[0], nil)
+
+	h := NewResponses(&r)
+	h.Codes.Set("404", &Response{Description: "missing"})
+	rendered, _ := h.Render()
+	assert.Equal(t, "default:\n    description: oops\nx-pizza: party\n\"404\":\n    description: missing\n",
+		string(rendered))
+}
+
+func TestResponses_FindResponseForCode(t *testing.T) {
+	codes := orderedmap.New[string, *Response]()
+	codes.Set("200", &Response{Description: "ok"})
+	codes.Set("4XX", &Response{Description: "range"})
+	r := &Responses{Codes: codes, Default: &Response{Description: "oops"}}
+
+	assert.Equal(t, "ok", r.FindResponseByCode(200).Description)
+	assert.Nil(t, r.FindResponseByCode(201))
+	assert.Equal(t, "ok", r.FindResponseForCode(200).Description)
+	assert.Equal(t, "oops", r.FindResponseForCode(500).Description)
+	assert.EqualError(t, r.ValidateCodes(), "response code range '4XX' is not valid, ranges are not supported by Swagger")
+
+	codes.Delete("4XX")
+	assert.NoError(t, r.ValidateCodes())
+}
+
+func TestSwagger_Positions(t *testing.T) {
+	initTest()
+	h := NewSwaggerDocument(doc)
+
+	pos := h.GetPosition()
+	assert.NotNil(t, pos)
+	assert.Equal(t, 2, pos.Line)
+	assert.Equal(t, doc.Index.GetSpecAbsolutePath(), pos.File)
+	assert.Nil(t, h.GetKeyPosition())
+
+	op := h.Paths.PathItems.GetOrZero("/pet/{petId}/uploadImage").Post
+	assert.Equal(t, 54, op.GetPosition().Line)
+	assert.Equal(t, 53, op.GetKeyPosition().Line)
+	assert.Equal(t, 5, op.GetKeyPosition().Column)
+
+	assert.Equal(t, 772, h.Definitions.Definitions.GetOrZero("Pet").GetKeyPosition().Line)
+	assert.NotNil(t, h.Paths.GetPosition())
+	assert.NotNil(t, h.SecurityDefinitions.GetPosition())
+
+	// no low model, no position
+	assert.Nil(t, (&Operation{}).GetPosition())
+	assert.Nil(t, (&Swagger{}).GetKeyPosition())
+}
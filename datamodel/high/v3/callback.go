@@ -46,6 +46,16 @@ func (c *Callback) GoLowUntyped() any {
 	return c.low
 }
 
+// GetPosition returns the position of the Callback in the source specification, or nil if it is not available.
+func (c *Callback) GetPosition() *high.Position {
+	return high.GetPosition(c.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Callback is defined under, or nil if it is not available.
+func (c *Callback) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(c.GoLow())
+}
+
 // Render will return a YAML representation of the Callback object as a byte slice.
 func (c *Callback) Render() ([]byte, error) {
 	return yaml.Marshal(c)
@@ -4,7 +4,7 @@
 package v3
 
 import (
-	"sync"
+	"fmt"
 
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high"
@@ -57,50 +57,24 @@ func NewComponents(comp *low.Components) *Components {
 	schemas := orderedmap.New[string, *highbase.SchemaProxy]()
 
 	// build all components asynchronously.
-	var wg sync.WaitGroup
-	wg.Add(10)
-	go func() {
-		buildComponent[*low.Callback, *Callback](comp.Callbacks.Value, cbMap, NewCallback)
-		wg.Done()
-	}()
-	go func() {
-		buildComponent[*low.Link, *Link](comp.Links.Value, linkMap, NewLink)
-		wg.Done()
-	}()
-	go func() {
-		buildComponent[*low.Response, *Response](comp.Responses.Value, responseMap, NewResponse)
-		wg.Done()
-	}()
-	go func() {
-		buildComponent[*low.Parameter, *Parameter](comp.Parameters.Value, parameterMap, NewParameter)
-		wg.Done()
-	}()
-	go func() {
-		buildComponent[*base.Example, *highbase.Example](comp.Examples.Value, exampleMap, highbase.NewExample)
-		wg.Done()
-	}()
-	go func() {
-		buildComponent[*low.RequestBody, *RequestBody](comp.RequestBodies.Value, requestBodyMap, NewRequestBody)
-		wg.Done()
-	}()
-	go func() {
-		buildComponent[*low.Header, *Header](comp.Headers.Value, headerMap, NewHeader)
-		wg.Done()
-	}()
-	go func() {
-		buildComponent[*low.PathItem, *PathItem](comp.PathItems.Value, pathItemMap, NewPathItem)
-		wg.Done()
-	}()
-	go func() {
-		buildComponent[*low.SecurityScheme, *SecurityScheme](comp.SecuritySchemes.Value, securitySchemeMap, NewSecurityScheme)
-		wg.Done()
-	}()
-	go func() {
-		buildSchema(comp.Schemas.Value, schemas)
-		wg.Done()
-	}()
-
-	wg.Wait()
+	datamodel.RunParallel(
+		func() { buildComponent[*low.Callback, *Callback](comp.Callbacks.Value, cbMap, NewCallback) },
+		func() { buildComponent[*low.Link, *Link](comp.Links.Value, linkMap, NewLink) },
+		func() { buildComponent[*low.Response, *Response](comp.Responses.Value, responseMap, NewResponse) },
+		func() { buildComponent[*low.Parameter, *Parameter](comp.Parameters.Value, parameterMap, NewParameter) },
+		func() {
+			buildComponent[*base.Example, *highbase.Example](comp.Examples.Value, exampleMap, highbase.NewExample)
+		},
+		func() {
+			buildComponent[*low.RequestBody, *RequestBody](comp.RequestBodies.Value, requestBodyMap, NewRequestBody)
+		},
+		func() { buildComponent[*low.Header, *Header](comp.Headers.Value, headerMap, NewHeader) },
+		func() { buildComponent[*low.PathItem, *PathItem](comp.PathItems.Value, pathItemMap, NewPathItem) },
+		func() {
+			buildComponent[*low.SecurityScheme, *SecurityScheme](comp.SecuritySchemes.Value, securitySchemeMap, NewSecurityScheme)
+		},
+		func() { buildSchema(comp.Schemas.Value, schemas) },
+	)
 	c.Schemas = schemas
 	c.Callbacks = cbMap
 	c.Links = linkMap
@@ -149,6 +123,29 @@ func buildSchema(inMap *orderedmap.Map[lowmodel.KeyReference[string], lowmodel.V
 	_ = datamodel.TranslateMapParallel(inMap, translateFunc, resultFunc)
 }
 
+// WarmSchemas builds every schema in the components, in parallel (using up to datamodel.TranslateWorkers goroutines),
+// so they are already built when they are first used. Schemas are otherwise built lazily, the first time Schema() is
+// called on their SchemaProxy. The build errors of any schemas that could not be built are returned, in the order
+// the schemas are defined.
+func (c *Components) WarmSchemas() []error {
+	if c == nil {
+		return nil
+	}
+	var errs []error
+	translateFunc := func(pair orderedmap.Pair[string, *highbase.SchemaProxy]) (componentResult[error], error) {
+		_, err := pair.Value().BuildSchema()
+		return componentResult[error]{key: pair.Key(), res: err}, nil
+	}
+	resultFunc := func(value componentResult[error]) error {
+		if value.res != nil {
+			errs = append(errs, fmt.Errorf("unable to build schema '%s': %w", value.key, value.res))
+		}
+		return nil
+	}
+	_ = datamodel.TranslateMapParallel(c.Schemas, translateFunc, resultFunc)
+	return errs
+}
+
 // GoLow returns the low-level Components instance used to create the high-level one.
 func (c *Components) GoLow() *low.Components {
 	return c.low
@@ -159,6 +156,16 @@ func (c *Components) GoLowUntyped() any {
 	return c.low
 }
 
+// GetPosition returns the position of the Components in the source specification, or nil if it is not available.
+func (c *Components) GetPosition() *high.Position {
+	return high.GetPosition(c.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Components is defined under, or nil if it is not available.
+func (c *Components) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(c.GoLow())
+}
+
 // Render will return a YAML representation of the Components object as a byte slice.
 func (c *Components) Render() ([]byte, error) {
 	return yaml.Marshal(c)
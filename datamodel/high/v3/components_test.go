@@ -14,6 +14,7 @@ import (
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
 
@@ -81,3 +82,42 @@ pathItems:
 	assert.Equal(t, desired, strings.TrimSpace(string(dat)))
 	assert.NotNil(t, r.GoLowUntyped())
 }
+
+func TestComponents_WarmSchemas(t *testing.T) {
+	yml := `schemas:
+  rice:
+    type: string
+  nice:
+    properties:
+      rice:
+        $ref: '#/schemas/rice'
+  ice:
+    properties:
+      rice:
+        $ref: '#/schemas/I-do-not-exist'`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3.Components
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), idxNode.Content[0], idx)
+
+	c := NewComponents(&n)
+	errs := c.WarmSchemas()
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "unable to build schema 'ice'")
+
+	// every call returns the schema that was warmed.
+	rice := c.Schemas.GetOrZero("rice")
+	sch := rice.Schema()
+	require.NotNil(t, sch)
+	assert.Same(t, sch, rice.Schema())
+	assert.Same(t, sch, c.Schemas.GetOrZero("rice").Schema())
+	assert.NotNil(t, c.Schemas.GetOrZero("nice").Schema())
+	assert.Nil(t, c.Schemas.GetOrZero("ice").Schema())
+
+	var nilComponents *Components
+	assert.Nil(t, nilComponents.WarmSchemas())
+}
@@ -0,0 +1,252 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// DeprecationType describes what kind of object has been marked as deprecated.
+type DeprecationType string
+
+const (
+	DeprecatedOperation DeprecationType = "operation"
+	DeprecatedParameter DeprecationType = "parameter"
+	DeprecatedHeader    DeprecationType = "header"
+	DeprecatedSchema    DeprecationType = "schema"
+	DeprecatedProperty  DeprecationType = "property"
+)
+
+// Deprecation represents a single object in the document that has been marked as `deprecated: true`.
+type Deprecation struct {
+	// Type is the kind of object that is deprecated.
+	Type DeprecationType
+
+	// Name is the operationId of an operation, or the name of a parameter, header, schema or property.
+	Name string
+
+	// Location is the JSON path to the deprecated object in the document.
+	Location string
+
+	// Line and Column are the position of the `deprecated` keyword, or the object if not available.
+	Line   int
+	Column int
+
+	// Extensions holds the `x-sunset` and `x-deprecated-*` extensions defined on the deprecated object, if any.
+	Extensions *orderedmap.Map[string, *yaml.Node]
+}
+
+// GetDeprecations will walk the document and return every deprecated operation, parameter, header, schema and
+// schema property, in the order they are found. Paths and webhooks are walked first, followed by components.
+//
+// Any `x-sunset` or `x-deprecated-*` extensions defined alongside the deprecated object are included.
+func (d *Document) GetDeprecations() []*Deprecation {
+	dw := new(deprecationWalker)
+	if d.Paths != nil {
+		for path, pi := range d.Paths.PathItems.FromOldest() {
+			dw.pathItem(pi, fmt.Sprintf("$.paths['%s']", path))
+		}
+	}
+	for name, pi := range d.Webhooks.FromOldest() {
+		dw.pathItem(pi, fmt.Sprintf("$.webhooks['%s']", name))
+	}
+	if d.Components != nil {
+		c := d.Components
+		for name, s := range c.Schemas.FromOldest() {
+			if s != nil {
+				dw.schema(s.Schema(), DeprecatedSchema, name, fmt.Sprintf("$.components.schemas['%s']", name), 0)
+			}
+		}
+		for name, p := range c.Parameters.FromOldest() {
+			dw.parameter(p, fmt.Sprintf("$.components.parameters['%s']", name))
+		}
+		for name, h := range c.Headers.FromOldest() {
+			dw.header(h, name, fmt.Sprintf("$.components.headers['%s']", name))
+		}
+		for name, rb := range c.RequestBodies.FromOldest() {
+			if rb != nil {
+				dw.content(rb.Content, fmt.Sprintf("$.components.requestBodies['%s']", name))
+			}
+		}
+		for name, r := range c.Responses.FromOldest() {
+			dw.response(r, fmt.Sprintf("$.components.responses['%s']", name))
+		}
+		for name, pi := range c.PathItems.FromOldest() {
+			dw.pathItem(pi, fmt.Sprintf("$.components.pathItems['%s']", name))
+		}
+	}
+	return dw.deprecations
+}
+
+type deprecationWalker struct {
+	deprecations []*Deprecation
+	seen         map[*PathItem]bool
+}
+
+func (dw *deprecationWalker) add(t DeprecationType, name, location string, ref low.NodeReference[bool],
+	extensions *orderedmap.Map[string, *yaml.Node],
+) {
+	dep := &Deprecation{Type: t, Name: name, Location: location}
+	if n := ref.GetKeyNode(); n != nil {
+		dep.Line, dep.Column = n.Line, n.Column
+	}
+	for k, v := range extensions.FromOldest() {
+		if k == "x-sunset" || strings.HasPrefix(k, "x-deprecated") {
+			if dep.Extensions == nil {
+				dep.Extensions = orderedmap.New[string, *yaml.Node]()
+			}
+			dep.Extensions.Set(k, v)
+		}
+	}
+	dw.deprecations = append(dw.deprecations, dep)
+}
+
+func (dw *deprecationWalker) pathItem(pi *PathItem, location string) {
+	if pi == nil {
+		return
+	}
+	if dw.seen == nil {
+		dw.seen = make(map[*PathItem]bool)
+	}
+	if dw.seen[pi] {
+		return
+	}
+	dw.seen[pi] = true
+	for i, p := range pi.Parameters {
+		dw.parameter(p, fmt.Sprintf("%s.parameters[%d]", location, i))
+	}
+	for method, op := range pi.GetOperations().FromOldest() {
+		dw.operation(op, fmt.Sprintf("%s.%s", location, method))
+	}
+}
+
+func (dw *deprecationWalker) operation(op *Operation, location string) {
+	if op == nil {
+		return
+	}
+	if op.Deprecated != nil && *op.Deprecated {
+		var ref low.NodeReference[bool]
+		if op.GoLow() != nil {
+			ref = op.GoLow().Deprecated
+		}
+		dw.add(DeprecatedOperation, op.OperationId, location, ref, op.Extensions)
+	}
+	for i, p := range op.Parameters {
+		dw.parameter(p, fmt.Sprintf("%s.parameters[%d]", location, i))
+	}
+	if op.RequestBody != nil {
+		dw.content(op.RequestBody.Content, location+".requestBody")
+	}
+	if op.Responses != nil {
+		for code, r := range op.Responses.Codes.FromOldest() {
+			dw.response(r, fmt.Sprintf("%s.responses['%s']", location, code))
+		}
+		dw.response(op.Responses.Default, location+".responses.default")
+	}
+	for name, cb := range op.Callbacks.FromOldest() {
+		if cb == nil {
+			continue
+		}
+		for expression, pi := range cb.Expression.FromOldest() {
+			dw.pathItem(pi, fmt.Sprintf("%s.callbacks['%s']['%s']", location, name, expression))
+		}
+	}
+}
+
+func (dw *deprecationWalker) parameter(p *Parameter, location string) {
+	if p == nil {
+		return
+	}
+	if p.Deprecated {
+		var ref low.NodeReference[bool]
+		if p.GoLow() != nil {
+			ref = p.GoLow().Deprecated
+		}
+		dw.add(DeprecatedParameter, p.Name, location, ref, p.Extensions)
+	}
+	dw.schemaProxy(p.Schema, location+".schema")
+	dw.content(p.Content, location)
+}
+
+func (dw *deprecationWalker) header(h *Header, name, location string) {
+	if h == nil {
+		return
+	}
+	if h.Deprecated {
+		var ref low.NodeReference[bool]
+		if h.GoLow() != nil {
+			ref = h.GoLow().Deprecated
+		}
+		dw.add(DeprecatedHeader, name, location, ref, h.Extensions)
+	}
+	dw.schemaProxy(h.Schema, location+".schema")
+	dw.content(h.Content, location)
+}
+
+func (dw *deprecationWalker) response(r *Response, location string) {
+	if r == nil {
+		return
+	}
+	for name, h := range r.Headers.FromOldest() {
+		dw.header(h, name, fmt.Sprintf("%s.headers['%s']", location, name))
+	}
+	dw.content(r.Content, location)
+}
+
+func (dw *deprecationWalker) content(content *orderedmap.Map[string, *MediaType], location string) {
+	for mt, m := range content.FromOldest() {
+		if m != nil {
+			dw.schemaProxy(m.Schema, fmt.Sprintf("%s.content['%s'].schema", location, mt))
+		}
+	}
+}
+
+// schemaProxy walks inline schemas only, references are reported where they are defined.
+func (dw *deprecationWalker) schemaProxy(sp *base.SchemaProxy, location string) {
+	if sp == nil || sp.IsReference() {
+		return
+	}
+	dw.schema(sp.Schema(), DeprecatedSchema, "", location, 0)
+}
+
+func (dw *deprecationWalker) schema(s *base.Schema, t DeprecationType, name, location string, depth int) {
+	if s == nil || depth > 10 {
+		return
+	}
+	if s.Deprecated != nil && *s.Deprecated {
+		var ref low.NodeReference[bool]
+		if s.GoLow() != nil {
+			ref = s.GoLow().Deprecated
+		}
+		dw.add(t, name, location, ref, s.Extensions)
+	}
+	for prop, sp := range s.Properties.FromOldest() {
+		if sp == nil || sp.IsReference() {
+			continue
+		}
+		dw.schema(sp.Schema(), DeprecatedProperty, prop, fmt.Sprintf("%s.properties['%s']", location, prop), depth+1)
+	}
+	if s.Items != nil && s.Items.IsA() && !s.Items.A.IsReference() {
+		dw.schema(s.Items.A.Schema(), DeprecatedSchema, "", location+".items", depth+1)
+	}
+	dw.composition(s.AllOf, "allOf", location, depth)
+	dw.composition(s.OneOf, "oneOf", location, depth)
+	dw.composition(s.AnyOf, "anyOf", location, depth)
+}
+
+// composition walks the inline schemas of allOf, oneOf or anyOf.
+func (dw *deprecationWalker) composition(proxies []*base.SchemaProxy, keyword, location string, depth int) {
+	for i, sp := range proxies {
+		if sp == nil || sp.IsReference() {
+			continue
+		}
+		dw.schema(sp.Schema(), DeprecatedSchema, "", fmt.Sprintf("%s.%s[%d]", location, keyword, i), depth+1)
+	}
+}
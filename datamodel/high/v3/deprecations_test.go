@@ -0,0 +1,151 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_GetDeprecations(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets:
+    parameters:
+      - name: legacy
+        in: query
+        deprecated: true
+    get:
+      operationId: listPets
+      deprecated: true
+      x-sunset: 2025-01-01
+      x-deprecated-replacement: listAnimals
+      x-other: nope
+      responses:
+        "200":
+          description: ok
+          headers:
+            X-Old:
+              deprecated: true
+              schema:
+                type: string
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                nickname:
+                  type: string
+                  deprecated: true
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+          deprecated: true
+    OldPet:
+      deprecated: true
+      type: object
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      deprecated: true`
+
+	doc := buildExampleTestDocument(t, spec)
+	deps := doc.GetDeprecations()
+	require.Len(t, deps, 7)
+
+	assert.Equal(t, DeprecatedParameter, deps[0].Type)
+	assert.Equal(t, "legacy", deps[0].Name)
+	assert.Equal(t, "$.paths['/pets'].parameters[0]", deps[0].Location)
+	assert.Equal(t, 7, deps[0].Line)
+	assert.Equal(t, 9, deps[0].Column)
+
+	assert.Equal(t, DeprecatedOperation, deps[1].Type)
+	assert.Equal(t, "listPets", deps[1].Name)
+	assert.Equal(t, "$.paths['/pets'].get", deps[1].Location)
+	assert.Equal(t, 10, deps[1].Line)
+	require.NotNil(t, deps[1].Extensions)
+	assert.Equal(t, []string{"x-sunset", "x-deprecated-replacement"},
+		[]string{deps[1].Extensions.First().Key(), deps[1].Extensions.First().Next().Key()})
+	assert.Equal(t, 2, deps[1].Extensions.Len())
+
+	assert.Equal(t, DeprecatedHeader, deps[2].Type)
+	assert.Equal(t, "X-Old", deps[2].Name)
+	assert.Equal(t, "$.paths['/pets'].get.responses['200'].headers['X-Old']", deps[2].Location)
+	assert.Nil(t, deps[2].Extensions)
+
+	assert.Equal(t, DeprecatedProperty, deps[3].Type)
+	assert.Equal(t, "nickname", deps[3].Name)
+	assert.Equal(t, "$.paths['/pets'].post.requestBody.content['application/json'].schema.properties['nickname']",
+		deps[3].Location)
+
+	assert.Equal(t, DeprecatedProperty, deps[4].Type)
+	assert.Equal(t, "tag", deps[4].Name)
+	assert.Equal(t, "$.components.schemas['Pet'].properties['tag']", deps[4].Location)
+
+	assert.Equal(t, DeprecatedSchema, deps[5].Type)
+	assert.Equal(t, "OldPet", deps[5].Name)
+	assert.Equal(t, "$.components.schemas['OldPet']", deps[5].Location)
+
+	assert.Equal(t, DeprecatedParameter, deps[6].Type)
+	assert.Equal(t, "limit", deps[6].Name)
+	assert.Equal(t, "$.components.parameters['Limit']", deps[6].Location)
+}
+
+func TestDocument_GetDeprecations_None(t *testing.T) {
+	doc := buildExampleTestDocument(t, `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      deprecated: false`)
+	assert.Empty(t, doc.GetDeprecations())
+	assert.Empty(t, (&Document{}).GetDeprecations())
+}
+
+func TestDocument_GetDeprecations_Compositions(t *testing.T) {
+	doc := buildExampleTestDocument(t, `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            nickname:
+              type: string
+              deprecated: true
+      oneOf:
+        - type: string
+          deprecated: true
+      anyOf:
+        - type: integer
+    Base:
+      type: object`)
+	deps := doc.GetDeprecations()
+	require.Len(t, deps, 2)
+
+	assert.Equal(t, DeprecatedProperty, deps[0].Type)
+	assert.Equal(t, "nickname", deps[0].Name)
+	assert.Equal(t, "$.components.schemas['Pet'].allOf[1].properties['nickname']", deps[0].Location)
+
+	assert.Equal(t, DeprecatedSchema, deps[1].Type)
+	assert.Equal(t, "$.components.schemas['Pet'].oneOf[0]", deps[1].Location)
+}
@@ -11,7 +11,10 @@ package v3
 
 import (
 	"bytes"
+	"io"
+	"iter"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -52,6 +55,9 @@ type Document struct {
 	// security requirement objects that can be used. Only one of the security requirement objects need to be satisfied
 	// to authorize a request. Individual operations can override this definition. To make security optional,
 	// an empty security requirement ({}) can be included in the array.
+	//
+	// A nil Security means no security is defined. An empty (non-nil) Security means security was defined as
+	// an empty array (security: []), which is retained when rendered.
 	// - https://spec.openapis.org/oas/v3.1.0#security-requirement-object
 	Security []*base.SecurityRequirement `json:"security,omitempty" yaml:"security,omitempty"`
 	// Security []*base.SecurityRequirement `json:"-" yaml:"-"`
@@ -125,24 +131,37 @@ func NewDocument(document *lowv3.Document) *Document {
 	if orderedmap.Len(document.Extensions) > 0 {
 		d.Extensions = high.ExtractExtensions(document.Extensions)
 	}
-	if !document.Components.IsEmpty() {
-		d.Components = NewComponents(document.Components.Value)
-	}
-	if !document.Paths.IsEmpty() {
-		d.Paths = NewPaths(document.Paths.Value)
-	}
+	// components, paths and webhooks are the bulk of a document, so they are built at the same time.
+	datamodel.RunParallel(
+		func() {
+			if !document.Components.IsEmpty() {
+				d.Components = NewComponents(document.Components.Value)
+			}
+		},
+		func() {
+			if !document.Paths.IsEmpty() {
+				d.Paths = NewPaths(document.Paths.Value)
+			}
+		},
+		func() {
+			if !document.Webhooks.IsEmpty() {
+				d.Webhooks = low.FromReferenceMapWithFunc(document.Webhooks.Value, NewPathItem)
+			}
+		},
+	)
 	if !document.JsonSchemaDialect.IsEmpty() {
 		d.JsonSchemaDialect = document.JsonSchemaDialect.Value
 	}
-	if !document.Webhooks.IsEmpty() {
-		d.Webhooks = low.FromReferenceMapWithFunc(document.Webhooks.Value, NewPathItem)
-	}
 	if !document.Security.IsEmpty() {
 		var security []*base.SecurityRequirement
 		for s := range document.Security.Value {
 			security = append(security, base.NewSecurityRequirement(document.Security.Value[s].Value))
 		}
-		d.Security = security
+		if len(security) > 0 {
+			d.Security = security
+		} else {
+			d.Security = []*base.SecurityRequirement{} // security is defined, but empty.
+		}
 	}
 	return d
 }
@@ -152,11 +171,76 @@ func (d *Document) GoLow() *lowv3.Document {
 	return d.low
 }
 
+// GetPosition returns the position of the Document in the source specification, or nil if it is not available.
+func (d *Document) GetPosition() *high.Position {
+	return high.GetPosition(d.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Document is defined under, or nil if it is not available.
+func (d *Document) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(d.GoLow())
+}
+
 // GoLowUntyped returns the low-level Document that was used to create the high level one, however, it's untyped.
 func (d *Document) GoLowUntyped() any {
 	return d.low
 }
 
+// IsSecurityDefined will return true if the Document defines security, even if it's empty (security: []).
+func (d *Document) IsSecurityDefined() bool {
+	return d.Security != nil
+}
+
+// PathOperation is an Operation, along with the path and the HTTP method it is defined under.
+type PathOperation struct {
+	Path      string
+	Method    string
+	PathItem  *PathItem
+	Operation *Operation
+}
+
+// Operations returns an iterator over every operation defined in the document's paths, in the order they
+// were defined. Each operation is yielded along with the path and method it belongs to.
+func (d *Document) Operations() iter.Seq[*PathOperation] {
+	return func(yield func(*PathOperation) bool) {
+		if d.Paths == nil {
+			return
+		}
+		for path, pi := range d.Paths.PathItems.FromOldest() {
+			if pi == nil {
+				continue
+			}
+			for method, op := range pi.GetOperations().FromOldest() {
+				if !yield(&PathOperation{Path: path, Method: method, PathItem: pi, Operation: op}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FindOperation will locate an operation using its operationId, returning the operation, along with the
+// path and method it is defined under. The index is used to locate the operation if it's available, otherwise
+// all operations are scanned. If no operation is found, the operation returned will be nil.
+func (d *Document) FindOperation(operationId string) (*Operation, string, string) {
+	if d.Index != nil && d.Paths != nil {
+		path, method, ref := d.Index.FindOperationById(operationId)
+		if ref != nil {
+			if pi := d.Paths.PathItems.GetOrZero(path); pi != nil {
+				if op := pi.GetOperations().GetOrZero(method); op != nil && op.OperationId == operationId {
+					return op, path, method
+				}
+			}
+		}
+	}
+	for po := range d.Operations() {
+		if po.Operation.OperationId == operationId {
+			return po.Operation, po.Path, po.Method
+		}
+	}
+	return nil, "", ""
+}
+
 // Render will return a YAML representation of the Document object as a byte slice.
 func (d *Document) Render() ([]byte, error) {
 	return yaml.Marshal(d)
@@ -166,12 +250,41 @@ func (d *Document) Render() ([]byte, error) {
 // the rendering will use the original indention of the document.
 func (d *Document) RenderWithIndention(indent int) []byte {
 	var buf bytes.Buffer
-	yamlEncoder := yaml.NewEncoder(&buf)
-	yamlEncoder.SetIndent(indent)
-	_ = yamlEncoder.Encode(d)
+	_ = d.RenderWithIndentionTo(&buf, indent)
 	return buf.Bytes()
 }
 
+// RenderTo writes the same YAML as Render to w as it is encoded, so the complete output is never held in memory as
+// a byte slice. The rendered yaml.Node tree of the Document is still built in full before anything is written.
+func (d *Document) RenderTo(w io.Writer) error {
+	return d.RenderWithIndentionTo(w, 4)
+}
+
+// RenderWithIndentionTo writes the same YAML as RenderWithIndention to w as it is rendered.
+func (d *Document) RenderWithIndentionTo(w io.Writer, indent int) error {
+	yamlEncoder := yaml.NewEncoder(w)
+	yamlEncoder.SetIndent(indent)
+	if err := yamlEncoder.Encode(d); err != nil {
+		return err
+	}
+	return yamlEncoder.Close()
+}
+
+// RenderWithAnchors will return a YAML representation of the Document object as a byte slice, with the YAML
+// anchors and aliases of the original document restored. Anchors and aliases are only restored for parts of the
+// document that have not been modified, anything that has changed is rendered out in full.
+func (d *Document) RenderWithAnchors() ([]byte, error) {
+	rendered, err := d.MarshalYAML()
+	if err != nil {
+		return nil, err
+	}
+	node, _ := rendered.(*yaml.Node)
+	if node != nil && d.low != nil {
+		high.RestoreAnchors(node, d.low.GetAnchors())
+	}
+	return yaml.Marshal(rendered)
+}
+
 // RenderJSON will return a JSON representation of the Document object as a byte slice.
 func (d *Document) RenderJSON(indention string) ([]byte, error) {
 	nb := high.NewNodeBuilder(d, d.low)
@@ -183,6 +296,13 @@ func (d *Document) RenderJSON(indention string) ([]byte, error) {
 	return dat, nil
 }
 
+// RenderJSONTo writes the same JSON as RenderJSON to w as it is encoded, so the complete output is never held in
+// memory as a byte slice. The rendered yaml.Node tree of the Document is still built in full before anything is written.
+func (d *Document) RenderJSONTo(w io.Writer, indention string) error {
+	nb := high.NewNodeBuilder(d, d.low)
+	return json.YAMLNodeToJSONWriter(w, nb.Render(), indention)
+}
+
 func (d *Document) RenderInline() ([]byte, error) {
 	di, _ := d.MarshalYAMLInline()
 	return yaml.Marshal(di)
@@ -880,7 +880,7 @@ components:
 	assert.Equal(t, desired, strings.TrimSpace(string(r)))
 }
 
-func TestDocument_RenderJSONError(t *testing.T) {
+func TestDocument_RenderJSON_NumberLiterals(t *testing.T) {
 	// create a new document
 	jsonFile := `{"openapi":"3.0.0","info":{"title":"dummy","version":"1.0.0"},"paths":{"/dummy":{"post":{"requestBody":{"content":{"application/json":{"schema":{"type":"object","properties":{"value":{"type":"number","format":"decimal","multipleOf":0.01,"minimum":-999.99}}}}}},"responses":{"200":{"description":"OK"}}}}}}`
 
@@ -895,9 +895,175 @@ func TestDocument_RenderJSONError(t *testing.T) {
 	}
 	h := NewDocument(lowDoc)
 
-	// render the document to YAML and it should be identical.
+	// render the document to JSON, numbers keep their literal value.
 	r, e := h.RenderJSON(" ")
-	assert.Nil(t, r)
-	assert.Error(t, e)
-	assert.Equal(t, "yaml: cannot decode !!float `-999.99` as a !!int", e.Error())
+	assert.NoError(t, e)
+	assert.Contains(t, string(r), `"multipleOf": 0.01`)
+	assert.Contains(t, string(r), `"minimum": -999.99`)
+}
+
+func TestDocument_FindOperation(t *testing.T) {
+	initTest()
+	h := NewDocument(lowDoc)
+
+	op, path, method := h.FindOperation("getDressing")
+	assert.NotNil(t, op)
+	assert.Equal(t, "/dressings/{dressingId}", path)
+	assert.Equal(t, "get", method)
+	assert.Equal(t, "getDressing", op.OperationId)
+
+	op, path, method = h.FindOperation("createBurger")
+	assert.NotNil(t, op)
+	assert.Equal(t, "/burgers", path)
+	assert.Equal(t, "post", method)
+
+	op, path, method = h.FindOperation("pizza")
+	assert.Nil(t, op)
+	assert.Empty(t, path)
+	assert.Empty(t, method)
+}
+
+func TestDocument_FindOperation_NoIndex(t *testing.T) {
+	initTest()
+	h := NewDocument(lowDoc)
+	h.Index = nil
+
+	op, path, method := h.FindOperation("getAllDressings")
+	assert.NotNil(t, op)
+	assert.Equal(t, "/dressings", path)
+	assert.Equal(t, "get", method)
+}
+
+func TestDocument_Operations(t *testing.T) {
+	initTest()
+	h := NewDocument(lowDoc)
+
+	var ids []string
+	for po := range h.Operations() {
+		assert.NotNil(t, po.PathItem)
+		ids = append(ids, fmt.Sprintf("%s %s %s", po.Method, po.Path, po.Operation.OperationId))
+	}
+	assert.Equal(t, []string{
+		"post /burgers createBurger",
+		"get /burgers/{burgerId} locateBurger",
+		"get /burgers/{burgerId}/dressings listBurgerDressings",
+		"get /dressings/{dressingId} getDressing",
+		"get /dressings getAllDressings",
+	}, ids)
+
+	// stop early
+	count := 0
+	for range h.Operations() {
+		count++
+		break
+	}
+	assert.Equal(t, 1, count)
+
+	// no paths
+	count = 0
+	for range (&Document{}).Operations() {
+		count++
+	}
+	assert.Zero(t, count)
+}
+
+func TestDocument_Positions(t *testing.T) {
+	initTest()
+	h := NewDocument(lowDoc)
+
+	op, _, _ := h.FindOperation("createBurger")
+	pos := op.GetPosition()
+	assert.NotNil(t, pos)
+	assert.Equal(t, 65, pos.Line)
+	assert.Equal(t, 7, pos.Column)
+	assert.Equal(t, h.Index.GetSpecAbsolutePath(), pos.File)
+
+	pos = op.GetKeyPosition()
+	assert.Equal(t, 64, pos.Line)
+	assert.Equal(t, 5, pos.Column)
+
+	pos = h.Components.Schemas.GetOrZero("Burger").GetKeyPosition()
+	assert.NotNil(t, pos)
+	assert.Equal(t, 442, pos.Line)
+	assert.Equal(t, 5, pos.Column)
+
+	assert.NotNil(t, h.Info.GetPosition())
+	assert.NotNil(t, h.Servers[0].GetKeyPosition())
+
+	pos = h.GetPosition()
+	assert.Equal(t, 1, pos.Line)
+	assert.Equal(t, h.Index.GetSpecAbsolutePath(), pos.File)
+	assert.Nil(t, h.GetKeyPosition())
+
+	// no low model, no position
+	assert.Nil(t, (&Operation{}).GetPosition())
+	assert.Nil(t, (&Operation{}).GetKeyPosition())
+}
+
+func TestDocument_RenderWithAnchors(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/yaml-anchor.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+	lowDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+
+	d := NewDocument(lowDoc)
+	rendered, err := d.RenderWithAnchors()
+	assert.NoError(t, err)
+
+	out := string(rendered)
+	assert.Contains(t, out, "tags: &a1")
+	assert.Contains(t, out, "tags: *a1")
+	assert.Contains(t, out, "parameters: *id")
+	assert.Contains(t, out, "responses: *a2")
+	assert.Contains(t, out, "content: *example")
+
+	// modify the post operation, the tags alias can no longer be used.
+	post := d.Paths.PathItems.GetOrZero("/system/examples/{id}").Post
+	post.Tags = []string{"Changed"}
+	rendered, _ = d.RenderWithAnchors()
+	out = string(rendered)
+	assert.Contains(t, out, "tags: &a1")
+	assert.NotContains(t, out, "*a1")
+	assert.Contains(t, out, "- Changed")
+	assert.Contains(t, out, "parameters: *id")
+
+	// the regular render does not include anchors
+	rendered, _ = d.Render()
+	assert.NotContains(t, string(rendered), "&a1")
+}
+
+func TestNewDocument_EmptySecurity(t *testing.T) {
+	yml := `openapi: 3.1.0
+security: []
+paths:
+    /pizza:
+        get:
+            security: []
+        post:
+            operationId: bake`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+
+	h := NewDocument(lDoc)
+	assert.NotNil(t, h.Security)
+	assert.Len(t, h.Security, 0)
+	assert.True(t, h.IsSecurityDefined())
+
+	get := h.Paths.PathItems.GetOrZero("/pizza").Get
+	post := h.Paths.PathItems.GetOrZero("/pizza").Post
+	assert.True(t, get.IsSecurityDefined())
+	assert.False(t, post.IsSecurityDefined())
+
+	// the empty security is retained when rendered.
+	rend, _ := h.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+
+	// without any security, nil is used.
+	info, _ = datamodel.ExtractSpecInfo([]byte(`openapi: 3.1.0`))
+	lDoc, _ = lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	h = NewDocument(lDoc)
+	assert.Nil(t, h.Security)
+	assert.False(t, h.IsSecurityDefined())
 }
@@ -4,7 +4,10 @@
 package v3
 
 import (
+	"slices"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
 	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
@@ -13,16 +16,32 @@ import (
 )
 
 // Encoding represents an OpenAPI 3+ Encoding object
+//
+// Encoding, ItemEncoding and PrefixEncoding are OpenAPI 3.2+ properties, they describe the encoding of nested
+// multipart payloads, in the same way as the properties of the same name on the MediaType object.
 //   - https://spec.openapis.org/oas/v3.1.0#encoding-object
 type Encoding struct {
-	ContentType   string                           `json:"contentType,omitempty" yaml:"contentType,omitempty"`
-	Headers       *orderedmap.Map[string, *Header] `json:"headers,omitempty" yaml:"headers,omitempty"`
-	Style         string                           `json:"style,omitempty" yaml:"style,omitempty"`
-	Explode       *bool                            `json:"explode,omitempty" yaml:"explode,omitempty"`
-	AllowReserved bool                             `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"`
-	low           *lowv3.Encoding
+	ContentType    string                              `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	Headers        *orderedmap.Map[string, *Header]    `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Style          string                              `json:"style,omitempty" yaml:"style,omitempty"`
+	Explode        *bool                               `json:"explode,omitempty" yaml:"explode,omitempty"`
+	AllowReserved  bool                                `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"`
+	Encoding       *orderedmap.Map[string, *Encoding]  `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	ItemEncoding   *Encoding                           `json:"itemEncoding,omitempty" yaml:"itemEncoding,omitempty"`
+	PrefixEncoding []*Encoding                         `json:"prefixEncoding,omitempty" yaml:"prefixEncoding,omitempty"`
+	Extensions     *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low            *lowv3.Encoding
 }
 
+const (
+	// EncodingContentTypeBinary is the default content type of an encoded binary (or untyped) property.
+	EncodingContentTypeBinary = "application/octet-stream"
+	// EncodingContentTypeText is the default content type of an encoded primitive property.
+	EncodingContentTypeText = "text/plain"
+	// EncodingContentTypeJSON is the default content type of an encoded object property.
+	EncodingContentTypeJSON = "application/json"
+)
+
 // NewEncoding creates a new instance of Encoding from a low-level one.
 func NewEncoding(encoding *lowv3.Encoding) *Encoding {
 	e := new(Encoding)
@@ -34,9 +53,56 @@ func NewEncoding(encoding *lowv3.Encoding) *Encoding {
 	}
 	e.AllowReserved = encoding.AllowReserved.Value
 	e.Headers = ExtractHeaders(encoding.Headers.Value)
+	e.Encoding = ExtractEncoding(encoding.Encoding.Value)
+	if !encoding.ItemEncoding.IsEmpty() {
+		e.ItemEncoding = NewEncoding(encoding.ItemEncoding.Value)
+	}
+	for _, enc := range encoding.PrefixEncoding.Value {
+		e.PrefixEncoding = append(e.PrefixEncoding, NewEncoding(enc.Value))
+	}
+	e.Extensions = high.ExtractExtensions(encoding.Extensions)
 	return e
 }
 
+// GetContentType returns the ContentType of the Encoding if set, otherwise the default content type for a
+// property described by the supplied schema is returned (see DefaultEncodingContentType).
+func (e *Encoding) GetContentType(schema *base.Schema) string {
+	if e != nil && e.ContentType != "" {
+		return e.ContentType
+	}
+	return DefaultEncodingContentType(schema)
+}
+
+// DefaultEncodingContentType returns the content type used to encode a property when no Encoding contentType
+// is defined, based on the type of the property schema:
+//   - string with a format of binary, or no type: application/octet-stream
+//   - any other primitive type: text/plain
+//   - object: application/json
+//   - array: the default of the items schema, as each item is encoded as a separate part.
+func DefaultEncodingContentType(schema *base.Schema) string {
+	if schema == nil {
+		return EncodingContentTypeBinary
+	}
+	switch {
+	case slices.Contains(schema.Type, "object"):
+		return EncodingContentTypeJSON
+	case slices.Contains(schema.Type, "array"):
+		if schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil {
+			return DefaultEncodingContentType(schema.Items.A.Schema())
+		}
+		return EncodingContentTypeBinary
+	case slices.Contains(schema.Type, "string"):
+		if schema.Format == "binary" {
+			return EncodingContentTypeBinary
+		}
+		return EncodingContentTypeText
+	case slices.Contains(schema.Type, "integer"), slices.Contains(schema.Type, "number"),
+		slices.Contains(schema.Type, "boolean"):
+		return EncodingContentTypeText
+	}
+	return EncodingContentTypeBinary
+}
+
 // GoLow returns the low-level Encoding instance used to create the high-level one.
 func (e *Encoding) GoLow() *lowv3.Encoding {
 	return e.low
@@ -47,6 +113,16 @@ func (e *Encoding) GoLowUntyped() any {
 	return e.low
 }
 
+// GetPosition returns the position of the Encoding in the source specification, or nil if it is not available.
+func (e *Encoding) GetPosition() *high.Position {
+	return high.GetPosition(e.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Encoding is defined under, or nil if it is not available.
+func (e *Encoding) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(e.GoLow())
+}
+
 // Render will return a YAML representation of the Encoding object as a byte slice.
 func (e *Encoding) Render() ([]byte, error) {
 	return yaml.Marshal(e)
@@ -4,11 +4,16 @@
 package v3
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestEncoding_MarshalYAML(t *testing.T) {
@@ -69,3 +74,103 @@ style: simple`
 	assert.Equal(t, desired, strings.TrimSpace(string(rend)))
 
 }
+
+func TestNewEncoding_NestedEncoding(t *testing.T) {
+	yml := `contentType: multipart/mixed
+encoding:
+    metadata:
+        contentType: application/json
+itemEncoding:
+    contentType: image/png
+prefixEncoding:
+    - contentType: application/json
+    - contentType: text/csv`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var n v3.Encoding
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], nil)
+
+	enc := NewEncoding(&n)
+	assert.Equal(t, "application/json", enc.Encoding.GetOrZero("metadata").ContentType)
+	assert.Equal(t, "image/png", enc.ItemEncoding.ContentType)
+	assert.Len(t, enc.PrefixEncoding, 2)
+	assert.Equal(t, "text/csv", enc.PrefixEncoding[1].ContentType)
+
+	rend, _ := enc.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+}
+
+func TestNewEncoding_Extensions(t *testing.T) {
+	yml := `contentType: multipart/mixed
+x-pizza: pie`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var n v3.Encoding
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], nil)
+
+	enc := NewEncoding(&n)
+	assert.Equal(t, "pie", enc.Extensions.GetOrZero("x-pizza").Value)
+
+	rend, _ := enc.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+}
+
+func TestEncoding_MarshalYAML_NestedEncoding(t *testing.T) {
+	encoding := &Encoding{
+		ContentType: "multipart/mixed",
+		Encoding: orderedmap.ToOrderedMap(map[string]*Encoding{
+			"metadata": {ContentType: "application/json"},
+		}),
+		ItemEncoding:   &Encoding{ContentType: "image/png"},
+		PrefixEncoding: []*Encoding{{ContentType: "text/csv"}},
+	}
+
+	rend, _ := encoding.Render()
+
+	desired := `contentType: multipart/mixed
+encoding:
+    metadata:
+        contentType: application/json
+itemEncoding:
+    contentType: image/png
+prefixEncoding:
+    - contentType: text/csv`
+
+	assert.Equal(t, desired, strings.TrimSpace(string(rend)))
+}
+
+func TestEncoding_GetContentType(t *testing.T) {
+	str := &base.Schema{Type: []string{"string"}}
+	arr := &base.Schema{
+		Type:  []string{"array"},
+		Items: &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(&base.Schema{Type: []string{"object"}})},
+	}
+
+	assert.Equal(t, "image/png", (&Encoding{ContentType: "image/png"}).GetContentType(str))
+	assert.Equal(t, EncodingContentTypeText, (&Encoding{}).GetContentType(str))
+
+	var enc *Encoding
+	assert.Equal(t, EncodingContentTypeJSON, enc.GetContentType(arr))
+}
+
+func TestDefaultEncodingContentType(t *testing.T) {
+	assert.Equal(t, EncodingContentTypeBinary, DefaultEncodingContentType(nil))
+	assert.Equal(t, EncodingContentTypeBinary, DefaultEncodingContentType(&base.Schema{}))
+	assert.Equal(t, EncodingContentTypeText, DefaultEncodingContentType(&base.Schema{Type: []string{"string"}}))
+	assert.Equal(t, EncodingContentTypeBinary,
+		DefaultEncodingContentType(&base.Schema{Type: []string{"string"}, Format: "binary"}))
+	assert.Equal(t, EncodingContentTypeText, DefaultEncodingContentType(&base.Schema{Type: []string{"integer"}}))
+	assert.Equal(t, EncodingContentTypeText, DefaultEncodingContentType(&base.Schema{Type: []string{"boolean", "null"}}))
+	assert.Equal(t, EncodingContentTypeJSON, DefaultEncodingContentType(&base.Schema{Type: []string{"object"}}))
+	assert.Equal(t, EncodingContentTypeBinary, DefaultEncodingContentType(&base.Schema{Type: []string{"array"}}))
+	assert.Equal(t, EncodingContentTypeText, DefaultEncodingContentType(&base.Schema{
+		Type:  []string{"array"},
+		Items: &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(&base.Schema{Type: []string{"number"}})},
+	}))
+}
@@ -0,0 +1,226 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateExamples will validate every `example` and `examples` value defined in the document against the schema
+// that owns it. Parameters, headers, media types (in request bodies and responses) and schemas are all checked,
+// both in paths and in components, including every inline schema they hold. Referenced schemas are checked once,
+// where they are defined. Examples that use an externalValue are skipped.
+//
+// Each violation carries the location of the example in the document, the path of the offending value inside
+// the example and the node (with line and column) of the offending value.
+func (d *Document) ValidateExamples() []*base.ExampleViolation {
+	ev := new(exampleWalker)
+	if d.Paths != nil {
+		for path, pi := range d.Paths.PathItems.FromOldest() {
+			ev.pathItem(pi, fmt.Sprintf("$.paths['%s']", path))
+		}
+	}
+	for name, pi := range d.Webhooks.FromOldest() {
+		ev.pathItem(pi, fmt.Sprintf("$.webhooks['%s']", name))
+	}
+	if d.Components != nil {
+		c := d.Components
+		for name, s := range c.Schemas.FromOldest() {
+			if s != nil {
+				ev.schema(s.Schema(), fmt.Sprintf("$.components.schemas['%s']", name), 0)
+			}
+		}
+		for name, p := range c.Parameters.FromOldest() {
+			ev.parameter(p, fmt.Sprintf("$.components.parameters['%s']", name))
+		}
+		for name, h := range c.Headers.FromOldest() {
+			ev.header(h, fmt.Sprintf("$.components.headers['%s']", name))
+		}
+		for name, rb := range c.RequestBodies.FromOldest() {
+			if rb != nil {
+				ev.content(rb.Content, fmt.Sprintf("$.components.requestBodies['%s']", name))
+			}
+		}
+		for name, r := range c.Responses.FromOldest() {
+			ev.response(r, fmt.Sprintf("$.components.responses['%s']", name))
+		}
+		for name, pi := range c.PathItems.FromOldest() {
+			ev.pathItem(pi, fmt.Sprintf("$.components.pathItems['%s']", name))
+		}
+	}
+	return ev.violations
+}
+
+type exampleWalker struct {
+	violations []*base.ExampleViolation
+	seen       map[*PathItem]bool
+}
+
+func (ev *exampleWalker) check(sp *base.SchemaProxy, example *yaml.Node,
+	examples *orderedmap.Map[string, *base.Example], location string,
+) {
+	if sp == nil {
+		return
+	}
+	schema := sp.Schema()
+	if schema == nil {
+		return
+	}
+	if !sp.IsReference() {
+		// inline schemas are checked here, references are checked where they are defined.
+		ev.schema(schema, location+".schema", 0)
+	}
+	if example != nil {
+		ev.violations = append(ev.violations, base.ValidateExample(schema, example, location+".example")...)
+	}
+	for name, ex := range examples.FromOldest() {
+		if ex == nil || ex.Value == nil {
+			continue
+		}
+		ev.violations = append(ev.violations,
+			base.ValidateExample(schema, ex.Value, fmt.Sprintf("%s.examples['%s'].value", location, name))...)
+	}
+}
+
+func (ev *exampleWalker) pathItem(pi *PathItem, location string) {
+	if pi == nil {
+		return
+	}
+	if ev.seen == nil {
+		ev.seen = make(map[*PathItem]bool)
+	}
+	if ev.seen[pi] {
+		return
+	}
+	ev.seen[pi] = true
+	for i, p := range pi.Parameters {
+		ev.parameter(p, fmt.Sprintf("%s.parameters[%d]", location, i))
+	}
+	for method, op := range pi.GetOperations().FromOldest() {
+		ev.operation(op, fmt.Sprintf("%s.%s", location, method))
+	}
+}
+
+func (ev *exampleWalker) operation(op *Operation, location string) {
+	if op == nil {
+		return
+	}
+	for i, p := range op.Parameters {
+		ev.parameter(p, fmt.Sprintf("%s.parameters[%d]", location, i))
+	}
+	if op.RequestBody != nil {
+		ev.content(op.RequestBody.Content, location+".requestBody")
+	}
+	if op.Responses != nil {
+		for code, r := range op.Responses.Codes.FromOldest() {
+			ev.response(r, fmt.Sprintf("%s.responses['%s']", location, code))
+		}
+		ev.response(op.Responses.Default, location+".responses.default")
+	}
+	for name, cb := range op.Callbacks.FromOldest() {
+		if cb == nil {
+			continue
+		}
+		for expression, pi := range cb.Expression.FromOldest() {
+			ev.pathItem(pi, fmt.Sprintf("%s.callbacks['%s']['%s']", location, name, expression))
+		}
+	}
+}
+
+func (ev *exampleWalker) parameter(p *Parameter, location string) {
+	if p == nil {
+		return
+	}
+	ev.check(p.Schema, p.Example, p.Examples, location)
+	ev.content(p.Content, location)
+}
+
+func (ev *exampleWalker) header(h *Header, location string) {
+	if h == nil {
+		return
+	}
+	ev.check(h.Schema, h.Example, h.Examples, location)
+	ev.content(h.Content, location)
+}
+
+func (ev *exampleWalker) response(r *Response, location string) {
+	if r == nil {
+		return
+	}
+	for name, h := range r.Headers.FromOldest() {
+		ev.header(h, fmt.Sprintf("%s.headers['%s']", location, name))
+	}
+	ev.content(r.Content, location)
+}
+
+func (ev *exampleWalker) content(content *orderedmap.Map[string, *MediaType], location string) {
+	for mt, m := range content.FromOldest() {
+		if m != nil {
+			ev.check(m.Schema, m.Example, m.Examples, fmt.Sprintf("%s.content['%s']", location, mt))
+		}
+	}
+}
+
+// schema checks the example and examples of a schema against itself, and descends into every inline schema
+// below it (properties, items, allOf / anyOf / oneOf and so on).
+func (ev *exampleWalker) schema(s *base.Schema, location string, depth int) {
+	if s == nil || depth > 10 {
+		return
+	}
+	if s.Example != nil {
+		ev.violations = append(ev.violations, base.ValidateExample(s, s.Example, location+".example")...)
+	}
+	for i, ex := range s.Examples {
+		ev.violations = append(ev.violations,
+			base.ValidateExample(s, ex, fmt.Sprintf("%s.examples[%d]", location, i))...)
+	}
+	for name, prop := range s.Properties.FromOldest() {
+		ev.subSchema(prop, fmt.Sprintf("%s.properties['%s']", location, name), depth)
+	}
+	for name, prop := range s.PatternProperties.FromOldest() {
+		ev.subSchema(prop, fmt.Sprintf("%s.patternProperties['%s']", location, name), depth)
+	}
+	for name, ds := range s.DependentSchemas.FromOldest() {
+		ev.subSchema(ds, fmt.Sprintf("%s.dependentSchemas['%s']", location, name), depth)
+	}
+	for name, def := range s.Defs.FromOldest() {
+		ev.subSchema(def, fmt.Sprintf("%s.$defs['%s']", location, name), depth)
+	}
+	for _, list := range []struct {
+		keyword string
+		proxies []*base.SchemaProxy
+	}{{"allOf", s.AllOf}, {"anyOf", s.AnyOf}, {"oneOf", s.OneOf}, {"prefixItems", s.PrefixItems}} {
+		for i, sp := range list.proxies {
+			ev.subSchema(sp, fmt.Sprintf("%s.%s[%d]", location, list.keyword, i), depth)
+		}
+	}
+	if s.Items != nil && s.Items.IsA() {
+		ev.subSchema(s.Items.A, location+".items", depth)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.IsA() {
+		ev.subSchema(s.AdditionalProperties.A, location+".additionalProperties", depth)
+	}
+	if s.UnevaluatedProperties != nil && s.UnevaluatedProperties.IsA() {
+		ev.subSchema(s.UnevaluatedProperties.A, location+".unevaluatedProperties", depth)
+	}
+	ev.subSchema(s.Not, location+".not", depth)
+	ev.subSchema(s.Contains, location+".contains", depth)
+	ev.subSchema(s.If, location+".if", depth)
+	ev.subSchema(s.Then, location+".then", depth)
+	ev.subSchema(s.Else, location+".else", depth)
+	ev.subSchema(s.PropertyNames, location+".propertyNames", depth)
+	ev.subSchema(s.UnevaluatedItems, location+".unevaluatedItems", depth)
+}
+
+// subSchema checks an inline schema below a schema, references are checked where they are defined.
+func (ev *exampleWalker) subSchema(sp *base.SchemaProxy, location string, depth int) {
+	if sp == nil || sp.IsReference() {
+		return
+	}
+	ev.schema(sp.Schema(), location, depth+1)
+}
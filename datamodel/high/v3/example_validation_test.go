@@ -0,0 +1,274 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildExampleTestDocument(t *testing.T, spec string) *Document {
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	require.NoError(t, err)
+	low, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	return NewDocument(low)
+}
+
+func TestDocument_ValidateExamples(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          schema:
+            type: integer
+            minimum: 1
+          example: 0
+        - name: kind
+          in: query
+          schema:
+            type: string
+            enum: [cat, dog]
+          examples:
+            good:
+              value: cat
+            bad:
+              value: fish
+      responses:
+        "200":
+          description: ok
+          headers:
+            X-Rate:
+              schema:
+                type: integer
+              example: lots
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+              example:
+                name: fluffy
+                age: -1
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name, age]
+      additionalProperties: false
+      properties:
+        name:
+          type: string
+          minLength: 2
+        age:
+          type: integer
+          minimum: 0
+      example:
+        name: f
+        age: 2
+        color: red
+  requestBodies:
+    NewPet:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+          examples:
+            missing:
+              value:
+                name: rex`
+
+	doc := buildExampleTestDocument(t, spec)
+	violations := doc.ValidateExamples()
+
+	type v struct {
+		location, path string
+		line           int
+	}
+	var found []v
+	for _, vi := range violations {
+		found = append(found, v{vi.Location, vi.Path, vi.Line})
+	}
+
+	assert.Contains(t, found, v{"$.paths['/pets/{id}'].get.parameters[0].example", "$", 11})
+	assert.Contains(t, found, v{"$.paths['/pets/{id}'].get.parameters[1].examples['bad'].value", "$", 21})
+	assert.Contains(t, found, v{"$.paths['/pets/{id}'].get.responses['200'].headers['X-Rate'].example", "$", 29})
+	assert.Contains(t, found, v{"$.paths['/pets/{id}'].get.responses['200'].content['application/json'].example", "$.age", 36})
+	assert.Contains(t, found, v{"$.components.schemas['Pet'].example", "$.name", 51})
+	assert.Contains(t, found, v{"$.components.schemas['Pet'].example", "$.color", 53})
+	assert.Contains(t, found, v{"$.components.requestBodies['NewPet'].content['application/json'].examples['missing'].value", "$", 63})
+	assert.Len(t, violations, 7)
+	assert.NotEmpty(t, violations[0].Error())
+}
+
+func TestDocument_ValidateExamples_Valid(t *testing.T) {
+	spec := `openapi: 3.0.3
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                tags:
+                  type: array
+                  uniqueItems: true
+                  items:
+                    type: string
+                nickname:
+                  type: string
+                  nullable: true
+                score:
+                  type: number
+                  multipleOf: 0.5
+                  maximum: 10
+                  exclusiveMaximum: true
+                kind:
+                  oneOf:
+                    - type: string
+                    - type: integer
+            example:
+              tags: [a, b]
+              nickname: null
+              score: 9.5
+              kind: 3
+      responses:
+        default:
+          description: ok`
+
+	doc := buildExampleTestDocument(t, spec)
+	assert.Empty(t, doc.ValidateExamples())
+}
+
+func TestDocument_ValidateExamples_Composition(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      oneOf:
+        - type: number
+        - type: integer
+      example: 3
+    Other:
+      anyOf:
+        - type: string
+        - type: boolean
+      not:
+        const: nope
+      examples:
+        - 12
+        - nope
+    List:
+      type: array
+      prefixItems:
+        - type: string
+      items: false
+      minItems: 1
+      example: [a, b]`
+
+	doc := buildExampleTestDocument(t, spec)
+	violations := doc.ValidateExamples()
+
+	var messages []string
+	for _, vi := range violations {
+		messages = append(messages, vi.Location+" "+vi.Message)
+	}
+	assert.Equal(t, []string{
+		"$.components.schemas['Thing'].example value must match exactly one oneOf schema, but matched 2",
+		"$.components.schemas['Other'].examples[0] expected type 'boolean', but got 'integer'",
+		"$.components.schemas['Other'].examples[1] value must not match the 'not' schema",
+		"$.components.schemas['List'].example additional array items are not allowed",
+	}, messages)
+}
+
+func TestDocument_ValidateExamples_InlineSchemas(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            example: many
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: array
+              items:
+                type: object
+                properties:
+                  age:
+                    type: integer
+                    minimum: 0
+                    example: -1
+      responses:
+        "200":
+          description: ok
+          headers:
+            X-Rate:
+              schema:
+                allOf:
+                  - type: string
+                    examples: [fast, 3]
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object`
+
+	doc := buildExampleTestDocument(t, spec)
+	violations := doc.ValidateExamples()
+
+	var messages []string
+	for _, vi := range violations {
+		messages = append(messages, vi.Location+" "+vi.Message)
+	}
+	assert.Equal(t, []string{
+		"$.paths['/pets'].post.parameters[0].schema.example expected type 'integer', but got 'string'",
+		"$.paths['/pets'].post.requestBody.content['application/json'].schema.items.properties['age'].example " +
+			"value -1 is less than minimum 0",
+		"$.paths['/pets'].post.responses['200'].headers['X-Rate'].schema.allOf[0].examples[1] " +
+			"expected type 'string', but got 'integer'",
+	}, messages)
+}
+
+func TestDocument_ValidateExamples_BooleanSchema(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: anything
+          in: query
+          schema: true
+          example: 1
+        - name: nothing
+          in: query
+          schema: false
+          example: 1
+      responses:
+        default:
+          description: ok`
+
+	doc := buildExampleTestDocument(t, spec)
+	violations := doc.ValidateExamples()
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "$.paths['/pets'].get.parameters[1].example", violations[0].Location)
+		assert.Equal(t, "value is not allowed", violations[0].Message)
+	}
+}
@@ -4,6 +4,9 @@
 package v3
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -67,6 +70,94 @@ func (h *Header) GoLowUntyped() any {
 	return h.low
 }
 
+// GetPosition returns the position of the Header in the source specification, or nil if it is not available.
+func (h *Header) GetPosition() *high.Position {
+	return high.GetPosition(h.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Header is defined under, or nil if it is not available.
+func (h *Header) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(h.GoLow())
+}
+
+// Validate will check the Header is valid. A Header uses either a Schema or Content (but not both), Content must
+// contain exactly one media type, and Example and Examples are mutually exclusive. All problems found are returned.
+func (h *Header) Validate() error {
+	var errs []error
+	if h.Schema != nil && h.Content != nil {
+		errs = append(errs, errors.New("header cannot have both a 'schema' and 'content', they are mutually exclusive"))
+	}
+	if h.Content != nil && h.Content.Len() != 1 {
+		errs = append(errs, fmt.Errorf("header 'content' must contain exactly one media type, found %d", h.Content.Len()))
+	}
+	if h.Example != nil && h.Examples != nil && h.Examples.Len() > 0 {
+		errs = append(errs, errors.New("header cannot have both an 'example' and 'examples', they are mutually exclusive"))
+	}
+	return errors.Join(errs...)
+}
+
+// GetMediaType returns the media type name and MediaType of a Header that uses Content instead of a Schema. If the
+// Header has no Content, an empty string and nil are returned.
+func (h *Header) GetMediaType() (string, *MediaType) {
+	if h.Content == nil {
+		return "", nil
+	}
+	for k, v := range h.Content.FromOldest() {
+		return k, v
+	}
+	return "", nil
+}
+
+// GetExample returns the example value for the Header, following the precedence defined by the specification.
+// Example is used first, then the first of Examples. If neither is set, the example of the media type (for
+// headers using Content) or the schema is used. nil is returned if no example can be found.
+func (h *Header) GetExample() *yaml.Node {
+	if h.Example != nil {
+		return h.Example
+	}
+	if ex := firstExampleValue(h.Examples); ex != nil {
+		return ex
+	}
+	if _, mt := h.GetMediaType(); mt != nil {
+		if mt.Example != nil {
+			return mt.Example
+		}
+		if ex := firstExampleValue(mt.Examples); ex != nil {
+			return ex
+		}
+		if mt.Schema != nil {
+			return schemaExample(mt.Schema)
+		}
+	}
+	if h.Schema != nil {
+		return schemaExample(h.Schema)
+	}
+	return nil
+}
+
+func firstExampleValue(examples *orderedmap.Map[string, *highbase.Example]) *yaml.Node {
+	for ex := range examples.ValuesFromOldest() {
+		if ex != nil && ex.Value != nil {
+			return ex.Value
+		}
+	}
+	return nil
+}
+
+func schemaExample(proxy *highbase.SchemaProxy) *yaml.Node {
+	sch := proxy.Schema()
+	if sch == nil {
+		return nil
+	}
+	if sch.Example != nil {
+		return sch.Example
+	}
+	if len(sch.Examples) > 0 {
+		return sch.Examples[0]
+	}
+	return nil
+}
+
 // ExtractHeaders will extract a hard to navigate low-level Header map, into simple high-level one.
 func ExtractHeaders(elements *orderedmap.Map[lowmodel.KeyReference[string], lowmodel.ValueReference[*lowv3.Header]]) *orderedmap.Map[string, *Header] {
 	return low.FromReferenceMapWithFunc(elements, NewHeader)
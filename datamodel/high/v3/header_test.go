@@ -50,3 +50,45 @@ x-burgers: why not?`
 
 	assert.Equal(t, desired, strings.TrimSpace(string(rend)))
 }
+
+func TestHeader_Validate(t *testing.T) {
+	schema := base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})
+	content := orderedmap.ToOrderedMap(map[string]*MediaType{"application/json": {Schema: schema}})
+
+	assert.NoError(t, (&Header{Schema: schema}).Validate())
+	assert.NoError(t, (&Header{Content: content}).Validate())
+	assert.Error(t, (&Header{Schema: schema, Content: content}).Validate())
+	assert.Error(t, (&Header{Content: orderedmap.New[string, *MediaType]()}).Validate())
+	assert.Error(t, (&Header{
+		Example:  utils.CreateStringNode("one"),
+		Examples: orderedmap.ToOrderedMap(map[string]*base.Example{"two": {Value: utils.CreateStringNode("two")}}),
+	}).Validate())
+}
+
+func TestHeader_GetMediaType(t *testing.T) {
+	name, mt := (&Header{}).GetMediaType()
+	assert.Empty(t, name)
+	assert.Nil(t, mt)
+
+	h := &Header{Content: orderedmap.ToOrderedMap(map[string]*MediaType{"text/plain": {}})}
+	name, mt = h.GetMediaType()
+	assert.Equal(t, "text/plain", name)
+	assert.NotNil(t, mt)
+}
+
+func TestHeader_GetExample(t *testing.T) {
+	schema := base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}, Example: utils.CreateStringNode("schema")})
+	examples := orderedmap.ToOrderedMap(map[string]*base.Example{"named": {Value: utils.CreateStringNode("named")}})
+
+	assert.Nil(t, (&Header{}).GetExample())
+	assert.Equal(t, "schema", (&Header{Schema: schema}).GetExample().Value)
+	assert.Equal(t, "named", (&Header{Schema: schema, Examples: examples}).GetExample().Value)
+	assert.Equal(t, "header", (&Header{Schema: schema, Example: utils.CreateStringNode("header")}).GetExample().Value)
+
+	content := orderedmap.ToOrderedMap(map[string]*MediaType{"application/json": {Schema: schema}})
+	assert.Equal(t, "schema", (&Header{Content: content}).GetExample().Value)
+	content.GetOrZero("application/json").Examples = examples
+	assert.Equal(t, "named", (&Header{Content: content}).GetExample().Value)
+	content.GetOrZero("application/json").Example = utils.CreateStringNode("media")
+	assert.Equal(t, "media", (&Header{Content: content}).GetExample().Value)
+}
@@ -60,6 +60,16 @@ func (l *Link) GoLowUntyped() any {
 	return l.low
 }
 
+// GetPosition returns the position of the Link in the source specification, or nil if it is not available.
+func (l *Link) GetPosition() *high.Position {
+	return high.GetPosition(l.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Link is defined under, or nil if it is not available.
+func (l *Link) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(l.GoLow())
+}
+
 // Render will return a YAML representation of the Link object as a byte slice.
 func (l *Link) Render() ([]byte, error) {
 	return yaml.Marshal(l)
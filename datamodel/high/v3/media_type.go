@@ -16,14 +16,21 @@ import (
 // MediaType represents a high-level OpenAPI MediaType object that is backed by a low-level one.
 //
 // Each Media Type Object provides schema and examples for the media type identified by its key.
+//
+// ItemSchema, ItemEncoding and PrefixEncoding are OpenAPI 3.2+ properties used to describe sequential media types
+// such as server-sent events (text/event-stream) or JSON Lines (application/jsonl). ItemSchema describes each
+// item in the stream, rather than the stream as a whole.
 //   - https://spec.openapis.org/oas/v3.1.0#media-type-object
 type MediaType struct {
-	Schema     *base.SchemaProxy                      `json:"schema,omitempty" yaml:"schema,omitempty"`
-	Example    *yaml.Node                             `json:"example,omitempty" yaml:"example,omitempty"`
-	Examples   *orderedmap.Map[string, *base.Example] `json:"examples,omitempty" yaml:"examples,omitempty"`
-	Encoding   *orderedmap.Map[string, *Encoding]     `json:"encoding,omitempty" yaml:"encoding,omitempty"`
-	Extensions *orderedmap.Map[string, *yaml.Node]    `json:"-" yaml:"-"`
-	low        *low.MediaType
+	Schema         *base.SchemaProxy                      `json:"schema,omitempty" yaml:"schema,omitempty"`
+	ItemSchema     *base.SchemaProxy                      `json:"itemSchema,omitempty" yaml:"itemSchema,omitempty"`
+	Example        *yaml.Node                             `json:"example,omitempty" yaml:"example,omitempty"`
+	Examples       *orderedmap.Map[string, *base.Example] `json:"examples,omitempty" yaml:"examples,omitempty"`
+	Encoding       *orderedmap.Map[string, *Encoding]     `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	ItemEncoding   *Encoding                              `json:"itemEncoding,omitempty" yaml:"itemEncoding,omitempty"`
+	PrefixEncoding []*Encoding                            `json:"prefixEncoding,omitempty" yaml:"prefixEncoding,omitempty"`
+	Extensions     *orderedmap.Map[string, *yaml.Node]    `json:"-" yaml:"-"`
+	low            *low.MediaType
 }
 
 // NewMediaType will create a new high-level MediaType instance from a low-level one.
@@ -37,6 +44,15 @@ func NewMediaType(mediaType *low.MediaType) *MediaType {
 	m.Examples = base.ExtractExamples(mediaType.Examples.Value)
 	m.Extensions = high.ExtractExtensions(mediaType.Extensions)
 	m.Encoding = ExtractEncoding(mediaType.Encoding.Value)
+	if !mediaType.ItemSchema.IsEmpty() {
+		m.ItemSchema = base.NewSchemaProxy(&mediaType.ItemSchema)
+	}
+	if !mediaType.ItemEncoding.IsEmpty() {
+		m.ItemEncoding = NewEncoding(mediaType.ItemEncoding.Value)
+	}
+	for _, enc := range mediaType.PrefixEncoding.Value {
+		m.PrefixEncoding = append(m.PrefixEncoding, NewEncoding(enc.Value))
+	}
 	return m
 }
 
@@ -50,6 +66,16 @@ func (m *MediaType) GoLowUntyped() any {
 	return m.low
 }
 
+// GetPosition returns the position of the MediaType in the source specification, or nil if it is not available.
+func (m *MediaType) GetPosition() *high.Position {
+	return high.GetPosition(m.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the MediaType is defined under, or nil if it is not available.
+func (m *MediaType) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(m.GoLow())
+}
+
 // Render will return a YAML representation of the MediaType object as a byte slice.
 func (m *MediaType) Render() ([]byte, error) {
 	return yaml.Marshal(m)
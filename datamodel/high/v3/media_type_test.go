@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/index"
@@ -189,3 +190,48 @@ func TestMediaType_Examples_NotFromSchema(t *testing.T) {
 
 	assert.Equal(t, 0, orderedmap.Len(r.Examples))
 }
+
+func TestMediaType_ItemSchema(t *testing.T) {
+	yml := `itemSchema:
+    type: object
+    properties:
+        data:
+            type: string
+itemEncoding:
+    contentType: application/json
+prefixEncoding:
+    - contentType: text/plain`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3.MediaType
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	mt := NewMediaType(&n)
+	assert.Nil(t, mt.Schema)
+	assert.Equal(t, []string{"object"}, mt.ItemSchema.Schema().Type)
+	assert.Equal(t, "application/json", mt.ItemEncoding.ContentType)
+	assert.Len(t, mt.PrefixEncoding, 1)
+	assert.Equal(t, "text/plain", mt.PrefixEncoding[0].ContentType)
+
+	rend, _ := mt.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+}
+
+func TestMediaType_ItemSchema_Mutate(t *testing.T) {
+	mt := &MediaType{
+		ItemSchema: base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+		ItemEncoding: &Encoding{
+			ContentType: "text/plain",
+		},
+	}
+
+	rend, _ := mt.Render()
+	assert.Equal(t, `itemSchema:
+    type: string
+itemEncoding:
+    contentType: text/plain`, strings.TrimSpace(string(rend)))
+}
@@ -12,14 +12,17 @@ import (
 )
 
 // OAuthFlow represents a high-level OpenAPI 3+ OAuthFlow object that is backed by a low-level one.
+//
+// DeviceAuthorizationUrl is an OpenAPI 3.2+ property, used by the device authorization flow.
 //   - https://spec.openapis.org/oas/v3.1.0#oauth-flow-object
 type OAuthFlow struct {
-	AuthorizationUrl string                              `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
-	TokenUrl         string                              `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
-	RefreshUrl       string                              `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
-	Scopes           *orderedmap.Map[string, string]     `json:"scopes,renderZero" yaml:"scopes,renderZero"`
-	Extensions       *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
-	low              *lowv3.OAuthFlow
+	AuthorizationUrl       string                              `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
+	DeviceAuthorizationUrl string                              `json:"deviceAuthorizationUrl,omitempty" yaml:"deviceAuthorizationUrl,omitempty"`
+	TokenUrl               string                              `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
+	RefreshUrl             string                              `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
+	Scopes                 *orderedmap.Map[string, string]     `json:"scopes,renderZero" yaml:"scopes,renderZero"`
+	Extensions             *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low                    *lowv3.OAuthFlow
 }
 
 // NewOAuthFlow creates a new high-level OAuthFlow instance from a low-level one.
@@ -29,6 +32,7 @@ func NewOAuthFlow(flow *lowv3.OAuthFlow) *OAuthFlow {
 	o.TokenUrl = flow.TokenUrl.Value
 	o.AuthorizationUrl = flow.AuthorizationUrl.Value
 	o.RefreshUrl = flow.RefreshUrl.Value
+	o.DeviceAuthorizationUrl = flow.DeviceAuthorizationUrl.Value
 	o.Scopes = low.FromReferenceMap(flow.Scopes.Value)
 	o.Extensions = high.ExtractExtensions(flow.Extensions)
 	return o
@@ -44,6 +48,16 @@ func (o *OAuthFlow) GoLowUntyped() any {
 	return o.low
 }
 
+// GetPosition returns the position of the OAuthFlow in the source specification, or nil if it is not available.
+func (o *OAuthFlow) GetPosition() *high.Position {
+	return high.GetPosition(o.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the OAuthFlow is defined under, or nil if it is not available.
+func (o *OAuthFlow) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(o.GoLow())
+}
+
 // Render will return a YAML representation of the OAuthFlow object as a byte slice.
 func (o *OAuthFlow) Render() ([]byte, error) {
 	return yaml.Marshal(o)
@@ -11,14 +11,17 @@ import (
 )
 
 // OAuthFlows represents a high-level OpenAPI 3+ OAuthFlows object that is backed by a low-level one.
+//
+// DeviceAuthorization is an OpenAPI 3.2+ property for the OAuth 2.0 Device Authorization flow (RFC8628).
 //   - https://spec.openapis.org/oas/v3.1.0#oauth-flows-object
 type OAuthFlows struct {
-	Implicit          *OAuthFlow                          `json:"implicit,omitempty" yaml:"implicit,omitempty"`
-	Password          *OAuthFlow                          `json:"password,omitempty" yaml:"password,omitempty"`
-	ClientCredentials *OAuthFlow                          `json:"clientCredentials,omitempty" yaml:"clientCredentials,omitempty"`
-	AuthorizationCode *OAuthFlow                          `json:"authorizationCode,omitempty" yaml:"authorizationCode,omitempty"`
-	Extensions        *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
-	low               *low.OAuthFlows
+	Implicit            *OAuthFlow                          `json:"implicit,omitempty" yaml:"implicit,omitempty"`
+	Password            *OAuthFlow                          `json:"password,omitempty" yaml:"password,omitempty"`
+	ClientCredentials   *OAuthFlow                          `json:"clientCredentials,omitempty" yaml:"clientCredentials,omitempty"`
+	AuthorizationCode   *OAuthFlow                          `json:"authorizationCode,omitempty" yaml:"authorizationCode,omitempty"`
+	DeviceAuthorization *OAuthFlow                          `json:"deviceAuthorization,omitempty" yaml:"deviceAuthorization,omitempty"`
+	Extensions          *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low                 *low.OAuthFlows
 }
 
 // NewOAuthFlows creates a new high-level OAuthFlows instance from a low-level one.
@@ -37,8 +40,8 @@ func NewOAuthFlows(flows *low.OAuthFlows) *OAuthFlows {
 	if !flows.AuthorizationCode.IsEmpty() {
 		o.AuthorizationCode = NewOAuthFlow(flows.AuthorizationCode.Value)
 	}
-	if !flows.Implicit.IsEmpty() {
-		o.Implicit = NewOAuthFlow(flows.Implicit.Value)
+	if !flows.DeviceAuthorization.IsEmpty() {
+		o.DeviceAuthorization = NewOAuthFlow(flows.DeviceAuthorization.Value)
 	}
 	o.Extensions = high.ExtractExtensions(flows.Extensions)
 	return o
@@ -54,6 +57,16 @@ func (o *OAuthFlows) GoLowUntyped() any {
 	return o.low
 }
 
+// GetPosition returns the position of the OAuthFlows in the source specification, or nil if it is not available.
+func (o *OAuthFlows) GetPosition() *high.Position {
+	return high.GetPosition(o.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the OAuthFlows is defined under, or nil if it is not available.
+func (o *OAuthFlows) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(o.GoLow())
+}
+
 // Render will return a YAML representation of the OAuthFlows object as a byte slice.
 func (o *OAuthFlows) Render() ([]byte, error) {
 	return yaml.Marshal(o)
@@ -100,6 +100,31 @@ func (o *Operation) GoLowUntyped() any {
 	return o.low
 }
 
+// GetPosition returns the position of the Operation in the source specification, or nil if it is not available.
+func (o *Operation) GetPosition() *high.Position {
+	return high.GetPosition(o.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Operation is defined under, or nil if it is not available.
+func (o *Operation) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(o.GoLow())
+}
+
+// IsSecurityDefined will return true if the Operation defines its own security, which overrides the security of the
+// Document. An empty Security (security: []) is defined, and removes all security from the Operation.
+func (o *Operation) IsSecurityDefined() bool {
+	return o.Security != nil
+}
+
+// GetEffectiveSecurity returns the security requirements that apply to the Operation. If the Operation defines
+// its own security, it is returned (even if empty), otherwise the security of the supplied Document is returned.
+func (o *Operation) GetEffectiveSecurity(document *Document) []*base.SecurityRequirement {
+	if o.IsSecurityDefined() || document == nil {
+		return o.Security
+	}
+	return document.Security
+}
+
 // Render will return a YAML representation of the Operation object as a byte slice.
 func (o *Operation) Render() ([]byte, error) {
 	return yaml.Marshal(o)
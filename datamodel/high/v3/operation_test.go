@@ -161,3 +161,17 @@ func TestOperation_NoSecurity(t *testing.T) {
 
 	assert.Nil(t, r.Security)
 }
+
+func TestOperation_GetEffectiveSecurity(t *testing.T) {
+	doc := &Document{Security: []*base.SecurityRequirement{{}}}
+
+	op := &Operation{}
+	assert.False(t, op.IsSecurityDefined())
+	assert.Equal(t, doc.Security, op.GetEffectiveSecurity(doc))
+	assert.Nil(t, op.GetEffectiveSecurity(nil))
+
+	op.Security = []*base.SecurityRequirement{}
+	assert.True(t, op.IsSecurityDefined())
+	assert.NotNil(t, op.GetEffectiveSecurity(doc))
+	assert.Len(t, op.GetEffectiveSecurity(doc), 0)
+}
@@ -70,6 +70,16 @@ func (p *Parameter) GoLowUntyped() any {
 	return p.low
 }
 
+// GetPosition returns the position of the Parameter in the source specification, or nil if it is not available.
+func (p *Parameter) GetPosition() *high.Position {
+	return high.GetPosition(p.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Parameter is defined under, or nil if it is not available.
+func (p *Parameter) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(p.GoLow())
+}
+
 // Render will return a YAML representation of the Encoding object as a byte slice.
 func (p *Parameter) Render() ([]byte, error) {
 	return yaml.Marshal(p)
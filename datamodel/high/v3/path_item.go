@@ -133,6 +133,16 @@ func (p *PathItem) GoLowUntyped() any {
 	return p.low
 }
 
+// GetPosition returns the position of the PathItem in the source specification, or nil if it is not available.
+func (p *PathItem) GetPosition() *high.Position {
+	return high.GetPosition(p.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the PathItem is defined under, or nil if it is not available.
+func (p *PathItem) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(p.GoLow())
+}
+
 func (p *PathItem) GetOperations() *orderedmap.Map[string, *Operation] {
 	o := orderedmap.New[string, *Operation]()
 
@@ -4,7 +4,11 @@
 package v3
 
 import (
+	"net/url"
+	"regexp"
 	"sort"
+	"strings"
+	"sync"
 
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high"
@@ -25,6 +29,10 @@ type Paths struct {
 	PathItems  *orderedmap.Map[string, *PathItem]  `json:"-" yaml:"-"`
 	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low        *v3low.Paths
+
+	// templates holds the compiled template of every path, compiled the first time a request path is matched.
+	templates     map[string]*pathTemplate
+	templatesOnce sync.Once
 }
 
 // NewPaths creates a new high-level instance of Paths from a low-level one.
@@ -63,6 +71,168 @@ func (p *Paths) GoLowUntyped() any {
 	return p.low
 }
 
+// GetPosition returns the position of the Paths in the source specification, or nil if it is not available.
+func (p *Paths) GetPosition() *high.Position {
+	return high.GetPosition(p.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Paths is defined under, or nil if it is not available.
+func (p *Paths) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(p.GoLow())
+}
+
+// PathMatch is the result of matching a concrete request path and method against the Paths of a document.
+type PathMatch struct {
+	// Path is the templated path that was matched, e.g. /users/{userId}
+	Path string
+
+	// PathItem is the PathItem defined for the matched path.
+	PathItem *PathItem
+
+	// Method is the lower case HTTP method of the request.
+	Method string
+
+	// Operation is the Operation defined for the method, it will be nil if the path matched, but
+	// the PathItem has no operation for the method (a 405 Method Not Allowed, in HTTP terms).
+	Operation *Operation
+
+	// Parameters holds the (unescaped) values extracted for each templated path parameter, keyed by name.
+	Parameters map[string]string
+}
+
+var pathTemplateParamRegex = regexp.MustCompile(`\{([^{}]+)}`)
+
+type pathMatcher struct {
+	path     string
+	pathItem *PathItem
+	*pathTemplate
+}
+
+// pathTemplate is the compiled form of a templated path, the templates of a Paths object are compiled once, and are
+// kept by it.
+type pathTemplate struct {
+	regex    *regexp.Regexp
+	names    []string
+	literals []bool // true for every segment that contains no template expressions.
+}
+
+// template returns the compiled template of a path. The templates of every path are compiled the first time it's
+// called, paths added after that are compiled every time they are matched.
+func (p *Paths) template(path string) *pathTemplate {
+	p.templatesOnce.Do(func() {
+		p.templates = make(map[string]*pathTemplate, orderedmap.Len(p.PathItems))
+		for k := range p.PathItems.KeysFromOldest() {
+			p.templates[k] = compilePathTemplate(k)
+		}
+	})
+	if pt, ok := p.templates[path]; ok {
+		return pt
+	}
+	return compilePathTemplate(path)
+}
+
+func compilePathTemplate(path string) *pathTemplate {
+	pt := &pathTemplate{}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, seg := range segments {
+		expr.WriteString("/")
+		locs := pathTemplateParamRegex.FindAllStringSubmatchIndex(seg, -1)
+		pt.literals = append(pt.literals, len(locs) == 0)
+		last := 0
+		for _, loc := range locs {
+			expr.WriteString(regexp.QuoteMeta(seg[last:loc[0]]))
+			expr.WriteString("([^/]+?)")
+			pt.names = append(pt.names, seg[loc[2]:loc[3]])
+			last = loc[1]
+		}
+		expr.WriteString(regexp.QuoteMeta(seg[last:]))
+	}
+	expr.WriteString("$")
+	pt.regex = regexp.MustCompile(expr.String())
+	return pt
+}
+
+// moreSpecific returns true if the matcher should take precedence over another. Paths with literal segments
+// take precedence over templated ones, comparing segment by segment from left to right.
+func (pm *pathMatcher) moreSpecific(other *pathMatcher) bool {
+	for i := range pm.literals {
+		if i >= len(other.literals) {
+			break
+		}
+		if pm.literals[i] != other.literals[i] {
+			return pm.literals[i]
+		}
+	}
+	return false
+}
+
+// MatchPath will match a concrete request path and HTTP method (e.g. GET /users/42/pets/7) against the templated
+// paths defined in the document, returning the PathItem and Operation that serves the request, as well as the
+// values of any templated path parameters.
+//
+// Concrete (non-templated) paths are matched before their templated counterparts, as required by the OpenAPI
+// specification. When multiple templated paths match, the one with the most leading literal segments wins, with
+// any remaining ties decided by the order the paths are defined in the document. Paths that define an operation
+// for the method are preferred over those that do not.
+//
+// The request path is expected to be relative to the server URL, any query string or fragment is ignored.
+// If no path matches, nil is returned.
+func (p *Paths) MatchPath(method, requestPath string) *PathMatch {
+	if p == nil || p.PathItems == nil {
+		return nil
+	}
+	if i := strings.IndexAny(requestPath, "?#"); i >= 0 {
+		requestPath = requestPath[:i]
+	}
+	requestPath = "/" + strings.Trim(requestPath, "/")
+	method = strings.ToLower(method)
+
+	var candidates []*pathMatcher
+	for path, pi := range p.PathItems.FromOldest() {
+		if pi == nil {
+			continue
+		}
+		pm := &pathMatcher{path: path, pathItem: pi, pathTemplate: p.template(path)}
+		if pm.regex.MatchString(requestPath) {
+			candidates = append(candidates, pm)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].moreSpecific(candidates[j])
+	})
+
+	best := candidates[0]
+	var op *Operation
+	for _, c := range candidates {
+		if o := c.pathItem.GetOperations().GetOrZero(method); o != nil {
+			best, op = c, o
+			break
+		}
+	}
+
+	params := make(map[string]string, len(best.names))
+	values := best.regex.FindStringSubmatch(requestPath)
+	for i, name := range best.names {
+		value := values[i+1]
+		if unescaped, err := url.PathUnescape(value); err == nil {
+			value = unescaped
+		}
+		params[name] = value
+	}
+	return &PathMatch{
+		Path:       best.path,
+		PathItem:   best.pathItem,
+		Method:     method,
+		Operation:  op,
+		Parameters: params,
+	}
+}
+
 // Render will return a YAML representation of the Paths object as a byte slice.
 func (p *Paths) Render() ([]byte, error) {
 	return yaml.Marshal(p)
@@ -11,7 +11,9 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/low"
 	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
 
@@ -118,3 +120,105 @@ func TestPaths_MarshalYAMLInline(t *testing.T) {
 	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
 
 }
+
+func TestPaths_MatchPath(t *testing.T) {
+	yml := `/users/{userId}/pets/{petId}:
+  get:
+    operationId: getUserPet
+/users/{userId}/pets/mine:
+  get:
+    operationId: getMyPet
+/users/me/pets/{petId}:
+  get:
+    operationId: getMePet
+  delete:
+    operationId: deleteMePet
+/users/{userId}:
+  get:
+    operationId: getUser
+  put:
+    operationId: updateUser
+/users/me:
+  get:
+    operationId: getMe
+/files/{name}.{ext}:
+  get:
+    operationId: getFile
+/:
+  get:
+    operationId: root`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3low.Paths
+	_ = low.BuildModel(&idxNode, &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	paths := NewPaths(&n)
+
+	m := paths.MatchPath("GET", "/users/42/pets/7")
+	assert.NotNil(t, m)
+	assert.Equal(t, "/users/{userId}/pets/{petId}", m.Path)
+	assert.Equal(t, "get", m.Method)
+	assert.Equal(t, "getUserPet", m.Operation.OperationId)
+	assert.Equal(t, map[string]string{"userId": "42", "petId": "7"}, m.Parameters)
+
+	// concrete paths win over templated ones.
+	m = paths.MatchPath("get", "/users/me")
+	assert.Equal(t, "getMe", m.Operation.OperationId)
+	assert.Empty(t, m.Parameters)
+
+	// leading literal segments win.
+	m = paths.MatchPath("get", "/users/me/pets/mine")
+	assert.Equal(t, "getMePet", m.Operation.OperationId)
+	assert.Equal(t, "mine", m.Parameters["petId"])
+
+	m = paths.MatchPath("get", "/users/42/pets/mine")
+	assert.Equal(t, "getMyPet", m.Operation.OperationId)
+
+	// paths with an operation for the method are preferred.
+	m = paths.MatchPath("PUT", "/users/me")
+	assert.Equal(t, "/users/{userId}", m.Path)
+	assert.Equal(t, "updateUser", m.Operation.OperationId)
+	assert.Equal(t, "me", m.Parameters["userId"])
+
+	// path matches, method does not.
+	m = paths.MatchPath("post", "/users/me")
+	assert.NotNil(t, m)
+	assert.Equal(t, "/users/me", m.Path)
+	assert.Nil(t, m.Operation)
+
+	// mixed templates, escaping, query strings and trailing slashes.
+	m = paths.MatchPath("get", "/files/my%20report.pdf/?download=true")
+	assert.Equal(t, "getFile", m.Operation.OperationId)
+	assert.Equal(t, map[string]string{"name": "my report", "ext": "pdf"}, m.Parameters)
+
+	m = paths.MatchPath("get", "/")
+	assert.Equal(t, "root", m.Operation.OperationId)
+
+	assert.Nil(t, paths.MatchPath("get", "/users/42/cars"))
+	assert.Nil(t, paths.MatchPath("get", "/users//pets/1"))
+
+	var empty *Paths
+	assert.Nil(t, empty.MatchPath("get", "/"))
+}
+
+func TestPaths_MatchPath_CompiledOnce(t *testing.T) {
+	paths := &Paths{PathItems: orderedmap.New[string, *PathItem]()}
+	paths.PathItems.Set("/users/{userId}/pets/{petId}", &PathItem{Get: &Operation{OperationId: "getPet"}})
+
+	// the templates are kept by the paths object.
+	first := paths.template("/users/{userId}/pets/{petId}")
+	assert.Same(t, first, paths.template("/users/{userId}/pets/{petId}"))
+	assert.Equal(t, []string{"userId", "petId"}, first.names)
+	assert.Equal(t, []bool{true, false, true, false}, first.literals)
+	assert.NotSame(t, first, (&Paths{}).template("/users/{userId}/pets/{petId}"))
+
+	// paths added later are still matched.
+	paths.PathItems.Set("/pets/{petId}", &PathItem{Get: &Operation{OperationId: "getAnyPet"}})
+	m := paths.MatchPath("get", "/pets/7")
+	require.NotNil(t, m)
+	assert.Equal(t, "getAnyPet", m.Operation.OperationId)
+	assert.Equal(t, "getPet", paths.MatchPath("get", "/users/1/pets/7").Operation.OperationId)
+}
@@ -43,6 +43,16 @@ func (r *RequestBody) GoLowUntyped() any {
 	return r.low
 }
 
+// GetPosition returns the position of the RequestBody in the source specification, or nil if it is not available.
+func (r *RequestBody) GetPosition() *high.Position {
+	return high.GetPosition(r.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the RequestBody is defined under, or nil if it is not available.
+func (r *RequestBody) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(r.GoLow())
+}
+
 // Render will return a YAML representation of the RequestBody object as a byte slice.
 func (r *RequestBody) Render() ([]byte, error) {
 	return yaml.Marshal(r)
@@ -53,6 +53,16 @@ func (r *Response) GoLowUntyped() any {
 	return r.low
 }
 
+// GetPosition returns the position of the Response in the source specification, or nil if it is not available.
+func (r *Response) GetPosition() *high.Position {
+	return high.GetPosition(r.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Response is defined under, or nil if it is not available.
+func (r *Response) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(r.GoLow())
+}
+
 // Render will return a YAML representation of the Response object as a byte slice.
 func (r *Response) Render() ([]byte, error) {
 	return yaml.Marshal(r)
@@ -4,6 +4,7 @@
 package v3
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 
@@ -68,6 +69,36 @@ func (r *Responses) FindResponseByCode(code int) *Response {
 	return r.Codes.GetOrZero(fmt.Sprintf("%d", code))
 }
 
+// FindResponseForCode will locate the Response that describes an HTTP status code, following the precedence
+// defined by the specification. An exact code (like 204) is used first, then a range (like 2XX), then the
+// Default. If no response applies, nil is returned.
+func (r *Responses) FindResponseForCode(status int) *Response {
+	var found *Response
+	rank := 0
+	for code, resp := range r.Codes.FromOldest() {
+		if m := utils.MatchResponseCode(code, status); m > rank {
+			found = resp
+			rank = m
+		}
+	}
+	if found == nil {
+		return r.Default
+	}
+	return found
+}
+
+// ValidateCodes will check every response code is well-formed (see utils.ValidateResponseCode), all problems
+// found are returned.
+func (r *Responses) ValidateCodes() error {
+	var errs []error
+	for code := range r.Codes.KeysFromOldest() {
+		if err := utils.ValidateResponseCode(code); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // GoLow returns the low-level Response object used to create the high-level one.
 func (r *Responses) GoLow() *low.Responses {
 	return r.low
@@ -78,6 +109,16 @@ func (r *Responses) GoLowUntyped() any {
 	return r.low
 }
 
+// GetPosition returns the position of the Responses in the source specification, or nil if it is not available.
+func (r *Responses) GetPosition() *high.Position {
+	return high.GetPosition(r.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Responses is defined under, or nil if it is not available.
+func (r *Responses) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(r.GoLow())
+}
+
 // Render will return a YAML representation of the Responses object as a byte slice.
 func (r *Responses) Render() ([]byte, error) {
 	return yaml.Marshal(r)
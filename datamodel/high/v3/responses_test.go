@@ -11,6 +11,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/low"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 )
@@ -99,3 +100,27 @@ func TestResponses_MarshalYAMLInline(t *testing.T) {
 	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
 
 }
+
+func TestResponses_FindResponseForCode(t *testing.T) {
+	codes := orderedmap.New[string, *Response]()
+	codes.Set("2XX", &Response{Description: "success"})
+	codes.Set("201", &Response{Description: "created"})
+	codes.Set("5XX", &Response{Description: "server error"})
+	r := &Responses{Codes: codes, Default: &Response{Description: "default"}}
+
+	assert.Equal(t, "created", r.FindResponseForCode(201).Description)
+	assert.Equal(t, "success", r.FindResponseForCode(200).Description)
+	assert.Equal(t, "server error", r.FindResponseForCode(503).Description)
+	assert.Equal(t, "default", r.FindResponseForCode(404).Description)
+
+	r.Default = nil
+	assert.Nil(t, r.FindResponseForCode(404))
+	assert.NoError(t, r.ValidateCodes())
+
+	codes.Set("20", &Response{})
+	codes.Set("6XX", &Response{})
+	err := r.ValidateCodes()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "response code '20' is not valid")
+	assert.Contains(t, err.Error(), "response code range '6XX' is not valid")
+}
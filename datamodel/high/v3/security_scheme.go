@@ -19,18 +19,21 @@ import (
 // authorization code) as defined in RFC6749 (https://www.rfc-editor.org/rfc/rfc6749), and OpenID Connect Discovery.
 // Please note that as of 2020, the implicit  flow is about to be deprecated by OAuth 2.0 Security Best Current Practice.
 // Recommended for most use case is Authorization Code Grant flow with PKCE.
+//
+// OAuth2MetadataUrl (the URL of the OAuth2 authorization server metadata, RFC8414) is an OpenAPI 3.2+ property.
 //   - https://spec.openapis.org/oas/v3.1.0#security-scheme-object
 type SecurityScheme struct {
-	Type             string                              `json:"type,omitempty" yaml:"type,omitempty"`
-	Description      string                              `json:"description,omitempty" yaml:"description,omitempty"`
-	Name             string                              `json:"name,omitempty" yaml:"name,omitempty"`
-	In               string                              `json:"in,omitempty" yaml:"in,omitempty"`
-	Scheme           string                              `json:"scheme,omitempty" yaml:"scheme,omitempty"`
-	BearerFormat     string                              `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
-	Flows            *OAuthFlows                         `json:"flows,omitempty" yaml:"flows,omitempty"`
-	OpenIdConnectUrl string                              `json:"openIdConnectUrl,omitempty" yaml:"openIdConnectUrl,omitempty"`
-	Extensions       *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
-	low              *low.SecurityScheme
+	Type              string                              `json:"type,omitempty" yaml:"type,omitempty"`
+	Description       string                              `json:"description,omitempty" yaml:"description,omitempty"`
+	Name              string                              `json:"name,omitempty" yaml:"name,omitempty"`
+	In                string                              `json:"in,omitempty" yaml:"in,omitempty"`
+	Scheme            string                              `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	BearerFormat      string                              `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	Flows             *OAuthFlows                         `json:"flows,omitempty" yaml:"flows,omitempty"`
+	OpenIdConnectUrl  string                              `json:"openIdConnectUrl,omitempty" yaml:"openIdConnectUrl,omitempty"`
+	OAuth2MetadataUrl string                              `json:"oauth2MetadataUrl,omitempty" yaml:"oauth2MetadataUrl,omitempty"`
+	Extensions        *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low               *low.SecurityScheme
 }
 
 // NewSecurityScheme creates a new high-level SecurityScheme from a low-level one.
@@ -44,6 +47,7 @@ func NewSecurityScheme(ss *low.SecurityScheme) *SecurityScheme {
 	s.In = ss.In.Value
 	s.BearerFormat = ss.BearerFormat.Value
 	s.OpenIdConnectUrl = ss.OpenIdConnectUrl.Value
+	s.OAuth2MetadataUrl = ss.OAuth2MetadataUrl.Value
 	s.Extensions = high.ExtractExtensions(ss.Extensions)
 	if !ss.Flows.IsEmpty() {
 		s.Flows = NewOAuthFlows(ss.Flows.Value)
@@ -61,6 +65,16 @@ func (s *SecurityScheme) GoLowUntyped() any {
 	return s.low
 }
 
+// GetPosition returns the position of the SecurityScheme in the source specification, or nil if it is not available.
+func (s *SecurityScheme) GetPosition() *high.Position {
+	return high.GetPosition(s.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the SecurityScheme is defined under, or nil if it is not available.
+func (s *SecurityScheme) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(s.GoLow())
+}
+
 // Render will return a YAML representation of the SecurityScheme object as a byte slice.
 func (s *SecurityScheme) Render() ([]byte, error) {
 	return yaml.Marshal(s)
@@ -46,3 +46,30 @@ scheme: https`
 
 	assert.Equal(t, desired, strings.TrimSpace(string(dat)))
 }
+
+func TestSecurityScheme_DeviceAuthorization(t *testing.T) {
+	yml := `type: oauth2
+flows:
+    deviceAuthorization:
+        deviceAuthorizationUrl: https://pb33f.io/device
+        tokenUrl: https://pb33f.io/token
+        scopes:
+            chicken: nuggets
+oauth2MetadataUrl: https://pb33f.io/.well-known/oauth-authorization-server`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3.SecurityScheme
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	r := NewSecurityScheme(&n)
+	assert.Equal(t, "https://pb33f.io/.well-known/oauth-authorization-server", r.OAuth2MetadataUrl)
+	assert.Equal(t, "https://pb33f.io/device", r.Flows.DeviceAuthorization.DeviceAuthorizationUrl)
+	assert.Equal(t, "nuggets", r.Flows.DeviceAuthorization.Scopes.GetOrZero("chicken"))
+
+	dat, _ := r.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(dat)))
+}
@@ -4,6 +4,11 @@
 package v3
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
@@ -32,6 +37,93 @@ func NewServer(server *lowv3.Server) *Server {
 	return s
 }
 
+var serverVariableRegex = regexp.MustCompile(`\{([^{}]+)}`)
+
+// ExpandURL will expand the URL template of the Server, substituting each {variable} with the value supplied
+// in values, or the default value of the variable if no value is supplied.
+//
+// An error is returned if a supplied value is not one of the enum values defined for the variable, or if a
+// variable used in the URL has no value and no default.
+func (s *Server) ExpandURL(values map[string]string) (string, error) {
+	var errs []string
+	expanded := serverVariableRegex.ReplaceAllStringFunc(s.URL, func(match string) string {
+		name := match[1 : len(match)-1]
+		value, err := s.resolveVariable(name, values)
+		if err != nil {
+			errs = append(errs, err.Error())
+			return match
+		}
+		return value
+	})
+	if len(errs) > 0 {
+		return "", fmt.Errorf("unable to expand server URL '%s': %s", s.URL, strings.Join(errs, ", "))
+	}
+	return expanded, nil
+}
+
+// ExpandAllURLs will expand the URL template of the Server into every concrete URL it can represent. Variables
+// that have a supplied value use that value, variables constrained by an enum are expanded into every enum value
+// and the remaining variables use their default value. URLs are returned in the order the enums are defined.
+//
+// The same validation rules as ExpandURL apply.
+func (s *Server) ExpandAllURLs(values map[string]string) ([]string, error) {
+	// validate everything first.
+	if _, err := s.ExpandURL(values); err != nil {
+		return nil, err
+	}
+	urls := []string{s.URL}
+	var seen []string
+	for _, m := range serverVariableRegex.FindAllStringSubmatch(s.URL, -1) {
+		name := m[1]
+		if slices.Contains(seen, name) {
+			continue
+		}
+		seen = append(seen, name)
+		var options []string
+		if v, ok := values[name]; ok {
+			options = []string{v}
+		} else if sv := s.variable(name); len(sv.Enum) > 0 {
+			options = sv.Enum
+		} else {
+			options = []string{sv.Default}
+		}
+		var next []string
+		for _, u := range urls {
+			for _, o := range options {
+				next = append(next, strings.ReplaceAll(u, m[0], o))
+			}
+		}
+		urls = next
+	}
+	return urls, nil
+}
+
+func (s *Server) variable(name string) *ServerVariable {
+	if s.Variables == nil {
+		return nil
+	}
+	return s.Variables.GetOrZero(name)
+}
+
+func (s *Server) resolveVariable(name string, values map[string]string) (string, error) {
+	sv := s.variable(name)
+	value, supplied := values[name]
+	if !supplied {
+		if sv == nil {
+			return "", fmt.Errorf("variable '%s' is not defined", name)
+		}
+		if sv.Default == "" {
+			return "", fmt.Errorf("variable '%s' has no value and no default", name)
+		}
+		return sv.Default, nil
+	}
+	if sv != nil && len(sv.Enum) > 0 && !slices.Contains(sv.Enum, value) {
+		return "", fmt.Errorf("value '%s' for variable '%s' is not one of [%s]",
+			value, name, strings.Join(sv.Enum, ", "))
+	}
+	return value, nil
+}
+
 // GoLow returns the low-level Server instance that was used to create the high-level one
 func (s *Server) GoLow() *lowv3.Server {
 	return s.low
@@ -42,6 +134,16 @@ func (s *Server) GoLowUntyped() any {
 	return s.low
 }
 
+// GetPosition returns the position of the Server in the source specification, or nil if it is not available.
+func (s *Server) GetPosition() *high.Position {
+	return high.GetPosition(s.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the Server is defined under, or nil if it is not available.
+func (s *Server) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(s.GoLow())
+}
+
 // Render will return a YAML representation of the Server object as a byte slice.
 func (s *Server) Render() ([]byte, error) {
 	return yaml.Marshal(s)
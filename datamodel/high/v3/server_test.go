@@ -43,3 +43,66 @@ variables:
 	rend, _ = server.Render()
 	assert.Equal(t, desired, strings.TrimSpace(string(rend)))
 }
+
+func TestServer_ExpandURL(t *testing.T) {
+	server := &Server{
+		URL: "https://{region}.{host}:{port}/{version}",
+		Variables: orderedmap.ToOrderedMap(map[string]*ServerVariable{
+			"region":  {Enum: []string{"us", "eu"}, Default: "us"},
+			"host":    {Default: "pb33f.io"},
+			"port":    {Enum: []string{"443", "8443"}, Default: "443"},
+			"version": {},
+		}),
+	}
+
+	u, err := server.ExpandURL(map[string]string{"version": "v1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://us.pb33f.io:443/v1", u)
+
+	u, err = server.ExpandURL(map[string]string{"version": "v2", "region": "eu", "host": "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://eu.example.com:443/v2", u)
+
+	_, err = server.ExpandURL(map[string]string{"region": "mars"})
+	assert.EqualError(t, err, "unable to expand server URL 'https://{region}.{host}:{port}/{version}': "+
+		"value 'mars' for variable 'region' is not one of [us, eu], variable 'version' has no value and no default")
+
+	server.URL = "https://{unknown}/api"
+	_, err = server.ExpandURL(nil)
+	assert.EqualError(t, err, "unable to expand server URL 'https://{unknown}/api': variable 'unknown' is not defined")
+
+	u, err = (&Server{URL: "/api/{stage}"}).ExpandURL(map[string]string{"stage": "prod"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/prod", u)
+}
+
+func TestServer_ExpandAllURLs(t *testing.T) {
+	server := &Server{
+		URL: "https://{region}.pb33f.io:{port}/{region}",
+		Variables: orderedmap.ToOrderedMap(map[string]*ServerVariable{
+			"region": {Enum: []string{"us", "eu"}, Default: "us"},
+			"port":   {Enum: []string{"443", "8443"}, Default: "443"},
+		}),
+	}
+
+	urls, err := server.ExpandAllURLs(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"https://us.pb33f.io:443/us",
+		"https://us.pb33f.io:8443/us",
+		"https://eu.pb33f.io:443/eu",
+		"https://eu.pb33f.io:8443/eu",
+	}, urls)
+
+	urls, err = server.ExpandAllURLs(map[string]string{"port": "8443"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://us.pb33f.io:8443/us", "https://eu.pb33f.io:8443/eu"}, urls)
+
+	urls, err = server.ExpandAllURLs(map[string]string{"port": "80"})
+	assert.Error(t, err)
+	assert.Nil(t, urls)
+
+	urls, err = (&Server{URL: "https://pb33f.io"}).ExpandAllURLs(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://pb33f.io"}, urls)
+}
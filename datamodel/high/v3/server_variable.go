@@ -49,6 +49,16 @@ func (s *ServerVariable) GoLowUntyped() any {
 	return s.low
 }
 
+// GetPosition returns the position of the ServerVariable in the source specification, or nil if it is not available.
+func (s *ServerVariable) GetPosition() *high.Position {
+	return high.GetPosition(s.GoLow())
+}
+
+// GetKeyPosition returns the position of the key the ServerVariable is defined under, or nil if it is not available.
+func (s *ServerVariable) GetKeyPosition() *high.Position {
+	return high.GetKeyPosition(s.GoLow())
+}
+
 // Render will return a YAML representation of the ServerVariable object as a byte slice.
 func (s *ServerVariable) Render() ([]byte, error) {
 	return yaml.Marshal(s)
@@ -0,0 +1,139 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pb33f/libopenapi/index"
+)
+
+// AllocatorKey is the context key used to carry an Allocator through the model building process.
+const AllocatorKey index.ContextKey = "allocator"
+
+// allocatorSlabSize is the number of models of the same type allocated at once.
+const allocatorSlabSize = 64
+
+// Allocator allocates the low-level models of a document (and the references and node maps they hold) in slabs of
+// models of the same type, instead of one at a time, which means there are far fewer allocations for the garbage
+// collector to keep track of when building big documents. Every document built with bulk allocation has an
+// allocator of its own (see datamodel.DocumentConfiguration.BulkAllocation).
+//
+// Models are allocated without taking a lock, so building a document in parallel is not slowed down. A slab is only
+// freed once none of the models allocated from it are used any more, so the memory of an allocator is tied to the
+// lifetime of the document it's used to build: holding on to a single model holds on to the slab it came from.
+type Allocator struct {
+	slabs    sync.Map // reflect.Type of a model -> *typedSlabs of the model.
+	released atomic.Bool
+}
+
+// typedSlabs holds the slab models of one type are currently allocated from, and counts the slabs used up.
+type typedSlabs[N any] struct {
+	current atomic.Pointer[slab[N]]
+	full    atomic.Int64
+}
+
+// slab is a block of models, next is the index of the next model to allocate. next keeps counting past the end of
+// the slab when it's used up, until it's replaced.
+type slab[N any] struct {
+	models []N
+	next   atomic.Int64
+}
+
+// counter is implemented by typedSlabs of every type, so an allocator can count its models.
+type counter interface {
+	count() int
+}
+
+// NewAllocator creates a new Allocator, ready to be carried through the model building process by WithAllocator.
+func NewAllocator() *Allocator {
+	return new(Allocator)
+}
+
+// Len returns the number of models allocated.
+func (a *Allocator) Len() int {
+	if a == nil {
+		return 0
+	}
+	n := 0
+	a.slabs.Range(func(_, slabs any) bool {
+		n += slabs.(counter).count()
+		return true
+	})
+	return n
+}
+
+// Release lets go of the slabs held by the allocator, so each one is freed as soon as the models already allocated
+// from it are no longer used. Models allocated after the allocator is released are allocated one at a time.
+func (a *Allocator) Release() {
+	if a == nil {
+		return
+	}
+	a.released.Store(true)
+	a.slabs.Clear()
+}
+
+// WithAllocator returns a copy of ctx that carries the supplied Allocator, every model built with the returned
+// context is allocated by it.
+func WithAllocator(ctx context.Context, allocator *Allocator) context.Context {
+	return context.WithValue(ctx, AllocatorKey, allocator)
+}
+
+// GetAllocator will return the Allocator carried by ctx, or nil if there isn't one.
+func GetAllocator(ctx context.Context) *Allocator {
+	if ctx == nil {
+		return nil
+	}
+	if a, ok := ctx.Value(AllocatorKey).(*Allocator); ok {
+		return a
+	}
+	return nil
+}
+
+// New returns a new, zeroed N. If ctx carries an Allocator (see WithAllocator), N is allocated from a slab held by
+// it, otherwise N is allocated on its own.
+func New[N any](ctx context.Context) *N {
+	a := GetAllocator(ctx)
+	if a == nil || a.released.Load() {
+		return new(N)
+	}
+	t := reflect.TypeFor[N]()
+	s, ok := a.slabs.Load(t)
+	if !ok {
+		s, _ = a.slabs.LoadOrStore(t, new(typedSlabs[N]))
+	}
+	return s.(*typedSlabs[N]).allocate()
+}
+
+// allocate returns the next model of the current slab, or replaces the slab if it's used up. When two goroutines
+// race to replace a slab, the one that loses allocates from the slab of the one that wins.
+func (s *typedSlabs[N]) allocate() *N {
+	for {
+		current := s.current.Load()
+		if current != nil {
+			if i := current.next.Add(1) - 1; i < allocatorSlabSize {
+				return &current.models[i]
+			}
+		}
+		replacement := &slab[N]{models: make([]N, allocatorSlabSize)}
+		replacement.next.Store(1)
+		if s.current.CompareAndSwap(current, replacement) {
+			if current != nil {
+				s.full.Add(1)
+			}
+			return &replacement.models[0]
+		}
+	}
+}
+
+func (s *typedSlabs[N]) count() int {
+	n := int(s.full.Load()) * allocatorSlabSize
+	if current := s.current.Load(); current != nil {
+		n += int(min(current.next.Load(), allocatorSlabSize))
+	}
+	return n
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type allocated struct {
+	Name  string
+	Count int
+}
+
+func TestNew(t *testing.T) {
+	// without an allocator, every model is allocated on its own.
+	n := New[allocated](context.Background())
+	assert.Equal(t, allocated{}, *n)
+	assert.Nil(t, GetAllocator(context.Background()))
+	assert.Nil(t, GetAllocator(nil))
+
+	a := NewAllocator()
+	ctx := WithAllocator(context.Background(), a)
+	assert.Same(t, a, GetAllocator(ctx))
+
+	first := New[allocated](ctx)
+	second := New[allocated](ctx)
+	other := New[string](ctx)
+	assert.Equal(t, allocated{}, *first)
+	assert.Empty(t, *other)
+	assert.Equal(t, 3, a.Len())
+
+	// models of the same type come from the same slab.
+	assert.Equal(t, uintptr(unsafe.Pointer(first))+unsafe.Sizeof(allocated{}), uintptr(unsafe.Pointer(second)))
+
+	// a slab that is used up is replaced.
+	for i := 0; i < allocatorSlabSize; i++ {
+		New[allocated](ctx).Count = i
+	}
+	assert.Equal(t, 3+allocatorSlabSize, a.Len())
+	assert.Zero(t, first.Count)
+}
+
+func TestAllocator_Release(t *testing.T) {
+	a := NewAllocator()
+	ctx := WithAllocator(context.Background(), a)
+	New[allocated](ctx).Name = "pizza"
+	a.Release()
+
+	// models allocated once released are allocated on their own.
+	n := New[allocated](ctx)
+	assert.Equal(t, allocated{}, *n)
+	assert.Zero(t, a.Len())
+
+	var none *Allocator
+	none.Release()
+	assert.Zero(t, none.Len())
+}
+
+func TestNew_Concurrent(t *testing.T) {
+	a := NewAllocator()
+	ctx := WithAllocator(context.Background(), a)
+	var wg sync.WaitGroup
+	seen := sync.Map{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10*allocatorSlabSize; j++ {
+				_, dupe := seen.LoadOrStore(New[allocated](ctx), true)
+				assert.False(t, dupe)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100*allocatorSlabSize, a.Len())
+}
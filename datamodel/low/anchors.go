@@ -0,0 +1,103 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Anchor represents a YAML anchor (`&name`) defined in a document, and every alias (`*name`) that refers to it.
+//
+// Low-level models are built from resolved aliases, so the identity of anchors and aliases is lost once a model
+// has been built. Anchors keep track of that identity, so it can be restored when rendering.
+type Anchor struct {
+	// Name is the name of the anchor, without the `&`
+	Name string
+
+	// Node is the anchored node.
+	Node *yaml.Node
+
+	// Path is the location of the anchored node in the document, for example `$['paths']['/pizza']['get']['tags']`
+	Path string
+
+	// Aliases holds every alias of this anchor, in the order they appear in the document.
+	Aliases []*AnchorAlias
+}
+
+// AnchorAlias represents a single alias of an Anchor.
+type AnchorAlias struct {
+	// Node is the alias node.
+	Node *yaml.Node
+
+	// Path is the location of the alias in the document.
+	Path string
+}
+
+// FindAnchors will walk a yaml.Node tree and return every anchor defined, along with the aliases of each anchor,
+// in the order the anchors appear in the document. Aliases used as merge keys (`<<: *name`) are not included.
+func FindAnchors(root *yaml.Node) []*Anchor {
+	if root == nil {
+		return nil
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	af := &anchorFinder{byNode: make(map[*yaml.Node]*Anchor)}
+	af.walk(root, "$")
+	return af.anchors
+}
+
+type anchorFinder struct {
+	anchors []*Anchor
+	byNode  map[*yaml.Node]*Anchor
+}
+
+func (af *anchorFinder) walk(node *yaml.Node, path string) {
+	if node == nil {
+		return
+	}
+	if node.Kind == yaml.AliasNode {
+		if node.Alias == nil {
+			return
+		}
+		a := af.byNode[node.Alias]
+		if a == nil {
+			// yaml does not allow forward references, but be safe.
+			a = &Anchor{Name: node.Value, Node: node.Alias}
+			af.byNode[node.Alias] = a
+			af.anchors = append(af.anchors, a)
+		}
+		a.Aliases = append(a.Aliases, &AnchorAlias{Node: node, Path: path})
+		return
+	}
+	if node.Anchor != "" {
+		if a := af.byNode[node]; a != nil {
+			a.Path = path
+		} else {
+			a = &Anchor{Name: node.Anchor, Node: node, Path: path}
+			af.byNode[node] = a
+			af.anchors = append(af.anchors, a)
+		}
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "<<" {
+				continue
+			}
+			af.walk(node.Content[i+1], AnchorPath(path, node.Content[i].Value))
+		}
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			af.walk(n, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+// AnchorPath appends a mapping key to a path used by Anchor and AnchorAlias.
+func AnchorPath(path, key string) string {
+	return fmt.Sprintf("%s['%s']", path, key)
+}
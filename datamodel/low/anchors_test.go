@@ -0,0 +1,43 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFindAnchors(t *testing.T) {
+	yml := `base: &base
+  name: pizza
+things:
+  - &first one
+  - *first
+copy: *base
+merged:
+  <<: *base
+  extra: cake`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+
+	anchors := FindAnchors(&root)
+	assert.Len(t, anchors, 2)
+
+	assert.Equal(t, "base", anchors[0].Name)
+	assert.Equal(t, "$['base']", anchors[0].Path)
+	assert.Equal(t, 1, anchors[0].Node.Line)
+	assert.Len(t, anchors[0].Aliases, 1) // merge keys are not included.
+	assert.Equal(t, "$['copy']", anchors[0].Aliases[0].Path)
+	assert.Equal(t, 6, anchors[0].Aliases[0].Node.Line)
+
+	assert.Equal(t, "first", anchors[1].Name)
+	assert.Equal(t, "$['things'][0]", anchors[1].Path)
+	assert.Len(t, anchors[1].Aliases, 1)
+	assert.Equal(t, "$['things'][1]", anchors[1].Aliases[0].Path)
+
+	assert.Nil(t, FindAnchors(nil))
+}
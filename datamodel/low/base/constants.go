@@ -12,6 +12,7 @@ const (
 	EmailLabel                 = "email"
 	NameLabel                  = "name"
 	URLLabel                   = "url"
+	IdentifierLabel            = "identifier"
 	TagsLabel                  = "tags"
 	ExternalDocsLabel          = "externalDocs"
 	ExamplesLabel              = "examples"
@@ -22,6 +23,7 @@ const (
 	LicenseLabel               = "license"
 	PropertiesLabel            = "properties"
 	DependentSchemasLabel      = "dependentSchemas"
+	DefsLabel                  = "$defs"
 	PatternPropertiesLabel     = "patternProperties"
 	IfLabel                    = "if"
 	ElseLabel                  = "else"
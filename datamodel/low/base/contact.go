@@ -33,7 +33,7 @@ type Contact struct {
 func (c *Contact) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	c.KeyNode = keyNode
 	c.RootNode = root
-	c.Reference = new(low.Reference)
+	c.Reference = low.New[low.Reference](ctx)
 	c.Nodes = low.ExtractNodes(ctx, root)
 	c.Extensions = low.ExtractExtensions(root)
 	c.context = ctx
@@ -61,6 +61,11 @@ func (c *Contact) GetKeyNode() *yaml.Node {
 	return c.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Contact object, which is the same as the root node.
+func (c *Contact) GetValueNode() *yaml.Node {
+	return c.RootNode
+}
+
 // Hash will return a consistent SHA256 Hash of the Contact object
 func (c *Contact) Hash() [32]byte {
 	var f []string
@@ -76,6 +81,11 @@ func (c *Contact) Hash() [32]byte {
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
 
+// FindExtension attempts to locate an extension with the supplied key
+func (c *Contact) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, c.Extensions)
+}
+
 // GetExtensions returns all extensions for Contact
 func (c *Contact) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return c.Extensions
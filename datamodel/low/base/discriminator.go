@@ -25,10 +25,14 @@ import (
 type Discriminator struct {
 	PropertyName low.NodeReference[string]
 	Mapping      low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
+	Extensions   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 	KeyNode      *yaml.Node
 	RootNode     *yaml.Node
 	low.Reference
 	low.NodeMap
+
+	// mappingIndex allows mapping lookups by key without scanning Mapping.
+	mappingIndex map[string]low.KeyReference[string]
 }
 
 // GetRootNode will return the root yaml node of the Discriminator object
@@ -41,14 +45,60 @@ func (d *Discriminator) GetKeyNode() *yaml.Node {
 	return d.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Discriminator object, which is the same as the root node.
+func (d *Discriminator) GetValueNode() *yaml.Node {
+	return d.RootNode
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (d *Discriminator) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap[*yaml.Node](ext, d.Extensions)
+}
+
+// GetExtensions returns all Discriminator extensions and satisfies the low.HasExtensions interface.
+func (d *Discriminator) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return d.Extensions
+}
+
 // FindMappingValue will return a ValueReference containing the string mapping value
 func (d *Discriminator) FindMappingValue(key string) *low.ValueReference[string] {
+	_, v := d.FindMapping(key)
+	return v
+}
+
+// FindMapping will return both the KeyReference and ValueReference of a mapping entry, so the position of the key
+// and the value can both be determined. If the key cannot be found, both returned values are nil.
+//
+// Mapping is an ordered map, iterating over it returns entries in the order they appear in the source document.
+func (d *Discriminator) FindMapping(key string) (*low.KeyReference[string], *low.ValueReference[string]) {
+	if d.Mapping.Value == nil {
+		return nil, nil
+	}
+	// the index is only trusted when the entry it points to is still in the mapping. Mapping can be modified
+	// directly, so a miss (or an entry that has gone) falls back to a scan. The index is never written here, so
+	// lookups are safe from many goroutines.
+	if k, ok := d.mappingIndex[key]; ok {
+		if v, found := d.Mapping.Value.Get(k); found {
+			return &k, &v
+		}
+	}
 	for k, v := range d.Mapping.Value.FromOldest() {
 		if k.Value == key {
-			return &v
+			return &k, &v
 		}
 	}
-	return nil
+	return nil, nil
+}
+
+// buildMappingIndex will index the mapping keys so FindMapping and FindMappingValue do not need to scan.
+func (d *Discriminator) buildMappingIndex() {
+	if d.Mapping.Value == nil {
+		return
+	}
+	d.mappingIndex = make(map[string]low.KeyReference[string], d.Mapping.Value.Len())
+	for k := range d.Mapping.Value.KeysFromOldest() {
+		d.mappingIndex[k.Value] = k
+	}
 }
 
 // Hash will return a consistent SHA256 Hash of the Discriminator object
@@ -62,6 +112,7 @@ func (d *Discriminator) Hash() [32]byte {
 	for v := range orderedmap.SortAlpha(d.Mapping.Value).ValuesFromOldest() {
 		f = append(f, v.Value)
 	}
+	f = append(f, low.HashExtensions(d.Extensions)...)
 
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
@@ -4,7 +4,9 @@
 package base
 
 import (
+	"context"
 	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 	"testing"
@@ -54,3 +56,90 @@ propertyName: freshCakes`
 	assert.NotNil(t, lDoc.GetKeyNode())
 
 }
+
+func TestDiscriminator_Extensions(t *testing.T) {
+	yml := `type: object
+discriminator:
+  propertyName: freshCakes
+  mapping:
+    zebra: stripes
+    apple: pie
+  x-cakes: yummy`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var sch Schema
+	_ = low.BuildModel(idxNode.Content[0], &sch)
+	err := sch.Build(context.Background(), idxNode.Content[0], nil)
+	assert.NoError(t, err)
+
+	d := sch.Discriminator.Value
+	assert.Equal(t, 1, orderedmap.Len(d.GetExtensions()))
+
+	var ext string
+	_ = d.FindExtension("x-cakes").Value.Decode(&ext)
+	assert.Equal(t, "yummy", ext)
+
+	var keys []string
+	for k := range d.Mapping.Value.KeysFromOldest() {
+		keys = append(keys, k.Value)
+	}
+	assert.Equal(t, []string{"zebra", "apple"}, keys)
+
+	// extensions contribute to the hash.
+	var plain Discriminator
+	_ = low.BuildModel(sch.Discriminator.ValueNode, &plain)
+	assert.NotEqual(t, d.Hash(), plain.Hash())
+}
+
+func TestDiscriminator_FindMapping(t *testing.T) {
+	yml := `discriminator:
+  propertyName: freshCakes
+  mapping:
+    zebra: stripes
+    apple: pie`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var sch Schema
+	_ = low.BuildModel(idxNode.Content[0], &sch)
+	_ = sch.Build(context.Background(), idxNode.Content[0], nil)
+
+	d := sch.Discriminator.Value
+	assert.Len(t, d.mappingIndex, 2)
+
+	k, v := d.FindMapping("apple")
+	assert.Equal(t, "apple", k.Value)
+	assert.Equal(t, 5, k.KeyNode.Line)
+	assert.Equal(t, 5, k.KeyNode.Column)
+	assert.Equal(t, "pie", v.Value)
+	assert.Equal(t, 12, v.ValueNode.Column)
+	assert.Equal(t, "stripes", d.FindMappingValue("zebra").Value)
+
+	k, v = d.FindMapping("pizza")
+	assert.Nil(t, k)
+	assert.Nil(t, v)
+
+	// modifying the mapping after the build falls back to scanning.
+	d.Mapping.Value.Set(low.KeyReference[string]{Value: "pizza"}, low.ValueReference[string]{Value: "party"})
+	assert.Equal(t, "party", d.FindMappingValue("pizza").Value)
+
+	// replacing an entry without changing the length of the mapping is never stale.
+	var appleKey low.KeyReference[string]
+	for key := range d.Mapping.Value.KeysFromOldest() {
+		if key.Value == "apple" {
+			appleKey = key
+		}
+	}
+	d.Mapping.Value.Delete(appleKey)
+	d.Mapping.Value.Set(low.KeyReference[string]{Value: "banana"}, low.ValueReference[string]{Value: "split"})
+	assert.Nil(t, d.FindMappingValue("apple"))
+	assert.Equal(t, "split", d.FindMappingValue("banana").Value)
+
+	var empty Discriminator
+	k, v = empty.FindMapping("pizza")
+	assert.Nil(t, k)
+	assert.Nil(t, v)
+}
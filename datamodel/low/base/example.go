@@ -48,6 +48,11 @@ func (ex *Example) GetKeyNode() *yaml.Node {
 	return ex.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Example object, which is the same as the root node.
+func (ex *Example) GetValueNode() *yaml.Node {
+	return ex.RootNode
+}
+
 // Hash will return a consistent SHA256 Hash of the Discriminator object
 func (ex *Example) Hash() [32]byte {
 	var f []string
@@ -75,7 +80,7 @@ func (ex *Example) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 	root = utils.NodeAlias(root)
 	ex.RootNode = root
 	utils.CheckForMergeNodes(root)
-	ex.Reference = new(low.Reference)
+	ex.Reference = low.New[low.Reference](ctx)
 	ex.Nodes = low.ExtractNodes(ctx, root)
 	ex.Extensions = low.ExtractExtensions(root)
 	ex.context = ctx
@@ -48,13 +48,18 @@ func (ex *ExternalDoc) GetKeyNode() *yaml.Node {
 	return ex.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the ExternalDoc object, which is the same as the root node.
+func (ex *ExternalDoc) GetValueNode() *yaml.Node {
+	return ex.RootNode
+}
+
 // Build will extract extensions from the ExternalDoc instance.
 func (ex *ExternalDoc) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	ex.KeyNode = keyNode
 	root = utils.NodeAlias(root)
 	ex.RootNode = root
 	utils.CheckForMergeNodes(root)
-	ex.Reference = new(low.Reference)
+	ex.Reference = low.New[low.Reference](ctx)
 	ex.Nodes = low.ExtractNodes(ctx, root)
 	ex.Extensions = low.ExtractExtensions(root)
 	ex.context = ctx
@@ -55,6 +55,11 @@ func (i *Info) GetKeyNode() *yaml.Node {
 	return i.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Info object, which is the same as the root node.
+func (i *Info) GetValueNode() *yaml.Node {
+	return i.RootNode
+}
+
 // GetExtensions returns all extensions for Info
 func (i *Info) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return i.Extensions
@@ -66,7 +71,7 @@ func (i *Info) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.S
 	root = utils.NodeAlias(root)
 	i.RootNode = root
 	utils.CheckForMergeNodes(root)
-	i.Reference = new(low.Reference)
+	i.Reference = low.New[low.Reference](ctx)
 	i.Nodes = low.ExtractNodes(ctx, root)
 	i.Extensions = low.ExtractExtensions(root)
 	i.index = idx
@@ -6,6 +6,7 @@ package base
 import (
 	"context"
 	"crypto/sha256"
+	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
@@ -37,12 +38,35 @@ func (l *License) Build(ctx context.Context, keyNode, root *yaml.Node, idx *inde
 	root = utils.NodeAlias(root)
 	l.RootNode = root
 	utils.CheckForMergeNodes(root)
-	l.Reference = new(low.Reference)
+	l.Reference = low.New[low.Reference](ctx)
 	no := low.ExtractNodes(ctx, root)
 	l.Extensions = low.ExtractExtensions(root)
 	l.Nodes = no
 	l.context = ctx
 	l.index = idx
+
+	// the license is still built, dropping it would lose data.
+	if err := l.Validate(); err != nil {
+		low.AddBuildWarning(ctx, l.Identifier.KeyNode, licenseExclusiveMessage)
+		if idx != nil && idx.GetLogger() != nil {
+			idx.GetLogger().Warn(err.Error())
+		}
+	}
+	return nil
+}
+
+const licenseExclusiveMessage = "license cannot have both a 'url' and an 'identifier', they are mutually exclusive"
+
+// Validate will check the License is valid, a License cannot have both a URL and an identifier (3.1), they are
+// mutually exclusive. Use ValidateSPDXExpression to check the identifier itself.
+func (l *License) Validate() error {
+	if !l.URL.IsEmpty() && !l.Identifier.IsEmpty() {
+		line, col := 0, 0
+		if l.Identifier.KeyNode != nil {
+			line, col = l.Identifier.KeyNode.Line, l.Identifier.KeyNode.Column
+		}
+		return fmt.Errorf("%s, line %d, column %d", licenseExclusiveMessage, line, col)
+	}
 	return nil
 }
 
@@ -66,6 +90,11 @@ func (l *License) GetKeyNode() *yaml.Node {
 	return l.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the License object, which is the same as the root node.
+func (l *License) GetValueNode() *yaml.Node {
+	return l.RootNode
+}
+
 // Hash will return a consistent SHA256 Hash of the License object
 func (l *License) Hash() [32]byte {
 	var f []string
@@ -81,6 +110,11 @@ func (l *License) Hash() [32]byte {
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
 
+// FindExtension attempts to locate an extension with the supplied key
+func (l *License) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, l.Extensions)
+}
+
 // GetExtensions returns all extensions for License
 func (l *License) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return l.Extensions
@@ -66,3 +66,68 @@ description: the ranch`
 	assert.Equal(t, lDoc.Hash(), rDoc.Hash())
 
 }
+
+func TestLicense_Validate(t *testing.T) {
+
+	yml := `name: pizza
+url: https://pb33f.io
+identifier: MIT`
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &node)
+
+	var lic License
+	_ = low.BuildModel(node.Content[0], &lic)
+	err := lic.Build(context.Background(), nil, node.Content[0], nil)
+	assert.NoError(t, err)
+
+	// nothing is lost.
+	assert.Equal(t, "https://pb33f.io", lic.URL.Value)
+	assert.Equal(t, "MIT", lic.Identifier.Value)
+
+	err = lic.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "license cannot have both a 'url' and an 'identifier', they are mutually exclusive, "+
+		"line 3, column 1", err.Error())
+
+	lic.URL = low.NodeReference[string]{}
+	assert.NoError(t, lic.Validate())
+}
+
+func TestValidateSPDXExpression(t *testing.T) {
+	valid := []string{
+		"MIT",
+		"Apache-2.0",
+		"GPL-2.0+",
+		"GPL-2.0-or-later WITH Classpath-exception-2.0",
+		"MIT OR Apache-2.0",
+		"(MIT OR Apache-2.0) AND BSD-3-Clause",
+		"LGPL-2.1-only or (MIT and BSD-2-Clause)",
+		"LicenseRef-pizza",
+		"DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2",
+	}
+	for _, v := range valid {
+		assert.NoError(t, ValidateSPDXExpression(v), v)
+	}
+
+	invalid := map[string]string{
+		"":                  "SPDX license expression is empty",
+		"MIT OR":            "invalid SPDX license expression 'MIT OR': unexpected end of expression",
+		"(MIT":              "invalid SPDX license expression '(MIT': missing ')'",
+		"MIT)":              "invalid SPDX license expression 'MIT)': unexpected ')'",
+		"MIT Apache-2.0":    "invalid SPDX license expression 'MIT Apache-2.0': unexpected 'Apache-2.0'",
+		"MIT WITH":          "invalid SPDX license expression 'MIT WITH': expected a license exception after 'WITH'",
+		"AND MIT":           "invalid SPDX license expression 'AND MIT': unexpected 'AND'",
+		"My License!":       "invalid SPDX license expression 'My License!': unexpected 'License!'",
+		"pizza!":            "invalid SPDX license expression 'pizza!': 'pizza!' is not a valid license identifier",
+		"LicenseRef-":       "invalid SPDX license expression 'LicenseRef-': 'LicenseRef-' is not a valid license reference",
+		"DocumentRef-a:MIT": "invalid SPDX license expression 'DocumentRef-a:MIT': 'DocumentRef-a:MIT' is not a valid license reference",
+		"MIT Or Apache-2.0": "invalid SPDX license expression 'MIT Or Apache-2.0': unexpected 'Or'",
+	}
+	for expression, msg := range invalid {
+		err := ValidateSPDXExpression(expression)
+		if assert.Error(t, err, expression) {
+			assert.Equal(t, msg, err.Error())
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -103,6 +104,7 @@ type Schema struct {
 	UnevaluatedItems      low.NodeReference[*SchemaProxy]
 	UnevaluatedProperties low.NodeReference[*SchemaDynamicValue[*SchemaProxy, bool]]
 	Anchor                low.NodeReference[string]
+	Defs                  low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*SchemaProxy]]]
 
 	// Compatible with all versions
 	Title                low.NodeReference[string]
@@ -147,6 +149,7 @@ type Schema struct {
 	context  context.Context
 	*low.Reference
 	low.NodeMap
+	hashCache low.HashCache
 }
 
 // GetIndex will return the index.SpecIndex instance attached to the Schema object
@@ -162,6 +165,11 @@ func (s *Schema) GetContext() context.Context {
 // Hash will calculate a SHA256 hash from the values of the schema, This allows equality checking against
 // Schemas defined inside an OpenAPI document. The only way to know if a schema has changed, is to hash it.
 func (s *Schema) Hash() [32]byte {
+	return s.hashCache.GetOrCompute(s.hash)
+}
+
+// hash calculates the hash of the Schema, without using the cache.
+func (s *Schema) hash() [32]byte {
 	// calculate a hash from every property in the schema.
 	var d []string
 	if !s.SchemaTypeRef.IsEmpty() {
@@ -376,6 +384,7 @@ func (s *Schema) Hash() [32]byte {
 
 	d = low.AppendMapHashes(d, orderedmap.SortAlpha(s.DependentSchemas.Value))
 	d = low.AppendMapHashes(d, orderedmap.SortAlpha(s.PatternProperties.Value))
+	d = low.AppendMapHashes(d, orderedmap.SortAlpha(s.Defs.Value))
 
 	if len(s.PrefixItems.Value) > 0 {
 		itemsKeys := make([]string, len(s.PrefixItems.Value))
@@ -429,12 +438,23 @@ func (s *Schema) FindDependentSchema(name string) *low.ValueReference[*SchemaPro
 	return low.FindItemInOrderedMap[*SchemaProxy](name, s.DependentSchemas.Value)
 }
 
+// FindDef will return a ValueReference pointer containing a SchemaProxy pointer
+// from a $defs key name. if found (3.1+ only)
+func (s *Schema) FindDef(name string) *low.ValueReference[*SchemaProxy] {
+	return low.FindItemInOrderedMap[*SchemaProxy](name, s.Defs.Value)
+}
+
 // FindPatternProperty will return a ValueReference pointer containing a SchemaProxy pointer
 // from a pattern property key name. if found (3.1+ only)
 func (s *Schema) FindPatternProperty(name string) *low.ValueReference[*SchemaProxy] {
 	return low.FindItemInOrderedMap[*SchemaProxy](name, s.PatternProperties.Value)
 }
 
+// FindExtension attempts to locate an extension with the supplied key
+func (s *Schema) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, s.Extensions)
+}
+
 // GetExtensions returns all extensions for Schema
 func (s *Schema) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return s.Extensions
@@ -445,6 +465,19 @@ func (s *Schema) GetRootNode() *yaml.Node {
 	return s.RootNode
 }
 
+// GetValueNode will return the value yaml node of the Schema object, which is the same as the root node.
+func (s *Schema) GetValueNode() *yaml.Node {
+	return s.RootNode
+}
+
+// GetKeyNode will return the key yaml node of the Schema object, taken from the parent SchemaProxy (if set).
+func (s *Schema) GetKeyNode() *yaml.Node {
+	if s.ParentProxy != nil {
+		return s.ParentProxy.GetKeyNode()
+	}
+	return nil
+}
+
 // Build will perform a number of operations.
 // Extraction of the following happens in this method:
 //   - Extensions
@@ -469,18 +502,20 @@ func (s *Schema) GetRootNode() *yaml.Node {
 //   - UnevaluatedItems
 //   - UnevaluatedProperties
 //   - Anchor
+//   - Defs
 func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) error {
 	if root == nil {
 		return fmt.Errorf("cannot build schema from a nil node")
 	}
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	s.Reference = new(low.Reference)
+	s.Reference = low.New[low.Reference](ctx)
 	no := low.ExtractNodes(ctx, root)
 	s.Nodes = no
 	s.Index = idx
 	s.RootNode = root
 	s.context = ctx
+	s.hashCache.SetScope(low.GetHashScope(ctx))
 	s.index = idx
 
 	if h, _, _ := utils.IsNodeRefValue(root); h {
@@ -506,6 +541,9 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 		return err
 	}
 
+	// index the keys of the schema once, rather than scanning the schema for every keyword.
+	keys := utils.NewKeyIndex(root)
+
 	s.extractExtensions(root)
 
 	// if the schema has required values, extract the nodes for them.
@@ -523,7 +561,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	}
 
 	// determine schema type, singular (3.0) or multiple (3.1), use a variable value
-	_, typeLabel, typeValue := utils.FindKeyNodeFullTop(TypeLabel, root.Content)
+	typeLabel, typeValue := keys.Find(TypeLabel)
 	if typeValue != nil {
 		if utils.IsNodeStringValue(typeValue) {
 			s.Type = low.NodeReference[SchemaDynamicValue[string, []low.ValueReference[string]]]{
@@ -531,6 +569,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 				ValueNode: typeValue,
 				Value:     SchemaDynamicValue[string, []low.ValueReference[string]]{N: 0, A: typeValue.Value},
 			}
+			checkSchemaType(ctx, typeValue)
 		}
 		if utils.IsNodeArray(typeValue) {
 
@@ -540,6 +579,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 					Value:     typeValue.Content[r].Value,
 					ValueNode: typeValue.Content[r],
 				})
+				checkSchemaType(ctx, typeValue.Content[r])
 			}
 			s.Type = low.NodeReference[SchemaDynamicValue[string, []low.ValueReference[string]]]{
 				KeyNode:   typeLabel,
@@ -547,15 +587,21 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 				Value:     SchemaDynamicValue[string, []low.ValueReference[string]]{N: 1, B: refs},
 			}
 		}
+		if !utils.IsNodeStringValue(typeValue) && !utils.IsNodeArray(typeValue) {
+			low.AddBuildWarning(ctx, typeValue, "schema 'type' is ignored, it must be a string or an array of strings")
+		}
 	}
 
 	// determine exclusive minimum type, bool (3.0) or int (3.1)
-	_, exMinLabel, exMinValue := utils.FindKeyNodeFullTop(ExclusiveMinimumLabel, root.Content)
+	exMinLabel, exMinValue := keys.Find(ExclusiveMinimumLabel)
 	if exMinValue != nil {
 		// if there is an index, determine if this a 3.0 or 3.1 schema
 		if idx != nil {
 			if idx.GetConfig().SpecInfo.VersionNumeric == 3.1 {
-				val, _ := strconv.ParseFloat(exMinValue.Value, 64)
+				val, pErr := strconv.ParseFloat(exMinValue.Value, 64)
+				if pErr != nil {
+					low.AddBuildWarning(ctx, exMinValue, "schema '%s' value '%s' is not a number, using 0", ExclusiveMinimumLabel, exMinValue.Value)
+				}
 				s.ExclusiveMinimum = low.NodeReference[*SchemaDynamicValue[bool, float64]]{
 					KeyNode:   exMinLabel,
 					ValueNode: exMinValue,
@@ -563,7 +609,10 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 				}
 			}
 			if idx.GetConfig().SpecInfo.VersionNumeric <= 3.0 {
-				val, _ := strconv.ParseBool(exMinValue.Value)
+				val, pErr := strconv.ParseBool(exMinValue.Value)
+				if pErr != nil {
+					low.AddBuildWarning(ctx, exMinValue, "schema '%s' value '%s' is not a boolean, using false", ExclusiveMinimumLabel, exMinValue.Value)
+				}
 				s.ExclusiveMinimum = low.NodeReference[*SchemaDynamicValue[bool, float64]]{
 					KeyNode:   exMinLabel,
 					ValueNode: exMinValue,
@@ -593,12 +642,15 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	}
 
 	// determine exclusive maximum type, bool (3.0) or int (3.1)
-	_, exMaxLabel, exMaxValue := utils.FindKeyNodeFullTop(ExclusiveMaximumLabel, root.Content)
+	exMaxLabel, exMaxValue := keys.Find(ExclusiveMaximumLabel)
 	if exMaxValue != nil {
 		// if there is an index, determine if this a 3.0 or 3.1 schema
 		if idx != nil {
 			if idx.GetConfig().SpecInfo.VersionNumeric == 3.1 {
-				val, _ := strconv.ParseFloat(exMaxValue.Value, 64)
+				val, pErr := strconv.ParseFloat(exMaxValue.Value, 64)
+				if pErr != nil {
+					low.AddBuildWarning(ctx, exMaxValue, "schema '%s' value '%s' is not a number, using 0", ExclusiveMaximumLabel, exMaxValue.Value)
+				}
 				s.ExclusiveMaximum = low.NodeReference[*SchemaDynamicValue[bool, float64]]{
 					KeyNode:   exMaxLabel,
 					ValueNode: exMaxValue,
@@ -606,7 +658,10 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 				}
 			}
 			if idx.GetConfig().SpecInfo.VersionNumeric <= 3.0 {
-				val, _ := strconv.ParseBool(exMaxValue.Value)
+				val, pErr := strconv.ParseBool(exMaxValue.Value)
+				if pErr != nil {
+					low.AddBuildWarning(ctx, exMaxValue, "schema '%s' value '%s' is not a boolean, using false", ExclusiveMaximumLabel, exMaxValue.Value)
+				}
 				s.ExclusiveMaximum = low.NodeReference[*SchemaDynamicValue[bool, float64]]{
 					KeyNode:   exMaxLabel,
 					ValueNode: exMaxValue,
@@ -636,7 +691,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	}
 
 	// handle schema reference type if set. (3.1)
-	_, schemaRefLabel, schemaRefNode := utils.FindKeyNodeFullTop(SchemaTypeLabel, root.Content)
+	schemaRefLabel, schemaRefNode := keys.Find(SchemaTypeLabel)
 	if schemaRefNode != nil {
 		s.SchemaTypeRef = low.NodeReference[string]{
 			Value: schemaRefNode.Value, KeyNode: schemaRefLabel, ValueNode: schemaRefLabel,
@@ -644,7 +699,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	}
 
 	// handle anchor if set. (3.1)
-	_, anchorLabel, anchorNode := utils.FindKeyNodeFullTop(AnchorLabel, root.Content)
+	anchorLabel, anchorNode := keys.Find(AnchorLabel)
 	if anchorNode != nil {
 		s.Anchor = low.NodeReference[string]{
 			Value: anchorNode.Value, KeyNode: anchorLabel, ValueNode: anchorLabel,
@@ -652,7 +707,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	}
 
 	// handle example if set. (3.0)
-	_, expLabel, expNode := utils.FindKeyNodeFullTop(ExampleLabel, root.Content)
+	expLabel, expNode := keys.Find(ExampleLabel)
 	if expNode != nil {
 		s.Example = low.NodeReference[*yaml.Node]{Value: expNode, KeyNode: expLabel, ValueNode: expNode}
 
@@ -670,7 +725,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	}
 
 	// handle examples if set.(3.1)
-	_, expArrLabel, expArrNode := utils.FindKeyNodeFullTop(ExamplesLabel, root.Content)
+	expArrLabel, expArrNode := keys.Find(ExamplesLabel)
 	if expArrNode != nil {
 		if utils.IsNodeArray(expArrNode) {
 			var examples []low.ValueReference[*yaml.Node]
@@ -699,7 +754,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	// check additionalProperties type for schema or bool
 	addPropsIsBool := false
 	addPropsBoolValue := true
-	_, addPLabel, addPValue := utils.FindKeyNodeFullTop(AdditionalPropertiesLabel, root.Content)
+	addPLabel, addPValue := keys.Find(AdditionalPropertiesLabel)
 	if addPValue != nil {
 		if utils.IsNodeBoolValue(addPValue) {
 			addPropsIsBool = true
@@ -718,13 +773,15 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	}
 
 	// handle discriminator if set.
-	_, discLabel, discNode := utils.FindKeyNodeFullTop(DiscriminatorLabel, root.Content)
+	discLabel, discNode := keys.Find(DiscriminatorLabel)
 	if discNode != nil {
 		var discriminator Discriminator
 		_ = low.BuildModel(discNode, &discriminator)
 		discriminator.KeyNode = discLabel
 		discriminator.RootNode = discNode
 		discriminator.Nodes = low.ExtractNodes(ctx, discNode)
+		discriminator.Extensions = low.ExtractExtensions(discNode)
+		discriminator.buildMappingIndex()
 		s.Discriminator = low.NodeReference[*Discriminator]{Value: &discriminator, KeyNode: discLabel, ValueNode: discNode}
 		// add discriminator nodes, because there is no build method.
 		dn := low.ExtractNodesRecursive(ctx, discNode)
@@ -739,7 +796,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	}
 
 	// handle externalDocs if set.
-	_, extDocLabel, extDocNode := utils.FindKeyNodeFullTop(ExternalDocsLabel, root.Content)
+	extDocLabel, extDocNode := keys.Find(ExternalDocsLabel)
 	if extDocNode != nil {
 		var exDoc ExternalDoc
 		_ = low.BuildModel(extDocNode, &exDoc)
@@ -749,12 +806,13 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	}
 
 	// handle xml if set.
-	_, xmlLabel, xmlNode := utils.FindKeyNodeFullTop(XMLLabel, root.Content)
+	xmlLabel, xmlNode := keys.Find(XMLLabel)
 	if xmlNode != nil {
 		var xml XML
 		_ = low.BuildModel(xmlNode, &xml)
 		// extract extensions if set.
 		_ = xml.Build(xmlNode, idx) // returns no errors, can't check for one.
+		xml.KeyNode = xmlLabel
 		xml.Nodes = low.ExtractNodes(ctx, xmlNode)
 		s.XML = low.NodeReference[*XML]{Value: &xml, KeyNode: xmlLabel, ValueNode: xmlNode}
 	}
@@ -777,6 +835,15 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 		s.DependentSchemas = *props
 	}
 
+	// handle $defs
+	props, err = buildPropertyMap(ctx, s, root, idx, DefsLabel)
+	if err != nil {
+		return err
+	}
+	if props != nil {
+		s.Defs = *props
+	}
+
 	// handle pattern properties
 	props, err = buildPropertyMap(ctx, s, root, idx, PatternPropertiesLabel)
 	if err != nil {
@@ -789,7 +856,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	// check items type for schema or bool (3.1 only)
 	itemsIsBool := false
 	itemsBoolValue := false
-	_, itemsLabel, itemsValue := utils.FindKeyNodeFullTop(ItemsLabel, root.Content)
+	itemsLabel, itemsValue := keys.Find(ItemsLabel)
 	if itemsValue != nil {
 		if utils.IsNodeBoolValue(itemsValue) {
 			itemsIsBool = true
@@ -810,7 +877,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	// check unevaluatedProperties type for schema or bool (3.1 only)
 	unevalIsBool := false
 	unevalBoolValue := true
-	_, unevalLabel, unevalValue := utils.FindKeyNodeFullTop(UnevaluatedPropertiesLabel, root.Content)
+	unevalLabel, unevalValue := keys.Find(UnevaluatedPropertiesLabel)
 	if unevalValue != nil {
 		if utils.IsNodeBoolValue(unevalValue) {
 			unevalIsBool = true
@@ -831,19 +898,19 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	var allOf, anyOf, oneOf, prefixItems []low.ValueReference[*SchemaProxy]
 	var items, not, contains, sif, selse, sthen, propertyNames, unevalItems, unevalProperties, addProperties low.ValueReference[*SchemaProxy]
 
-	_, allOfLabel, allOfValue := utils.FindKeyNodeFullTop(AllOfLabel, root.Content)
-	_, anyOfLabel, anyOfValue := utils.FindKeyNodeFullTop(AnyOfLabel, root.Content)
-	_, oneOfLabel, oneOfValue := utils.FindKeyNodeFullTop(OneOfLabel, root.Content)
-	_, notLabel, notValue := utils.FindKeyNodeFullTop(NotLabel, root.Content)
-	_, prefixItemsLabel, prefixItemsValue := utils.FindKeyNodeFullTop(PrefixItemsLabel, root.Content)
-	_, containsLabel, containsValue := utils.FindKeyNodeFullTop(ContainsLabel, root.Content)
-	_, sifLabel, sifValue := utils.FindKeyNodeFullTop(IfLabel, root.Content)
-	_, selseLabel, selseValue := utils.FindKeyNodeFullTop(ElseLabel, root.Content)
-	_, sthenLabel, sthenValue := utils.FindKeyNodeFullTop(ThenLabel, root.Content)
-	_, propNamesLabel, propNamesValue := utils.FindKeyNodeFullTop(PropertyNamesLabel, root.Content)
-	_, unevalItemsLabel, unevalItemsValue := utils.FindKeyNodeFullTop(UnevaluatedItemsLabel, root.Content)
-	_, unevalPropsLabel, unevalPropsValue := utils.FindKeyNodeFullTop(UnevaluatedPropertiesLabel, root.Content)
-	_, addPropsLabel, addPropsValue := utils.FindKeyNodeFullTop(AdditionalPropertiesLabel, root.Content)
+	allOfLabel, allOfValue := keys.Find(AllOfLabel)
+	anyOfLabel, anyOfValue := keys.Find(AnyOfLabel)
+	oneOfLabel, oneOfValue := keys.Find(OneOfLabel)
+	notLabel, notValue := keys.Find(NotLabel)
+	prefixItemsLabel, prefixItemsValue := keys.Find(PrefixItemsLabel)
+	containsLabel, containsValue := keys.Find(ContainsLabel)
+	sifLabel, sifValue := keys.Find(IfLabel)
+	selseLabel, selseValue := keys.Find(ElseLabel)
+	sthenLabel, sthenValue := keys.Find(ThenLabel)
+	propNamesLabel, propNamesValue := keys.Find(PropertyNamesLabel)
+	unevalItemsLabel, unevalItemsValue := keys.Find(UnevaluatedItemsLabel)
+	unevalPropsLabel, unevalPropsValue := keys.Find(UnevaluatedPropertiesLabel)
+	addPropsLabel, addPropsValue := keys.Find(AdditionalPropertiesLabel)
 
 	errorChan := make(chan error)
 	allOfChan := make(chan schemaProxyBuildResult)
@@ -1076,6 +1143,16 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	return nil
 }
 
+// schemaTypes are the type names defined by JSON Schema.
+var schemaTypes = []string{"string", "number", "integer", "boolean", "array", "object", "null"}
+
+// checkSchemaType adds a build warning if the type is not one defined by JSON Schema.
+func checkSchemaType(ctx context.Context, typeNode *yaml.Node) {
+	if !slices.Contains(schemaTypes, typeNode.Value) {
+		low.AddBuildWarning(ctx, typeNode, "schema 'type' '%s' is not a known type", typeNode.Value)
+	}
+}
+
 func buildPropertyMap(ctx context.Context, parent *Schema, root *yaml.Node, idx *index.SpecIndex, label string) (*low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*SchemaProxy]]], error) {
 	_, propLabel, propsNode := utils.FindKeyNodeFullTop(label, root.Content)
 	if propsNode != nil {
@@ -1108,7 +1185,8 @@ func buildPropertyMap(ctx context.Context, parent *Schema, root *yaml.Node, idx
 				}
 			}
 
-			sp := &SchemaProxy{ctx: foundCtx, kn: currentProp, vn: prop, idx: foundIdx}
+			sp := low.New[SchemaProxy](foundCtx)
+			sp.ctx, sp.kn, sp.vn, sp.idx = context.WithoutCancel(foundCtx), currentProp, prop, foundIdx
 			sp.SetReference(refString, refNode)
 
 			propertyMap.Set(low.KeyReference[string]{
@@ -1131,7 +1209,7 @@ func buildPropertyMap(ctx context.Context, parent *Schema, root *yaml.Node, idx
 
 // count the number of sub-schemas in a node.
 func countSubSchemaItems(node *yaml.Node) int {
-	if utils.IsNodeMap(node) {
+	if utils.IsNodeMap(node) || utils.IsNodeBoolValue(node) {
 		return 1
 	}
 	if utils.IsNodeArray(node) {
@@ -1171,11 +1249,11 @@ func buildSchema(ctx context.Context, schemas chan schemaProxyBuildResult, label
 			// chasing down circles, that in turn spin up endless threads.
 			// In order to combat this, we need a schema proxy that will only resolve the schema when asked, and then
 			// it will only do it one level at a time.
-			sp := new(SchemaProxy)
+			sp := low.New[SchemaProxy](pctx)
 			sp.kn = kn
 			sp.vn = vn
 			sp.idx = fIdx
-			sp.ctx = pctx
+			sp.ctx = context.WithoutCancel(pctx)
 			if isRef {
 				sp.SetReference(refLocation, rf)
 			}
@@ -1258,6 +1336,16 @@ func buildSchema(ctx context.Context, schemas chan schemaProxyBuildResult, label
 					v: *r,
 				}
 			}
+		} else if utils.IsNodeBoolValue(valueNode) {
+			// a boolean schema (3.1)
+			r := build(foundCtx, foundIdx, labelNode, valueNode, nil, -1, syncChan, false, "")
+			schemas <- schemaProxyBuildResult{
+				k: low.KeyReference[string]{
+					KeyNode: labelNode,
+					Value:   labelNode.Value,
+				},
+				v: *r.res,
+			}
 		} else {
 			errors <- fmt.Errorf("build schema failed: unexpected data type: '%s', line %d, col %d",
 				utils.MakeTagReadable(valueNode), valueNode.Line, valueNode.Column)
@@ -1269,6 +1357,12 @@ func buildSchema(ctx context.Context, schemas chan schemaProxyBuildResult, label
 // will specifically look for a key node named 'schema' and extract the value mapped to that key. If the operation
 // fails then no NodeReference is returned and an error is returned instead.
 func ExtractSchema(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) (*low.NodeReference[*SchemaProxy], error) {
+	return ExtractSchemaWithLabel(ctx, SchemaLabel, root, idx)
+}
+
+// ExtractSchemaWithLabel operates the same way as ExtractSchema, except the key node to look for is supplied
+// as label, for objects that hold schemas under keys other than 'schema' (like 'itemSchema').
+func ExtractSchemaWithLabel(ctx context.Context, label string, root *yaml.Node, idx *index.SpecIndex) (*low.NodeReference[*SchemaProxy], error) {
 	var schLabel, schNode *yaml.Node
 	errStr := "schema build failed: reference '%s' cannot be found at line %d, col %d"
 
@@ -1294,7 +1388,7 @@ func ExtractSchema(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) (
 				v, root.Content[1].Line, root.Content[1].Column)
 		}
 	} else {
-		_, schLabel, schNode = utils.FindKeyNodeFull(SchemaLabel, root.Content)
+		_, schLabel, schNode = utils.FindKeyNodeFull(label, root.Content)
 		if schNode != nil {
 			h := false
 			if h, _, refLocation = utils.IsNodeRefValue(schNode); h {
@@ -1320,7 +1414,8 @@ func ExtractSchema(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) (
 
 	if schNode != nil {
 		// check if schema has already been built.
-		schema := &SchemaProxy{kn: schLabel, vn: schNode, idx: foundIndex, ctx: foundCtx}
+		schema := low.New[SchemaProxy](foundCtx)
+		schema.kn, schema.vn, schema.idx, schema.ctx = schLabel, schNode, foundIndex, context.WithoutCancel(foundCtx)
 		schema.SetReference(refLocation, refNode)
 
 		n := &low.NodeReference[*SchemaProxy]{
@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"log/slog"
+	"strconv"
 	"sync"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -54,6 +55,8 @@ type SchemaProxy struct {
 	idx        *index.SpecIndex
 	rendered   *Schema
 	buildError error
+	built      bool
+	lock       sync.Mutex
 	ctx        context.Context
 	*low.NodeMap
 }
@@ -64,7 +67,9 @@ func (sp *SchemaProxy) Build(ctx context.Context, key, value *yaml.Node, idx *in
 	sp.kn = key
 	sp.vn = value
 	sp.idx = idx
-	sp.ctx = ctx
+	// the schema is built lazily, long after the document has been built, so cancelling the context
+	// used to build the document must not break it.
+	sp.ctx = context.WithoutCancel(ctx)
 	if rf, _, r := utils.IsNodeRefValue(value); rf {
 		sp.SetReference(r, value)
 	}
@@ -84,11 +89,17 @@ func (sp *SchemaProxy) Build(ctx context.Context, key, value *yaml.Node, idx *in
 //
 // If anything goes wrong during the build, then nothing is returned and the error that occurred can
 // be retrieved by using GetBuildError()
+//
+// Schema() is safe to call from multiple goroutines, the Schema is only ever built once. Goroutines that call
+// Schema() while it is being built wait for it, and then receive the same Schema (or the same build error).
 func (sp *SchemaProxy) Schema() *Schema {
-	if sp.rendered != nil {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	if sp.built {
 		return sp.rendered
 	}
-	schema := new(Schema)
+	sp.built = true
+	schema := low.New[Schema](sp.ctx)
 	utils.CheckForMergeNodes(sp.vn)
 	err := schema.Build(sp.ctx, sp.vn, sp.idx)
 	if err != nil {
@@ -111,9 +122,25 @@ func (sp *SchemaProxy) Schema() *Schema {
 // GetBuildError returns the build error that was set when Schema() was called. If Schema() has not been run, or
 // there were no errors during build, then nil will be returned.
 func (sp *SchemaProxy) GetBuildError() error {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
 	return sp.buildError
 }
 
+// IsBuilt returns true if Schema() has been called, and the schema has been built (or failed to build).
+func (sp *SchemaProxy) IsBuilt() bool {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	return sp.built
+}
+
+// getRendered returns the Schema rendered by Schema(), or nil if it has not been rendered.
+func (sp *SchemaProxy) getRendered() *Schema {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	return sp.rendered
+}
+
 func (sp *SchemaProxy) GetSchemaReferenceLocation() *index.NodeOrigin {
 	if sp.idx != nil {
 		origin := sp.idx.FindNodeOrigin(sp.vn)
@@ -143,17 +170,46 @@ func (sp *SchemaProxy) GetValueNode() *yaml.Node {
 	return sp.vn
 }
 
+// GetRootNode will return the yaml.Node pointer used by the proxy to generate the Schema, the same as GetValueNode.
+func (sp *SchemaProxy) GetRootNode() *yaml.Node {
+	return sp.vn
+}
+
+// IsBoolean returns true if the proxy holds a boolean schema (`true` or `false`), which JSON Schema 2020-12
+// (OpenAPI 3.1) allows anywhere a schema is allowed. A `true` schema allows anything, a `false` schema allows nothing.
+//
+// Boolean schemas have no properties, calling Schema() on a boolean proxy returns an empty Schema.
+func (sp *SchemaProxy) IsBoolean() bool {
+	if sp.vn == nil || !utils.IsNodeBoolValue(sp.vn) {
+		return false
+	}
+	_, err := strconv.ParseBool(sp.vn.Value)
+	return err == nil
+}
+
+// GetBoolean returns the value of a boolean schema, false is returned if the proxy does not hold a boolean schema,
+// use IsBoolean to check first.
+func (sp *SchemaProxy) GetBoolean() bool {
+	if !sp.IsBoolean() {
+		return false
+	}
+	b, _ := strconv.ParseBool(sp.vn.Value)
+	return b
+}
+
 // Hash will return a consistent SHA256 Hash of the SchemaProxy object (it will resolve it)
 func (sp *SchemaProxy) Hash() [32]byte {
-	if sp.rendered != nil {
+	if sp.IsBoolean() && !sp.IsReference() {
+		return sha256.Sum256([]byte(strconv.FormatBool(sp.GetBoolean())))
+	}
+	if rendered := sp.getRendered(); rendered != nil {
 		if !sp.IsReference() {
-			return sp.rendered.Hash()
+			return rendered.Hash()
 		}
 	} else {
 		if !sp.IsReference() {
 			// only resolve this proxy if it's not a ref.
 			sch := sp.Schema()
-			sp.rendered = sch
 			if sch != nil {
 				return sch.Hash()
 			}
@@ -173,6 +229,8 @@ func (sp *SchemaProxy) Hash() [32]byte {
 
 // AddNode stores nodes in the underlying schema if rendered, otherwise holds in the proxy until build.
 func (sp *SchemaProxy) AddNode(key int, node *yaml.Node) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
 	if sp.rendered != nil {
 		sp.rendered.AddNode(key, node)
 	} else {
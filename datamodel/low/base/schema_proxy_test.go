@@ -7,6 +7,7 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -199,3 +200,156 @@ description: cakes`
 	assert.NotNil(t, n)
 
 }
+
+func TestSchemaProxy_SourceNodes(t *testing.T) {
+	yml := `pizza:
+  type: object
+  xml:
+    name: cake`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	root := idxNode.Content[0]
+
+	sp := new(SchemaProxy)
+	err := sp.Build(context.Background(), root.Content[0], root.Content[1], nil)
+	assert.NoError(t, err)
+
+	var _ low.HasSourceNodes = sp
+	assert.Equal(t, root.Content[1], sp.GetRootNode())
+	assert.Equal(t, sp.GetValueNode(), sp.GetRootNode())
+
+	s := sp.Schema()
+	var _ low.HasSourceNodes = s
+	assert.Equal(t, "pizza", s.GetKeyNode().Value)
+	assert.Equal(t, s.GetRootNode(), s.GetValueNode())
+
+	x := s.XML.Value
+	var _ low.HasSourceNodes = x
+	assert.Equal(t, "xml", x.GetKeyNode().Value)
+	assert.Equal(t, 3, x.GetKeyNode().Line)
+	assert.Equal(t, 4, x.GetValueNode().Line)
+
+	var orphan Schema
+	assert.Nil(t, orphan.GetKeyNode())
+}
+
+func TestSchemaProxy_Boolean(t *testing.T) {
+	yml := `type: object
+properties:
+  anything: true
+  nothing: false
+  something:
+    type: string
+allOf:
+  - false
+not: true`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var sch Schema
+	_ = low.BuildModel(idxNode.Content[0], &sch)
+	err := sch.Build(context.Background(), idxNode.Content[0], nil)
+	assert.NoError(t, err)
+
+	anything := sch.FindProperty("anything").Value
+	assert.True(t, anything.IsBoolean())
+	assert.True(t, anything.GetBoolean())
+
+	nothing := sch.FindProperty("nothing").Value
+	assert.True(t, nothing.IsBoolean())
+	assert.False(t, nothing.GetBoolean())
+	assert.NotEqual(t, anything.Hash(), nothing.Hash())
+
+	something := sch.FindProperty("something").Value
+	assert.False(t, something.IsBoolean())
+	assert.False(t, something.GetBoolean())
+
+	assert.True(t, sch.AllOf.Value[0].Value.IsBoolean())
+	assert.False(t, sch.AllOf.Value[0].Value.GetBoolean())
+	assert.True(t, sch.Not.Value.IsBoolean())
+	assert.True(t, sch.Not.Value.GetBoolean())
+	assert.Equal(t, anything.Hash(), sch.Not.Value.Hash())
+}
+
+func TestSchemaProxy_Schema_Concurrent(t *testing.T) {
+	yml := `type: object
+properties:
+  name:
+    type: string`
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &node)
+
+	sp := new(SchemaProxy)
+	assert.NoError(t, sp.Build(context.Background(), nil, node.Content[0], nil))
+
+	var wg sync.WaitGroup
+	schemas := make([]*Schema, 20)
+	for i := range schemas {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			schemas[i] = sp.Schema()
+			sp.Hash()
+		}(i)
+	}
+	wg.Wait()
+
+	// every goroutine receives the same schema, which was only built once.
+	assert.NotNil(t, schemas[0])
+	for _, s := range schemas {
+		assert.Same(t, schemas[0], s)
+	}
+	assert.NoError(t, sp.GetBuildError())
+}
+
+func TestSchemaProxy_Schema_BuildErrorIsKept(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(`properties:
+  rice:
+    $ref: '#/components/schemas/I-do-not-exist'`), &node)
+
+	idx := index.NewSpecIndexWithConfig(&node, index.CreateOpenAPIIndexConfig())
+	sp := new(SchemaProxy)
+	assert.NoError(t, sp.Build(context.Background(), nil, node.Content[0], idx))
+
+	assert.Nil(t, sp.Schema())
+	err := sp.GetBuildError()
+	assert.Error(t, err)
+
+	// the build is not retried.
+	assert.Nil(t, sp.Schema())
+	assert.Same(t, err, sp.GetBuildError())
+}
+
+func TestSchemaProxy_FindUnknownKeys_NotBuilt(t *testing.T) {
+	yml := `type: object
+Description: miscased
+properties:
+  pizza:
+    type: string
+    maxLenght: 2
+items:
+  minItem: 1
+xml:
+  nmae: nope`
+
+	var sch SchemaProxy
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	_ = sch.Build(context.Background(), nil, idxNode.Content[0], nil)
+
+	var names []string
+	for _, k := range low.FindUnknownKeys(&sch) {
+		names = append(names, k.ParentType+"."+k.Name)
+	}
+	assert.Equal(t, []string{"base.Schema.Description", "base.Schema.maxLenght", "base.Schema.minItem", "base.XML.nmae"}, names)
+	assert.False(t, sch.IsBuilt())
+
+	// once built, the schema is walked instead, with the same result.
+	assert.NotNil(t, sch.Schema())
+	assert.True(t, sch.IsBuilt())
+	assert.Len(t, low.FindUnknownKeys(&sch), 4)
+}
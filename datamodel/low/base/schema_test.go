@@ -429,6 +429,53 @@ func BenchmarkSchema_Hash(b *testing.B) {
 	}
 }
 
+func TestSchema_Hash_Cached(t *testing.T) {
+	var n yaml.Node
+	_ = yaml.Unmarshal([]byte(test_get_schema_blob()), &n)
+	scope := low.NewHashScope()
+	sch := Schema{}
+	_ = low.BuildModel(n.Content[0], &sch)
+	_ = sch.Build(low.WithHashScope(context.Background(), scope), n.Content[0], nil)
+
+	// not frozen, changing the model directly changes the hash.
+	original := sch.Hash()
+	assert.Equal(t, original, sch.hash())
+	sch.Description.Value = "a different description"
+	mutated := sch.Hash()
+	assert.NotEqual(t, original, mutated)
+	assert.Equal(t, mutated, sch.hash())
+
+	// frozen, the hash is cached until the scope is invalidated or the freeze is released.
+	release := scope.Freeze()
+	assert.Equal(t, mutated, sch.Hash())
+	sch.Description.Value = "something object"
+	assert.Equal(t, mutated, sch.Hash())
+	scope.Invalidate()
+	assert.Equal(t, original, sch.Hash())
+	release()
+
+	sch.Description.Value = "a different description"
+	assert.Equal(t, mutated, sch.Hash())
+
+	// kept, the hash is cached between calls, and changing the description through SetValue changes the hash.
+	scope.Keep(&n)
+	assert.Equal(t, mutated, sch.Hash())
+	assert.NoError(t, sch.Description.SetValue("something object"))
+	assert.Equal(t, original, sch.Hash())
+	assert.NoError(t, sch.Description.SetValue("a different description"))
+	assert.Equal(t, mutated, sch.Hash())
+	scope.Release()
+
+	// a schema of another document (or without one) is never cached by the scope.
+	other := Schema{}
+	_ = low.BuildModel(n.Content[0], &other)
+	_ = other.Build(context.Background(), n.Content[0], nil)
+	defer scope.Freeze()()
+	h := other.Hash()
+	other.Description.Value = "another description"
+	assert.NotEqual(t, h, other.Hash())
+}
+
 func Test_Schema_31(t *testing.T) {
 	testSpec := `$schema: https://something
 type:
@@ -556,6 +603,87 @@ dependentSchemas:
 	assert.Error(t, err)
 }
 
+func TestSchema_Build_Defs(t *testing.T) {
+	yml := `type: object
+properties:
+  pet:
+    $ref: '#/$defs/Pet'
+$defs:
+  Pet:
+    type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Schema
+	err := n.Build(context.Background(), idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n.Defs.Value.Len())
+	assert.Equal(t, "$defs", n.Defs.KeyNode.Value)
+	assert.Equal(t, "string", n.FindDef("Pet").Value.Schema().Type.Value.A)
+	assert.Nil(t, n.FindDef("Cat"))
+	assert.Equal(t, "string", n.FindProperty("pet").Value.Schema().Type.Value.A)
+
+	// changing a definition changes the hash of the schema.
+	yml = `type: object
+properties:
+  pet:
+    $ref: '#/$defs/Pet'
+$defs:
+  Pet:
+    type: integer`
+
+	var rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rNode)
+	var r Schema
+	_ = r.Build(context.Background(), rNode.Content[0], index.NewSpecIndex(&rNode))
+	assert.NotEqual(t, n.Hash(), r.Hash())
+}
+
+func TestSchema_Build_TypeWarnings(t *testing.T) {
+	yml := `type:
+  - string
+  - pizza
+properties:
+  odd:
+    type:
+      not: valid`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	warnings := low.NewBuildWarnings()
+	ctx := low.WithBuildWarnings(context.Background(), warnings)
+
+	var n Schema
+	err := n.Build(ctx, idxNode.Content[0], nil)
+	assert.NoError(t, err)
+	odd := n.FindProperty("odd").Value.Schema()
+	assert.NotNil(t, odd)
+	assert.True(t, odd.Type.IsEmpty())
+
+	w := warnings.GetWarnings()
+	assert.Len(t, w, 2)
+	assert.Equal(t, "schema 'type' 'pizza' is not a known type, line 3, column 5", w[0].String())
+	assert.Equal(t, "schema 'type' is ignored, it must be a string or an array of strings, line 7, column 7", w[1].String())
+}
+
+func TestSchema_Build_Defs_Fail(t *testing.T) {
+	yml := `type: object
+$defs:
+  aValue:
+    $ref: '#/bork'`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Schema
+	err := n.Build(context.Background(), idxNode.Content[0], idx)
+	assert.Error(t, err)
+}
+
 func TestSchema_Build_PatternProperties_Fail(t *testing.T) {
 	yml := `components:
   schemas:
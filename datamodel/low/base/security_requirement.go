@@ -52,7 +52,7 @@ func (s *SecurityRequirement) Build(ctx context.Context, keyNode, root *yaml.Nod
 	root = utils.NodeAlias(root)
 	s.RootNode = root
 	utils.CheckForMergeNodes(root)
-	s.Reference = new(low.Reference)
+	s.Reference = low.New[low.Reference](ctx)
 	s.Nodes = low.ExtractNodes(ctx, root)
 	s.context = ctx
 	s.index = idx
@@ -108,6 +108,11 @@ func (s *SecurityRequirement) GetKeyNode() *yaml.Node {
 	return s.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the SecurityRequirement object, which is the same as the root node.
+func (s *SecurityRequirement) GetValueNode() *yaml.Node {
+	return s.RootNode
+}
+
 // FindRequirement will attempt to locate a security requirement string from a supplied name.
 func (s *SecurityRequirement) FindRequirement(name string) []low.ValueReference[string] {
 	for k, v := range s.Requirements.Value.FromOldest() {
@@ -0,0 +1,169 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateSPDXExpression will check a license identifier is a well-formed SPDX license expression, as required by
+// the 3.1 License object `identifier` field. For example `MIT`, `Apache-2.0`, `GPL-2.0-or-later WITH
+// Classpath-exception-2.0` or `(MIT OR Apache-2.0)`.
+//
+// Only the syntax of the expression is checked (https://spdx.github.io/spdx-spec/v2.3/SPDX-license-expressions/),
+// the identifiers used are not checked against the SPDX license list, which changes over time.
+func ValidateSPDXExpression(expression string) error {
+	tokens := tokenizeSPDX(expression)
+	if len(tokens) == 0 {
+		return fmt.Errorf("SPDX license expression is empty")
+	}
+	p := &spdxParser{tokens: tokens}
+	if err := p.parseExpression(); err != nil {
+		return fmt.Errorf("invalid SPDX license expression '%s': %s", expression, err.Error())
+	}
+	if p.pos < len(p.tokens) {
+		return fmt.Errorf("invalid SPDX license expression '%s': unexpected '%s'", expression, p.tokens[p.pos])
+	}
+	return nil
+}
+
+func tokenizeSPDX(expression string) []string {
+	var tokens []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+	for _, r := range expression {
+		switch r {
+		case '(', ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case ' ', '\t', '\n', '\r':
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+type spdxParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *spdxParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func isSPDXOperator(token, operator string) bool {
+	return token == operator || token == strings.ToLower(operator)
+}
+
+// parseExpression parses `and-expression *(OR and-expression)`
+func (p *spdxParser) parseExpression() error {
+	if err := p.parseAnd(); err != nil {
+		return err
+	}
+	for isSPDXOperator(p.peek(), "OR") {
+		p.pos++
+		if err := p.parseAnd(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseAnd parses `with-expression *(AND with-expression)`
+func (p *spdxParser) parseAnd() error {
+	if err := p.parseWith(); err != nil {
+		return err
+	}
+	for isSPDXOperator(p.peek(), "AND") {
+		p.pos++
+		if err := p.parseWith(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseWith parses `simple-expression [WITH exception] | "(" expression ")"`
+func (p *spdxParser) parseWith() error {
+	token := p.peek()
+	if token == "(" {
+		p.pos++
+		if err := p.parseExpression(); err != nil {
+			return err
+		}
+		if p.peek() != ")" {
+			return fmt.Errorf("missing ')'")
+		}
+		p.pos++
+		return nil
+	}
+	if err := p.parseSimple(); err != nil {
+		return err
+	}
+	if isSPDXOperator(p.peek(), "WITH") {
+		p.pos++
+		exception := p.peek()
+		if !isSPDXIdString(exception) {
+			return fmt.Errorf("expected a license exception after 'WITH'")
+		}
+		p.pos++
+	}
+	return nil
+}
+
+// parseSimple parses `license-id ["+"] | LicenseRef-idstring | DocumentRef-idstring:LicenseRef-idstring`
+func (p *spdxParser) parseSimple() error {
+	token := p.peek()
+	switch {
+	case token == "":
+		return fmt.Errorf("unexpected end of expression")
+	case token == "(" || token == ")" || isSPDXOperator(token, "AND") || isSPDXOperator(token, "OR") ||
+		isSPDXOperator(token, "WITH"):
+		return fmt.Errorf("unexpected '%s'", token)
+	}
+	p.pos++
+	if doc, ref, found := strings.Cut(token, ":"); found {
+		if !strings.HasPrefix(doc, "DocumentRef-") || !isSPDXIdString(strings.TrimPrefix(doc, "DocumentRef-")) ||
+			!strings.HasPrefix(ref, "LicenseRef-") || !isSPDXIdString(strings.TrimPrefix(ref, "LicenseRef-")) {
+			return fmt.Errorf("'%s' is not a valid license reference", token)
+		}
+		return nil
+	}
+	if strings.HasPrefix(token, "LicenseRef-") {
+		if !isSPDXIdString(strings.TrimPrefix(token, "LicenseRef-")) {
+			return fmt.Errorf("'%s' is not a valid license reference", token)
+		}
+		return nil
+	}
+	if !isSPDXIdString(strings.TrimSuffix(token, "+")) {
+		return fmt.Errorf("'%s' is not a valid license identifier", token)
+	}
+	return nil
+}
+
+// isSPDXIdString checks a string is `1*(ALPHA / DIGIT / "-" / ".")`
+func isSPDXIdString(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
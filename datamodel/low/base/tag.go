@@ -59,13 +59,18 @@ func (t *Tag) GetKeyNode() *yaml.Node {
 	return t.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Tag object, which is the same as the root node.
+func (t *Tag) GetValueNode() *yaml.Node {
+	return t.RootNode
+}
+
 // Build will extract extensions and external docs for the Tag.
 func (t *Tag) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	t.KeyNode = keyNode
 	root = utils.NodeAlias(root)
 	t.RootNode = root
 	utils.CheckForMergeNodes(root)
-	t.Reference = new(low.Reference)
+	t.Reference = low.New[low.Reference](ctx)
 	t.Nodes = low.ExtractNodes(ctx, root)
 	t.Extensions = low.ExtractExtensions(root)
 	t.index = idx
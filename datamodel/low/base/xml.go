@@ -28,7 +28,9 @@ type XML struct {
 	Prefix     low.NodeReference[string]
 	Attribute  low.NodeReference[bool]
 	Wrapped    low.NodeReference[bool]
+	NodeType   low.NodeReference[string]
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode    *yaml.Node
 	RootNode   *yaml.Node
 	index      *index.SpecIndex
 	context    context.Context
@@ -37,16 +39,42 @@ type XML struct {
 }
 
 // Build will extract extensions from the XML instance.
-func (x *XML) Build(root *yaml.Node, _ *index.SpecIndex) error {
+func (x *XML) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	x.RootNode = root
+	x.index = idx
 	x.Reference = new(low.Reference)
 	x.Nodes = low.ExtractNodes(nil, root)
 	x.Extensions = low.ExtractExtensions(root)
 	return nil
 }
 
+// GetRootNode returns the root yaml node of the XML object.
+func (x *XML) GetRootNode() *yaml.Node {
+	return x.RootNode
+}
+
+// GetIndex returns the index.SpecIndex the XML object was built with, or nil if there wasn't one.
+func (x *XML) GetIndex() *index.SpecIndex {
+	return x.index
+}
+
+// GetKeyNode returns the key yaml node of the XML object.
+func (x *XML) GetKeyNode() *yaml.Node {
+	return x.KeyNode
+}
+
+// GetValueNode returns the value yaml node of the XML object, which is the same as the root node.
+func (x *XML) GetValueNode() *yaml.Node {
+	return x.RootNode
+}
+
+// FindExtension attempts to locate an extension with the supplied key
+func (x *XML) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, x.Extensions)
+}
+
 // GetExtensions returns all Tag extensions and satisfies the low.HasExtensions interface.
 func (x *XML) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return x.Extensions
@@ -70,6 +98,9 @@ func (x *XML) Hash() [32]byte {
 	if !x.Wrapped.IsEmpty() {
 		f = append(f, fmt.Sprint(x.Wrapped.Value))
 	}
+	if !x.NodeType.IsEmpty() {
+		f = append(f, x.NodeType.Value)
+	}
 	f = append(f, low.HashExtensions(x.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
@@ -0,0 +1,138 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// BuildWarningsKey is the context key used to carry a *BuildWarnings collector through the model building process.
+const BuildWarningsKey index.ContextKey = "buildWarnings"
+
+// BuildWarning describes something that was tolerated when building a model, like a value that was coerced
+// into the expected type, a value of an unknown type, or a key that was ignored. Warnings do not stop a model
+// from being built, unlike errors.
+type BuildWarning struct {
+	Message string     `json:"message" yaml:"message"`
+	Line    int        `json:"line" yaml:"line"`
+	Column  int        `json:"column" yaml:"column"`
+	Node    *yaml.Node `json:"-" yaml:"-"`
+}
+
+// String returns the warning message along with the line and column the warning relates to.
+func (w *BuildWarning) String() string {
+	return fmt.Sprintf("%s, line %d, column %d", w.Message, w.Line, w.Column)
+}
+
+// BuildWarnings collects BuildWarning instances as models are built. It is safe to use from multiple goroutines.
+type BuildWarnings struct {
+	lock     sync.Mutex
+	warnings []*BuildWarning
+	seen     map[buildWarningKey]struct{}
+}
+
+type buildWarningKey struct {
+	node    *yaml.Node
+	message string
+}
+
+// NewBuildWarnings creates a new, empty BuildWarnings collector.
+func NewBuildWarnings() *BuildWarnings {
+	return &BuildWarnings{seen: make(map[buildWarningKey]struct{})}
+}
+
+// Add will add a new warning about the supplied node to the collector. Models can be built more than once (schemas
+// are built lazily, for example), so a warning with the same message for the same node is only collected once.
+func (b *BuildWarnings) Add(node *yaml.Node, format string, args ...any) {
+	if b == nil {
+		return
+	}
+	w := &BuildWarning{Message: fmt.Sprintf(format, args...), Node: node}
+	if node != nil {
+		w.Line, w.Column = node.Line, node.Column
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.seen == nil {
+		b.seen = make(map[buildWarningKey]struct{})
+	}
+	key := buildWarningKey{node: node, message: w.Message}
+	if _, ok := b.seen[key]; ok {
+		return
+	}
+	b.seen[key] = struct{}{}
+	b.warnings = append(b.warnings, w)
+}
+
+// GetWarnings returns all the warnings collected, sorted by the position in the document they relate to.
+func (b *BuildWarnings) GetWarnings() []*BuildWarning {
+	if b == nil {
+		return nil
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if len(b.warnings) == 0 {
+		return nil
+	}
+	warnings := make([]*BuildWarning, len(b.warnings))
+	copy(warnings, b.warnings)
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if warnings[i].Line != warnings[j].Line {
+			return warnings[i].Line < warnings[j].Line
+		}
+		return warnings[i].Column < warnings[j].Column
+	})
+	return warnings
+}
+
+// Len returns the number of warnings collected.
+func (b *BuildWarnings) Len() int {
+	if b == nil {
+		return 0
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return len(b.warnings)
+}
+
+// WithBuildWarnings returns a copy of ctx that carries the supplied BuildWarnings collector, every model built with
+// the returned context will add any warnings to it.
+func WithBuildWarnings(ctx context.Context, warnings *BuildWarnings) context.Context {
+	return context.WithValue(ctx, BuildWarningsKey, warnings)
+}
+
+// GetBuildWarnings will return the BuildWarnings collector carried by ctx, or nil if there isn't one.
+func GetBuildWarnings(ctx context.Context) *BuildWarnings {
+	if ctx == nil {
+		return nil
+	}
+	if w, ok := ctx.Value(BuildWarningsKey).(*BuildWarnings); ok {
+		return w
+	}
+	return nil
+}
+
+// AddUnknownKeyWarnings will add a warning to the BuildWarnings collector carried by ctx for every key of the model
+// and every model below it, that is not recognized and was ignored when the model was built (see FindUnknownKeys).
+func AddUnknownKeyWarnings(ctx context.Context, model HasSourceNodes) {
+	warnings := GetBuildWarnings(ctx)
+	if warnings == nil {
+		return
+	}
+	for _, k := range FindUnknownKeys(model) {
+		warnings.Add(k.KeyNode, "unknown key '%s' in %s is ignored", k.Name, k.ParentType)
+	}
+}
+
+// AddBuildWarning will add a warning about the supplied node to the BuildWarnings collector carried by ctx.
+// If there is no collector, nothing happens.
+func AddBuildWarning(ctx context.Context, node *yaml.Node, format string, args ...any) {
+	GetBuildWarnings(ctx).Add(node, format, args...)
+}
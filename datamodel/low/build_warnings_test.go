@@ -0,0 +1,86 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildWarnings_Add(t *testing.T) {
+	warnings := NewBuildWarnings()
+	second := &yaml.Node{Line: 2, Column: 3}
+	first := &yaml.Node{Line: 1, Column: 5}
+
+	warnings.Add(second, "second %s", "warning")
+	warnings.Add(first, "first warning")
+	warnings.Add(first, "first warning") // duplicate, ignored.
+	warnings.Add(nil, "no node")
+
+	assert.Equal(t, 3, warnings.Len())
+	w := warnings.GetWarnings()
+	assert.Len(t, w, 3)
+	assert.Equal(t, "no node", w[0].Message)
+	assert.Equal(t, "first warning", w[1].Message)
+	assert.Equal(t, first, w[1].Node)
+	assert.Equal(t, "second warning, line 2, column 3", w[2].String())
+}
+
+func TestBuildWarnings_Concurrent(t *testing.T) {
+	warnings := &BuildWarnings{}
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			warnings.Add(&yaml.Node{Line: i}, "warning")
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 50, warnings.Len())
+	assert.Equal(t, 49, warnings.GetWarnings()[49].Line)
+}
+
+func TestBuildWarnings_Nil(t *testing.T) {
+	var warnings *BuildWarnings
+	warnings.Add(&yaml.Node{}, "nothing")
+	assert.Nil(t, warnings.GetWarnings())
+	assert.Equal(t, 0, warnings.Len())
+	assert.Nil(t, NewBuildWarnings().GetWarnings())
+}
+
+func TestBuildWarnings_Context(t *testing.T) {
+	assert.Nil(t, GetBuildWarnings(nil))
+	assert.Nil(t, GetBuildWarnings(context.Background()))
+
+	// no collector, nothing happens.
+	AddBuildWarning(context.Background(), &yaml.Node{}, "nothing")
+	AddUnknownKeyWarnings(context.Background(), &unknownKeyChild{})
+
+	warnings := NewBuildWarnings()
+	ctx := WithBuildWarnings(context.Background(), warnings)
+	assert.Equal(t, warnings, GetBuildWarnings(ctx))
+
+	AddBuildWarning(ctx, &yaml.Node{Line: 4}, "value '%s' was coerced", "yes")
+	assert.Equal(t, "value 'yes' was coerced", warnings.GetWarnings()[0].Message)
+}
+
+func TestAddUnknownKeyWarnings(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`name: known
+pizza: unknown
+x-pizza: extension`), &root)
+
+	warnings := NewBuildWarnings()
+	ctx := WithBuildWarnings(context.Background(), warnings)
+	AddUnknownKeyWarnings(ctx, &unknownKeyChild{RootNode: root.Content[0]})
+
+	assert.Equal(t, 1, warnings.Len())
+	assert.Equal(t, "unknown key 'pizza' in low.unknownKeyChild is ignored, line 2, column 1",
+		warnings.GetWarnings()[0].String())
+}
@@ -80,6 +80,16 @@ func LocateRefNodeWithContext(ctx context.Context, root *yaml.Node, idx *index.S
 				root.Line, root.Column), ctx
 		}
 
+		// lookups may be remote, don't start one if building has been cancelled.
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err, ctx
+		}
+
+		// references to the $defs of an enclosing schema are mapped to an absolute definition by the index.
+		if def := idx.GetSchemaRelativeDefinition(root); def != "" {
+			rv = def
+		}
+
 		// run through everything and return as soon as we find a match.
 		// this operates as fast as possible as ever
 		collections := generateIndexCollection(idx)
@@ -270,7 +280,7 @@ func ExtractObjectRaw[T Buildable[N], N any](ctx context.Context, key, root *yam
 			}
 		}
 	}
-	var n T = new(N)
+	var n T = New[N](ctx)
 	err := BuildModel(root, n)
 	if err != nil {
 		return n, err, isReference, referenceValue
@@ -344,7 +354,7 @@ func ExtractObject[T Buildable[N], N any](ctx context.Context, label string, roo
 			}
 		}
 	}
-	var n T = new(N)
+	var n T = New[N](ctx)
 	err := BuildModel(vn, n)
 	if err != nil {
 		return NodeReference[T]{}, err
@@ -477,7 +487,7 @@ func ExtractArray[T Buildable[N], N any](ctx context.Context, label string, root
 					}
 				}
 			}
-			var n T = new(N)
+			var n T = New[N](ctx)
 			err := BuildModel(node, n)
 			if err != nil {
 				return []ValueReference[T]{}, ln, vn, err
@@ -577,7 +587,7 @@ func ExtractMapNoLookupExtensions[PT Buildable[N], N any](
 					}
 				}
 			}
-			var n PT = new(N)
+			var n PT = New[N](ctx)
 			err := BuildModel(node, n)
 			if err != nil {
 				return nil, err
@@ -779,7 +789,7 @@ func ExtractMapExtensions[PT Buildable[N], N any](
 				}
 			}
 
-			var n PT = new(N)
+			var n PT = New[N](ctx)
 			en = utils.NodeAlias(en)
 			_ = BuildModel(en, n)
 			err := n.Build(sCtx, input.label, en, sIdx)
@@ -862,6 +872,36 @@ func ExtractExtensions(root *yaml.Node) *orderedmap.Map[KeyReference[string], Va
 	return extensionMap
 }
 
+// ExtensionNodeReferences converts extensions into an ordered map of NodeReferences keyed by the extension name,
+// in the order they appear in the document. Each NodeReference holds the key node and value node of the extension,
+// so tooling can report precise locations, or edit an extension in place. Returns nil if there are no extensions.
+func ExtensionNodeReferences(ext *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]]) *orderedmap.Map[string, NodeReference[*yaml.Node]] {
+	if orderedmap.Len(ext) == 0 {
+		return nil
+	}
+	refs := orderedmap.New[string, NodeReference[*yaml.Node]]()
+	for k, v := range ext.FromOldest() {
+		refs.Set(k.Value, NodeReference[*yaml.Node]{
+			Value:     v.Value,
+			KeyNode:   k.KeyNode,
+			ValueNode: v.ValueNode,
+		})
+	}
+	return refs
+}
+
+// GetExtensionNodeReferences returns the extensions of any low level object that has them as an ordered map
+// of NodeReferences, see ExtensionNodeReferences.
+func GetExtensionNodeReferences(obj HasExtensionsUntyped) *orderedmap.Map[string, NodeReference[*yaml.Node]] {
+	if obj == nil {
+		return nil
+	}
+	if v := reflect.ValueOf(obj); v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil
+	}
+	return ExtensionNodeReferences(obj.GetExtensions())
+}
+
 // AreEqual returns true if two Hashable objects are equal or not.
 func AreEqual(l, r Hashable) bool {
 	if l == nil || r == nil {
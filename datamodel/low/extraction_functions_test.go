@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -74,6 +75,29 @@ func TestLocateRefNode(t *testing.T) {
 	assert.NotNil(t, located)
 }
 
+func TestLocateRefNodeWithContext_Cancelled(t *testing.T) {
+	yml := `components:
+  schemas:
+    cake:
+      description: hello`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateClosedAPIIndexConfig())
+
+	yml = `$ref: '#/components/schemas/cake'`
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	located, _, err, _ := LocateRefNodeWithContext(ctx, cNode.Content[0], idx)
+	assert.Nil(t, located)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestLocateRefNode_BadNode(t *testing.T) {
 	yml := `components:
   schemas:
@@ -2204,6 +2228,54 @@ func TestExtractExtensions_Nill(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestExtensionNodeReferences(t *testing.T) {
+	yml := `description: not an extension
+x-bing: ding
+x-fish:
+  woo: yeah`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	refs := ExtensionNodeReferences(ExtractExtensions(idxNode.Content[0]))
+	assert.Equal(t, 2, refs.Len())
+	assert.Equal(t, []string{"x-bing", "x-fish"}, slices.Collect(refs.KeysFromOldest()))
+
+	bing := refs.GetOrZero("x-bing")
+	assert.Equal(t, "x-bing", bing.KeyNode.Value)
+	assert.Equal(t, 2, bing.KeyNode.Line)
+	assert.Equal(t, "ding", bing.ValueNode.Value)
+	assert.Equal(t, bing.ValueNode, bing.Value)
+
+	fish := refs.GetOrZero("x-fish")
+	assert.Equal(t, 3, fish.KeyNode.Line)
+	assert.Equal(t, yaml.MappingNode, fish.ValueNode.Kind)
+
+	assert.Nil(t, ExtensionNodeReferences(nil))
+	assert.Nil(t, ExtensionNodeReferences(ExtractExtensions(&yaml.Node{Kind: yaml.MappingNode})))
+}
+
+type extensionsHolder struct {
+	ext *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]]
+}
+
+func (e *extensionsHolder) GetExtensions() *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]] {
+	return e.ext
+}
+
+func TestGetExtensionNodeReferences(t *testing.T) {
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`x-bing: ding`), &idxNode)
+
+	holder := &extensionsHolder{ext: ExtractExtensions(idxNode.Content[0])}
+	refs := GetExtensionNodeReferences(holder)
+	assert.Equal(t, "ding", refs.GetOrZero("x-bing").Value.Value)
+
+	var nilHolder *extensionsHolder
+	assert.Nil(t, GetExtensionNodeReferences(nilHolder))
+	assert.Nil(t, GetExtensionNodeReferences(nil))
+}
+
 func TestFromReferenceMap(t *testing.T) {
 	refMap := orderedmap.New[KeyReference[string], ValueReference[string]]()
 	refMap.Set(KeyReference[string]{Value: "foo"}, ValueReference[string]{Value: "bar"})
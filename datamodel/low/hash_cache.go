@@ -0,0 +1,242 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// HashScopeKey is the context key used to carry a HashScope through the model building process.
+const HashScopeKey index.ContextKey = "hashScope"
+
+// HashScope controls the caching of hashes for the low-level models of a single document. Hashing a model hashes
+// everything below it, which is expensive for large documents, so hashes can be cached. Because a model can be
+// changed directly (without going through SetValue or ReplaceNode), hashes are only cached while the scope is frozen,
+// when nothing is changing the document, or while the scope keeps the hashes of a document that is only changed
+// through SetValue and ReplaceNode (see Keep). Otherwise, Hash() always reflects the current values.
+//
+// Values worked out from the nodes of the document (see NodeValue) are cached in the same way.
+//
+// A HashScope is safe for concurrent use.
+type HashScope struct {
+	lock       sync.Mutex
+	frozen     int
+	generation atomic.Uint64 // odd while frozen, bumped every time a freeze starts and ends.
+	nodeValues atomic.Pointer[nodeValueCache]
+	release    func() // releases the freeze held by Keep.
+}
+
+// keptNodes holds every node of the documents whose hashes are kept, by the scope keeping them (see HashScope.Keep).
+var keptNodes sync.Map // *yaml.Node -> *HashScope
+
+// nodeValueCache holds the values worked out from nodes during a single generation of a HashScope.
+type nodeValueCache struct {
+	generation uint64
+	values     sync.Map
+}
+
+type nodeValueKey struct {
+	node *yaml.Node
+	kind string
+}
+
+// NewHashScope creates a new HashScope, that is not frozen.
+func NewHashScope() *HashScope {
+	return new(HashScope)
+}
+
+// Freeze caches the hashes of the models of the document until the returned function is called. The document must
+// not be changed while it is frozen. Freezes can be nested, hashes are cached until the last one is released.
+//
+// Nothing cached during a freeze is used again once it is released, because the document may have changed since.
+func (s *HashScope) Freeze() func() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.frozen++; s.frozen == 1 {
+		s.generation.Add(1)
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.lock.Lock()
+			defer s.lock.Unlock()
+			if s.frozen--; s.frozen == 0 {
+				s.generation.Add(1)
+				s.nodeValues.Store(nil)
+			}
+		})
+	}
+}
+
+// Keep caches the hashes of the models of the document between calls to Hash(), until Release is called. Unlike a
+// freeze, the document can be changed while its hashes are kept, as long as it's changed through SetValue or
+// ReplaceNode (of NodeReference or ValueReference): changing any node of the supplied trees that way drops every
+// hash cached in the scope. Changing a model directly does not, Invalidate needs to be called after.
+//
+// A node is kept by the first scope keeping it, so a tree should only be kept by a single scope.
+func (s *HashScope) Keep(roots ...*yaml.Node) {
+	release := s.Freeze()
+	s.lock.Lock()
+	previous := s.release
+	s.release = release
+	s.lock.Unlock()
+	if previous != nil {
+		previous()
+	}
+	for _, root := range roots {
+		if root != nil {
+			keptNodes.LoadOrStore(root, s)
+			s.keep(root)
+		}
+	}
+}
+
+// keep marks every node below n as kept by the scope, stopping at nodes that are already kept.
+func (s *HashScope) keep(n *yaml.Node) {
+	for _, child := range n.Content {
+		if _, loaded := keptNodes.LoadOrStore(child, s); !loaded {
+			s.keep(child)
+		}
+	}
+}
+
+// Release stops keeping the hashes of the document (see Keep), a scope that is not keeping hashes is left as it is.
+func (s *HashScope) Release() {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	release := s.release
+	s.release = nil
+	s.lock.Unlock()
+	if release == nil {
+		return
+	}
+	keptNodes.Range(func(n, scope any) bool {
+		if scope == s {
+			keptNodes.Delete(n)
+		}
+		return true
+	})
+	release()
+}
+
+// invalidateKeptHashes is called when a node is changed through SetValue or ReplaceNode, it drops the hashes of the
+// scope keeping the node (if any), and keeps the new content of the node.
+func invalidateKeptHashes(node *yaml.Node) {
+	if scope, ok := keptNodes.Load(node); ok {
+		s := scope.(*HashScope)
+		s.keep(node)
+		s.Invalidate()
+	}
+}
+
+// Invalidate drops every hash cached in the scope, it needs to be called if the document is changed while frozen
+// (or while its hashes are kept, see Keep), other than through SetValue or ReplaceNode.
+func (s *HashScope) Invalidate() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.frozen > 0 {
+		s.generation.Add(2)
+		s.nodeValues.Store(nil)
+	}
+}
+
+// NodeValue returns a value worked out from a node of the document, kind names what the value is (a node can have
+// more than one value worked out from it). While the scope is frozen, the value is cached, and compute is not called
+// again for the same node and kind. Outside of a freeze, compute is always called.
+func (s *HashScope) NodeValue(node *yaml.Node, kind string, compute func() string) string {
+	if s == nil || node == nil {
+		return compute()
+	}
+	generation := s.generation.Load()
+	if generation%2 == 0 {
+		return compute()
+	}
+	c := s.nodeValues.Load()
+	if c == nil || c.generation != generation {
+		s.lock.Lock()
+		if s.generation.Load() != generation {
+			// the freeze was released (or invalidated) since.
+			s.lock.Unlock()
+			return compute()
+		}
+		if c = s.nodeValues.Load(); c == nil || c.generation != generation {
+			c = &nodeValueCache{generation: generation}
+			s.nodeValues.Store(c)
+		}
+		s.lock.Unlock()
+	}
+	key := nodeValueKey{node, kind}
+	if v, ok := c.values.Load(key); ok {
+		return v.(string)
+	}
+	v := compute()
+	c.values.Store(key, v)
+	return v
+}
+
+// WithHashScope returns a copy of ctx carrying a HashScope, every model built with it caches hashes in the scope.
+func WithHashScope(ctx context.Context, scope *HashScope) context.Context {
+	return context.WithValue(ctx, HashScopeKey, scope)
+}
+
+// GetHashScope will return the HashScope carried by ctx, or nil if there isn't one.
+func GetHashScope(ctx context.Context) *HashScope {
+	if ctx == nil {
+		return nil
+	}
+	if s, ok := ctx.Value(HashScopeKey).(*HashScope); ok {
+		return s
+	}
+	return nil
+}
+
+// HashCache holds the cached hash of a low-level model, for the HashScope of the document the model belongs to.
+// A model without a scope (or whose scope is not frozen or keeping hashes) never caches its hash.
+//
+// A HashCache is safe for concurrent use, it must not be copied after first use.
+type HashCache struct {
+	scope *HashScope
+	entry atomic.Pointer[hashCacheEntry]
+}
+
+type hashCacheEntry struct {
+	generation uint64
+	hash       [32]byte
+}
+
+// SetScope sets the HashScope the hash is cached for, it's called when the model is built.
+func (c *HashCache) SetScope(scope *HashScope) {
+	c.scope = scope
+	c.entry.Store(nil)
+}
+
+// GetOrCompute returns the cached hash, or calls compute and caches the result if there is no valid hash cached.
+func (c *HashCache) GetOrCompute(compute func() [32]byte) [32]byte {
+	if c.scope == nil {
+		return compute()
+	}
+	generation := c.scope.generation.Load()
+	if generation%2 == 0 {
+		// not frozen, the model may be changing.
+		return compute()
+	}
+	if e := c.entry.Load(); e != nil && e.generation == generation {
+		return e.hash
+	}
+	h := compute()
+	c.entry.Store(&hashCacheEntry{generation: generation, hash: h})
+	return h
+}
+
+// Reset clears the cached hash.
+func (c *HashCache) Reset() {
+	c.entry.Store(nil)
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestHashCache_GetOrCompute(t *testing.T) {
+	scope := NewHashScope()
+	var c HashCache
+	c.SetScope(scope)
+	calls := 0
+	compute := func() [32]byte {
+		calls++
+		return sha256.Sum256([]byte("pizza"))
+	}
+
+	// not frozen, nothing is cached.
+	h := c.GetOrCompute(compute)
+	assert.Equal(t, sha256.Sum256([]byte("pizza")), h)
+	assert.Equal(t, h, c.GetOrCompute(compute))
+	assert.Equal(t, 2, calls)
+
+	release := scope.Freeze()
+	nested := scope.Freeze()
+	assert.Equal(t, h, c.GetOrCompute(compute))
+	assert.Equal(t, h, c.GetOrCompute(compute))
+	assert.Equal(t, 3, calls)
+
+	scope.Invalidate()
+	c.GetOrCompute(compute)
+	assert.Equal(t, 4, calls)
+
+	c.Reset()
+	c.GetOrCompute(compute)
+	assert.Equal(t, 5, calls)
+
+	// still frozen until the last freeze is released, releasing twice does nothing.
+	nested()
+	nested()
+	c.GetOrCompute(compute)
+	assert.Equal(t, 5, calls)
+
+	// hashes cached during a freeze are not used by the next one.
+	release()
+	release = scope.Freeze()
+	c.GetOrCompute(compute)
+	assert.Equal(t, 6, calls)
+	release()
+
+	// no scope, nothing is cached.
+	var none HashCache
+	none.GetOrCompute(compute)
+	none.GetOrCompute(compute)
+	assert.Equal(t, 8, calls)
+}
+
+func TestGetHashScope(t *testing.T) {
+	scope := NewHashScope()
+	assert.Same(t, scope, GetHashScope(WithHashScope(context.Background(), scope)))
+	assert.Nil(t, GetHashScope(context.Background()))
+	assert.Nil(t, GetHashScope(nil))
+}
+
+func TestHashScope_NodeValue(t *testing.T) {
+	scope := NewHashScope()
+	node := &yaml.Node{Kind: yaml.ScalarNode, Value: "pizza"}
+	calls := 0
+	compute := func() string {
+		calls++
+		return node.Value
+	}
+
+	// not frozen, nothing is cached.
+	assert.Equal(t, "pizza", scope.NodeValue(node, "key", compute))
+	assert.Equal(t, "pizza", scope.NodeValue(node, "key", compute))
+	assert.Equal(t, 2, calls)
+
+	release := scope.Freeze()
+	assert.Equal(t, "pizza", scope.NodeValue(node, "key", compute))
+	assert.Equal(t, "pizza", scope.NodeValue(node, "key", compute))
+	assert.Equal(t, 3, calls)
+
+	// every kind of value is cached on its own.
+	scope.NodeValue(node, "hash", compute)
+	assert.Equal(t, 4, calls)
+
+	node.Value = "burger"
+	scope.Invalidate()
+	assert.Equal(t, "burger", scope.NodeValue(node, "key", compute))
+	assert.Equal(t, 5, calls)
+
+	// nothing is held on to once the freeze is released.
+	release()
+	assert.Nil(t, scope.nodeValues.Load())
+	scope.NodeValue(node, "key", compute)
+	assert.Equal(t, 6, calls)
+
+	// no scope, nothing is cached.
+	var none *HashScope
+	none.NodeValue(node, "key", compute)
+	none.NodeValue(node, "key", compute)
+	assert.Equal(t, 8, calls)
+}
+
+func TestHashScope_Keep(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte("name: pizza\ntoppings:\n  cheese: true"), &root)
+	scope := NewHashScope()
+	var c HashCache
+	c.SetScope(scope)
+	calls := 0
+	compute := func() [32]byte {
+		calls++
+		return sha256.Sum256([]byte(root.Content[0].Content[1].Value))
+	}
+
+	// kept, the hash is cached between calls.
+	scope.Keep(&root)
+	h := c.GetOrCompute(compute)
+	assert.Equal(t, h, c.GetOrCompute(compute))
+	assert.Equal(t, 1, calls)
+
+	// changing a node of the document drops the cached hash.
+	name := NodeReference[string]{Value: "pizza", ValueNode: root.Content[0].Content[1]}
+	assert.NoError(t, name.SetValue("burger"))
+	assert.NotEqual(t, h, c.GetOrCompute(compute))
+	assert.Equal(t, sha256.Sum256([]byte("burger")), c.GetOrCompute(compute))
+	assert.Equal(t, 2, calls)
+
+	// the new content of a replaced node is kept too.
+	toppings := NodeReference[*yaml.Node]{ValueNode: root.Content[0].Content[3]}
+	var replacement yaml.Node
+	_ = yaml.Unmarshal([]byte("ham: true"), &replacement)
+	assert.NoError(t, toppings.ReplaceNode(&replacement))
+	c.GetOrCompute(compute)
+	assert.Equal(t, 3, calls)
+	ham := NodeReference[bool]{Value: true, ValueNode: root.Content[0].Content[3].Content[1]}
+	assert.NoError(t, ham.SetValue(false))
+	c.GetOrCompute(compute)
+	c.GetOrCompute(compute)
+	assert.Equal(t, 4, calls)
+
+	// released, nothing is cached or kept.
+	scope.Release()
+	scope.Release()
+	c.GetOrCompute(compute)
+	c.GetOrCompute(compute)
+	assert.Equal(t, 6, calls)
+	_, kept := keptNodes.Load(root.Content[0])
+	assert.False(t, kept)
+}
@@ -20,3 +20,15 @@ import "gopkg.in/yaml.v3"
 type HasRootNode interface {
 	GetRootNode() *yaml.Node
 }
+
+// HasSourceNodes is implemented by every low-level model. It provides uniform access to the yaml.Node instances
+// that an object was built from, so generic tooling can locate source positions and raw nodes without needing
+// to know the concrete type of the model.
+//
+// GetKeyNode returns the key the object was defined under (nil for top-level documents), GetRootNode and
+// GetValueNode both return the node holding the object itself.
+type HasSourceNodes interface {
+	HasKeyNode
+	HasRootNode
+	GetValueNode() *yaml.Node
+}
@@ -31,6 +31,7 @@ func BuildModel(node *yaml.Node, model interface{}) error {
 	}
 	v := reflect.ValueOf(model).Elem()
 	num := v.NumField()
+	keys := utils.NewKeyIndex(node)
 	for i := 0; i < num; i++ {
 
 		fName := v.Type().Field(i).Name
@@ -43,7 +44,7 @@ func BuildModel(node *yaml.Node, model interface{}) error {
 			continue // internal construct
 		}
 
-		kn, vn := utils.FindKeyNodeTop(fName, node.Content)
+		kn, vn := keys.FindFold(fName)
 		if vn == nil {
 			// no point in going on.
 			continue
@@ -0,0 +1,218 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrReferenceNode is returned when changing a node that holds references ($ref), or changing a node so that it
+// holds references. The index of a document maps every reference to the node holding it, changing those nodes in
+// place would leave the index out of date. Build the document again from the changed specification instead (see
+// Document.RenderAndReload).
+var ErrReferenceNode = datamodel.NewCodedError("reference-node",
+	"unable to change the node, it holds references mapped by the index")
+
+// SetValue will set the Value of the reference and update the underlying ValueNode to match. The ValueNode is
+// updated in place, so the root document is mutated and the change is reflected in any serialization of it.
+// Because the node is not replaced, any structure holding the node (like the index) sees the new content, so the
+// index stays consistent with the document. Nodes that hold references, or values that would add references, are
+// not changed, an error matching ErrReferenceNode is returned instead.
+//
+// The style of the original node is preserved where possible (quoting, literal and folded strings, block or flow
+// collections). Line, column, comments and anchors are preserved. Low-level models (like *base.Schema) cannot be
+// set this way, they need to be rebuilt from a node instead.
+//
+// Nodes of trees shared with other documents (see datamodel.SharedResolutionCache) are not changed, an error matching
+// datamodel.ErrSharedNode is returned instead. Hashes kept for the document are dropped (see HashScope.Keep).
+func (n *NodeReference[T]) SetValue(value T) error {
+	v, err := setValue(n.ValueNode, value)
+	if err != nil {
+		return err
+	}
+	n.Value = v
+	return nil
+}
+
+// ReplaceNode will replace the contents of the ValueNode with the supplied node, and decode the new Value from it.
+// Like SetValue, the ValueNode is updated in place, and shared nodes or nodes holding references are not changed.
+// If the supplied node has no style set, the style of the original node is kept.
+func (n *NodeReference[T]) ReplaceNode(node *yaml.Node) error {
+	v, err := replaceNode[T](n.ValueNode, node)
+	if err != nil {
+		return err
+	}
+	n.Value = v
+	return nil
+}
+
+// SetValue will set the Value of the reference and update the underlying ValueNode to match, the same as
+// NodeReference.SetValue.
+func (n *ValueReference[T]) SetValue(value T) error {
+	v, err := setValue(n.ValueNode, value)
+	if err != nil {
+		return err
+	}
+	n.Value = v
+	return nil
+}
+
+// ReplaceNode will replace the contents of the ValueNode with the supplied node, and decode the new Value from it,
+// the same as NodeReference.ReplaceNode.
+func (n *ValueReference[T]) ReplaceNode(node *yaml.Node) error {
+	v, err := replaceNode[T](n.ValueNode, node)
+	if err != nil {
+		return err
+	}
+	n.Value = v
+	return nil
+}
+
+func setValue[T any](target *yaml.Node, value T) (T, error) {
+	if target == nil {
+		return value, errors.New("unable to set value: reference has no value node")
+	}
+	if datamodel.IsSharedNode(target) {
+		return value, datamodel.NewError(datamodel.ErrSharedNode, nil,
+			"unable to set value: the value node is shared with other documents")
+	}
+	if node, ok := any(value).(*yaml.Node); ok {
+		// the value becomes the (updated) value node.
+		return replaceNode[T](target, node)
+	}
+	if err := checkMutable[T](); err != nil {
+		return value, err
+	}
+	var encoded yaml.Node
+	if err := encoded.Encode(value); err != nil {
+		return value, fmt.Errorf("unable to set value: %s", err.Error())
+	}
+	if holdsReference(target) || holdsReference(&encoded) {
+		return value, datamodel.NewError(ErrReferenceNode, nil,
+			"unable to set value: the value node holds references at line %d, col %d", target.Line, target.Column)
+	}
+	encoded.Style = mergeStyle(target, &encoded)
+	copyNodeInPlace(target, &encoded)
+	invalidateKeptHashes(target)
+	return value, nil
+}
+
+func replaceNode[T any](target, node *yaml.Node) (T, error) {
+	var v T
+	if target == nil {
+		return v, errors.New("unable to replace node: reference has no value node")
+	}
+	if node == nil {
+		return v, errors.New("unable to replace node: replacement node is nil")
+	}
+	if datamodel.IsSharedNode(target) {
+		return v, datamodel.NewError(datamodel.ErrSharedNode, nil,
+			"unable to replace node: the value node is shared with other documents")
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if _, ok := any(v).(*yaml.Node); !ok {
+		if err := checkMutable[T](); err != nil {
+			return v, err
+		}
+		// decode first, so nothing is changed if the node is not compatible.
+		if err := node.Decode(&v); err != nil {
+			return v, fmt.Errorf("unable to replace node: %s", err.Error())
+		}
+	}
+	if holdsReference(target) || holdsReference(node) {
+		return v, datamodel.NewError(ErrReferenceNode, nil,
+			"unable to replace node: the value node holds references at line %d, col %d", target.Line, target.Column)
+	}
+	replacement := *node
+	if replacement.Style == 0 {
+		replacement.Style = mergeStyle(target, &replacement)
+	}
+	copyNodeInPlace(target, &replacement)
+	invalidateKeptHashes(target)
+	if _, ok := any(v).(*yaml.Node); ok {
+		v = any(target).(T)
+	}
+	return v, nil
+}
+
+// holdsReference returns true if a node, or any node in its tree, is a mapping with a $ref key. Aliases are not
+// followed, the anchors they point to are part of the tree somewhere else.
+func holdsReference(node *yaml.Node) bool {
+	if node == nil {
+		return false
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" {
+				return true
+			}
+		}
+	}
+	for _, child := range node.Content {
+		if holdsReference(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMutable returns an error if T is a low-level model, models can't be set from a value or decoded from a node.
+func checkMutable[T any]() error {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct && strings.HasPrefix(t.PkgPath(), lowPackage) {
+		return fmt.Errorf("unable to mutate '%s': low-level models must be rebuilt", t.String())
+	}
+	return nil
+}
+
+// mergeStyle works out the style to use for a new node, preferring the style of the original node
+// unless it would change the meaning of the new value.
+func mergeStyle(original, updated *yaml.Node) yaml.Style {
+	if original.Kind != updated.Kind {
+		return updated.Style
+	}
+	switch updated.Kind {
+	case yaml.ScalarNode:
+		if updated.Tag != "!!str" {
+			// numbers, booleans and nulls are never quoted.
+			return updated.Style &^ (yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle | yaml.LiteralStyle | yaml.FoldedStyle)
+		}
+		if original.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			return original.Style
+		}
+		// the original was plain, the new value may need quoting to remain a string.
+		return updated.Style
+	case yaml.MappingNode, yaml.SequenceNode:
+		return updated.Style | (original.Style & yaml.FlowStyle)
+	}
+	return updated.Style
+}
+
+// copyNodeInPlace copies the content of updated into target, keeping the position, comments and anchor of target.
+func copyNodeInPlace(target, updated *yaml.Node) {
+	line, column, anchor := target.Line, target.Column, target.Anchor
+	head, lineComment, foot := target.HeadComment, target.LineComment, target.FootComment
+	*target = *updated
+	target.Line, target.Column, target.Anchor = line, column, anchor
+	if target.HeadComment == "" {
+		target.HeadComment = head
+	}
+	if target.LineComment == "" {
+		target.LineComment = lineComment
+	}
+	if target.FootComment == "" {
+		target.FootComment = foot
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func parseMutateYAML(t *testing.T, spec string) *yaml.Node {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	return root.Content[0]
+}
+
+func renderMutateYAML(t *testing.T, node *yaml.Node) string {
+	b, err := yaml.Marshal(node)
+	require.NoError(t, err)
+	return strings.TrimSpace(string(b))
+}
+
+func TestNodeReference_SetValue_PreserveQuotes(t *testing.T) {
+	root := parseMutateYAML(t, `description: "a pizza" # the best kind`)
+	nr := NodeReference[string]{KeyNode: root.Content[0], ValueNode: root.Content[1]}
+	vn := nr.ValueNode
+
+	require.NoError(t, nr.SetValue("a burger"))
+	assert.Equal(t, "a burger", nr.Value)
+	assert.Same(t, vn, root.Content[1])
+	assert.Equal(t, 1, vn.Line)
+	assert.Equal(t, 14, vn.Column)
+	assert.Equal(t, `description: "a burger" # the best kind`, renderMutateYAML(t, root))
+}
+
+func TestNodeReference_SetValue_QuoteWhenRequired(t *testing.T) {
+	root := parseMutateYAML(t, `description: pizza`)
+	nr := NodeReference[string]{KeyNode: root.Content[0], ValueNode: root.Content[1]}
+
+	require.NoError(t, nr.SetValue("burger"))
+	assert.Equal(t, `description: burger`, renderMutateYAML(t, root))
+
+	require.NoError(t, nr.SetValue("true"))
+	assert.Equal(t, `description: "true"`, renderMutateYAML(t, root))
+}
+
+func TestNodeReference_SetValue_Literal(t *testing.T) {
+	root := parseMutateYAML(t, "description: |\n  one\n  two\n")
+	nr := NodeReference[string]{KeyNode: root.Content[0], ValueNode: root.Content[1]}
+
+	require.NoError(t, nr.SetValue("three\nfour\n"))
+	assert.Equal(t, yaml.LiteralStyle, nr.ValueNode.Style)
+	assert.Equal(t, "description: |\n    three\n    four", renderMutateYAML(t, root))
+}
+
+func TestNodeReference_SetValue_NotQuotedNumber(t *testing.T) {
+	root := parseMutateYAML(t, `max: '10'`)
+	nr := NodeReference[int]{KeyNode: root.Content[0], ValueNode: root.Content[1]}
+
+	require.NoError(t, nr.SetValue(20))
+	assert.Equal(t, 20, nr.Value)
+	assert.Equal(t, "!!int", nr.ValueNode.Tag)
+	assert.Equal(t, `max: 20`, renderMutateYAML(t, root))
+}
+
+func TestNodeReference_SetValue_FlowSequence(t *testing.T) {
+	root := parseMutateYAML(t, `required: [a, b]`)
+	nr := NodeReference[[]string]{KeyNode: root.Content[0], ValueNode: root.Content[1]}
+
+	require.NoError(t, nr.SetValue([]string{"c", "d", "e"}))
+	assert.Equal(t, []string{"c", "d", "e"}, nr.Value)
+	assert.Equal(t, `required: [c, d, e]`, renderMutateYAML(t, root))
+}
+
+func TestNodeReference_SetValue_BlockSequence(t *testing.T) {
+	root := parseMutateYAML(t, "required:\n  - a\n")
+	nr := NodeReference[[]string]{KeyNode: root.Content[0], ValueNode: root.Content[1]}
+
+	require.NoError(t, nr.SetValue([]string{"c", "d"}))
+	assert.Equal(t, "required:\n    - c\n    - d", renderMutateYAML(t, root))
+}
+
+func TestNodeReference_SetValue_Anchor(t *testing.T) {
+	root := parseMutateYAML(t, "a: &pizza hot\nb: *pizza")
+	nr := NodeReference[string]{KeyNode: root.Content[0], ValueNode: root.Content[1]}
+
+	require.NoError(t, nr.SetValue("cold"))
+	assert.Equal(t, "pizza", nr.ValueNode.Anchor)
+	assert.Equal(t, "a: &pizza cold\nb: *pizza", renderMutateYAML(t, root))
+}
+
+func TestNodeReference_SetValue_YAMLNode(t *testing.T) {
+	root := parseMutateYAML(t, `example: pizza`)
+	nr := NodeReference[*yaml.Node]{KeyNode: root.Content[0], ValueNode: root.Content[1], Value: root.Content[1]}
+
+	replacement := parseMutateYAML(t, `{cheese: yes}`)
+	require.NoError(t, nr.SetValue(replacement))
+	assert.Same(t, nr.ValueNode, nr.Value)
+	assert.Same(t, root.Content[1], nr.Value)
+	assert.Equal(t, `example: {cheese: yes}`, renderMutateYAML(t, root))
+}
+
+func TestNodeReference_SetValue_NoValueNode(t *testing.T) {
+	nr := NodeReference[string]{Value: "pizza"}
+	err := nr.SetValue("burger")
+	assert.Error(t, err)
+	assert.Equal(t, "pizza", nr.Value)
+}
+
+func TestNodeReference_SetValue_LowModel(t *testing.T) {
+	root := parseMutateYAML(t, `contact: pizza`)
+	nr := NodeReference[*Reference]{KeyNode: root.Content[0], ValueNode: root.Content[1]}
+	err := nr.SetValue(&Reference{})
+	assert.Error(t, err)
+	assert.Equal(t, "pizza", root.Content[1].Value)
+}
+
+func TestNodeReference_SetValue_SharedNode(t *testing.T) {
+	cache := datamodel.NewSharedResolutionCache()
+	data := []byte(`contact: pizza`)
+	shared, ok, err := cache.Tree(data, func() (*yaml.Node, error) {
+		var root yaml.Node
+		return &root, yaml.Unmarshal(data, &root)
+	})
+	require.NoError(t, err)
+	require.True(t, ok)
+	root := shared.Content[0]
+
+	// the tree is shared with other documents, it's never changed.
+	nr := NodeReference[string]{KeyNode: root.Content[0], ValueNode: root.Content[1], Value: "pizza"}
+	assert.ErrorIs(t, nr.SetValue("burger"), datamodel.ErrSharedNode)
+	assert.ErrorIs(t, nr.ReplaceNode(&yaml.Node{Kind: yaml.ScalarNode, Value: "burger"}), datamodel.ErrSharedNode)
+	vr := ValueReference[string]{ValueNode: root.Content[1], Value: "pizza"}
+	assert.ErrorIs(t, vr.SetValue("burger"), datamodel.ErrSharedNode)
+	assert.Equal(t, "pizza", root.Content[1].Value)
+	assert.Equal(t, "pizza", nr.Value)
+
+	// once the cache no longer shares it, it can be changed.
+	cache.Clear()
+	require.NoError(t, nr.SetValue("burger"))
+	assert.Equal(t, "burger", root.Content[1].Value)
+}
+
+func TestNodeReference_SetValue_ReferenceNode(t *testing.T) {
+	root := parseMutateYAML(t, `schema:
+  items:
+    $ref: '#/components/schemas/Pizza'
+other: pizza`)
+
+	// the index maps the reference to its node, so it can't be changed in place.
+	nr := NodeReference[*yaml.Node]{KeyNode: root.Content[0], ValueNode: root.Content[1], Value: root.Content[1]}
+	err := nr.ReplaceNode(parseMutateYAML(t, `type: string`))
+	assert.ErrorIs(t, err, ErrReferenceNode)
+	assert.ErrorContains(t, err, "line 2, col 3")
+	assert.ErrorIs(t, nr.SetValue(parseMutateYAML(t, `type: string`)), ErrReferenceNode)
+	assert.True(t, holdsReference(root.Content[1]))
+
+	// nor can references be added.
+	other := NodeReference[map[string]string]{KeyNode: root.Content[2], ValueNode: root.Content[3]}
+	assert.ErrorIs(t, other.SetValue(map[string]string{"$ref": "#/components/schemas/Burger"}), ErrReferenceNode)
+	vr := ValueReference[*yaml.Node]{ValueNode: root.Content[3], Value: root.Content[3]}
+	assert.ErrorIs(t, vr.ReplaceNode(parseMutateYAML(t, `{$ref: '#/components/schemas/Burger'}`)), ErrReferenceNode)
+	assert.Equal(t, "pizza", root.Content[3].Value)
+
+	// anything else can.
+	require.NoError(t, other.SetValue(map[string]string{"type": "string"}))
+	assert.False(t, holdsReference(root.Content[3]))
+}
+
+func TestNodeReference_ReplaceNode(t *testing.T) {
+	root := parseMutateYAML(t, `enum: [a, b]`)
+	nr := NodeReference[[]string]{KeyNode: root.Content[0], ValueNode: root.Content[1]}
+	vn := nr.ValueNode
+
+	require.NoError(t, nr.ReplaceNode(parseMutateYAML(t, "- x\n- y\n- z")))
+	assert.Equal(t, []string{"x", "y", "z"}, nr.Value)
+	assert.Same(t, vn, root.Content[1])
+	assert.Equal(t, 1, vn.Line)
+	assert.Equal(t, `enum: [x, y, z]`, renderMutateYAML(t, root))
+}
+
+func TestNodeReference_ReplaceNode_Invalid(t *testing.T) {
+	root := parseMutateYAML(t, `enum: [a, b]`)
+	nr := NodeReference[[]string]{KeyNode: root.Content[0], ValueNode: root.Content[1], Value: []string{"a", "b"}}
+
+	assert.Error(t, nr.ReplaceNode(parseMutateYAML(t, `{not: a list}`)))
+	assert.Error(t, nr.ReplaceNode(nil))
+	assert.Equal(t, []string{"a", "b"}, nr.Value)
+	assert.Equal(t, `enum: [a, b]`, renderMutateYAML(t, root))
+
+	empty := NodeReference[string]{}
+	assert.Error(t, empty.ReplaceNode(parseMutateYAML(t, `pizza`)))
+}
+
+func TestValueReference_SetValue(t *testing.T) {
+	root := parseMutateYAML(t, `- 'one'`)
+	vr := ValueReference[string]{ValueNode: root.Content[0]}
+
+	require.NoError(t, vr.SetValue("two"))
+	assert.Equal(t, "two", vr.Value)
+	assert.Equal(t, `- 'two'`, renderMutateYAML(t, root))
+
+	assert.Error(t, (&ValueReference[string]{}).SetValue("three"))
+}
+
+func TestValueReference_ReplaceNode(t *testing.T) {
+	root := parseMutateYAML(t, `- "one"`)
+	vr := ValueReference[string]{ValueNode: root.Content[0]}
+
+	require.NoError(t, vr.ReplaceNode(parseMutateYAML(t, `two`)))
+	assert.Equal(t, "two", vr.Value)
+	assert.Equal(t, `- "two"`, renderMutateYAML(t, root))
+
+	assert.Error(t, vr.ReplaceNode(nil))
+}
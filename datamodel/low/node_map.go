@@ -95,9 +95,8 @@ func (nm *NodeMap) ContainsLine(line int) bool {
 }
 
 // ExtractNodes will extract all nodes from a yaml.Node and return them in a map
-func ExtractNodes(_ context.Context, root *yaml.Node) *sync.Map {
-	var syncMap sync.Map
-	nm := &NodeMap{Nodes: &syncMap}
+func ExtractNodes(ctx context.Context, root *yaml.Node) *sync.Map {
+	nm := &NodeMap{Nodes: New[sync.Map](ctx)}
 	nm.ExtractNodes(root, false)
 	return nm.Nodes
 }
@@ -105,9 +104,8 @@ func ExtractNodes(_ context.Context, root *yaml.Node) *sync.Map {
 // ExtractNodesRecursive will extract all nodes from a yaml.Node and return them in a map, just like ExtractNodes
 // however, this version will dive-down the tree and extract all nodes from all child nodes as well until the tree
 // is done.
-func ExtractNodesRecursive(_ context.Context, root *yaml.Node) *sync.Map {
-	var syncMap sync.Map
-	nm := &NodeMap{Nodes: &syncMap}
+func ExtractNodesRecursive(ctx context.Context, root *yaml.Node) *sync.Map {
+	nm := &NodeMap{Nodes: New[sync.Map](ctx)}
 	nm.ExtractNodes(root, true)
 	return nm.Nodes
 }
@@ -0,0 +1,35 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pb33f/libopenapi/index"
+)
+
+// PathFilterKey is the context key used to carry the paths that should be built through the model building process.
+const PathFilterKey index.ContextKey = "pathFilter"
+
+// WithPathFilter returns a copy of ctx that limits the path items built with it to the paths supplied. An empty
+// slice of paths does not limit anything.
+func WithPathFilter(ctx context.Context, paths []string) context.Context {
+	if len(paths) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, PathFilterKey, paths)
+}
+
+// ShouldBuildPath returns true if the path item of a path should be built with ctx, which is always the case unless
+// ctx carries a filter created by WithPathFilter that does not include the path.
+func ShouldBuildPath(ctx context.Context, path string) bool {
+	if ctx == nil {
+		return true
+	}
+	if paths, ok := ctx.Value(PathFilterKey).([]string); ok {
+		return slices.Contains(paths, path)
+	}
+	return true
+}
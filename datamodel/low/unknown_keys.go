@@ -0,0 +1,413 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// UnknownKey represents a key found in the source of a low-level model that is not part of the specification,
+// and is not an extension (x-) either. These are often typos, like `requierd` instead of `required`.
+type UnknownKey struct {
+	// Name is the key as it appears in the source.
+	Name string
+
+	// KeyNode and ValueNode are the nodes of the unknown key and its value.
+	KeyNode   *yaml.Node
+	ValueNode *yaml.Node
+
+	// ParentType is the type of the low-level model the key was found in, for example `v3.Operation`
+	ParentType string
+}
+
+// GetUnknownKeys returns every key found in the root node of a low-level model that is not recognized. A key
+// is recognized if it is an extension, starts with a `$` (like `$ref` or `$schema`), is the specification name of
+// a field on the model or a standard keyword the model does not hold, like `dependentRequired` for a schema
+// (matched exactly, so `Description` is reported when `description` is expected), or was consumed by the model
+// when it was built (the key node is referenced by a field or by a map entry held by the model).
+//
+// Only the model itself is checked, use FindUnknownKeys to check a model and everything below it.
+func GetUnknownKeys(model HasSourceNodes) []*UnknownKey {
+	if model == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(model)
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+	root := utils.NodeAlias(model.GetRootNode())
+	if root == nil || root.Kind != yaml.MappingNode {
+		return nil
+	}
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	mk := getModelKeys(rv.Type())
+	consumed := make(map[*yaml.Node]bool)
+	for _, i := range mk.fields {
+		collectKeyNodes(rv.Field(i), consumed)
+	}
+	return mk.unknown(root, consumed)
+}
+
+// FindUnknownKeys walks a low-level model and every low-level model below it, and returns all unknown keys
+// found (see GetUnknownKeys). References are only checked where they are defined, and each model is only
+// checked once, regardless of how many times it is referenced.
+//
+// Nothing is built during the walk, schemas that have not been built yet are checked against their source nodes.
+func FindUnknownKeys(model any) []*UnknownKey {
+	w := &unknownKeyWalker{
+		seen:     make(map[uintptr]bool),
+		seenRoot: make(map[*yaml.Node]bool),
+	}
+	w.walk(reflect.ValueOf(model), 0)
+	return w.unknown
+}
+
+// specKeyOverrides holds the specification key names that cannot be derived from the name of the field holding
+// them, keyed by field name, or by type and field name. An empty name means the field does not hold a key.
+var specKeyOverrides = map[string]string{
+	"Ref":                              "$ref",
+	"SchemaTypeRef":                    "$schema",
+	"Anchor":                           "$anchor",
+	"Defs":                             "$defs",
+	"OAuth2MetadataUrl":                "oauth2MetadataUrl",
+	"Document.Version":                 "openapi",
+	"SecurityRequirement.Requirements": "",
+}
+
+// standardKeys holds keys that are part of the specification, but are not modeled by the low-level model type,
+// keyed by type. Schemas hold any JSON Schema (2020-12 and earlier drafts) or OpenAPI schema keyword, modeled or not.
+var standardKeys = map[string][]string{
+	"base.Schema": {
+		// applicator and unevaluated vocabularies.
+		"prefixItems", "items", "contains", "additionalProperties", "properties", "patternProperties",
+		"dependentSchemas", "propertyNames", "if", "then", "else", "allOf", "anyOf", "oneOf", "not",
+		"unevaluatedItems", "unevaluatedProperties",
+		// validation vocabulary.
+		"type", "const", "enum", "multipleOf", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum",
+		"maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "maxContains", "minContains",
+		"maxProperties", "minProperties", "required", "dependentRequired",
+		// format, content and meta-data vocabularies.
+		"format", "contentEncoding", "contentMediaType", "contentSchema", "title", "description", "default",
+		"deprecated", "readOnly", "writeOnly", "examples",
+		// earlier drafts.
+		"definitions", "dependencies", "additionalItems",
+		// OpenAPI.
+		"discriminator", "xml", "externalDocs", "example", "nullable",
+	},
+}
+
+// modelKeys holds the keys of a low-level model type, computed once per type.
+type modelKeys struct {
+	name   string
+	keys   map[string]reflect.Type // specification key names, and the type of the field holding them.
+	folded map[string]string       // lower-cased specification key names, to the correct name.
+	extra  map[string]bool         // specification key names not held by a field, see standardKeys.
+	fields []int                   // exported fields that may hold consumed key nodes.
+}
+
+var modelKeyCache sync.Map
+
+func getModelKeys(t reflect.Type) *modelKeys {
+	if mk, ok := modelKeyCache.Load(t); ok {
+		return mk.(*modelKeys)
+	}
+	mk := &modelKeys{
+		name:   t.String(),
+		keys:   make(map[string]reflect.Type),
+		folded: make(map[string]string),
+		extra:  make(map[string]bool),
+	}
+	for _, key := range standardKeys[mk.name] {
+		mk.extra[key] = true
+		mk.folded[strings.ToLower(key)] = key
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		mk.fields = append(mk.fields, i)
+		if f.Name == "Extensions" || !isNodeReference(f.Type) {
+			continue
+		}
+		key := specKeyName(f.Name)
+		if o, ok := specKeyOverrides[f.Name]; ok {
+			key = o
+		}
+		if o, ok := specKeyOverrides[t.Name()+"."+f.Name]; ok {
+			key = o
+		}
+		if key == "" {
+			continue
+		}
+		mk.keys[key] = f.Type
+		mk.folded[strings.ToLower(key)] = key
+	}
+	actual, _ := modelKeyCache.LoadOrStore(t, mk)
+	return actual.(*modelKeys)
+}
+
+// isNodeReference returns true if t is a NodeReference or ValueReference, or a slice of them.
+func isNodeReference(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t.PkgPath() == lowPackage &&
+		(strings.HasPrefix(t.Name(), "NodeReference[") || strings.HasPrefix(t.Name(), "ValueReference["))
+}
+
+// specKeyName returns the specification name of a key held by a field, for example `operationId` for `OperationId`
+// and `url` for `URL`.
+func specKeyName(field string) string {
+	n := 0
+	for n < len(field) && field[n] >= 'A' && field[n] <= 'Z' {
+		n++
+	}
+	switch {
+	case n == len(field):
+		return strings.ToLower(field)
+	case n > 1:
+		return strings.ToLower(field[:n-1]) + field[n-1:]
+	}
+	return strings.ToLower(field[:1]) + field[1:]
+}
+
+// unknown returns the unknown keys of a mapping node, built (or to be built) as this model type.
+func (mk *modelKeys) unknown(root *yaml.Node, consumed map[*yaml.Node]bool) []*UnknownKey {
+	var unknown []*UnknownKey
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		k := root.Content[i]
+		if strings.HasPrefix(strings.ToLower(k.Value), "x-") || strings.HasPrefix(k.Value, "$") || k.Value == "<<" {
+			continue
+		}
+		if _, ok := mk.keys[k.Value]; ok || mk.extra[k.Value] {
+			continue
+		}
+		if _, ok := mk.folded[strings.ToLower(k.Value)]; !ok && consumed[k] {
+			continue
+		}
+		unknown = append(unknown, &UnknownKey{
+			Name:       k.Value,
+			KeyNode:    k,
+			ValueNode:  root.Content[i+1],
+			ParentType: mk.name,
+		})
+	}
+	return unknown
+}
+
+// collectKeyNodes adds the key node of a NodeReference (or KeyReference) and the key nodes of any map entries
+// held by it, to the consumed set.
+func collectKeyNodes(v reflect.Value, consumed map[*yaml.Node]bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return
+	}
+	if kn, ok := v.Interface().(HasKeyNode); ok {
+		if n := kn.GetKeyNode(); n != nil {
+			consumed[n] = true
+		}
+	}
+	if v.Kind() == reflect.Struct {
+		// NodeReference values may hold a map of KeyReferences.
+		if fv := v.FieldByName("Value"); fv.IsValid() {
+			collectMapKeyNodes(fv, consumed)
+		}
+		return
+	}
+	collectMapKeyNodes(v, consumed)
+}
+
+func collectMapKeyNodes(v reflect.Value, consumed map[*yaml.Node]bool) {
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return
+	}
+	m := v.MethodByName("KeysFromOldest")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 || m.Type().Out(0).Kind() != reflect.Func {
+		return
+	}
+	for k := range m.Call(nil)[0].Seq() {
+		if !k.CanInterface() {
+			continue
+		}
+		if kn, ok := k.Interface().(HasKeyNode); ok {
+			if n := kn.GetKeyNode(); n != nil {
+				consumed[n] = true
+			}
+		}
+	}
+}
+
+const lowPackage = "github.com/pb33f/libopenapi/datamodel/low"
+
+// isReference returns true if v is a model (or NodeReference) that holds a reference. Models that were not built
+// (so have no Reference set) are not references.
+func isReference(v reflect.Value) bool {
+	s := v
+	if s.Kind() == reflect.Pointer {
+		s = s.Elem()
+	}
+	if f := s.FieldByName("Reference"); f.IsValid() && f.Kind() == reflect.Pointer && f.IsNil() {
+		return false
+	}
+	r, ok := v.Interface().(IsReferenced)
+	return ok && r.IsReference()
+}
+
+type unknownKeyWalker struct {
+	unknown  []*UnknownKey
+	seen     map[uintptr]bool
+	seenRoot map[*yaml.Node]bool
+}
+
+func (w *unknownKeyWalker) walk(v reflect.Value, depth int) {
+	if !v.IsValid() || depth > 500 {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			w.walk(v.Elem(), depth+1)
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		if m := v.MethodByName("ValuesFromOldest"); m.IsValid() && m.Type().NumIn() == 0 &&
+			m.Type().NumOut() == 1 && m.Type().Out(0).Kind() == reflect.Func {
+			for val := range m.Call(nil)[0].Seq() {
+				w.walk(val, depth+1)
+			}
+			return
+		}
+		if v.Elem().Kind() != reflect.Struct || !strings.HasPrefix(v.Elem().Type().PkgPath(), lowPackage) {
+			return
+		}
+		if w.seen[v.Pointer()] {
+			return
+		}
+		w.seen[v.Pointer()] = true
+		if isReference(v) {
+			// references are checked where they are defined.
+			return
+		}
+		if m := v.MethodByName("Schema"); m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 &&
+			m.Type().Out(0).Kind() == reflect.Pointer {
+			// a schema proxy, only walk the schema it is proxying if it has been built already, otherwise check
+			// the source nodes it will be built from.
+			if b, ok := v.Interface().(interface{ IsBuilt() bool }); ok && b.IsBuilt() {
+				w.walk(m.Call(nil)[0], depth+1)
+			} else if sn, ok := v.Interface().(HasSourceNodes); ok {
+				w.raw(sn.GetValueNode(), m.Type().Out(0).Elem(), depth+1)
+			}
+			return
+		}
+		if sn, ok := v.Interface().(HasSourceNodes); ok {
+			if root := sn.GetRootNode(); root != nil && !w.seenRoot[root] {
+				w.seenRoot[root] = true
+				w.unknown = append(w.unknown, GetUnknownKeys(sn)...)
+			}
+		}
+		w.walk(v.Elem(), depth+1)
+	case reflect.Struct:
+		if !strings.HasPrefix(v.Type().PkgPath(), lowPackage) {
+			return
+		}
+		if isReference(v) {
+			// a NodeReference or ValueReference holding a resolved reference, checked where it is defined.
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() || f.Anonymous {
+				continue
+			}
+			w.walk(v.Field(i), depth+1)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			w.walk(v.Index(i), depth+1)
+		}
+	}
+}
+
+// raw checks a mapping node that has not been built into a model of type t (yet), and everything below it.
+func (w *unknownKeyWalker) raw(node *yaml.Node, t reflect.Type, depth int) {
+	node = utils.NodeAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode || depth > 500 || w.seenRoot[node] {
+		return
+	}
+	w.seenRoot[node] = true
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "$ref" {
+			// references are checked where they are defined.
+			return
+		}
+	}
+	mk := getModelKeys(t)
+	w.unknown = append(w.unknown, mk.unknown(node, nil)...)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if vt, ok := mk.keys[node.Content[i].Value]; ok {
+			w.rawValue(node.Content[i+1], vt, depth+1)
+		}
+	}
+}
+
+// rawValue checks a node that has not been built into a value of type t (yet), by the shape of t.
+func (w *unknownKeyWalker) rawValue(node *yaml.Node, t reflect.Type, depth int) {
+	node = utils.NodeAlias(node)
+	if node == nil || depth > 500 {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		if m, ok := t.MethodByName("Schema"); ok && m.Type.NumIn() == 1 && m.Type.NumOut() == 1 &&
+			m.Type.Out(0).Kind() == reflect.Pointer {
+			// a schema proxy.
+			w.raw(node, m.Type.Out(0).Elem(), depth)
+			return
+		}
+		if m, ok := t.MethodByName("GetOrZero"); ok && m.Type.NumOut() == 1 {
+			// an ordered map, check the values.
+			if node.Kind == yaml.MappingNode {
+				for i := 1; i < len(node.Content); i += 2 {
+					w.rawValue(node.Content[i], m.Type.Out(0), depth+1)
+				}
+			}
+			return
+		}
+		w.rawValue(node, t.Elem(), depth)
+	case reflect.Slice:
+		if node.Kind == yaml.SequenceNode {
+			for _, n := range node.Content {
+				w.rawValue(n, t.Elem(), depth+1)
+			}
+		}
+	case reflect.Struct:
+		if !strings.HasPrefix(t.PkgPath(), lowPackage) {
+			return
+		}
+		switch {
+		case strings.HasPrefix(t.Name(), "NodeReference["), strings.HasPrefix(t.Name(), "ValueReference["):
+			vf, _ := t.FieldByName("Value")
+			w.rawValue(node, vf.Type, depth)
+		case strings.HasPrefix(t.Name(), "SchemaDynamicValue["):
+			a, _ := t.FieldByName("A")
+			w.rawValue(node, a.Type, depth)
+		case reflect.PointerTo(t).Implements(hasSourceNodesType):
+			w.raw(node, t, depth)
+		}
+	}
+}
+
+var hasSourceNodesType = reflect.TypeOf((*HasSourceNodes)(nil)).Elem()
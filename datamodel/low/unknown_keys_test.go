@@ -0,0 +1,153 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type unknownKeyChild struct {
+	Name     NodeReference[string]
+	RootNode *yaml.Node
+}
+
+func (u *unknownKeyChild) GetKeyNode() *yaml.Node   { return nil }
+func (u *unknownKeyChild) GetRootNode() *yaml.Node  { return u.RootNode }
+func (u *unknownKeyChild) GetValueNode() *yaml.Node { return u.RootNode }
+
+type unknownKeyParent struct {
+	Version  NodeReference[string]
+	Child    NodeReference[*unknownKeyChild]
+	Things   NodeReference[*orderedmap.Map[KeyReference[string], ValueReference[string]]]
+	RootNode *yaml.Node
+}
+
+func (u *unknownKeyParent) GetKeyNode() *yaml.Node   { return nil }
+func (u *unknownKeyParent) GetRootNode() *yaml.Node  { return u.RootNode }
+func (u *unknownKeyParent) GetValueNode() *yaml.Node { return u.RootNode }
+
+func TestGetUnknownKeys(t *testing.T) {
+	yml := `openapi: 3.1
+child:
+  name: pizza
+  nmae: typo
+thing: one
+chicken: nuggets
+x-cake: yes
+$schema: something`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+	m := root.Content[0]
+
+	things := orderedmap.New[KeyReference[string], ValueReference[string]]()
+	things.Set(KeyReference[string]{Value: "thing", KeyNode: m.Content[4]}, ValueReference[string]{Value: "one"})
+
+	child := &unknownKeyChild{RootNode: m.Content[3]}
+	_ = BuildModel(m.Content[3], child)
+
+	parent := &unknownKeyParent{
+		Version:  NodeReference[string]{Value: "3.1", KeyNode: m.Content[0], ValueNode: m.Content[1]},
+		Child:    NodeReference[*unknownKeyChild]{Value: child, KeyNode: m.Content[2], ValueNode: m.Content[3]},
+		Things:   NodeReference[*orderedmap.Map[KeyReference[string], ValueReference[string]]]{Value: things},
+		RootNode: m,
+	}
+
+	unknown := GetUnknownKeys(parent)
+	assert.Len(t, unknown, 1)
+	assert.Equal(t, "chicken", unknown[0].Name)
+	assert.Equal(t, 6, unknown[0].KeyNode.Line)
+	assert.Equal(t, "nuggets", unknown[0].ValueNode.Value)
+	assert.Equal(t, "low.unknownKeyParent", unknown[0].ParentType)
+
+	all := FindUnknownKeys(parent)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "chicken", all[0].Name)
+	assert.Equal(t, "nmae", all[1].Name)
+	assert.Equal(t, "low.unknownKeyChild", all[1].ParentType)
+
+	var empty *unknownKeyParent
+	assert.Nil(t, GetUnknownKeys(empty))
+	assert.Nil(t, GetUnknownKeys(nil))
+	assert.Nil(t, FindUnknownKeys(nil))
+}
+
+type unknownKeyModel struct {
+	OperationId NodeReference[string]
+	URL         NodeReference[string]
+	Tags        []NodeReference[string]
+	Extensions  *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]]
+	Index       *yaml.Node
+	RootNode    *yaml.Node
+	NodeMap
+}
+
+func (u *unknownKeyModel) GetKeyNode() *yaml.Node   { return nil }
+func (u *unknownKeyModel) GetRootNode() *yaml.Node  { return u.RootNode }
+func (u *unknownKeyModel) GetValueNode() *yaml.Node { return u.RootNode }
+
+func TestGetUnknownKeys_SpecNames(t *testing.T) {
+	yml := `operationId: pizza
+url: https://pb33f.io
+tags: [a, b]
+extensions: nope
+index: nope
+nodes: nope
+rootNode: nope
+OperationID: nope`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+
+	model := &unknownKeyModel{RootNode: root.Content[0]}
+	_ = BuildModel(root.Content[0], model)
+
+	var names []string
+	for _, k := range GetUnknownKeys(model) {
+		names = append(names, k.Name)
+	}
+	assert.Equal(t, []string{"extensions", "index", "nodes", "rootNode", "OperationID"}, names)
+}
+
+func TestSpecKeyName(t *testing.T) {
+	assert.Equal(t, "operationId", specKeyName("OperationId"))
+	assert.Equal(t, "url", specKeyName("URL"))
+	assert.Equal(t, "xml", specKeyName("XML"))
+	assert.Equal(t, "in", specKeyName("In"))
+}
+
+func TestGetModelKeys_Cached(t *testing.T) {
+	mk := getModelKeys(reflect.TypeOf(unknownKeyModel{}))
+	assert.Same(t, mk, getModelKeys(reflect.TypeOf(unknownKeyModel{})))
+	assert.Len(t, mk.keys, 3)
+	assert.Contains(t, mk.keys, "operationId")
+	assert.Contains(t, mk.keys, "url")
+	assert.Contains(t, mk.keys, "tags")
+}
+
+type unknownKeyReferenced struct {
+	*Reference
+	unknownKeyChild
+}
+
+func TestFindUnknownKeys_NotBuilt(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte("nmae: typo"), &root)
+
+	// a model that was never built has no Reference, it is not a reference and is still checked.
+	m := &unknownKeyReferenced{unknownKeyChild: unknownKeyChild{RootNode: root.Content[0]}}
+	assert.False(t, isReference(reflect.ValueOf(m)))
+	unknown := FindUnknownKeys(m)
+	assert.Len(t, unknown, 1)
+
+	m.Reference = new(Reference)
+	m.SetReference("#/components/schemas/Pizza", nil)
+	assert.True(t, isReference(reflect.ValueOf(m)))
+	assert.Empty(t, FindUnknownKeys(m))
+}
@@ -25,6 +25,8 @@ import (
 //   - https://swagger.io/specification/v2/#parametersDefinitionsObject
 type ParameterDefinitions struct {
 	Definitions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*Parameter]]
+	KeyNode     *yaml.Node
+	RootNode    *yaml.Node
 }
 
 // ResponsesDefinitions is a low-level representation of a Swagger / OpenAPI 2 Responses Definitions object.
@@ -34,6 +36,8 @@ type ParameterDefinitions struct {
 //   - https://swagger.io/specification/v2/#responsesDefinitionsObject
 type ResponsesDefinitions struct {
 	Definitions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*Response]]
+	KeyNode     *yaml.Node
+	RootNode    *yaml.Node
 }
 
 // SecurityDefinitions is a low-level representation of a Swagger / OpenAPI 2 Security Definitions object.
@@ -43,6 +47,8 @@ type ResponsesDefinitions struct {
 //   - https://swagger.io/specification/v2/#securityDefinitionsObject
 type SecurityDefinitions struct {
 	Definitions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*SecurityScheme]]
+	KeyNode     *yaml.Node
+	RootNode    *yaml.Node
 }
 
 // Definitions is a low-level representation of a Swagger / OpenAPI 2 Definitions object
@@ -51,7 +57,9 @@ type SecurityDefinitions struct {
 // arrays or models.
 //   - https://swagger.io/specification/v2/#definitionsObject
 type Definitions struct {
-	Schemas *orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.SchemaProxy]]
+	Schemas  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.SchemaProxy]]
+	KeyNode  *yaml.Node
+	RootNode *yaml.Node
 }
 
 // FindSchema will attempt to locate a base.SchemaProxy instance using a name.
@@ -74,9 +82,26 @@ func (s *SecurityDefinitions) FindSecurityDefinition(securityDef string) *low.Va
 	return low.FindItemInOrderedMap[*SecurityScheme](securityDef, s.Definitions)
 }
 
+// GetKeyNode returns the key yaml node of the Definitions object.
+func (d *Definitions) GetKeyNode() *yaml.Node {
+	return d.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the Definitions object.
+func (d *Definitions) GetRootNode() *yaml.Node {
+	return d.RootNode
+}
+
+// GetValueNode returns the value yaml node of the Definitions object, which is the same as the root node.
+func (d *Definitions) GetValueNode() *yaml.Node {
+	return d.RootNode
+}
+
 // Build will extract all definitions into SchemaProxy instances.
-func (d *Definitions) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (d *Definitions) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	d.KeyNode = keyNode
+	d.RootNode = root
 	utils.CheckForMergeNodes(root)
 	type buildInput struct {
 		label *yaml.Node
@@ -164,8 +189,25 @@ func (d *Definitions) Hash() [32]byte {
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
 
+// GetKeyNode returns the key yaml node of the ParameterDefinitions object.
+func (pd *ParameterDefinitions) GetKeyNode() *yaml.Node {
+	return pd.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the ParameterDefinitions object.
+func (pd *ParameterDefinitions) GetRootNode() *yaml.Node {
+	return pd.RootNode
+}
+
+// GetValueNode returns the value yaml node of the ParameterDefinitions object, which is the same as the root node.
+func (pd *ParameterDefinitions) GetValueNode() *yaml.Node {
+	return pd.RootNode
+}
+
 // Build will extract all ParameterDefinitions into Parameter instances.
-func (pd *ParameterDefinitions) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (pd *ParameterDefinitions) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	pd.KeyNode = keyNode
+	pd.RootNode = root
 	errorChan := make(chan error)
 	resultChan := make(chan definitionResult[*Parameter])
 	var defLabel *yaml.Node
@@ -220,8 +262,25 @@ type definitionResult[T any] struct {
 	v low.ValueReference[T]
 }
 
+// GetKeyNode returns the key yaml node of the ResponsesDefinitions object.
+func (r *ResponsesDefinitions) GetKeyNode() *yaml.Node {
+	return r.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the ResponsesDefinitions object.
+func (r *ResponsesDefinitions) GetRootNode() *yaml.Node {
+	return r.RootNode
+}
+
+// GetValueNode returns the value yaml node of the ResponsesDefinitions object, which is the same as the root node.
+func (r *ResponsesDefinitions) GetValueNode() *yaml.Node {
+	return r.RootNode
+}
+
 // Build will extract all ResponsesDefinitions into Response instances.
-func (r *ResponsesDefinitions) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (r *ResponsesDefinitions) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	r.KeyNode = keyNode
+	r.RootNode = root
 	errorChan := make(chan error)
 	resultChan := make(chan definitionResult[*Response])
 	var defLabel *yaml.Node
@@ -270,8 +329,25 @@ func (r *ResponsesDefinitions) Build(ctx context.Context, _, root *yaml.Node, id
 	return nil
 }
 
+// GetKeyNode returns the key yaml node of the SecurityDefinitions object.
+func (s *SecurityDefinitions) GetKeyNode() *yaml.Node {
+	return s.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the SecurityDefinitions object.
+func (s *SecurityDefinitions) GetRootNode() *yaml.Node {
+	return s.RootNode
+}
+
+// GetValueNode returns the value yaml node of the SecurityDefinitions object, which is the same as the root node.
+func (s *SecurityDefinitions) GetValueNode() *yaml.Node {
+	return s.RootNode
+}
+
 // Build will extract all SecurityDefinitions into SecurityScheme instances.
-func (s *SecurityDefinitions) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (s *SecurityDefinitions) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	s.KeyNode = keyNode
+	s.RootNode = root
 	errorChan := make(chan error)
 	resultChan := make(chan definitionResult[*SecurityScheme])
 	var defLabel *yaml.Node
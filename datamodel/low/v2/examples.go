@@ -19,7 +19,9 @@ import (
 // Allows sharing examples for operation responses
 //   - https://swagger.io/specification/v2/#exampleObject
 type Examples struct {
-	Values *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	Values   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode  *yaml.Node
+	RootNode *yaml.Node
 }
 
 // FindExample attempts to locate an example value, using a key label.
@@ -27,9 +29,26 @@ func (e *Examples) FindExample(name string) *low.ValueReference[*yaml.Node] {
 	return low.FindItemInOrderedMap(name, e.Values)
 }
 
+// GetKeyNode returns the key yaml node of the Examples object.
+func (e *Examples) GetKeyNode() *yaml.Node {
+	return e.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the Examples object.
+func (e *Examples) GetRootNode() *yaml.Node {
+	return e.RootNode
+}
+
+// GetValueNode returns the value yaml node of the Examples object, which is the same as the root node.
+func (e *Examples) GetValueNode() *yaml.Node {
+	return e.RootNode
+}
+
 // Build will extract all examples and will attempt to unmarshal content into a map or slice based on type.
-func (e *Examples) Build(_ context.Context, _, root *yaml.Node, _ *index.SpecIndex) error {
+func (e *Examples) Build(_ context.Context, key, root *yaml.Node, _ *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	e.KeyNode = key
+	e.RootNode = root
 	utils.CheckForMergeNodes(root)
 	var keyNode, currNode *yaml.Node
 	e.Values = orderedmap.New[low.KeyReference[string], low.ValueReference[*yaml.Node]]()
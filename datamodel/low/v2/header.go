@@ -41,6 +41,8 @@ type Header struct {
 	Enum             low.NodeReference[[]low.ValueReference[*yaml.Node]]
 	MultipleOf       low.NodeReference[int]
 	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode          *yaml.Node
+	RootNode         *yaml.Node
 }
 
 // FindExtension will attempt to locate an extension value using a name lookup.
@@ -53,9 +55,26 @@ func (h *Header) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.V
 	return h.Extensions
 }
 
+// GetKeyNode returns the key yaml node of the Header object.
+func (h *Header) GetKeyNode() *yaml.Node {
+	return h.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the Header object.
+func (h *Header) GetRootNode() *yaml.Node {
+	return h.RootNode
+}
+
+// GetValueNode returns the value yaml node of the Header object, which is the same as the root node.
+func (h *Header) GetValueNode() *yaml.Node {
+	return h.RootNode
+}
+
 // Build will build out items, extensions and default value from the supplied node.
-func (h *Header) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (h *Header) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	h.KeyNode = keyNode
+	h.RootNode = root
 	utils.CheckForMergeNodes(root)
 	h.Extensions = low.ExtractExtensions(root)
 	items, err := low.ExtractObject[*Items](ctx, ItemsLabel, root, idx)
@@ -64,7 +83,7 @@ func (h *Header) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecI
 	}
 	h.Items = items
 
-	_, ln, vn := utils.FindKeyNodeFull(DefaultLabel, root.Content)
+	_, ln, vn := utils.FindKeyNodeFullTop(DefaultLabel, root.Content)
 	if vn != nil {
 		h.Default = low.NodeReference[*yaml.Node]{
 			Value:     vn,
@@ -41,6 +41,8 @@ type Items struct {
 	Enum             low.NodeReference[[]low.ValueReference[*yaml.Node]]
 	MultipleOf       low.NodeReference[int]
 	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode          *yaml.Node
+	RootNode         *yaml.Node
 }
 
 // FindExtension will attempt to locate an extension value using a name lookup.
@@ -68,16 +70,36 @@ func (i *Items) Hash() [32]byte {
 	if i.Default.Value != nil && !i.Default.Value.IsZero() {
 		f = append(f, low.GenerateHashString(i.Default.Value))
 	}
-	f = append(f, fmt.Sprint(i.Maximum.Value))
-	f = append(f, fmt.Sprint(i.Minimum.Value))
-	f = append(f, fmt.Sprint(i.ExclusiveMinimum.Value))
-	f = append(f, fmt.Sprint(i.ExclusiveMaximum.Value))
-	f = append(f, fmt.Sprint(i.MinLength.Value))
-	f = append(f, fmt.Sprint(i.MaxLength.Value))
-	f = append(f, fmt.Sprint(i.MinItems.Value))
-	f = append(f, fmt.Sprint(i.MaxItems.Value))
-	f = append(f, fmt.Sprint(i.MultipleOf.Value))
-	f = append(f, fmt.Sprint(i.UniqueItems.Value))
+	if !i.Maximum.IsEmpty() {
+		f = append(f, fmt.Sprintf("maximum:%v", i.Maximum.Value))
+	}
+	if !i.Minimum.IsEmpty() {
+		f = append(f, fmt.Sprintf("minimum:%v", i.Minimum.Value))
+	}
+	if !i.ExclusiveMinimum.IsEmpty() {
+		f = append(f, fmt.Sprintf("exclusiveMinimum:%v", i.ExclusiveMinimum.Value))
+	}
+	if !i.ExclusiveMaximum.IsEmpty() {
+		f = append(f, fmt.Sprintf("exclusiveMaximum:%v", i.ExclusiveMaximum.Value))
+	}
+	if !i.MinLength.IsEmpty() {
+		f = append(f, fmt.Sprintf("minLength:%v", i.MinLength.Value))
+	}
+	if !i.MaxLength.IsEmpty() {
+		f = append(f, fmt.Sprintf("maxLength:%v", i.MaxLength.Value))
+	}
+	if !i.MinItems.IsEmpty() {
+		f = append(f, fmt.Sprintf("minItems:%v", i.MinItems.Value))
+	}
+	if !i.MaxItems.IsEmpty() {
+		f = append(f, fmt.Sprintf("maxItems:%v", i.MaxItems.Value))
+	}
+	if !i.MultipleOf.IsEmpty() {
+		f = append(f, fmt.Sprintf("multipleOf:%v", i.MultipleOf.Value))
+	}
+	if !i.UniqueItems.IsEmpty() {
+		f = append(f, fmt.Sprintf("uniqueItems:%v", i.UniqueItems.Value))
+	}
 	if i.Pattern.Value != "" {
 		f = append(f, fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(i.Pattern.Value)))))
 	}
@@ -97,9 +119,26 @@ func (i *Items) Hash() [32]byte {
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
 
+// GetKeyNode returns the key yaml node of the Items object.
+func (i *Items) GetKeyNode() *yaml.Node {
+	return i.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the Items object.
+func (i *Items) GetRootNode() *yaml.Node {
+	return i.RootNode
+}
+
+// GetValueNode returns the value yaml node of the Items object, which is the same as the root node.
+func (i *Items) GetValueNode() *yaml.Node {
+	return i.RootNode
+}
+
 // Build will build out items and default value.
-func (i *Items) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (i *Items) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	i.KeyNode = keyNode
+	i.RootNode = root
 	utils.CheckForMergeNodes(root)
 	i.Extensions = low.ExtractExtensions(root)
 	items, iErr := low.ExtractObject[*Items](ctx, ItemsLabel, root, idx)
@@ -108,7 +147,7 @@ func (i *Items) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIn
 	}
 	i.Items = items
 
-	_, ln, vn := utils.FindKeyNodeFull(DefaultLabel, root.Content)
+	_, ln, vn := utils.FindKeyNodeFullTop(DefaultLabel, root.Content)
 	if vn != nil {
 		i.Default = low.NodeReference[*yaml.Node]{
 			Value:     vn,
@@ -168,3 +168,23 @@ func TestItems_GetDescription(t *testing.T) {
 	i := Items{}
 	assert.Nil(t, i.GetDescription())
 }
+
+func TestItems_Hash_ZeroVsAbsent(t *testing.T) {
+	build := func(yml string) *Items {
+		var idxNode yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &idxNode)
+		idx := index.NewSpecIndex(&idxNode)
+
+		var n Items
+		_ = low.BuildModel(idxNode.Content[0], &n)
+		_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+		return &n
+	}
+
+	absent := build(`type: integer`)
+	assert.NotEqual(t, absent.Hash(), build("type: integer\nmaximum: 0").Hash())
+	assert.NotEqual(t, absent.Hash(), build("type: integer\nexclusiveMaximum: false").Hash())
+	assert.NotEqual(t, absent.Hash(), build("type: integer\nmultipleOf: 0").Hash())
+	assert.NotEqual(t, build("type: integer\nmaximum: 0").Hash(), build("type: integer\nminimum: 0").Hash())
+	assert.NotEqual(t, build("type: integer\ndefault: 1").Hash(), build("type: integer\ndefault: \"1\"").Hash())
+}
@@ -36,11 +36,32 @@ type Operation struct {
 	Deprecated   low.NodeReference[bool]
 	Security     low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]]
 	Extensions   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode      *yaml.Node
+	RootNode     *yaml.Node
+	hashCache    low.HashCache
+}
+
+// GetKeyNode returns the key yaml node of the Operation object.
+func (o *Operation) GetKeyNode() *yaml.Node {
+	return o.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the Operation object.
+func (o *Operation) GetRootNode() *yaml.Node {
+	return o.RootNode
+}
+
+// GetValueNode returns the value yaml node of the Operation object, which is the same as the root node.
+func (o *Operation) GetValueNode() *yaml.Node {
+	return o.RootNode
 }
 
 // Build will extract external docs, extensions, parameters, responses and security requirements.
-func (o *Operation) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (o *Operation) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	o.KeyNode = keyNode
+	o.RootNode = root
+	o.hashCache.SetScope(low.GetHashScope(ctx))
 	utils.CheckForMergeNodes(root)
 	o.Extensions = low.ExtractExtensions(root)
 
@@ -88,6 +109,11 @@ func (o *Operation) Build(ctx context.Context, _, root *yaml.Node, idx *index.Sp
 
 // Hash will return a consistent SHA256 Hash of the Operation object
 func (o *Operation) Hash() [32]byte {
+	return o.hashCache.GetOrCompute(o.hash)
+}
+
+// hash calculates the hash of the Operation, without using the cache.
+func (o *Operation) hash() [32]byte {
 	var f []string
 	if !o.Summary.IsEmpty() {
 		f = append(f, o.Summary.Value)
@@ -180,6 +206,13 @@ func (o *Operation) GetOperationId() low.NodeReference[string] {
 func (o *Operation) GetDeprecated() low.NodeReference[bool] {
 	return o.Deprecated
 }
+
+// FindExtension attempts to locate an extension with the supplied key
+func (o *Operation) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, o.Extensions)
+}
+
+// GetExtensions returns all Operation extensions and satisfies the low.HasExtensions interface.
 func (o *Operation) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return o.Extensions
 }
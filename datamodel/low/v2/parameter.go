@@ -84,6 +84,9 @@ type Parameter struct {
 	Enum             low.NodeReference[[]low.ValueReference[*yaml.Node]]
 	MultipleOf       low.NodeReference[int]
 	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode          *yaml.Node
+	RootNode         *yaml.Node
+	hashCache        low.HashCache
 }
 
 // FindExtension attempts to locate a extension value given a name.
@@ -96,9 +99,27 @@ func (p *Parameter) GetExtensions() *orderedmap.Map[low.KeyReference[string], lo
 	return p.Extensions
 }
 
+// GetKeyNode returns the key yaml node of the Parameter object.
+func (p *Parameter) GetKeyNode() *yaml.Node {
+	return p.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the Parameter object.
+func (p *Parameter) GetRootNode() *yaml.Node {
+	return p.RootNode
+}
+
+// GetValueNode returns the value yaml node of the Parameter object, which is the same as the root node.
+func (p *Parameter) GetValueNode() *yaml.Node {
+	return p.RootNode
+}
+
 // Build will extract out extensions, schema, items and default value
-func (p *Parameter) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (p *Parameter) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	p.KeyNode = keyNode
+	p.RootNode = root
+	p.hashCache.SetScope(low.GetHashScope(ctx))
 	utils.CheckForMergeNodes(root)
 	p.Extensions = low.ExtractExtensions(root)
 	sch, sErr := base.ExtractSchema(ctx, root, idx)
@@ -108,13 +129,16 @@ func (p *Parameter) Build(ctx context.Context, _, root *yaml.Node, idx *index.Sp
 	if sch != nil {
 		p.Schema = *sch
 	}
-	items, iErr := low.ExtractObject[*Items](ctx, ItemsLabel, root, idx)
-	if iErr != nil {
-		return iErr
+	// only extract items defined directly on the parameter, not those belonging to a body parameter's schema.
+	if _, iv := utils.FindKeyNodeTop(ItemsLabel, root.Content); iv != nil {
+		items, iErr := low.ExtractObject[*Items](ctx, ItemsLabel, root, idx)
+		if iErr != nil {
+			return iErr
+		}
+		p.Items = items
 	}
-	p.Items = items
 
-	_, ln, vn := utils.FindKeyNodeFull(DefaultLabel, root.Content)
+	_, ln, vn := utils.FindKeyNodeFullTop(DefaultLabel, root.Content)
 	if vn != nil {
 		p.Default = low.NodeReference[*yaml.Node]{
 			Value:     vn,
@@ -128,6 +152,11 @@ func (p *Parameter) Build(ctx context.Context, _, root *yaml.Node, idx *index.Sp
 
 // Hash will return a consistent SHA256 Hash of the Parameter object
 func (p *Parameter) Hash() [32]byte {
+	return p.hashCache.GetOrCompute(p.hash)
+}
+
+// hash calculates the hash of the Parameter, without using the cache.
+func (p *Parameter) hash() [32]byte {
 	var f []string
 	if p.Name.Value != "" {
 		f = append(f, p.Name.Value)
@@ -218,3 +218,26 @@ allowEmptyValue: true
 	assert.True(t, n.GetAllowEmptyValue().Value)
 	assert.Equal(t, 1, orderedmap.Len(n.GetExtensions()))
 }
+
+func TestParameter_Build_IgnoresNestedItemsAndDefault(t *testing.T) {
+	yml := `in: body
+name: body
+schema:
+  type: array
+  default: []
+  items:
+    type: string
+    default: pizza`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Parameter
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	err := n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.True(t, n.Items.IsEmpty())
+	assert.True(t, n.Default.IsEmpty())
+	assert.False(t, n.Schema.IsEmpty())
+}
@@ -36,6 +36,9 @@ type PathItem struct {
 	Patch      low.NodeReference[*Operation]
 	Parameters low.NodeReference[[]low.ValueReference[*Parameter]]
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode    *yaml.Node
+	RootNode   *yaml.Node
+	hashCache  low.HashCache
 }
 
 // FindExtension will attempt to locate an extension given a name.
@@ -48,10 +51,28 @@ func (p *PathItem) GetExtensions() *orderedmap.Map[low.KeyReference[string], low
 	return p.Extensions
 }
 
+// GetKeyNode returns the key yaml node of the PathItem object.
+func (p *PathItem) GetKeyNode() *yaml.Node {
+	return p.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the PathItem object.
+func (p *PathItem) GetRootNode() *yaml.Node {
+	return p.RootNode
+}
+
+// GetValueNode returns the value yaml node of the PathItem object, which is the same as the root node.
+func (p *PathItem) GetValueNode() *yaml.Node {
+	return p.RootNode
+}
+
 // Build will extract extensions, parameters and operations for all methods. Every method is handled
 // asynchronously, in order to keep things moving quickly for complex operations.
-func (p *PathItem) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	p.KeyNode = keyNode
+	p.RootNode = root
+	p.hashCache.SetScope(low.GetHashScope(ctx))
 	utils.CheckForMergeNodes(root)
 	p.Extensions = low.ExtractExtensions(root)
 	skip := false
@@ -189,6 +210,11 @@ func (p *PathItem) Build(ctx context.Context, _, root *yaml.Node, idx *index.Spe
 
 // Hash will return a consistent SHA256 Hash of the PathItem object
 func (p *PathItem) Hash() [32]byte {
+	return p.hashCache.GetOrCompute(p.hash)
+}
+
+// hash calculates the hash of the PathItem, without using the cache.
+func (p *PathItem) hash() [32]byte {
 	var f []string
 	if !p.Get.IsEmpty() {
 		f = append(f, fmt.Sprintf("%s-%s", GetLabel, low.GenerateHashString(p.Get.Value)))
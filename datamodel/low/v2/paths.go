@@ -21,6 +21,9 @@ import (
 type Paths struct {
 	PathItems  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*PathItem]]
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode    *yaml.Node
+	RootNode   *yaml.Node
+	hashCache  low.HashCache
 }
 
 // GetExtensions returns all Paths extensions and satisfies the low.HasExtensions interface.
@@ -56,9 +59,27 @@ func (p *Paths) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
 	return low.FindItemInOrderedMap(ext, p.Extensions)
 }
 
+// GetKeyNode returns the key yaml node of the Paths object.
+func (p *Paths) GetKeyNode() *yaml.Node {
+	return p.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the Paths object.
+func (p *Paths) GetRootNode() *yaml.Node {
+	return p.RootNode
+}
+
+// GetValueNode returns the value yaml node of the Paths object, which is the same as the root node.
+func (p *Paths) GetValueNode() *yaml.Node {
+	return p.RootNode
+}
+
 // Build will extract extensions and paths from node.
-func (p *Paths) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (p *Paths) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	p.KeyNode = keyNode
+	p.RootNode = root
+	p.hashCache.SetScope(low.GetHashScope(ctx))
 	utils.CheckForMergeNodes(root)
 	p.Extensions = low.ExtractExtensions(root)
 
@@ -99,6 +120,9 @@ func (p *Paths) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIn
 				currentNode = pathNode
 				continue
 			}
+			if !low.ShouldBuildPath(ctx, currentNode.Value) {
+				continue
+			}
 
 			select {
 			case in <- buildInput{
@@ -154,8 +178,13 @@ func (p *Paths) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIn
 	return nil
 }
 
-// Hash will return a consistent SHA256 Hash of the PathItem object
+// Hash will return a consistent SHA256 Hash of the Paths object
 func (p *Paths) Hash() [32]byte {
+	return p.hashCache.GetOrCompute(p.hash)
+}
+
+// hash calculates the hash of the Paths, without using the cache.
+func (p *Paths) hash() [32]byte {
 	var f []string
 	for v := range orderedmap.SortAlpha(p.PathItems).ValuesFromOldest() {
 		f = append(f, low.GenerateHashString(v.Value))
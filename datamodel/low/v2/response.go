@@ -26,6 +26,9 @@ type Response struct {
 	Headers     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Header]]]
 	Examples    low.NodeReference[*Examples]
 	Extensions  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode     *yaml.Node
+	RootNode    *yaml.Node
+	hashCache   low.HashCache
 }
 
 // FindExtension will attempt to locate an extension value given a key to lookup.
@@ -43,9 +46,27 @@ func (r *Response) FindHeader(hType string) *low.ValueReference[*Header] {
 	return low.FindItemInOrderedMap[*Header](hType, r.Headers.Value)
 }
 
+// GetKeyNode returns the key yaml node of the Response object.
+func (r *Response) GetKeyNode() *yaml.Node {
+	return r.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the Response object.
+func (r *Response) GetRootNode() *yaml.Node {
+	return r.RootNode
+}
+
+// GetValueNode returns the value yaml node of the Response object, which is the same as the root node.
+func (r *Response) GetValueNode() *yaml.Node {
+	return r.RootNode
+}
+
 // Build will extract schema, extensions, examples and headers from node
-func (r *Response) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (r *Response) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	r.KeyNode = keyNode
+	r.RootNode = root
+	r.hashCache.SetScope(low.GetHashScope(ctx))
 	utils.CheckForMergeNodes(root)
 	r.Extensions = low.ExtractExtensions(root)
 	s, err := base.ExtractSchema(ctx, root, idx)
@@ -80,6 +101,11 @@ func (r *Response) Build(ctx context.Context, _, root *yaml.Node, idx *index.Spe
 
 // Hash will return a consistent SHA256 Hash of the Response object
 func (r *Response) Hash() [32]byte {
+	return r.hashCache.GetOrCompute(r.hash)
+}
+
+// hash calculates the hash of the Response, without using the cache.
+func (r *Response) hash() [32]byte {
 	var f []string
 	if r.Description.Value != "" {
 		f = append(f, r.Description.Value)
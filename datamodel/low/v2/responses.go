@@ -6,6 +6,7 @@ package v2
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -21,6 +22,14 @@ type Responses struct {
 	Codes      *orderedmap.Map[low.KeyReference[string], low.ValueReference[*Response]]
 	Default    low.NodeReference[*Response]
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode    *yaml.Node
+	RootNode   *yaml.Node
+	hashCache  low.HashCache
+}
+
+// FindExtension attempts to locate an extension with the supplied key
+func (r *Responses) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, r.Extensions)
 }
 
 // GetExtensions returns all Responses extensions and satisfies the low.HasExtensions interface.
@@ -28,9 +37,27 @@ func (r *Responses) GetExtensions() *orderedmap.Map[low.KeyReference[string], lo
 	return r.Extensions
 }
 
+// GetKeyNode returns the key yaml node of the Responses object.
+func (r *Responses) GetKeyNode() *yaml.Node {
+	return r.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the Responses object.
+func (r *Responses) GetRootNode() *yaml.Node {
+	return r.RootNode
+}
+
+// GetValueNode returns the value yaml node of the Responses object, which is the same as the root node.
+func (r *Responses) GetValueNode() *yaml.Node {
+	return r.RootNode
+}
+
 // Build will extract default value and extensions from node.
-func (r *Responses) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (r *Responses) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	r.KeyNode = keyNode
+	r.RootNode = root
+	r.hashCache.SetScope(low.GetHashScope(ctx))
 	utils.CheckForMergeNodes(root)
 	r.Extensions = low.ExtractExtensions(root)
 
@@ -47,7 +74,7 @@ func (r *Responses) Build(ctx context.Context, _, root *yaml.Node, idx *index.Sp
 			// default is bundled into codes, pull it out
 			r.Default = *def
 			// remove default from codes
-			r.deleteCode(DefaultLabel)
+			r.deleteCode(def.KeyNode.Value)
 		}
 	} else {
 		return fmt.Errorf("responses build failed: vn node is not a map! line %d, col %d",
@@ -91,8 +118,52 @@ func (r *Responses) FindResponseByCode(code string) *low.ValueReference[*Respons
 	return low.FindItemInOrderedMap[*Response](code, r.Codes)
 }
 
-// Hash will return a consistent SHA256 Hash of the Examples object
+// FindResponseForCode will locate the Response that describes an HTTP status code. An exact code (like 204) is
+// used first, then the default. If no response applies, nil is returned.
+func (r *Responses) FindResponseForCode(status int) *low.ValueReference[*Response] {
+	var found *low.ValueReference[*Response]
+	rank := 0
+	for code, resp := range r.Codes.FromOldest() {
+		if m := utils.MatchResponseCode(code.Value, status); m > rank {
+			found = &resp
+			rank = m
+		}
+	}
+	if found == nil && !r.Default.IsEmpty() {
+		found = &low.ValueReference[*Response]{Value: r.Default.Value, ValueNode: r.Default.ValueNode}
+	}
+	return found
+}
+
+// ValidateCodes will check every response code is well-formed (see ValidateResponseCode), all problems
+// found are returned.
+func (r *Responses) ValidateCodes() error {
+	var errs []error
+	for code := range r.Codes.KeysFromOldest() {
+		if err := ValidateResponseCode(code.Value); err != nil {
+			errs = append(errs, fmt.Errorf("%s, line %d, column %d", err.Error(), code.KeyNode.Line, code.KeyNode.Column))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateResponseCode will check a response code used by a Swagger Responses object is well-formed. A valid code
+// is either 'default' or an HTTP status code between 100 and 599. Ranges of codes (like 2XX) were added in
+// OpenAPI 3 and are not valid in Swagger.
+func ValidateResponseCode(code string) error {
+	if len(code) == 3 && strings.EqualFold(code[1:], "XX") {
+		return fmt.Errorf("response code range '%s' is not valid, ranges are not supported by Swagger", code)
+	}
+	return utils.ValidateResponseCode(code)
+}
+
+// Hash will return a consistent SHA256 Hash of the Responses object
 func (r *Responses) Hash() [32]byte {
+	return r.hashCache.GetOrCompute(r.hash)
+}
+
+// hash calculates the hash of the Responses, without using the cache.
+func (r *Responses) hash() [32]byte {
 	var f []string
 	f = low.AppendMapHashes(f, orderedmap.SortAlpha(r.Codes))
 	if !r.Default.IsEmpty() {
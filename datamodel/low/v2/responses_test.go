@@ -112,3 +112,31 @@ x-tea: warm`
 	assert.Equal(t, n.Hash(), n2.Hash())
 	assert.Equal(t, 1, orderedmap.Len(n.GetExtensions()))
 }
+
+func TestResponses_FindResponseForCode(t *testing.T) {
+	yml := `"200":
+  description: ok
+DEFAULT:
+  description: oops
+2XX:
+  description: range
+x-pizza: party`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Responses
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	err := n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, orderedmap.Len(n.Codes))
+	assert.Equal(t, "oops", n.Default.Value.Description.Value)
+	assert.Equal(t, "ok", n.FindResponseForCode(200).Value.Description.Value)
+	assert.Equal(t, "oops", n.FindResponseForCode(500).Value.Description.Value)
+	assert.Equal(t, "party", n.FindExtension("x-pizza").Value.Value)
+
+	assert.EqualError(t, n.ValidateCodes(), "response code range '2XX' is not valid, ranges are not "+
+		"supported by Swagger, line 5, column 1")
+}
@@ -23,6 +23,13 @@ import (
 type Scopes struct {
 	Values     *orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode    *yaml.Node
+	RootNode   *yaml.Node
+}
+
+// FindExtension attempts to locate an extension with the supplied key
+func (s *Scopes) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, s.Extensions)
 }
 
 // GetExtensions returns all Scopes extensions and satisfies the low.HasExtensions interface.
@@ -35,16 +42,33 @@ func (s *Scopes) FindScope(scope string) *low.ValueReference[string] {
 	return low.FindItemInOrderedMap[string](scope, s.Values)
 }
 
+// GetKeyNode returns the key yaml node of the Scopes object.
+func (s *Scopes) GetKeyNode() *yaml.Node {
+	return s.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the Scopes object.
+func (s *Scopes) GetRootNode() *yaml.Node {
+	return s.RootNode
+}
+
+// GetValueNode returns the value yaml node of the Scopes object, which is the same as the root node.
+func (s *Scopes) GetValueNode() *yaml.Node {
+	return s.RootNode
+}
+
 // Build will extract scope values and extensions from node.
-func (s *Scopes) Build(_ context.Context, _, root *yaml.Node, _ *index.SpecIndex) error {
+func (s *Scopes) Build(_ context.Context, keyNode, root *yaml.Node, _ *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	s.KeyNode = keyNode
+	s.RootNode = root
 	utils.CheckForMergeNodes(root)
 	s.Extensions = low.ExtractExtensions(root)
 	valueMap := orderedmap.New[low.KeyReference[string], low.ValueReference[string]]()
 	if utils.IsNodeMap(root) {
 		for k := range root.Content {
 			if k%2 == 0 {
-				if strings.Contains(root.Content[k].Value, "x-") {
+				if strings.HasPrefix(root.Content[k].Value, "x-") {
 					continue
 				}
 				valueMap.Set(
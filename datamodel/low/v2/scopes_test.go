@@ -43,3 +43,22 @@ burgers: chips`
 	assert.Equal(t, n.Hash(), n2.Hash())
 	assert.Equal(t, 1, orderedmap.Len(n.GetExtensions()))
 }
+
+func TestScopes_Build_ExtensionPrefixOnly(t *testing.T) {
+	yml := `read:x-files: read the files
+write:pets: modify pets
+x-scope-owner: pets-team`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Scopes
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	assert.Equal(t, 2, orderedmap.Len(n.Values))
+	assert.Equal(t, "read the files", n.FindScope("read:x-files").Value)
+	assert.Nil(t, n.FindScope("x-scope-owner"))
+	assert.Equal(t, "pets-team", n.FindExtension("x-scope-owner").Value.Value)
+}
@@ -31,6 +31,13 @@ type SecurityScheme struct {
 	TokenUrl         low.NodeReference[string]
 	Scopes           low.NodeReference[*Scopes]
 	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode          *yaml.Node
+	RootNode         *yaml.Node
+}
+
+// FindExtension attempts to locate an extension with the supplied key
+func (ss *SecurityScheme) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, ss.Extensions)
 }
 
 // GetExtensions returns all SecurityScheme extensions and satisfies the low.HasExtensions interface.
@@ -38,9 +45,26 @@ func (ss *SecurityScheme) GetExtensions() *orderedmap.Map[low.KeyReference[strin
 	return ss.Extensions
 }
 
+// GetKeyNode returns the key yaml node of the SecurityScheme object.
+func (ss *SecurityScheme) GetKeyNode() *yaml.Node {
+	return ss.KeyNode
+}
+
+// GetRootNode returns the root yaml node of the SecurityScheme object.
+func (ss *SecurityScheme) GetRootNode() *yaml.Node {
+	return ss.RootNode
+}
+
+// GetValueNode returns the value yaml node of the SecurityScheme object, which is the same as the root node.
+func (ss *SecurityScheme) GetValueNode() *yaml.Node {
+	return ss.RootNode
+}
+
 // Build will extract extensions and scopes from the node.
-func (ss *SecurityScheme) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+func (ss *SecurityScheme) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
+	ss.KeyNode = keyNode
+	ss.RootNode = root
 	utils.CheckForMergeNodes(root)
 	ss.Extensions = low.ExtractExtensions(root)
 
@@ -116,6 +116,56 @@ type Swagger struct {
 	// Rolodex is a reference to the index.Rolodex instance created when the specification was read.
 	// The rolodex is used to look up references from file systems (local or remote)
 	Rolodex *index.Rolodex
+
+	// BuildWarnings collects everything that was tolerated when building the document (and the models within it),
+	// that did not stop the document from being built.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	BuildWarnings *low.BuildWarnings
+
+	// HashScope controls the caching of hashes for the models of the document. Hashes are only cached while the
+	// scope is frozen (see low.HashScope.Freeze), which is done when the document is compared, or while the scope
+	// keeps them (see datamodel.DocumentConfiguration.CacheHashes).
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	HashScope *low.HashScope
+
+	// Allocator allocated the models of the document, if it was built with bulk allocation (see
+	// datamodel.DocumentConfiguration.BulkAllocation), otherwise it's nil.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	Allocator *low.Allocator
+
+	// RootNode is the top-level mapping node of the document.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	RootNode *yaml.Node
+}
+
+// GetUnknownKeys walks the entire Swagger document and returns every key that is not a part of the specification, and is
+// not an extension. Each unknown key carries the key and value nodes, along with the type of object it was found in.
+func (s *Swagger) GetUnknownKeys() []*low.UnknownKey {
+	return low.FindUnknownKeys(s)
+}
+
+// GetRootNode returns the top-level mapping node of the Swagger document.
+func (s *Swagger) GetRootNode() *yaml.Node {
+	return s.RootNode
+}
+
+// GetValueNode returns the top-level mapping node of the Swagger document, which is the same as the root node.
+func (s *Swagger) GetValueNode() *yaml.Node {
+	return s.RootNode
+}
+
+// GetKeyNode always returns nil, a Swagger document is not defined under a key.
+func (s *Swagger) GetKeyNode() *yaml.Node {
+	return nil
+}
+
+// GetIndex returns the index of the root of the Swagger.
+func (s *Swagger) GetIndex() *index.SpecIndex {
+	return s.Index
 }
 
 // FindExtension locates an extension from the root of the Swagger document.
@@ -129,13 +179,46 @@ func (s *Swagger) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.
 }
 
 // CreateDocumentFromConfig will create a new Swagger document from the provided SpecInfo and DocumentConfiguration.
+// Building is governed by the Context and Timeout of the configuration, see CreateDocumentFromConfigWithContext.
 func CreateDocumentFromConfig(info *datamodel.SpecInfo,
 	configuration *datamodel.DocumentConfiguration) (*Swagger, error) {
-	return createDocument(info, configuration)
+	ctx, cancel := configuration.BuildContext(nil)
+	defer cancel()
+	return createDocument(ctx, info, configuration)
+}
+
+// CreateDocumentFromConfigWithContext is the same as CreateDocumentFromConfig, except the supplied context is passed
+// through to every model as it is built. If the context is cancelled, or its deadline is exceeded, no more references
+// are looked up and building stops, the document is not returned and the context error is.
+//
+// The Timeout of the configuration is applied to the supplied context, and the same context governs the lookups
+// made by the rolodex and building the index.
+func CreateDocumentFromConfigWithContext(ctx context.Context, info *datamodel.SpecInfo,
+	configuration *datamodel.DocumentConfiguration) (*Swagger, error) {
+	ctx, cancel := configuration.BuildContext(ctx)
+	defer cancel()
+	return createDocument(ctx, info, configuration)
 }
 
-func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Swagger, error) {
+func createDocument(ctx context.Context, info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Swagger, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	doc := Swagger{Swagger: low.ValueReference[string]{Value: info.Version, ValueNode: info.RootNode}}
+	doc.RootNode = info.RootNode.Content[0]
+	doc.BuildWarnings = low.GetBuildWarnings(ctx)
+	if doc.BuildWarnings == nil {
+		doc.BuildWarnings = low.NewBuildWarnings()
+		ctx = low.WithBuildWarnings(ctx, doc.BuildWarnings)
+	}
+	doc.HashScope = low.NewHashScope()
+	ctx = low.WithHashScope(ctx, doc.HashScope)
+	doc.Allocator = low.GetAllocator(ctx)
+	if doc.Allocator == nil && config.BulkAllocation {
+		doc.Allocator = low.NewAllocator()
+		ctx = low.WithAllocator(ctx, doc.Allocator)
+	}
+	ctx = low.WithPathFilter(ctx, config.BuildPaths)
 	doc.Extensions = low.ExtractExtensions(info.RootNode.Content[0])
 
 	// create an index config and shadow the document configuration.
@@ -146,7 +229,16 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	idxConfig.AvoidCircularReferenceCheck = true
 	idxConfig.BaseURL = config.BaseURL
 	idxConfig.BasePath = config.BasePath
-	idxConfig.Logger = config.Logger
+	logger := config.GetLogger()
+	idxConfig.Logger = logger
+	idxConfig.SharedResolutionCache = config.SharedResolutionCache
+	idxConfig.ParseLimits = config.GetParseLimits()
+	idxConfig.YAMLEngine = config.YAMLEngine
+	idxConfig.MaxResolveDepth = config.MaxResolveDepth
+	// the rolodex outlives building the document, lookups made later on are not bound by the build context.
+	indexCtx, release := datamodel.IndexContext(ctx)
+	defer release()
+	idxConfig.Context = indexCtx
 	rolodex := index.NewRolodex(idxConfig)
 	rolodex.SetRootNode(info.RootNode)
 	doc.Rolodex = rolodex
@@ -165,6 +257,7 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 				BaseDirectory: cwd,
 				IndexConfig:   idxConfig,
 				FileFilters:   config.FileFilter,
+				Logger:        logger,
 			}
 			fileFS, _ := index.NewLocalFSWithConfig(&localFSConf)
 			idxConfig.AllowFileLookup = true
@@ -195,6 +288,14 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 
 	// index all the things!
 	_ = rolodex.IndexTheRolodex()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if config.OnIndexBuilt != nil {
+		if err := config.OnIndexBuilt(rolodex); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	// check for circular references
 	if !config.SkipCircularReferenceCheck {
@@ -206,6 +307,11 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	if roloErrs != nil {
 		errs = append(errs, roloErrs...)
 	}
+	if config.OnResolved != nil {
+		if err := config.OnResolved(rolodex); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	// set the index on the document.
 	doc.Index = rolodex.GetRootIndex()
@@ -214,8 +320,6 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	// build out swagger scalar variables.
 	_ = low.BuildModel(info.RootNode.Content[0], &doc)
 
-	ctx := context.Background()
-
 	// extract externalDocs
 	extDocs, err := low.ExtractObject[*base.ExternalDoc](ctx, base.ExternalDocsLabel, info.RootNode, rolodex.GetRootIndex())
 	if err != nil {
@@ -249,7 +353,19 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 			errs = append(errs, e)
 		}
 	}
-
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !config.SkipUnknownKeyWarnings {
+		low.AddUnknownKeyWarnings(ctx, &doc)
+	}
+	if config.CacheHashes {
+		roots := []*yaml.Node{info.RootNode}
+		for _, idx := range rolodex.GetIndexes() {
+			roots = append(roots, idx.GetRootNode())
+		}
+		doc.HashScope.Keep(roots...)
+	}
 	return &doc, errors.Join(errs...)
 }
 
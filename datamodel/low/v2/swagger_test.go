@@ -4,6 +4,7 @@
 package v2
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -14,6 +15,7 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -35,6 +37,22 @@ func initTest() {
 	}
 }
 
+func TestCreateDocumentFromConfigWithContext(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/petstorev2-complete.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	d, err := CreateDocumentFromConfigWithContext(context.Background(), info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+	require.NotNil(t, d)
+	assert.Equal(t, "Swagger Petstore", d.Info.Value.Title.Value)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d, err = CreateDocumentFromConfigWithContext(ctx, info, datamodel.NewDocumentConfiguration())
+	assert.Nil(t, d)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func BenchmarkCreateDocument(b *testing.B) {
 	data, _ := os.ReadFile("../../../test_specs/petstorev2-complete.yaml")
 	info, _ := datamodel.ExtractSpecInfo(data)
@@ -106,6 +124,54 @@ func TestCreateDocument_SecurityDefinitions(t *testing.T) {
 	assert.Equal(t, "read your pets", petStoreAuth.Value.Scopes.Value.FindScope("read:pets").Value)
 }
 
+func TestCreateDocument_SourceNodes(t *testing.T) {
+	initTest()
+
+	var _ low.HasSourceNodes = doc
+	assert.Nil(t, doc.GetKeyNode())
+	assert.Equal(t, doc.GetRootNode(), doc.GetValueNode())
+	assert.Equal(t, 2, doc.GetRootNode().Line)
+
+	sd := doc.SecurityDefinitions.Value
+	assert.Equal(t, "securityDefinitions", sd.GetKeyNode().Value)
+	assert.Equal(t, 649, sd.GetKeyNode().Line)
+	assert.Equal(t, 650, sd.GetRootNode().Line)
+
+	petStoreAuth := doc.SecurityDefinitions.Value.FindSecurityDefinition("petstore_auth")
+	assert.Equal(t, "petstore_auth", petStoreAuth.Value.GetKeyNode().Value)
+	assert.Equal(t, 661, petStoreAuth.Value.GetValueNode().Line)
+
+	scopes := petStoreAuth.Value.Scopes.Value
+	assert.Equal(t, "scopes", scopes.GetKeyNode().Value)
+	assert.Equal(t, 665, scopes.GetRootNode().Line)
+
+	nodes := []low.HasSourceNodes{
+		doc.Paths.Value, doc.Definitions.Value, doc.Parameters.Value, doc.Responses.Value,
+		doc.SecurityDefinitions.Value, scopes,
+	}
+	for _, n := range nodes {
+		assert.NotNil(t, n.GetKeyNode())
+		assert.NotNil(t, n.GetRootNode())
+		assert.Equal(t, n.GetRootNode(), n.GetValueNode())
+	}
+
+	pet := doc.Paths.Value.FindPath("/pet")
+	assert.Equal(t, "/pet", pet.Value.GetKeyNode().Value)
+	assert.Equal(t, "post", pet.Value.Post.Value.GetKeyNode().Value)
+}
+
+func TestCreateDocument_UnknownKeys(t *testing.T) {
+	initTest()
+	unknown := doc.GetUnknownKeys()
+	assert.Len(t, unknown, 3)
+
+	var found []string
+	for _, u := range unknown {
+		found = append(found, u.ParentType+":"+u.Name)
+	}
+	assert.ElementsMatch(t, []string{"v2.Swagger:externalPaths", "v2.PathItem:borked", "v2.Parameter:summary"}, found)
+}
+
 func TestCreateDocument_Definitions(t *testing.T) {
 	initTest()
 	apiResp := doc.Definitions.Value.FindSchema("ApiResponse").Value.Schema()
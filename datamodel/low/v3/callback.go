@@ -44,6 +44,11 @@ func (cb *Callback) GetContext() context.Context {
 	return cb.context
 }
 
+// FindExtension attempts to locate an extension with the supplied key
+func (cb *Callback) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, cb.Extensions)
+}
+
 // GetExtensions returns all Callback extensions and satisfies the low.HasExtensions interface.
 func (cb *Callback) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return cb.Extensions
@@ -59,6 +64,11 @@ func (cb *Callback) GetKeyNode() *yaml.Node {
 	return cb.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Callback object, which is the same as the root node.
+func (cb *Callback) GetValueNode() *yaml.Node {
+	return cb.RootNode
+}
+
 // FindExpression will locate a string expression and return a ValueReference containing the located PathItem
 func (cb *Callback) FindExpression(exp string) *low.ValueReference[*PathItem] {
 	return low.FindItemInOrderedMap(exp, cb.Expression)
@@ -70,7 +80,7 @@ func (cb *Callback) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 	root = utils.NodeAlias(root)
 	cb.RootNode = root
 	utils.CheckForMergeNodes(root)
-	cb.Reference = new(low.Reference)
+	cb.Reference = low.New[low.Reference](ctx)
 	cb.Nodes = low.ExtractNodes(ctx, root)
 	cb.Extensions = low.ExtractExtensions(root)
 	cb.context = ctx
@@ -78,7 +88,7 @@ func (cb *Callback) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 
 	low.ExtractExtensionNodes(ctx, cb.Extensions, cb.Nodes)
 
-	expressions, err := extractPathItemsMap(ctx, root, idx)
+	expressions, err := extractPathItemsMap(ctx, root, idx, false)
 	if err != nil {
 		return err
 	}
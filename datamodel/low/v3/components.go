@@ -43,6 +43,7 @@ type Components struct {
 	context         context.Context
 	*low.Reference
 	low.NodeMap
+	hashCache low.HashCache
 }
 
 type componentBuildResult[T any] struct {
@@ -80,8 +81,18 @@ func (co *Components) GetKeyNode() *yaml.Node {
 	return co.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Components object, which is the same as the root node.
+func (co *Components) GetValueNode() *yaml.Node {
+	return co.RootNode
+}
+
 // Hash will return a consistent SHA256 Hash of the Encoding object
 func (co *Components) Hash() [32]byte {
+	return co.hashCache.GetOrCompute(co.hash)
+}
+
+// hash calculates the hash of the Components, without using the cache.
+func (co *Components) hash() [32]byte {
 	var f []string
 	generateHashForObjectMap(co.Schemas.Value, &f)
 	generateHashForObjectMap(co.Responses.Value, &f)
@@ -158,7 +169,7 @@ func (co *Components) FindCallback(callback string) *low.ValueReference[*Callbac
 func (co *Components) Build(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	co.Reference = new(low.Reference)
+	co.Reference = low.New[low.Reference](ctx)
 	co.Nodes = low.ExtractNodes(ctx, root)
 	co.Extensions = low.ExtractExtensions(root)
 	low.ExtractExtensionNodes(ctx, co.Extensions, co.Nodes)
@@ -166,6 +177,7 @@ func (co *Components) Build(ctx context.Context, root *yaml.Node, idx *index.Spe
 	co.KeyNode = root
 	co.index = idx
 	co.context = ctx
+	co.hashCache.SetScope(low.GetHashScope(ctx))
 
 	var reterr error
 	var ceMutex sync.Mutex
@@ -15,6 +15,7 @@ import (
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
 )
 
 // CreateDocument will create a new Document instance from the provided SpecInfo.
@@ -22,23 +23,89 @@ import (
 // Deprecated: Use CreateDocumentFromConfig instead. This function will be removed in a later version, it
 // defaults to allowing file and remote references, and does not support relative file references.
 func CreateDocument(info *datamodel.SpecInfo) (*Document, error) {
-	return createDocument(info, datamodel.NewDocumentConfiguration())
+	return createDocument(context.Background(), info, datamodel.NewDocumentConfiguration())
 }
 
 // CreateDocumentFromConfig Create a new document from the provided SpecInfo and DocumentConfiguration pointer.
+// Building is governed by the Context and Timeout of the configuration, see CreateDocumentFromConfigWithContext.
 func CreateDocumentFromConfig(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Document, error) {
-	return createDocument(info, config)
+	ctx, cancel := config.BuildContext(nil)
+	defer cancel()
+	return createDocument(ctx, info, config)
 }
 
-func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Document, error) {
+// CreateDocumentFromConfigWithContext is the same as CreateDocumentFromConfig, except the supplied context is passed
+// through to every model as it is built. If the context is cancelled, or its deadline is exceeded, no more references
+// are looked up (local or remote) and building stops, the document is not returned and the context error is.
+//
+// The Timeout of the configuration is applied to the supplied context, and the same context governs the lookups
+// made by the rolodex and building the index.
+func CreateDocumentFromConfigWithContext(ctx context.Context, info *datamodel.SpecInfo,
+	config *datamodel.DocumentConfiguration,
+) (*Document, error) {
+	ctx, cancel := config.BuildContext(ctx)
+	defer cancel()
+	return createDocument(ctx, info, config)
+}
+
+// CreateDocumentFromRolodex creates a new document from the provided SpecInfo, using a rolodex that has already
+// indexed it, such as the rolodex of a document built before. No file is read or indexed again, which makes it the
+// way to build a document again after a file of the rolodex has been reloaded (see index.Rolodex.ReloadFile).
+func CreateDocumentFromRolodex(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration,
+	rolodex *index.Rolodex,
+) (*Document, error) {
+	ctx, cancel := config.BuildContext(nil)
+	defer cancel()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if rolodex == nil || rolodex.GetRootIndex() == nil {
+		return nil, errors.New("the rolodex has not indexed a document, cannot create document")
+	}
+	doc, ctx, err := newDocument(ctx, info, config)
+	if err != nil {
+		return nil, err
+	}
+	doc.Rolodex = rolodex
+	return extractDocument(ctx, info, doc, config, nil)
+}
+
+// newDocument creates a document holding the version and nodes of the provided SpecInfo, and returns the context
+// to build the rest of it with.
+func newDocument(ctx context.Context, info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration,
+) (*Document, context.Context, error) {
 	_, labelNode, versionNode := utils.FindKeyNodeFull(OpenAPILabel, info.RootNode.Content)
 	var version low.NodeReference[string]
 	if versionNode == nil {
-		return nil, errors.New("no openapi version/tag found, cannot create document")
+		return nil, ctx, errors.New("no openapi version/tag found, cannot create document")
 	}
 	version = low.NodeReference[string]{Value: versionNode.Value, KeyNode: labelNode, ValueNode: versionNode}
-	doc := Document{Version: version}
+	doc := Document{Version: version, RootNode: info.RootNode.Content[0]}
+	doc.BuildWarnings = low.GetBuildWarnings(ctx)
+	if doc.BuildWarnings == nil {
+		doc.BuildWarnings = low.NewBuildWarnings()
+		ctx = low.WithBuildWarnings(ctx, doc.BuildWarnings)
+	}
+	doc.HashScope = low.NewHashScope()
+	ctx = low.WithHashScope(ctx, doc.HashScope)
+	doc.Allocator = low.GetAllocator(ctx)
+	if doc.Allocator == nil && config.BulkAllocation {
+		doc.Allocator = low.NewAllocator()
+		ctx = low.WithAllocator(ctx, doc.Allocator)
+	}
+	ctx = low.WithPathFilter(ctx, config.BuildPaths)
 	doc.Nodes = low.ExtractNodes(nil, info.RootNode.Content[0])
+	return &doc, ctx, nil
+}
+
+func createDocument(ctx context.Context, info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Document, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	doc, ctx, err := newDocument(ctx, info, config)
+	if err != nil {
+		return nil, err
+	}
 	// create an index config and shadow the document configuration.
 	idxConfig := index.CreateClosedAPIIndexConfig()
 	idxConfig.SpecInfo = info
@@ -48,7 +115,16 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	idxConfig.BaseURL = urlWithoutTrailingSlash(config.BaseURL)
 	idxConfig.BasePath = config.BasePath
 	idxConfig.SpecFilePath = config.SpecFilePath
-	idxConfig.Logger = config.Logger
+	logger := config.GetLogger()
+	idxConfig.Logger = logger
+	idxConfig.SharedResolutionCache = config.SharedResolutionCache
+	idxConfig.ParseLimits = config.GetParseLimits()
+	idxConfig.YAMLEngine = config.YAMLEngine
+	idxConfig.MaxResolveDepth = config.MaxResolveDepth
+	// the rolodex outlives building the document, lookups made later on are not bound by the build context.
+	indexCtx, release := datamodel.IndexContext(ctx)
+	defer release()
+	idxConfig.Context = indexCtx
 	extract := config.ExtractRefsSequentially
 	idxConfig.ExtractRefsSequentially = extract
 	rolodex := index.NewRolodex(idxConfig)
@@ -69,6 +145,7 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 				BaseDirectory: cwd,
 				IndexConfig:   idxConfig,
 				FileFilters:   config.FileFilter,
+				Logger:        logger,
 			}
 
 			fileFS, _ := index.NewLocalFSWithConfig(&localFSConf)
@@ -100,34 +177,49 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	var errs []error
 
 	// index all the things.
-	if config.Logger != nil {
-		config.Logger.Debug("indexing rolodex")
-	}
+	logger.Debug("indexing rolodex")
 	now := time.Now()
 	_ = rolodex.IndexTheRolodex()
 	done := time.Duration(time.Since(now).Milliseconds())
-	if config.Logger != nil {
-		config.Logger.Debug("rolodex indexed", "ms", done)
+	logger.Debug("rolodex indexed", "ms", done)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	// check for circular references
-	if config.Logger != nil {
-		config.Logger.Debug("checking for circular references")
+	if config.OnIndexBuilt != nil {
+		if err := config.OnIndexBuilt(rolodex); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	// check for circular references
+	logger.Debug("checking for circular references")
 	now = time.Now()
 	if !config.SkipCircularReferenceCheck {
 		rolodex.CheckForCircularReferences()
 	}
 	done = time.Duration(time.Since(now).Milliseconds())
-	if config.Logger != nil {
-		if !config.SkipCircularReferenceCheck {
-			config.Logger.Debug("circular check completed", "ms", done)
-		}
+	if !config.SkipCircularReferenceCheck {
+		logger.Debug("circular check completed", "ms", done)
 	}
 	// extract errors
 	roloErrs := rolodex.GetCaughtErrors()
 	if roloErrs != nil {
 		errs = append(errs, roloErrs...)
 	}
+	if config.OnResolved != nil {
+		if err := config.OnResolved(rolodex); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return extractDocument(ctx, info, doc, config, errs)
+}
+
+// extractDocument builds everything in the document from the root index of its rolodex.
+func extractDocument(ctx context.Context, info *datamodel.SpecInfo, doc *Document,
+	config *datamodel.DocumentConfiguration, errs []error,
+) (*Document, error) {
+	rolodex := doc.Rolodex
+	logger := config.GetLogger()
 
 	// set root index.
 	doc.Index = rolodex.GetRootIndex()
@@ -135,7 +227,8 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 
 	var cacheMap sync.Map
 	modelContext := base.ModelContext{SchemaCache: &cacheMap}
-	ctx := context.WithValue(context.Background(), "modelCtx", &modelContext)
+	ctx = context.WithValue(ctx, "modelCtx", &modelContext)
+	doc.context = ctx
 
 	doc.Extensions = low.ExtractExtensions(info.RootNode.Content[0])
 	low.ExtractExtensionNodes(ctx, doc.Extensions, doc.Nodes)
@@ -153,6 +246,11 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 		ers *[]error,
 		wg *sync.WaitGroup,
 	) {
+		if ctx.Err() != nil {
+			// cancelled, there is no point in extracting anything else.
+			wg.Done()
+			return
+		}
 		if er := runFunc(ctx, info, doc, idx); er != nil {
 			*ers = append(*ers, er)
 		}
@@ -170,19 +268,28 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	}
 
 	wg.Add(len(extractionFuncs))
-	if config.Logger != nil {
-		config.Logger.Debug("running extractions")
-	}
-	now = time.Now()
+	logger.Debug("running extractions")
+	now := time.Now()
 	for _, f := range extractionFuncs {
-		runExtraction(ctx, info, &doc, rolodex.GetRootIndex(), f, &errs, &wg)
+		runExtraction(ctx, info, doc, rolodex.GetRootIndex(), f, &errs, &wg)
 	}
 	wg.Wait()
-	done = time.Duration(time.Since(now).Milliseconds())
-	if config.Logger != nil {
-		config.Logger.Debug("extractions complete", "time", done)
+	done := time.Duration(time.Since(now).Milliseconds())
+	logger.Debug("extractions complete", "time", done)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	return &doc, errors.Join(errs...)
+	if !config.SkipUnknownKeyWarnings {
+		low.AddUnknownKeyWarnings(ctx, doc)
+	}
+	if config.CacheHashes {
+		roots := []*yaml.Node{info.RootNode}
+		for _, idx := range rolodex.GetIndexes() {
+			roots = append(roots, idx.GetRootNode())
+		}
+		doc.HashScope.Keep(roots...)
+	}
+	return doc, errors.Join(errs...)
 }
 
 func extractInfo(ctx context.Context, info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex) error {
@@ -203,6 +310,10 @@ func extractSecurity(ctx context.Context, info *datamodel.SpecInfo, doc *Documen
 		return err
 	}
 	if vn != nil && ln != nil {
+		if sec == nil {
+			// security is set, but no requirements are defined (security is disabled).
+			sec = []low.ValueReference[*base.SecurityRequirement]{}
+		}
 		doc.Security = low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]]{
 			Value:     sec,
 			KeyNode:   ln,
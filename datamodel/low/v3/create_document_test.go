@@ -1,20 +1,24 @@
 package v3
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 var doc *Document
@@ -75,6 +79,27 @@ func TestRolodexLocalFileSystem(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCreateDocumentFromRolodex(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/first.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	cf := datamodel.NewDocumentConfiguration()
+	cf.BasePath = "../../../test_specs"
+	cf.FileFilter = []string{"first.yaml", "second.yaml", "third.yaml"}
+	lDoc, err := CreateDocumentFromConfig(info, cf)
+	require.NoError(t, err)
+
+	rebuilt, err := CreateDocumentFromRolodex(info, cf, lDoc.Rolodex)
+	require.NoError(t, err)
+	assert.Same(t, lDoc.Rolodex, rebuilt.Rolodex)
+	assert.Same(t, lDoc.Index, rebuilt.Index)
+	assert.NotSame(t, lDoc.Paths.Value, rebuilt.Paths.Value)
+	assert.Equal(t, lDoc.Paths.Value.Hash(), rebuilt.Paths.Value.Hash())
+
+	_, err = CreateDocumentFromRolodex(info, cf, index.NewRolodex(index.CreateClosedAPIIndexConfig()))
+	assert.Error(t, err)
+}
+
 func TestRolodexLocalFileSystem_ProvideNonRolodexFS(t *testing.T) {
 	data, _ := os.ReadFile("../../../test_specs/first.yaml")
 	info, _ := datamodel.ExtractSpecInfo(data)
@@ -235,6 +260,137 @@ components:
 	assert.Len(t, utils.UnwrapErrors(err), 0)
 }
 
+func TestCreateDocumentFromConfigWithContext(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/burgershop.openapi.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	d, err := CreateDocumentFromConfigWithContext(context.Background(), info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+	require.NotNil(t, d)
+	assert.Equal(t, "Burger Shop", d.Info.Value.Title.Value)
+
+	// schemas are built lazily, cancelling the context after the document is built must not break them.
+	ctx, cancel := context.WithCancel(context.Background())
+	d, err = CreateDocumentFromConfigWithContext(ctx, info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	cancel()
+	burger := d.Components.Value.FindSchema("Burger")
+	require.NotNil(t, burger)
+	assert.NotNil(t, burger.Value.Schema())
+	assert.NoError(t, burger.Value.GetBuildError())
+}
+
+func TestCreateDocumentFromConfigWithContext_Cancelled(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/burgershop.openapi.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d, err := CreateDocumentFromConfigWithContext(ctx, info, datamodel.NewDocumentConfiguration())
+	assert.Nil(t, d)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCreateDocumentFromConfig_Timeout(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      responses:
+        "200":
+          description: burgers
+          content:
+            application/json:
+              schema:
+                $ref: 'https://pb33f.io/slow.yaml#/components/schemas/Burger'`
+	info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+
+	hang := make(chan struct{})
+	defer close(hang)
+	config := datamodel.NewDocumentConfiguration()
+	config.AllowRemoteReferences = true
+	config.Timeout = 50 * time.Millisecond
+	config.RemoteURLHandler = func(url string) (*http.Response, error) {
+		<-hang
+		return nil, fmt.Errorf("too late")
+	}
+
+	start := time.Now()
+	d, err := CreateDocumentFromConfig(info, config)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Nil(t, d)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCreateDocumentFromConfig_Context(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/burgershop.openapi.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	config := datamodel.NewDocumentConfiguration()
+	config.Context = ctx
+	d, err := CreateDocumentFromConfig(info, config)
+	assert.Nil(t, d)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCreateDocumentFromConfig_Timeout_LazySchemas(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/burgershop.openapi.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	// the build context is done once the document is returned, schemas built later on must still resolve.
+	config := datamodel.NewDocumentConfiguration()
+	config.Timeout = time.Minute
+	d, err := CreateDocumentFromConfig(info, config)
+	require.NoError(t, err)
+	assert.NoError(t, d.Rolodex.GetConfig().Context.Err())
+
+	content := d.Paths.Value.FindPath("/burgers").Value.Post.Value.RequestBody.Value.FindContent("application/json")
+	schema := content.Value.Schema.Value.Schema()
+	require.NotNil(t, schema)
+	for _, prop := range schema.Properties.Value.FromOldest() {
+		assert.NotNil(t, prop.Value.Schema())
+		assert.NoError(t, prop.Value.GetBuildError())
+	}
+}
+
+func TestCreateDocumentFromConfig_BuildPaths(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/burgershop.openapi.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	config := datamodel.NewDocumentConfiguration()
+	config.BuildPaths = []string{"/burgers/{burgerId}", "/nowhere"}
+	d, err := CreateDocumentFromConfig(info, config)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, orderedmap.Len(d.Paths.Value.PathItems))
+	assert.Nil(t, d.Paths.Value.FindPath("/burgers"))
+	assert.NotNil(t, d.Paths.Value.FindExtension("x-milky-milk"))
+
+	// callback expressions are not filtered.
+	get := d.Paths.Value.FindPath("/burgers/{burgerId}").Value.Get.Value
+	callback := get.FindCallback("burgerCallback").Value
+	assert.Equal(t, 1, orderedmap.Len(callback.Expression))
+	assert.NotNil(t, d.Components.Value.FindCallback("BurgerCallback"))
+
+	// path items that were filtered out are built later, those already built are kept.
+	burger := d.Paths.Value.FindPath("/burgers/{burgerId}").Value
+	require.NoError(t, d.Paths.Value.BuildPaths("/burgers", "/burgers/{burgerId}", "/nowhere"))
+	assert.Equal(t, 2, orderedmap.Len(d.Paths.Value.PathItems))
+	assert.Same(t, burger, d.Paths.Value.FindPath("/burgers/{burgerId}").Value)
+	assert.Equal(t, "createBurger", d.Paths.Value.FindPath("/burgers").Value.Post.Value.OperationId.Value)
+
+	// a selection holds the path items in the order of the specification.
+	selected := d.Paths.Value.Select("/burgers/{burgerId}", "/burgers", "/dressings")
+	var paths []string
+	for k := range selected.PathItems.KeysFromOldest() {
+		paths = append(paths, k.Value)
+	}
+	assert.Equal(t, []string{"/burgers", "/burgers/{burgerId}"}, paths)
+	assert.Same(t, burger, selected.FindPath("/burgers/{burgerId}").Value)
+}
+
 func BenchmarkCreateDocument_Stripe(b *testing.B) {
 	data, _ := os.ReadFile("../../../test_specs/stripe.yaml")
 	info, _ := datamodel.ExtractSpecInfo(data)
@@ -247,6 +403,97 @@ func BenchmarkCreateDocument_Stripe(b *testing.B) {
 	}
 }
 
+// BenchmarkCreateDocument_Stripe_BulkAllocation builds the stripe spec, and every schema in its components, with and
+// without bulk allocation, compare the allocs/op of both.
+func BenchmarkCreateDocument_Stripe_BulkAllocation(b *testing.B) {
+	data, _ := os.ReadFile("../../../test_specs/stripe.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	for _, bulk := range []bool{false, true} {
+		b.Run(fmt.Sprintf("bulk=%v", bulk), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				// stripe has a circular reference, which is reported without stopping the document from being built.
+				doc, _ := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{BulkAllocation: bulk})
+				for _, schema := range doc.Components.Value.Schemas.Value.FromOldest() {
+					schema.Value.Schema()
+				}
+				doc.Allocator.Release()
+			}
+		})
+	}
+}
+
+// BenchmarkDocument_Hash hashes the paths and components of the stripe and docusign specs (over 9MB combined).
+// The cached benchmark freezes the documents (see low.HashScope), so hashes are only worked out once.
+// largeSpec returns a single specification of more than 10MB, made of the stripe specification and copies of its
+// paths and schemas, which reference the copied schemas.
+func largeSpec() []byte {
+	data, _ := os.ReadFile("../../../test_specs/stripe.yaml")
+	var root yaml.Node
+	_ = yaml.Unmarshal(data, &root)
+	_, _, paths := utils.FindKeyNodeFull("paths", root.Content[0].Content)
+	_, _, components := utils.FindKeyNodeFull("components", root.Content[0].Content)
+	_, _, schemas := utils.FindKeyNodeFull("schemas", components.Content)
+	var rename func(n *yaml.Node, suffix string)
+	rename = func(n *yaml.Node, suffix string) {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Kind == yaml.MappingNode && n.Content[i].Value == "$ref" {
+				n.Content[i+1].Value += suffix
+			}
+		}
+		for _, c := range n.Content {
+			rename(c, suffix)
+		}
+	}
+	pathCount, schemaCount := len(paths.Content), len(schemas.Content)
+	for copies := 1; copies <= 2; copies++ {
+		suffix := fmt.Sprintf("_%d", copies)
+		for i := 0; i < pathCount; i += 2 {
+			value := utils.CloneNode(paths.Content[i+1])
+			rename(value, suffix)
+			paths.Content = append(paths.Content, utils.CreateStringNode(fmt.Sprintf("/%d%s", copies,
+				paths.Content[i].Value)), value)
+		}
+		for i := 0; i < schemaCount; i += 2 {
+			value := utils.CloneNode(schemas.Content[i+1])
+			rename(value, suffix)
+			schemas.Content = append(schemas.Content, utils.CreateStringNode(schemas.Content[i].Value+suffix), value)
+		}
+	}
+	spec, _ := yaml.Marshal(&root)
+	if len(spec) < 10*1024*1024 {
+		panic("the specification should be more than 10MB")
+	}
+	return spec
+}
+
+func BenchmarkDocument_Hash(b *testing.B) {
+	info, _ := datamodel.ExtractSpecInfo(largeSpec())
+	d, _ := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{})
+	if d == nil {
+		panic("this should not fail")
+	}
+	hash := func() {
+		_ = d.Paths.Value.Hash()
+		_ = d.Components.Value.Hash()
+	}
+	b.Run("cached", func(b *testing.B) {
+		d.HashScope.Keep(d.RootNode)
+		defer d.HashScope.Release()
+		hash()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			hash()
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			hash()
+		}
+	})
+}
+
 func BenchmarkCreateDocument_Petstore(b *testing.B) {
 	data, _ := os.ReadFile("../../../test_specs/petstorev3.json")
 	info, _ := datamodel.ExtractSpecInfo(data)
@@ -308,6 +555,134 @@ func TestCreateDocument_Info(t *testing.T) {
 	assert.Equal(t, "https://pb33f.io/made-up", doc.Info.Value.License.Value.URL.Value)
 }
 
+func TestCreateDocument_SourceNodes(t *testing.T) {
+	initTest()
+
+	var _ low.HasSourceNodes = doc
+	assert.Nil(t, doc.GetKeyNode())
+	assert.Equal(t, doc.GetRootNode(), doc.GetValueNode())
+	assert.Equal(t, yaml.MappingNode, doc.GetRootNode().Kind)
+
+	oAuth := doc.Components.Value.FindSecurityScheme("OAuthScheme").Value
+	implicit := oAuth.Flows.Value.Implicit.Value
+	assert.Equal(t, "implicit", implicit.GetKeyNode().Value)
+	assert.Equal(t, 378, implicit.GetKeyNode().Line)
+	assert.Equal(t, 379, implicit.GetValueNode().Line)
+
+	burger := doc.Components.Value.FindSchema("Burger").Value.Schema()
+	assert.Equal(t, "Burger", burger.GetKeyNode().Value)
+	assert.Equal(t, 442, burger.GetKeyNode().Line)
+
+	nodes := []low.HasSourceNodes{
+		doc.Info.Value, doc.Paths.Value, doc.Components.Value, oAuth, oAuth.Flows.Value, implicit,
+		doc.Paths.Value.FindPath("/burgers").Value, burger,
+	}
+	for _, n := range nodes {
+		assert.NotNil(t, n.GetKeyNode())
+		assert.NotNil(t, n.GetRootNode())
+		assert.Equal(t, n.GetRootNode(), n.GetValueNode())
+	}
+}
+
+func TestCreateDocument_UnknownKeys(t *testing.T) {
+	yml := `openapi: 3.1.0
+info:
+  title: test
+  versoin: 1
+paths:
+  /pizza:
+    get:
+      summery: nope
+      parameters:
+        - name: cake
+          in: query
+          requierd: true
+          schema:
+            $ref: '#/components/schemas/Pizza'
+      responses:
+        "200":
+          description: ok
+          x-contnet: fine
+components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        b:
+          type: string
+          maxLenght: 2`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	d, err := CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+
+	unknown := d.GetUnknownKeys()
+	assert.Len(t, unknown, 4)
+
+	expected := []struct {
+		name, parent string
+		line         int
+	}{
+		{"versoin", "base.Info", 4},
+		{"summery", "v3.Operation", 8},
+		{"requierd", "v3.Parameter", 12},
+		{"maxLenght", "base.Schema", 26},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.name, unknown[i].Name)
+		assert.Equal(t, e.parent, unknown[i].ParentType)
+		assert.Equal(t, e.line, unknown[i].KeyNode.Line)
+	}
+
+	// a valid specification has no unknown keys.
+	initTest()
+	assert.Empty(t, doc.GetUnknownKeys())
+}
+
+func TestCreateDocument_UnknownKeys_JSONSchema(t *testing.T) {
+	yml := `openapi: 3.1.0
+info:
+  title: test
+  version: 1
+components:
+  schemas:
+    Pizza:
+      type: object
+      dependentRequired:
+        size: [crust]
+      dependentSchemas:
+        crust:
+          required: [size]
+      unevaluatedProperties: false
+      propertyNames:
+        pattern: ^[a-z]+$
+      properties:
+        toppings:
+          type: array
+          prefixItems:
+            - const: cheese
+          contains:
+            const: pepperoni
+          minContains: 1
+          maxContains: 2
+        notes:
+          type: string
+          contentMediaType: text/plain
+          contentEncoding: base64
+      if:
+        required: [toppings]
+      then:
+        minProperties: 1
+      else:
+        maxProperties: 3`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	d, err := CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+	assert.Empty(t, d.GetUnknownKeys())
+	assert.Zero(t, d.BuildWarnings.Len())
+}
+
 func TestCreateDocument_WebHooks(t *testing.T) {
 	initTest()
 	assert.Equal(t, 1, orderedmap.Len(doc.Webhooks.Value))
@@ -9,6 +9,8 @@
 package v3
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/index"
@@ -87,7 +89,63 @@ type Document struct {
 	// Rolodex is a reference to the rolodex used when creating this document.
 	Rolodex *index.Rolodex
 
+	// BuildWarnings collects everything that was tolerated when building the document (and the models within it),
+	// that did not stop the document from being built.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	BuildWarnings *low.BuildWarnings
+
+	// HashScope controls the caching of hashes for the models of the document. Hashes are only cached while the
+	// scope is frozen (see low.HashScope.Freeze), which is done when the document is compared, or while the scope
+	// keeps them (see datamodel.DocumentConfiguration.CacheHashes).
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	HashScope *low.HashScope
+
+	// Allocator allocated the models of the document, if it was built with bulk allocation (see
+	// datamodel.DocumentConfiguration.BulkAllocation), otherwise it's nil.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	Allocator *low.Allocator
+
+	// RootNode is the top-level mapping node of the document.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	RootNode *yaml.Node
+
 	low.NodeMap
+	context context.Context // the context the document was built with, used to build parts of it again.
+}
+
+// GetUnknownKeys walks the entire Document and returns every key that is not a part of the specification, and is
+// not an extension. Each unknown key carries the key and value nodes, along with the type of object it was found in.
+func (d *Document) GetUnknownKeys() []*low.UnknownKey {
+	return low.FindUnknownKeys(d)
+}
+
+// GetAnchors returns every YAML anchor defined in the Document, along with the aliases that refer to each anchor.
+func (d *Document) GetAnchors() []*low.Anchor {
+	return low.FindAnchors(d.RootNode)
+}
+
+// GetRootNode returns the top-level mapping node of the Document.
+func (d *Document) GetRootNode() *yaml.Node {
+	return d.RootNode
+}
+
+// GetValueNode returns the top-level mapping node of the Document, which is the same as the root node.
+func (d *Document) GetValueNode() *yaml.Node {
+	return d.RootNode
+}
+
+// GetKeyNode always returns nil, a Document is not defined under a key.
+func (d *Document) GetKeyNode() *yaml.Node {
+	return nil
+}
+
+// GetIndex returns the index of the root of the Document.
+func (d *Document) GetIndex() *index.SpecIndex {
+	return d.Index
 }
 
 // FindSecurityRequirement will attempt to locate a security requirement string from a supplied name.
@@ -103,6 +161,11 @@ func (d *Document) FindSecurityRequirement(name string) []low.ValueReference[str
 	return nil
 }
 
+// FindExtension attempts to locate an extension with the supplied key
+func (d *Document) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, d.Extensions)
+}
+
 // GetExtensions returns all Document extensions and satisfies the low.HasExtensions interface.
 func (d *Document) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return d.Extensions
@@ -17,17 +17,24 @@ import (
 )
 
 // Encoding represents a low-level OpenAPI 3+ Encoding object
+//
+// Encoding, ItemEncoding and PrefixEncoding are OpenAPI 3.2+ properties that allow encodings to be nested, for
+// multipart payloads that contain nested multipart payloads (or arrays of parts).
 //   - https://spec.openapis.org/oas/v3.1.0#encoding-object
 type Encoding struct {
-	ContentType   low.NodeReference[string]
-	Headers       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Header]]]
-	Style         low.NodeReference[string]
-	Explode       low.NodeReference[bool]
-	AllowReserved low.NodeReference[bool]
-	KeyNode       *yaml.Node
-	RootNode      *yaml.Node
-	index         *index.SpecIndex
-	context       context.Context
+	ContentType    low.NodeReference[string]
+	Headers        low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Header]]]
+	Style          low.NodeReference[string]
+	Explode        low.NodeReference[bool]
+	AllowReserved  low.NodeReference[bool]
+	Encoding       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Encoding]]]
+	ItemEncoding   low.NodeReference[*Encoding]
+	PrefixEncoding low.NodeReference[[]low.ValueReference[*Encoding]]
+	Extensions     *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode        *yaml.Node
+	RootNode       *yaml.Node
+	index          *index.SpecIndex
+	context        context.Context
 	*low.Reference
 	low.NodeMap
 }
@@ -47,6 +54,21 @@ func (en *Encoding) FindHeader(hType string) *low.ValueReference[*Header] {
 	return low.FindItemInOrderedMap[*Header](hType, en.Headers.Value)
 }
 
+// FindPropertyEncoding will attempt to locate a nested Encoding value with a specific name.
+func (en *Encoding) FindPropertyEncoding(eType string) *low.ValueReference[*Encoding] {
+	return low.FindItemInOrderedMap[*Encoding](eType, en.Encoding.Value)
+}
+
+// FindExtension attempts to locate an extension with the supplied key
+func (en *Encoding) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, en.Extensions)
+}
+
+// GetExtensions returns all Encoding extensions and satisfies the low.HasExtensions interface.
+func (en *Encoding) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return en.Extensions
+}
+
 // GetRootNode returns the root yaml node of the Encoding object
 func (en *Encoding) GetRootNode() *yaml.Node {
 	return en.RootNode
@@ -57,6 +79,11 @@ func (en *Encoding) GetKeyNode() *yaml.Node {
 	return en.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Encoding object, which is the same as the root node.
+func (en *Encoding) GetValueNode() *yaml.Node {
+	return en.RootNode
+}
+
 // Hash will return a consistent SHA256 Hash of the Encoding object
 func (en *Encoding) Hash() [32]byte {
 	var f []string
@@ -71,6 +98,16 @@ func (en *Encoding) Hash() [32]byte {
 	}
 	f = append(f, fmt.Sprint(sha256.Sum256([]byte(fmt.Sprint(en.Explode.Value)))))
 	f = append(f, fmt.Sprint(sha256.Sum256([]byte(fmt.Sprint(en.AllowReserved.Value)))))
+	for k, v := range orderedmap.SortAlpha(en.Encoding.Value).FromOldest() {
+		f = append(f, fmt.Sprintf("%s-%x", k.Value, v.Value.Hash()))
+	}
+	if en.ItemEncoding.Value != nil {
+		f = append(f, low.GenerateHashString(en.ItemEncoding.Value))
+	}
+	for _, v := range en.PrefixEncoding.Value {
+		f = append(f, low.GenerateHashString(v.Value))
+	}
+	f = append(f, low.HashExtensions(en.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
 
@@ -81,9 +118,11 @@ func (en *Encoding) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 	en.RootNode = root
 	utils.CheckForMergeNodes(root)
 	en.Nodes = low.ExtractNodes(ctx, root)
-	en.Reference = new(low.Reference)
+	en.Reference = low.New[low.Reference](ctx)
 	en.index = idx
 	en.context = ctx
+	en.Extensions = low.ExtractExtensions(root)
+	low.ExtractExtensionNodes(ctx, en.Extensions, en.Nodes)
 
 	headers, hL, hN, err := low.ExtractMap[*Header](ctx, HeadersLabel, root, idx)
 	if err != nil {
@@ -100,5 +139,45 @@ func (en *Encoding) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 			v.Value.Nodes.Store(k.KeyNode.Line, k.KeyNode)
 		}
 	}
+
+	// handle nested encoding (3.2+)
+	encs, encsL, encsN, encErr := low.ExtractMap[*Encoding](ctx, EncodingLabel, root, idx)
+	if encErr != nil {
+		return encErr
+	}
+	if encs != nil {
+		en.Encoding = low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Encoding]]]{
+			Value:     encs,
+			KeyNode:   encsL,
+			ValueNode: encsN,
+		}
+		en.Nodes.Store(encsL.Line, encsL)
+		for k, v := range encs.FromOldest() {
+			v.Value.Nodes.Store(k.KeyNode.Line, k.KeyNode)
+		}
+	}
+
+	// handle item encoding (3.2+)
+	if _, ieL, _ := utils.FindKeyNodeFullTop(ItemEncodingLabel, root.Content); ieL != nil {
+		itemEnc, ieErr := low.ExtractObject[*Encoding](ctx, ItemEncodingLabel, root, idx)
+		if ieErr != nil {
+			return ieErr
+		}
+		en.ItemEncoding = itemEnc
+	}
+
+	// handle prefix encoding (3.2+)
+	prefixEnc, peL, peN, peErr := low.ExtractArray[*Encoding](ctx, PrefixEncodingLabel, root, idx)
+	if peErr != nil {
+		return peErr
+	}
+	if peL != nil {
+		en.Nodes.Store(peL.Line, peL)
+		en.PrefixEncoding = low.NodeReference[[]low.ValueReference[*Encoding]]{
+			Value:     prefixEnc,
+			KeyNode:   peL,
+			ValueNode: peN,
+		}
+	}
 	return nil
 }
@@ -107,3 +107,112 @@ style: post modern
 	assert.Equal(t, n.Hash(), n2.Hash())
 
 }
+
+func TestEncoding_Build_NestedEncoding(t *testing.T) {
+
+	yml := `contentType: multipart/mixed
+encoding:
+  metadata:
+    contentType: application/json
+  files:
+    contentType: image/png
+itemEncoding:
+  contentType: text/plain
+prefixEncoding:
+  - contentType: application/json
+  - contentType: application/xml`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Encoding
+	err := low.BuildModel(idxNode.Content[0], &n)
+	assert.NoError(t, err)
+
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", n.ContentType.Value)
+	assert.Equal(t, 2, n.Encoding.Value.Len())
+	assert.Equal(t, "application/json", n.FindPropertyEncoding("metadata").Value.ContentType.Value)
+	assert.Equal(t, "image/png", n.FindPropertyEncoding("files").Value.ContentType.Value)
+	assert.Nil(t, n.FindPropertyEncoding("nope"))
+	assert.Equal(t, "text/plain", n.ItemEncoding.Value.ContentType.Value)
+	assert.Len(t, n.PrefixEncoding.Value, 2)
+	assert.Equal(t, "application/xml", n.PrefixEncoding.Value[1].Value.ContentType.Value)
+}
+
+func TestEncoding_Build_NestedEncodingError(t *testing.T) {
+
+	yml := `encoding:
+  metadata:
+    headers:
+      $ref: #/borked`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Encoding
+	err := low.BuildModel(idxNode.Content[0], &n)
+	assert.NoError(t, err)
+
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.Error(t, err)
+}
+
+func TestEncoding_Hash_NestedEncoding(t *testing.T) {
+
+	left := `itemEncoding:
+  contentType: text/plain
+prefixEncoding:
+  - contentType: application/json`
+
+	right := `itemEncoding:
+  contentType: text/csv
+prefixEncoding:
+  - contentType: application/json`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	var lDoc, rDoc Encoding
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	assert.NotEqual(t, lDoc.Hash(), rDoc.Hash())
+}
+
+func TestEncoding_Build_Extensions(t *testing.T) {
+	yml := `contentType: hot/cakes
+x-pizza: pie`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var n Encoding
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	err := n.Build(context.Background(), nil, idxNode.Content[0], nil)
+	assert.NoError(t, err)
+
+	ext := n.FindExtension("x-pizza")
+	assert.Equal(t, "pie", ext.Value.Value)
+	assert.Equal(t, 2, ext.ValueNode.Line)
+	assert.Equal(t, 1, n.GetExtensions().Len())
+
+	yml2 := `contentType: hot/cakes
+x-pizza: party`
+
+	var idxNode2 yaml.Node
+	_ = yaml.Unmarshal([]byte(yml2), &idxNode2)
+
+	var n2 Encoding
+	_ = low.BuildModel(idxNode2.Content[0], &n2)
+	_ = n2.Build(context.Background(), nil, idxNode2.Content[0], nil)
+	assert.NotEqual(t, n.Hash(), n2.Hash())
+}
@@ -6,7 +6,9 @@ package v3
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -38,6 +40,7 @@ type Header struct {
 	context         context.Context
 	*low.Reference
 	low.NodeMap
+	hashCache low.HashCache
 }
 
 // GetIndex returns the index.SpecIndex instance attached to the Header object
@@ -75,6 +78,11 @@ func (h *Header) GetKeyNode() *yaml.Node {
 	return h.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Header object, which is the same as the root node.
+func (h *Header) GetValueNode() *yaml.Node {
+	return h.RootNode
+}
+
 // GetExtensions returns all Header extensions and satisfies the low.HasExtensions interface.
 func (h *Header) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return h.Extensions
@@ -82,6 +90,11 @@ func (h *Header) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.V
 
 // Hash will return a consistent SHA256 Hash of the Header object
 func (h *Header) Hash() [32]byte {
+	return h.hashCache.GetOrCompute(h.hash)
+}
+
+// hash calculates the hash of the Header, without using the cache.
+func (h *Header) hash() [32]byte {
 	var f []string
 	if h.Description.Value != "" {
 		f = append(f, h.Description.Value)
@@ -116,15 +129,16 @@ func (h *Header) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index
 	root = utils.NodeAlias(root)
 	h.RootNode = root
 	utils.CheckForMergeNodes(root)
-	h.Reference = new(low.Reference)
+	h.Reference = low.New[low.Reference](ctx)
 	h.Nodes = low.ExtractNodes(ctx, root)
 	h.Extensions = low.ExtractExtensions(root)
 	h.context = ctx
+	h.hashCache.SetScope(low.GetHashScope(ctx))
 	h.index = idx
 
 	low.ExtractExtensionNodes(ctx, h.Extensions, h.Nodes)
 	// handle example if set.
-	_, expLabel, expNode := utils.FindKeyNodeFull(base.ExampleLabel, root.Content)
+	_, expLabel, expNode := utils.FindKeyNodeFullTop(base.ExampleLabel, root.Content)
 	if expNode != nil {
 		h.Example = low.NodeReference[*yaml.Node]{
 			Value:     expNode,
@@ -144,13 +158,17 @@ func (h *Header) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index
 	if eErr != nil {
 		return eErr
 	}
-	if exps != nil {
+	// Only consider examples if they are defined in the root node.
+	if exps != nil && slices.Contains(root.Content, expsL) {
 		h.Examples = low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.Example]]]{
 			Value:     exps,
 			KeyNode:   expsL,
 			ValueNode: expsN,
 		}
 		h.Nodes.Store(expsL.Line, expsL)
+		for k, v := range exps.FromOldest() {
+			v.Value.Nodes.Store(k.KeyNode.Line, k.KeyNode)
+		}
 	}
 
 	// handle schema
@@ -174,10 +192,60 @@ func (h *Header) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index
 	}
 	if cL != nil {
 		h.Nodes.Store(cL.Line, cL)
+		for k, v := range con.FromOldest() {
+			v.Value.Nodes.Store(k.KeyNode.Line, k.KeyNode)
+		}
+	}
+
+	// the header is still built, dropping it would lose data.
+	if problems := h.problems(); len(problems) > 0 {
+		for _, p := range problems {
+			low.AddBuildWarning(ctx, p.node, "%s", p.message)
+		}
+		if idx != nil && idx.GetLogger() != nil {
+			idx.GetLogger().Warn(h.Validate().Error())
+		}
 	}
 	return nil
 }
 
+// Validate will check the Header is valid. A Header uses either a schema or content (but not both), content must
+// contain exactly one media type, and example and examples are mutually exclusive. All problems found are returned.
+func (h *Header) Validate() error {
+	var errs []error
+	for _, p := range h.problems() {
+		line, col := 0, 0
+		if p.node != nil {
+			line, col = p.node.Line, p.node.Column
+		}
+		errs = append(errs, fmt.Errorf("%s, line %d, column %d", p.message, line, col))
+	}
+	return errors.Join(errs...)
+}
+
+type headerProblem struct {
+	node    *yaml.Node
+	message string
+}
+
+// problems returns everything wrong with the Header, along with the node each problem relates to.
+func (h *Header) problems() []headerProblem {
+	var problems []headerProblem
+	if !h.Schema.IsEmpty() && h.Content.Value != nil {
+		problems = append(problems, headerProblem{h.Content.KeyNode,
+			"header cannot have both a 'schema' and 'content', they are mutually exclusive"})
+	}
+	if h.Content.Value != nil && h.Content.Value.Len() != 1 {
+		problems = append(problems, headerProblem{h.Content.KeyNode,
+			fmt.Sprintf("header 'content' must contain exactly one media type, found %d", h.Content.Value.Len())})
+	}
+	if !h.Example.IsEmpty() && h.Examples.Value != nil {
+		problems = append(problems, headerProblem{h.Examples.KeyNode,
+			"header cannot have both an 'example' and 'examples', they are mutually exclusive"})
+	}
+	return problems
+}
+
 // Getter methods to satisfy OpenAPIHeader interface.
 
 func (h *Header) GetDescription() *low.NodeReference[string] {
@@ -254,3 +254,86 @@ schema:
 	assert.Equal(t, 1, orderedmap.Cast[low.KeyReference[string], low.ValueReference[*base.Example]](n.GetExamples().Value).Len())
 	assert.Equal(t, 1, orderedmap.Cast[low.KeyReference[string], low.ValueReference[*MediaType]](n.GetContent().Value).Len())
 }
+
+func TestHeader_Build_ContentForm(t *testing.T) {
+	yml := `description: a header using content
+content:
+  application/json:
+    schema:
+      type: object
+      properties:
+        example:
+          type: string
+          example: not a header example`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Header
+	err := low.BuildModel(idxNode.Content[0], &n)
+	assert.NoError(t, err)
+
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.NoError(t, n.Validate())
+
+	// the example belongs to the nested schema, not the header.
+	assert.True(t, n.Example.IsEmpty())
+	assert.NotNil(t, n.FindContent("application/json"))
+	assert.Equal(t, 3, n.FindContent("application/json").Value.GetKeyNode().Line)
+}
+
+func TestHeader_Validate(t *testing.T) {
+	yml := `schema:
+  type: string
+example: one
+examples:
+  two:
+    value: two
+content:
+  application/json:
+    schema:
+      type: string
+  application/xml:
+    schema:
+      type: string`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Header
+	err := low.BuildModel(idxNode.Content[0], &n)
+	assert.NoError(t, err)
+
+	// invalid headers are still built.
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+
+	err = n.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "header cannot have both a 'schema' and 'content', they are mutually exclusive, line 7, column 1")
+	assert.Contains(t, err.Error(), "header 'content' must contain exactly one media type, found 2")
+	assert.Contains(t, err.Error(), "header cannot have both an 'example' and 'examples'")
+}
+
+func TestHeader_Hash_Cached(t *testing.T) {
+	yml := `description: cached
+schema:
+  type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var n Header
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], nil)
+
+	h := n.Hash()
+	assert.Equal(t, h, n.Hash())
+	require.NoError(t, n.Description.SetValue("changed"))
+	assert.NotEqual(t, h, n.Hash())
+}
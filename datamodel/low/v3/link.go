@@ -78,13 +78,18 @@ func (l *Link) GetKeyNode() *yaml.Node {
 	return l.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Link object, which is the same as the root node.
+func (l *Link) GetValueNode() *yaml.Node {
+	return l.RootNode
+}
+
 // Build will extract extensions and servers from the node.
 func (l *Link) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	l.KeyNode = keyNode
 	root = utils.NodeAlias(root)
 	l.RootNode = root
 	utils.CheckForMergeNodes(root)
-	l.Reference = new(low.Reference)
+	l.Reference = low.New[low.Reference](ctx)
 	l.Nodes = low.ExtractNodes(ctx, root)
 	l.Extensions = low.ExtractExtensions(root)
 	l.index = idx
@@ -20,19 +20,26 @@ import (
 // MediaType represents a low-level OpenAPI MediaType object.
 //
 // Each Media Type Object provides schema and examples for the media type identified by its key.
+//
+// ItemSchema, ItemEncoding and PrefixEncoding are OpenAPI 3.2+ properties used to describe sequential media types
+// (like server-sent events or JSON Lines), where each item in the stream is described by ItemSchema.
 //   - https://spec.openapis.org/oas/v3.1.0#media-type-object
 type MediaType struct {
-	Schema     low.NodeReference[*base.SchemaProxy]
-	Example    low.NodeReference[*yaml.Node]
-	Examples   low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.Example]]]
-	Encoding   low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Encoding]]]
-	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode    *yaml.Node
-	RootNode   *yaml.Node
-	index      *index.SpecIndex
-	context    context.Context
+	Schema         low.NodeReference[*base.SchemaProxy]
+	ItemSchema     low.NodeReference[*base.SchemaProxy]
+	Example        low.NodeReference[*yaml.Node]
+	Examples       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.Example]]]
+	Encoding       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Encoding]]]
+	ItemEncoding   low.NodeReference[*Encoding]
+	PrefixEncoding low.NodeReference[[]low.ValueReference[*Encoding]]
+	Extensions     *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode        *yaml.Node
+	RootNode       *yaml.Node
+	index          *index.SpecIndex
+	context        context.Context
 	*low.Reference
 	low.NodeMap
+	hashCache low.HashCache
 }
 
 // GetIndex returns the index.SpecIndex instance attached to the MediaType object.
@@ -80,17 +87,23 @@ func (mt *MediaType) GetKeyNode() *yaml.Node {
 	return mt.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the MediaType object, which is the same as the root node.
+func (mt *MediaType) GetValueNode() *yaml.Node {
+	return mt.RootNode
+}
+
 // Build will extract examples, extensions, schema and encoding from node.
 func (mt *MediaType) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	mt.KeyNode = keyNode
 	root = utils.NodeAlias(root)
 	mt.RootNode = root
 	utils.CheckForMergeNodes(root)
-	mt.Reference = new(low.Reference)
+	mt.Reference = low.New[low.Reference](ctx)
 	mt.Nodes = low.ExtractNodes(ctx, root)
 	mt.Extensions = low.ExtractExtensions(root)
 	mt.index = idx
 	mt.context = ctx
+	mt.hashCache.SetScope(low.GetHashScope(ctx))
 
 	low.ExtractExtensionNodes(ctx, mt.Extensions, mt.Nodes)
 
@@ -115,6 +128,17 @@ func (mt *MediaType) Build(ctx context.Context, keyNode, root *yaml.Node, idx *i
 		mt.Schema = *sch
 	}
 
+	// handle item schema (3.2+), only if defined at the top level.
+	if _, isL, _ := utils.FindKeyNodeFullTop(ItemSchemaLabel, root.Content); isL != nil {
+		itemSch, isErr := base.ExtractSchemaWithLabel(ctx, ItemSchemaLabel, root, idx)
+		if isErr != nil {
+			return isErr
+		}
+		if itemSch != nil {
+			mt.ItemSchema = *itemSch
+		}
+	}
+
 	// handle examples if set.
 	exps, expsL, expsN, eErr := low.ExtractMap[*base.Example](ctx, base.ExamplesLabel, root, idx)
 	if eErr != nil {
@@ -149,11 +173,39 @@ func (mt *MediaType) Build(ctx context.Context, keyNode, root *yaml.Node, idx *i
 			v.Value.Nodes.Store(k.KeyNode.Line, k.KeyNode)
 		}
 	}
+
+	// handle item encoding (3.2+)
+	if _, ieL, _ := utils.FindKeyNodeFullTop(ItemEncodingLabel, root.Content); ieL != nil {
+		itemEnc, ieErr := low.ExtractObject[*Encoding](ctx, ItemEncodingLabel, root, idx)
+		if ieErr != nil {
+			return ieErr
+		}
+		mt.ItemEncoding = itemEnc
+	}
+
+	// handle prefix encoding (3.2+)
+	prefixEnc, peL, peN, peErr := low.ExtractArray[*Encoding](ctx, PrefixEncodingLabel, root, idx)
+	if peErr != nil {
+		return peErr
+	}
+	if peL != nil {
+		mt.Nodes.Store(peL.Line, peL)
+		mt.PrefixEncoding = low.NodeReference[[]low.ValueReference[*Encoding]]{
+			Value:     prefixEnc,
+			KeyNode:   peL,
+			ValueNode: peN,
+		}
+	}
 	return nil
 }
 
 // Hash will return a consistent SHA256 Hash of the MediaType object
 func (mt *MediaType) Hash() [32]byte {
+	return mt.hashCache.GetOrCompute(mt.hash)
+}
+
+// hash calculates the hash of the MediaType, without using the cache.
+func (mt *MediaType) hash() [32]byte {
 	var f []string
 	if mt.Schema.Value != nil {
 		f = append(f, low.GenerateHashString(mt.Schema.Value))
@@ -167,6 +219,15 @@ func (mt *MediaType) Hash() [32]byte {
 	for v := range orderedmap.SortAlpha(mt.Encoding.Value).ValuesFromOldest() {
 		f = append(f, low.GenerateHashString(v.Value))
 	}
+	if mt.ItemSchema.Value != nil {
+		f = append(f, low.GenerateHashString(mt.ItemSchema.Value))
+	}
+	if mt.ItemEncoding.Value != nil {
+		f = append(f, low.GenerateHashString(mt.ItemEncoding.Value))
+	}
+	for _, v := range mt.PrefixEncoding.Value {
+		f = append(f, low.GenerateHashString(v.Value))
+	}
 	f = append(f, low.HashExtensions(mt.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
@@ -203,3 +203,100 @@ func TestMediaType_Examples_NotFromSchema(t *testing.T) {
 
 	assert.Equal(t, 0, orderedmap.Len(n.Examples.Value))
 }
+
+func TestMediaType_Build_ItemSchema(t *testing.T) {
+	yml := `itemSchema:
+  type: object
+  properties:
+    event:
+      type: string
+itemEncoding:
+  contentType: application/json
+prefixEncoding:
+  - contentType: text/plain
+  - contentType: application/xml`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n MediaType
+	err := low.BuildModel(&idxNode, &n)
+	assert.NoError(t, err)
+
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+
+	assert.True(t, n.Schema.IsEmpty())
+	assert.Equal(t, "object", n.ItemSchema.Value.Schema().Type.Value.A)
+	assert.Equal(t, 1, n.ItemSchema.KeyNode.Line)
+	assert.Equal(t, "application/json", n.ItemEncoding.Value.ContentType.Value)
+	assert.Len(t, n.PrefixEncoding.Value, 2)
+	assert.Equal(t, "application/xml", n.PrefixEncoding.Value[1].Value.ContentType.Value)
+}
+
+func TestMediaType_Build_ItemSchema_NotTopLevel(t *testing.T) {
+	yml := `schema:
+  type: object
+  properties:
+    itemSchema:
+      type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n MediaType
+	_ = low.BuildModel(&idxNode, &n)
+	err := n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.True(t, n.ItemSchema.IsEmpty())
+	assert.True(t, n.ItemEncoding.IsEmpty())
+	assert.True(t, n.PrefixEncoding.IsEmpty())
+}
+
+func TestMediaType_Build_ItemSchema_BadRef(t *testing.T) {
+	yml := `itemSchema:
+  $ref: '#/nowhere'`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n MediaType
+	_ = low.BuildModel(&idxNode, &n)
+	err := n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.Error(t, err)
+}
+
+func TestMediaType_Build_PrefixEncoding_NotArray(t *testing.T) {
+	yml := `prefixEncoding: nope`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n MediaType
+	_ = low.BuildModel(&idxNode, &n)
+	err := n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.Error(t, err)
+}
+
+func TestMediaType_Hash_ItemSchema(t *testing.T) {
+	left := `itemSchema:
+  type: string`
+	right := `itemSchema:
+  type: integer`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	var lDoc, rDoc MediaType
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	assert.NotEqual(t, lDoc.Hash(), rDoc.Hash())
+}
@@ -17,17 +17,20 @@ import (
 )
 
 // OAuthFlows represents a low-level OpenAPI 3+ OAuthFlows object.
+//
+// DeviceAuthorization is an OpenAPI 3.2+ property for the OAuth 2.0 Device Authorization flow (RFC8628).
 //   - https://spec.openapis.org/oas/v3.1.0#oauth-flows-object
 type OAuthFlows struct {
-	Implicit          low.NodeReference[*OAuthFlow]
-	Password          low.NodeReference[*OAuthFlow]
-	ClientCredentials low.NodeReference[*OAuthFlow]
-	AuthorizationCode low.NodeReference[*OAuthFlow]
-	Extensions        *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode           *yaml.Node
-	RootNode          *yaml.Node
-	index             *index.SpecIndex
-	context           context.Context
+	Implicit            low.NodeReference[*OAuthFlow]
+	Password            low.NodeReference[*OAuthFlow]
+	ClientCredentials   low.NodeReference[*OAuthFlow]
+	AuthorizationCode   low.NodeReference[*OAuthFlow]
+	DeviceAuthorization low.NodeReference[*OAuthFlow]
+	Extensions          *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode             *yaml.Node
+	RootNode            *yaml.Node
+	index               *index.SpecIndex
+	context             context.Context
 	*low.Reference
 	low.NodeMap
 }
@@ -62,13 +65,18 @@ func (o *OAuthFlows) GetKeyNode() *yaml.Node {
 	return o.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the OAuthFlows object, which is the same as the root node.
+func (o *OAuthFlows) GetValueNode() *yaml.Node {
+	return o.RootNode
+}
+
 // Build will extract extensions and all OAuthFlow types from the supplied node.
 func (o *OAuthFlows) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	o.KeyNode = keyNode
 	root = utils.NodeAlias(root)
 	o.RootNode = root
 	utils.CheckForMergeNodes(root)
-	o.Reference = new(low.Reference)
+	o.Reference = low.New[low.Reference](ctx)
 	o.Nodes = low.ExtractNodes(ctx, root)
 	o.Extensions = low.ExtractExtensions(root)
 	o.index = idx
@@ -97,6 +105,12 @@ func (o *OAuthFlows) Build(ctx context.Context, keyNode, root *yaml.Node, idx *i
 		return vErr
 	}
 	o.AuthorizationCode = v
+
+	v, vErr = low.ExtractObject[*OAuthFlow](ctx, DeviceAuthorizationLabel, root, idx)
+	if vErr != nil {
+		return vErr
+	}
+	o.DeviceAuthorization = v
 	return nil
 }
 
@@ -115,21 +129,28 @@ func (o *OAuthFlows) Hash() [32]byte {
 	if !o.AuthorizationCode.IsEmpty() {
 		f = append(f, low.GenerateHashString(o.AuthorizationCode.Value))
 	}
+	if !o.DeviceAuthorization.IsEmpty() {
+		f = append(f, low.GenerateHashString(o.DeviceAuthorization.Value))
+	}
 	f = append(f, low.HashExtensions(o.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
 
 // OAuthFlow represents a low-level OpenAPI 3+ OAuthFlow object.
+//
+// DeviceAuthorizationUrl is an OpenAPI 3.2+ property, used by the device authorization flow.
 //   - https://spec.openapis.org/oas/v3.1.0#oauth-flow-object
 type OAuthFlow struct {
-	AuthorizationUrl low.NodeReference[string]
-	TokenUrl         low.NodeReference[string]
-	RefreshUrl       low.NodeReference[string]
-	Scopes           low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
-	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	RootNode         *yaml.Node
-	index            *index.SpecIndex
-	context          context.Context
+	AuthorizationUrl       low.NodeReference[string]
+	DeviceAuthorizationUrl low.NodeReference[string]
+	TokenUrl               low.NodeReference[string]
+	RefreshUrl             low.NodeReference[string]
+	Scopes                 low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
+	Extensions             *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode                *yaml.Node
+	RootNode               *yaml.Node
+	index                  *index.SpecIndex
+	context                context.Context
 	*low.Reference
 	low.NodeMap
 }
@@ -164,9 +185,20 @@ func (o *OAuthFlow) GetRootNode() *yaml.Node {
 	return o.RootNode
 }
 
+// GetKeyNode returns the key yaml node of the OAuthFlow object.
+func (o *OAuthFlow) GetKeyNode() *yaml.Node {
+	return o.KeyNode
+}
+
+// GetValueNode returns the value yaml node of the OAuthFlow object, which is the same as the root node.
+func (o *OAuthFlow) GetValueNode() *yaml.Node {
+	return o.RootNode
+}
+
 // Build will extract extensions from the node.
-func (o *OAuthFlow) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
-	o.Reference = new(low.Reference)
+func (o *OAuthFlow) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	o.KeyNode = keyNode
+	o.Reference = low.New[low.Reference](ctx)
 	o.Nodes = low.ExtractNodes(ctx, root)
 	o.Extensions = low.ExtractExtensions(root)
 	o.index = idx
@@ -195,6 +227,9 @@ func (o *OAuthFlow) Hash() [32]byte {
 	if !o.RefreshUrl.IsEmpty() {
 		f = append(f, o.RefreshUrl.Value)
 	}
+	if !o.DeviceAuthorizationUrl.IsEmpty() {
+		f = append(f, o.DeviceAuthorizationUrl.Value)
+	}
 	for k, v := range orderedmap.SortAlpha(o.Scopes.Value).FromOldest() {
 		f = append(f, fmt.Sprintf("%s-%s", k.Value, sha256.Sum256([]byte(fmt.Sprint(v.Value)))))
 	}
@@ -176,6 +176,44 @@ func TestOAuthFlow_Build_AuthCode(t *testing.T) {
 	assert.Equal(t, "https://pb33f.io/auth", n.AuthorizationCode.Value.AuthorizationUrl.Value)
 }
 
+func TestOAuthFlow_Build_DeviceAuthorization(t *testing.T) {
+	yml := `deviceAuthorization:
+  deviceAuthorizationUrl: https://pb33f.io/device
+  tokenUrl: https://pb33f.io/token
+  scopes:
+    chicken: nuggets`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n OAuthFlows
+	err := low.BuildModel(&idxNode, &n)
+	assert.NoError(t, err)
+
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://pb33f.io/device", n.DeviceAuthorization.Value.DeviceAuthorizationUrl.Value)
+	assert.Equal(t, "https://pb33f.io/token", n.DeviceAuthorization.Value.TokenUrl.Value)
+	assert.Equal(t, "nuggets", n.DeviceAuthorization.Value.FindScope("chicken").Value)
+}
+
+func TestOAuthFlow_Build_DeviceAuthorization_Fail(t *testing.T) {
+	yml := `deviceAuthorization:
+  $ref: #bork"`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n OAuthFlows
+	err := low.BuildModel(&idxNode, &n)
+	assert.NoError(t, err)
+
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.Error(t, err)
+}
+
 func TestOAuthFlow_Build_AuthCode_Fail(t *testing.T) {
 	yml := `authorizationCode:
   $ref: #bork"`
@@ -43,6 +43,7 @@ type Operation struct {
 	context      context.Context
 	*low.Reference
 	low.NodeMap
+	hashCache low.HashCache
 }
 
 // GetIndex returns the index.SpecIndex instance attached to the Operation object.
@@ -83,17 +84,23 @@ func (o *Operation) GetKeyNode() *yaml.Node {
 	return o.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Operation object, which is the same as the root node.
+func (o *Operation) GetValueNode() *yaml.Node {
+	return o.RootNode
+}
+
 // Build will extract external docs, parameters, request body, responses, callbacks, security and servers.
 func (o *Operation) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	o.KeyNode = keyNode
 	o.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	o.Reference = new(low.Reference)
+	o.Reference = low.New[low.Reference](ctx)
 	o.Nodes = low.ExtractNodes(ctx, root)
 	o.Extensions = low.ExtractExtensions(root)
 	o.index = idx
 	o.context = ctx
+	o.hashCache.SetScope(low.GetHashScope(ctx))
 	low.ExtractExtensionNodes(ctx, o.Extensions, o.Nodes)
 
 	// extract externalDocs
@@ -204,6 +211,11 @@ func (o *Operation) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 
 // Hash will return a consistent SHA256 Hash of the Operation object
 func (o *Operation) Hash() [32]byte {
+	return o.hashCache.GetOrCompute(o.hash)
+}
+
+// hash calculates the hash of the Operation, without using the cache.
+func (o *Operation) hash() [32]byte {
 	var f []string
 	if !o.Summary.IsEmpty() {
 		f = append(f, o.Summary.Value)
@@ -227,6 +239,10 @@ func (o *Operation) Hash() [32]byte {
 		f = append(f, low.GenerateHashString(o.Responses.Value))
 	}
 	if !o.Security.IsEmpty() {
+		if len(o.Security.Value) == 0 {
+			// security is defined, but empty (security is disabled).
+			f = append(f, SecurityLabel)
+		}
 		for k := range o.Security.Value {
 			f = append(f, low.GenerateHashString(o.Security.Value[k].Value))
 		}
@@ -294,6 +310,12 @@ func (o *Operation) GetDeprecated() low.NodeReference[bool] {
 	return o.Deprecated
 }
 
+// FindExtension attempts to locate an extension with the supplied key
+func (o *Operation) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, o.Extensions)
+}
+
+// GetExtensions returns all Operation extensions and satisfies the low.HasExtensions interface.
 func (o *Operation) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return o.Extensions
 }
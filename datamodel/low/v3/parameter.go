@@ -43,6 +43,7 @@ type Parameter struct {
 	context         context.Context
 	*low.Reference
 	low.NodeMap
+	hashCache low.HashCache
 }
 
 // GetIndex returns the index.SpecIndex instance attached to the Parameter object.
@@ -65,6 +66,11 @@ func (p *Parameter) GetKeyNode() *yaml.Node {
 	return p.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Parameter object, which is the same as the root node.
+func (p *Parameter) GetValueNode() *yaml.Node {
+	return p.RootNode
+}
+
 // FindContent will attempt to locate a MediaType instance using the specified name.
 func (p *Parameter) FindContent(cType string) *low.ValueReference[*MediaType] {
 	return low.FindItemInOrderedMap[*MediaType](cType, p.Content.Value)
@@ -91,11 +97,12 @@ func (p *Parameter) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 	p.KeyNode = keyNode
 	p.RootNode = root
 	utils.CheckForMergeNodes(root)
-	p.Reference = new(low.Reference)
+	p.Reference = low.New[low.Reference](ctx)
 	p.Nodes = low.ExtractNodes(ctx, root)
 	p.Extensions = low.ExtractExtensions(root)
 	p.index = idx
 	p.context = ctx
+	p.hashCache.SetScope(low.GetHashScope(ctx))
 	low.ExtractExtensionNodes(ctx, p.Extensions, p.Nodes)
 
 	// handle example if set.
@@ -154,6 +161,11 @@ func (p *Parameter) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 
 // Hash will return a consistent SHA256 Hash of the Parameter object
 func (p *Parameter) Hash() [32]byte {
+	return p.hashCache.GetOrCompute(p.hash)
+}
+
+// hash calculates the hash of the Parameter, without using the cache.
+func (p *Parameter) hash() [32]byte {
 	var f []string
 	if p.Name.Value != "" {
 		f = append(f, p.Name.Value)
@@ -45,6 +45,7 @@ type PathItem struct {
 	context     context.Context
 	*low.Reference
 	low.NodeMap
+	hashCache low.HashCache
 }
 
 // GetIndex returns the index.SpecIndex instance attached to the PathItem object.
@@ -59,6 +60,11 @@ func (p *PathItem) GetContext() context.Context {
 
 // Hash will return a consistent SHA256 Hash of the PathItem object
 func (p *PathItem) Hash() [32]byte {
+	return p.hashCache.GetOrCompute(p.hash)
+}
+
+// hash calculates the hash of the PathItem, without using the cache.
+func (p *PathItem) hash() [32]byte {
 	var f []string
 	if !p.Description.IsEmpty() {
 		f = append(f, p.Description.Value)
@@ -116,6 +122,11 @@ func (p *PathItem) GetKeyNode() *yaml.Node {
 	return p.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the PathItem object, which is the same as the root node.
+func (p *PathItem) GetValueNode() *yaml.Node {
+	return p.RootNode
+}
+
 // FindExtension attempts to find an extension
 func (p *PathItem) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
 	return low.FindItemInOrderedMap(ext, p.Extensions)
@@ -133,11 +144,12 @@ func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 	p.KeyNode = keyNode
 	p.RootNode = root
 	utils.CheckForMergeNodes(root)
-	p.Reference = new(low.Reference)
+	p.Reference = low.New[low.Reference](ctx)
 	p.Nodes = low.ExtractNodes(ctx, root)
 	p.Extensions = low.ExtractExtensions(root)
 	p.index = idx
 	p.context = ctx
+	p.hashCache.SetScope(low.GetHashScope(ctx))
 
 	low.ExtractExtensionNodes(ctx, p.Extensions, p.Nodes)
 	skip := false
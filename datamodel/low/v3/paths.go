@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
@@ -33,6 +34,7 @@ type Paths struct {
 	context    context.Context
 	*low.Reference
 	low.NodeMap
+	hashCache low.HashCache
 }
 
 // GetIndex returns the index.SpecIndex instance attached to the Paths object.
@@ -55,6 +57,11 @@ func (p *Paths) GetKeyNode() *yaml.Node {
 	return p.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Paths object, which is the same as the root node.
+func (p *Paths) GetValueNode() *yaml.Node {
+	return p.RootNode
+}
+
 // FindPath will attempt to locate a PathItem using the provided path string.
 func (p *Paths) FindPath(path string) (result *low.ValueReference[*PathItem]) {
 	for pair := orderedmap.First(p.PathItems); pair != nil; pair = pair.Next() {
@@ -94,15 +101,17 @@ func (p *Paths) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.
 	p.KeyNode = keyNode
 	p.RootNode = root
 	utils.CheckForMergeNodes(root)
-	p.Reference = new(low.Reference)
+	p.Reference = low.New[low.Reference](ctx)
 	p.Nodes = low.ExtractNodes(ctx, nil) // don't extract anything.
 	p.Extensions = low.ExtractExtensions(root)
 	p.index = idx
 	p.context = ctx
+	p.hashCache.SetScope(low.GetHashScope(ctx))
 
 	low.ExtractExtensionNodes(ctx, p.Extensions, p.Nodes)
 
-	pathsMap, err := extractPathItemsMap(ctx, root, idx)
+	// only the paths object is filtered by low.WithPathFilter, callback expressions are always built.
+	pathsMap, err := extractPathItemsMap(ctx, root, idx, true)
 	if err != nil {
 		return err
 	}
@@ -117,15 +126,88 @@ func (p *Paths) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.
 	return nil
 }
 
+// BuildPaths builds the path items of the paths supplied that have not been built yet, because they were filtered
+// out when the paths object was built (see low.WithPathFilter), with the index and context the paths object was built
+// with, and adds them. Paths that are already built, or that are not part of the specification, are skipped.
+//
+// The paths object must not be read while path items are being added.
+func (p *Paths) BuildPaths(paths ...string) error {
+	if p.RootNode == nil || p.PathItems == nil {
+		return nil
+	}
+	built := make(map[string]bool, orderedmap.Len(p.PathItems))
+	for k := range p.PathItems.KeysFromOldest() {
+		built[k.Value] = true
+	}
+	var content []*yaml.Node
+	for i := 0; i+1 < len(p.RootNode.Content); i += 2 {
+		key := p.RootNode.Content[i]
+		if !built[key.Value] && slices.Contains(paths, key.Value) {
+			content = append(content, key, p.RootNode.Content[i+1])
+		}
+	}
+	if len(content) == 0 {
+		return nil
+	}
+	entries := &yaml.Node{Kind: yaml.MappingNode, Tag: p.RootNode.Tag, Line: p.RootNode.Line,
+		Column: p.RootNode.Column, Content: content}
+	items, err := extractPathItemsMap(rebuildContext(p.context), entries, p.index, false)
+	if err != nil {
+		return err
+	}
+	for k, v := range items.FromOldest() {
+		v.Value.Nodes.Store(k.KeyNode.Line, k.KeyNode)
+		p.PathItems.Set(k, v)
+	}
+	p.hashCache.Reset()
+	return nil
+}
+
+// Select returns a paths object that only holds the path items of the paths supplied that have been built, in the
+// order they appear in the specification. The path items (and everything else) are shared with p.
+func (p *Paths) Select(paths ...string) *Paths {
+	selected := &Paths{
+		PathItems:  orderedmap.New[low.KeyReference[string], low.ValueReference[*PathItem]](),
+		Extensions: p.Extensions,
+		KeyNode:    p.KeyNode,
+		RootNode:   p.RootNode,
+		index:      p.index,
+		context:    p.context,
+		Reference:  p.Reference,
+		NodeMap:    p.NodeMap,
+	}
+	selected.hashCache.SetScope(low.GetHashScope(p.context))
+	if p.RootNode == nil || p.PathItems == nil {
+		return selected
+	}
+	built := make(map[string]orderedmap.Pair[low.KeyReference[string], low.ValueReference[*PathItem]],
+		orderedmap.Len(p.PathItems))
+	for pair := orderedmap.First(p.PathItems); pair != nil; pair = pair.Next() {
+		built[pair.Key().Value] = pair
+	}
+	for i := 0; i+1 < len(p.RootNode.Content); i += 2 {
+		name := p.RootNode.Content[i].Value
+		if pair, ok := built[name]; ok && slices.Contains(paths, name) {
+			selected.PathItems.Set(pair.Key(), pair.Value())
+		}
+	}
+	return selected
+}
+
 // Hash will return a consistent SHA256 Hash of the PathItem object
 func (p *Paths) Hash() [32]byte {
+	return p.hashCache.GetOrCompute(p.hash)
+}
+
+// hash calculates the hash of the Paths, without using the cache.
+func (p *Paths) hash() [32]byte {
 	var f []string
 	f = low.AppendMapHashes(f, p.PathItems)
 	f = append(f, low.HashExtensions(p.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
 
-func extractPathItemsMap(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) (*orderedmap.Map[low.KeyReference[string], low.ValueReference[*PathItem]], error) {
+func extractPathItemsMap(ctx context.Context, root *yaml.Node, idx *index.SpecIndex, filterPaths bool) (*orderedmap.Map[low.KeyReference[string], low.ValueReference[*PathItem]], error) {
 	// Translate YAML nodes to pathsMap using `TranslatePipeline`.
 	type buildResult struct {
 		key   low.KeyReference[string]
@@ -163,6 +245,9 @@ func extractPathItemsMap(ctx context.Context, root *yaml.Node, idx *index.SpecIn
 				currentNode = pathNode
 				continue
 			}
+			if filterPaths && !low.ShouldBuildPath(ctx, currentNode.Value) {
+				continue
+			}
 
 			select {
 			case in <- buildInput{
@@ -0,0 +1,136 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RebuiltEntries holds the names of the path items, webhooks and components built again by Document.Rebuild.
+type RebuiltEntries struct {
+	Paths    []string
+	Webhooks []string
+
+	// Components holds the names of the components built again, by the label of their type (like SchemasLabel).
+	Components map[string][]string
+}
+
+// Rebuild builds the path items, webhooks and components of the document that affected returns true for again, with
+// the index and context the document was built with, and puts them in place of the ones already built, keeping their
+// order. Nothing else in the document is built again. affected is called with the value node of every path item,
+// webhook and component.
+//
+// Rebuild is used to update a document after a file it references has been reloaded (see index.Rolodex.ReloadFile).
+// The document must not be read while it's being rebuilt. The entries that were built again are returned.
+func (d *Document) Rebuild(affected func(node *yaml.Node) bool) (*RebuiltEntries, error) {
+	rebuilt := &RebuiltEntries{Components: make(map[string][]string)}
+	var errs []error
+
+	if p := d.Paths.Value; p != nil && p.PathItems != nil {
+		if entries := affectedEntries(p.RootNode, affected); entries != nil {
+			items, err := extractPathItemsMap(rebuildContext(p.context), entries, p.index, true)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			for k, v := range items.FromOldest() {
+				v.Value.Nodes.Store(k.KeyNode.Line, k.KeyNode)
+				p.PathItems.Set(k, v)
+				rebuilt.Paths = append(rebuilt.Paths, k.Value)
+			}
+		}
+	}
+
+	if hooks := d.Webhooks.Value; hooks != nil {
+		if entries := affectedEntries(d.Webhooks.ValueNode, affected); entries != nil {
+			root := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{d.Webhooks.KeyNode, entries}}
+			items, _, _, err := low.ExtractMap[*PathItem](rebuildContext(d.context), WebhooksLabel, root, d.Index)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			for k, v := range items.FromOldest() {
+				v.Value.Nodes.Store(k.KeyNode.Line, k.KeyNode)
+				hooks.Set(k, v)
+				rebuilt.Webhooks = append(rebuilt.Webhooks, k.Value)
+			}
+		}
+	}
+
+	if co := d.Components.Value; co != nil {
+		ctx := rebuildContext(co.context)
+		errs = append(errs,
+			rebuildComponents(ctx, SchemasLabel, co, co.Schemas, affected, rebuilt),
+			rebuildComponents(ctx, ResponsesLabel, co, co.Responses, affected, rebuilt),
+			rebuildComponents(ctx, ParametersLabel, co, co.Parameters, affected, rebuilt),
+			rebuildComponents(ctx, base.ExamplesLabel, co, co.Examples, affected, rebuilt),
+			rebuildComponents(ctx, RequestBodiesLabel, co, co.RequestBodies, affected, rebuilt),
+			rebuildComponents(ctx, HeadersLabel, co, co.Headers, affected, rebuilt),
+			rebuildComponents(ctx, SecuritySchemesLabel, co, co.SecuritySchemes, affected, rebuilt),
+			rebuildComponents(ctx, LinksLabel, co, co.Links, affected, rebuilt),
+			rebuildComponents(ctx, CallbacksLabel, co, co.Callbacks, affected, rebuilt),
+			rebuildComponents(ctx, PathItemsLabel, co, co.PathItems, affected, rebuilt),
+		)
+	}
+
+	if d.HashScope != nil {
+		d.HashScope.Invalidate()
+	}
+	return rebuilt, errors.Join(errs...)
+}
+
+// rebuildComponents builds the components of a type that affected returns true for again, and puts them in place of
+// the ones already built.
+func rebuildComponents[T low.Buildable[N], N any](ctx context.Context, label string, co *Components,
+	components low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[T]]],
+	affected func(node *yaml.Node) bool, rebuilt *RebuiltEntries,
+) error {
+	if components.Value == nil {
+		return nil
+	}
+	entries := affectedEntries(components.ValueNode, affected)
+	if entries == nil {
+		return nil
+	}
+	root := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{components.KeyNode, entries}}
+	built, err := extractComponentValues[T](ctx, label, root, co.index, co)
+	for k, v := range built.Value.FromOldest() {
+		components.Value.Set(k, v)
+		rebuilt.Components[label] = append(rebuilt.Components[label], k.Value)
+	}
+	return err
+}
+
+// affectedEntries returns a map node holding the entries of a map node that affected returns true for, or nil if
+// there are none. The entries are built again from it, with the builders used for the whole map.
+func affectedEntries(node *yaml.Node, affected func(node *yaml.Node) bool) *yaml.Node {
+	node = utils.NodeAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	var content []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if affected(node.Content[i+1]) {
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+	}
+	if len(content) == 0 {
+		return nil
+	}
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: node.Tag, Line: node.Line, Column: node.Column, Content: content}
+}
+
+// rebuildContext returns the context to build parts of a document again with. The context the document was built
+// with may be cancelled once it's built, only its values are kept.
+func rebuildContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return context.WithoutCancel(ctx)
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDocument_Rebuild(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a:
+    get:
+      description: a
+  /b:
+    get:
+      description: b
+webhooks:
+  hook:
+    post:
+      description: hook
+components:
+  schemas:
+    One:
+      type: string
+    Two:
+      type: integer
+  responses:
+    Ok:
+      description: ok`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+	ctx, cancel := context.WithCancel(context.Background())
+	config := datamodel.NewDocumentConfiguration()
+	config.Context = ctx
+	doc, err := CreateDocumentFromConfig(info, config)
+	require.NoError(t, err)
+	cancel()
+
+	paths := doc.Paths.Value.PathItems
+	schemas := doc.Components.Value.Schemas.Value
+	pathA := doc.Paths.Value.FindPath("/a").Value
+	pathB := doc.Paths.Value.FindPath("/b").Value
+	hook := low.FindItemInOrderedMap("hook", doc.Webhooks.Value).Value
+	one := low.FindItemInOrderedMap("One", schemas).Value
+	two := low.FindItemInOrderedMap("Two", schemas).Value
+	ok := low.FindItemInOrderedMap("Ok", doc.Components.Value.Responses.Value).Value
+
+	affected := map[*yaml.Node]bool{
+		pathB.GetRootNode(): true,
+		hook.GetRootNode():  true,
+		two.GetValueNode():  true,
+	}
+	rebuilt, err := doc.Rebuild(func(node *yaml.Node) bool {
+		return affected[node]
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/b"}, rebuilt.Paths)
+	assert.Equal(t, []string{"hook"}, rebuilt.Webhooks)
+	assert.Equal(t, map[string][]string{SchemasLabel: {"Two"}}, rebuilt.Components)
+
+	// the affected entries are built again, even though the build context is cancelled.
+	newB := doc.Paths.Value.FindPath("/b").Value
+	assert.NotSame(t, pathB, newB)
+	assert.Equal(t, "b", newB.Get.Value.Description.Value)
+	assert.NotSame(t, hook, low.FindItemInOrderedMap("hook", doc.Webhooks.Value).Value)
+	newTwo := low.FindItemInOrderedMap("Two", schemas).Value
+	assert.NotSame(t, two, newTwo)
+	assert.Equal(t, "integer", newTwo.Schema().Type.Value.A)
+
+	// everything else is kept, in the same order.
+	assert.Same(t, pathA, doc.Paths.Value.FindPath("/a").Value)
+	assert.Same(t, one, low.FindItemInOrderedMap("One", schemas).Value)
+	assert.Same(t, ok, low.FindItemInOrderedMap("Ok", doc.Components.Value.Responses.Value).Value)
+	var names []string
+	for k := range paths.KeysFromOldest() {
+		names = append(names, k.Value)
+	}
+	assert.Equal(t, []string{"/a", "/b"}, names)
+	names = names[:0]
+	for k := range schemas.KeysFromOldest() {
+		names = append(names, k.Value)
+	}
+	assert.Equal(t, []string{"One", "Two"}, names)
+
+	// nothing affected, nothing is built again.
+	rebuilt, err = doc.Rebuild(func(*yaml.Node) bool { return false })
+	require.NoError(t, err)
+	assert.Empty(t, rebuilt.Paths)
+	assert.Empty(t, rebuilt.Webhooks)
+	assert.Empty(t, rebuilt.Components)
+}
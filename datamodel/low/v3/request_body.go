@@ -29,6 +29,7 @@ type RequestBody struct {
 	context     context.Context
 	*low.Reference
 	low.NodeMap
+	hashCache low.HashCache
 }
 
 // GetIndex returns the index.SpecIndex instance attached to the RequestBody object.
@@ -51,6 +52,11 @@ func (rb *RequestBody) GetKeyNode() *yaml.Node {
 	return rb.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the RequestBody object, which is the same as the root node.
+func (rb *RequestBody) GetValueNode() *yaml.Node {
+	return rb.RootNode
+}
+
 // FindExtension attempts to locate an extension using the provided name.
 func (rb *RequestBody) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
 	return low.FindItemInOrderedMap(ext, rb.Extensions)
@@ -72,11 +78,12 @@ func (rb *RequestBody) Build(ctx context.Context, keyNode, root *yaml.Node, idx
 	root = utils.NodeAlias(root)
 	rb.RootNode = root
 	utils.CheckForMergeNodes(root)
-	rb.Reference = new(low.Reference)
+	rb.Reference = low.New[low.Reference](ctx)
 	rb.Nodes = low.ExtractNodes(ctx, root)
 	rb.Extensions = low.ExtractExtensions(root)
 	rb.index = idx
 	rb.context = ctx
+	rb.hashCache.SetScope(low.GetHashScope(ctx))
 
 	low.ExtractExtensionNodes(ctx, rb.Extensions, rb.Nodes)
 
@@ -101,6 +108,11 @@ func (rb *RequestBody) Build(ctx context.Context, keyNode, root *yaml.Node, idx
 
 // Hash will return a consistent SHA256 Hash of the RequestBody object
 func (rb *RequestBody) Hash() [32]byte {
+	return rb.hashCache.GetOrCompute(rb.hash)
+}
+
+// hash calculates the hash of the RequestBody, without using the cache.
+func (rb *RequestBody) hash() [32]byte {
 	var f []string
 	if rb.Description.Value != "" {
 		f = append(f, rb.Description.Value)
@@ -32,6 +32,7 @@ type Response struct {
 	context     context.Context
 	*low.Reference
 	low.NodeMap
+	hashCache low.HashCache
 }
 
 // GetIndex returns the index.SpecIndex instance attached to the Response object.
@@ -54,6 +55,11 @@ func (r *Response) GetKeyNode() *yaml.Node {
 	return r.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Response object, which is the same as the root node.
+func (r *Response) GetValueNode() *yaml.Node {
+	return r.RootNode
+}
+
 // FindExtension will attempt to locate an extension using the supplied key
 func (r *Response) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
 	return low.FindItemInOrderedMap(ext, r.Extensions)
@@ -85,11 +91,12 @@ func (r *Response) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 	root = utils.NodeAlias(root)
 	r.RootNode = root
 	utils.CheckForMergeNodes(root)
-	r.Reference = new(low.Reference)
+	r.Reference = low.New[low.Reference](ctx)
 	r.Nodes = low.ExtractNodes(ctx, root)
 	r.Extensions = low.ExtractExtensions(root)
 	r.index = idx
 	r.context = ctx
+	r.hashCache.SetScope(low.GetHashScope(ctx))
 
 	low.ExtractExtensionNodes(ctx, r.Extensions, r.Nodes)
 
@@ -147,6 +154,11 @@ func (r *Response) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 
 // Hash will return a consistent SHA256 Hash of the Response object
 func (r *Response) Hash() [32]byte {
+	return r.hashCache.GetOrCompute(r.hash)
+}
+
+// hash calculates the hash of the Response, without using the cache.
+func (r *Response) hash() [32]byte {
 	var f []string
 	if r.Description.Value != "" {
 		f = append(f, r.Description.Value)
@@ -349,3 +349,52 @@ links:
 //	assert.Equal(t, "a link", link.Value.Description.Value)
 //
 //}
+
+func TestResponses_FindResponseForCode(t *testing.T) {
+	yml := `"2XX":
+  description: success
+"204":
+  description: no content
+4xx:
+  description: client error
+default:
+  description: default`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Responses
+	err := low.BuildModel(idxNode.Content[0], &n)
+	assert.NoError(t, err)
+
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "no content", n.FindResponseForCode(204).Value.Description.Value)
+	assert.Equal(t, "success", n.FindResponseForCode(200).Value.Description.Value)
+	assert.Equal(t, "client error", n.FindResponseForCode(404).Value.Description.Value)
+	assert.Equal(t, "default", n.FindResponseForCode(500).Value.Description.Value)
+	assert.Equal(t, 8, n.FindResponseForCode(500).ValueNode.Line)
+
+	err = n.ValidateCodes()
+	assert.Error(t, err)
+	assert.Equal(t, "response code range '4xx' is not valid, the wildcard must be an uppercase 'X', line 5, column 1", err.Error())
+}
+
+func TestResponses_FindResponseForCode_NoDefault(t *testing.T) {
+	yml := `"200":
+  description: OK`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var n Responses
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], nil)
+
+	assert.NotNil(t, n.FindResponseForCode(200))
+	assert.Nil(t, n.FindResponseForCode(201))
+	assert.NoError(t, n.ValidateCodes())
+}
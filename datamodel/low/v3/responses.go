@@ -6,6 +6,7 @@ package v3
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -44,6 +45,7 @@ type Responses struct {
 	context    context.Context
 	*low.Reference
 	low.NodeMap
+	hashCache low.HashCache
 }
 
 // GetIndex returns the index.SpecIndex instance attached to the Responses object.
@@ -66,6 +68,16 @@ func (r *Responses) GetKeyNode() *yaml.Node {
 	return r.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the Responses object, which is the same as the root node.
+func (r *Responses) GetValueNode() *yaml.Node {
+	return r.RootNode
+}
+
+// FindExtension attempts to locate an extension with the supplied key
+func (r *Responses) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, r.Extensions)
+}
+
 // GetExtensions returns all Responses extensions and satisfies the low.HasExtensions interface.
 func (r *Responses) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return r.Extensions
@@ -76,11 +88,12 @@ func (r *Responses) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 	r.KeyNode = keyNode
 	root = utils.NodeAlias(root)
 	r.RootNode = root
-	r.Reference = new(low.Reference)
+	r.Reference = low.New[low.Reference](ctx)
 	r.Nodes = low.ExtractNodes(ctx, root)
 	r.Extensions = low.ExtractExtensions(root)
 	r.index = idx
 	r.context = ctx
+	r.hashCache.SetScope(low.GetHashScope(ctx))
 
 	low.ExtractExtensionNodes(ctx, r.Extensions, r.Nodes)
 	utils.CheckForMergeNodes(root)
@@ -104,6 +117,11 @@ func (r *Responses) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 			// remove default from codes
 			r.deleteCode(DefaultLabel)
 		}
+		for code := range r.Codes.KeysFromOldest() {
+			if err := utils.ValidateResponseCode(code.Value); err != nil {
+				low.AddBuildWarning(ctx, code.KeyNode, "%s", err.Error())
+			}
+		}
 	} else {
 		return fmt.Errorf("responses build failed: vn node is not a map! line %d, col %d",
 			root.Line, root.Column)
@@ -144,8 +162,43 @@ func (r *Responses) FindResponseByCode(code string) *low.ValueReference[*Respons
 	return low.FindItemInOrderedMap[*Response](code, r.Codes)
 }
 
+// FindResponseForCode will locate the Response that describes an HTTP status code, following the precedence
+// defined by the specification. An exact code (like 204) is used first, then a range (like 2XX), then the
+// default. If no response applies, nil is returned.
+func (r *Responses) FindResponseForCode(status int) *low.ValueReference[*Response] {
+	var found *low.ValueReference[*Response]
+	rank := 0
+	for code, resp := range r.Codes.FromOldest() {
+		if m := utils.MatchResponseCode(code.Value, status); m > rank {
+			found = &resp
+			rank = m
+		}
+	}
+	if found == nil && !r.Default.IsEmpty() {
+		found = &low.ValueReference[*Response]{Value: r.Default.Value, ValueNode: r.Default.ValueNode}
+	}
+	return found
+}
+
+// ValidateCodes will check every response code is well-formed (see utils.ValidateResponseCode), all problems
+// found are returned.
+func (r *Responses) ValidateCodes() error {
+	var errs []error
+	for code := range r.Codes.KeysFromOldest() {
+		if err := utils.ValidateResponseCode(code.Value); err != nil {
+			errs = append(errs, fmt.Errorf("%s, line %d, column %d", err.Error(), code.KeyNode.Line, code.KeyNode.Column))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Hash will return a consistent SHA256 Hash of the Examples object
 func (r *Responses) Hash() [32]byte {
+	return r.hashCache.GetOrCompute(r.hash)
+}
+
+// hash calculates the hash of the Responses, without using the cache.
+func (r *Responses) hash() [32]byte {
 	var f []string
 	f = low.AppendMapHashes(f, r.Codes)
 	if !r.Default.IsEmpty() {
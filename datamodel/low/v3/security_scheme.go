@@ -24,21 +24,24 @@ import (
 // authorization code) as defined in RFC6749 (https://www.rfc-editor.org/rfc/rfc6749), and OpenID Connect Discovery.
 // Please note that as of 2020, the implicit  flow is about to be deprecated by OAuth 2.0 Security Best Current Practice.
 // Recommended for most use case is Authorization Code Grant flow with PKCE.
+//
+// OAuth2MetadataUrl is an OpenAPI 3.2+ property.
 //   - https://spec.openapis.org/oas/v3.1.0#security-scheme-object
 type SecurityScheme struct {
-	Type             low.NodeReference[string]
-	Description      low.NodeReference[string]
-	Name             low.NodeReference[string]
-	In               low.NodeReference[string]
-	Scheme           low.NodeReference[string]
-	BearerFormat     low.NodeReference[string]
-	Flows            low.NodeReference[*OAuthFlows]
-	OpenIdConnectUrl low.NodeReference[string]
-	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode          *yaml.Node
-	RootNode         *yaml.Node
-	index            *index.SpecIndex
-	context          context.Context
+	Type              low.NodeReference[string]
+	Description       low.NodeReference[string]
+	Name              low.NodeReference[string]
+	In                low.NodeReference[string]
+	Scheme            low.NodeReference[string]
+	BearerFormat      low.NodeReference[string]
+	Flows             low.NodeReference[*OAuthFlows]
+	OpenIdConnectUrl  low.NodeReference[string]
+	OAuth2MetadataUrl low.NodeReference[string]
+	Extensions        *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode           *yaml.Node
+	RootNode          *yaml.Node
+	index             *index.SpecIndex
+	context           context.Context
 	*low.Reference
 	low.NodeMap
 }
@@ -63,6 +66,11 @@ func (ss *SecurityScheme) GetKeyNode() *yaml.Node {
 	return ss.KeyNode
 }
 
+// GetValueNode returns the value yaml node of the SecurityScheme object, which is the same as the root node.
+func (ss *SecurityScheme) GetValueNode() *yaml.Node {
+	return ss.RootNode
+}
+
 // FindExtension attempts to locate an extension using the supplied key.
 func (ss *SecurityScheme) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
 	return low.FindItemInOrderedMap(ext, ss.Extensions)
@@ -79,7 +87,7 @@ func (ss *SecurityScheme) Build(ctx context.Context, keyNode, root *yaml.Node, i
 	root = utils.NodeAlias(root)
 	ss.RootNode = root
 	utils.CheckForMergeNodes(root)
-	ss.Reference = new(low.Reference)
+	ss.Reference = low.New[low.Reference](ctx)
 	ss.Nodes = low.ExtractNodes(ctx, root)
 	ss.Extensions = low.ExtractExtensions(root)
 	ss.index = idx
@@ -124,6 +132,9 @@ func (ss *SecurityScheme) Hash() [32]byte {
 	if !ss.OpenIdConnectUrl.IsEmpty() {
 		f = append(f, ss.OpenIdConnectUrl.Value)
 	}
+	if !ss.OAuth2MetadataUrl.IsEmpty() {
+		f = append(f, ss.OAuth2MetadataUrl.Value)
+	}
 	f = append(f, low.HashExtensions(ss.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
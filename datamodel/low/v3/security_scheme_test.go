@@ -85,6 +85,34 @@ x-milk: please`
 	assert.NotNil(t, n.GetIndex())
 }
 
+func TestSecurityScheme_Build_Metadata(t *testing.T) {
+	yml := `type: oauth2
+oauth2MetadataUrl: https://pb33f.io/.well-known/oauth-authorization-server`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n SecurityScheme
+	err := low.BuildModel(idxNode.Content[0], &n)
+	assert.NoError(t, err)
+
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://pb33f.io/.well-known/oauth-authorization-server", n.OAuth2MetadataUrl.Value)
+
+	yml2 := `type: oauth2
+oauth2MetadataUrl: https://pb33f.io/.well-known/openid-configuration`
+
+	var idxNode2 yaml.Node
+	_ = yaml.Unmarshal([]byte(yml2), &idxNode2)
+
+	var n2 SecurityScheme
+	_ = low.BuildModel(idxNode2.Content[0], &n2)
+	_ = n2.Build(context.Background(), nil, idxNode2.Content[0], index.NewSpecIndex(&idxNode2))
+	assert.NotEqual(t, n.Hash(), n2.Hash())
+}
+
 func TestSecurityScheme_Build_Fail(t *testing.T) {
 	yml := `flows:
   $ref: #bork`
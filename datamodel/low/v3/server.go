@@ -45,6 +45,21 @@ func (s *Server) GetRootNode() *yaml.Node {
 	return s.RootNode
 }
 
+// GetKeyNode returns the key yaml node of the Server object.
+func (s *Server) GetKeyNode() *yaml.Node {
+	return s.KeyNode
+}
+
+// GetValueNode returns the value yaml node of the Server object, which is the same as the root node.
+func (s *Server) GetValueNode() *yaml.Node {
+	return s.RootNode
+}
+
+// FindExtension attempts to locate an extension with the supplied key
+func (s *Server) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, s.Extensions)
+}
+
 // GetExtensions returns all Paths extensions and satisfies the low.HasExtensions interface.
 func (s *Server) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return s.Extensions
@@ -61,7 +76,7 @@ func (s *Server) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index
 	root = utils.NodeAlias(root)
 	s.RootNode = root
 	utils.CheckForMergeNodes(root)
-	s.Reference = new(low.Reference)
+	s.Reference = low.New[low.Reference](ctx)
 	s.Nodes = low.ExtractNodes(ctx, root)
 	s.Extensions = low.ExtractExtensions(root)
 	s.context = ctx
@@ -84,7 +99,7 @@ func (s *Server) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index
 				continue
 			}
 			variable := ServerVariable{}
-			variable.Reference = new(low.Reference)
+			variable.Reference = low.New[low.Reference](ctx)
 			_ = low.BuildModel(varNode, &variable)
 			variable.Nodes = low.ExtractNodesRecursive(ctx, varNode)
 			variable.Extensions = low.ExtractExtensions(varNode)
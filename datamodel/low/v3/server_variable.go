@@ -36,9 +36,19 @@ func (s *ServerVariable) GetRootNode() *yaml.Node {
 
 // GetKeyNode returns the key yaml node of the ServerVariable object.
 func (s *ServerVariable) GetKeyNode() *yaml.Node {
+	return s.KeyNode
+}
+
+// GetValueNode returns the value yaml node of the ServerVariable object, which is the same as the root node.
+func (s *ServerVariable) GetValueNode() *yaml.Node {
 	return s.RootNode
 }
 
+// FindExtension attempts to locate an extension with the supplied key
+func (s *ServerVariable) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, s.Extensions)
+}
+
 // GetExtensions returns all extensions and satisfies the low.HasExtensions interface.
 func (s *ServerVariable) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return s.Extensions
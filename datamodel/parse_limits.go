@@ -0,0 +1,163 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"errors"
+	"math"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrDocumentTooLarge is returned when a specification is larger than the MaxDocumentSize of the configuration.
+	ErrDocumentTooLarge = NewCodedError("document-too-large",
+		"unable to read document, it is larger than the maximum document size")
+
+	// ErrTooManyNodes is returned when a specification holds more nodes than the MaxNodeCount of the configuration.
+	ErrTooManyNodes = NewCodedError("too-many-nodes",
+		"unable to parse specification, it holds more nodes than the maximum node count")
+
+	// ErrAliasExpansionTooLarge is returned when the aliases of a specification expand into more nodes than the
+	// MaxAliasExpansion of the configuration.
+	ErrAliasExpansionTooLarge = NewCodedError("alias-expansion-too-large",
+		"unable to parse specification, its aliases expand beyond the maximum alias expansion")
+
+	// ErrNestingTooDeep is returned when a specification is nested deeper than the MaxNestingDepth of the
+	// configuration.
+	ErrNestingTooDeep = NewCodedError("nesting-too-deep",
+		"unable to parse specification, it is nested deeper than the maximum nesting depth")
+)
+
+// IsParseLimitError returns true if err is, or wraps, ErrTooManyNodes, ErrAliasExpansionTooLarge or
+// ErrNestingTooDeep.
+func IsParseLimitError(err error) bool {
+	return errors.Is(err, ErrTooManyNodes) || errors.Is(err, ErrAliasExpansionTooLarge) ||
+		errors.Is(err, ErrNestingTooDeep)
+}
+
+// ParseLimits are the limits that a parsed specification, and every file it references, is checked against. See
+// the MaxNodeCount, MaxAliasExpansion and MaxNestingDepth of DocumentConfiguration. Zero means there is no limit.
+type ParseLimits struct {
+	MaxNodeCount      int
+	MaxAliasExpansion int
+	MaxNestingDepth   int
+}
+
+// GetParseLimits returns the MaxNodeCount, MaxAliasExpansion and MaxNestingDepth of the configuration, no limits
+// are returned if the configuration is nil.
+func (c *DocumentConfiguration) GetParseLimits() ParseLimits {
+	if c == nil {
+		return ParseLimits{}
+	}
+	return ParseLimits{
+		MaxNodeCount:      c.MaxNodeCount,
+		MaxAliasExpansion: c.MaxAliasExpansion,
+		MaxNestingDepth:   c.MaxNestingDepth,
+	}
+}
+
+// Check returns ErrTooManyNodes, ErrAliasExpansionTooLarge or ErrNestingTooDeep if a parsed tree breaks any of the
+// limits. Aliases are measured without expanding them, so a tree that expands exponentially is rejected without
+// using exponential time or memory.
+func (p ParseLimits) Check(root *yaml.Node) error {
+	if root == nil || (p.MaxNodeCount <= 0 && p.MaxAliasExpansion <= 0 && p.MaxNestingDepth <= 0) {
+		return nil
+	}
+	l := &parseLimits{
+		maxNodes:   p.MaxNodeCount,
+		maxAliases: p.MaxAliasExpansion,
+		maxDepth:   p.MaxNestingDepth,
+		expanded:   make(map[*yaml.Node]expansion),
+	}
+	return l.check(root, 0)
+}
+
+// parseLimits checks a parsed yaml.Node tree against the parsing limits of a configuration.
+type parseLimits struct {
+	maxNodes   int
+	maxAliases int
+	maxDepth   int
+	nodes      int
+	aliases    int
+
+	// the size and depth of anchored nodes once their aliases are expanded, so every anchor is only measured once.
+	expanded map[*yaml.Node]expansion
+}
+
+type expansion struct {
+	size, depth int
+}
+
+func (l *parseLimits) check(n *yaml.Node, depth int) error {
+	if n.Kind != yaml.DocumentNode {
+		l.nodes++
+		if l.maxNodes > 0 && l.nodes > l.maxNodes {
+			return ErrTooManyNodes
+		}
+	}
+	switch n.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		depth++
+		if l.maxDepth > 0 && depth > l.maxDepth {
+			return ErrNestingTooDeep
+		}
+	case yaml.AliasNode:
+		if n.Alias == nil {
+			return nil
+		}
+		e := l.expand(n.Alias)
+		l.aliases = addCapped(l.aliases, e.size)
+		if l.maxAliases > 0 && l.aliases > l.maxAliases {
+			return ErrAliasExpansionTooLarge
+		}
+		if l.maxDepth > 0 && addCapped(depth, e.depth) > l.maxDepth {
+			return ErrNestingTooDeep
+		}
+		return nil
+	}
+	for _, c := range n.Content {
+		if err := l.check(c, depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expand returns the number of nodes, and the depth, of a node once every alias in it is expanded.
+func (l *parseLimits) expand(n *yaml.Node) expansion {
+	if e, ok := l.expanded[n]; ok {
+		return e
+	}
+	// an anchor that contains an alias of itself expands forever.
+	l.expanded[n] = expansion{size: math.MaxInt, depth: math.MaxInt}
+
+	var e expansion
+	switch n.Kind {
+	case yaml.AliasNode:
+		if n.Alias != nil {
+			e = l.expand(n.Alias)
+		}
+	default:
+		e.size = 1
+		for _, c := range n.Content {
+			ce := l.expand(c)
+			e.size = addCapped(e.size, ce.size)
+			e.depth = max(e.depth, ce.depth)
+		}
+		if n.Kind == yaml.MappingNode || n.Kind == yaml.SequenceNode {
+			e.depth = addCapped(e.depth, 1)
+		}
+	}
+	l.expanded[n] = e
+	return e
+}
+
+// addCapped adds two positive numbers, without overflowing.
+func addCapped(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
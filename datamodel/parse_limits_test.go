@@ -0,0 +1,107 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// billionLaughs returns a specification where every level of anchors holds ten aliases of the level before it.
+func billionLaughs(levels int) string {
+	var b strings.Builder
+	b.WriteString("openapi: 3.1.0\ninfo:\n  title: laughs\n  version: 1.0.0\nx-laughs:\n  l0: &l0 lol\n")
+	for i := 1; i <= levels; i++ {
+		fmt.Fprintf(&b, "  l%d: &l%d [", i, i)
+		for j := 0; j < 10; j++ {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "*l%d", i-1)
+		}
+		b.WriteString("]\n")
+	}
+	return b.String()
+}
+
+func TestExtractSpecInfoWithConfig_MaxAliasExpansion(t *testing.T) {
+	spec := []byte(billionLaughs(9))
+
+	_, err := ExtractSpecInfoWithConfig(spec, &DocumentConfiguration{MaxAliasExpansion: 10000})
+	assert.ErrorIs(t, err, ErrAliasExpansionTooLarge)
+
+	// a handful of aliases is fine.
+	spec = []byte(billionLaughs(2))
+	info, err := ExtractSpecInfoWithConfig(spec, &DocumentConfiguration{MaxAliasExpansion: 120})
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", info.Version)
+
+	_, err = ExtractSpecInfoWithConfig(spec, &DocumentConfiguration{MaxAliasExpansion: 119})
+	assert.ErrorIs(t, err, ErrAliasExpansionTooLarge)
+}
+
+func TestExtractSpecInfoWithConfig_MaxNodeCount(t *testing.T) {
+	spec := []byte("openapi: 3.1.0\ninfo:\n  title: pizza\n  version: 1.0.0\npaths: {}\n")
+
+	_, err := ExtractSpecInfoWithConfig(spec, &DocumentConfiguration{MaxNodeCount: 11})
+	assert.NoError(t, err)
+
+	_, err = ExtractSpecInfoWithConfig(spec, &DocumentConfiguration{MaxNodeCount: 10})
+	assert.ErrorIs(t, err, ErrTooManyNodes)
+}
+
+func TestExtractSpecInfoWithConfig_MaxNestingDepth(t *testing.T) {
+	spec := []byte("openapi: 3.1.0\ninfo:\n  title: pizza\n  version: 1.0.0\nx-deep: &deep\n  a:\n    b: [1]\nx-alias:\n  c: *deep\n")
+
+	_, err := ExtractSpecInfoWithConfig(spec, &DocumentConfiguration{MaxNestingDepth: 5})
+	assert.NoError(t, err)
+
+	// the alias nests the anchored map two levels down.
+	_, err = ExtractSpecInfoWithConfig(spec, &DocumentConfiguration{MaxNestingDepth: 4})
+	assert.ErrorIs(t, err, ErrNestingTooDeep)
+}
+
+func TestExtractSpecInfoWithConfig_MaxDocumentSize(t *testing.T) {
+	spec := []byte("openapi: 3.1.0\ninfo:\n  title: pizza\n")
+
+	_, err := ExtractSpecInfoWithConfig(spec, &DocumentConfiguration{MaxDocumentSize: int64(len(spec))})
+	assert.NoError(t, err)
+
+	_, err = ExtractSpecInfoWithConfig(spec, &DocumentConfiguration{MaxDocumentSize: 10})
+	assert.ErrorIs(t, err, ErrDocumentTooLarge)
+
+	info, err := ExtractSpecInfoWithConfig(spec, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", info.Version)
+}
+
+func TestCheckParseLimits_Recursive(t *testing.T) {
+	// an anchor that holds an alias of itself never stops expanding.
+	spec := []byte("openapi: 3.1.0\nx-loop: &loop\n  self: *loop\n")
+	_, err := ExtractSpecInfoWithConfig(spec, &DocumentConfiguration{MaxAliasExpansion: 1000})
+	assert.ErrorIs(t, err, ErrAliasExpansionTooLarge)
+
+	_, err = ExtractSpecInfoWithConfig(spec, &DocumentConfiguration{MaxNestingDepth: 1000})
+	assert.ErrorIs(t, err, ErrNestingTooDeep)
+}
+
+func TestParseLimits_Check(t *testing.T) {
+	var config *DocumentConfiguration
+	assert.Equal(t, ParseLimits{}, config.GetParseLimits())
+
+	config = &DocumentConfiguration{MaxNodeCount: 1, MaxAliasExpansion: 2, MaxNestingDepth: 3}
+	assert.Equal(t, ParseLimits{MaxNodeCount: 1, MaxAliasExpansion: 2, MaxNestingDepth: 3}, config.GetParseLimits())
+
+	info, err := ExtractSpecInfoWithConfig([]byte(billionLaughs(9)), &DocumentConfiguration{})
+	require.NoError(t, err)
+
+	assert.NoError(t, ParseLimits{}.Check(info.RootNode))
+	assert.NoError(t, ParseLimits{MaxAliasExpansion: 1000}.Check(nil))
+	assert.ErrorIs(t, ParseLimits{MaxAliasExpansion: 1000}.Check(info.RootNode), ErrAliasExpansionTooLarge)
+	assert.ErrorIs(t, ParseLimits{MaxNodeCount: 10}.Check(info.RootNode), ErrTooManyNodes)
+}
@@ -0,0 +1,604 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// maximum depth the schema validator will descend into a schema, for a single value, before giving up.
+// this protects against references that never consume any of the value.
+const maxSchemaValidationDepth = 256
+
+// SchemaValidator validates values against a JSON Schema, both held as yaml.Node trees. It's the validator used to
+// check specifications against the OpenAPI meta-schemas (see SpecInfo.Validate), and examples against the schemas
+// that own them (see base.ValidateExample).
+//
+// It understands the keywords used by OpenAPI schemas and meta-schemas, from draft-04 to 2020-12: type (and the
+// 3.0 nullable), enum, const, string, number, array and object bounds, pattern, multipleOf, properties,
+// patternProperties, additionalProperties, unevaluatedProperties, propertyNames, required, dependentRequired,
+// dependentSchemas, items (and draft-04 tuples), prefixItems, contains, composition (allOf, anyOf, oneOf, not,
+// if/then/else), boolean schemas, $ref and $dynamicRef. Formats are not validated, and references that can't be
+// resolved are accepted as valid.
+//
+// A SchemaValidator is safe for concurrent use.
+type SchemaValidator struct {
+	// Resolve locates the schema a $ref (or $dynamicRef) points to. If not set, or if it returns nil, references
+	// are resolved in the root schema, by JSON pointer or by anchor.
+	Resolve func(ref string) *yaml.Node
+
+	root    *yaml.Node
+	anchors map[string]*yaml.Node
+}
+
+// NewSchemaValidator creates a SchemaValidator that resolves local references ('#/...' and '#anchor') in root.
+func NewSchemaValidator(root *yaml.Node) *SchemaValidator {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	v := &SchemaValidator{root: root, anchors: make(map[string]*yaml.Node)}
+	if root != nil {
+		collectAnchors(root, v.anchors)
+	}
+	return v
+}
+
+// Validate evaluates a value against a schema, and returns every violation found, in the order they are found.
+// The paths of the violations start at '$', the value itself.
+func (v *SchemaValidator) Validate(schema, value *yaml.Node) []*ValidationError {
+	errs, _ := v.validate(schema, value, "$", 0)
+	return errs
+}
+
+func collectAnchors(n *yaml.Node, anchors map[string]*yaml.Node) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if k := n.Content[i].Value; k == "$anchor" || k == "$dynamicAnchor" {
+				anchors[n.Content[i+1].Value] = n
+			}
+			collectAnchors(n.Content[i+1], anchors)
+		}
+	case yaml.SequenceNode:
+		for _, c := range n.Content {
+			collectAnchors(c, anchors)
+		}
+	}
+}
+
+// resolve locates the schema a $ref (or $dynamicRef) points to, with Resolve first, then in the root schema.
+func (v *SchemaValidator) resolve(ref string) *yaml.Node {
+	if v.Resolve != nil {
+		if n := v.Resolve(ref); n != nil {
+			return n
+		}
+	}
+	if !strings.HasPrefix(ref, "#") || v.root == nil {
+		return nil
+	}
+	ref = ref[1:]
+	if !strings.HasPrefix(ref, "/") {
+		return v.anchors[ref]
+	}
+	n := v.root
+	for _, segment := range strings.Split(ref[1:], "/") {
+		segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+		n = mappingValue(n, segment)
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func (v *SchemaValidator) violation(node *yaml.Node, path, msg string, args ...any) *ValidationError {
+	return &ValidationError{
+		Message: fmt.Sprintf(msg, args...),
+		Path:    path,
+		Node:    node,
+		Line:    node.Line,
+		Column:  node.Column,
+	}
+}
+
+func schemaNodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!int":
+			return "integer"
+		case "!!float":
+			return "number"
+		case "!!bool":
+			return "boolean"
+		case "!!null":
+			return "null"
+		}
+	}
+	return "string"
+}
+
+func schemaTypeMatches(schemaType, nodeType string, node *yaml.Node) bool {
+	if schemaType == nodeType {
+		return true
+	}
+	switch schemaType {
+	case "number":
+		return nodeType == "integer"
+	case "integer":
+		if nodeType == "number" {
+			f, err := strconv.ParseFloat(node.Value, 64)
+			return err == nil && f == math.Trunc(f)
+		}
+	}
+	return false
+}
+
+// sameValue compares the values of two nodes, how they are written (and the order of keys) is ignored.
+var sameValue = utils.NodesEqualOptions{IgnoreStyle: true, IgnoreComments: true, IgnoreKeyOrder: true}
+
+func schemaNodeNumber(node *yaml.Node) (float64, bool) {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return 0, false
+	}
+	if t := node.ShortTag(); t != "!!int" && t != "!!float" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(node.Value, 64)
+	if err != nil {
+		// integers written in other bases, like 0x1F.
+		var i int64
+		if node.Decode(&i) != nil {
+			return 0, false
+		}
+		f = float64(i)
+	}
+	return f, true
+}
+
+func isFalse(node *yaml.Node) bool {
+	return node != nil && node.ShortTag() == "!!bool" && node.Value == "false"
+}
+
+// schemaPath appends a property to a JSON path, properties that are not simple names are quoted.
+func schemaPath(path, key string) string {
+	if key == "" || (key[0] >= '0' && key[0] <= '9') {
+		return fmt.Sprintf("%s['%s']", path, key)
+	}
+	for _, r := range key {
+		if !(r == '_' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return fmt.Sprintf("%s['%s']", path, key)
+		}
+	}
+	return path + "." + key
+}
+
+// schemaPathDepth returns the number of segments in a path created by schemaPath.
+func schemaPathDepth(path string) int {
+	depth := 0
+	quoted := false
+	for _, r := range path {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case !quoted && (r == '.' || r == '['):
+			depth++
+		}
+	}
+	return depth
+}
+
+// validate evaluates a node against a schema, it returns every violation found, along with the properties of the
+// node that were evaluated (for unevaluatedProperties).
+func (v *SchemaValidator) validate(schema, node *yaml.Node, path string, depth int) ([]*ValidationError, map[string]bool) {
+	if schema == nil || node == nil || depth > maxSchemaValidationDepth {
+		return nil, nil
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	if schema.Kind == yaml.DocumentNode && len(schema.Content) > 0 {
+		schema = schema.Content[0]
+	}
+	if schema.Kind == yaml.ScalarNode && schema.ShortTag() == "!!bool" {
+		if schema.Value == "false" {
+			return []*ValidationError{v.violation(node, path, "value is not allowed")}, nil
+		}
+		return nil, nil
+	}
+	if schema.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var errs []*ValidationError
+	evaluated := make(map[string]bool)
+	merge := func(e []*ValidationError, ev map[string]bool) {
+		errs = append(errs, e...)
+		for k := range ev {
+			evaluated[k] = true
+		}
+	}
+	keyword := func(k string) *yaml.Node {
+		return mappingValue(schema, k)
+	}
+
+	if ref := keyword("$ref"); ref != nil {
+		merge(v.validate(v.resolve(ref.Value), node, path, depth+1))
+	}
+	if ref := keyword("$dynamicRef"); ref != nil {
+		merge(v.validate(v.resolve(ref.Value), node, path, depth+1))
+	}
+
+	nodeType := schemaNodeType(node)
+	if t := keyword("type"); t != nil {
+		types := []*yaml.Node{t}
+		if t.Kind == yaml.SequenceNode {
+			types = t.Content
+		}
+		matched := false
+		var names []string
+		for _, st := range types {
+			names = append(names, st.Value)
+			if schemaTypeMatches(st.Value, nodeType, node) {
+				matched = true
+			}
+		}
+		if n := keyword("nullable"); !matched && nodeType == "null" && n != nil && n.Value == "true" {
+			matched = true
+		}
+		if !matched {
+			errs = append(errs, v.violation(node, path, "expected type '%s', but got '%s'",
+				strings.Join(names, "' or '"), nodeType))
+			return errs, evaluated
+		}
+	}
+	if e := keyword("enum"); e != nil && e.Kind == yaml.SequenceNode {
+		found := false
+		var allowed []string
+		for _, ev := range e.Content {
+			allowed = append(allowed, ev.Value)
+			if equal, _ := utils.NodesEqual(ev, node, sameValue); equal {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, v.violation(node, path, "value '%s' is not one of the allowed values '%s'",
+				node.Value, strings.Join(allowed, "', '")))
+		}
+	}
+	if c := keyword("const"); c != nil {
+		if equal, _ := utils.NodesEqual(c, node, sameValue); !equal {
+			errs = append(errs, v.violation(node, path, "value '%s' does not match the const value '%s'",
+				node.Value, c.Value))
+		}
+	}
+
+	switch nodeType {
+	case "string":
+		errs = append(errs, v.validateString(schema, node, path)...)
+	case "integer", "number":
+		errs = append(errs, v.validateNumber(schema, node, path)...)
+	case "object":
+		merge(v.validateObject(schema, node, path, depth))
+	case "array":
+		errs = append(errs, v.validateArray(schema, node, path, depth)...)
+	}
+	merge(v.validateComposition(schema, node, path, depth))
+
+	if u := keyword("unevaluatedProperties"); u != nil && nodeType == "object" {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if evaluated[key.Value] {
+				continue
+			}
+			propPath := schemaPath(path, key.Value)
+			if isFalse(u) {
+				errs = append(errs, v.violation(key, propPath, "property '%s' is not allowed", key.Value))
+			} else {
+				e, _ := v.validate(u, node.Content[i+1], propPath, depth+1)
+				errs = append(errs, e...)
+			}
+			evaluated[key.Value] = true
+		}
+	}
+	return errs, evaluated
+}
+
+func (v *SchemaValidator) validateString(schema, node *yaml.Node, path string) []*ValidationError {
+	var errs []*ValidationError
+	length := float64(utf8.RuneCountInString(node.Value))
+	if m, ok := schemaNodeNumber(mappingValue(schema, "minLength")); ok && length < m {
+		errs = append(errs, v.violation(node, path, "string length %v is less than minLength %v", length, m))
+	}
+	if m, ok := schemaNodeNumber(mappingValue(schema, "maxLength")); ok && length > m {
+		errs = append(errs, v.violation(node, path, "string length %v is greater than maxLength %v", length, m))
+	}
+	if p := mappingValue(schema, "pattern"); p != nil {
+		if rx, err := regexp.Compile(p.Value); err == nil && !rx.MatchString(node.Value) {
+			errs = append(errs, v.violation(node, path, "value '%s' does not match pattern '%s'", node.Value, p.Value))
+		}
+	}
+	return errs
+}
+
+func (v *SchemaValidator) validateNumber(schema, node *yaml.Node, path string) []*ValidationError {
+	n, ok := schemaNodeNumber(node)
+	if !ok {
+		return nil
+	}
+	var errs []*ValidationError
+	exclusiveMin, exclusiveMax := mappingValue(schema, "exclusiveMinimum"), mappingValue(schema, "exclusiveMaximum")
+	if m, ok := schemaNodeNumber(mappingValue(schema, "minimum")); ok {
+		if n < m || (exclusiveMin != nil && exclusiveMin.Value == "true" && n == m) {
+			errs = append(errs, v.violation(node, path, "value %v is less than minimum %v", n, m))
+		}
+	}
+	if m, ok := schemaNodeNumber(mappingValue(schema, "maximum")); ok {
+		if n > m || (exclusiveMax != nil && exclusiveMax.Value == "true" && n == m) {
+			errs = append(errs, v.violation(node, path, "value %v is greater than maximum %v", n, m))
+		}
+	}
+	if m, ok := schemaNodeNumber(exclusiveMin); ok && n <= m {
+		errs = append(errs, v.violation(node, path, "value %v must be greater than %v", n, m))
+	}
+	if m, ok := schemaNodeNumber(exclusiveMax); ok && n >= m {
+		errs = append(errs, v.violation(node, path, "value %v must be less than %v", n, m))
+	}
+	if m, ok := schemaNodeNumber(mappingValue(schema, "multipleOf")); ok && m != 0 {
+		if q := n / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			errs = append(errs, v.violation(node, path, "value %v is not a multiple of %v", n, m))
+		}
+	}
+	return errs
+}
+
+func (v *SchemaValidator) validateObject(schema, node *yaml.Node, path string, depth int) ([]*ValidationError, map[string]bool) {
+	var errs []*ValidationError
+	evaluated := make(map[string]bool)
+	props := float64(len(node.Content) / 2)
+	if m, ok := schemaNodeNumber(mappingValue(schema, "minProperties")); ok && props < m {
+		errs = append(errs, v.violation(node, path, "object has %v properties, less than minProperties %v", props, m))
+	}
+	if m, ok := schemaNodeNumber(mappingValue(schema, "maxProperties")); ok && props > m {
+		errs = append(errs, v.violation(node, path, "object has %v properties, more than maxProperties %v", props, m))
+	}
+	if r := mappingValue(schema, "required"); r != nil && r.Kind == yaml.SequenceNode {
+		for _, req := range r.Content {
+			if mappingValue(node, req.Value) == nil {
+				errs = append(errs, v.violation(node, path, "missing required property '%s'", req.Value))
+			}
+		}
+	}
+
+	properties := mappingValue(schema, "properties")
+	patternProperties := mappingValue(schema, "patternProperties")
+	additional := mappingValue(schema, "additionalProperties")
+	propertyNames := mappingValue(schema, "propertyNames")
+	dependentSchemas := mappingValue(schema, "dependentSchemas")
+	dependentRequired := mappingValue(schema, "dependentRequired")
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		propPath := schemaPath(path, key.Value)
+		if propertyNames != nil {
+			e, _ := v.validate(propertyNames, key, propPath, depth+1)
+			errs = append(errs, e...)
+		}
+		if dr := mappingValue(dependentRequired, key.Value); dr != nil && dr.Kind == yaml.SequenceNode {
+			for _, req := range dr.Content {
+				if mappingValue(node, req.Value) == nil {
+					errs = append(errs, v.violation(node, path, "missing property '%s', required by property '%s'",
+						req.Value, key.Value))
+				}
+			}
+		}
+		if ds := mappingValue(dependentSchemas, key.Value); ds != nil {
+			e, ev := v.validate(ds, node, path, depth+1)
+			errs = append(errs, e...)
+			for k := range ev {
+				evaluated[k] = true
+			}
+		}
+		matched := false
+		if prop := mappingValue(properties, key.Value); prop != nil {
+			matched = true
+			e, _ := v.validate(prop, value, propPath, depth+1)
+			errs = append(errs, e...)
+		}
+		if patternProperties != nil {
+			for j := 0; j+1 < len(patternProperties.Content); j += 2 {
+				rx, err := regexp.Compile(patternProperties.Content[j].Value)
+				if err != nil || !rx.MatchString(key.Value) {
+					continue
+				}
+				matched = true
+				e, _ := v.validate(patternProperties.Content[j+1], value, propPath, depth+1)
+				errs = append(errs, e...)
+			}
+		}
+		if !matched && additional != nil {
+			matched = true
+			if isFalse(additional) {
+				errs = append(errs, v.violation(key, propPath, "property '%s' is not allowed", key.Value))
+			} else {
+				e, _ := v.validate(additional, value, propPath, depth+1)
+				errs = append(errs, e...)
+			}
+		}
+		if matched {
+			evaluated[key.Value] = true
+		}
+	}
+	return errs, evaluated
+}
+
+func (v *SchemaValidator) validateArray(schema, node *yaml.Node, path string, depth int) []*ValidationError {
+	var errs []*ValidationError
+	count := float64(len(node.Content))
+	if m, ok := schemaNodeNumber(mappingValue(schema, "minItems")); ok && count < m {
+		errs = append(errs, v.violation(node, path, "array has %v items, less than minItems %v", count, m))
+	}
+	if m, ok := schemaNodeNumber(mappingValue(schema, "maxItems")); ok && count > m {
+		errs = append(errs, v.violation(node, path, "array has %v items, more than maxItems %v", count, m))
+	}
+	if u := mappingValue(schema, "uniqueItems"); u != nil && u.Value == "true" {
+		for i := 0; i < len(node.Content); i++ {
+			for j := i + 1; j < len(node.Content); j++ {
+				if equal, _ := utils.NodesEqual(node.Content[i], node.Content[j], sameValue); equal {
+					errs = append(errs, v.violation(node.Content[j], fmt.Sprintf("%s[%d]", path, j),
+						"array items must be unique"))
+				}
+			}
+		}
+	}
+	items := mappingValue(schema, "items")
+	additionalItems := mappingValue(schema, "additionalItems")
+	prefixItems := mappingValue(schema, "prefixItems")
+	for i, item := range node.Content {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		itemSchema := items
+		switch {
+		case prefixItems != nil && prefixItems.Kind == yaml.SequenceNode && i < len(prefixItems.Content):
+			// 2020-12 tuples, items beyond the tuple are checked by items.
+			itemSchema = prefixItems.Content[i]
+		case items != nil && items.Kind == yaml.SequenceNode:
+			// draft-04 tuples, items beyond the tuple are checked by additionalItems.
+			itemSchema = additionalItems
+			if i < len(items.Content) {
+				itemSchema = items.Content[i]
+			}
+		}
+		if isFalse(itemSchema) {
+			errs = append(errs, v.violation(item, itemPath, "additional array items are not allowed"))
+			continue
+		}
+		e, _ := v.validate(itemSchema, item, itemPath, depth+1)
+		errs = append(errs, e...)
+	}
+	if contains := mappingValue(schema, "contains"); contains != nil {
+		found := 0
+		for i, item := range node.Content {
+			if e, _ := v.validate(contains, item, fmt.Sprintf("%s[%d]", path, i), depth+1); len(e) == 0 {
+				found++
+			}
+		}
+		minContains, maxContains := 1.0, math.Inf(1)
+		if m, ok := schemaNodeNumber(mappingValue(schema, "minContains")); ok {
+			minContains = m
+		}
+		if m, ok := schemaNodeNumber(mappingValue(schema, "maxContains")); ok {
+			maxContains = m
+		}
+		if float64(found) < minContains || float64(found) > maxContains {
+			errs = append(errs, v.violation(node, path, "array has %d items matching 'contains', expected %v to %v",
+				found, minContains, maxContains))
+		}
+	}
+	return errs
+}
+
+func (v *SchemaValidator) validateComposition(schema, node *yaml.Node, path string, depth int) ([]*ValidationError, map[string]bool) {
+	var errs []*ValidationError
+	evaluated := make(map[string]bool)
+	merge := func(ev map[string]bool) {
+		for k := range ev {
+			evaluated[k] = true
+		}
+	}
+
+	if allOf := mappingValue(schema, "allOf"); allOf != nil {
+		for _, s := range allOf.Content {
+			e, ev := v.validate(s, node, path, depth+1)
+			errs = append(errs, e...)
+			merge(ev)
+		}
+	}
+
+	// evaluate every schema, and return how many accept the value. If none do, the violations of the closest
+	// schema are returned, that's the schema with violations deepest into the value, then with the fewest
+	// violations. OpenAPI meta-schemas list references first, so ties go to the later schema.
+	matches := func(schemas *yaml.Node) (int, []*ValidationError) {
+		count := 0
+		var closest []*ValidationError
+		closestDepth := -1
+		for _, s := range schemas.Content {
+			e, ev := v.validate(s, node, path, depth+1)
+			if len(e) == 0 {
+				count++
+				merge(ev)
+				continue
+			}
+			d := 0
+			for _, ve := range e {
+				d = max(d, schemaPathDepth(ve.Path))
+			}
+			if d > closestDepth || (d == closestDepth && len(e) <= len(closest)) {
+				closest, closestDepth = e, d
+			}
+		}
+		return count, closest
+	}
+
+	if anyOf := mappingValue(schema, "anyOf"); anyOf != nil && len(anyOf.Content) > 0 {
+		if c, closest := matches(anyOf); c == 0 {
+			errs = append(errs, closest...)
+		}
+	}
+	if oneOf := mappingValue(schema, "oneOf"); oneOf != nil && len(oneOf.Content) > 0 {
+		c, closest := matches(oneOf)
+		switch {
+		case c == 0:
+			errs = append(errs, closest...)
+		case c > 1:
+			errs = append(errs, v.violation(node, path, "value must match exactly one oneOf schema, but matched %d", c))
+		}
+	}
+	if not := mappingValue(schema, "not"); not != nil {
+		if e, _ := v.validate(not, node, path, depth+1); len(e) == 0 {
+			errs = append(errs, v.violation(node, path, "value must not match the 'not' schema"))
+		}
+	}
+	if ifSchema := mappingValue(schema, "if"); ifSchema != nil {
+		e, ev := v.validate(ifSchema, node, path, depth+1)
+		branch := mappingValue(schema, "else")
+		if len(e) == 0 {
+			merge(ev)
+			branch = mappingValue(schema, "then")
+		}
+		if branch != nil {
+			e, ev = v.validate(branch, node, path, depth+1)
+			errs = append(errs, e...)
+			merge(ev)
+		}
+	}
+	return errs, evaluated
+}
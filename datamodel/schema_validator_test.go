@@ -0,0 +1,74 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func validateForTest(t *testing.T, v *SchemaValidator, schema *yaml.Node, value string) []string {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &node); err != nil {
+		t.Fatal(err)
+	}
+	var messages []string
+	for _, e := range v.Validate(schema, &node) {
+		messages = append(messages, e.Path+": "+e.Message)
+	}
+	return messages
+}
+
+func TestSchemaValidator_Validate(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`type: object
+properties:
+  size:
+    type: number
+    multipleOf: 0.5
+  notes:
+    type: string
+    nullable: true
+  pair:
+    type: array
+    prefixItems:
+      - type: string
+      - type: integer
+    items: false
+  tags:
+    type: array
+    contains:
+      const: pizza
+    maxContains: 1
+  owner:
+    $ref: '#/$defs/Owner'
+dependentRequired:
+  size: [owner]
+$defs:
+  Owner:
+    type: string`), &root)
+	v := NewSchemaValidator(&root)
+
+	assert.Empty(t, validateForTest(t, v, &root, "{size: 1.5, notes: null, pair: [a, 1], tags: [pizza], owner: me}"))
+	assert.Equal(t, []string{
+		"$: missing property 'owner', required by property 'size'",
+		"$.size: value 1.2 is not a multiple of 0.5",
+		"$.pair[1]: expected type 'integer', but got 'string'",
+		"$.pair[2]: additional array items are not allowed",
+		"$.tags: array has 2 items matching 'contains', expected 1 to 1",
+	}, validateForTest(t, v, &root, "{size: 1.2, pair: [a, b, c], tags: [pizza, pizza]}"))
+	assert.Equal(t, []string{
+		"$.owner: expected type 'string', but got 'integer'",
+	}, validateForTest(t, v, &root, "{owner: 1}"))
+
+	// references are resolved with Resolve first.
+	v.Resolve = func(ref string) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "false"}
+	}
+	assert.Equal(t, []string{
+		"$.owner: value is not allowed",
+	}, validateForTest(t, v, &root, "{owner: me}"))
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"crypto/sha256"
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SharedResolutionCache shares the node trees of the files referenced by documents, and the subtrees that references
+// are resolved to in them, between documents. Files are identified by the SHA-256 hash of their bytes, and subtrees
+// by the hash of their file and their JSON pointer, so when many documents reference the same common files, the
+// files are parsed, and their subtrees located, only once.
+//
+// Shared trees are copy-on-write, they are never changed, a document that resolves its references (which changes
+// the node trees, see index.Rolodex.Resolve) copies the shared trees it uses first. Files with merge keys are not
+// shared, because building models merges them into the tree. Changing a node of a shared tree in place (like
+// low.NodeReference.SetValue does) fails with ErrSharedNode, see IsSharedNode. A cache is safe for concurrent use,
+// see DocumentConfiguration.SharedResolutionCache.
+type SharedResolutionCache struct {
+	trees    sync.Map // [32]byte -> *yaml.Node
+	hashes   sync.Map // *yaml.Node -> [32]byte
+	subtrees sync.Map // sharedSubtreeKey -> *yaml.Node
+	hits     atomic.Int64
+}
+
+// ErrSharedNode is returned when changing a node of a tree shared with other documents, which would change the
+// other documents too.
+var ErrSharedNode = NewCodedError("shared-node",
+	"unable to change the node, it is shared with other documents by a SharedResolutionCache")
+
+// sharedNodes holds every node of every tree shared by a cache, by the cache sharing it.
+var sharedNodes sync.Map // *yaml.Node -> *SharedResolutionCache
+
+// IsSharedNode returns true if a node is a part of a tree shared with other documents by a SharedResolutionCache. The
+// node must not be changed, the shared trees of the rolodex holding it need to be copied first (see
+// index.Rolodex.DetachSharedTrees), and the models using them built again.
+func IsSharedNode(node *yaml.Node) bool {
+	_, ok := sharedNodes.Load(node)
+	return ok
+}
+
+type sharedSubtreeKey struct {
+	hash    [32]byte
+	pointer string
+}
+
+// NewSharedResolutionCache creates an empty SharedResolutionCache.
+func NewSharedResolutionCache() *SharedResolutionCache {
+	return new(SharedResolutionCache)
+}
+
+// Tree returns the node tree of the bytes of a file. If the same bytes have been parsed before, the tree parsed then
+// is returned, and it must not be changed, otherwise the bytes are parsed with parse. The returned bool is true if
+// the tree is shared.
+func (c *SharedResolutionCache) Tree(data []byte, parse func() (*yaml.Node, error)) (*yaml.Node, bool, error) {
+	hash := sha256.Sum256(data)
+	if root, ok := c.trees.Load(hash); ok {
+		c.hits.Add(1)
+		return root.(*yaml.Node), true, nil
+	}
+	root, err := parse()
+	if err != nil || root == nil || hasMergeKeys(root, make(map[*yaml.Node]bool)) {
+		return root, false, err
+	}
+	if existing, loaded := c.trees.LoadOrStore(hash, root); loaded {
+		c.hits.Add(1)
+		return existing.(*yaml.Node), true, nil
+	}
+	c.hashes.Store(root, hash)
+	c.markShared(root)
+	return root, true, nil
+}
+
+// markShared marks every node of a shared tree, see IsSharedNode.
+func (c *SharedResolutionCache) markShared(n *yaml.Node) {
+	if n == nil {
+		return
+	}
+	if _, loaded := sharedNodes.LoadOrStore(n, c); loaded {
+		return
+	}
+	for _, child := range n.Content {
+		c.markShared(child)
+	}
+}
+
+// IsShared returns true if a node tree is shared by the cache.
+func (c *SharedResolutionCache) IsShared(root *yaml.Node) bool {
+	_, ok := c.hashes.Load(root)
+	return ok
+}
+
+// Subtree returns the node a JSON pointer locates in a shared tree. If the node has been located before, that node is
+// returned, otherwise it is located with find. Nodes that are not found are not cached, and neither are nodes of
+// trees that are not shared.
+func (c *SharedResolutionCache) Subtree(root *yaml.Node, pointer string, find func() *yaml.Node) *yaml.Node {
+	hash, ok := c.hashes.Load(root)
+	if !ok {
+		return find()
+	}
+	key := sharedSubtreeKey{hash.([32]byte), pointer}
+	if n, found := c.subtrees.Load(key); found {
+		c.hits.Add(1)
+		return n.(*yaml.Node)
+	}
+	n := find()
+	if n != nil {
+		c.subtrees.Store(key, n)
+	}
+	return n
+}
+
+// Len returns the number of trees shared.
+func (c *SharedResolutionCache) Len() int {
+	count := 0
+	c.trees.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// Hits returns the number of times a tree or a subtree has been shared instead of parsed or located again.
+func (c *SharedResolutionCache) Hits() int64 {
+	return c.hits.Load()
+}
+
+// Clear removes every tree and subtree from the cache, documents that share them keep them. The nodes of the trees
+// are no longer reported by IsSharedNode.
+func (c *SharedResolutionCache) Clear() {
+	c.trees.Clear()
+	c.hashes.Clear()
+	c.subtrees.Clear()
+	sharedNodes.Range(func(n, cache any) bool {
+		if cache == c {
+			sharedNodes.Delete(n)
+		}
+		return true
+	})
+}
+
+// hasMergeKeys returns true if a node tree has a merge key anywhere.
+func hasMergeKeys(n *yaml.Node, seen map[*yaml.Node]bool) bool {
+	if n == nil || seen[n] {
+		return false
+	}
+	seen[n] = true
+	if n.Tag == "!!merge" {
+		return true
+	}
+	for _, c := range n.Content {
+		if hasMergeKeys(c, seen) {
+			return true
+		}
+	}
+	return false
+}
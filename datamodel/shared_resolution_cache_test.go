@@ -0,0 +1,82 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSharedResolutionCache(t *testing.T) {
+	cache := NewSharedResolutionCache()
+	data := []byte("components:\n  schemas:\n    Pet:\n      type: object")
+	parsed := 0
+	parse := func() (*yaml.Node, error) {
+		parsed++
+		var root yaml.Node
+		err := yaml.Unmarshal(data, &root)
+		return &root, err
+	}
+
+	first, shared, err := cache.Tree(data, parse)
+	require.NoError(t, err)
+	assert.True(t, shared)
+	second, shared, err := cache.Tree(append([]byte(nil), data...), parse)
+	require.NoError(t, err)
+	assert.True(t, shared)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, parsed)
+	assert.Equal(t, 1, cache.Len())
+	assert.True(t, cache.IsShared(first))
+	assert.False(t, cache.IsShared(&yaml.Node{}))
+	assert.True(t, IsSharedNode(first.Content[0].Content[1]))
+	assert.False(t, IsSharedNode(&yaml.Node{}))
+
+	// subtrees are located once.
+	located := 0
+	find := func() *yaml.Node {
+		located++
+		return first.Content[0].Content[1].Content[1]
+	}
+	pet := cache.Subtree(first, "#/components/schemas/Pet", find)
+	assert.Same(t, pet, cache.Subtree(second, "#/components/schemas/Pet", find))
+	assert.Equal(t, 1, located)
+	assert.Equal(t, int64(2), cache.Hits())
+
+	// nodes that are not found, and nodes of trees that are not shared, are not cached.
+	assert.Nil(t, cache.Subtree(first, "#/nope", func() *yaml.Node { return nil }))
+	cache.Subtree(&yaml.Node{}, "#/components", find)
+	cache.Subtree(&yaml.Node{}, "#/components", find)
+	assert.Equal(t, 3, located)
+
+	cache.Clear()
+	assert.Equal(t, 0, cache.Len())
+	assert.False(t, cache.IsShared(first))
+	assert.False(t, IsSharedNode(first.Content[0].Content[1]))
+}
+
+func TestSharedResolutionCache_NotShared(t *testing.T) {
+	cache := NewSharedResolutionCache()
+
+	_, shared, err := cache.Tree([]byte("bad"), func() (*yaml.Node, error) { return nil, errors.New("bad") })
+	assert.Error(t, err)
+	assert.False(t, shared)
+
+	// trees with merge keys are changed when models are built, they are not shared.
+	data := []byte("base: &base\n  type: object\npet:\n  <<: *base")
+	root, shared, err := cache.Tree(data, func() (*yaml.Node, error) {
+		var root yaml.Node
+		err := yaml.Unmarshal(data, &root)
+		return &root, err
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, root)
+	assert.False(t, shared)
+	assert.Equal(t, 0, cache.Len())
+	assert.False(t, IsSharedNode(root))
+}
@@ -4,6 +4,7 @@
 package datamodel
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +21,10 @@ const (
 
 // SpecInfo represents a 'ready-to-process' OpenAPI Document. The RootNode is the most important property
 // used by the library, this contains the top of the document tree that every single low model is based off.
+//
+// When the configuration sets LazySpecJSON, only one copy of the specification is held, SpecBytes. SpecJSONBytes
+// shares it when the specification is JSON and is nil when it is YAML, SpecJSON is nil. GetSpecJSONBytes and
+// GetSpecJSON work them out when they are needed.
 type SpecInfo struct {
 	SpecType            string                  `json:"type"`
 	NumLines            int                     `json:"numLines"`
@@ -29,16 +34,35 @@ type SpecInfo struct {
 	SpecFileType        string                  `json:"fileType"`
 	SpecBytes           *[]byte                 `json:"bytes"` // the original byte array
 	RootNode            *yaml.Node              `json:"-"`     // reference to the root node of the spec.
-	SpecJSONBytes       *[]byte                 `json:"-"`     // original bytes converted to JSON
-	SpecJSON            *map[string]interface{} `json:"-"`     // standard JSON map of original bytes
+	SpecJSONBytes       *[]byte                 `json:"-"`     // original bytes converted to JSON, see GetSpecJSONBytes
+	SpecJSON            *map[string]interface{} `json:"-"`     // standard JSON map of original bytes, see GetSpecJSON
 	Error               error                   `json:"-"`     // something go wrong?
 	APISchema           string                  `json:"-"`     // API Schema for supplied spec type (2 or 3)
 	Generated           time.Time               `json:"-"`
 	OriginalIndentation int                     `json:"-"` // the original whitespace
 }
 
+// ExtractSpecInfoWithConfig is the same as ExtractSpecInfoWithDocumentCheck, except the document check is bypassed if
+// the configuration sets BypassDocumentCheck, and the parsing limits of the configuration are enforced
+// (MaxDocumentSize, MaxNodeCount, MaxAliasExpansion and MaxNestingDepth). The configuration may be nil.
 func ExtractSpecInfoWithConfig(spec []byte, config *DocumentConfiguration) (*SpecInfo, error) {
-	return ExtractSpecInfoWithDocumentCheck(spec, config.BypassDocumentCheck)
+	if config == nil {
+		return ExtractSpecInfoWithDocumentCheck(spec, false)
+	}
+	if config.MaxDocumentSize > 0 && int64(len(spec)) > config.MaxDocumentSize {
+		config.ReportErrors(ErrDocumentTooLarge)
+		return nil, ErrDocumentTooLarge
+	}
+	info, err := extractSpecInfo(spec, config.BypassDocumentCheck, config)
+	if err == nil && config.OnSpecInfoExtracted != nil {
+		if err = config.OnSpecInfoExtracted(info); err != nil {
+			info = nil
+		}
+	}
+	if err != nil {
+		config.ReportErrors(err)
+	}
+	return info, err
 }
 
 // ExtractSpecInfoWithDocumentCheckSync accepts an OpenAPI/Swagger specification that has been read into a byte array
@@ -56,6 +80,10 @@ func ExtractSpecInfoWithDocumentCheckSync(spec []byte, bypass bool) (*SpecInfo,
 // and will return a SpecInfo pointer, which contains details on the version and an un-marshaled
 // ensures the document is an OpenAPI document.
 func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, error) {
+	return extractSpecInfo(spec, bypass, nil)
+}
+
+func extractSpecInfo(spec []byte, bypass bool, config *DocumentConfiguration) (*SpecInfo, error) {
 	var parsedSpec yaml.Node
 
 	specInfo := &SpecInfo{}
@@ -63,32 +91,45 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 	// set original bytes
 	specInfo.SpecBytes = &spec
 
-	stringSpec := string(spec)
-	runes := []rune(strings.TrimSpace(stringSpec))
-	if len(runes) <= 0 {
+	// the spec is scanned as bytes, a string of it would be another copy of the whole spec.
+	trimmed := bytes.TrimSpace(spec)
+	if len(trimmed) <= 0 {
 		return specInfo, errors.New("there is nothing in the spec, it's empty - so there is nothing to be done")
 	}
 
-	if runes[0] == '{' && runes[len(runes)-1] == '}' {
+	if trimmed[0] == '{' && trimmed[len(trimmed)-1] == '}' {
 		specInfo.SpecFileType = JSONFileType
 	} else {
 		specInfo.SpecFileType = YAMLFileType
 	}
 
-	specInfo.NumLines = strings.Count(stringSpec, "\n") + 1
+	specInfo.NumLines = bytes.Count(spec, []byte("\n")) + 1
 
-	err := yaml.Unmarshal(spec, &parsedSpec)
+	err := config.GetYAMLEngine().Unmarshal(spec, &parsedSpec)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse specification: %s", err.Error())
 	}
 
+	// the limits are checked before anything expands aliases, like decoding the JSON map.
+	if err = config.GetParseLimits().Check(&parsedSpec); err != nil {
+		return nil, err
+	}
+
 	specInfo.RootNode = &parsedSpec
 
 	_, openAPI3 := utils.FindKeyNode(utils.OpenApi3, parsedSpec.Content)
 	_, openAPI2 := utils.FindKeyNode(utils.OpenApi2, parsedSpec.Content)
 	_, asyncAPI := utils.FindKeyNode(utils.AsyncApi, parsedSpec.Content)
 
+	lazyJSON := config != nil && config.LazySpecJSON
 	parseJSON := func(bytes []byte, spec *SpecInfo, parsedNode *yaml.Node) {
+		if lazyJSON {
+			// JSON bytes are the original bytes, anything else is worked out when it is needed.
+			if spec.SpecFileType == JSONFileType {
+				spec.SpecJSONBytes = &bytes
+			}
+			return
+		}
 		var jsonSpec map[string]interface{}
 		if utils.IsYAML(string(bytes)) {
 			_ = parsedNode.Decode(&jsonSpec)
@@ -197,8 +238,17 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 	}
 
 	if specInfo.SpecType == "" {
-		// parse JSON
-		if !bypass {
+		// name the type of document, if it's one we know about.
+		if detected, version := detectDocumentType(&parsedSpec); detected != "" {
+			specInfo.SpecType = detected
+			specInfo.Version = version
+			if !bypass {
+				parseJSON(spec, specInfo, &parsedSpec)
+				specInfo.Error = &UnsupportedDocumentType{Type: detected, Version: version}
+				return specInfo, specInfo.Error
+			}
+		} else if !bypass {
+			// parse JSON
 			parseJSON(spec, specInfo, &parsedSpec)
 			parsed = true
 			specInfo.Error = errors.New("spec type not supported by libopenapi, sorry")
@@ -215,11 +265,50 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 	}
 
 	// detect the original whitespace indentation
-	specInfo.OriginalIndentation = utils.DetermineWhitespaceLength(string(spec))
+	specInfo.OriginalIndentation = utils.DetermineWhitespaceLengthBytes(spec)
 
 	return specInfo, nil
 }
 
+// GetSpecJSONBytes returns the specification as JSON. The bytes are SpecJSONBytes when it is set, otherwise they are
+// rendered from the root node every time, and are not kept.
+func (si *SpecInfo) GetSpecJSONBytes() *[]byte {
+	if si.SpecJSONBytes != nil {
+		return si.SpecJSONBytes
+	}
+	jsonSpec := si.GetSpecJSON()
+	if jsonSpec == nil {
+		return nil
+	}
+	b, err := json.Marshal(jsonSpec)
+	if err != nil {
+		return nil
+	}
+	return &b
+}
+
+// GetSpecJSON returns the specification as a standard JSON map. The map is SpecJSON when it is set, otherwise it is
+// decoded every time, and is not kept.
+func (si *SpecInfo) GetSpecJSON() *map[string]interface{} {
+	if si.SpecJSON != nil {
+		return si.SpecJSON
+	}
+	var jsonSpec map[string]interface{}
+	if si.SpecJSONBytes != nil {
+		if err := json.Unmarshal(*si.SpecJSONBytes, &jsonSpec); err != nil {
+			return nil
+		}
+		return &jsonSpec
+	}
+	if si.RootNode == nil {
+		return nil
+	}
+	if err := si.RootNode.Decode(&jsonSpec); err != nil {
+		return nil
+	}
+	return &jsonSpec
+}
+
 // ExtractSpecInfo accepts an OpenAPI/Swagger specification that has been read into a byte array
 // and will return a SpecInfo pointer, which contains details on the version and an un-marshaled
 // *yaml.Node root node tree. The root node tree is what's used by the library when building out models.
@@ -0,0 +1,218 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// binarySpecInfo is how a SpecInfo is encoded by MarshalBinary.
+type binarySpecInfo struct {
+	SpecType            string
+	NumLines            int
+	Version             string
+	VersionNumeric      float32
+	SpecFormat          string
+	SpecFileType        string
+	SpecBytes           []byte
+	SpecJSONBytes       []byte
+	SharedJSONBytes     bool // the JSON bytes are the spec bytes, which are not encoded twice.
+	Generated           time.Time
+	OriginalIndentation int
+	Nodes               []binaryNode
+}
+
+// binaryNode is an encoded yaml.Node. Content and Alias hold the positions of other nodes in the list of nodes,
+// Alias is -1 if the node is not an alias.
+type binaryNode struct {
+	Kind        yaml.Kind
+	Style       yaml.Style
+	Tag         string
+	Value       string
+	Anchor      string
+	Alias       int
+	Content     []int
+	HeadComment string
+	LineComment string
+	FootComment string
+	Line        int
+	Column      int
+}
+
+// MarshalBinary encodes the SpecInfo (including its whole node tree, with every line, column, comment, anchor and
+// alias) so it can be cached, and decoded with UnmarshalBinary, which is much faster than parsing the specification
+// again. SpecInfo implements encoding.BinaryMarshaler, so it can also be encoded with encoding/gob. The Error of the
+// SpecInfo is not encoded.
+//
+// Only the SpecInfo is encoded, the index and the models of a document are not: a document created from a decoded
+// SpecInfo (see libopenapi.NewDocumentFromSpecInfo) skips parsing, but still indexes the specification and builds
+// its models.
+func (si *SpecInfo) MarshalBinary() ([]byte, error) {
+	b := binarySpecInfo{
+		SpecType:            si.SpecType,
+		NumLines:            si.NumLines,
+		Version:             si.Version,
+		VersionNumeric:      si.VersionNumeric,
+		SpecFormat:          si.SpecFormat,
+		SpecFileType:        si.SpecFileType,
+		Generated:           si.Generated,
+		OriginalIndentation: si.OriginalIndentation,
+	}
+	if si.SpecBytes != nil {
+		b.SpecBytes = *si.SpecBytes
+	}
+	if si.SpecJSONBytes != nil {
+		if si.SpecBytes != nil && sameBytes(*si.SpecJSONBytes, *si.SpecBytes) {
+			b.SharedJSONBytes = true
+		} else {
+			b.SpecJSONBytes = *si.SpecJSONBytes
+		}
+	}
+	if si.RootNode != nil {
+		positions := make(map[*yaml.Node]int)
+		if _, err := flattenNode(si.RootNode, positions, make(map[*yaml.Node]bool), &b.Nodes); err != nil {
+			return nil, err
+		}
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(b); err != nil {
+		return nil, fmt.Errorf("unable to encode spec info: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a SpecInfo encoded by MarshalBinary.
+func (si *SpecInfo) UnmarshalBinary(data []byte) error {
+	var b binarySpecInfo
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&b); err != nil {
+		return fmt.Errorf("unable to decode spec info: %w", err)
+	}
+	var root *yaml.Node
+	if len(b.Nodes) > 0 {
+		var err error
+		if root, err = unflattenNodes(b.Nodes); err != nil {
+			return err
+		}
+	}
+	*si = SpecInfo{
+		SpecType:            b.SpecType,
+		NumLines:            b.NumLines,
+		Version:             b.Version,
+		VersionNumeric:      b.VersionNumeric,
+		SpecFormat:          b.SpecFormat,
+		SpecFileType:        b.SpecFileType,
+		Generated:           b.Generated,
+		OriginalIndentation: b.OriginalIndentation,
+		RootNode:            root,
+	}
+	if b.SpecBytes != nil {
+		si.SpecBytes = &b.SpecBytes
+	}
+	if b.SharedJSONBytes && si.SpecBytes != nil {
+		si.SpecJSONBytes = si.SpecBytes
+	} else if b.SpecJSONBytes != nil {
+		var specJSON map[string]interface{}
+		if err := json.Unmarshal(b.SpecJSONBytes, &specJSON); err != nil {
+			return fmt.Errorf("unable to decode spec info: %w", err)
+		}
+		si.SpecJSONBytes = &b.SpecJSONBytes
+		si.SpecJSON = &specJSON
+	}
+	switch si.SpecFormat {
+	case OAS2:
+		si.APISchema = OpenAPI2SchemaData
+	case OAS3:
+		si.APISchema = OpenAPI3SchemaData
+	case OAS31:
+		si.APISchema = OpenAPI31SchemaData
+	}
+	return nil
+}
+
+// sameBytes returns true if two byte slices are the same memory.
+func sameBytes(a, b []byte) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// flattenNode adds a node, and every node in its tree, to a list of encoded nodes, and returns its position. The
+// content of a node always comes after it in the list (a node held by more than one node is added for each of them),
+// only aliases point back to nodes added before. A tree holding one of its ancestors can't be encoded.
+func flattenNode(n *yaml.Node, positions map[*yaml.Node]int, ancestors map[*yaml.Node]bool, nodes *[]binaryNode,
+) (int, error) {
+	if ancestors[n] {
+		return 0, errors.New("unable to encode spec info, a node holds one of its ancestors")
+	}
+	p := len(*nodes)
+	if _, ok := positions[n]; !ok {
+		positions[n] = p
+	}
+	*nodes = append(*nodes, binaryNode{
+		Kind: n.Kind, Style: n.Style, Tag: n.Tag, Value: n.Value, Anchor: n.Anchor, Alias: -1,
+		HeadComment: n.HeadComment, LineComment: n.LineComment, FootComment: n.FootComment,
+		Line: n.Line, Column: n.Column,
+	})
+	ancestors[n] = true
+	defer delete(ancestors, n)
+	var content []int
+	if n.Content != nil {
+		content = make([]int, len(n.Content))
+		for i, c := range n.Content {
+			var err error
+			if content[i], err = flattenNode(c, positions, ancestors, nodes); err != nil {
+				return 0, err
+			}
+		}
+	}
+	alias := -1
+	if n.Alias != nil {
+		var ok bool
+		if alias, ok = positions[n.Alias]; !ok {
+			var err error
+			if alias, err = flattenNode(n.Alias, positions, ancestors, nodes); err != nil {
+				return 0, err
+			}
+		}
+	}
+	(*nodes)[p].Content = content
+	(*nodes)[p].Alias = alias
+	return p, nil
+}
+
+// unflattenNodes rebuilds a node tree from a list of encoded nodes, the first node is the root. The content of a node
+// must come after it in the list, so a corrupt list can't make a node hold itself or one of its ancestors.
+func unflattenNodes(encoded []binaryNode) (*yaml.Node, error) {
+	nodes := make([]yaml.Node, len(encoded))
+	for i, e := range encoded {
+		n := &nodes[i]
+		n.Kind, n.Style, n.Tag, n.Value, n.Anchor = e.Kind, e.Style, e.Tag, e.Value, e.Anchor
+		n.HeadComment, n.LineComment, n.FootComment = e.HeadComment, e.LineComment, e.FootComment
+		n.Line, n.Column = e.Line, e.Column
+		if e.Alias >= 0 {
+			if e.Alias >= len(nodes) {
+				return nil, errors.New("unable to decode spec info, an alias points to a missing node")
+			}
+			n.Alias = &nodes[e.Alias]
+		}
+		if e.Content != nil {
+			n.Content = make([]*yaml.Node, len(e.Content))
+			for j, c := range e.Content {
+				if c >= len(nodes) {
+					return nil, errors.New("unable to decode spec info, a node points to a missing node")
+				}
+				if c <= i {
+					return nil, errors.New("unable to decode spec info, a node points back to a node that is not an alias")
+				}
+				n.Content[j] = &nodes[c]
+			}
+		}
+	}
+	return &nodes[0], nil
+}
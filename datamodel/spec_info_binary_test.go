@@ -0,0 +1,153 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecInfo_MarshalBinary(t *testing.T) {
+	spec := `# pizza shop
+openapi: 3.1.0
+info:
+  title: pizza   # the title
+  version: "1.0"
+components:
+  schemas:
+    Topping: &topping
+      type: string
+    Extra: *topping
+`
+	info, err := ExtractSpecInfo([]byte(spec))
+	require.NoError(t, err)
+
+	b, err := info.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded SpecInfo
+	require.NoError(t, decoded.UnmarshalBinary(b))
+	assert.Equal(t, info.SpecType, decoded.SpecType)
+	assert.Equal(t, info.Version, decoded.Version)
+	assert.Equal(t, info.VersionNumeric, decoded.VersionNumeric)
+	assert.Equal(t, info.SpecFormat, decoded.SpecFormat)
+	assert.Equal(t, info.SpecFileType, decoded.SpecFileType)
+	assert.Equal(t, info.NumLines, decoded.NumLines)
+	assert.Equal(t, info.OriginalIndentation, decoded.OriginalIndentation)
+	assert.True(t, info.Generated.Equal(decoded.Generated))
+	assert.Equal(t, *info.SpecBytes, *decoded.SpecBytes)
+	assert.Equal(t, *info.SpecJSON, *decoded.SpecJSON)
+	assert.Equal(t, OpenAPI31SchemaData, decoded.APISchema)
+
+	// the node tree renders exactly the same, with its comments and alias.
+	original, _ := yaml.Marshal(info.RootNode)
+	rendered, _ := yaml.Marshal(decoded.RootNode)
+	assert.Equal(t, string(original), string(rendered))
+
+	schemas := decoded.RootNode.Content[0].Content[5].Content[1]
+	assert.Equal(t, 8, schemas.Content[1].Line)
+	assert.Equal(t, yaml.AliasNode, schemas.Content[3].Kind)
+	assert.Same(t, schemas.Content[1], schemas.Content[3].Alias)
+}
+
+func TestSpecInfo_MarshalBinary_Gob(t *testing.T) {
+	info, err := ExtractSpecInfo([]byte(`{"swagger": "2.0", "info": {"title": "pizza"}}`))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(info))
+
+	var decoded *SpecInfo
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+	assert.Equal(t, OAS2, decoded.SpecFormat)
+	assert.Equal(t, JSONFileType, decoded.SpecFileType)
+	assert.Equal(t, OpenAPI2SchemaData, decoded.APISchema)
+	assert.Equal(t, "pizza", decoded.RootNode.Content[0].Content[3].Content[1].Value)
+
+	// the JSON bytes are the spec bytes, they are only encoded once.
+	assert.Same(t, &(*decoded.SpecBytes)[0], &(*decoded.SpecJSONBytes)[0])
+}
+
+func TestSpecInfo_MarshalBinary_LazySpecJSON(t *testing.T) {
+	info, err := ExtractSpecInfoWithConfig([]byte("openapi: 3.1.0\ninfo:\n  title: pizza"),
+		&DocumentConfiguration{LazySpecJSON: true})
+	require.NoError(t, err)
+
+	b, err := info.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded SpecInfo
+	require.NoError(t, decoded.UnmarshalBinary(b))
+	assert.Nil(t, decoded.SpecJSONBytes)
+	assert.Equal(t, *info.GetSpecJSON(), *decoded.GetSpecJSON())
+}
+
+func TestSpecInfo_UnmarshalBinary_Error(t *testing.T) {
+	var decoded SpecInfo
+	assert.Error(t, decoded.UnmarshalBinary([]byte("not a spec")))
+
+	for _, nodes := range [][]binaryNode{
+		{{Kind: yaml.AliasNode, Alias: 4}},
+		{{Kind: yaml.MappingNode, Alias: -1, Content: []int{-2}}},
+	} {
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(binarySpecInfo{Nodes: nodes}))
+		assert.Error(t, decoded.UnmarshalBinary(buf.Bytes()))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(binarySpecInfo{SpecJSONBytes: []byte("{")}))
+	assert.Error(t, decoded.UnmarshalBinary(buf.Bytes()))
+}
+
+func TestSpecInfo_MarshalBinary_SharedAndCyclic(t *testing.T) {
+	info, err := ExtractSpecInfo([]byte("openapi: 3.1.0\ninfo:\n  title: pizza"))
+	require.NoError(t, err)
+
+	// a node held twice is encoded twice.
+	root := info.RootNode.Content[0]
+	root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "x-info"}, root.Content[3])
+	b, err := info.MarshalBinary()
+	require.NoError(t, err)
+	var decoded SpecInfo
+	require.NoError(t, decoded.UnmarshalBinary(b))
+	decodedRoot := decoded.RootNode.Content[0]
+	assert.Equal(t, "pizza", decodedRoot.Content[5].Content[1].Value)
+	assert.NotSame(t, decodedRoot.Content[3], decodedRoot.Content[5])
+
+	// a node holding its ancestor can't be encoded.
+	info.RootNode.Content[0].Content[3].Content = append(info.RootNode.Content[0].Content[3].Content, root)
+	_, err = info.MarshalBinary()
+	assert.EqualError(t, err, "unable to encode spec info, a node holds one of its ancestors")
+}
+
+func TestSpecInfo_UnmarshalBinary_Corrupt(t *testing.T) {
+	decode := func(nodes []binaryNode) error {
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(binarySpecInfo{Nodes: nodes}))
+		var decoded SpecInfo
+		return decoded.UnmarshalBinary(buf.Bytes())
+	}
+
+	// a node holding itself, or a node before it, is rejected.
+	assert.EqualError(t, decode([]binaryNode{{Kind: yaml.DocumentNode, Alias: -1, Content: []int{0}}}),
+		"unable to decode spec info, a node points back to a node that is not an alias")
+	assert.EqualError(t, decode([]binaryNode{
+		{Kind: yaml.DocumentNode, Alias: -1, Content: []int{1}},
+		{Kind: yaml.MappingNode, Alias: -1, Content: []int{0}},
+	}), "unable to decode spec info, a node points back to a node that is not an alias")
+	assert.EqualError(t, decode([]binaryNode{{Kind: yaml.DocumentNode, Alias: -1, Content: []int{2}}}),
+		"unable to decode spec info, a node points to a missing node")
+
+	// an alias can point back.
+	assert.NoError(t, decode([]binaryNode{
+		{Kind: yaml.DocumentNode, Alias: -1, Content: []int{1}},
+		{Kind: yaml.AliasNode, Alias: 0},
+	}))
+}
@@ -281,6 +281,54 @@ func TestExtractSpecInfo_BadVersion_AsyncAPI(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestExtractSpecInfo_UnsupportedDocumentType(t *testing.T) {
+	tests := []struct {
+		spec, specType, version, message string
+	}{
+		{
+			spec:     "arazzo: 1.0.1\ninfo:\n  title: pizza\nworkflows: []",
+			specType: utils.Arazzo,
+			version:  "1.0.1",
+			message:  "the document is Arazzo 1.0.1, not OpenAPI or Swagger",
+		},
+		{
+			spec:     `{"$schema": "http://json-schema.org/draft-07/schema#", "type": "object"}`,
+			specType: utils.JSONSchema,
+			version:  "draft 07",
+			message:  "the document is JSON Schema draft 07, not OpenAPI or Swagger",
+		},
+		{
+			spec:     `{"$schema": "https://json-schema.org/draft/2020-12/schema", "$defs": {}}`,
+			specType: utils.JSONSchema,
+			version:  "draft 2020-12",
+		},
+		{
+			spec:     "type: object\nproperties:\n  topping:\n    type: string",
+			specType: utils.JSONSchema,
+			message:  "the document is JSON Schema, not OpenAPI or Swagger",
+		},
+	}
+	for _, tc := range tests {
+		info, err := ExtractSpecInfo([]byte(tc.spec))
+		var unsupported *UnsupportedDocumentType
+		if assert.ErrorAs(t, err, &unsupported, tc.spec) {
+			assert.Equal(t, tc.specType, unsupported.Type)
+			assert.Equal(t, tc.version, unsupported.Version)
+			assert.ErrorContains(t, err, tc.message)
+		}
+		assert.Equal(t, tc.specType, info.SpecType)
+		assert.Equal(t, tc.version, info.Version)
+
+		// the type is still detected when the document check is bypassed.
+		info, err = ExtractSpecInfoWithDocumentCheck([]byte(tc.spec), true)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.specType, info.SpecType)
+	}
+
+	_, err := ExtractSpecInfo([]byte("pizza: yummy"))
+	assert.EqualError(t, err, "spec type not supported by libopenapi, sorry")
+}
+
 func ExampleExtractSpecInfo() {
 	// load bytes from openapi spec file.
 	bytes, _ := os.ReadFile("../test_specs/petstorev3.json")
@@ -304,3 +352,71 @@ func TestExtractSpecInfoSync_Error(t *testing.T) {
 	_, e := ExtractSpecInfoWithDocumentCheckSync([]byte(random), true)
 	assert.Error(t, e)
 }
+
+func TestExtractSpecInfoWithConfig_Hooks(t *testing.T) {
+	var extracted *SpecInfo
+	var reported []error
+	config := &DocumentConfiguration{
+		OnSpecInfoExtracted: func(info *SpecInfo) error {
+			extracted = info
+			return nil
+		},
+		OnError: func(err error) {
+			reported = append(reported, err)
+		},
+	}
+	info, err := ExtractSpecInfoWithConfig([]byte("openapi: 3.1.0"), config)
+	assert.NoError(t, err)
+	assert.Same(t, info, extracted)
+	assert.Empty(t, reported)
+
+	// the hook can reject a specification.
+	config.OnSpecInfoExtracted = func(info *SpecInfo) error {
+		return fmt.Errorf("version %s is not allowed", info.Version)
+	}
+	info, err = ExtractSpecInfoWithConfig([]byte("openapi: 3.1.0"), config)
+	assert.EqualError(t, err, "version 3.1.0 is not allowed")
+	assert.Nil(t, info)
+	assert.Equal(t, []error{err}, reported)
+
+	_, err = ExtractSpecInfoWithConfig([]byte("pizza: 1.0"), config)
+	assert.Error(t, err)
+	assert.Len(t, reported, 2)
+	assert.Equal(t, err, reported[1])
+}
+
+func TestExtractSpecInfoWithConfig_LazySpecJSON(t *testing.T) {
+	config := &DocumentConfiguration{LazySpecJSON: true}
+
+	// a YAML spec holds no JSON copy, it is worked out when it is needed.
+	r, e := ExtractSpecInfoWithConfig([]byte(OpenApi3Spec), config)
+	assert.NoError(t, e)
+	assert.Nil(t, r.SpecJSONBytes)
+	assert.Nil(t, r.SpecJSON)
+	assert.Equal(t, "3.0.1", (*r.GetSpecJSON())["openapi"])
+	assert.Contains(t, string(*r.GetSpecJSONBytes()), `"openapi":"3.0.1"`)
+	assert.Equal(t, 2, r.OriginalIndentation)
+
+	// a JSON spec shares its bytes.
+	spec := []byte(`{"openapi": "3.1.0", "info": {"title": "pizza"}}`)
+	r, e = ExtractSpecInfoWithConfig(spec, config)
+	assert.NoError(t, e)
+	assert.Equal(t, JSONFileType, r.SpecFileType)
+	assert.Same(t, &spec[0], &(*r.SpecJSONBytes)[0])
+	assert.Same(t, &(*r.SpecBytes)[0], &(*r.SpecJSONBytes)[0])
+	assert.Nil(t, r.SpecJSON)
+	assert.Equal(t, "3.1.0", (*r.GetSpecJSON())["openapi"])
+
+	// nothing to work out.
+	assert.Nil(t, (&SpecInfo{}).GetSpecJSON())
+	assert.Nil(t, (&SpecInfo{}).GetSpecJSONBytes())
+}
+
+func TestExtractSpecInfo_SpecJSON(t *testing.T) {
+	r, e := ExtractSpecInfo([]byte(OpenApi3Spec))
+	assert.NoError(t, e)
+	assert.Greater(t, len(*r.SpecJSONBytes), 0)
+	assert.Equal(t, "3.0.1", (*r.SpecJSON)["openapi"])
+	assert.Same(t, r.SpecJSON, r.GetSpecJSON())
+	assert.Same(t, r.SpecJSONBytes, r.GetSpecJSONBytes())
+}
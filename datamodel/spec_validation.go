@@ -0,0 +1,83 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError represents a single violation of a JSON Schema, such as the OpenAPI (or Swagger) meta-schema, found
+// in a value, such as a specification.
+type ValidationError struct {
+	// Message is a human-readable description of the violation.
+	Message string
+
+	// Path is the JSON path to the offending value, for example $.paths['/pets'].get
+	Path string
+
+	// Node is the yaml.Node of the offending value (or key, for properties that are not allowed).
+	Node *yaml.Node
+
+	// Line and Column of the offending node.
+	Line   int
+	Column int
+}
+
+// Error returns a readable version of the violation, so it can be used as an error.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s (line %d, col %d)", e.Path, e.Message, e.Line, e.Column)
+}
+
+var (
+	metaSchemas     = make(map[string]*SchemaValidator)
+	metaSchemasLock sync.Mutex
+)
+
+// Validate checks the specification against the OpenAPI (or Swagger) meta-schema for its version (APISchema), and
+// returns every violation found, ordered by position. No violations means the specification is structurally valid.
+//
+// The meta-schema is evaluated by a SchemaValidator, formats are not validated, and references to schemas outside
+// the meta-schema are accepted as valid. An error is returned if there is no specification or meta-schema to
+// validate with.
+func (si *SpecInfo) Validate() ([]*ValidationError, error) {
+	if si == nil || si.RootNode == nil {
+		return nil, errors.New("unable to validate, no specification has been loaded")
+	}
+	if si.APISchema == "" {
+		return nil, fmt.Errorf("unable to validate, there is no meta-schema for specification version '%s'",
+			si.Version)
+	}
+	v, err := loadMetaSchema(si.APISchema)
+	if err != nil {
+		return nil, err
+	}
+	errs := v.Validate(v.root, si.RootNode)
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+		return errs[i].Column < errs[j].Column
+	})
+	return errs, nil
+}
+
+func loadMetaSchema(data string) (*SchemaValidator, error) {
+	metaSchemasLock.Lock()
+	defer metaSchemasLock.Unlock()
+	if v, ok := metaSchemas[data]; ok {
+		return v, nil
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(data), &root); err != nil {
+		return nil, fmt.Errorf("unable to read meta-schema: %w", err)
+	}
+	v := NewSchemaValidator(root.Content[0])
+	metaSchemas[data] = v
+	return v, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecInfo_Validate(t *testing.T) {
+	for _, f := range []string{"petstorev3.json", "petstorev2.json"} {
+		spec, _ := os.ReadFile("../test_specs/" + f)
+		info, err := ExtractSpecInfo(spec)
+		require.NoError(t, err)
+
+		errs, err := info.Validate()
+		require.NoError(t, err, f)
+		assert.Empty(t, errs, f)
+	}
+}
+
+func TestSpecInfo_Validate_OpenAPI31(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: OK
+        "600":
+          description: not a status code
+      parameters:
+        - $ref: '#/components/parameters/Size'
+        - name: topping
+          schema:
+            type: string
+    pizza: true
+components:
+  parameters:
+    Size:
+      name: size
+      in: query
+      schema:
+        type: string
+      content:
+        application/json: {}`
+
+	info, err := ExtractSpecInfo([]byte(spec))
+	require.NoError(t, err)
+
+	errs, err := info.Validate()
+	require.NoError(t, err)
+	require.Len(t, errs, 5)
+
+	assert.Equal(t, "$.info", errs[0].Path)
+	assert.Equal(t, "missing required property 'title'", errs[0].Message)
+	assert.Equal(t, 3, errs[0].Line)
+	assert.Equal(t, 3, errs[0].Column)
+
+	assert.Equal(t, "$.paths['/pizza'].get.responses['600']", errs[1].Path)
+	assert.Equal(t, "property '600' is not allowed", errs[1].Message)
+	assert.Equal(t, 10, errs[1].Line)
+
+	assert.Equal(t, "$.paths['/pizza'].get.parameters[1]", errs[2].Path)
+	assert.Equal(t, "missing required property 'in'", errs[2].Message)
+
+	assert.Equal(t, "$.paths['/pizza'].pizza", errs[3].Path)
+	assert.Equal(t, "$.paths['/pizza'].pizza: property 'pizza' is not allowed (line 17, col 5)", errs[3].Error())
+
+	assert.Equal(t, "$.components.parameters.Size", errs[4].Path)
+	assert.Equal(t, "value must match exactly one oneOf schema, but matched 2", errs[4].Message)
+}
+
+func TestSpecInfo_Validate_OpenAPI3(t *testing.T) {
+	spec := `{
+  "openapi": "3.0.3",
+  "info": {"title": "pizza", "version": "1.0.0"},
+  "paths": {
+    "/pizza": {
+      "get": {
+        "parameters": [{"$ref": "#/components/parameters/Size"}, {"name": "size", "in": "cookies", "schema": {"type": "string"}}],
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  },
+  "x-pizza": {"anything": "goes"},
+  "servers": [{"url": 123}]
+}`
+
+	info, err := ExtractSpecInfo([]byte(spec))
+	require.NoError(t, err)
+
+	errs, err := info.Validate()
+	require.NoError(t, err)
+	require.Len(t, errs, 2)
+
+	assert.Equal(t, "$.paths['/pizza'].get.parameters[1].in", errs[0].Path)
+	assert.Equal(t, "value 'cookies' is not one of the allowed values 'cookie'", errs[0].Message)
+	assert.Equal(t, 7, errs[0].Line)
+	assert.Equal(t, "$.servers[0].url", errs[1].Path)
+	assert.Equal(t, "expected type 'string', but got 'integer'", errs[1].Message)
+}
+
+func TestSpecInfo_Validate_Swagger(t *testing.T) {
+	spec, _ := os.ReadFile("../test_specs/petstorev2-complete.yaml")
+	info, err := ExtractSpecInfo(spec)
+	require.NoError(t, err)
+
+	errs, err := info.Validate()
+	require.NoError(t, err)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "$.paths['/user'].borked", errs[0].Path)
+	assert.Equal(t, "$.externalPaths", errs[1].Path)
+}
+
+func TestSpecInfo_Validate_NoSchema(t *testing.T) {
+	_, err := (*SpecInfo)(nil).Validate()
+	assert.Error(t, err)
+
+	info, err := ExtractSpecInfoWithDocumentCheck([]byte("pizza: cake"), true)
+	require.NoError(t, err)
+	_, err = info.Validate()
+	assert.EqualError(t, err, "unable to validate, there is no meta-schema for specification version ''")
+}
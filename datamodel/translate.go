@@ -6,8 +6,10 @@ import (
 	"io"
 	"runtime"
 	"sync"
+	"sync/atomic"
 
 	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
 )
 
 type (
@@ -24,6 +26,49 @@ type continueError struct {
 
 var Continue = &continueError{error: errors.New("Continue")}
 
+var translateWorkers atomic.Int64
+
+func init() {
+	translateWorkers.Store(int64(runtime.NumCPU()))
+}
+
+// SetTranslateWorkers sets the number of items (like path items, or components) that the parallel translate
+// functions translate at the same time, which is how many are built at once when building a high-level model. The
+// default is runtime.NumCPU. A limit of 1 (or less) translates everything one at a time.
+func SetTranslateWorkers(workers int) {
+	translateWorkers.Store(int64(workers))
+}
+
+// TranslateWorkers returns the number of items translated at the same time, see SetTranslateWorkers. It is never
+// more than the concurrency of the library, see utils.SetMaxConcurrency.
+func TranslateWorkers() int {
+	workers := int(max(translateWorkers.Load(), 1))
+	if limit := utils.MaxConcurrency(); limit > 0 {
+		return min(workers, limit)
+	}
+	return workers
+}
+
+// RunParallel runs every function at the same time if more than one translate worker is allowed (see
+// SetTranslateWorkers), otherwise it runs them one after another. It returns once every function has returned.
+func RunParallel(funcs ...func()) {
+	if TranslateWorkers() <= 1 {
+		for _, f := range funcs {
+			f()
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	wg.Add(len(funcs))
+	for _, f := range funcs {
+		utils.Go(func() {
+			defer wg.Done()
+			f()
+		})
+	}
+	wg.Wait()
+}
+
 type jobStatus[OUT any] struct {
 	done   chan struct{}
 	cont   bool
@@ -49,8 +94,8 @@ func TranslateSliceParallel[IN any, OUT any](in []IN, translate TranslateSliceFu
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	concurrency := runtime.NumCPU()
-	jobChan := make(chan *jobStatus[OUT], concurrency)
+	// one job is being waited on while the rest are queued, so no more than the number of workers run at once.
+	jobChan := make(chan *jobStatus[OUT], TranslateWorkers()-1)
 	var reterr error
 	var mu sync.Mutex
 	var wg sync.WaitGroup
@@ -73,7 +118,7 @@ func TranslateSliceParallel[IN any, OUT any](in []IN, translate TranslateSliceFu
 			}
 
 			wg.Add(1)
-			go func(idx int, valueIn IN) {
+			utils.Go(func() {
 				valueOut, err := translate(idx, valueIn)
 				if err == Continue {
 					j.cont = true
@@ -90,7 +135,7 @@ func TranslateSliceParallel[IN any, OUT any](in []IN, translate TranslateSliceFu
 				j.result = valueOut
 				close(j.done)
 				wg.Done()
-			}(idx, valueIn)
+			})
 		}
 	}()
 
@@ -135,9 +180,9 @@ func TranslateMapParallel[K comparable, V any, RV any](m *orderedmap.Map[K, V],
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	concurrency := runtime.NumCPU()
 	c := orderedmap.Iterate(ctx, m)
-	jobChan := make(chan *jobStatus[RV], concurrency)
+	// one job is being waited on while the rest are queued, so no more than the number of workers run at once.
+	jobChan := make(chan *jobStatus[RV], TranslateWorkers()-1)
 	var reterr error
 	var wg sync.WaitGroup
 	var mu sync.Mutex
@@ -160,7 +205,7 @@ func TranslateMapParallel[K comparable, V any, RV any](m *orderedmap.Map[K, V],
 			}
 
 			wg.Add(1)
-			go func(pair orderedmap.Pair[K, V]) {
+			utils.Go(func() {
 				value, err := translate(pair)
 				if err != nil {
 					mu.Lock()
@@ -177,7 +222,7 @@ func TranslateMapParallel[K comparable, V any, RV any](m *orderedmap.Map[K, V],
 				j.result = value
 				close(j.done)
 				wg.Done()
-			}(pair)
+			})
 		}
 	}()
 
@@ -214,7 +259,7 @@ JOBLOOP:
 func TranslatePipeline[IN any, OUT any](in <-chan IN, out chan<- OUT, translate TranslateFunc[IN, OUT]) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	concurrency := runtime.NumCPU()
+	concurrency := TranslateWorkers()
 	workChan := make(chan *pipelineJobStatus[IN, OUT])
 	resultChan := make(chan *pipelineJobStatus[IN, OUT])
 	var reterr error
@@ -546,3 +546,63 @@ func TestTranslatePipeline(t *testing.T) {
 		})
 	}
 }
+
+func TestSetTranslateWorkers(t *testing.T) {
+	defer datamodel.SetTranslateWorkers(datamodel.TranslateWorkers())
+
+	for _, workers := range []int{1, 3} {
+		datamodel.SetTranslateWorkers(workers)
+		assert.Equal(t, workers, datamodel.TranslateWorkers())
+
+		var active, peak atomic.Int64
+		translate := func() {
+			n := active.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+		}
+
+		m := orderedmap.New[int, int]()
+		in := make([]int, 20)
+		for i := range in {
+			m.Set(i, i)
+		}
+		var results []int
+		err := datamodel.TranslateMapParallel(m, func(pair orderedmap.Pair[int, int]) (int, error) {
+			translate()
+			return pair.Value(), nil
+		}, func(v int) error {
+			results = append(results, v)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Len(t, results, 20)
+		err = datamodel.TranslateSliceParallel(in, func(_ int, v int) (int, error) {
+			translate()
+			return v, nil
+		}, nil)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, peak.Load(), int64(workers))
+
+		var order []int
+		var mu sync.Mutex
+		datamodel.RunParallel(func() {
+			mu.Lock()
+			order = append(order, 1)
+			mu.Unlock()
+		}, func() {
+			mu.Lock()
+			order = append(order, 2)
+			mu.Unlock()
+		})
+		assert.ElementsMatch(t, []int{1, 2}, order)
+	}
+
+	datamodel.SetTranslateWorkers(0)
+	assert.Equal(t, 1, datamodel.TranslateWorkers())
+}
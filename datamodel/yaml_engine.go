@@ -0,0 +1,85 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"bytes"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLEngine parses specifications into yaml.Node trees, and renders yaml.Node trees as YAML. It allows a different
+// YAML implementation (a faster one, or a maintained fork) to be used in place of gopkg.in/yaml.v3, see
+// DocumentConfiguration.YAMLEngine.
+//
+// Every model is built from, and rendered into, the nodes of gopkg.in/yaml.v3, so an engine must produce and accept
+// those nodes, including their line and column numbers.
+type YAMLEngine interface {
+	// Unmarshal parses a YAML (or JSON) specification into a document node.
+	Unmarshal(data []byte, node *yaml.Node) error
+
+	// Marshal renders a node as YAML, indented by the number of spaces supplied.
+	Marshal(node *yaml.Node, indent int) ([]byte, error)
+}
+
+// YAMLStreamEngine is a YAMLEngine that can also render a node as YAML straight to a writer, so the complete YAML is
+// never held in memory as a byte slice. Engines that are not stream engines render into memory first, see EncodeYAML.
+type YAMLStreamEngine interface {
+	YAMLEngine
+
+	// Encode renders a node as YAML to w, indented by the number of spaces supplied.
+	Encode(w io.Writer, node *yaml.Node, indent int) error
+}
+
+// EncodeYAML renders a node as YAML to w with an engine, streaming it if the engine is a YAMLStreamEngine. A nil
+// engine is the DefaultYAMLEngine.
+func EncodeYAML(engine YAMLEngine, w io.Writer, node *yaml.Node, indent int) error {
+	if engine == nil {
+		engine = DefaultYAMLEngine
+	}
+	if stream, ok := engine.(YAMLStreamEngine); ok {
+		return stream.Encode(w, node, indent)
+	}
+	b, err := engine.Marshal(node, indent)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// DecodeYAML parses data into a document node with an engine. A nil engine is the DefaultYAMLEngine.
+func DecodeYAML(engine YAMLEngine, data []byte, node *yaml.Node) error {
+	if engine == nil {
+		engine = DefaultYAMLEngine
+	}
+	return engine.Unmarshal(data, node)
+}
+
+// DefaultYAMLEngine is the YAMLEngine used when a configuration does not set one, it uses gopkg.in/yaml.v3.
+var DefaultYAMLEngine YAMLEngine = yamlV3Engine{}
+
+type yamlV3Engine struct{}
+
+func (yamlV3Engine) Unmarshal(data []byte, node *yaml.Node) error {
+	return yaml.Unmarshal(data, node)
+}
+
+func (e yamlV3Engine) Marshal(node *yaml.Node, indent int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := e.Encode(&buf, node, indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (yamlV3Engine) Encode(w io.Writer, node *yaml.Node, indent int) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(indent)
+	if err := encoder.Encode(node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// countingEngine counts the specifications it parses, and fails to parse 'pineapple'.
+type countingEngine struct {
+	unmarshalled int
+}
+
+func (c *countingEngine) Unmarshal(data []byte, node *yaml.Node) error {
+	c.unmarshalled++
+	if string(data) == "pineapple" {
+		return errors.New("no pineapple")
+	}
+	return DefaultYAMLEngine.Unmarshal(data, node)
+}
+
+func (c *countingEngine) Marshal(node *yaml.Node, indent int) ([]byte, error) {
+	return DefaultYAMLEngine.Marshal(node, indent)
+}
+
+func TestDefaultYAMLEngine(t *testing.T) {
+	var node yaml.Node
+	require.NoError(t, DefaultYAMLEngine.Unmarshal([]byte("pizza:\n  topping: cheese\n"), &node))
+	assert.Equal(t, yaml.DocumentNode, node.Kind)
+
+	b, err := DefaultYAMLEngine.Marshal(&node, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "pizza:\n  topping: cheese\n", string(b))
+
+	b, err = DefaultYAMLEngine.Marshal(&node, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "pizza:\n    topping: cheese\n", string(b))
+}
+
+func TestEncodeYAML(t *testing.T) {
+	var node yaml.Node
+	require.NoError(t, DefaultYAMLEngine.Unmarshal([]byte("pizza:\n  topping: cheese\n"), &node))
+
+	// the default engine streams, other engines render into memory first.
+	for _, engine := range []YAMLEngine{nil, DefaultYAMLEngine, &countingEngine{}} {
+		var b bytes.Buffer
+		require.NoError(t, EncodeYAML(engine, &b, &node, 2))
+		assert.Equal(t, "pizza:\n  topping: cheese\n", b.String())
+	}
+	_, streams := DefaultYAMLEngine.(YAMLStreamEngine)
+	assert.True(t, streams)
+
+	assert.Error(t, EncodeYAML(nil, &bytes.Buffer{}, &yaml.Node{Kind: 99}, 2))
+	assert.Error(t, EncodeYAML(&countingEngine{}, &bytes.Buffer{}, &yaml.Node{Kind: 99}, 2))
+}
+
+func TestDecodeYAML(t *testing.T) {
+	var node yaml.Node
+	require.NoError(t, DecodeYAML(nil, []byte("pizza: cheese"), &node))
+	assert.Equal(t, "cheese", node.Content[0].Content[1].Value)
+
+	engine := &countingEngine{}
+	require.NoError(t, DecodeYAML(engine, []byte("pizza: cheese"), &node))
+	assert.Equal(t, 1, engine.unmarshalled)
+	assert.EqualError(t, DecodeYAML(engine, []byte("pineapple"), &node), "no pineapple")
+}
+
+func TestExtractSpecInfoWithConfig_YAMLEngine(t *testing.T) {
+	engine := &countingEngine{}
+	config := &DocumentConfiguration{YAMLEngine: engine}
+	assert.Same(t, engine, config.GetYAMLEngine())
+	assert.Equal(t, DefaultYAMLEngine, (&DocumentConfiguration{}).GetYAMLEngine())
+
+	info, err := ExtractSpecInfoWithConfig([]byte("openapi: 3.1.0"), config)
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", info.Version)
+	assert.Equal(t, 1, engine.unmarshalled)
+
+	_, err = ExtractSpecInfoWithConfig([]byte("pineapple"), config)
+	assert.EqualError(t, err, "unable to parse specification: no pineapple")
+}
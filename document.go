@@ -14,24 +14,42 @@
 package libopenapi
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/pb33f/libopenapi/index"
 
+	"github.com/pb33f/libopenapi/convert"
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high"
 	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
 	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
 	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/utils"
-	what_changed "github.com/pb33f/libopenapi/what-changed"
 	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pb33f/libopenapi/what-changed/patch"
 	"gopkg.in/yaml.v3"
 )
 
 // Document Represents an OpenAPI specification that can then be rendered into a model or serialized back into
 // a string document after being manipulated.
+//
+// A Document is safe to share between goroutines. Models are built once, concurrent calls to BuildV2Model or
+// BuildV3Model wait for the model being built and all receive the same model. Mutating a built model, or changing
+// the configuration while building, is not synchronized and must be coordinated by the caller.
 type Document interface {
 	// GetVersion will return the exact version of the OpenAPI specification set for the document.
 	GetVersion() string
@@ -42,6 +60,25 @@ type Document interface {
 	// GetSpecInfo will return the *datamodel.SpecInfo instance that contains all specification information.
 	GetSpecInfo() *datamodel.SpecInfo
 
+	// Fingerprint returns a semantic hash of the specification, along with a checksum of its raw bytes. The semantic
+	// hash only changes when what the specification defines changes, so registries can de-duplicate specifications,
+	// or detect changes, without comparing them. It is calculated from the low-level model of the specification as
+	// it was loaded, a model that has already been built is used, otherwise a low-level model is built.
+	Fingerprint() (*Fingerprint, error)
+
+	// Validate checks the specification against the official OpenAPI (or Swagger) meta-schema for its version,
+	// without building a model. Every violation is returned with the JSON path and line/column of the offending
+	// node, an empty slice means the specification is valid. An error is returned if the specification cannot be
+	// validated, for example when there is no meta-schema for its version.
+	Validate() ([]*datamodel.ValidationError, error)
+
+	// GetStats returns a summary of the specification: the number of paths, operations (by method), components (by
+	// type), references (local, remote and file) and circular references, along with how long it took to parse the
+	// specification and build its model. If no model has been built yet, one is built (see BuildV3Model and
+	// BuildV2Model), an error is returned if the model cannot be built. The approximate memory held by the document
+	// is included when the MemoryAccounting of the configuration is set.
+	GetStats() (*DocumentStats, error)
+
 	// SetConfiguration will set the configuration for the document. This allows for finer grained control over
 	// allowing remote or local references, as well as a BaseURL to allow for relative file references.
 	SetConfiguration(configuration *datamodel.DocumentConfiguration)
@@ -50,18 +87,74 @@ type Document interface {
 	// allowing remote or local references, as well as a BaseURL to allow for relative file references.
 	GetConfiguration() *datamodel.DocumentConfiguration
 
+	// SetLogger will set the structured logger used to build models for the document. It is the same as setting the
+	// Logger of the configuration, the logger is passed on to the rolodex, every index and the resolver, so a single
+	// logger controls the level and format of everything logged while building.
+	SetLogger(logger *slog.Logger)
+
+	// ConvertTo converts the specification into another version of OpenAPI, and returns a new Document (with the same
+	// configuration) for the converted specification, along with a report of anything that could not be converted
+	// faithfully. Swagger documents can be converted into OpenAPI 3.0 or 3.1, and OpenAPI 3.0 documents into 3.1.
+	// The converted specification is written in the same format (YAML or JSON) as the original. Converting into the
+	// version of the document returns the document itself.
+	//
+	// See the convert package for exactly what changes between versions.
+	ConvertTo(version Version) (Document, *convert.Report, error)
+
+	// Clone returns an independent copy of the document, without parsing the specification again. The copy has its
+	// own node tree and configuration, and builds its own models (and index), so the copy can be mutated, or its
+	// references resolved in place, without changing the document. The original bytes and the JSON map of the
+	// specification (see GetSpecInfo) are shared, and must not be changed.
+	Clone() Document
+
+	// Close lets go of the models built by the document, and releases the slabs they were allocated from if they
+	// were built with bulk allocation (see datamodel.DocumentConfiguration.BulkAllocation), so their memory is freed
+	// once they are no longer used. Hashes kept for the models (see datamodel.DocumentConfiguration.CacheHashes) are
+	// dropped. Models returned before can still be used, building a model after the document is
+	// closed builds it again.
+	Close()
+
 	// BuildV2Model will build out a Swagger (version 2) model from the specification used to create the document
 	// If there are any issues, then no model will be returned, instead a slice of errors will explain all the
 	// problems that occurred. This method will only support version 2 specifications and will throw an error for
 	// any other types.
+	//
+	// The model is built once and kept by the document, every later call returns the same model without errors.
+	// It is safe to call from multiple goroutines.
 	BuildV2Model() (*DocumentModel[v2high.Swagger], []error)
 
 	// BuildV3Model will build out an OpenAPI (version 3+) model from the specification used to create the document
 	// If there are any issues, then no model will be returned, instead a slice of errors will explain all the
 	// problems that occurred. This method will only support version 3 specifications and will throw an error for
-	// any other types.
+	// any other types, unless DocumentConfiguration.UpgradeSwaggerDocuments is set, in which case a Swagger document
+	// is converted into OpenAPI 3 before the model is built.
+	//
+	// The model is built once and kept by the document, every later call returns the same model without errors.
+	// It is safe to call from multiple goroutines.
 	BuildV3Model() (*DocumentModel[v3high.Document], []error)
 
+	// BuildV3ModelPaths will build an OpenAPI (version 3+) model, in the same way as BuildV3Model, that only holds
+	// the path items of the paths supplied (for example "/users/{id}"). Every other path item is skipped, so reading
+	// a few operations from a very large specification does not require building every operation in it. Components
+	// are built lazily as they are used. See DocumentConfiguration.BuildPaths.
+	//
+	// The specification is only indexed by the first call (or not at all, if BuildV3Model has already built the full
+	// model), every later call reuses the index, and only builds the path items that have not been asked for before.
+	// Each call returns a new model holding only the paths supplied, which is never returned by BuildV3Model. It is
+	// safe to call from multiple goroutines.
+	BuildV3ModelPaths(paths ...string) (*DocumentModel[v3high.Document], []error)
+
+	// ReloadFile updates the OpenAPI model after a file referenced by the specification has changed, without reading
+	// or indexing any other file again (see index.Rolodex.ReloadFile). Only the references to the file, made directly
+	// or through other files, are looked up again, and only the path items, webhooks and components that use the
+	// file (directly, or through other components) are built again. Everything else in the model is kept as it was.
+	// The location is absolute, or relative to the BasePath of the configuration, the specification itself cannot be
+	// reloaded.
+	//
+	// The model must have been built by BuildV3Model first, the same model is returned and updated. If the file
+	// cannot be reloaded, the model is not changed.
+	ReloadFile(location string) (*DocumentModel[v3high.Document], []error)
+
 	// RenderAndReload will render the high level model as it currently exists (including any mutations, additions
 	// and removals to and from any object in the tree). It will then reload the low level model with the new bytes
 	// extracted from the model that was re-rendered. This is useful if you want to make changes to the high level model
@@ -69,6 +162,8 @@ type Document interface {
 	// according to the changes made.
 	//
 	// The method returns the raw YAML bytes that were rendered, and any errors that occurred during rebuilding of the model.
+	// When the specification is YAML, the comments, anchors and aliases of everything that has not been modified
+	// are kept, see Render.
 	// This is a destructive operation, and will re-build the entire model from scratch using the new bytes, so any
 	// references to the old model will be lost. The second return is the new Document that was created, and the third
 	// return is any errors hit trying to re-render.
@@ -78,6 +173,14 @@ type Document interface {
 	// it's too old, so it should be motivation to upgrade to OpenAPI 3.
 	RenderAndReload() ([]byte, Document, *DocumentModel[v3high.Document], []error)
 
+	// BeginTransaction starts an edit session of an OpenAPI model. Any number of mutations can be made to the model
+	// of the Transaction returned, before they are rendered and reloaded together by Transaction.Commit. This is
+	// the same as RenderAndReload, without paying for a reload after every change.
+	//
+	// The transaction builds its own model (in the same way as BuildV3Model), so the document, and any model it has
+	// built, is never changed by the transaction. Errors are returned if the model cannot be built.
+	BeginTransaction() (*Transaction, []error)
+
 	// Render will render the high level model as it currently exists (including any mutations, additions
 	// and removals to and from any object in the tree). Unlike RenderAndReload, Render will simply print the state
 	// of the model as it currently exists, and will not re-load the model into memory. It means that the low-level and
@@ -90,9 +193,28 @@ type Document interface {
 	// 'reload' the model into memory, so that line numbers and column numbers are correct and the index is accurate.
 	// However, if you don't care about the low-level model, and you're not using the index, and you just want to
 	// print the state of the model as it currently exists, then Render() is the method to use.
+	//
+	// When the specification is YAML, the head, line and foot comments of the specification, along with its anchors
+	// and aliases, are rendered for everything that has not been modified. Comments of a value that has been changed
+	// are dropped, and a changed anchor or alias is rendered out in full.
 	// **IMPORTANT** This method only supports OpenAPI Documents.
 	Render() ([]byte, error)
 
+	// RenderTo is the same as Render, except the specification is written to w as it is rendered, so very large
+	// documents can be rendered (to a file, or a response) without holding all the rendered bytes in memory. If an
+	// error is returned, some of the specification may have been written already.
+	RenderTo(w io.Writer) error
+
+	// RenderJSON will render the high level model as it currently exists as JSON, no matter if the specification
+	// was written in YAML or JSON. Keys are rendered in the order they were authored, and numbers are rendered
+	// exactly as they were written. Every level is indented by indent, an empty indent renders compact JSON.
+	//
+	// The OpenAPI model is rendered if it has been built, otherwise the Swagger model is rendered.
+	RenderJSON(indent string) ([]byte, error)
+
+	// RenderJSONTo is the same as RenderJSON, except the JSON is written to w as it is rendered, see RenderTo.
+	RenderJSONTo(w io.Writer, indent string) error
+
 	// Serialize will re-render a Document back into a []byte slice. If any modifications have been made to the
 	// underlying data model using low level APIs, then those changes will be reflected in the serialized output.
 	//
@@ -111,13 +233,206 @@ type document struct {
 	config            *datamodel.DocumentConfiguration
 	highOpenAPI3Model *DocumentModel[v3high.Document]
 	highSwaggerModel  *DocumentModel[v2high.Swagger]
+
+	// pathsModel is the model built by the first call to BuildV3ModelPaths (unless the full model was already built),
+	// the path items asked for by later calls are added to it as they are first asked for.
+	pathsModel *DocumentModel[v3high.Document]
+
+	// how long it took to parse the specification, and to build its model.
+	parseDuration time.Duration
+	buildDuration time.Duration
+
+	// lock guards the models, rolodex and configuration, so models are only built once.
+	lock sync.RWMutex
 }
 
 // DocumentModel represents either a Swagger document (version 2) or an OpenAPI document (version 3) that is
 // built from a parent Document.
 type DocumentModel[T v2high.Swagger | v3high.Document] struct {
-	Model T
-	Index *index.SpecIndex // index created from the document.
+	Model    T
+	Index    *index.SpecIndex // index created from the document.
+	warnings *low.BuildWarnings
+	report   *convert.Report
+}
+
+// GetWarnings returns what was tolerated when building the model, but did not stop it from being built. Warnings are
+// collected for:
+//   - unknown keys that were ignored, in any model of the document (unless DocumentConfiguration.SkipUnknownKeyWarnings
+//     is set).
+//   - schema 'type', 'exclusiveMinimum' and 'exclusiveMaximum' values that were ignored or coerced, and unknown types.
+//   - a license with both an 'identifier' and a 'url'.
+//   - header properties that are not valid together.
+//   - response codes that are not valid.
+//
+// Schemas are built lazily, so warnings about schema values are only available once the schema has been built.
+func (m *DocumentModel[T]) GetWarnings() []*low.BuildWarning {
+	if m == nil {
+		return nil
+	}
+	return m.warnings.GetWarnings()
+}
+
+// GetConversionReport returns the report created when a Swagger document was upgraded to OpenAPI 3 to build the
+// model. It returns nil if no conversion took place. See DocumentConfiguration.UpgradeSwaggerDocuments.
+func (m *DocumentModel[T]) GetConversionReport() *convert.Report {
+	if m == nil {
+		return nil
+	}
+	return m.report
+}
+
+// RenderJSON renders the model as JSON, keeping keys in the order they were authored and numbers exactly as they
+// were written. Every level is indented by indent, an empty indent renders compact JSON.
+func (m *DocumentModel[T]) RenderJSON(indent string) ([]byte, error) {
+	if m == nil {
+		return nil, datamodel.NewError(ErrNoModel, nil, "unable to render, no model has been built")
+	}
+	switch model := any(&m.Model).(type) {
+	case *v3high.Document:
+		return model.RenderJSON(indent)
+	case *v2high.Swagger:
+		return model.RenderJSON(indent)
+	}
+	return nil, errors.New("unable to render, unknown model type")
+}
+
+// RenderJSONTo is the same as RenderJSON, except the JSON is written to w as it is rendered.
+func (m *DocumentModel[T]) RenderJSONTo(w io.Writer, indent string) error {
+	if m == nil {
+		return datamodel.NewError(ErrNoModel, nil, "unable to render, no model has been built")
+	}
+	switch model := any(&m.Model).(type) {
+	case *v3high.Document:
+		return model.RenderJSONTo(w, indent)
+	case *v2high.Swagger:
+		return model.RenderJSONTo(w, indent)
+	}
+	return errors.New("unable to render, unknown model type")
+}
+
+// upgradeSwaggerSpecInfo converts a Swagger document into OpenAPI 3, and returns the SpecInfo of the converted
+// document. The converted document is rendered and parsed again, so line and column numbers match the new document.
+func upgradeSwaggerSpecInfo(info *datamodel.SpecInfo) (*datamodel.SpecInfo, *convert.Report, error) {
+	converted, report, err := convert.SwaggerToOpenAPI3(info.RootNode)
+	if err != nil {
+		return nil, nil, err
+	}
+	upgraded, err := specInfoFromNode(converted)
+	if err != nil {
+		return nil, nil, err
+	}
+	return upgraded, report, nil
+}
+
+// specInfoFromNode renders a converted document and parses it again.
+func specInfoFromNode(converted *yaml.Node) (*datamodel.SpecInfo, error) {
+	b, err := yaml.Marshal(converted)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render converted document: %w", err)
+	}
+	upgraded, err := datamodel.ExtractSpecInfoWithDocumentCheck(b, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read converted document: %w", err)
+	}
+	return upgraded, nil
+}
+
+// removeConversionArtifacts cleans a document converted from Swagger of anything that the conversion adds, but
+// would not be written by hand: the version is set to match the document it is compared with, parameter styles
+// that are the default for the location of the parameter are removed, and request bodies lose the name of the
+// body parameter they were created from.
+func removeConversionArtifacts(root *yaml.Node, version string) {
+	for i := 0; i < len(root.Content)-1; i += 2 {
+		switch root.Content[i].Value {
+		case "openapi":
+			if version != "" {
+				root.Content[i+1].Value = version
+			}
+		case "paths":
+			paths := root.Content[i+1]
+			for j := 1; j < len(paths.Content); j += 2 {
+				removePathItemArtifacts(paths.Content[j])
+			}
+		case "components":
+			components := root.Content[i+1]
+			for j := 0; j < len(components.Content)-1; j += 2 {
+				if components.Content[j].Value != "parameters" {
+					continue
+				}
+				params := components.Content[j+1]
+				for k := 1; k < len(params.Content); k += 2 {
+					removeParameterArtifacts(params.Content[k])
+				}
+			}
+		}
+	}
+}
+
+func removePathItemArtifacts(item *yaml.Node) {
+	for i := 0; i < len(item.Content)-1; i += 2 {
+		v := item.Content[i+1]
+		if item.Content[i].Value == "parameters" {
+			for _, p := range v.Content {
+				removeParameterArtifacts(p)
+			}
+			continue
+		}
+		if !utils.IsNodeMap(v) {
+			continue
+		}
+		for j := 0; j < len(v.Content)-1; j += 2 {
+			switch v.Content[j].Value {
+			case "parameters":
+				for _, p := range v.Content[j+1].Content {
+					removeParameterArtifacts(p)
+				}
+			case "requestBody":
+				removeMapKeys(v.Content[j+1], "x-codegen-request-body-name")
+			}
+		}
+	}
+}
+
+// removeParameterArtifacts removes style and explode from a parameter, when they are the defaults for the location
+// of the parameter.
+func removeParameterArtifacts(param *yaml.Node) {
+	var in, style, explode string
+	for i := 0; i < len(param.Content)-1; i += 2 {
+		switch param.Content[i].Value {
+		case "in":
+			in = param.Content[i+1].Value
+		case "style":
+			style = param.Content[i+1].Value
+		case "explode":
+			explode = param.Content[i+1].Value
+		}
+	}
+	defaultStyle := "simple"
+	if in == "query" || in == "cookie" {
+		defaultStyle = "form"
+	}
+	if style == defaultStyle {
+		removeMapKeys(param, "style")
+	}
+	if style == "" {
+		style = defaultStyle
+	}
+	if explode == strconv.FormatBool(style == "form") {
+		removeMapKeys(param, "explode")
+	}
+}
+
+func removeMapKeys(m *yaml.Node, keys ...string) {
+	if !utils.IsNodeMap(m) {
+		return
+	}
+	for i := 0; i < len(m.Content)-1; {
+		if slices.Contains(keys, m.Content[i].Value) {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			continue
+		}
+		i += 2
+	}
 }
 
 // NewDocument will create a new OpenAPI instance from an OpenAPI specification []byte array. If anything goes
@@ -137,34 +452,197 @@ func NewDocument(specByteArray []byte) (Document, error) {
 }
 
 func NewDocumentWithTypeCheck(specByteArray []byte, bypassCheck bool) (Document, error) {
+	start := time.Now()
 	info, err := datamodel.ExtractSpecInfoWithDocumentCheck(specByteArray, bypassCheck)
 	if err != nil {
 		return nil, err
 	}
+	return newDocument(info, start), nil
+}
+
+// NewDocumentWithConfiguration is the same as NewDocument, except the configuration is set on the returned Document
+// (see SetConfiguration). The specification is parsed within the limits set by the configuration (MaxDocumentSize,
+// MaxNodeCount, MaxAliasExpansion and MaxNestingDepth), so untrusted specifications can be loaded safely.
+func NewDocumentWithConfiguration(specByteArray []byte, configuration *datamodel.DocumentConfiguration) (Document, error) {
+	if configuration == nil {
+		return NewDocument(specByteArray)
+	}
+	start := time.Now()
+	info, err := datamodel.ExtractSpecInfoWithConfig(specByteArray, configuration)
+	if err != nil {
+		return nil, err
+	}
+	d := newDocument(info, start)
+	d.config = configuration
+	return d, nil
+}
+
+// NewDocumentFromSpecInfo creates a new Document from a SpecInfo that has already been extracted, for example one
+// that has been cached with SpecInfo.MarshalBinary and decoded with SpecInfo.UnmarshalBinary, so the specification is
+// not parsed again. The specification is still indexed, and its models built, when they are first asked for. The
+// configuration can be nil.
+func NewDocumentFromSpecInfo(info *datamodel.SpecInfo, configuration *datamodel.DocumentConfiguration) (Document, error) {
+	if info == nil || info.RootNode == nil {
+		return nil, datamodel.NewError(ErrNoSpecification, nil, "unable to create document, the spec info has no specification")
+	}
+	d := newDocument(info, time.Now())
+	d.config = configuration
+	return d, nil
+}
+
+// newDocument creates a document for a parsed specification, that started parsing at start.
+func newDocument(info *datamodel.SpecInfo, start time.Time) *document {
 	d := new(document)
 	d.version = info.Version
 	d.info = info
-	return d, nil
+	d.parseDuration = time.Since(start)
+	return d
 }
 
-// NewDocumentWithConfiguration is the same as NewDocument, except it's a convenience function that calls NewDocument
-// under the hood and then calls SetConfiguration() on the returned Document.
-func NewDocumentWithConfiguration(specByteArray []byte, configuration *datamodel.DocumentConfiguration) (Document, error) {
-	var d Document
-	var err error
-	if configuration != nil && configuration.BypassDocumentCheck {
-		d, err = NewDocumentWithTypeCheck(specByteArray, true)
+// ErrDocumentTooLarge is returned by NewDocumentFromReader when the reader holds more than the MaxDocumentSize
+// set by the configuration, and by NewDocumentWithConfiguration when the specification is larger.
+var ErrDocumentTooLarge = datamodel.ErrDocumentTooLarge
+
+var (
+	// ErrNoSpecification is matched by the errors of methods called on a Document without a specification.
+	ErrNoSpecification = datamodel.NewCodedError("no-specification", "no specification has been loaded")
+
+	// ErrNoModel is matched by the errors of methods that need a model, called before one has been built.
+	ErrNoModel = datamodel.NewCodedError("no-model", "no model has been built for the document")
+
+	// ErrVersionMismatch is matched when a method does not support the version of the specification, like building a
+	// Swagger model of an OpenAPI 3 specification.
+	ErrVersionMismatch = datamodel.NewCodedError("version-mismatch", "the specification is a different version")
+)
+
+// UnsupportedDocumentType is returned when a document is an AsyncAPI, Arazzo or JSON Schema document, instead of
+// an OpenAPI or Swagger specification. NewDocument returns it for Arazzo and JSON Schema documents, and the
+// BuildV2Model and BuildV3Model methods return it for AsyncAPI documents.
+type UnsupportedDocumentType = datamodel.UnsupportedDocumentType
+
+// NewDocumentFromReader is the same as NewDocumentWithConfiguration, except the specification is read from an
+// io.Reader (like a file, or the body of an HTTP request or response), so it doesn't need to be read into a byte slice
+// first. The configuration may be nil.
+//
+// When the size of the specification is known up front (like an *os.File, *bytes.Reader or *strings.Reader), the
+// buffer that holds it is allocated once. If the configuration sets MaxDocumentSize, no more than that many bytes are
+// read, and ErrDocumentTooLarge is returned if the reader holds more.
+func NewDocumentFromReader(reader io.Reader, configuration *datamodel.DocumentConfiguration) (Document, error) {
+	if reader == nil {
+		return nil, errors.New("unable to read document, the reader is nil")
+	}
+	var limit int64
+	if configuration != nil && configuration.MaxDocumentSize > 0 {
+		limit = configuration.MaxDocumentSize
+		reader = io.LimitReader(reader, limit+1)
+	}
+	var buf bytes.Buffer
+	if size := readerSize(reader); size > 0 && (limit == 0 || size <= limit) {
+		buf.Grow(int(size) + bytes.MinRead)
+	}
+	if _, err := buf.ReadFrom(reader); err != nil {
+		return nil, fmt.Errorf("unable to read document: %w", err)
+	}
+	if limit > 0 && int64(buf.Len()) > limit {
+		return nil, ErrDocumentTooLarge
+	}
+	return NewDocumentWithConfiguration(buf.Bytes(), configuration)
+}
+
+// NewDocumentFromURL fetches a specification from an http or https URL, and creates a Document from it in the same way
+// as NewDocumentFromReader (so MaxDocumentSize and the other parsing limits apply). The configuration may be nil, and
+// is not modified.
+//
+// The specification is fetched with the RemoteFS of the configuration if one is set, otherwise with its
+// RemoteURLHandler, or a default HTTP client, bound to the Context and Timeout of the configuration. If the
+// configuration has no BaseURL, the configuration of the returned Document uses the location of the specification,
+// so relative references are fetched from the same place, in the same way.
+func NewDocumentFromURL(location string, configuration *datamodel.DocumentConfiguration) (Document, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read document, invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unable to read document, '%s' is not an http or https URL", location)
+	}
+	config := &datamodel.DocumentConfiguration{}
+	if configuration != nil {
+		copied := *configuration
+		config = &copied
+	}
+	if config.BaseURL == nil {
+		base := &url.URL{Scheme: u.Scheme, User: u.User, Host: u.Host}
+		if dir := path.Dir(u.Path); dir != "." && dir != "/" {
+			base.Path = dir
+		}
+		config.BaseURL = base
+	}
+
+	if config.RemoteFS != nil {
+		f, openErr := config.RemoteFS.Open(location)
+		if openErr != nil {
+			return nil, fmt.Errorf("unable to read document: %w", openErr)
+		}
+		defer f.Close()
+		return NewDocumentFromReader(f, config)
+	}
+
+	ctx, cancel := config.BuildContext(nil)
+	defer cancel()
+	var response *http.Response
+	if config.RemoteURLHandler != nil {
+		response, err = config.RemoteURLHandler(location)
 	} else {
-		d, err = NewDocument(specByteArray)
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if reqErr != nil {
+			return nil, fmt.Errorf("unable to read document: %w", reqErr)
+		}
+		response, err = http.DefaultClient.Do(req)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read document: %w", err)
+	}
+	if response == nil {
+		return nil, fmt.Errorf("unable to read document, empty response from '%s'", location)
 	}
+	defer response.Body.Close()
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("unable to read document '%s' (error %d)", location, response.StatusCode)
+	}
+	return NewDocumentFromReader(response.Body, config)
+}
 
-	if d != nil {
-		d.SetConfiguration(configuration)
+// readerSize returns the number of bytes left to read from a reader, or zero if the size isn't known.
+func readerSize(reader io.Reader) int64 {
+	if l, ok := reader.(*io.LimitedReader); ok {
+		size := readerSize(l.R)
+		if size > l.N {
+			return l.N
+		}
+		return size
+	}
+	switch r := reader.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case interface{ Stat() (fs.FileInfo, error) }:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0
+		}
+		size := info.Size()
+		if s, ok := reader.(io.Seeker); ok {
+			if offset, err := s.Seek(0, io.SeekCurrent); err == nil {
+				size -= offset
+			}
+		}
+		return size
 	}
-	return d, err
+	return 0
 }
 
 func (d *document) GetRolodex() *index.Rolodex {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	return d.rolodex
 }
 
@@ -176,22 +654,41 @@ func (d *document) GetSpecInfo() *datamodel.SpecInfo {
 	return d.info
 }
 
+func (d *document) Validate() ([]*datamodel.ValidationError, error) {
+	return d.info.Validate()
+}
+
 func (d *document) GetConfiguration() *datamodel.DocumentConfiguration {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	return d.config
 }
 
 func (d *document) SetConfiguration(configuration *datamodel.DocumentConfiguration) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
 	d.config = configuration
 }
 
+func (d *document) SetLogger(logger *slog.Logger) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.config == nil {
+		d.config = &datamodel.DocumentConfiguration{}
+	}
+	d.config.Logger = logger
+}
+
 func (d *document) Serialize() ([]byte, error) {
 	if d.info == nil {
 		return nil, fmt.Errorf("unable to serialize, document has not yet been initialized")
 	}
+	// four spaces is the default indentation of yaml.Marshal.
+	engine := d.GetConfiguration().GetYAMLEngine()
 	if d.info.SpecFileType == datamodel.YAMLFileType {
-		return yaml.Marshal(d.info.RootNode)
+		return engine.Marshal(d.info.RootNode, 4)
 	} else {
-		yamlData, _ := yaml.Marshal(d.info.RootNode)
+		yamlData, _ := engine.Marshal(d.info.RootNode, 4)
 		return utils.ConvertYAMLtoJSON(yamlData)
 	}
 }
@@ -201,8 +698,12 @@ func (d *document) RenderAndReload() ([]byte, Document, *DocumentModel[v3high.Do
 	if rerr != nil {
 		return nil, nil, nil, []error{rerr}
 	}
+	return d.reload(newBytes)
+}
 
-	newDoc, err := NewDocumentWithConfiguration(newBytes, d.config)
+// reload creates a new document, with the configuration of this document, from rendered bytes and builds its model.
+func (d *document) reload(newBytes []byte) ([]byte, Document, *DocumentModel[v3high.Document], []error) {
+	newDoc, err := NewDocumentWithConfiguration(newBytes, d.GetConfiguration())
 	if err != nil {
 		return nil, nil, nil, []error{err}
 	}
@@ -217,19 +718,38 @@ func (d *document) RenderAndReload() ([]byte, Document, *DocumentModel[v3high.Do
 }
 
 func (d *document) Render() ([]byte, error) {
+	var b bytes.Buffer
+	if err := d.RenderTo(&b); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func (d *document) RenderTo(w io.Writer) error {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	if d.highOpenAPI3Model == nil {
 		// check for Swagger model first, to give a more helpful error message.
 		if d.highSwaggerModel != nil {
-			return nil, errors.New("this method only supports OpenAPI 3 documents, not Swagger")
+			return datamodel.NewError(ErrVersionMismatch, nil, "this method only supports OpenAPI 3 documents, not Swagger")
 		}
-		return nil, errors.New("unable to render, no openapi model has been built for the document")
+		return datamodel.NewError(ErrNoModel, nil, "unable to render, no openapi model has been built for the document")
 	}
 	if d.info == nil {
-		return nil, errors.New("unable to render, no specification has been loaded")
+		return datamodel.NewError(ErrNoSpecification, nil, "unable to render, no specification has been loaded")
 	}
+	return d.renderTo(w, &d.highOpenAPI3Model.Model)
+}
 
-	var newBytes []byte
-	var jsonErr error
+// render renders an OpenAPI model in the format (YAML or JSON) and indentation of the specification.
+func (d *document) render(model *v3high.Document) ([]byte, error) {
+	var b bytes.Buffer
+	err := d.renderTo(&b, model)
+	return b.Bytes(), err
+}
+
+// renderTo writes an OpenAPI model to w in the format (YAML or JSON) and indentation of the specification.
+func (d *document) renderTo(w io.Writer, model *v3high.Document) error {
 	if d.info.SpecFileType == datamodel.JSONFileType {
 		jsonIndent := "  "
 		i := d.info.OriginalIndentation
@@ -238,25 +758,72 @@ func (d *document) Render() ([]byte, error) {
 				jsonIndent += " "
 			}
 		}
-		newBytes, jsonErr = d.highOpenAPI3Model.Model.RenderJSON(jsonIndent)
+		return model.RenderJSONTo(w, jsonIndent)
 	}
 	if d.info.SpecFileType == datamodel.YAMLFileType {
-		newBytes = d.highOpenAPI3Model.Model.RenderWithIndention(d.info.OriginalIndentation)
+		return d.renderYAMLTo(w, model)
+	}
+	return nil
+}
+
+// renderYAMLTo writes an OpenAPI model to w as YAML, using the indentation of the specification. The comments,
+// anchors and aliases of the specification are restored for everything in the model that has not been modified.
+func (d *document) renderYAMLTo(w io.Writer, model *v3high.Document) error {
+	rendered, _ := model.MarshalYAML()
+	node, _ := rendered.(*yaml.Node)
+	if node == nil || d.info.RootNode == nil {
+		return model.RenderWithIndentionTo(w, d.info.OriginalIndentation)
+	}
+	high.RestoreAnchors(node, low.FindAnchors(d.info.RootNode))
+	node = high.RestoreComments(node, d.info.RootNode)
+	return datamodel.EncodeYAML(d.config.GetYAMLEngine(), w, node, d.info.OriginalIndentation)
+}
+
+func (d *document) RenderJSON(indent string) ([]byte, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if d.highOpenAPI3Model != nil {
+		return d.highOpenAPI3Model.RenderJSON(indent)
+	}
+	if d.highSwaggerModel != nil {
+		return d.highSwaggerModel.RenderJSON(indent)
 	}
-	return newBytes, jsonErr
+	return nil, datamodel.NewError(ErrNoModel, nil, "unable to render, no model has been built for the document")
+}
+
+func (d *document) RenderJSONTo(w io.Writer, indent string) error {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if d.highOpenAPI3Model != nil {
+		return d.highOpenAPI3Model.RenderJSONTo(w, indent)
+	}
+	if d.highSwaggerModel != nil {
+		return d.highSwaggerModel.RenderJSONTo(w, indent)
+	}
+	return datamodel.NewError(ErrNoModel, nil, "unable to render, no model has been built for the document")
 }
 
 func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], []error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
 	if d.highSwaggerModel != nil {
 		return d.highSwaggerModel, nil
 	}
 	var errs []error
+	defer func() {
+		d.config.ReportErrors(errs...)
+	}()
 	if d.info == nil {
-		errs = append(errs, fmt.Errorf("unable to build swagger document, no specification has been loaded"))
+		errs = append(errs, datamodel.NewError(ErrNoSpecification, nil,
+			"unable to build swagger document, no specification has been loaded"))
+		return nil, errs
+	}
+	if err := unsupportedDocumentType(d.info); err != nil {
+		errs = append(errs, err)
 		return nil, errs
 	}
 	if d.info.SpecFormat != datamodel.OAS2 {
-		errs = append(errs, fmt.Errorf("unable to build swagger document, "+
+		errs = append(errs, datamodel.NewError(ErrVersionMismatch, nil, "unable to build swagger document, "+
 			"supplied spec is a different version (%v). Try 'BuildV3Model()'", d.info.SpecFormat))
 		return nil, errs
 	}
@@ -266,20 +833,24 @@ func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], []error) {
 		d.config = datamodel.NewDocumentConfiguration()
 	}
 
+	start := time.Now()
 	var docErr error
 	lowDoc, docErr = v2low.CreateDocumentFromConfig(d.info, d.config)
-	d.rolodex = lowDoc.Rolodex
-
 	if docErr != nil {
 		errs = append(errs, utils.UnwrapErrors(docErr)...)
 	}
+	// building stops without a document when the context of the configuration is done.
+	if lowDoc == nil {
+		return nil, errs
+	}
+	d.rolodex = lowDoc.Rolodex
 
-	// Do not short-circuit on circular reference errors, so the client
-	// has the option of ignoring them.
+	// Do not short-circuit on circular reference errors, or references nested too deep to resolve completely, so the
+	// client has the option of ignoring them.
 	for _, err := range errs {
 		var refErr *index.ResolvingError
 		if errors.As(err, &refErr) {
-			if refErr.CircularReference == nil {
+			if refErr.CircularReference == nil && !errors.Is(refErr, index.ErrTooDeep) {
 				return nil, errs
 			}
 		}
@@ -287,29 +858,21 @@ func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], []error) {
 	highDoc := v2high.NewSwaggerDocument(lowDoc)
 
 	d.highSwaggerModel = &DocumentModel[v2high.Swagger]{
-		Model: *highDoc,
-		Index: lowDoc.Index,
+		Model:    *highDoc,
+		Index:    lowDoc.Index,
+		warnings: lowDoc.BuildWarnings,
 	}
+	d.buildDuration = time.Since(start)
 	return d.highSwaggerModel, errs
 }
 
 func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], []error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
 	if d.highOpenAPI3Model != nil {
 		return d.highOpenAPI3Model, nil
 	}
-	var errs []error
-	if d.info == nil {
-		errs = append(errs, fmt.Errorf("unable to build document, no specification has been loaded"))
-		return nil, errs
-	}
-	if d.info.SpecFormat != datamodel.OAS3 && d.info.SpecFormat != datamodel.OAS31 {
-		errs = append(errs, fmt.Errorf("unable to build openapi document, "+
-			"supplied spec is a different version (%v). Try 'BuildV2Model()'", d.info.SpecFormat))
-		return nil, errs
-	}
-
-	var lowDoc *v3low.Document
-	if d.config == nil {
+	if d.info != nil && d.config == nil {
 		d.config = &datamodel.DocumentConfiguration{
 			AllowFileReferences:   false,
 			BasePath:              "",
@@ -317,35 +880,159 @@ func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], []error) {
 			BaseURL:               nil,
 		}
 	}
+	start := time.Now()
+	m, rolodex, errs := d.buildV3Model(d.config)
+	if rolodex != nil {
+		d.rolodex = rolodex
+	}
+	if m != nil {
+		d.highOpenAPI3Model = m
+		d.pathsModel = nil // BuildV3ModelPaths uses the full model from now on.
+		d.buildDuration = time.Since(start)
+	}
+	return m, errs
+}
 
-	var docErr error
-	lowDoc, docErr = v3low.CreateDocumentFromConfig(d.info, d.config)
-	d.rolodex = lowDoc.Rolodex
+func (d *document) Close() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.highOpenAPI3Model != nil {
+		d.highOpenAPI3Model.Model.GoLow().Allocator.Release()
+		d.highOpenAPI3Model.Model.GoLow().HashScope.Release()
+	}
+	if d.highSwaggerModel != nil {
+		d.highSwaggerModel.Model.GoLow().Allocator.Release()
+		d.highSwaggerModel.Model.GoLow().HashScope.Release()
+	}
+	if d.pathsModel != nil {
+		d.pathsModel.Model.GoLow().Allocator.Release()
+		d.pathsModel.Model.GoLow().HashScope.Release()
+	}
+	d.highOpenAPI3Model = nil
+	d.highSwaggerModel = nil
+	d.pathsModel = nil
+	d.rolodex = nil
+}
 
+func (d *document) BuildV3ModelPaths(paths ...string) (*DocumentModel[v3high.Document], []error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	// the specification is only indexed once, by the first call (or by BuildV3Model, which builds every path item).
+	built := d.highOpenAPI3Model
+	if built == nil {
+		built = d.pathsModel
+	}
+	var errs []error
+	if built == nil {
+		config := &datamodel.DocumentConfiguration{}
+		if d.config != nil {
+			copied := *d.config
+			config = &copied
+		}
+		config.BuildPaths = paths
+		built, _, errs = d.buildV3Model(config)
+		if built == nil {
+			return nil, errs
+		}
+		d.pathsModel = built
+	}
+
+	lowDoc := *built.Model.GoLow()
+	if p := lowDoc.Paths.Value; p != nil {
+		if err := p.BuildPaths(paths...); err != nil {
+			errs = append(errs, utils.UnwrapErrors(err)...)
+		}
+		lowDoc.Paths.Value = p.Select(paths...)
+	}
+	highDoc := v3high.NewDocument(&lowDoc)
+	highDoc.Rolodex = built.Model.Rolodex
+	return &DocumentModel[v3high.Document]{
+		Model:    *highDoc,
+		Index:    built.Index,
+		warnings: built.warnings,
+		report:   built.report,
+	}, errs
+}
+
+// buildV3Model builds an OpenAPI model of the specification with config, and returns it along with the rolodex
+// used to build it.
+func (d *document) buildV3Model(config *datamodel.DocumentConfiguration) (*DocumentModel[v3high.Document], *index.Rolodex, []error) {
+	var errs []error
+	defer func() {
+		config.ReportErrors(errs...)
+	}()
+	if d.info == nil {
+		errs = append(errs, datamodel.NewError(ErrNoSpecification, nil,
+			"unable to build document, no specification has been loaded"))
+		return nil, nil, errs
+	}
+
+	if err := unsupportedDocumentType(d.info); err != nil {
+		errs = append(errs, err)
+		return nil, nil, errs
+	}
+
+	info := d.info
+	var report *convert.Report
+	if info.SpecFormat == datamodel.OAS2 && config.UpgradeSwaggerDocuments {
+		var convErr error
+		info, report, convErr = upgradeSwaggerSpecInfo(d.info)
+		if convErr != nil {
+			errs = append(errs, convErr)
+			return nil, nil, errs
+		}
+	}
+	if info.SpecFormat != datamodel.OAS3 && info.SpecFormat != datamodel.OAS31 {
+		errs = append(errs, datamodel.NewError(ErrVersionMismatch, nil, "unable to build openapi document, "+
+			"supplied spec is a different version (%v). Try 'BuildV2Model()'", info.SpecFormat))
+		return nil, nil, errs
+	}
+
+	var lowDoc *v3low.Document
+	var docErr error
+	lowDoc, docErr = v3low.CreateDocumentFromConfig(info, config)
 	if docErr != nil {
 		errs = append(errs, utils.UnwrapErrors(docErr)...)
 	}
+	// building stops without a document when the context of the configuration is done.
+	if lowDoc == nil {
+		return nil, nil, errs
+	}
 
-	// Do not short-circuit on circular reference errors, so the client
-	// has the option of ignoring them.
+	// Do not short-circuit on circular reference errors, or references nested too deep to resolve completely, so the
+	// client has the option of ignoring them.
 	for _, err := range utils.UnwrapErrors(docErr) {
 		var refErr *index.ResolvingError
 		if errors.As(err, &refErr) {
-			if refErr.CircularReference == nil {
-				return nil, errs
+			if refErr.CircularReference == nil && !errors.Is(refErr, index.ErrTooDeep) {
+				return nil, lowDoc.Rolodex, errs
 			}
 		}
 	}
 
 	highDoc := v3high.NewDocument(lowDoc)
 	highDoc.Rolodex = lowDoc.Index.GetRolodex()
-
-	d.highOpenAPI3Model = &DocumentModel[v3high.Document]{
-		Model: *highDoc,
-		Index: lowDoc.Index,
+	if config.WarmComponentSchemas {
+		errs = append(errs, highDoc.Components.WarmSchemas()...)
 	}
 
-	return d.highOpenAPI3Model, errs
+	return &DocumentModel[v3high.Document]{
+		Model:    *highDoc,
+		Index:    lowDoc.Index,
+		warnings: lowDoc.BuildWarnings,
+		report:   report,
+	}, lowDoc.Rolodex, errs
+}
+
+// unsupportedDocumentType returns an UnsupportedDocumentType error if the specification is a type of document that
+// is not OpenAPI or Swagger.
+func unsupportedDocumentType(info *datamodel.SpecInfo) error {
+	switch info.SpecType {
+	case utils.OpenApi3, utils.OpenApi2, "":
+		return nil
+	}
+	return &UnsupportedDocumentType{Type: info.SpecType, Version: info.Version}
 }
 
 // CompareDocuments will accept a left and right Document implementing struct, build a model for the correct
@@ -354,9 +1041,40 @@ func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], []error) {
 // If there are any errors when building the models, those errors are returned with a nil pointer for the
 // model.DocumentChanges. If there are any changes found however between either Document, then a pointer to
 // model.DocumentChanges is returned containing every single change, broken down, model by model.
-func CompareDocuments(original, updated Document) (*model.DocumentChanges, []error) {
+//
+// A Swagger (OpenAPI 2) document can be compared with an OpenAPI 3+ document. The Swagger document is converted
+// into OpenAPI 3 first (see convert.SwaggerToOpenAPI3), and anything that is only an artifact of the conversion
+// (like the version, or default parameter styles) is not reported. Line and column numbers of changes on the
+// Swagger side refer to the converted document.
+//
+// Any filters supplied restrict the changes returned to specific paths, tags or components, or ignore changes to
+// descriptions, examples, extensions or JSON Pointers (see model.ChangeFilter). If no changes are left once
+// filtered, a nil pointer is returned.
+func CompareDocuments(original, updated Document, filters ...*model.ChangeFilter) (*model.DocumentChanges, []error) {
+	changes, _, _, errs := compareDocuments(original, updated, filters)
+	return changes, errs
+}
+
+// compareDocuments compares two documents, and returns the changes along with the low-level documents that were
+// compared.
+func compareDocuments(original, updated Document, filters []*model.ChangeFilter) (*model.DocumentChanges, any, any, []error) {
+	l, r, errs := lowDocuments(original, updated)
+	if l == nil || r == nil {
+		return nil, nil, nil, errs
+	}
+	changes := model.CompareDocuments(l, r)
+	for _, filter := range filters {
+		changes = model.FilterChanges(changes, filter, l, r)
+	}
+	return changes, l, r, errs
+}
+
+// lowDocuments builds the low-level documents to compare for the original and updated documents, either two
+// *v2.Swagger documents or two *v3.Document documents. If either can't be built, nil documents are returned.
+func lowDocuments(original, updated Document) (any, any, []error) {
 	var errs []error
-	if original.GetSpecInfo().SpecType == utils.OpenApi3 && updated.GetSpecInfo().SpecType == utils.OpenApi3 {
+	originalType, updatedType := original.GetSpecInfo().SpecType, updated.GetSpecInfo().SpecType
+	if originalType == utils.OpenApi3 && updatedType == utils.OpenApi3 {
 		v3ModelLeft, oErrs := original.BuildV3Model()
 		if len(oErrs) > 0 {
 			errs = oErrs
@@ -365,13 +1083,12 @@ func CompareDocuments(original, updated Document) (*model.DocumentChanges, []err
 		if len(uErrs) > 0 {
 			errs = append(errs, uErrs...)
 		}
-		if v3ModelLeft != nil && v3ModelRight != nil {
-			return what_changed.CompareOpenAPIDocuments(v3ModelLeft.Model.GoLow(), v3ModelRight.Model.GoLow()), errs
-		} else {
-			return nil, errs
+		if v3ModelLeft == nil || v3ModelRight == nil {
+			return nil, nil, errs
 		}
+		return v3ModelLeft.Model.GoLow(), v3ModelRight.Model.GoLow(), errs
 	}
-	if original.GetSpecInfo().SpecType == utils.OpenApi2 && updated.GetSpecInfo().SpecType == utils.OpenApi2 {
+	if originalType == utils.OpenApi2 && updatedType == utils.OpenApi2 {
 		v2ModelLeft, oErrs := original.BuildV2Model()
 		if len(oErrs) > 0 {
 			errs = oErrs
@@ -380,7 +1097,117 @@ func CompareDocuments(original, updated Document) (*model.DocumentChanges, []err
 		if len(uErrs) > 0 {
 			errs = append(errs, uErrs...)
 		}
-		return what_changed.CompareSwaggerDocuments(v2ModelLeft.Model.GoLow(), v2ModelRight.Model.GoLow()), errs
+		if v2ModelLeft == nil || v2ModelRight == nil {
+			return nil, nil, errs
+		}
+		return v2ModelLeft.Model.GoLow(), v2ModelRight.Model.GoLow(), errs
+	}
+	if (originalType == utils.OpenApi2 && updatedType == utils.OpenApi3) ||
+		(originalType == utils.OpenApi3 && updatedType == utils.OpenApi2) {
+		left, right, errs := upgradedDocuments(original, updated)
+		if left == nil || right == nil {
+			return nil, nil, errs
+		}
+		return left, right, errs
+	}
+	return nil, nil, []error{fmt.Errorf("unable to compare documents, one or both documents are not of the same version")}
+}
+
+// CompareDocumentsStream works the same way as CompareDocuments, but instead of returning every change at once,
+// each change is passed to the callback as soon as it is found. Path items are compared one at a time, so only
+// the changes of a single path item are held in memory, see model.StreamChanges.
+//
+// Returning false from the callback stops the comparison, and the callback is not called again. Changes are
+// classified as breaking before they reach the callback, so stopping at the first breaking change looks like:
+//
+//	CompareDocumentsStream(original, updated, func(change *model.Change) bool {
+//		return !change.Breaking
+//	})
+//
+// If there are any errors when building the models, those errors are returned and the callback is not called.
+func CompareDocumentsStream(original, updated Document, callback func(change *model.Change) bool) []error {
+	l, r, errs := lowDocuments(original, updated)
+	if l == nil || r == nil {
+		return errs
+	}
+	model.StreamChanges(l, r, callback)
+	return errs
+}
+
+// upgradedDocuments builds low-level OpenAPI 3 documents for a Swagger document and an OpenAPI 3+ document, so
+// they can be compared. The Swagger document is converted using the version of the other document, and is cleaned
+// of anything that would only show up as a change because of the conversion.
+func upgradedDocuments(original, updated Document) (*v3low.Document, *v3low.Document, []error) {
+	var errs []error
+	docs := make([]*v3low.Document, 2)
+	swagger, version := 0, updated.GetVersion()
+	if updated.GetSpecInfo().SpecType == utils.OpenApi2 {
+		swagger, version = 1, original.GetVersion()
+	}
+	for i, d := range []Document{original, updated} {
+		if i != swagger {
+			m, mErrs := d.BuildV3Model()
+			errs = append(errs, mErrs...)
+			if m != nil {
+				docs[i] = m.Model.GoLow()
+			}
+			continue
+		}
+		converted, _, err := convert.SwaggerToOpenAPI3(d.GetSpecInfo().RootNode)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		removeConversionArtifacts(converted, version)
+		info, err := specInfoFromNode(converted)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		config := d.GetConfiguration()
+		if config == nil {
+			config = datamodel.NewDocumentConfiguration()
+		}
+		lowDoc, docErr := v3low.CreateDocumentFromConfig(info, config)
+		errs = append(errs, utils.UnwrapErrors(docErr)...)
+		docs[i] = lowDoc
+	}
+	return docs[0], docs[1], errs
+}
+
+// CompareDocumentsWithAffectedOperations works the same way as CompareDocuments, but will also record the operations
+// that use each changed component, on every change made to that component (see model.MapAffectedOperations). A
+// change inside a referenced schema will then show the operations it impacts, not only a components change.
+func CompareDocumentsWithAffectedOperations(original, updated Document,
+	filters ...*model.ChangeFilter,
+) (*model.DocumentChanges, []error) {
+	changes, l, r, errs := compareDocuments(original, updated, filters)
+	if changes == nil {
+		return changes, errs
 	}
-	return nil, []error{fmt.Errorf("unable to compare documents, one or both documents are not of the same version")}
+	model.MapAffectedOperations(changes, l, r)
+	return changes, errs
+}
+
+// CompareDocumentsIgnoringRefactors works the same way as CompareDocuments, but only reports changes to the API,
+// not to how the documents are written. Schemas that moved between inline definitions and components are compared
+// by the schemas they resolve to, so moving a schema into components and referencing it is not a change, see
+// model.IgnoreRefactors.
+func CompareDocumentsIgnoringRefactors(original, updated Document,
+	filters ...*model.ChangeFilter,
+) (*model.DocumentChanges, []error) {
+	changes, l, r, errs := compareDocuments(original, updated, filters)
+	return model.IgnoreRefactors(changes, l, r), errs
+}
+
+// CreateJSONPatch will compare the original and updated Document, and return the RFC 6902 JSON Patch operations
+// that turn the original specification into the updated one. See patch.CreateJSONPatch.
+func CreateJSONPatch(original, updated Document) ([]*patch.Operation, error) {
+	return patch.CreateJSONPatch(original.GetSpecInfo().RootNode, updated.GetSpecInfo().RootNode)
+}
+
+// CreateMergePatch will compare the original and updated Document, and return an RFC 7396 JSON Merge Patch
+// that turns the original specification into the updated one. See patch.CreateMergePatch.
+func CreateMergePatch(original, updated Document) ([]byte, error) {
+	return patch.CreateMergePatch(original.GetSpecInfo().RootNode, updated.GetSpecInfo().RootNode)
 }
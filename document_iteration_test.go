@@ -82,10 +82,11 @@ func runTest(t *testing.T, specLocation string) {
 		handleSchema(t, schemaProxy, context{})
 	}
 
+	// a proxy keeps the schema it found in the cache, so the cache is hit once per proxy, not once per call.
 	require.Equal(t, uint64(10), m.Index.GetHighCacheMisses())
 	require.Equal(t, uint64(11), m.Index.GetHighCacheHits())
 	require.Equal(t, uint64(101), m.Index.GetRolodex().GetIndexes()[0].GetHighCacheMisses())
-	require.Equal(t, uint64(206), m.Index.GetRolodex().GetIndexes()[0].GetHighCacheHits())
+	require.Equal(t, uint64(149), m.Index.GetRolodex().GetIndexes()[0].GetHighCacheHits())
 }
 
 func iterateOperations(t *testing.T, ops *orderedmap.Map[string, *v3.Operation]) {
@@ -0,0 +1,202 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// the labels of every type of component, as written under components.
+var componentLabels = []string{
+	"schemas", "responses", "parameters", "examples", "requestBodies", "headers", "securitySchemes", "links",
+	"callbacks", "pathItems",
+}
+
+func (d *document) ReloadFile(location string) (*DocumentModel[v3high.Document], []error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	var errs []error
+	defer func() {
+		d.config.ReportErrors(errs...)
+	}()
+	if d.highOpenAPI3Model == nil || d.rolodex == nil || d.rolodex.GetRootIndex() == nil {
+		errs = append(errs, datamodel.NewError(ErrNoModel, nil,
+			"unable to reload '%s', no openapi model has been built for the document", location))
+		return nil, errs
+	}
+	rolodex := d.rolodex
+	rootIndex := rolodex.GetRootIndex()
+	if !filepath.IsAbs(location) && !strings.HasPrefix(location, "http") {
+		location, _ = filepath.Abs(filepath.Join(d.config.BasePath, location))
+	}
+	if location == rootIndex.GetSpecAbsolutePath() {
+		errs = append(errs, errors.New("unable to reload the specification itself, create a new document instead"))
+		return nil, errs
+	}
+
+	dependents, err := rolodex.ReloadFile(location)
+	if err != nil {
+		errs = append(errs, utils.UnwrapErrors(err)...)
+	}
+	if dependents == nil && err != nil {
+		return nil, errs
+	}
+
+	// only the path items, webhooks and components that use the files are built again, everything else is kept.
+	affected := newReloadAffected(rootIndex, append(dependents, location))
+	model := &d.highOpenAPI3Model.Model
+	rebuilt, rebuildErr := model.GoLow().Rebuild(func(node *yaml.Node) bool {
+		return affected.uses(node, make(map[*yaml.Node]bool))
+	})
+	errs = append(errs, utils.UnwrapErrors(rebuildErr)...)
+	setRebuilt(model, rebuilt)
+	if d.config.WarmComponentSchemas {
+		errs = append(errs, model.Components.WarmSchemas()...)
+	}
+	return d.highOpenAPI3Model, errs
+}
+
+// reloadAffected finds what in a specification uses a set of files that have been reloaded, directly, or through
+// the components of the specification.
+type reloadAffected struct {
+	root       *yaml.Node
+	location   string
+	files      map[string]bool
+	refs       map[*yaml.Node]string // the full definition of every reference of the specification, by its node.
+	components map[string]bool       // the JSON pointers of the components that use the files.
+}
+
+func newReloadAffected(rootIndex *index.SpecIndex, files []string) *reloadAffected {
+	a := &reloadAffected{
+		root:       rootIndex.GetRootNode(),
+		location:   rootIndex.GetSpecAbsolutePath(),
+		files:      make(map[string]bool, len(files)),
+		refs:       make(map[*yaml.Node]string),
+		components: make(map[string]bool),
+	}
+	for _, f := range files {
+		a.files[f] = true
+	}
+	for _, ref := range rootIndex.GetRawReferencesSequenced() {
+		a.refs[ref.Node] = ref.FullDefinition
+	}
+	if a.root == nil || len(a.root.Content) == 0 {
+		a.root = &yaml.Node{Kind: yaml.MappingNode}
+	} else {
+		a.root = a.root.Content[0]
+	}
+
+	// a component that references a component that uses the files uses them too.
+	_, components := utils.FindKeyNodeTop("components", a.root.Content)
+	for changed := true; changed && components != nil; {
+		changed = false
+		for _, label := range componentLabels {
+			_, entries := utils.FindKeyNodeTop(label, components.Content)
+			for name, node := range mapEntries(entries) {
+				pointer := "/components/" + label + "/" + utils.EscapeJSONPointer(name)
+				if !a.components[pointer] && a.uses(node, make(map[*yaml.Node]bool)) {
+					a.components[pointer] = true
+					changed = true
+				}
+			}
+		}
+	}
+	return a
+}
+
+// uses returns true if a node of the specification uses any of the files.
+func (a *reloadAffected) uses(node *yaml.Node, seen map[*yaml.Node]bool) bool {
+	if node == nil || seen[node] {
+		return false
+	}
+	seen[node] = true
+	if node.Kind == yaml.AliasNode {
+		return a.uses(node.Alias, seen)
+	}
+	if fullDefinition, ok := a.refs[node]; ok {
+		file, fragment, _ := strings.Cut(fullDefinition, "#")
+		if a.files[file] || (file == a.location && a.components[fragment]) {
+			return true
+		}
+	}
+	for _, child := range node.Content {
+		if a.uses(child, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// setRebuilt puts new high level models of the path items, webhooks and components that were built again in the
+// low level model, in place of the ones of the high level model.
+func setRebuilt(model *v3high.Document, rebuilt *v3low.RebuiltEntries) {
+	lowDoc := model.GoLow()
+	if model.Paths != nil && lowDoc.Paths.Value != nil {
+		setEntries(rebuilt.Paths, lowDoc.Paths.Value.PathItems, model.Paths.PathItems, v3high.NewPathItem)
+	}
+	setEntries(rebuilt.Webhooks, lowDoc.Webhooks.Value, model.Webhooks, v3high.NewPathItem)
+
+	lc, hc := lowDoc.Components.Value, model.Components
+	if lc == nil || hc == nil {
+		return
+	}
+	names := rebuilt.Components
+	for _, name := range names[v3low.SchemasLabel] {
+		if v := low.FindItemInOrderedMap(name, lc.Schemas.Value); v != nil && hc.Schemas != nil {
+			hc.Schemas.Set(name, highbase.NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{
+				Value:     v.Value,
+				ValueNode: v.ValueNode,
+			}))
+		}
+	}
+	setEntries(names[v3low.ResponsesLabel], lc.Responses.Value, hc.Responses, v3high.NewResponse)
+	setEntries(names[v3low.ParametersLabel], lc.Parameters.Value, hc.Parameters, v3high.NewParameter)
+	setEntries(names[lowbase.ExamplesLabel], lc.Examples.Value, hc.Examples, highbase.NewExample)
+	setEntries(names[v3low.RequestBodiesLabel], lc.RequestBodies.Value, hc.RequestBodies, v3high.NewRequestBody)
+	setEntries(names[v3low.HeadersLabel], lc.Headers.Value, hc.Headers, v3high.NewHeader)
+	setEntries(names[v3low.SecuritySchemesLabel], lc.SecuritySchemes.Value, hc.SecuritySchemes,
+		v3high.NewSecurityScheme)
+	setEntries(names[v3low.LinksLabel], lc.Links.Value, hc.Links, v3high.NewLink)
+	setEntries(names[v3low.CallbacksLabel], lc.Callbacks.Value, hc.Callbacks, v3high.NewCallback)
+	setEntries(names[v3low.PathItemsLabel], lc.PathItems.Value, hc.PathItems, v3high.NewPathItem)
+}
+
+// setEntries puts a new high level model of every named entry of a low level map into a high level map.
+func setEntries[L, H any](names []string, lowMap *orderedmap.Map[low.KeyReference[string], low.ValueReference[L]],
+	highMap *orderedmap.Map[string, H], translate func(L) H,
+) {
+	if highMap == nil {
+		return
+	}
+	for _, name := range names {
+		if v := low.FindItemInOrderedMap(name, lowMap); v != nil {
+			highMap.Set(name, translate(v.Value))
+		}
+	}
+}
+
+// mapEntries returns the value nodes of a map node by their keys.
+func mapEntries(node *yaml.Node) map[string]*yaml.Node {
+	entries := make(map[string]*yaml.Node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return entries
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		entries[node.Content[i].Value] = node.Content[i+1]
+	}
+	return entries
+}
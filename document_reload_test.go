@@ -0,0 +1,157 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var reloadSpec = `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /things:
+    get:
+      responses:
+        "200":
+          description: things
+          content:
+            application/json:
+              schema:
+                $ref: 'thing.yaml#/components/schemas/Thing'
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml#/components/schemas/Pet'
+    Pets:
+      type: array
+      items:
+        $ref: '#/components/schemas/Pet'
+    Thing:
+      $ref: 'thing.yaml#/components/schemas/Thing'
+    Local:
+      type: string`
+
+func writeReloadSpec(t *testing.T) (Document, string) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"root.yaml": reloadSpec,
+		"pet.yaml": `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          $ref: 'name.yaml#/components/schemas/Name'`,
+		"name.yaml": `components:
+  schemas:
+    Name:
+      type: string
+      description: the name`,
+		"thing.yaml": `components:
+  schemas:
+    Thing:
+      type: string`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	doc, err := NewDocumentWithConfiguration([]byte(reloadSpec), &datamodel.DocumentConfiguration{
+		BasePath:            dir,
+		SpecFilePath:        "root.yaml",
+		AllowFileReferences: true,
+	})
+	require.NoError(t, err)
+	return doc, dir
+}
+
+func TestDocument_ReloadFile(t *testing.T) {
+	doc, dir := writeReloadSpec(t)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	schemas := m.Model.Components.Schemas
+	pet := schemas.GetOrZero("Pet").Schema()
+	assert.Equal(t, "the name", pet.Properties.GetOrZero("name").Schema().Description)
+	pets := schemas.GetOrZero("Pets")
+	thing := schemas.GetOrZero("Thing")
+	local := schemas.GetOrZero("Local")
+	petsPath := m.Model.Paths.PathItems.GetOrZero("/pets")
+	thingsPath := m.Model.Paths.PathItems.GetOrZero("/things")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "name.yaml"), []byte(`components:
+  schemas:
+    Name:
+      type: string
+      description: the new name`), 0o644))
+
+	reloaded, errs := doc.ReloadFile("name.yaml")
+	require.Empty(t, errs)
+	assert.Same(t, m, reloaded)
+
+	// everything that uses the file is built again.
+	schemas = reloaded.Model.Components.Schemas
+	pet = schemas.GetOrZero("Pet").Schema()
+	assert.Equal(t, "the new name", pet.Properties.GetOrZero("name").Schema().Description)
+	assert.NotSame(t, pets, schemas.GetOrZero("Pets"))
+	assert.NotSame(t, petsPath, reloaded.Model.Paths.PathItems.GetOrZero("/pets"))
+
+	// everything else is kept.
+	assert.Same(t, thing, schemas.GetOrZero("Thing"))
+	assert.Same(t, local, schemas.GetOrZero("Local"))
+	assert.Same(t, thingsPath, reloaded.Model.Paths.PathItems.GetOrZero("/things"))
+	assert.Equal(t, []string{"Pet", "Pets", "Thing", "Local"}, slices.Collect(schemas.KeysFromOldest()))
+
+	// the low level model is built again in place, and the high level models point at it.
+	lowSchemas := reloaded.Model.GoLow().Components.Value.Schemas.Value
+	for name, schema := range schemas.FromOldest() {
+		assert.Same(t, low.FindItemInOrderedMap(name, lowSchemas).Value, schema.GoLow())
+	}
+	lowPaths := reloaded.Model.GoLow().Paths.Value
+	assert.Same(t, lowPaths.FindPath("/pets").Value, reloaded.Model.Paths.PathItems.GetOrZero("/pets").GoLow())
+	assert.Same(t, lowPaths.FindPath("/things").Value, thingsPath.GoLow())
+
+	// the model renders with the change.
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "#/components/schemas/Pet")
+}
+
+func TestDocument_ReloadFile_Errors(t *testing.T) {
+	doc, dir := writeReloadSpec(t)
+	_, errs := doc.ReloadFile("name.yaml")
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrNoModel)
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	thing := m.Model.Components.Schemas.GetOrZero("Thing")
+
+	_, errs = doc.ReloadFile(filepath.Join(dir, "root.yaml"))
+	assert.Len(t, errs, 1)
+
+	_, errs = doc.ReloadFile("missing.yaml")
+	assert.NotEmpty(t, errs)
+
+	// the model is not changed.
+	assert.Same(t, thing, m.Model.Components.Schemas.GetOrZero("Thing"))
+}
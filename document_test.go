@@ -3,26 +3,36 @@
 package libopenapi
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
 	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/pb33f/libopenapi/what-changed/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadDocument_Simple_V2(t *testing.T) {
@@ -127,6 +137,540 @@ func TestDocument_Serialize_Error(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestNewDocumentFromReader(t *testing.T) {
+	f, err := os.Open("test_specs/burgershop.openapi.yaml")
+	require.NoError(t, err)
+	defer f.Close()
+
+	doc, err := NewDocumentFromReader(f, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.NotNil(t, m.Model.Paths.PathItems.GetOrZero("/burgers"))
+
+	doc, err = NewDocumentFromReader(strings.NewReader("openapi: 3.1.0"), datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+	assert.NotNil(t, doc.GetConfiguration())
+}
+
+func TestNewDocumentFromReader_MaxDocumentSize(t *testing.T) {
+	spec := "openapi: 3.1.0\ninfo:\n  title: pizza\n"
+	config := &datamodel.DocumentConfiguration{MaxDocumentSize: int64(len(spec))}
+
+	doc, err := NewDocumentFromReader(strings.NewReader(spec), config)
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+
+	config.MaxDocumentSize = 10
+	doc, err = NewDocumentFromReader(strings.NewReader(spec), config)
+	assert.ErrorIs(t, err, ErrDocumentTooLarge)
+	assert.Nil(t, doc)
+
+	// the size of a plain reader is not known.
+	doc, err = NewDocumentFromReader(bufio.NewReader(strings.NewReader(spec)), config)
+	assert.ErrorIs(t, err, ErrDocumentTooLarge)
+	assert.Nil(t, doc)
+}
+
+func TestNewDocumentWithConfiguration_ParseLimits(t *testing.T) {
+	spec := "openapi: 3.1.0\ninfo:\n  title: pizza\n  version: 1.0.0\n"
+
+	doc, err := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		MaxDocumentSize:   int64(len(spec)),
+		MaxNodeCount:      9,
+		MaxAliasExpansion: 1,
+		MaxNestingDepth:   2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+	assert.Equal(t, 9, doc.GetConfiguration().MaxNodeCount)
+
+	doc, err = NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{MaxDocumentSize: 10})
+	assert.ErrorIs(t, err, ErrDocumentTooLarge)
+	assert.Nil(t, doc)
+
+	doc, err = NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{MaxNodeCount: 8})
+	assert.ErrorIs(t, err, datamodel.ErrTooManyNodes)
+	assert.Nil(t, doc)
+
+	doc, err = NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{MaxNestingDepth: 1})
+	assert.ErrorIs(t, err, datamodel.ErrNestingTooDeep)
+	assert.Nil(t, doc)
+}
+
+func TestDocument_ParseLimits_ReferencedFile(t *testing.T) {
+	dir := t.TempDir()
+	laughs := "l0: &l0 lol\n"
+	for i := 1; i <= 9; i++ {
+		laughs += fmt.Sprintf("l%d: &l%d [*l%d, *l%d, *l%d, *l%d, *l%d, *l%d, *l%d, *l%d, *l%d, *l%d]\n",
+			i, i, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1)
+	}
+	laughs += "components:\n  schemas:\n    Pet:\n      type: object\n"
+	require.NoError(t, os.WriteFile(dir+"/laughs.yaml", []byte(laughs), 0o644))
+	spec := "openapi: 3.1.0\ninfo:\n  title: pizza\n  version: 1.0.0\ncomponents:\n  schemas:\n    Pet:\n" +
+		"      $ref: 'laughs.yaml#/components/schemas/Pet'\n"
+
+	// the specification itself is within the limits, the file it references is not.
+	doc, err := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		BasePath:            dir,
+		AllowFileReferences: true,
+		MaxAliasExpansion:   10000,
+	})
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	assert.ErrorIs(t, errors.Join(errs...), datamodel.ErrAliasExpansionTooLarge)
+}
+
+func TestDocument_MaxResolveDepth(t *testing.T) {
+	spec := "openapi: 3.1.0\ninfo:\n  title: deep\n  version: 1.0.0\ncomponents:\n  schemas:\n    Deep:\n      " +
+		strings.Repeat("{type: object, properties: {a: ", 300) + "{type: string}" + strings.Repeat("}}", 300) + "\n"
+
+	// a reference nested too deep is reported, like a circular reference, it does not stop the model being built.
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.ErrorIs(t, errors.Join(errs...), index.ErrTooDeep)
+	require.NotNil(t, m)
+	assert.NotNil(t, m.Model.Components.Schemas.GetOrZero("Deep").Schema())
+
+	doc, err = NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{MaxResolveDepth: 1000})
+	require.NoError(t, err)
+	m, errs = doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.NotNil(t, m.Model.Components.Schemas.GetOrZero("Deep").Schema())
+}
+
+func TestNewDocumentFromURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/specs/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: a pizza
+          content:
+            application/json:
+              schema:
+                $ref: "schemas/pizza.yaml"`))
+	})
+	mux.HandleFunc("/specs/schemas/pizza.yaml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`type: object
+description: a pizza
+properties:
+  topping:
+    type: string`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	doc, err := NewDocumentFromURL(server.URL+"/specs/openapi.yaml", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+	assert.Equal(t, server.URL+"/specs", doc.GetConfiguration().BaseURL.String())
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	schema := m.Model.Paths.PathItems.GetOrZero("/pizza").Get.Responses.Codes.GetOrZero("200").
+		Content.GetOrZero("application/json").Schema.Schema()
+	assert.Equal(t, "a pizza", schema.Description)
+	assert.NotNil(t, schema.Properties.GetOrZero("topping"))
+
+	// the configuration of the caller is not changed.
+	config := datamodel.NewDocumentConfiguration()
+	doc, err = NewDocumentFromURL(server.URL+"/specs/openapi.yaml", config)
+	require.NoError(t, err)
+	assert.Nil(t, config.BaseURL)
+	assert.NotNil(t, doc.GetConfiguration().BaseURL)
+}
+
+func TestNewDocumentFromURL_RemoteURLHandler(t *testing.T) {
+	var fetched []string
+	config := &datamodel.DocumentConfiguration{
+		BaseURL: &url.URL{Scheme: "https", Host: "pb33f.io", Path: "/specs"},
+		RemoteURLHandler: func(location string) (*http.Response, error) {
+			fetched = append(fetched, location)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("openapi: 3.1.0\ninfo:\n  title: pizza\n")),
+			}, nil
+		},
+	}
+	doc, err := NewDocumentFromURL("https://pb33f.io/pizza/openapi.yaml", config)
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+	assert.Equal(t, []string{"https://pb33f.io/pizza/openapi.yaml"}, fetched)
+	assert.Equal(t, "https://pb33f.io/specs", doc.GetConfiguration().BaseURL.String())
+
+	config.RemoteURLHandler = func(string) (*http.Response, error) {
+		return nil, errors.New("pop")
+	}
+	_, err = NewDocumentFromURL("https://pb33f.io/pizza/openapi.yaml", config)
+	assert.ErrorContains(t, err, "pop")
+}
+
+func TestNewDocumentFromURL_RemoteFS(t *testing.T) {
+	config := &datamodel.DocumentConfiguration{
+		RemoteFS: urlFS{"https://pb33f.io/openapi.yaml": "openapi: 3.1.0\ninfo:\n  title: pizza\n"},
+	}
+	doc, err := NewDocumentFromURL("https://pb33f.io/openapi.yaml", config)
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+	assert.Equal(t, "https://pb33f.io", doc.GetConfiguration().BaseURL.String())
+
+	_, err = NewDocumentFromURL("https://pb33f.io/missing.yaml", config)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+// urlFS is a file system of specifications, keyed by URL.
+type urlFS map[string]string
+
+func (u urlFS) Open(name string) (fs.File, error) {
+	spec, ok := u[name]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return fstest.MapFS{"spec": &fstest.MapFile{Data: []byte(spec)}}.Open("spec")
+}
+
+func TestNewDocumentFromURL_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large.yaml" {
+			_, _ = w.Write([]byte("openapi: 3.1.0\ninfo:\n  title: pizza\n"))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	_, err := NewDocumentFromURL(server.URL+"/missing.yaml", nil)
+	assert.ErrorContains(t, err, "error 404")
+
+	_, err = NewDocumentFromURL(server.URL+"/large.yaml", &datamodel.DocumentConfiguration{MaxDocumentSize: 10})
+	assert.ErrorIs(t, err, ErrDocumentTooLarge)
+
+	_, err = NewDocumentFromURL("file:///specs/openapi.yaml", nil)
+	assert.ErrorContains(t, err, "is not an http or https URL")
+
+	_, err = NewDocumentFromURL("http://pb33f.io/%zz", nil)
+	assert.ErrorContains(t, err, "invalid URL")
+}
+
+func TestNewDocumentFromSpecInfo(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	info, err := datamodel.ExtractSpecInfo(spec)
+	require.NoError(t, err)
+
+	// cache the spec info, then create a document from the cached copy.
+	cached, err := info.MarshalBinary()
+	require.NoError(t, err)
+	var decoded datamodel.SpecInfo
+	require.NoError(t, decoded.UnmarshalBinary(cached))
+
+	doc, err := NewDocumentFromSpecInfo(&decoded, &datamodel.DocumentConfiguration{AllowFileReferences: true})
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+	assert.True(t, doc.GetConfiguration().AllowFileReferences)
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, "Burger Shop", m.Model.Info.Title)
+	assert.Equal(t, 3, m.Model.Info.GoLow().Title.KeyNode.Line)
+
+	_, err = NewDocumentFromSpecInfo(nil, nil)
+	assert.ErrorIs(t, err, ErrNoSpecification)
+	_, err = NewDocumentFromSpecInfo(&datamodel.SpecInfo{}, nil)
+	assert.ErrorIs(t, err, ErrNoSpecification)
+}
+
+func TestDocument_Hooks(t *testing.T) {
+	var stages []string
+	var reported []error
+	config := &datamodel.DocumentConfiguration{
+		OnSpecInfoExtracted: func(info *datamodel.SpecInfo) error {
+			stages = append(stages, "extracted "+info.Version)
+			return nil
+		},
+		OnIndexBuilt: func(rolodex any) error {
+			stages = append(stages, "indexed")
+			assert.NotNil(t, rolodex.(*index.Rolodex).GetRootIndex())
+			return nil
+		},
+		OnResolved: func(rolodex any) error {
+			stages = append(stages, "resolved")
+			return errors.New("no pizza")
+		},
+		OnError: func(err error) {
+			reported = append(reported, err)
+		},
+	}
+
+	spec, _ := os.ReadFile("test_specs/circular-tests.yaml")
+	doc, err := NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.NotNil(t, m)
+	assert.Equal(t, []string{"extracted 3.0", "indexed", "resolved"}, stages)
+	assert.Equal(t, errs, reported)
+	assert.Len(t, errs, 4) // three circular references, and the error of the hook.
+	assert.EqualError(t, errs[3], "no pizza")
+
+	stages, reported = nil, nil
+	spec, _ = os.ReadFile("test_specs/petstorev2.json")
+	doc, err = NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	_, errs = doc.BuildV2Model()
+	assert.Equal(t, []string{"extracted 2.0", "indexed", "resolved"}, stages)
+	assert.Equal(t, errs, reported)
+
+	reported = nil
+	_, errs = doc.BuildV3Model()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, errs, reported)
+}
+
+func TestDocument_UnsupportedDocumentType(t *testing.T) {
+	_, err := NewDocument([]byte("arazzo: 1.0.0\ninfo:\n  title: pizza\n"))
+	var unsupported *UnsupportedDocumentType
+	require.ErrorAs(t, err, &unsupported)
+	assert.Equal(t, utils.Arazzo, unsupported.Type)
+
+	doc, err := NewDocument([]byte("asyncapi: 2.6.0\ninfo:\n  title: pizza\n"))
+	require.NoError(t, err)
+	assert.Equal(t, utils.AsyncApi, doc.GetSpecInfo().SpecType)
+
+	_, errs := doc.BuildV3Model()
+	require.Len(t, errs, 1)
+	require.ErrorAs(t, errs[0], &unsupported)
+	assert.Equal(t, utils.AsyncApi, unsupported.Type)
+	assert.EqualError(t, errs[0],
+		"spec type not supported by libopenapi, the document is AsyncAPI 2.6.0, not OpenAPI or Swagger")
+
+	_, errs = doc.BuildV2Model()
+	require.Len(t, errs, 1)
+	assert.ErrorAs(t, errs[0], &unsupported)
+}
+
+// upperEngine renders YAML with upper case keys.
+type upperEngine struct{}
+
+func (upperEngine) Unmarshal(data []byte, node *yaml.Node) error {
+	return datamodel.DefaultYAMLEngine.Unmarshal(data, node)
+}
+
+func (upperEngine) Marshal(node *yaml.Node, indent int) ([]byte, error) {
+	b, err := datamodel.DefaultYAMLEngine.Marshal(node, indent)
+	return bytes.ToUpper(b), err
+}
+
+func TestDocument_YAMLEngine(t *testing.T) {
+	spec := "openapi: 3.1.0\ninfo:\n  title: pizza\n"
+	doc, err := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{YAMLEngine: upperEngine{}})
+	require.NoError(t, err)
+
+	b, err := doc.Serialize()
+	require.NoError(t, err)
+	assert.Equal(t, "OPENAPI: 3.1.0\nINFO:\n    TITLE: PIZZA\n", string(b))
+
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	b, err = doc.Render()
+	require.NoError(t, err)
+	assert.Equal(t, "OPENAPI: 3.1.0\nINFO:\n  TITLE: PIZZA\n", string(b))
+}
+
+// recordingEngine records every file it parses.
+type recordingEngine struct {
+	lock   sync.Mutex
+	parsed []string
+}
+
+func (e *recordingEngine) Unmarshal(data []byte, node *yaml.Node) error {
+	e.lock.Lock()
+	e.parsed = append(e.parsed, string(data))
+	e.lock.Unlock()
+	return datamodel.DefaultYAMLEngine.Unmarshal(data, node)
+}
+
+func (e *recordingEngine) Marshal(node *yaml.Node, indent int) ([]byte, error) {
+	return datamodel.DefaultYAMLEngine.Marshal(node, indent)
+}
+
+func TestDocument_YAMLEngine_ReferencedFile(t *testing.T) {
+	dir := t.TempDir()
+	pet := "components:\n  schemas:\n    Pet:\n      type: object\n"
+	require.NoError(t, os.WriteFile(dir+"/pet.yaml", []byte(pet), 0o644))
+	spec := "openapi: 3.1.0\ninfo:\n  title: pizza\n  version: 1.0.0\ncomponents:\n  schemas:\n    Pet:\n" +
+		"      $ref: 'pet.yaml#/components/schemas/Pet'\n"
+
+	// the referenced file is parsed by the engine too, not only the specification.
+	engine := &recordingEngine{}
+	doc, err := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		BasePath:            dir,
+		AllowFileReferences: true,
+		YAMLEngine:          engine,
+	})
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Contains(t, engine.parsed, spec)
+	assert.Contains(t, engine.parsed, pet)
+}
+
+func TestDocument_ErrorKinds(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+
+	_, err = doc.Render()
+	assert.ErrorIs(t, err, ErrNoModel)
+	assert.Equal(t, datamodel.ErrorCode("no-model"), datamodel.ErrorCodeOf(err))
+
+	_, errs := doc.BuildV2Model()
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrVersionMismatch)
+
+	_, errs = new(document).BuildV3Model()
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrNoSpecification)
+
+	// errors from the index match the kinds of the index.
+	doc, err = NewDocument([]byte(`openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      $ref: '#/components/schemas/Missing'`))
+	require.NoError(t, err)
+	_, errs = doc.BuildV3Model()
+	require.NotEmpty(t, errs)
+	assert.ErrorIs(t, errors.Join(errs...), index.ErrRefMissing)
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("pop")
+}
+
+func TestNewDocumentFromReader_Error(t *testing.T) {
+	_, err := NewDocumentFromReader(nil, nil)
+	assert.Error(t, err)
+
+	_, err = NewDocumentFromReader(errReader{}, nil)
+	assert.ErrorContains(t, err, "pop")
+
+	_, err = NewDocumentFromReader(strings.NewReader(""), nil)
+	assert.Error(t, err)
+}
+
+func TestDocument_BuildV3Model_Timeout(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Burger:
+      $ref: 'https://pb33f.io/slow.yaml#/components/schemas/Burger'`
+
+	hang := make(chan struct{})
+	defer close(hang)
+	config := datamodel.NewDocumentConfiguration()
+	config.AllowRemoteReferences = true
+	config.Timeout = 50 * time.Millisecond
+	config.RemoteURLHandler = func(url string) (*http.Response, error) {
+		<-hang
+		return nil, fmt.Errorf("too late")
+	}
+
+	doc, err := NewDocumentWithConfiguration([]byte(spec), config)
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.Nil(t, m)
+	require.NotEmpty(t, errs)
+	assert.ErrorContains(t, errors.Join(errs...), "context deadline exceeded")
+}
+
+func TestDocument_BuildV3ModelPaths(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(burgerShop)
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV3ModelPaths("/burgers/{burgerId}")
+	require.Empty(t, errs)
+	assert.Equal(t, 1, orderedmap.Len(m.Model.Paths.PathItems))
+	op := m.Model.Paths.PathItems.GetOrZero("/burgers/{burgerId}").Get
+	assert.Equal(t, "locateBurger", op.OperationId)
+	assert.NotNil(t, op.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/json").Schema.Schema())
+
+	// later calls reuse the index, and only build the path items not built before, in the order of the specification.
+	more, errs := doc.BuildV3ModelPaths("/dressings", "/burgers/{burgerId}")
+	require.Empty(t, errs)
+	assert.Same(t, m.Index, more.Index)
+	var paths []string
+	for path := range more.Model.Paths.PathItems.KeysFromOldest() {
+		paths = append(paths, path)
+	}
+	assert.Equal(t, []string{"/burgers/{burgerId}", "/dressings"}, paths)
+	assert.Same(t, m.Model.Paths.PathItems.GetOrZero("/burgers/{burgerId}").GoLow(),
+		more.Model.Paths.PathItems.GetOrZero("/burgers/{burgerId}").GoLow())
+	assert.Equal(t, 1, orderedmap.Len(m.Model.Paths.PathItems))
+
+	// the partial model is never returned by BuildV3Model, which builds every path item.
+	full, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, 5, orderedmap.Len(full.Model.Paths.PathItems))
+	assert.NotSame(t, m.Index, full.Index)
+
+	// once the full model is built, its path items are used.
+	again, errs := doc.BuildV3ModelPaths("/dressings")
+	require.Empty(t, errs)
+	assert.Equal(t, 1, orderedmap.Len(again.Model.Paths.PathItems))
+	assert.NotSame(t, full, again)
+	assert.Same(t, full.Index, again.Index)
+	assert.Same(t, full.Model.Paths.PathItems.GetOrZero("/dressings").GoLow(),
+		again.Model.Paths.PathItems.GetOrZero("/dressings").GoLow())
+
+	_, errs = (&document{}).BuildV3ModelPaths("/burgers")
+	assert.Len(t, errs, 1)
+}
+
+func TestDocument_BuildV3Model_WarmComponentSchemas(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocumentWithConfiguration(burgerShop, &datamodel.DocumentConfiguration{WarmComponentSchemas: true})
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	for _, proxy := range m.Model.Components.Schemas.FromOldest() {
+		sch, buildErr := proxy.BuildSchema()
+		assert.NoError(t, buildErr)
+		assert.NotNil(t, sch)
+	}
+}
+
+func TestDocument_SetLogger(t *testing.T) {
+	data, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(data)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	doc.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	require.NotNil(t, doc.GetConfiguration())
+	_, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+
+	// document, rolodex and resolver all log to the same logger.
+	logs := buf.String()
+	assert.Contains(t, logs, `"msg":"rolodex indexed"`)
+	assert.Contains(t, logs, `"msg":"[rolodex] root index build completed"`)
+	assert.Contains(t, logs, `"msg":"[resolver] circular reference check completed"`)
+	assert.Same(t, doc.GetConfiguration().Logger, doc.GetRolodex().GetLogger())
+}
+
 func TestDocument_Serialize(t *testing.T) {
 	yml := `openapi: 3.0
 info:
@@ -348,6 +892,57 @@ func TestDocument_RenderAndReload(t *testing.T) {
 		h.Components.SecuritySchemes.GetOrZero("petstore_auth").Flows.Implicit.AuthorizationUrl)
 }
 
+func TestDocument_RenderAndReload_CommentsAndAnchors(t *testing.T) {
+	spec := `# The pizza API
+openapi: 3.1.0
+info:
+  title: Pizza # the title
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      # shared tags
+      tags: &tags
+        - Pizza
+      responses:
+        "200":
+          description: OK # all good
+  /cake:
+    get:
+      tags: *tags
+      responses:
+        "200":
+          description: Cake
+# the end`
+
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	m.Model.Info.Title = "Pizza and Cake"
+
+	b, newDoc, _, errs := doc.RenderAndReload()
+	require.Empty(t, errs)
+
+	out := string(b)
+	assert.True(t, strings.HasPrefix(out, "# The pizza API\n"))
+	assert.Contains(t, out, "title: Pizza and Cake\n")
+	assert.NotContains(t, out, "# the title")
+	assert.Contains(t, out, "# shared tags\n")
+	assert.Contains(t, out, "tags: &tags")
+	assert.Contains(t, out, "tags: *tags")
+	assert.Contains(t, out, "description: OK # all good")
+	assert.Contains(t, out, "# the end")
+
+	// comments and anchors survive another round trip.
+	_, errs = newDoc.BuildV3Model()
+	require.Empty(t, errs)
+	again, _, _, errs := newDoc.RenderAndReload()
+	require.Empty(t, errs)
+	assert.Equal(t, out, string(again))
+}
+
 func TestDocument_RenderAndReload_WithErrors(t *testing.T) {
 	// load an OpenAPI 3 specification from bytes
 	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
@@ -430,6 +1025,148 @@ func TestDocument_Render(t *testing.T) {
 		h.Components.SecuritySchemes.GetOrZero("petstore_auth").Flows.Implicit.AuthorizationUrl)
 }
 
+func TestDocument_RenderJSON(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  version: 1.10
+  title: <Pets & Things>
+paths:
+  /b:
+    get:
+      responses:
+        "200":
+          description: OK
+  /a:
+    get:
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Price:
+      type: number
+      multipleOf: 0.01
+      maximum: 1e3`
+
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+
+	_, err = doc.RenderJSON("  ")
+	assert.EqualError(t, err, "unable to render, no model has been built for the document")
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	b, err := doc.RenderJSON("")
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"info":{"version":"1.10","title":"<Pets & Things>"}`)
+	assert.Contains(t, string(b), `"multipleOf":0.01`)
+	assert.Less(t, strings.Index(string(b), `"/b"`), strings.Index(string(b), `"/a"`))
+
+	mb, err := m.RenderJSON("")
+	require.NoError(t, err)
+	assert.Equal(t, b, mb)
+
+	b, err = doc.RenderJSON("    ")
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "{\n    \"openapi\": \"3.1.0\",")
+
+	reloaded, err := NewDocument(b)
+	require.NoError(t, err)
+	rm, errs := reloaded.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, "1.10", rm.Model.Info.Version)
+	assert.Equal(t, 1000.0, *rm.Model.Components.Schemas.GetOrZero("Price").Schema().Maximum)
+}
+
+func TestDocument_RenderJSON_Swagger(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV2Model()
+	require.Empty(t, errs)
+
+	b, err := doc.RenderJSON("  ")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(b), "{\n  \"swagger\": \"2.0\","))
+
+	mb, err := m.RenderJSON("  ")
+	require.NoError(t, err)
+	assert.Equal(t, b, mb)
+
+	var nilModel *DocumentModel[v2high.Swagger]
+	_, err = nilModel.RenderJSON("")
+	assert.Error(t, err)
+}
+
+func TestDocument_RenderTo(t *testing.T) {
+	for _, file := range []string{"test_specs/petstorev3.json", "test_specs/burgershop.openapi.yaml"} {
+		spec, _ := os.ReadFile(file)
+		doc, err := NewDocument(spec)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		assert.Error(t, doc.RenderTo(&b))
+		assert.Error(t, doc.RenderJSONTo(&b, "  "))
+
+		m, errs := doc.BuildV3Model()
+		require.Empty(t, errs)
+
+		rendered, err := doc.Render()
+		require.NoError(t, err)
+		require.NoError(t, doc.RenderTo(&b))
+		assert.Equal(t, string(rendered), b.String())
+
+		rendered, err = doc.RenderJSON("  ")
+		require.NoError(t, err)
+		b.Reset()
+		require.NoError(t, doc.RenderJSONTo(&b, "  "))
+		assert.Equal(t, string(rendered), b.String())
+
+		b.Reset()
+		require.NoError(t, m.RenderJSONTo(&b, "  "))
+		assert.Equal(t, string(rendered), b.String())
+
+		// the high-level model renders the same way.
+		rendered, err = m.Model.Render()
+		require.NoError(t, err)
+		b.Reset()
+		require.NoError(t, m.Model.RenderTo(&b))
+		assert.Equal(t, string(rendered), b.String())
+	}
+}
+
+func TestDocument_RenderTo_Swagger(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV2Model()
+	require.Empty(t, errs)
+
+	var b bytes.Buffer
+	assert.Error(t, doc.RenderTo(&b))
+
+	rendered, err := doc.RenderJSON("  ")
+	require.NoError(t, err)
+	require.NoError(t, doc.RenderJSONTo(&b, "  "))
+	assert.Equal(t, string(rendered), b.String())
+
+	b.Reset()
+	require.NoError(t, m.RenderJSONTo(&b, "  "))
+	assert.Equal(t, string(rendered), b.String())
+
+	rendered, err = m.Model.Render()
+	require.NoError(t, err)
+	b.Reset()
+	require.NoError(t, m.Model.RenderTo(&b))
+	assert.Equal(t, string(rendered), b.String())
+
+	var nilModel *DocumentModel[v2high.Swagger]
+	assert.Error(t, nilModel.RenderJSONTo(&b, ""))
+}
+
 func TestDocument_Render_Missing_Model_Error(t *testing.T) {
 	// load an OpenAPI 3 specification from bytes
 	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
@@ -629,13 +1366,214 @@ func TestDocument_BuildModel_CompareDocsV2_Error(t *testing.T) {
 	assert.Nil(t, changes)
 }
 
-func TestDocument_BuildModel_CompareDocsV2V3Mix_Error(t *testing.T) {
-	burgerShopOriginal, _ := os.ReadFile("test_specs/petstorev2.json")
-	burgerShopUpdated, _ := os.ReadFile("test_specs/petstorev3.json")
+func TestDocument_BuildModel_CompareDocsV2V3Mix(t *testing.T) {
+	petstoreV2, _ := os.ReadFile("test_specs/petstorev2.json")
+	petstoreV3, _ := os.ReadFile("test_specs/petstorev3.json")
+	originalDoc, _ := NewDocument(petstoreV2)
+	updatedDoc, _ := NewDocument(petstoreV3)
+	changes, errors := CompareDocuments(originalDoc, updatedDoc)
+	assert.Empty(t, errors)
+	require.NotNil(t, changes)
+	for _, c := range changes.PropertyChanges.Changes {
+		assert.NotEqual(t, "openapi", c.Property)
+	}
+
+	reversed, errors := CompareDocuments(updatedDoc, originalDoc)
+	assert.Empty(t, errors)
+	require.NotNil(t, reversed)
+	assert.NotZero(t, reversed.TotalChanges())
+}
+
+func TestCompareDocuments_CrossVersion_NoArtifacts(t *testing.T) {
+	swagger := `swagger: "2.0"
+info:
+  title: pets
+  version: "1.0"
+consumes: [application/json]
+produces: [application/json]
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: tags
+          in: query
+          type: array
+          items:
+            type: string
+          collectionFormat: multi
+      responses:
+        "200":
+          description: pets
+          schema:
+            type: string
+    post:
+      parameters:
+        - name: pet
+          in: body
+          required: true
+          schema:
+            type: string
+      responses:
+        "201":
+          description: created`
+
+	openapi := `openapi: 3.1.0
+info:
+  title: pets
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: string
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: string
+      responses:
+        "201":
+          description: created`
+
+	originalDoc, _ := NewDocument([]byte(swagger))
+	updatedDoc, _ := NewDocument([]byte(openapi))
+	changes, errs := CompareDocuments(originalDoc, updatedDoc)
+	assert.Empty(t, errs)
+	assert.Nil(t, changes)
+
+	// a real change is still found.
+	updatedDoc, _ = NewDocument([]byte(strings.Replace(openapi, "required: true", "required: false", 1)))
+	changes, errs = CompareDocumentsWithAffectedOperations(originalDoc, updatedDoc)
+	assert.Empty(t, errs)
+	require.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+}
+
+func TestCompareDocuments_Filtered(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("test_specs/burgershop.openapi-modified.yaml")
 	originalDoc, _ := NewDocument(burgerShopOriginal)
 	updatedDoc, _ := NewDocument(burgerShopUpdated)
-	changes, errors := CompareDocuments(updatedDoc, originalDoc)
-	assert.Len(t, errors, 1)
+
+	all, errs := CompareDocuments(originalDoc, updatedDoc)
+	assert.Empty(t, errs)
+	require.NotNil(t, all)
+
+	changes, errs := CompareDocuments(originalDoc, updatedDoc, &model.ChangeFilter{Paths: []string{"/burgers"}})
+	assert.Empty(t, errs)
+	require.NotNil(t, changes)
+	assert.Less(t, changes.TotalChanges(), all.TotalChanges())
+	assert.Nil(t, changes.InfoChanges)
+	assert.Nil(t, changes.ComponentsChanges)
+	for p := range changes.PathsChanges.PathItemsChanges {
+		assert.Equal(t, "/burgers", p)
+	}
+}
+
+func TestCompareDocumentsStream(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("test_specs/burgershop.openapi-modified.yaml")
+	originalDoc, _ := NewDocument(burgerShopOriginal)
+	updatedDoc, _ := NewDocument(burgerShopUpdated)
+
+	all, errs := CompareDocuments(originalDoc, updatedDoc)
+	assert.Empty(t, errs)
+	require.NotNil(t, all)
+
+	var streamed, breaking int
+	errs = CompareDocumentsStream(originalDoc, updatedDoc, func(change *model.Change) bool {
+		streamed++
+		if change.Breaking {
+			breaking++
+		}
+		return true
+	})
+	assert.Empty(t, errs)
+	assert.Equal(t, all.TotalChanges(), streamed)
+	expected := 0
+	model.WalkChanges(all, func(_ *model.ChangeLocation, change *model.Change) {
+		if change.Breaking {
+			expected++
+		}
+	})
+	assert.Equal(t, expected, breaking)
+
+	// stop at the first breaking change.
+	var seen []*model.Change
+	errs = CompareDocumentsStream(originalDoc, updatedDoc, func(change *model.Change) bool {
+		seen = append(seen, change)
+		return !change.Breaking
+	})
+	assert.Empty(t, errs)
+	require.NotEmpty(t, seen)
+	assert.Less(t, len(seen), streamed)
+	assert.True(t, seen[len(seen)-1].Breaking)
+}
+
+func TestCompareDocumentsStream_Error(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/badref-burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("test_specs/burgershop.openapi-modified.yaml")
+	originalDoc, _ := NewDocument(burgerShopOriginal)
+	updatedDoc, _ := NewDocument(burgerShopUpdated)
+	called := false
+	errs := CompareDocumentsStream(originalDoc, updatedDoc, func(change *model.Change) bool {
+		called = true
+		return true
+	})
+	assert.Len(t, errs, 6)
+	assert.False(t, called)
+}
+
+func TestCreateJSONPatch(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("test_specs/burgershop.openapi-modified.yaml")
+	originalDoc, _ := NewDocument(burgerShopOriginal)
+	updatedDoc, _ := NewDocument(burgerShopUpdated)
+
+	ops, err := CreateJSONPatch(originalDoc, updatedDoc)
+	require.NoError(t, err)
+	assert.NotEmpty(t, ops)
+
+	merge, err := CreateMergePatch(originalDoc, updatedDoc)
+	require.NoError(t, err)
+	assert.Contains(t, string(merge), `"info"`)
+
+	ops, err = CreateJSONPatch(originalDoc, originalDoc)
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+}
+
+func TestCompareDocumentsWithAffectedOperations(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("test_specs/burgershop.openapi-modified.yaml")
+	originalDoc, _ := NewDocument(burgerShopOriginal)
+	updatedDoc, _ := NewDocument(burgerShopUpdated)
+	changes, errs := CompareDocumentsWithAffectedOperations(originalDoc, updatedDoc)
+	assert.Empty(t, errs)
+	require.NotNil(t, changes)
+
+	var affected int
+	for _, c := range changes.ComponentsChanges.GetAllChanges() {
+		affected += len(c.AffectedOperations)
+	}
+	assert.NotZero(t, affected)
+
+	// no changes made means nothing to map.
+	changes, _ = CompareDocumentsWithAffectedOperations(originalDoc, originalDoc)
 	assert.Nil(t, changes)
 }
 
@@ -1428,9 +2366,10 @@ func TestDocument_Issue264(t *testing.T) {
 
 	_, _ = d.BuildV3Model()
 
-	_, _, _, errs := d.RenderAndReload() // code panics here
-	assert.Len(t, errs, 1)
-	assert.Equal(t, "yaml: cannot decode !!float `-999.99` as a !!int", errs[0].Error())
+	// numbers are rendered as they were written, so the float minimum no longer fails to decode as an int.
+	b, _, _, errs := d.RenderAndReload() // code panics here
+	assert.Empty(t, errs)
+	assert.Contains(t, string(b), `"minimum": -999.99`)
 }
 
 func TestDocument_Issue269(t *testing.T) {
@@ -1455,3 +2394,350 @@ components:
 	_, errs := doc.BuildV3Model()
 	assert.Len(t, errs, 0)
 }
+
+func TestDocument_BuildV3Model_Warnings(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: warnings
+  version: 1.0.0
+  license:
+    name: MIT
+    url: https://opensource.org/licenses/MIT
+    identifier: MIT
+pizza: party
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: pet
+        "999":
+          description: nope
+x-pizza: extension`
+
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	// schemas are built lazily.
+	schema := m.Model.Paths.PathItems.GetOrZero("/pets").Get.Responses.Codes.GetOrZero("200").
+		Content.GetOrZero("application/json").Schema.Schema()
+	assert.Equal(t, []string{"pet"}, schema.Type)
+
+	warnings := m.GetWarnings()
+	require.Len(t, warnings, 4)
+	assert.Equal(t, 8, warnings[0].Line)
+	assert.Contains(t, warnings[0].Message, "mutually exclusive")
+	assert.Equal(t, "unknown key 'pizza' in v3.Document is ignored, line 9, column 1", warnings[1].String())
+	assert.Equal(t, "schema 'type' 'pet' is not a known type, line 19, column 23", warnings[2].String())
+	assert.Equal(t, 20, warnings[3].Line)
+	assert.Contains(t, warnings[3].Message, "999")
+
+	var nilModel *DocumentModel[v3high.Document]
+	assert.Nil(t, nilModel.GetWarnings())
+}
+
+func TestDocument_BuildV2Model_Warnings(t *testing.T) {
+	spec := `swagger: 2.0
+info:
+  title: warnings
+  version: 1.0.0
+pizza: party
+paths: {}`
+
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV2Model()
+	require.Empty(t, errs)
+
+	warnings := m.GetWarnings()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "unknown key 'pizza' in v2.Swagger is ignored, line 5, column 1", warnings[0].String())
+}
+
+func TestDocument_BuildV3Model_Warnings_UnknownKeys(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: warnings
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      summery: typo
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      requierd:
+        - name`
+
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	// every model is checked, not just the root of the document.
+	warnings := m.GetWarnings()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "unknown key 'summery' in v3.Operation is ignored, line 8, column 7", warnings[0].String())
+	assert.Equal(t, "unknown key 'requierd' in base.Schema is ignored, line 16, column 7", warnings[1].String())
+
+	config := datamodel.NewDocumentConfiguration()
+	config.SkipUnknownKeyWarnings = true
+	doc, err = NewDocumentWithConfiguration([]byte(spec), config)
+	require.NoError(t, err)
+	m, errs = doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Empty(t, m.GetWarnings())
+}
+
+func TestDocument_BuildV3Model_UpgradeSwagger(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/petstorev2.json")
+
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	require.Len(t, errs, 1)
+
+	doc, err = NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{UpgradeSwaggerDocuments: true})
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, "2.0", doc.GetVersion())
+	assert.Equal(t, "3.0.3", m.Model.Version)
+
+	report := m.GetConversionReport()
+	require.NotNil(t, report)
+	assert.Equal(t, "2.0", report.SourceVersion)
+	assert.False(t, report.HasIssues())
+
+	assert.Equal(t, "https://petstore.swagger.io/v2", m.Model.Servers[0].URL)
+	assert.Equal(t, 6, m.Model.Components.Schemas.Len())
+	addPet := m.Model.Paths.PathItems.GetOrZero("/pet").Post
+	require.NotNil(t, addPet.RequestBody)
+	schema := addPet.RequestBody.Content.GetOrZero("application/json").Schema
+	assert.Equal(t, "#/components/schemas/Pet", schema.GetReference())
+	assert.Equal(t, "Pet", schema.Schema().XML.Name)
+	assert.Equal(t, "oauth2", m.Model.Components.SecuritySchemes.GetOrZero("petstore_auth").Type)
+	assert.NotNil(t, m.Model.Components.SecuritySchemes.GetOrZero("petstore_auth").Flows.Implicit)
+
+	v3, _ := NewDocument([]byte("openapi: 3.1.0"))
+	v3m, _ := v3.BuildV3Model()
+	assert.Nil(t, v3m.GetConversionReport())
+}
+
+func TestCompareDocumentsIgnoringRefactors(t *testing.T) {
+	original := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string`
+	updated := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string`
+	originalDoc, _ := NewDocument([]byte(original))
+	updatedDoc, _ := NewDocument([]byte(updated))
+
+	changes, errs := CompareDocuments(originalDoc, updatedDoc)
+	assert.Empty(t, errs)
+	assert.NotNil(t, changes)
+
+	changes, errs = CompareDocumentsIgnoringRefactors(originalDoc, updatedDoc)
+	assert.Empty(t, errs)
+	assert.Nil(t, changes)
+}
+
+func TestDocument_Validate(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	errs, err := doc.Validate()
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err = NewDocument(burgerShop)
+	require.NoError(t, err)
+
+	errs, err = doc.Validate()
+	require.NoError(t, err)
+	require.Len(t, errs, 5)
+	assert.Equal(t, "$.components.callbacks.BurgerCallback.x-break-everything", errs[0].Path)
+	assert.Equal(t, 296, errs[0].Line)
+	assert.Equal(t, "$.components.links.AnotherLocateBurger.server: property 'server' is not allowed (line 319, col 7)",
+		errs[1].Error())
+}
+
+func TestDocument_BuildV3Model_Concurrent(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(burgerShop)
+	require.NoError(t, err)
+
+	paths := []string{"/burgers", "/burgers/{burgerId}", "/dressings"}
+	models := make([]*DocumentModel[v3high.Document], 10)
+	var wg sync.WaitGroup
+	for i := range models {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// partial models are built before, while and after the full model is built.
+			partial, _ := doc.BuildV3ModelPaths(paths[i%len(paths)])
+			_, _ = partial.Model.Render()
+			models[i], _ = doc.BuildV3Model()
+			_, _ = doc.Render()
+			_, _ = doc.BuildV3ModelPaths(paths...)
+			_, _ = doc.Fingerprint()
+			_ = doc.GetRolodex()
+		}(i)
+	}
+	wg.Wait()
+
+	require.NotNil(t, models[0])
+	for _, m := range models {
+		assert.Same(t, models[0], m)
+	}
+	assert.NotNil(t, doc.GetRolodex())
+}
+
+func TestDocument_BuildV3Model_MaxConcurrency(t *testing.T) {
+	defer utils.SetMaxConcurrency(0)
+	utils.SetMaxConcurrency(1)
+
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(burgerShop)
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, 5, orderedmap.Len(m.Model.Paths.PathItems))
+
+	other, _ := NewDocument(burgerShop)
+	changes, errs := CompareDocuments(doc, other)
+	require.Empty(t, errs)
+	assert.Nil(t, changes)
+}
+
+func TestDocument_BulkAllocation(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocumentWithConfiguration(burgerShop, &datamodel.DocumentConfiguration{BulkAllocation: true})
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	allocator := m.Model.GoLow().Allocator
+	require.NotNil(t, allocator)
+	assert.Positive(t, allocator.Len())
+
+	plain, _ := NewDocument(burgerShop)
+	pm, _ := plain.BuildV3Model()
+	assert.Nil(t, pm.Model.GoLow().Allocator)
+	rendered, _ := m.Model.Render()
+	plainRendered, _ := pm.Model.Render()
+	assert.Equal(t, string(plainRendered), string(rendered))
+
+	// once closed, the model is built again.
+	doc.Close()
+	assert.Nil(t, doc.GetRolodex())
+
+	again, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.NotSame(t, m, again)
+	assert.NotSame(t, allocator, again.Model.GoLow().Allocator)
+}
+
+func TestDocument_BulkAllocation_Swagger(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocumentWithConfiguration(petstore, &datamodel.DocumentConfiguration{BulkAllocation: true})
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV2Model()
+	require.Empty(t, errs)
+	allocator := m.Model.GoLow().Allocator
+	require.NotNil(t, allocator)
+	assert.Positive(t, allocator.Len())
+
+	doc.Close()
+	assert.Nil(t, doc.GetRolodex())
+}
+
+func TestDocument_CacheHashes(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocumentWithConfiguration(burgerShop, &datamodel.DocumentConfiguration{CacheHashes: true})
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	lowDoc := m.Model.GoLow()
+	burger := lowDoc.Components.Value.FindSchema("Burger").Value.Schema()
+	original := lowDoc.Components.Value.Hash()
+	assert.Equal(t, original, lowDoc.Components.Value.Hash())
+
+	// changing the document drops the kept hashes.
+	require.NoError(t, burger.Description.SetValue("a burger"))
+	changed := lowDoc.Components.Value.Hash()
+	assert.NotEqual(t, original, changed)
+	require.NoError(t, burger.Description.SetValue(
+		"The tastiest food on the planet you would love to eat everyday"))
+	assert.Equal(t, original, lowDoc.Components.Value.Hash())
+
+	// once closed, the hashes are no longer kept.
+	doc.Close()
+	burger.Description.Value = "a burger"
+	assert.Equal(t, changed, lowDoc.Components.Value.Hash())
+}
+
+func TestDocument_BuildV2Model_Concurrent(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	models := make([]*DocumentModel[v2high.Swagger], 10)
+	var wg sync.WaitGroup
+	for i := range models {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			models[i], _ = doc.BuildV2Model()
+			_, _ = doc.RenderJSON("  ")
+		}(i)
+	}
+	wg.Wait()
+
+	require.NotNil(t, models[0])
+	for _, m := range models {
+		assert.Same(t, models[0], m)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// Fingerprint identifies the content of a specification, so specifications can be de-duplicated, or checked for
+// changes, without comparing them.
+type Fingerprint struct {
+	// Semantic is a SHA256 hash (as hex) of what the specification defines. It is built from the hash of every
+	// low-level object in the model, so it does not change when only whitespace, comments or the order of keys in a
+	// map change. Low-level hashes include the style of values, so quoting a value differently (or converting the
+	// specification between YAML and JSON) does change it.
+	Semantic string `json:"semantic" yaml:"semantic"`
+
+	// Checksum is a SHA256 hash (as hex) of the raw bytes of the specification, it changes with every byte.
+	Checksum string `json:"checksum" yaml:"checksum"`
+}
+
+func (d *document) Fingerprint() (*Fingerprint, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if d.info == nil || d.info.SpecBytes == nil {
+		return nil, datamodel.NewError(ErrNoSpecification, nil, "unable to fingerprint, no specification has been loaded")
+	}
+	var semantic [32]byte
+	switch {
+	case d.highOpenAPI3Model != nil && d.highOpenAPI3Model.Model.GoLow() != nil:
+		semantic = hashV3Document(d.highOpenAPI3Model.Model.GoLow())
+	case d.highSwaggerModel != nil && d.highSwaggerModel.Model.GoLow() != nil:
+		semantic = hashV2Document(d.highSwaggerModel.Model.GoLow())
+	default:
+		config := d.config
+		if config == nil {
+			config = &datamodel.DocumentConfiguration{}
+		}
+		// errors that still produce a model (like circular references) do not change what is defined.
+		if d.info.SpecFormat == datamodel.OAS2 {
+			lowDoc, err := v2low.CreateDocumentFromConfig(d.info, config)
+			if lowDoc == nil {
+				return nil, err
+			}
+			semantic = hashV2Document(lowDoc)
+		} else {
+			lowDoc, err := v3low.CreateDocumentFromConfig(d.info, config)
+			if lowDoc == nil {
+				return nil, err
+			}
+			semantic = hashV3Document(lowDoc)
+		}
+	}
+	return &Fingerprint{
+		Semantic: fmt.Sprintf(low.HASH, semantic),
+		Checksum: fmt.Sprintf(low.HASH, sha256.Sum256(*d.info.SpecBytes)),
+	}, nil
+}
+
+// hashV3Document returns a SHA256 hash of every top-level object of an OpenAPI document.
+func hashV3Document(doc *v3low.Document) [32]byte {
+	f := []string{doc.Version.Value, hashValue(doc.Info.Value), doc.JsonSchemaDialect.Value}
+	f = low.AppendMapHashes(f, doc.Webhooks.Value)
+	f = appendValueHashes(f, doc.Servers.Value)
+	f = append(f, hashValue(doc.Paths.Value), hashValue(doc.Components.Value))
+	f = appendValueHashes(f, doc.Security.Value)
+	f = appendValueHashes(f, doc.Tags.Value)
+	f = append(f, hashValue(doc.ExternalDocs.Value))
+	f = append(f, low.HashExtensions(doc.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
+
+// hashV2Document returns a SHA256 hash of every top-level object of a Swagger document.
+func hashV2Document(doc *v2low.Swagger) [32]byte {
+	f := []string{doc.Swagger.Value, hashValue(doc.Info.Value), doc.Host.Value, doc.BasePath.Value}
+	f = appendValueHashes(f, doc.Schemes.Value)
+	f = appendValueHashes(f, doc.Consumes.Value)
+	f = appendValueHashes(f, doc.Produces.Value)
+	f = append(f, hashValue(doc.Paths.Value), hashValue(doc.Definitions.Value))
+	if doc.SecurityDefinitions.Value != nil {
+		f = low.AppendMapHashes(f, doc.SecurityDefinitions.Value.Definitions)
+	}
+	if doc.Parameters.Value != nil {
+		f = low.AppendMapHashes(f, doc.Parameters.Value.Definitions)
+	}
+	if doc.Responses.Value != nil {
+		f = low.AppendMapHashes(f, doc.Responses.Value.Definitions)
+	}
+	f = appendValueHashes(f, doc.Security.Value)
+	f = appendValueHashes(f, doc.Tags.Value)
+	f = append(f, hashValue(doc.ExternalDocs.Value))
+	f = append(f, low.HashExtensions(doc.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
+
+// hashValue returns the hash of a low-level value, or an empty string if the value is a nil pointer.
+func hashValue(v any) string {
+	if rv := reflect.ValueOf(v); !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return ""
+	}
+	return low.GenerateHashString(v)
+}
+
+// appendValueHashes appends the hash of every value in a slice, in order.
+func appendValueHashes[T any](f []string, values []low.ValueReference[T]) []string {
+	for _, v := range values {
+		f = append(f, hashValue(v.Value))
+	}
+	return f
+}
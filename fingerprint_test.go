@@ -0,0 +1,120 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Fingerprint(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      description: get a pizza
+      responses:
+        "200":
+          description: OK
+  /cake:
+    get:
+      responses:
+        "200":
+          description: OK`
+
+	// the same specification, with the paths swapped, keys reordered and a comment added.
+	reordered := `# the pizza api
+openapi: 3.1.0
+info:
+  version: 1.0.0
+  title: Pizza
+paths:
+  /cake:
+    get:
+      responses:
+        "200":
+          description: OK
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: OK
+      description: get a pizza`
+
+	fingerprint := func(spec string) *Fingerprint {
+		doc, err := NewDocument([]byte(spec))
+		require.NoError(t, err)
+		f, err := doc.Fingerprint()
+		require.NoError(t, err)
+		return f
+	}
+
+	original := fingerprint(spec)
+	assert.Len(t, original.Semantic, 64)
+	assert.Len(t, original.Checksum, 64)
+	assert.Equal(t, original, fingerprint(spec))
+
+	moved := fingerprint(reordered)
+	assert.Equal(t, original.Semantic, moved.Semantic)
+	assert.NotEqual(t, original.Checksum, moved.Checksum)
+
+	changed := fingerprint(strings.Replace(spec, "get a pizza", "get a cake", 1))
+	assert.NotEqual(t, original.Semantic, changed.Semantic)
+}
+
+func TestDocument_Fingerprint_Model(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(burgerShop)
+	require.NoError(t, err)
+
+	before, err := doc.Fingerprint()
+	require.NoError(t, err)
+
+	// a model that has been built is used, and gives the same fingerprint.
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	after, err := doc.Fingerprint()
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	// mutations to the model are not part of the fingerprint, it is calculated from the specification as it was loaded.
+	m.Model.Info.Title = "Changed"
+	mutated, err := doc.Fingerprint()
+	require.NoError(t, err)
+	assert.Equal(t, before, mutated)
+}
+
+func TestDocument_Fingerprint_Swagger(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2-complete.yaml")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	before, err := doc.Fingerprint()
+	require.NoError(t, err)
+
+	_, errs := doc.BuildV2Model()
+	require.Empty(t, errs)
+	after, err := doc.Fingerprint()
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	modified, _ := os.ReadFile("test_specs/petstorev2-complete-modified.yaml")
+	modifiedDoc, err := NewDocument(modified)
+	require.NoError(t, err)
+	changed, err := modifiedDoc.Fingerprint()
+	require.NoError(t, err)
+	assert.NotEqual(t, before.Semantic, changed.Semantic)
+}
+
+func TestDocument_Fingerprint_NoSpec(t *testing.T) {
+	_, err := (&document{}).Fingerprint()
+	assert.EqualError(t, err, "unable to fingerprint, no specification has been loaded")
+}
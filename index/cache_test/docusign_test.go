@@ -61,8 +61,9 @@ func runTest(t *testing.T, specLocation string) {
 		handleSchema(t, schemaProxy, context{})
 	}
 
+	// a proxy keeps the schema it found in the cache, so the cache is hit once per proxy, not once per call.
 	require.Equal(t, uint64(1008), m.Index.GetHighCacheMisses())
-	require.Equal(t, uint64(4049727), m.Index.GetHighCacheHits())
+	require.Equal(t, uint64(18821), m.Index.GetHighCacheHits())
 }
 
 func iterateOperations(t *testing.T, ops *orderedmap.Map[string, *v3.Operation]) {
@@ -0,0 +1,38 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import "github.com/pb33f/libopenapi/datamodel"
+
+// Every error raised by the index, the resolver and the rolodex matches one of these errors with errors.Is (including
+// the ErrorRef of a ResolvingError, and the Err of an IndexingError), and datamodel.ErrorCodeOf returns its code.
+var (
+	// ErrRefMissing is matched by references that point to something that does not exist.
+	ErrRefMissing = datamodel.NewCodedError("ref-missing", "reference cannot be found")
+
+	// ErrRefInvalid is matched by references that cannot be looked up, like an empty reference.
+	ErrRefInvalid = datamodel.NewCodedError("ref-invalid", "reference is invalid")
+
+	// ErrCircularReference is matched by infinite circular references.
+	ErrCircularReference = datamodel.NewCodedError("circular-reference", "infinite circular reference")
+
+	// ErrTooDeep is matched by references nested deeper than the MaxResolveDepth of the index configuration.
+	ErrTooDeep = datamodel.NewCodedError("too-deep", "reference is nested too deeply to resolve")
+
+	// ErrRemoteFetch is matched when a remote document cannot be fetched.
+	ErrRemoteFetch = datamodel.NewCodedError("remote-fetch", "unable to fetch remote document")
+
+	// ErrLookupNotAllowed is matched when a reference needs a file or remote lookup, and the index configuration does
+	// not allow it.
+	ErrLookupNotAllowed = datamodel.NewCodedError("lookup-not-allowed", "reference lookup is not allowed")
+
+	// ErrNoFileSystem is matched when the rolodex has no file system to open a file with.
+	ErrNoFileSystem = datamodel.NewCodedError("no-file-system", "the rolodex has no file system to open the file")
+
+	// ErrEmptyDocument is matched when a file in the rolodex has no content.
+	ErrEmptyDocument = datamodel.NewCodedError("empty-document", "the document has no content")
+
+	// ErrInvalidParameter is matched by operation parameters that have no name, or are duplicated.
+	ErrInvalidParameter = datamodel.NewCodedError("invalid-parameter", "operation parameter is invalid")
+)
@@ -4,14 +4,15 @@
 package index
 
 import (
-	"errors"
 	"fmt"
 	"net/url"
-	"os"
+	"path"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
@@ -19,10 +20,17 @@ import (
 // ExtractRefs will return a deduplicated slice of references for every unique ref found in the document.
 // The total number of refs, will generally be much higher, you can extract those from GetRawReferenceCount()
 func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string, level int, poly bool, pName string) []*Reference {
+	found := index.extractRefs(nil, node, parent, seenPath, level, poly, pName)
+	index.refCount = len(index.allRefs)
+	return found
+}
+
+// extractRefs appends the references found in node to found, and returns it. Every level of the document appends
+// to the same slice, rather than returning a slice of its own to be copied into the level above.
+func (index *SpecIndex) extractRefs(found []*Reference, node, parent *yaml.Node, seenPath []string, level int, poly bool, pName string) []*Reference {
 	if node == nil {
-		return nil
+		return found
 	}
-	var found []*Reference
 	if len(node.Content) > 0 {
 		var prev, polyName string
 		for i, n := range node.Content {
@@ -38,7 +46,7 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 						polyName = prev
 					}
 				}
-				found = append(found, index.ExtractRefs(n, node, seenPath, level, poly, polyName)...)
+				found = index.extractRefs(found, n, node, seenPath, level, poly, polyName)
 			}
 
 			// check if we're dealing with an inline schema definition, that isn't part of an array
@@ -52,9 +60,10 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 				if len(seenPath) > 0 || n.Value != "" {
 					loc := append(seenPath, n.Value)
 					// create definition and full definition paths
-					definitionPath = fmt.Sprintf("#/%s", strings.Join(loc, "/"))
-					fullDefinitionPath = fmt.Sprintf("%s#/%s", index.specAbsolutePath, strings.Join(loc, "/"))
+					definitionPath = intern("#/" + strings.Join(loc, "/"))
+					fullDefinitionPath = intern(index.specAbsolutePath + definitionPath)
 					_, jsonPath = utils.ConvertComponentIdIntoFriendlyPathSearch(definitionPath)
+					jsonPath = intern(jsonPath)
 				}
 
 				ref := &Reference{
@@ -130,9 +139,10 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 					var jsonPath, definitionPath, fullDefinitionPath string
 					if len(seenPath) > 0 || n.Value != "" && label != "" {
 						loc := append(seenPath, n.Value, label)
-						definitionPath = fmt.Sprintf("#/%s", strings.Join(loc, "/"))
-						fullDefinitionPath = fmt.Sprintf("%s#/%s", index.specAbsolutePath, strings.Join(loc, "/"))
+						definitionPath = intern("#/" + strings.Join(loc, "/"))
+						fullDefinitionPath = intern(index.specAbsolutePath + definitionPath)
 						_, jsonPath = utils.ConvertComponentIdIntoFriendlyPathSearch(definitionPath)
+						jsonPath = intern(jsonPath)
 					}
 					ref := &Reference{
 						ParentNode:     parent,
@@ -172,15 +182,15 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 
 					var jsonPath, definitionPath, fullDefinitionPath string
 					if len(seenPath) > 0 {
-						loc := append(seenPath, n.Value, fmt.Sprintf("%d", h))
-						definitionPath = fmt.Sprintf("#/%s", strings.Join(loc, "/"))
-						fullDefinitionPath = fmt.Sprintf("%s#/%s", index.specAbsolutePath, strings.Join(loc, "/"))
-						_, jsonPath = utils.ConvertComponentIdIntoFriendlyPathSearch(definitionPath)
+						loc := append(seenPath, n.Value, strconv.Itoa(h))
+						definitionPath = "#/" + strings.Join(loc, "/")
 					} else {
-						definitionPath = fmt.Sprintf("#/%s", n.Value)
-						fullDefinitionPath = fmt.Sprintf("%s#/%s", index.specAbsolutePath, n.Value)
-						_, jsonPath = utils.ConvertComponentIdIntoFriendlyPathSearch(definitionPath)
+						definitionPath = "#/" + n.Value
 					}
+					fullDefinitionPath = index.specAbsolutePath + definitionPath
+					_, jsonPath = utils.ConvertComponentIdIntoFriendlyPathSearch(definitionPath)
+					definitionPath, fullDefinitionPath, jsonPath = intern(definitionPath), intern(fullDefinitionPath),
+						intern(jsonPath)
 
 					ref := &Reference{
 						ParentNode:     parent,
@@ -225,14 +235,17 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 
 				index.linesWithRefs[n.Line] = true
 
-				fp := make([]string, len(seenPath))
-				copy(fp, seenPath)
-
 				if len(node.Content) > i+1 {
 
-					value := node.Content[i+1].Value
-					segs := strings.Split(value, "/")
-					name := segs[len(segs)-1]
+					// the same few references are made again and again, every copy of the value kept by the
+					// index shares the same storage. The node itself is never changed, it may be read elsewhere.
+					value := intern(node.Content[i+1].Value)
+
+					// references to the $defs of an enclosing schema are re-mapped to an absolute definition.
+					if def := index.resolveSchemaRelativeDefinition(node, value); def != "" {
+						value = def
+					}
+					name := value[strings.LastIndex(value, "/")+1:]
 					uri := strings.Split(value, "#/")
 
 					// determine absolute path to this definition
@@ -261,9 +274,9 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 								} else {
 									// if the index has a base path, use that to resolve the path
 									if index.config.BasePath != "" && index.config.BaseURL == nil {
-										abs, _ := filepath.Abs(utils.CheckPathOverlap(index.config.BasePath, uri[0], string(os.PathSeparator)))
+										abs := utils.JoinRefPath(index.config.BasePath, uri[0])
 										if abs != defRoot {
-											abs, _ = filepath.Abs(utils.CheckPathOverlap(defRoot, uri[0], string(os.PathSeparator)))
+											abs = utils.JoinRefPath(defRoot, uri[0])
 										}
 										fullDefinitionPath = fmt.Sprintf("%s#/%s", abs, uri[1])
 										componentName = fmt.Sprintf("#/%s", uri[1])
@@ -273,20 +286,17 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 											var u url.URL
 											if strings.HasPrefix(defRoot, "http") {
 												up, _ := url.Parse(defRoot)
-												up.Path = utils.ReplaceWindowsDriveWithLinuxPath(filepath.Dir(up.Path))
+												up.Path = path.Dir(up.Path)
 												u = *up
 											} else {
 												u = *index.config.BaseURL
 											}
-											// abs, _ := filepath.Abs(filepath.Join(u.Path, uri[0]))
-											// abs, _ := filepath.Abs(utils.CheckPathOverlap(u.Path, uri[0], string(os.PathSeparator)))
-											abs := utils.CheckPathOverlap(u.Path, uri[0], string(os.PathSeparator))
-											u.Path = utils.ReplaceWindowsDriveWithLinuxPath(abs)
+											u.Path = utils.JoinURLRefPath(u.Path, uri[0])
 											fullDefinitionPath = fmt.Sprintf("%s#/%s", u.String(), uri[1])
 											componentName = fmt.Sprintf("#/%s", uri[1])
 
 										} else {
-											abs, _ := filepath.Abs(utils.CheckPathOverlap(defRoot, uri[0], string(os.PathSeparator)))
+											abs := utils.JoinRefPath(defRoot, uri[0])
 											fullDefinitionPath = fmt.Sprintf("%s#/%s", abs, uri[1])
 											componentName = fmt.Sprintf("#/%s", uri[1])
 										}
@@ -302,21 +312,15 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 							if !strings.Contains(uri[0], "#") {
 								if strings.HasPrefix(defRoot, "http") {
 									if !filepath.IsAbs(uri[0]) {
-										u, _ := url.Parse(defRoot)
-										pathDir := filepath.Dir(u.Path)
-										// pathAbs, _ := filepath.Abs(filepath.Join(pathDir, uri[0]))
-										pathAbs, _ := filepath.Abs(utils.CheckPathOverlap(pathDir, uri[0], string(os.PathSeparator)))
-										pathAbs = utils.ReplaceWindowsDriveWithLinuxPath(pathAbs)
-										u.Path = pathAbs
-										fullDefinitionPath = u.String()
+										fullDefinitionPath = utils.ResolveRefPath(defRoot, uri[0])
 									}
 								} else {
 									if !filepath.IsAbs(uri[0]) {
 										// if the index has a base path, use that to resolve the path
 										if index.config.BasePath != "" {
-											abs, _ := filepath.Abs(utils.CheckPathOverlap(index.config.BasePath, uri[0], string(os.PathSeparator)))
+											abs := utils.JoinRefPath(index.config.BasePath, uri[0])
 											if abs != defRoot {
-												abs, _ = filepath.Abs(utils.CheckPathOverlap(defRoot, uri[0], string(os.PathSeparator)))
+												abs = utils.JoinRefPath(defRoot, uri[0])
 											}
 											fullDefinitionPath = abs
 											componentName = uri[0]
@@ -325,14 +329,11 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 											if index.config.BaseURL != nil {
 
 												u := *index.config.BaseURL
-												abs := utils.CheckPathOverlap(u.Path, uri[0], string(os.PathSeparator))
-												abs = utils.ReplaceWindowsDriveWithLinuxPath(abs)
-												u.Path = abs
+												u.Path = utils.JoinURLRefPath(u.Path, uri[0])
 												fullDefinitionPath = u.String()
 												componentName = uri[0]
 											} else {
-												abs, _ := filepath.Abs(utils.CheckPathOverlap(defRoot, uri[0], string(os.PathSeparator)))
-												fullDefinitionPath = abs
+												fullDefinitionPath = utils.JoinRefPath(defRoot, uri[0])
 												componentName = uri[0]
 											}
 										}
@@ -343,6 +344,8 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 					}
 
 					_, p := utils.ConvertComponentIdIntoFriendlyPathSearch(componentName)
+					fullDefinitionPath, componentName, name, p = intern(fullDefinitionPath), intern(componentName),
+						intern(name), intern(p)
 
 					ref := &Reference{
 						ParentNode:     parent,
@@ -412,10 +415,10 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 
 					if value == "" {
 
-						completedPath := fmt.Sprintf("$.%s", strings.Join(fp, "."))
+						completedPath := "$." + strings.Join(seenPath, ".")
 
 						indexError := &IndexingError{
-							Err:  errors.New("schema reference is empty and cannot be processed"),
+							Err:  datamodel.NewError(ErrRefInvalid, nil, "schema reference is empty and cannot be processed"),
 							Node: node.Content[i+1],
 							Path: completedPath,
 						}
@@ -438,9 +441,13 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 					v = strings.Replace(v, "/", "~1", 1)
 				}
 
-				loc := append(seenPath, v)
-				definitionPath := fmt.Sprintf("#/%s", strings.Join(loc, "/"))
-				_, jsonPath := utils.ConvertComponentIdIntoFriendlyPathSearch(definitionPath)
+				// only the keys captured below need a path, building one for every key is expensive.
+				var jsonPath string
+				switch n.Value {
+				case "description", "summary", "security", "enum", "properties":
+					loc := append(seenPath, v)
+					_, jsonPath = utils.ConvertComponentIdIntoFriendlyPathSearch("#/" + strings.Join(loc, "/"))
+				}
 
 				// capture descriptions and summaries
 				if n.Value == "description" {
@@ -610,9 +617,6 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 			}
 		}
 	}
-
-	index.refCount = len(index.allRefs)
-
 	return found
 }
 
@@ -624,7 +628,7 @@ func (index *SpecIndex) ExtractComponentsFromRefs(refs []*Reference) []*Referenc
 	// run this async because when things get recursive, it can take a while
 	var c chan bool
 	if !index.config.ExtractRefsSequentially {
-		c = make(chan bool)
+		c = make(chan bool, len(refs))
 	}
 
 	locate := func(ref *Reference, refIndex int, sequence []*ReferenceMapped) {
@@ -659,7 +663,7 @@ func (index *SpecIndex) ExtractComponentsFromRefs(refs []*Reference) []*Referenc
 				index.refLock.Lock()
 				if index.allMappedRefs[ref.FullDefinition] == nil {
 					found = append(found, located)
-					index.allMappedRefs[located.FullDefinition] = located
+					index.mapReference(located)
 				}
 				rm := &ReferenceMapped{
 					OriginalReference: ref,
@@ -674,7 +678,7 @@ func (index *SpecIndex) ExtractComponentsFromRefs(refs []*Reference) []*Referenc
 
 				_, path := utils.ConvertComponentIdIntoFriendlyPathSearch(ref.Definition)
 				indexError := &IndexingError{
-					Err:     fmt.Errorf("component `%s` does not exist in the specification", ref.Definition),
+					Err:     datamodel.NewError(ErrRefMissing, nil, "component `%s` does not exist in the specification", ref.Definition),
 					Node:    ref.Node,
 					Path:    path,
 					KeyNode: ref.KeyNode,
@@ -697,7 +701,7 @@ func (index *SpecIndex) ExtractComponentsFromRefs(refs []*Reference) []*Referenc
 	for r := range refsToCheck {
 		// expand our index of all mapped refs
 		if !index.config.ExtractRefsSequentially {
-			go locate(refsToCheck[r], r, mappedRefsInSequence) // run async
+			utils.Go(func() { locate(refsToCheck[r], r, mappedRefsInSequence) }) // run async
 		} else {
 			locate(refsToCheck[r], r, mappedRefsInSequence) // run synchronously
 		}
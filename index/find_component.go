@@ -57,11 +57,35 @@ func FindComponent(root *yaml.Node, componentId, absoluteFilePath string, index
 	if friendlySearch == "$." {
 		friendlySearch = "$"
 	}
-	path, err := yamlpath.NewPath(friendlySearch)
-	if path == nil || err != nil || root == nil {
-		return nil // no component found
+	if root == nil {
+		return nil
+	}
+
+	// local references are JSON pointers, so evaluate them as one, which is exact and much faster than a JSON Path
+	// search. If that fails, fall back to the friendly search.
+	var res []*yaml.Node
+	if strings.HasPrefix(componentId, "#/") {
+		find := func() *yaml.Node {
+			n, _ := utils.FindNodeByJSONPointer(root, componentId)
+			return n
+		}
+		var n *yaml.Node
+		if index != nil && index.config != nil && index.config.SharedResolutionCache != nil {
+			n = index.config.SharedResolutionCache.Subtree(root, componentId, find)
+		} else {
+			n = find()
+		}
+		if n != nil {
+			res = []*yaml.Node{n}
+		}
+	}
+	if res == nil {
+		path, err := yamlpath.NewPath(friendlySearch)
+		if path == nil || err != nil {
+			return nil // no component found
+		}
+		res, _ = path.Find(root)
 	}
-	res, _ := path.Find(root)
 
 	if len(res) == 1 {
 		resNode := res[0]
@@ -200,6 +200,36 @@ components:
 	assert.Len(t, index.GetReferenceIndexErrors(), 0)
 }
 
+func TestSpecIndex_FindComponentInRoot_JSONPointer(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+ schemas:
+   pizza/slice:
+     type: object
+   cheese~ham:
+     type: string
+   "with.dot":
+     type: array
+     items:
+       - type: integer`
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	slice := index.FindComponentInRoot("#/components/schemas/pizza~1slice")
+	assert.NotNil(t, slice)
+	assert.Equal(t, 5, slice.Node.Line)
+
+	cheese := index.FindComponentInRoot("#/components/schemas/cheese~0ham")
+	assert.NotNil(t, cheese)
+	assert.Equal(t, 7, cheese.Node.Line)
+
+	item := index.FindComponentInRoot("#/components/schemas/with.dot/items/0")
+	assert.NotNil(t, item)
+	assert.Equal(t, 11, item.Node.Line)
+}
+
 func TestSpecIndex_FailFindComponentInRoot(t *testing.T) {
 
 	index := &SpecIndex{}
@@ -275,6 +305,7 @@ paths:
 	index := NewSpecIndexWithConfig(&rootNode, c)
 	assert.Len(t, index.GetReferenceIndexErrors(), 1)
 	assert.Equal(t, "component `#/paths/~1pet~1%$petId%7D/get/parameters` does not exist in the specification", index.GetReferenceIndexErrors()[0].Error())
+	assert.ErrorIs(t, index.GetReferenceIndexErrors()[0], ErrRefMissing)
 }
 
 func TestSpecIndex_LocateRemoteDocsWithEscapedCharacters(t *testing.T) {
@@ -4,6 +4,7 @@
 package index
 
 import (
+	"context"
 	"github.com/pb33f/libopenapi/datamodel"
 	"io/fs"
 	"log/slog"
@@ -150,10 +151,50 @@ type SpecIndexConfig struct {
 	// to be bundled.
 	ExtractRefsSequentially bool
 
+	// Context governs the work done by the rolodex and index. When it is cancelled, or its deadline is exceeded,
+	// no more remote files are fetched and no more indexes are built. If not set, the work can't be cancelled.
+	Context context.Context
+
+	// SharedResolutionCache shares the node trees of the files of the rolodex, and the subtrees located in them,
+	// with every other index (of any document) that uses the same cache, see datamodel.SharedResolutionCache.
+	SharedResolutionCache *datamodel.SharedResolutionCache
+
+	// ParseLimits are checked against every file the rolodex parses, so a file referenced by a specification can't
+	// exhaust memory by holding too many nodes, or aliases that expand into too many ('billion laughs'). A file that
+	// breaks them is not indexed, and the error (like datamodel.ErrAliasExpansionTooLarge) is returned by the
+	// rolodex. See datamodel.ParseLimits, no limits are set by default.
+	ParseLimits datamodel.ParseLimits
+
+	// YAMLEngine parses every file the rolodex reads, see datamodel.YAMLEngine. If not set,
+	// datamodel.DefaultYAMLEngine (gopkg.in/yaml.v3) is used.
+	YAMLEngine datamodel.YAMLEngine
+
+	// MaxResolveDepth is the deepest the resolver will walk into the maps and sequences of a reference, including
+	// those of the references it follows, and the deepest journey through references it will take, when checking
+	// for circular references and resolving. The walks are recursive, so they stop here rather than risk the stack,
+	// and the reference is reported with ErrTooDeep. Zero (the default) means DefaultMaxResolveDepth.
+	MaxResolveDepth int
+
 	// private fields
 	uri []string
 }
 
+// getContext returns the context that governs the index, or context.Background() if there isn't one.
+// getYAMLEngine returns the YAMLEngine of the configuration, or nil if there is no configuration.
+func (s *SpecIndexConfig) getYAMLEngine() datamodel.YAMLEngine {
+	if s == nil {
+		return nil
+	}
+	return s.YAMLEngine
+}
+
+func (s *SpecIndexConfig) getContext() context.Context {
+	if s == nil || s.Context == nil {
+		return context.Background()
+	}
+	return s.Context
+}
+
 // SetTheoreticalRoot sets the spec file paths to point to a theoretical spec file, which does not exist but is required
 // in order to formulate the absolute path to root references correctly.
 func (s *SpecIndexConfig) SetTheoreticalRoot() {
@@ -195,6 +236,7 @@ type SpecIndex struct {
 	rawSequencedRefs                    []*Reference                                  // all raw references in sequence as they are scanned, not deduped.
 	linesWithRefs                       map[int]bool                                  // lines that link to references.
 	allMappedRefs                       map[string]*Reference                         // these are the located mapped refs
+	mappedRefLookup                     map[string]*Reference                         // located mapped refs by absolute location and pointer
 	allMappedRefsSequenced              []*ReferenceMapped                            // sequenced mapped refs
 	refsByLine                          map[string]map[int]bool                       // every reference and the lines it's referenced from
 	pathRefs                            map[string]map[string]*Reference              // all path references
@@ -214,6 +256,9 @@ type SpecIndex struct {
 	operationTagsRefs                   map[string]map[string][]*Reference            // tags found in operations
 	operationDescriptionRefs            map[string]map[string]*Reference              // descriptions in operations.
 	operationSummaryRefs                map[string]map[string]*Reference              // summaries in operations
+	operationIdRefs                     map[string]map[string]*Reference              // operationIds in operations
+	operationIdLookup                   map[string]operationIdLocation                // first operation of each operationId
+	operationIdDuplicates               map[string][]*Reference                       // operationIds used more than once
 	callbackRefs                        map[string]*Reference                         // top level callback refs
 	serversRefs                         []*Reference                                  // all top level server refs
 	rootServersNode                     *yaml.Node                                    // servers root node
@@ -226,6 +271,9 @@ type SpecIndex struct {
 	rootSecurity                        []*Reference                                  // root security definitions.
 	rootSecurityNode                    *yaml.Node                                    // root security node.
 	refsWithSiblings                    map[string]Reference                          // references with sibling elements next to them
+	schemaRelativeRefs                  sync.Map                                      // $ref nodes pointing to an enclosing schema's $defs, mapped to absolute definitions
+	nodeParents                         map[*yaml.Node]*yaml.Node                     // the parent of every value in the document, mapped for schema relative refs
+	nodeParentsOnce                     sync.Once                                     // maps nodeParents once
 	pathRefsLock                        sync.RWMutex                                  // create lock for all refs maps, we want to build data as fast as we can
 	externalDocumentsCount              int                                           // number of externalDocument nodes found
 	operationTagsCount                  int                                           // number of unique tags in operations
@@ -297,6 +345,7 @@ type SpecIndex struct {
 	nodeMapCompleted                    chan bool
 	pendingResolve                      []refMap
 	highModelCache                      Cache
+	sharedTree                          bool // the node tree is shared with other documents, it must not be changed.
 }
 
 // GetResolver returns the resolver for this index.
@@ -343,6 +392,11 @@ func (i *IndexingError) Error() string {
 	return i.Err.Error()
 }
 
+// Unwrap returns the Err of the error, so the kind of error (like ErrRefMissing) can be matched with errors.Is.
+func (i *IndexingError) Unwrap() error {
+	return i.Err
+}
+
 // DescriptionReference holds data about a description that was found and where it was found.
 type DescriptionReference struct {
 	Content    string
@@ -31,6 +31,7 @@ func isHttpMethod(val string) bool {
 func boostrapIndexCollections(index *SpecIndex) {
 	index.allRefs = make(map[string]*Reference)
 	index.allMappedRefs = make(map[string]*Reference)
+	index.mappedRefLookup = make(map[string]*Reference)
 	index.refsByLine = make(map[string]map[int]bool)
 	index.linesWithRefs = make(map[int]bool)
 	index.pathRefs = make(map[string]map[string]*Reference)
@@ -38,6 +39,9 @@ func boostrapIndexCollections(index *SpecIndex) {
 	index.operationTagsRefs = make(map[string]map[string][]*Reference)
 	index.operationDescriptionRefs = make(map[string]map[string]*Reference)
 	index.operationSummaryRefs = make(map[string]map[string]*Reference)
+	index.operationIdRefs = make(map[string]map[string]*Reference)
+	index.operationIdLookup = make(map[string]operationIdLocation)
+	index.operationIdDuplicates = make(map[string][]*Reference)
 	index.paramCompRefs = make(map[string]*Reference)
 	index.paramAllRefs = make(map[string]*Reference)
 	index.paramInlineDuplicateNames = make(map[string][]*Reference)
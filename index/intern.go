@@ -0,0 +1,17 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import "unique"
+
+// intern returns the canonical copy of a string, so that identical strings (like the definitions of references,
+// which are built again for every reference, and property names) share the same storage instead of each holding
+// their own copy. Large specifications (and sets of them) repeat the same few thousand strings millions of times.
+// A canonical copy is released by the garbage collector once nothing holds it.
+func intern(s string) string {
+	if s == "" {
+		return s
+	}
+	return unique.Make(s).Value()
+}
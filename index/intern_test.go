@@ -0,0 +1,79 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// stringData returns the address of the storage of a string.
+func stringData(s string) uintptr {
+	return uintptr(unsafe.Pointer(unsafe.StringData(s)))
+}
+
+func TestIntern(t *testing.T) {
+	a := strings.Clone("#/components/schemas/Pet")
+	b := strings.Clone(a)
+	assert.NotEqual(t, stringData(a), stringData(b))
+	assert.Equal(t, stringData(intern(a)), stringData(intern(b)))
+	assert.Equal(t, a, intern(b))
+	assert.Equal(t, "", intern(""))
+}
+
+func TestSpecIndex_InternsReferences(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &root)
+	before := make(map[*yaml.Node]uintptr)
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		before[n] = stringData(n.Value)
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(&root)
+
+	idx := NewSpecIndexWithConfig(&root, CreateOpenAPIIndexConfig())
+
+	refs := idx.GetRawReferencesSequenced()
+	assert.Len(t, refs, 2)
+	assert.Equal(t, stringData(refs[0].FullDefinition), stringData(refs[1].FullDefinition))
+
+	// the parsed nodes are never changed while indexing, they may be read by other goroutines.
+	for n, data := range before {
+		assert.Equal(t, data, stringData(n.Value))
+	}
+
+	schema := idx.GetAllComponentSchemas()["#/components/schemas/Pet"]
+	assert.Equal(t, stringData(schema.Definition), stringData(refs[0].Definition))
+}
@@ -47,8 +47,8 @@ func (index *SpecIndex) GetNode(line int, column int) (*yaml.Node, bool) {
 // MapNodes maps all nodes in the document to a map of line/column to node.
 func (index *SpecIndex) MapNodes(rootNode *yaml.Node) {
 	cruising := make(chan bool)
-	nodeChan := make(chan *nodeMap)
-	go func(nodeChan chan *nodeMap) {
+	nodeChan := make(chan nodeMap)
+	go func(nodeChan chan nodeMap) {
 		done := false
 		for !done {
 			node, ok := <-nodeChan
@@ -70,10 +70,18 @@ func (index *SpecIndex) MapNodes(rootNode *yaml.Node) {
 	close(index.nodeMapCompleted)
 }
 
-func enjoyALuxuryCruise(node *yaml.Node, nodeChan chan *nodeMap, root bool) {
+// lastLine returns the line of the last node in a document, which is (close to) the number of lines that hold nodes.
+func lastLine(node *yaml.Node) int {
+	for len(node.Content) > 0 {
+		node = node.Content[len(node.Content)-1]
+	}
+	return node.Line
+}
+
+func enjoyALuxuryCruise(node *yaml.Node, nodeChan chan nodeMap, root bool) {
 	if len(node.Content) > 0 {
 		for _, child := range node.Content {
-			nodeChan <- &nodeMap{
+			nodeChan <- nodeMap{
 				line:   child.Line,
 				column: child.Column,
 				node:   child,
@@ -81,7 +89,7 @@ func enjoyALuxuryCruise(node *yaml.Node, nodeChan chan *nodeMap, root bool) {
 			enjoyALuxuryCruise(child, nodeChan, false)
 		}
 	}
-	nodeChan <- &nodeMap{
+	nodeChan <- nodeMap{
 		line:   node.Line,
 		column: node.Column,
 		node:   node,
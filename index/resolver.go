@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"slices"
@@ -51,6 +52,11 @@ func (r *ResolvingError) Error() string {
 	return strings.Join(msgs, "\n")
 }
 
+// Unwrap returns the ErrorRef of the error, so the kind of error (like ErrRefMissing) can be matched with errors.Is.
+func (r *ResolvingError) Unwrap() error {
+	return r.ErrorRef
+}
+
 // Resolver will use a *index.SpecIndex to stitch together a resolved root tree using all the discovered
 // references in the doc.
 type Resolver struct {
@@ -67,6 +73,7 @@ type Resolver struct {
 	IgnorePoly             bool
 	IgnoreArray            bool
 	circChecked            bool
+	tooDeep                map[string]bool
 }
 
 // NewResolver will create a new resolver from a *index.SpecIndex
@@ -82,6 +89,47 @@ func NewResolver(index *SpecIndex) *Resolver {
 	return r
 }
 
+// DefaultMaxResolveDepth is the MaxResolveDepth of an index configuration that does not set one.
+const DefaultMaxResolveDepth = 500
+
+// maxResolveDepth returns the MaxResolveDepth of the index configuration, or DefaultMaxResolveDepth.
+func (resolver *Resolver) maxResolveDepth() int {
+	if resolver.specIndex != nil && resolver.specIndex.config != nil && resolver.specIndex.config.MaxResolveDepth > 0 {
+		return resolver.specIndex.config.MaxResolveDepth
+	}
+	return DefaultMaxResolveDepth
+}
+
+// reportTooDeep reports a reference the resolver stopped walking into, because it's nested deeper than the maximum
+// resolve depth. It's reported with ErrTooDeep, which like a circular reference, does not stop a model from being
+// built, only the reference may not be resolved completely.
+func (resolver *Resolver) reportTooDeep(ref *Reference, node *yaml.Node) {
+	maxDepth := resolver.maxResolveDepth()
+	def := "unknown"
+	if ref != nil {
+		def = ref.FullDefinition
+	}
+	if resolver.specIndex != nil && resolver.specIndex.GetLogger() != nil {
+		resolver.specIndex.GetLogger().Warn("libopenapi resolver: relative depth exceeded the maximum resolve depth, "+
+			"resolving may be incomplete", "reference", def, "depth", maxDepth)
+	}
+	// deep references are walked by both the circular check and resolving, they are only reported once.
+	if resolver.tooDeep[def] {
+		return
+	}
+	if resolver.tooDeep == nil {
+		resolver.tooDeep = make(map[string]bool)
+	}
+	resolver.tooDeep[def] = true
+	_, path := utils.ConvertComponentIdIntoFriendlyPathSearch(def)
+	resolver.resolvingErrors = append(resolver.resolvingErrors, &ResolvingError{
+		ErrorRef: datamodel.NewError(ErrTooDeep, nil, "reference `%s` is nested deeper than the "+
+			"maximum resolve depth of %d", def, maxDepth),
+		Node: node,
+		Path: path,
+	})
+}
+
 // GetIgnoredCircularPolyReferences returns all ignored circular references that are polymorphic
 func (resolver *Resolver) GetIgnoredCircularPolyReferences() []*CircularReferenceResult {
 	return resolver.ignoredPolyReferences
@@ -189,6 +237,9 @@ func (resolver *Resolver) GetRelativesSeen() int {
 // this data can get big, it results in a massive duplication of data. This is a destructive method and will permanently
 // re-organize the node tree. Make sure you have copied your original tree before running this (if you want to preserve
 // original data)
+//
+// Resolved content is spliced into every node that references it, without being copied, so it's stored once no matter
+// how many times it's referenced. Changing the nodes of resolved content changes them everywhere they are spliced.
 func (resolver *Resolver) Resolve() []*ResolvingError {
 	visitIndex(resolver, resolver.specIndex)
 
@@ -200,7 +251,8 @@ func (resolver *Resolver) Resolve() []*ResolvingError {
 
 		if !resolver.circChecked {
 			resolver.resolvingErrors = append(resolver.resolvingErrors, &ResolvingError{
-				ErrorRef:          fmt.Errorf("infinite circular reference detected: %s", circRef.Start.Definition),
+				ErrorRef: datamodel.NewError(ErrCircularReference, nil, "infinite circular reference detected: %s",
+					circRef.Start.Definition),
 				Node:              circRef.ParentNode,
 				Path:              circRef.GenerateJourneyPath(),
 				CircularReference: circRef,
@@ -211,6 +263,7 @@ func (resolver *Resolver) Resolve() []*ResolvingError {
 	resolver.specIndex.SetIgnoredArrayCircularReferences(resolver.ignoredArrayReferences)
 	resolver.specIndex.SetIgnoredPolymorphicCircularReferences(resolver.ignoredPolyReferences)
 	resolver.circChecked = true
+	resolver.logCompleted("[resolver] resolving completed")
 	return resolver.resolvingErrors
 }
 
@@ -224,7 +277,8 @@ func (resolver *Resolver) CheckForCircularReferences() []*ResolvingError {
 		}
 		if !resolver.circChecked {
 			resolver.resolvingErrors = append(resolver.resolvingErrors, &ResolvingError{
-				ErrorRef:          fmt.Errorf("infinite circular reference detected: %s", circRef.Start.Name),
+				ErrorRef: datamodel.NewError(ErrCircularReference, nil, "infinite circular reference detected: %s",
+					circRef.Start.Name),
 				Node:              circRef.ParentNode,
 				Path:              circRef.GenerateJourneyPath(),
 				CircularReference: circRef,
@@ -236,9 +290,21 @@ func (resolver *Resolver) CheckForCircularReferences() []*ResolvingError {
 	resolver.specIndex.SetIgnoredArrayCircularReferences(resolver.ignoredArrayReferences)
 	resolver.specIndex.SetIgnoredPolymorphicCircularReferences(resolver.ignoredPolyReferences)
 	resolver.circChecked = true
+	resolver.logCompleted("[resolver] circular reference check completed")
 	return resolver.resolvingErrors
 }
 
+// logCompleted logs the work done by the resolver at the Debug level.
+func (resolver *Resolver) logCompleted(msg string) {
+	logger := resolver.specIndex.GetLogger()
+	if logger == nil {
+		return
+	}
+	logger.Debug(msg, "file", resolver.specIndex.GetSpecAbsolutePath(),
+		"references", resolver.referencesVisited, "journeys", resolver.journeysTaken,
+		"circular", len(resolver.circularReferences), "errors", len(resolver.resolvingErrors))
+}
+
 func visitIndexWithoutDamagingIt(res *Resolver, idx *SpecIndex) {
 	mapped := idx.GetMappedReferencesSequenced()
 	mappedIndex := idx.GetMappedReferences()
@@ -315,7 +381,7 @@ func visitIndex(res *Resolver, idx *SpecIndex) {
 		locatedDef := mappedIndex[sequenced.Definition]
 		if locatedDef != nil {
 			if !locatedDef.Circular && locatedDef.Seen {
-				sequenced.Node.Content = locatedDef.Node.Content
+				splice(sequenced.Node, locatedDef.Node.Content)
 			}
 		}
 	}
@@ -333,6 +399,11 @@ func (resolver *Resolver) VisitReference(ref *Reference, seen map[string]bool, j
 		return ref.Node.Content
 	}
 
+	if len(journey) >= resolver.maxResolveDepth() {
+		resolver.reportTooDeep(ref, ref.Node)
+		return ref.Node.Content
+	}
+
 	journey = append(journey, ref)
 	seenRelatives := make(map[int]bool)
 	relatives := resolver.extractRelatives(ref, ref.Node, nil, seen, journey, seenRelatives, resolve, 0)
@@ -358,7 +429,7 @@ func (resolver *Resolver) VisitReference(ref *Reference, seen map[string]bool, j
 
 					visitedDefinitions := make(map[string]bool)
 					isInfiniteLoop, _ := resolver.isInfiniteCircularDependency(foundDup,
-						visitedDefinitions, nil)
+						visitedDefinitions, nil, 0)
 
 					isArray := false
 					if r.ParentNodeSchemaType == "array" || slices.Contains(r.ParentNodeTypes, "array") {
@@ -402,7 +473,7 @@ func (resolver *Resolver) VisitReference(ref *Reference, seen map[string]bool, j
 			if resolve && !original.Circular {
 				ref.Resolved = true
 				r.Resolved = true
-				r.Node.Content = resolved // this is where we perform the actual resolving.
+				splice(r.Node, resolved) // this is where we perform the actual resolving.
 			}
 			r.Seen = true
 			ref.Seen = true
@@ -415,11 +486,15 @@ func (resolver *Resolver) VisitReference(ref *Reference, seen map[string]bool, j
 }
 
 func (resolver *Resolver) isInfiniteCircularDependency(ref *Reference, visitedDefinitions map[string]bool,
-	initialRef *Reference,
+	initialRef *Reference, depth int,
 ) (bool, map[string]bool) {
 	if ref == nil {
 		return false, visitedDefinitions
 	}
+	if depth > resolver.maxResolveDepth() {
+		resolver.reportTooDeep(ref, ref.Node)
+		return false, visitedDefinitions
+	}
 	for refDefinition := range ref.RequiredRefProperties {
 		r, _ := resolver.specIndex.SearchIndexForReference(refDefinition)
 		if initialRef != nil && initialRef.FullDefinition == r.FullDefinition {
@@ -442,7 +517,7 @@ func (resolver *Resolver) isInfiniteCircularDependency(ref *Reference, visitedDe
 
 		var isChildICD bool
 
-		isChildICD, visitedDefinitions = resolver.isInfiniteCircularDependency(r, visitedDefinitions, ir)
+		isChildICD, visitedDefinitions = resolver.isInfiniteCircularDependency(r, visitedDefinitions, ir, depth+1)
 		if isChildICD {
 			return true, visitedDefinitions
 		}
@@ -460,29 +535,8 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 	}
 
 	// this is a safety check to prevent a stack overflow.
-	if depth > 500 {
-		def := "unknown"
-		if ref != nil {
-			def = ref.FullDefinition
-		}
-		if resolver.specIndex != nil && resolver.specIndex.logger != nil {
-			resolver.specIndex.logger.Warn("libopenapi resolver: relative depth exceeded 100 levels, "+
-				"check for circular references - resolving may be incomplete",
-				"reference", def)
-		}
-
-		loop := append(journey, ref)
-		circRef := &CircularReferenceResult{
-			Journey:        loop,
-			Start:          ref,
-			LoopIndex:      depth,
-			LoopPoint:      ref,
-			IsInfiniteLoop: true,
-		}
-		if !resolver.circChecked {
-			resolver.circularReferences = append(resolver.circularReferences, circRef)
-			ref.Circular = true
-		}
+	if depth > resolver.maxResolveDepth() {
+		resolver.reportTooDeep(ref, node)
 		return nil
 	}
 
@@ -523,6 +577,13 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 
 				value := node.Content[i+1].Value
 				value = strings.ReplaceAll(value, "\\\\", "\\")
+
+				// references to the $defs of an enclosing schema are mapped to an absolute definition.
+				if def := resolver.specIndex.GetSchemaRelativeDefinition(node); def != "" {
+					value = def
+				} else if def = ref.Index.GetSchemaRelativeDefinition(node); def != "" {
+					value = def
+				}
 				var locatedRef *Reference
 				var fullDef string
 				var definition string
@@ -535,30 +596,8 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 						if strings.HasPrefix(exp[0], "http") {
 							fullDef = value
 						} else {
-
-							if strings.HasPrefix(ref.FullDefinition, "http") {
-
-								// split the http URI into parts
-								httpExp := strings.Split(ref.FullDefinition, "#/")
-
-								u, _ := url.Parse(httpExp[0])
-								abs, _ := filepath.Abs(utils.CheckPathOverlap(filepath.Dir(u.Path), exp[0], string(filepath.Separator)))
-								u.Path = utils.ReplaceWindowsDriveWithLinuxPath(abs)
-								u.Fragment = ""
-								fullDef = fmt.Sprintf("%s#/%s", u.String(), exp[1])
-
-							} else {
-
-								// split the referring ref full def into parts
-								fileDef := strings.Split(ref.FullDefinition, "#/")
-
-								// extract the location of the ref and build a full def path.
-								abs, _ := filepath.Abs(utils.CheckPathOverlap(filepath.Dir(fileDef[0]), exp[0], string(filepath.Separator)))
-								//abs = utils.ReplaceWindowsDriveWithLinuxPath(abs)
-								fullDef = fmt.Sprintf("%s#/%s", abs, exp[1])
-
-							}
-
+							// extract the location of the ref and build a full def path.
+							fullDef = fmt.Sprintf("%s#/%s", utils.ResolveRefPath(ref.FullDefinition, exp[0]), exp[1])
 						}
 					} else {
 						// local component, full def is based on passed in ref
@@ -587,21 +626,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 					if strings.HasPrefix(value, "http") {
 						fullDef = value
 					} else {
-
-						// split the full def into parts
-						fileDef := strings.Split(ref.FullDefinition, "#/")
-
-						// is the file def a http link?
-						if strings.HasPrefix(fileDef[0], "http") {
-							u, _ := url.Parse(fileDef[0])
-							path, _ := filepath.Abs(utils.CheckPathOverlap(filepath.Dir(u.Path), exp[0], string(filepath.Separator)))
-							u.Path = utils.ReplaceWindowsDriveWithLinuxPath(path)
-							fullDef = u.String()
-
-						} else {
-							fullDef, _ = filepath.Abs(utils.CheckPathOverlap(filepath.Dir(fileDef[0]), exp[0], string(filepath.Separator)))
-						}
-
+						fullDef = utils.ResolveRefPath(ref.FullDefinition, exp[0])
 					}
 				}
 
@@ -617,7 +642,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 				if locatedRef == nil {
 					_, path := utils.ConvertComponentIdIntoFriendlyPathSearch(value)
 					err := &ResolvingError{
-						ErrorRef: fmt.Errorf("cannot resolve reference `%s`, it's missing", value),
+						ErrorRef: datamodel.NewError(ErrRefMissing, nil, "cannot resolve reference `%s`, it's missing", value),
 						Node:     n,
 						Path:     path,
 					}
@@ -628,7 +653,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 				if resolve {
 					// if this is a reference also, we want to resolve it.
 					if ok, _, _ := utils.IsNodeRefValue(ref.Node); ok {
-						ref.Node.Content = locatedRef.Node.Content
+						splice(ref.Node, locatedRef.Node.Content)
 						ref.Resolved = true
 					}
 				}
@@ -815,30 +840,7 @@ func (resolver *Resolver) buildDefPath(ref *Reference, l string) string {
 		if exp[0] != "" {
 			if !strings.HasPrefix(exp[0], "http") {
 				if !filepath.IsAbs(exp[0]) {
-					if strings.HasPrefix(ref.FullDefinition, "http") {
-
-						u, _ := url.Parse(ref.FullDefinition)
-						p, _ := filepath.Abs(utils.CheckPathOverlap(filepath.Dir(u.Path), exp[0], string(filepath.Separator)))
-						u.Path = utils.ReplaceWindowsDriveWithLinuxPath(p)
-						def = fmt.Sprintf("%s#/%s", u.String(), exp[1])
-
-					} else {
-						z := strings.Split(ref.FullDefinition, "#/")
-						if len(z) == 2 {
-							if len(z[0]) > 0 {
-								abs, _ := filepath.Abs(utils.CheckPathOverlap(filepath.Dir(z[0]),
-									exp[0], string(filepath.Separator)))
-								def = fmt.Sprintf("%s#/%s", abs, exp[1])
-							} else {
-								abs, _ := filepath.Abs(exp[0])
-								def = fmt.Sprintf("%s#/%s", abs, exp[1])
-							}
-						} else {
-							abs, _ := filepath.Abs(utils.CheckPathOverlap(filepath.Dir(ref.FullDefinition),
-								exp[0], string(filepath.Separator)))
-							def = fmt.Sprintf("%s#/%s", abs, exp[1])
-						}
-					}
+					def = fmt.Sprintf("%s#/%s", utils.ResolveRefPath(ref.FullDefinition, exp[0]), exp[1])
 				}
 			} else {
 				if len(exp[1]) > 0 {
@@ -866,22 +868,7 @@ func (resolver *Resolver) buildDefPath(ref *Reference, l string) string {
 		if strings.HasPrefix(l, "http") {
 			def = l
 		} else {
-
-			// check if were dealing with a remote file
-			if strings.HasPrefix(ref.FullDefinition, "http") {
-
-				// split the url.
-				u, _ := url.Parse(ref.FullDefinition)
-				abs, _ := filepath.Abs(utils.CheckPathOverlap(filepath.Dir(u.Path), l, string(filepath.Separator)))
-				u.Path = utils.ReplaceWindowsDriveWithLinuxPath(abs)
-				u.Fragment = ""
-				def = u.String()
-			} else {
-				lookupRef := strings.Split(ref.FullDefinition, "#/")
-				abs, _ := filepath.Abs(utils.CheckPathOverlap(filepath.Dir(lookupRef[0]), l, string(filepath.Separator)))
-				def = abs
-			}
-
+			def = utils.ResolveRefPath(ref.FullDefinition, l)
 		}
 	}
 
@@ -891,7 +878,12 @@ func (resolver *Resolver) buildDefPath(ref *Reference, l string) string {
 func (resolver *Resolver) ResolvePendingNodes() {
 	// map everything afterwards
 	for _, r := range resolver.specIndex.pendingResolve {
-		// r.Node.Content = refs[r].nodes
-		r.ref.Node.Content = r.nodes
+		splice(r.ref.Node, r.nodes)
 	}
 }
+
+// splice shares the content of a referenced node with the node referencing it. The content is clipped to its
+// length, so appending to one node's content never writes over another's.
+func splice(node *yaml.Node, content []*yaml.Node) {
+	node.Content = slices.Clip(content)
+}
@@ -12,9 +12,11 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
 
@@ -22,6 +24,74 @@ func TestNewResolver(t *testing.T) {
 	assert.Nil(t, NewResolver(nil))
 }
 
+func TestResolver_Resolve_SharesContent(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+    Cat:
+      $ref: '#/components/schemas/Pet'
+    Dog:
+      $ref: '#/components/schemas/Pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	rolo := NewRolodex(CreateOpenAPIIndexConfig())
+	rolo.SetRootNode(&rootNode)
+	assert.NoError(t, rolo.IndexTheRolodex())
+	rolo.Resolve()
+
+	schemas := rootNode.Content[0].Content[3].Content[1]
+	pet, cat, dog := schemas.Content[1], schemas.Content[3], schemas.Content[5]
+
+	// both references share the content of the schema they reference.
+	assert.Len(t, cat.Content, 4)
+	assert.Same(t, pet.Content[1], cat.Content[1])
+	assert.Same(t, pet.Content[3], dog.Content[3])
+
+	// appending to shared content never writes over the content it's shared with.
+	cat.Content = append(cat.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "x-cat"})
+	assert.Len(t, dog.Content, 4)
+	assert.Len(t, pet.Content, 4)
+}
+
+func TestResolver_Logger(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    One:
+      type: object
+      properties:
+        two:
+          $ref: '#/components/schemas/Two'
+    Two:
+      type: object
+      required: [one]
+      properties:
+        one:
+          $ref: '#/components/schemas/One'`
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	var buf bytes.Buffer
+	config := CreateClosedAPIIndexConfig()
+	config.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	idx := NewSpecIndexWithConfig(&rootNode, config)
+	resolver := NewResolver(idx)
+	resolver.CheckForCircularReferences()
+	assert.Contains(t, buf.String(), `"msg":"[resolver] circular reference check completed"`)
+	assert.Contains(t, buf.String(), `"circular":1`)
+
+	buf.Reset()
+	resolver.Resolve()
+	assert.Contains(t, buf.String(), `"msg":"[resolver] resolving completed"`)
+}
+
 func TestResolvingError_Error(t *testing.T) {
 
 	errs := []error{
@@ -140,6 +210,9 @@ func TestResolver_CheckForCircularReferences(t *testing.T) {
 	rolo.CheckForCircularReferences()
 
 	assert.Len(t, rolo.GetCaughtErrors(), 3)
+	for _, err := range rolo.GetCaughtErrors() {
+		assert.ErrorIs(t, err, ErrCircularReference)
+	}
 	assert.Len(t, rolo.GetRootIndex().GetResolver().GetResolvingErrors(), 3)
 	assert.Len(t, rolo.GetRootIndex().GetResolver().GetInfiniteCircularReferences(), 3)
 
@@ -484,7 +557,52 @@ func TestResolver_DeepDepth(t *testing.T) {
 	found := resolver.extractRelatives(ref, refA, nil, nil, nil, nil, false, 0)
 
 	assert.Nil(t, found)
-	assert.Contains(t, buf.String(), "libopenapi resolver: relative depth exceeded 100 levels")
+	assert.Contains(t, buf.String(), "libopenapi resolver: relative depth exceeded the maximum resolve depth")
+	assert.Empty(t, resolver.GetCircularReferences())
+	assert.Len(t, resolver.GetResolvingErrors(), 1)
+	assert.ErrorIs(t, resolver.GetResolvingErrors()[0], ErrTooDeep)
+}
+
+func TestResolver_MaxResolveDepth(t *testing.T) {
+	spec := "openapi: 3.1.0\ncomponents:\n  schemas:\n    Deep:\n      " +
+		strings.Repeat("{type: object, properties: {a: ", 300) + "{type: string}" + strings.Repeat("}}", 300) + "\n"
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	// a deep schema is not a circular one, it's reported as too deep.
+	idx := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+	errs := NewResolver(idx).CheckForCircularReferences()
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrTooDeep)
+	assert.NotErrorIs(t, errs[0], ErrCircularReference)
+
+	cfg := CreateClosedAPIIndexConfig()
+	cfg.MaxResolveDepth = 1000
+	idx = NewSpecIndexWithConfig(&rootNode, cfg)
+	assert.Empty(t, NewResolver(idx).CheckForCircularReferences())
+}
+
+func TestResolver_MaxResolveDepth_Journeys(t *testing.T) {
+	cfg := CreateClosedAPIIndexConfig()
+	cfg.MaxResolveDepth = 2
+	resolver := NewResolver(NewSpecIndexWithConfig(nil, cfg))
+
+	// a journey through references as long as the maximum depth is not walked any further.
+	node := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{{Value: "type"}, {Value: "string"}}}
+	ref := &Reference{FullDefinition: "#/components/schemas/A", Node: node}
+	journey := []*Reference{{FullDefinition: "#/components/schemas/B"}, {FullDefinition: "#/components/schemas/C"}}
+	assert.Equal(t, node.Content, resolver.VisitReference(ref, nil, journey, false))
+	require.Len(t, resolver.GetResolvingErrors(), 1)
+	assert.ErrorIs(t, resolver.GetResolvingErrors()[0], ErrTooDeep)
+
+	// and neither are the required properties checked for infinite loops.
+	deep := &Reference{FullDefinition: "#/components/schemas/D", Node: node}
+	infinite, _ := resolver.isInfiniteCircularDependency(deep, map[string]bool{}, nil, 3)
+	assert.False(t, infinite)
+	require.Len(t, resolver.GetResolvingErrors(), 2)
+	assert.ErrorIs(t, resolver.GetResolvingErrors()[1], ErrTooDeep)
+	assert.Empty(t, resolver.GetCircularReferences())
 }
 
 func TestResolver_ResolveComponents_Stripe_NoRolodex(t *testing.T) {
@@ -651,6 +769,8 @@ components:
 	err := resolver.Resolve()
 	assert.Len(t, err, 2)
 	assert.Equal(t, "cannot resolve reference `go home, I am drunk`, it's missing: $go home, I am drunk [18:11]", err[0].Error())
+	assert.ErrorIs(t, err[0], ErrRefMissing)
+	assert.Equal(t, datamodel.ErrorCode("ref-missing"), datamodel.ErrorCodeOf(err[0]))
 }
 
 func TestResolver_ResolveThroughPaths(t *testing.T) {
@@ -920,7 +1040,7 @@ components:
 
 func TestResolver_isInfiniteCircularDep_NoRef(t *testing.T) {
 	resolver := NewResolver(nil)
-	a, b := resolver.isInfiniteCircularDependency(nil, nil, nil)
+	a, b := resolver.isInfiniteCircularDependency(nil, nil, nil, 0)
 	assert.False(t, a)
 	assert.Nil(t, b)
 }
@@ -1443,3 +1563,43 @@ components:
 	assert.Len(t, errs, 0)
 
 }
+
+func TestResolver_SwaggerCircularReferenceViaResponse(t *testing.T) {
+	yml := `swagger: "2.0"
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          $ref: '#/responses/Pets'
+responses:
+  Pets:
+    description: pets
+    schema:
+      $ref: '#/definitions/Pet'
+definitions:
+  Pet:
+    type: object
+    required: [owner]
+    properties:
+      owner:
+        $ref: '#/definitions/Owner'
+  Owner:
+    type: object
+    required: [pet]
+    properties:
+      pet:
+        $ref: '#/definitions/Pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	idx := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+	resolver := NewResolver(idx)
+	assert.NotNil(t, resolver)
+
+	circ := resolver.CheckForCircularReferences()
+	assert.Len(t, circ, 1)
+	assert.Len(t, resolver.GetInfiniteCircularReferences(), 1)
+	assert.Equal(t, "#/definitions/Pet", resolver.GetInfiniteCircularReferences()[0].LoopPoint.Definition)
+}
@@ -5,7 +5,6 @@ package index
 
 import (
 	"errors"
-	"fmt"
 	"io"
 	"io/fs"
 	"log/slog"
@@ -13,12 +12,15 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -70,6 +72,8 @@ type Rolodex struct {
 	rootIndex                  *SpecIndex
 	rootNode                   *yaml.Node
 	caughtErrors               []error
+	parseLimitErrors           []error
+	parseLimitLock             sync.Mutex
 	safeCircularReferences     []*CircularReferenceResult
 	infiniteCircularReferences []*CircularReferenceResult
 	ignoredCircularReferences  []*CircularReferenceResult
@@ -84,6 +88,8 @@ func NewRolodex(indexConfig *SpecIndexConfig) *Rolodex {
 		logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 			Level: slog.LevelError,
 		}))
+		// every index built by the rolodex uses the same logger.
+		indexConfig.Logger = logger
 	}
 
 	r := &Rolodex{
@@ -118,6 +124,11 @@ func (r *Rolodex) GetIndexingDuration() time.Duration {
 	return r.indexingDuration
 }
 
+// GetLogger returns the logger used by the rolodex, its file systems and every index it builds.
+func (r *Rolodex) GetLogger() *slog.Logger {
+	return r.logger
+}
+
 // GetRootIndex returns the root index of the rolodex (the entry point, the main document)
 func (r *Rolodex) GetRootIndex() *SpecIndex {
 	return r.rootIndex
@@ -138,9 +149,28 @@ func (r *Rolodex) GetIndexes() []*SpecIndex {
 	return r.indexes
 }
 
-// GetCaughtErrors returns all the errors that were caught during the indexing process.
+// GetCaughtErrors returns all the errors that were caught during the indexing process, including any file that was
+// refused for breaking the parse limits of the configuration.
 func (r *Rolodex) GetCaughtErrors() []error {
-	return r.caughtErrors
+	r.parseLimitLock.Lock()
+	defer r.parseLimitLock.Unlock()
+	if len(r.parseLimitErrors) == 0 {
+		return r.caughtErrors
+	}
+	return append(slices.Clip(r.caughtErrors), r.parseLimitErrors...)
+}
+
+// addParseLimitError records a file that was refused for breaking the parse limits, files are opened from many
+// indexes at once, so it is locked. Each file is only recorded once, no matter how many times it is referenced.
+func (r *Rolodex) addParseLimitError(err error) {
+	r.parseLimitLock.Lock()
+	defer r.parseLimitLock.Unlock()
+	for _, e := range r.parseLimitErrors {
+		if e.Error() == err.Error() {
+			return
+		}
+	}
+	r.parseLimitErrors = append(r.parseLimitErrors, err)
 }
 
 // AddLocalFS adds a local file system to the rolodex.
@@ -167,6 +197,17 @@ func (r *Rolodex) AddExternalIndex(idx *SpecIndex, location string) {
 	r.indexLock.Unlock()
 }
 
+// indexAt returns the index of the file at an absolute location, or nil if the file has not been indexed.
+func (r *Rolodex) indexAt(location string) *SpecIndex {
+	r.indexLock.Lock()
+	idx := r.indexMap[location]
+	r.indexLock.Unlock()
+	if idx != nil && idx.GetSpecAbsolutePath() == location {
+		return idx
+	}
+	return nil
+}
+
 func (r *Rolodex) AddIndex(idx *SpecIndex) {
 	if idx != nil {
 		p := idx.specAbsolutePath
@@ -238,7 +279,7 @@ func (r *Rolodex) IndexTheRolodex() error {
 				if idxFile, ko := f.(CanBeIndexed); ko {
 					wg.Add(1)
 					wait = true
-					go indexFileFunc(idxFile, f.GetFullPath())
+					utils.Go(func() { indexFileFunc(idxFile, f.GetFullPath()) })
 				}
 			}
 			if wait {
@@ -286,7 +327,13 @@ func (r *Rolodex) IndexTheRolodex() error {
 		return indexBuildQueue[i].specAbsolutePath < indexBuildQueue[j].specAbsolutePath
 	})
 
+	ctx := r.indexConfig.getContext()
 	for _, idx := range indexBuildQueue {
+		// stop building indexes if the work has been cancelled.
+		if err := ctx.Err(); err != nil {
+			caughtErrors = append(caughtErrors, err)
+			break
+		}
 		idx.BuildIndex()
 		if r.indexConfig.AvoidCircularReferenceCheck {
 			continue
@@ -339,7 +386,7 @@ func (r *Rolodex) IndexTheRolodex() error {
 		index.BuildIndex()
 		r.logger.Debug("[rolodex] root index build completed")
 
-		if !r.indexConfig.AvoidCircularReferenceCheck {
+		if !r.indexConfig.AvoidCircularReferenceCheck && ctx.Err() == nil {
 			resolvingErrors := resolver.CheckForCircularReferences()
 			r.circChecked = true
 			for e := range resolvingErrors {
@@ -361,13 +408,16 @@ func (r *Rolodex) IndexTheRolodex() error {
 	r.indexed = true
 	r.caughtErrors = caughtErrors
 	r.built = true
-	return errors.Join(caughtErrors...)
+	return errors.Join(r.GetCaughtErrors()...)
 
 }
 
 // CheckForCircularReferences checks for circular references in the rolodex.
 func (r *Rolodex) CheckForCircularReferences() {
 	if !r.circChecked {
+		if r.indexConfig.getContext().Err() != nil {
+			return
+		}
 		if r.rootIndex != nil && r.rootIndex.resolver != nil {
 			resolvingErrors := r.rootIndex.resolver.CheckForCircularReferences()
 			for e := range resolvingErrors {
@@ -386,8 +436,12 @@ func (r *Rolodex) CheckForCircularReferences() {
 	}
 }
 
-// Resolve resolves references in the rolodex.
+// Resolve resolves references in the rolodex. Resolving changes the node trees of the files, so any trees shared with
+// other documents (see SpecIndexConfig.SharedResolutionCache) are copied first.
 func (r *Rolodex) Resolve() {
+	if r.indexConfig != nil && r.indexConfig.SharedResolutionCache != nil {
+		r.caughtErrors = append(r.caughtErrors, r.DetachSharedTrees()...)
+	}
 
 	var resolvers []*Resolver
 	if r.rootIndex != nil && r.rootIndex.resolver != nil {
@@ -398,7 +452,12 @@ func (r *Rolodex) Resolve() {
 			resolvers = append(resolvers, idx.resolver)
 		}
 	}
+	ctx := r.indexConfig.getContext()
 	for _, res := range resolvers {
+		if err := ctx.Err(); err != nil {
+			r.caughtErrors = append(r.caughtErrors, err)
+			return
+		}
 		resolvingErrors := res.Resolve()
 		for e := range resolvingErrors {
 			r.caughtErrors = append(r.caughtErrors, resolvingErrors[e])
@@ -464,11 +523,11 @@ func (r *Rolodex) GetAllMappedReferences() map[string]*Reference {
 func (r *Rolodex) Open(location string) (RolodexFile, error) {
 
 	if r == nil {
-		return nil, fmt.Errorf("rolodex has not been initialized, cannot open file '%s'", location)
+		return nil, datamodel.NewError(ErrNoFileSystem, nil, "rolodex has not been initialized, cannot open file '%s'", location)
 	}
 
 	if len(r.localFS) <= 0 && len(r.remoteFS) <= 0 {
-		return nil, fmt.Errorf("rolodex has no file systems configured, cannot open '%s'. Add a BaseURL or BasePath to your configuration so the rolodex knows how to resolve references", location)
+		return nil, datamodel.NewError(ErrNoFileSystem, nil, "rolodex has no file systems configured, cannot open '%s'. Add a BaseURL or BasePath to your configuration so the rolodex knows how to resolve references", location)
 	}
 
 	var errorStack []error
@@ -483,7 +542,7 @@ func (r *Rolodex) Open(location string) (RolodexFile, error) {
 	if !isUrl {
 		if len(r.localFS) <= 0 {
 			r.logger.Warn("[rolodex] no local file systems configured, cannot open local file", "location", location)
-			return nil, fmt.Errorf("the rolodex has no local file systems configured, cannot open local file '%s'", location)
+			return nil, datamodel.NewError(ErrNoFileSystem, nil, "the rolodex has no local file systems configured, cannot open local file '%s'", location)
 		}
 
 		for k, v := range r.localFS {
@@ -527,6 +586,7 @@ func (r *Rolodex) Open(location string) (RolodexFile, error) {
 						fullPath:     fileLookup,
 						lastModified: s.ModTime(),
 						index:        r.rootIndex,
+						engine:       r.indexConfig.getYAMLEngine(),
 					}
 					break
 				}
@@ -536,8 +596,8 @@ func (r *Rolodex) Open(location string) (RolodexFile, error) {
 	} else {
 
 		if !r.indexConfig.AllowRemoteLookup {
-			return nil, fmt.Errorf("remote lookup for '%s' not allowed, please set the index configuration to "+
-				"AllowRemoteLookup to true", fileLookup)
+			return nil, datamodel.NewError(ErrLookupNotAllowed, nil, "remote lookup for '%s' not allowed, please set the "+
+				"index configuration to AllowRemoteLookup to true", fileLookup)
 		}
 
 		for _, v := range r.remoteFS {
@@ -545,6 +605,9 @@ func (r *Rolodex) Open(location string) (RolodexFile, error) {
 			f, err := v.Open(fileLookup)
 			if err != nil {
 				r.logger.Warn("[rolodex] errors opening remote file", "location", fileLookup, "error", err)
+				if datamodel.IsParseLimitError(err) {
+					errorStack = append(errorStack, err)
+				}
 			}
 			if f != nil {
 
@@ -572,6 +635,7 @@ func (r *Rolodex) Open(location string) (RolodexFile, error) {
 							fullPath:     fileLookup,
 							lastModified: s.ModTime(),
 							index:        r.rootIndex,
+							engine:       r.indexConfig.getYAMLEngine(),
 						}
 						break
 					}
@@ -596,6 +660,11 @@ func (r *Rolodex) Open(location string) (RolodexFile, error) {
 		}, errors.Join(errorStack...)
 	}
 
+	for _, err := range errorStack {
+		if datamodel.IsParseLimitError(err) {
+			r.addParseLimitError(err)
+		}
+	}
 	return nil, errors.Join(errorStack...)
 }
 
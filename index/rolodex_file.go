@@ -52,7 +52,7 @@ func (rf *rolodexFile) Index(config *SpecIndexConfig) (*SpecIndex, error) {
 	}
 
 	// first, we must parse the content of the file
-	info, err := datamodel.ExtractSpecInfoWithDocumentCheckSync(content, config.SkipDocumentCheck)
+	info, err := extractFileSpecInfo(content, config.SkipDocumentCheck, config)
 	if err != nil {
 		return nil, err
 	}
@@ -153,3 +153,41 @@ func (rf *rolodexFile) GetErrors() []error {
 	}
 	return nil
 }
+
+// extractFileSpecInfo parses the content of a file of the rolodex with the YAML engine of the configuration.
+func extractFileSpecInfo(content []byte, bypass bool, config *SpecIndexConfig) (*datamodel.SpecInfo, error) {
+	return datamodel.ExtractSpecInfoWithConfig(content, &datamodel.DocumentConfiguration{
+		BypassDocumentCheck: bypass,
+		YAMLEngine:          config.getYAMLEngine(),
+	})
+}
+
+// parseFileContent parses the content of a file of the rolodex, sharing its node tree with other documents if the
+// configuration has a shared resolution cache. The tree is checked against the parse limits of the configuration.
+func parseFileContent(content []byte, config *SpecIndexConfig) (*yaml.Node, error) {
+	parse := func() (*yaml.Node, error) {
+		info, err := extractFileSpecInfo(content, true, config)
+		if err != nil {
+			return nil, err
+		}
+		return info.RootNode, nil
+	}
+	if config == nil {
+		return parse()
+	}
+	var root *yaml.Node
+	var err error
+	if config.SharedResolutionCache == nil {
+		root, err = parse()
+	} else {
+		root, _, err = config.SharedResolutionCache.Tree(content, parse)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// a tree shared by the cache may have been parsed for a document with other limits, so it's always checked.
+	if err = config.ParseLimits.Check(root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
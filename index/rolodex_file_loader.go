@@ -4,7 +4,6 @@
 package index
 
 import (
-	"fmt"
 	"io"
 	"io/fs"
 	"log/slog"
@@ -53,6 +52,7 @@ type waiterLocal struct {
 	f         string
 	done      bool
 	file      *LocalFile
+	err       error
 	listeners int
 }
 
@@ -61,8 +61,8 @@ func (l *LocalFS) Open(name string) (fs.File, error) {
 	if l.indexConfig != nil && !l.indexConfig.AllowFileLookup {
 		return nil, &fs.PathError{
 			Op: "open", Path: name,
-			Err: fmt.Errorf("file lookup for '%s' not allowed, set the index configuration "+
-				"to AllowFileLookup to be true", name),
+			Err: datamodel.NewError(ErrLookupNotAllowed, nil, "file lookup for '%s' not allowed, set the index "+
+				"configuration to AllowFileLookup to be true", name),
 		}
 	}
 
@@ -90,6 +90,9 @@ func (l *LocalFS) Open(name string) (fs.File, error) {
 				}
 				wait.listeners--
 				l.logger.Debug("[rolodex file loader]: waiting done, OS load completed, returning file", "file", name, "listeners", wait.listeners)
+				if wait.err != nil {
+					return nil, wait.err
+				}
 				return wait.file, nil
 			}
 
@@ -122,6 +125,14 @@ func (l *LocalFS) Open(name string) (fs.File, error) {
 					}
 
 					if idxError != nil && idx == nil {
+						// a file that breaks the parse limits is refused, it must never be parsed again without them.
+						if datamodel.IsParseLimitError(idxError) {
+							l.Files.Delete(name)
+							processingWaiter.err = idxError
+							processingWaiter.done = true
+							l.processingFiles.Delete(name)
+							return nil, idxError
+						}
 						extractedFile.readingErrors = append(l.readingErrors, idxError)
 					} else {
 						// for each index, we need a resolver
@@ -169,6 +180,7 @@ type LocalFile struct {
 	index         *SpecIndex
 	parsed        *yaml.Node
 	offset        int64
+	engine        datamodel.YAMLEngine
 }
 
 // GetIndex returns the *SpecIndex for the file.
@@ -181,15 +193,13 @@ func (l *LocalFile) Index(config *SpecIndexConfig) (*SpecIndex, error) {
 	if l.index != nil {
 		return l.index, nil
 	}
-	content := l.data
-
 	// first, we must parse the content of the file
-	info, err := datamodel.ExtractSpecInfoWithDocumentCheckSync(content, true)
+	root, err := parseFileContent(l.data, config)
 	if err != nil {
 		return nil, err
 	}
 
-	index := NewSpecIndexWithConfig(info.RootNode, config)
+	index := NewSpecIndexWithConfig(root, config)
 	index.specAbsolutePath = l.fullPath
 
 	l.index = index
@@ -204,17 +214,18 @@ func (l *LocalFile) GetContent() string {
 // GetContentAsYAMLNode returns the content of the file as a *yaml.Node. If something went wrong
 // then an error is returned.
 func (l *LocalFile) GetContentAsYAMLNode() (*yaml.Node, error) {
-	if l.parsed != nil {
-		return l.parsed, nil
-	}
+	// the tree of the index comes first, it may be shared with other documents, see SharedResolutionCache.
 	if l.index != nil && l.index.root != nil {
 		return l.index.root, nil
 	}
+	if l.parsed != nil {
+		return l.parsed, nil
+	}
 	if l.data == nil {
-		return nil, fmt.Errorf("no data to parse for file: %s", l.fullPath)
+		return nil, datamodel.NewError(ErrEmptyDocument, nil, "no data to parse for file: %s", l.fullPath)
 	}
 	var root yaml.Node
-	err := yaml.Unmarshal(l.data, &root)
+	err := datamodel.DecodeYAML(l.engine, l.data, &root)
 	if err != nil {
 		return nil, err
 	}
@@ -430,6 +441,7 @@ func (l *LocalFS) extractFile(p string) (*LocalFile, error) {
 			fullPath:      abs,
 			lastModified:  modTime,
 			readingErrors: readingErrors,
+			engine:        l.indexConfig.getYAMLEngine(),
 		}
 		l.Files.Store(abs, lf)
 		return lf, nil
@@ -4,7 +4,10 @@
 package index
 
 import (
+	"fmt"
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 	"io"
 	"io/fs"
@@ -327,3 +330,43 @@ func TestRecursiveLocalFile_MultipleRequests(t *testing.T) {
 		completed++
 	}
 }
+
+func TestRolodex_ParseLimits(t *testing.T) {
+	dir := t.TempDir()
+	laughs := "l0: &l0 lol\n"
+	for i := 1; i <= 9; i++ {
+		laughs += fmt.Sprintf("l%d: &l%d [*l%d, *l%d, *l%d, *l%d, *l%d, *l%d, *l%d, *l%d, *l%d, *l%d]\n",
+			i, i, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1, i-1)
+	}
+	laughs += "components:\n  schemas:\n    Pet:\n      type: object\n"
+	root := "openapi: 3.1.0\ncomponents:\n  schemas:\n    Pet:\n      $ref: 'laughs.yaml#/components/schemas/Pet'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "laughs.yaml"), []byte(laughs), 0o644))
+
+	for _, limits := range []datamodel.ParseLimits{{}, {MaxAliasExpansion: 10000}} {
+		var rootNode yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(root), &rootNode))
+
+		cf := CreateOpenAPIIndexConfig()
+		cf.BasePath = dir
+		cf.SpecAbsolutePath = filepath.Join(dir, "root.yaml")
+		cf.ParseLimits = limits
+		rolo := NewRolodex(cf)
+		rolo.SetRootNode(&rootNode)
+		cf.Rolodex = rolo
+
+		fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{
+			BaseDirectory: dir,
+			DirFS:         os.DirFS(dir),
+			IndexConfig:   cf,
+		})
+		require.NoError(t, err)
+		rolo.AddLocalFS(dir, fileFS)
+
+		err = rolo.IndexTheRolodex()
+		if limits.MaxAliasExpansion == 0 {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.ErrorIs(t, err, datamodel.ErrAliasExpansionTooLarge)
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// GetDependents returns the absolute locations of every file that references a file of the rolodex, sorted. Files
+// that reference the file through other files are included, a file that references a file that references the file
+// depends on it too. The root specification is included if it depends on the file.
+func (r *Rolodex) GetDependents(location string) []string {
+	location = r.absoluteLocation(location)
+	graph := r.referenceGraph()
+	seen := map[string]bool{location: true}
+	queue := []string{location}
+	var dependents []string
+	for len(queue) > 0 {
+		file := queue[0]
+		queue = queue[1:]
+		for _, dependent := range graph[file] {
+			if !seen[dependent] {
+				seen[dependent] = true
+				dependents = append(dependents, dependent)
+				queue = append(queue, dependent)
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// ReloadFile re-reads a local file of the rolodex after it has changed, and re-indexes it, without re-reading or
+// re-indexing any other file. The references of every file that depends on it (see GetDependents) are invalidated,
+// references made directly to the file are looked up again in its new index, and the caches of every dependent
+// index are cleared. The absolute locations of the dependents are returned.
+//
+// No node tree is changed, the file is parsed into a new tree, so trees shared with other documents (see
+// SpecIndexConfig.SharedResolutionCache) stay as they are. Only files held by a LocalFS can be reloaded. Reloading a
+// file must not happen while the rolodex is being used to build a model.
+func (r *Rolodex) ReloadFile(location string) ([]string, error) {
+	location = r.absoluteLocation(location)
+	var lfs *LocalFS
+	var old *LocalFile
+	for _, v := range r.localFS {
+		if l, ok := v.(*LocalFS); ok {
+			if f, found := l.Files.Load(location); found {
+				lfs, old = l, f.(*LocalFile)
+				break
+			}
+		}
+	}
+	if old == nil {
+		return nil, datamodel.NewError(ErrNoFileSystem, nil, "the rolodex does not hold a local file '%s' to reload",
+			location)
+	}
+	dependents := r.GetDependents(location)
+
+	reloaded, err := lfs.extractFile(old.filename)
+	if err != nil {
+		return nil, err
+	}
+	if reloaded == nil {
+		return nil, datamodel.NewError(ErrEmptyDocument, nil, "unable to reload file '%s'", location)
+	}
+
+	copiedConfig := *r.indexConfig
+	copiedConfig.SpecAbsolutePath = location
+	copiedConfig.AvoidBuildIndex = true
+	idx, err := reloaded.Index(&copiedConfig)
+	if err != nil {
+		// keep the file that was working.
+		lfs.Files.Store(location, old)
+		return nil, err
+	}
+	caughtErrors := r.buildFileIndex(idx)
+	caughtErrors = append(caughtErrors, r.replaceIndex(location, old.index, idx, dependents)...)
+	r.logger.Debug("[rolodex] file reloaded", "file", location, "dependents", len(dependents))
+	return dependents, errors.Join(caughtErrors...)
+}
+
+// DetachSharedTrees copies every node tree the rolodex shares with other documents (see SpecIndexConfig.
+// SharedResolutionCache), and puts an index of the copy in place of the index of the shared tree, so the trees can be
+// changed without changing the trees of other documents.
+func (r *Rolodex) DetachSharedTrees() []error {
+	var caughtErrors []error
+	for _, old := range r.GetIndexes() {
+		if !old.sharedTree {
+			continue
+		}
+		location := old.specAbsolutePath
+		copiedConfig := *old.config
+		copiedConfig.SpecAbsolutePath = location
+		copiedConfig.AvoidBuildIndex = true
+		idx := NewSpecIndexWithConfig(utils.CloneNode(old.root), &copiedConfig)
+		idx.specAbsolutePath = location
+		caughtErrors = append(caughtErrors, r.buildFileIndex(idx)...)
+		r.setFileIndex(old, idx)
+		caughtErrors = append(caughtErrors, r.replaceIndex(location, old, idx, r.GetDependents(location))...)
+	}
+	return caughtErrors
+}
+
+// buildFileIndex builds an index of a file of the rolodex, created with AvoidBuildIndex set, and checks it for
+// circular references unless the rolodex avoids the check.
+func (r *Rolodex) buildFileIndex(idx *SpecIndex) []error {
+	resolver := NewResolver(idx)
+	if idx.config.IgnoreArrayCircularReferences {
+		resolver.IgnoreArrayCircularReferences()
+	}
+	if idx.config.IgnorePolymorphicCircularReferences {
+		resolver.IgnorePolymorphicCircularReferences()
+	}
+	idx.BuildIndex()
+
+	var caughtErrors []error
+	if !r.indexConfig.AvoidCircularReferenceCheck {
+		for _, e := range resolver.CheckForCircularReferences() {
+			caughtErrors = append(caughtErrors, e)
+		}
+	}
+	return caughtErrors
+}
+
+// replaceIndex puts the new index of a file in place of its old index, clears the caches of the files that depend on
+// it, and looks up the references they make to it again, returning any new reference errors.
+func (r *Rolodex) replaceIndex(location string, old, idx *SpecIndex, dependents []string) []error {
+	r.indexLock.Lock()
+	replaced := false
+	for i := range r.indexes {
+		if r.indexes[i] == old || r.indexes[i].specAbsolutePath == location {
+			r.indexes[i] = idx
+			replaced = true
+		}
+	}
+	if !replaced {
+		r.indexes = append(r.indexes, idx)
+	}
+	r.indexMap[location] = idx
+	r.indexLock.Unlock()
+
+	var caughtErrors []error
+	for _, dependent := range append(r.GetIndexes(), r.GetRootIndex()) {
+		if dependent == nil || !slices.Contains(dependents, dependent.specAbsolutePath) {
+			continue
+		}
+		dependent.cache.Clear()
+		dependent.GetHighCache().Clear()
+		caughtErrors = append(caughtErrors, dependent.remapReferencesTo(location)...)
+	}
+	return caughtErrors
+}
+
+// setFileIndex makes the file of the rolodex indexed by old hold a new index, and the node tree of the new index.
+func (r *Rolodex) setFileIndex(old, idx *SpecIndex) {
+	update := func(_, v any) bool {
+		switch f := v.(type) {
+		case *LocalFile:
+			if f.index == old {
+				f.index, f.parsed = idx, idx.root
+				return false
+			}
+		case *RemoteFile:
+			if f.index == old {
+				f.index, f.parsed = idx, idx.root
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range r.localFS {
+		if l, ok := v.(*LocalFS); ok {
+			l.Files.Range(update)
+		}
+	}
+	for _, v := range r.remoteFS {
+		if rfs, ok := v.(*RemoteFS); ok {
+			rfs.Files.Range(update)
+		}
+	}
+}
+
+// remapReferencesTo looks up every reference the index makes to a file again, and returns any new reference errors.
+func (index *SpecIndex) remapReferencesTo(location string) []error {
+	prefix := location + "#"
+	targets := func(fullDefinition string) bool {
+		return fullDefinition == location || strings.HasPrefix(fullDefinition, prefix)
+	}
+
+	var refs []*Reference
+	index.refLock.Lock()
+	for _, ref := range index.allRefs {
+		if targets(ref.FullDefinition) {
+			refs = append(refs, ref)
+			delete(index.allMappedRefs, ref.FullDefinition)
+		}
+	}
+	for key, mapped := range index.allMappedRefs {
+		if targets(mapped.FullDefinition) {
+			delete(index.allMappedRefs, key)
+		}
+	}
+	for key, mapped := range index.mappedRefLookup {
+		if targets(mapped.FullDefinition) {
+			delete(index.mappedRefLookup, key)
+		}
+	}
+	sequenced := index.allMappedRefsSequenced[:0]
+	for _, mapped := range index.allMappedRefsSequenced {
+		if !targets(mapped.FullDefinition) {
+			sequenced = append(sequenced, mapped)
+		}
+	}
+	clear(index.allMappedRefsSequenced[len(sequenced):])
+	index.allMappedRefsSequenced = sequenced
+	index.refLock.Unlock()
+
+	sort.Slice(refs, func(i, j int) bool { return refs[i].FullDefinition < refs[j].FullDefinition })
+	index.errorLock.Lock()
+	errorCount := len(index.refErrors)
+	index.errorLock.Unlock()
+
+	index.ExtractComponentsFromRefs(refs)
+
+	index.errorLock.Lock()
+	defer index.errorLock.Unlock()
+	return append([]error(nil), index.refErrors[errorCount:]...)
+}
+
+// referenceGraph maps the absolute location of every file of the rolodex to the locations of the files that
+// reference it.
+func (r *Rolodex) referenceGraph() map[string][]string {
+	graph := make(map[string][]string)
+	seen := make(map[[2]string]bool)
+	for _, idx := range append(r.GetIndexes(), r.GetRootIndex()) {
+		if idx == nil {
+			continue
+		}
+		for _, ref := range idx.GetRawReferencesSequenced() {
+			file, _, _ := strings.Cut(ref.FullDefinition, "#")
+			edge := [2]string{file, idx.specAbsolutePath}
+			if file == "" || file == idx.specAbsolutePath || seen[edge] {
+				continue
+			}
+			seen[edge] = true
+			graph[file] = append(graph[file], idx.specAbsolutePath)
+		}
+	}
+	return graph
+}
+
+// absoluteLocation returns the absolute location of a file, a relative location is relative to the first local file
+// system of the rolodex that holds it, by the order of their directories.
+func (r *Rolodex) absoluteLocation(location string) string {
+	if strings.HasPrefix(location, "http") || filepath.IsAbs(location) {
+		return location
+	}
+	for _, dir := range slices.Sorted(maps.Keys(r.localFS)) {
+		abs, _ := filepath.Abs(filepath.Join(dir, location))
+		if l, ok := r.localFS[dir].(*LocalFS); ok {
+			if _, found := l.Files.Load(abs); found {
+				return abs
+			}
+		}
+	}
+	abs, _ := filepath.Abs(location)
+	return abs
+}
@@ -0,0 +1,261 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func buildReloadRolodex(t *testing.T, files map[string]string) (*Rolodex, string) {
+	dir := t.TempDir()
+	return buildRolodexIn(t, dir, files, nil), dir
+}
+
+func buildRolodexIn(t *testing.T, dir string, files map[string]string, cache *datamodel.SharedResolutionCache) *Rolodex {
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	var rootNode yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(files["root.yaml"]), &rootNode))
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = dir
+	cf.SpecAbsolutePath = filepath.Join(dir, "root.yaml")
+	cf.SharedResolutionCache = cache
+
+	rolo := NewRolodex(cf)
+	rolo.SetRootNode(&rootNode)
+	cf.Rolodex = rolo
+
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{
+		BaseDirectory: dir,
+		DirFS:         os.DirFS(dir),
+		IndexConfig:   cf,
+	})
+	require.NoError(t, err)
+	rolo.AddLocalFS(dir, fileFS)
+
+	require.NoError(t, rolo.IndexTheRolodex())
+	rolo.BuildIndexes()
+	return rolo
+}
+
+func indexFor(rolo *Rolodex, location string) *SpecIndex {
+	for _, idx := range rolo.GetIndexes() {
+		if idx.GetSpecAbsolutePath() == location {
+			return idx
+		}
+	}
+	return nil
+}
+
+var reloadFiles = map[string]string{
+	"root.yaml": `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml#/components/schemas/Pet'
+    Thing:
+      $ref: 'thing.yaml#/components/schemas/Thing'`,
+	"pet.yaml": `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          $ref: 'name.yaml#/components/schemas/Name'`,
+	"name.yaml": `components:
+  schemas:
+    Name:
+      type: string
+      description: the name`,
+	"thing.yaml": `components:
+  schemas:
+    Thing:
+      type: string`,
+}
+
+func TestRolodex_GetDependents(t *testing.T) {
+	rolo, dir := buildReloadRolodex(t, reloadFiles)
+
+	assert.Equal(t, []string{filepath.Join(dir, "pet.yaml"), filepath.Join(dir, "root.yaml")},
+		rolo.GetDependents("name.yaml"))
+	assert.Equal(t, []string{filepath.Join(dir, "root.yaml")}, rolo.GetDependents(filepath.Join(dir, "thing.yaml")))
+	assert.Empty(t, rolo.GetDependents("root.yaml"))
+}
+
+func TestRolodex_ReloadFile(t *testing.T) {
+	rolo, dir := buildReloadRolodex(t, reloadFiles)
+	namePath := filepath.Join(dir, "name.yaml")
+	thingIndex := indexFor(rolo, filepath.Join(dir, "thing.yaml"))
+	require.NotNil(t, thingIndex)
+	oldNameIndex := indexFor(rolo, namePath)
+	require.NotNil(t, oldNameIndex)
+
+	require.NoError(t, os.WriteFile(namePath, []byte(`components:
+  schemas:
+    Name:
+      type: string
+      description: the new name`), 0o644))
+
+	dependents, err := rolo.ReloadFile("name.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "pet.yaml"), filepath.Join(dir, "root.yaml")}, dependents)
+
+	// the file is re-read, and re-indexed.
+	f, err := rolo.Open(namePath)
+	require.NoError(t, err)
+	assert.Contains(t, f.GetContent(), "the new name")
+	nameIndex := indexFor(rolo, namePath)
+	require.NotNil(t, nameIndex)
+	assert.NotSame(t, oldNameIndex, nameIndex)
+	assert.Len(t, rolo.GetIndexes(), 4)
+
+	// the reference made to the file is found in its new index.
+	petIndex := indexFor(rolo, filepath.Join(dir, "pet.yaml"))
+	require.NotNil(t, petIndex)
+	ref := petIndex.GetMappedReferences()[namePath+"#/components/schemas/Name"]
+	require.NotNil(t, ref)
+	assert.Equal(t, "the new name", ref.Node.Content[3].Value)
+
+	// files that do not depend on the file are not touched.
+	assert.Same(t, thingIndex, indexFor(rolo, filepath.Join(dir, "thing.yaml")))
+}
+
+func TestRolodex_AbsoluteLocation(t *testing.T) {
+	rolo := NewRolodex(CreateOpenAPIIndexConfig())
+	for _, dir := range []string{"/b", "/a", "/c"} {
+		fileFS := &LocalFS{}
+		if dir != "/c" {
+			fileFS.Files.Store(filepath.Join(dir, "pet.yaml"), &LocalFile{})
+		}
+		rolo.AddLocalFS(dir, fileFS)
+	}
+
+	// the first file system holding the file, by the order of their directories.
+	for range 10 {
+		assert.Equal(t, "/a/pet.yaml", rolo.absoluteLocation("pet.yaml"))
+	}
+	assert.Equal(t, "/c/pet.yaml", rolo.absoluteLocation("/c/pet.yaml"))
+	abs, _ := filepath.Abs("thing.yaml")
+	assert.Equal(t, abs, rolo.absoluteLocation("thing.yaml"))
+}
+
+func TestRolodex_ReloadFile_NotFound(t *testing.T) {
+	rolo, _ := buildReloadRolodex(t, reloadFiles)
+	_, err := rolo.ReloadFile("missing.yaml")
+	assert.ErrorIs(t, err, ErrNoFileSystem)
+}
+
+func TestRolodex_ReloadFile_Invalid(t *testing.T) {
+	rolo, dir := buildReloadRolodex(t, reloadFiles)
+	namePath := filepath.Join(dir, "name.yaml")
+	require.NoError(t, os.WriteFile(namePath, []byte("{{ not yaml"), 0o644))
+
+	_, err := rolo.ReloadFile(namePath)
+	assert.Error(t, err)
+
+	// the file that was working is kept.
+	f, err := rolo.Open(namePath)
+	require.NoError(t, err)
+	assert.Contains(t, f.GetContent(), "the name")
+}
+
+func TestRolodex_SharedResolutionCache(t *testing.T) {
+	cache := datamodel.NewSharedResolutionCache()
+	first, second := t.TempDir(), t.TempDir()
+	one := buildRolodexIn(t, first, reloadFiles, cache)
+	two := buildRolodexIn(t, second, reloadFiles, cache)
+	assert.Equal(t, 4, cache.Len())
+
+	// the files of both rolodexes share their trees.
+	petOne, petTwo := indexFor(one, filepath.Join(first, "pet.yaml")), indexFor(two, filepath.Join(second, "pet.yaml"))
+	require.NotNil(t, petOne)
+	require.NotNil(t, petTwo)
+	assert.True(t, petOne.SharesTree())
+	assert.Same(t, petOne.GetRootNode(), petTwo.GetRootNode())
+
+	// and the subtrees references resolve to.
+	refOne := one.GetRootIndex().FindComponent(filepath.Join(first, "pet.yaml") + "#/components/schemas/Pet")
+	refTwo := two.GetRootIndex().FindComponent(filepath.Join(second, "pet.yaml") + "#/components/schemas/Pet")
+	require.NotNil(t, refOne)
+	require.NotNil(t, refTwo)
+	assert.Same(t, refOne.Node, refTwo.Node)
+	assert.Greater(t, cache.Hits(), int64(3))
+
+	// resolving copies the shared trees first, the trees of the other rolodex do not change.
+	before, _ := yaml.Marshal(petTwo.GetRootNode())
+	one.Resolve()
+	assert.Empty(t, one.GetCaughtErrors())
+	detached := indexFor(one, filepath.Join(first, "pet.yaml"))
+	assert.False(t, detached.SharesTree())
+	assert.NotSame(t, petTwo.GetRootNode(), detached.GetRootNode())
+	after, _ := yaml.Marshal(petTwo.GetRootNode())
+	assert.Equal(t, string(before), string(after))
+
+	resolved, _ := yaml.Marshal(detached.GetRootNode())
+	assert.Contains(t, string(resolved), "the name")
+	assert.NotContains(t, string(after), "the name")
+}
+
+func TestRolodex_ReloadFile_SharedTrees(t *testing.T) {
+	cache := datamodel.NewSharedResolutionCache()
+	first, second := t.TempDir(), t.TempDir()
+	one := buildRolodexIn(t, first, reloadFiles, cache)
+	two := buildRolodexIn(t, second, reloadFiles, cache)
+	nameTwo := indexFor(two, filepath.Join(second, "name.yaml"))
+	require.True(t, nameTwo.SharesTree())
+	before, _ := yaml.Marshal(nameTwo.GetRootNode())
+
+	namePath := filepath.Join(first, "name.yaml")
+	require.NoError(t, os.WriteFile(namePath, []byte(`components:
+  schemas:
+    Name:
+      type: string
+      description: the new name`), 0o644))
+	_, err := one.ReloadFile(namePath)
+	require.NoError(t, err)
+
+	// the other rolodex keeps the tree it shares.
+	reloaded := indexFor(one, namePath)
+	assert.NotSame(t, nameTwo.GetRootNode(), reloaded.GetRootNode())
+	assert.Same(t, nameTwo, indexFor(two, filepath.Join(second, "name.yaml")))
+	after, _ := yaml.Marshal(nameTwo.GetRootNode())
+	assert.Equal(t, string(before), string(after))
+	rendered, _ := yaml.Marshal(reloaded.GetRootNode())
+	assert.Contains(t, string(rendered), "the new name")
+}
+
+func TestSharedResolutionCache_MergeKeys(t *testing.T) {
+	cache := datamodel.NewSharedResolutionCache()
+	files := map[string]string{
+		"root.yaml": `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml#/components/schemas/Pet'`,
+		"pet.yaml": `components:
+  schemas:
+    Base: &base
+      type: object
+    Pet:
+      <<: *base
+      description: a pet`,
+	}
+	dir := t.TempDir()
+	rolo := buildRolodexIn(t, dir, files, cache)
+
+	// merging changes the tree of the pet, so it is not shared.
+	assert.Equal(t, 1, cache.Len())
+	assert.False(t, indexFor(rolo, filepath.Join(dir, "pet.yaml")).SharesTree())
+	assert.True(t, indexFor(rolo, filepath.Join(dir, "root.yaml")).SharesTree())
+}
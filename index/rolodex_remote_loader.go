@@ -12,7 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"path/filepath"
+	"path"
 	"strings"
 	"sync"
 	"time"
@@ -61,6 +61,7 @@ type RemoteFile struct {
 	index         *SpecIndex
 	parsed        *yaml.Node
 	offset        int64
+	engine        datamodel.YAMLEngine
 }
 
 // GetFileName returns the name of the file.
@@ -75,17 +76,18 @@ func (f *RemoteFile) GetContent() string {
 
 // GetContentAsYAMLNode returns the content of the file as a yaml.Node.
 func (f *RemoteFile) GetContentAsYAMLNode() (*yaml.Node, error) {
-	if f.parsed != nil {
-		return f.parsed, nil
-	}
+	// the tree of the index comes first, it may be shared with other documents, see SharedResolutionCache.
 	if f.index != nil && f.index.root != nil {
 		return f.index.root, nil
 	}
+	if f.parsed != nil {
+		return f.parsed, nil
+	}
 	if f.data == nil {
-		return nil, fmt.Errorf("no data to parse for file: %s", f.fullPath)
+		return nil, datamodel.NewError(ErrEmptyDocument, nil, "no data to parse for file: %s", f.fullPath)
 	}
 	var root yaml.Node
-	err := yaml.Unmarshal(f.data, &root)
+	err := datamodel.DecodeYAML(f.engine, f.data, &root)
 	if err != nil {
 		return nil, err
 	}
@@ -178,15 +180,13 @@ func (f *RemoteFile) Index(config *SpecIndexConfig) (*SpecIndex, error) {
 	if f.index != nil {
 		return f.index, nil
 	}
-	content := f.data
-
 	// first, we must parse the content of the file
-	info, err := datamodel.ExtractSpecInfoWithDocumentCheckSync(content, true)
+	root, err := parseFileContent(f.data, config)
 	if err != nil {
 		return nil, err
 	}
 
-	index := NewSpecIndexWithConfig(info.RootNode, config)
+	index := NewSpecIndexWithConfig(root, config)
 	index.specAbsolutePath = config.SpecAbsolutePath
 	f.index = index
 	return index, nil
@@ -227,12 +227,49 @@ func NewRemoteFSWithConfig(specIndexConfig *SpecIndexConfig) (*RemoteFS, error)
 			Timeout: time.Second * 120,
 		}
 		rfs.RemoteHandlerFunc = func(url string) (*http.Response, error) {
-			return client.Get(url)
+			req, err := http.NewRequestWithContext(rfs.indexConfig.getContext(), http.MethodGet, url, nil)
+			if err != nil {
+				return nil, err
+			}
+			return client.Do(req)
 		}
 	}
 	return rfs, nil
 }
 
+// fetch calls the remote handler for a URL. If the context of the index is done before the handler returns, the
+// context error is returned without waiting, so a handler that hangs can't block the index.
+func (i *RemoteFS) fetch(remoteURL string) (*http.Response, error) {
+	ctx := i.indexConfig.getContext()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if ctx.Done() == nil {
+		return i.RemoteHandlerFunc(remoteURL)
+	}
+	type result struct {
+		response *http.Response
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		response, err := i.RemoteHandlerFunc(remoteURL)
+		done <- result{response, err}
+	}()
+	select {
+	case r := <-done:
+		return r.response, r.err
+	case <-ctx.Done():
+		go func() {
+			// the handler may still return a response, it won't be read.
+			if r := <-done; r.response != nil && r.response.Body != nil {
+				_ = r.response.Body.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
 // NewRemoteFSWithRootURL creates a new RemoteFS using the supplied root URL.
 func NewRemoteFSWithRootURL(rootURL string) (*RemoteFS, error) {
 	remoteRootURL, err := url.Parse(rootURL)
@@ -274,13 +311,14 @@ type waiterRemote struct {
 	f         string
 	done      bool
 	file      *RemoteFile
+	err       error
 	listeners int
 }
 
 // Open opens a file, returning it or an error. If the file is not found, the error is of type *PathError.
 func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 	if i.indexConfig != nil && !i.indexConfig.AllowRemoteLookup {
-		return nil, fmt.Errorf("remote lookup for '%s' is not allowed, please set "+
+		return nil, datamodel.NewError(ErrLookupNotAllowed, nil, "remote lookup for '%s' is not allowed, please set "+
 			"AllowRemoteLookup to true as part of the index configuration", remoteURL)
 	}
 
@@ -320,6 +358,9 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 		wait.listeners--
 		i.logger.Debug("[rolodex remote loader]: waiting done, remote completed, returning file", "file",
 			remoteParsedURL.String(), "listeners", wait.listeners)
+		if wait.err != nil {
+			return nil, wait.err
+		}
 		return wait.file, nil
 	}
 
@@ -358,9 +399,10 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 
 	i.logger.Debug("[rolodex remote loader] loading remote file", "file", remoteURL, "remoteURL", remoteParsedURL.String())
 
-	response, clientErr := i.RemoteHandlerFunc(remoteParsedURL.String())
+	response, clientErr := i.fetch(remoteParsedURL.String())
 	if clientErr != nil {
 
+		clientErr = datamodel.NewError(ErrRemoteFetch, clientErr, "")
 		i.remoteErrors = append(i.remoteErrors, clientErr)
 		// remove from processing
 		processingWaiter.done = true
@@ -376,7 +418,7 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 		// remove from processing
 		processingWaiter.done = true
 		i.ProcessingFiles.Delete(remoteParsedURL.Path)
-		return nil, fmt.Errorf("empty response from remote URL: %s", remoteParsedURL.String())
+		return nil, datamodel.NewError(ErrRemoteFetch, nil, "empty response from remote URL: %s", remoteParsedURL.String())
 	}
 	responseBytes, readError := io.ReadAll(response.Body)
 	if readError != nil {
@@ -385,7 +427,7 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 		processingWaiter.done = true
 		i.ProcessingFiles.Delete(remoteParsedURL.Path)
 
-		return nil, fmt.Errorf("error reading bytes from remote file '%s': [%s]",
+		return nil, datamodel.NewError(ErrRemoteFetch, readError, "error reading bytes from remote file '%s': [%s]",
 			remoteParsedURL.String(), readError.Error())
 	}
 
@@ -397,8 +439,8 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 
 		i.logger.Error("unable to fetch remote document",
 			"file", remoteParsedURL.Path, "status", response.StatusCode, "resp", string(responseBytes))
-		return nil, fmt.Errorf("unable to fetch remote document '%s' (error %d)", remoteParsedURL.String(),
-			response.StatusCode)
+		return nil, datamodel.NewError(ErrRemoteFetch, nil, "unable to fetch remote document '%s' (error %d)",
+			remoteParsedURL.String(), response.StatusCode)
 	}
 
 	absolutePath := remoteParsedURL.Path
@@ -414,7 +456,7 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 		lastModifiedTime = time.Now()
 	}
 
-	filename := filepath.Base(remoteParsedURL.Path)
+	filename := path.Base(remoteParsedURL.Path)
 
 	remoteFile := &RemoteFile{
 		filename:     filename,
@@ -424,12 +466,13 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 		fullPath:     remoteParsedURL.String(),
 		URL:          remoteParsedURL,
 		lastModified: lastModifiedTime,
+		engine:       i.indexConfig.getYAMLEngine(),
 	}
 
 	copiedCfg := *i.indexConfig
 
 	newBase := fmt.Sprintf("%s://%s%s", remoteParsedURLOriginal.Scheme, remoteParsedURLOriginal.Host,
-		filepath.Dir(remoteParsedURL.Path))
+		path.Dir(remoteParsedURL.Path))
 	newBaseURL, _ := url.Parse(newBase)
 
 	if newBaseURL != nil {
@@ -446,6 +489,14 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 	idx, idxError := remoteFile.Index(&copiedCfg)
 
 	if idxError != nil && idx == nil {
+		// a file that breaks the parse limits is refused, it must never be parsed again without them.
+		if datamodel.IsParseLimitError(idxError) {
+			i.Files.Delete(absolutePath)
+			processingWaiter.err = idxError
+			processingWaiter.done = true
+			i.ProcessingFiles.Delete(remoteParsedURL.Path)
+			return nil, idxError
+		}
 		i.remoteErrors = append(i.remoteErrors, idxError)
 	} else {
 
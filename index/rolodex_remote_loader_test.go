@@ -5,6 +5,7 @@ package index
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -125,6 +127,83 @@ func TestNewRemoteFS_BasicCheck_Valid(t *testing.T) {
 	assert.Equal(t, "2015-10-21 07:28:00 +0000 UTC", lastMod.UTC().String())
 }
 
+func TestNewRemoteFS_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	config := CreateOpenAPIIndexConfig()
+	config.AllowRemoteLookup = true
+	config.Context = ctx
+	remoteFS, _ := NewRemoteFSWithConfig(config)
+
+	hang := make(chan struct{})
+	defer close(hang)
+	remoteFS.RemoteHandlerFunc = func(url string) (*http.Response, error) {
+		<-hang
+		return nil, errors.New("too late")
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	file, err := remoteFS.Open("https://pb33f.io/hang.yaml")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, file)
+
+	// once cancelled, nothing else is fetched.
+	called := false
+	remoteFS.RemoteHandlerFunc = func(url string) (*http.Response, error) {
+		called = true
+		return nil, nil
+	}
+	_, err = remoteFS.Open("https://pb33f.io/another.yaml")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+}
+
+func TestNewRemoteFS_Errors(t *testing.T) {
+	config := CreateOpenAPIIndexConfig()
+	remoteFS, _ := NewRemoteFSWithConfig(config)
+	config.AllowRemoteLookup = false
+	_, err := remoteFS.Open("https://pb33f.io/pizza.yaml")
+	assert.ErrorIs(t, err, ErrLookupNotAllowed)
+
+	config.AllowRemoteLookup = true
+	remoteFS.RemoteHandlerFunc = func(url string) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+	_, err = remoteFS.Open("https://pb33f.io/missing.yaml")
+	assert.ErrorIs(t, err, ErrRemoteFetch)
+	assert.Equal(t, "unable to fetch remote document 'https://pb33f.io/missing.yaml' (error 404)", err.Error())
+
+	pop := errors.New("pop")
+	remoteFS.RemoteHandlerFunc = func(url string) (*http.Response, error) {
+		return nil, pop
+	}
+	_, err = remoteFS.Open("https://pb33f.io/broken.yaml")
+	assert.ErrorIs(t, err, ErrRemoteFetch)
+	assert.ErrorIs(t, err, pop)
+	assert.Equal(t, "pop", err.Error())
+	assert.Equal(t, datamodel.ErrorCode("remote-fetch"), datamodel.ErrorCodeOf(err))
+}
+
+func TestNewRemoteFS_Context_DefaultClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-req.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	config := CreateOpenAPIIndexConfig()
+	config.AllowRemoteLookup = true
+	config.Context = ctx
+	remoteFS, _ := NewRemoteFSWithConfig(config)
+
+	file, err := remoteFS.Open(server.URL + "/slow.yaml")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, file)
+}
+
 func TestNewRemoteFS_BasicCheck_NoScheme(t *testing.T) {
 	server := test_buildServer()
 	defer server.Close()
@@ -38,6 +38,22 @@ func TestRolodex_NewRolodex(t *testing.T) {
 
 }
 
+func TestRolodex_SharedLogger(t *testing.T) {
+	c := CreateOpenAPIIndexConfig()
+	rolo := NewRolodex(c)
+	assert.NotNil(t, rolo.GetLogger())
+	assert.Same(t, rolo.GetLogger(), c.Logger)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c = CreateOpenAPIIndexConfig()
+	c.Logger = logger
+	rolo = NewRolodex(c)
+	rolo.SetRootNode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}})
+	assert.NoError(t, rolo.IndexTheRolodex())
+	assert.Same(t, logger, rolo.GetLogger())
+	assert.Same(t, logger, rolo.GetRootIndex().GetLogger())
+}
+
 func TestRolodex_NoFS(t *testing.T) {
 
 	rolo := NewRolodex(CreateOpenAPIIndexConfig())
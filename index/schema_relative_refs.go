@@ -0,0 +1,102 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const defsPrefix = "#/$defs/"
+
+// GetSchemaRelativeDefinition returns the absolute definition (like '#/components/schemas/Pet/$defs/Tag') of a
+// schema relative reference. JSON Schema files routinely use '#/$defs/...' references to point to definitions held
+// by an enclosing schema, rather than the root of the document. The node is the mapping node holding the $ref.
+// If the reference is not schema relative, an empty string is returned.
+func (index *SpecIndex) GetSchemaRelativeDefinition(node *yaml.Node) string {
+	if index == nil || node == nil {
+		return ""
+	}
+	if def, ok := index.schemaRelativeRefs.Load(node); ok {
+		return def.(string)
+	}
+	return ""
+}
+
+// resolveSchemaRelativeDefinition checks if a '#/$defs/...' reference held by node can be found at the root of the
+// document. If it cannot, the ancestors of node are searched (nearest first) for a schema holding the definition
+// in its $defs, and the absolute definition is returned. An empty string is returned if there is nothing to do.
+func (index *SpecIndex) resolveSchemaRelativeDefinition(node *yaml.Node, value string) string {
+	if !strings.HasPrefix(value, defsPrefix) || index.root == nil {
+		return ""
+	}
+	root := index.root
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	tokens, err := utils.ParseJSONPointer(value)
+	if err != nil {
+		return ""
+	}
+	if _, err = utils.FindNodeByJSONPointerTokens(root, tokens); err == nil {
+		return ""
+	}
+	ancestors := index.nodeAncestry(root, node)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if _, err = utils.FindNodeByJSONPointerTokens(ancestors[i], tokens); err == nil {
+			base, _ := utils.JSONPointerFromAncestry(ancestors[:i+1])
+			def := "#" + base + utils.JoinJSONPointer(tokens...)
+			index.schemaRelativeRefs.Store(node, def)
+			return def
+		}
+	}
+	return ""
+}
+
+// nodeAncestry returns every node from root down to (and including) node, the same as utils.FindNodeAncestry.
+// The parent of every value in the document is mapped the first time it is called, so the document is only walked
+// once, no matter how many schema relative references it holds.
+func (index *SpecIndex) nodeAncestry(root, node *yaml.Node) []*yaml.Node {
+	index.nodeParentsOnce.Do(func() {
+		index.nodeParents = make(map[*yaml.Node]*yaml.Node)
+		mapNodeParents(root, index.nodeParents)
+	})
+	ancestors := []*yaml.Node{node}
+	for n := node; n != root; {
+		parent, ok := index.nodeParents[n]
+		if !ok {
+			return nil
+		}
+		ancestors = append(ancestors, parent)
+		n = parent
+	}
+	slices.Reverse(ancestors)
+	return ancestors
+}
+
+// mapNodeParents maps every value below node (map values and array items) to its parent. Map keys and aliases are
+// not followed, a node found in more than one place keeps the parent it's found under first.
+func mapNodeParents(node *yaml.Node, parents map[*yaml.Node]*yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range node.Content {
+			if _, seen := parents[n]; !seen {
+				parents[n] = node
+				mapNodeParents(n, parents)
+			}
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if n := node.Content[i]; n != nil {
+				if _, seen := parents[n]; !seen {
+					parents[n] = node
+					mapNodeParents(n, parents)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_SchemaRelativeDefs(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Owner:
+      $id: https://example.com/owner
+      type: object
+      properties:
+        pet:
+          $ref: '#/$defs/Pet'
+        tags:
+          type: array
+          items:
+            $ref: '#/$defs/Tag'
+      $defs:
+        Pet:
+          type: object
+          properties:
+            tag:
+              $ref: '#/$defs/Tag'
+        Tag:
+          type: string`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.NotNil(t, idx.GetMappedReferences()["#/components/schemas/Owner/$defs/Pet"])
+	assert.NotNil(t, idx.GetMappedReferences()["#/components/schemas/Owner/$defs/Tag"])
+
+	petRef := rootNode.Content[0].Content[3].Content[1].Content[1].Content[5].Content[1]
+	assert.Equal(t, "#/components/schemas/Owner/$defs/Pet", idx.GetSchemaRelativeDefinition(petRef))
+
+	resolver := NewResolver(idx)
+	assert.Empty(t, resolver.Resolve())
+}
+
+func TestSpecIndex_SchemaRelativeDefs_RootDefs(t *testing.T) {
+	yml := `$defs:
+  Pet:
+    type: string
+properties:
+  pet:
+    $ref: '#/$defs/Pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	// the definition exists at the root, so there is nothing to re-map.
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	petRef := rootNode.Content[0].Content[3].Content[1]
+	assert.Empty(t, idx.GetSchemaRelativeDefinition(petRef))
+	assert.Empty(t, idx.resolveSchemaRelativeDefinition(petRef, "#/components/schemas/Pet"))
+}
+
+func TestSpecIndex_SchemaRelativeDefs_Missing(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Owner:
+      properties:
+        pet:
+          $ref: '#/$defs/Pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	assert.Len(t, idx.GetReferenceIndexErrors(), 1)
+
+	var nilIndex *SpecIndex
+	assert.Empty(t, nilIndex.GetSchemaRelativeDefinition(&yaml.Node{}))
+}
+
+func TestSpecIndex_NodeAncestry(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Owner:
+      properties:
+        tags:
+          type: array
+          items:
+            $ref: '#/$defs/Tag'
+      $defs:
+        Tag:
+          type: string`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+	root := rootNode.Content[0]
+
+	// the parents are mapped once, by the schema relative reference.
+	assert.NotEmpty(t, idx.nodeParents)
+	parents := len(idx.nodeParents)
+
+	items := root.Content[3].Content[1].Content[1].Content[1].Content[1].Content[3]
+	assert.Equal(t, utils.FindNodeAncestry(root, items), idx.nodeAncestry(root, items))
+	assert.Equal(t, []*yaml.Node{root}, idx.nodeAncestry(root, root))
+	assert.Len(t, idx.nodeParents, parents)
+
+	// keys and nodes outside the document have no ancestry.
+	assert.Nil(t, idx.nodeAncestry(root, root.Content[0]))
+	assert.Nil(t, idx.nodeAncestry(root, &yaml.Node{}))
+}
@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"path/filepath"
 	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
 )
 
 type ContextKey string
@@ -42,8 +44,6 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 		}
 	}
 
-	ref := searchRef.FullDefinition
-	refAlt := ref
 	absPath := index.specAbsolutePath
 	if searchRef.RemoteLocation != "" {
 		absPath = searchRef.RemoteLocation
@@ -51,6 +51,18 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 	if absPath == "" {
 		absPath = index.config.BasePath
 	}
+
+	// most references are already absolute, or local to the document, so they can be found with a single lookup.
+	if key := referenceLookupKey(absPath, searchRef.FullDefinition); key != "" {
+		if r, ok := index.mappedRefLookup[key]; ok {
+			idx := index.extractIndex(r)
+			index.cache.Store(searchRef.FullDefinition, r)
+			return r, idx, context.WithValue(ctx, CurrentPathKey, r.RemoteLocation)
+		}
+	}
+
+	ref := searchRef.FullDefinition
+	refAlt := ref
 	var roloLookup string
 	uri := strings.Split(ref, "#/")
 	if len(uri) == 2 {
@@ -62,9 +74,10 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 					roloLookup = uri[0]
 				} else {
 					if filepath.Ext(absPath) != "" {
-						absPath = filepath.Dir(absPath)
+						roloLookup = utils.ResolveRefPath(absPath, uri[0])
+					} else {
+						roloLookup = utils.JoinRefPath(absPath, uri[0])
 					}
-					roloLookup, _ = filepath.Abs(filepath.Join(absPath, uri[0]))
 				}
 			}
 		} else {
@@ -87,9 +100,10 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 				roloLookup = ref
 			} else {
 				if filepath.Ext(absPath) != "" {
-					absPath = filepath.Dir(absPath)
+					roloLookup = utils.ResolveRefPath(absPath, uri[0])
+				} else {
+					roloLookup = utils.JoinRefPath(absPath, uri[0])
 				}
-				roloLookup, _ = filepath.Abs(filepath.Join(absPath, uri[0]))
 			}
 		}
 		ref = uri[0]
@@ -134,6 +148,10 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 		if b == sfn && roloLookup == abp {
 			return nil, index, ctx
 		}
+		if ctx.Err() != nil {
+			// opening a file may trigger a remote lookup, don't if the context is done.
+			return nil, index, ctx
+		}
 		rFile, err := index.rolodex.Open(roloLookup)
 		if err != nil {
 			return nil, index, ctx
@@ -225,9 +243,48 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 	return nil, index, ctx
 }
 
+// referenceLookupKey normalizes a reference into the key it's mapped under: the absolute location of the document
+// it's in, and the pointer to it. References local to a document are in base. An empty key is returned for a
+// reference relative to another document, which has to be resolved before it can be looked up.
+func referenceLookupKey(base, ref string) string {
+	if strings.Contains(ref, "%") {
+		ref, _ = url.QueryUnescape(ref)
+	}
+	if strings.HasPrefix(ref, "#/") {
+		return base + ref
+	}
+	if strings.HasPrefix(ref, "http") {
+		return ref
+	}
+	location := ref
+	if i := strings.IndexByte(ref, '#'); i >= 0 {
+		location = ref[:i]
+	}
+	if filepath.IsAbs(location) {
+		return ref
+	}
+	return ""
+}
+
+// mapReference adds a located reference to the mapped references, and to the lookup used to search for them.
+// The caller must hold refLock.
+func (index *SpecIndex) mapReference(r *Reference) {
+	index.allMappedRefs[r.FullDefinition] = r
+	base := index.specAbsolutePath
+	if base == "" && index.config != nil {
+		base = index.config.BasePath
+	}
+	if key := referenceLookupKey(base, r.FullDefinition); key != "" {
+		index.mappedRefLookup[key] = r
+	}
+}
+
 func (index *SpecIndex) extractIndex(r *Reference) *SpecIndex {
 	idx := r.Index
 	if idx != nil && r.Index.GetSpecAbsolutePath() != r.RemoteLocation {
+		if found := r.Index.rolodex.indexAt(r.RemoteLocation); found != nil {
+			return found
+		}
 		for i := range r.Index.rolodex.indexes {
 			if r.Index.rolodex.indexes[i].GetSpecAbsolutePath() == r.RemoteLocation {
 				idx = r.Index.rolodex.indexes[i]
@@ -5,10 +5,19 @@ package index
 
 import (
 	"context"
-	"github.com/stretchr/testify/assert"
-	"gopkg.in/yaml.v3"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestSpecIndex_SearchIndexForReference(t *testing.T) {
@@ -34,3 +43,85 @@ func TestSpecIndex_SearchIndexForReferenceWithContext(t *testing.T) {
 	ref, _, _ := idx.SearchIndexForReferenceWithContext(context.Background(), "#/components/schemas/Pet")
 	assert.NotNil(t, ref)
 }
+
+func TestReferenceLookupKey(t *testing.T) {
+	assert.Equal(t, "/spec/root.yaml#/components/schemas/Pet",
+		referenceLookupKey("/spec/root.yaml", "#/components/schemas/Pet"))
+	assert.Equal(t, "/spec/pet.yaml#/components/schemas/Pet",
+		referenceLookupKey("/spec/root.yaml", "/spec/pet.yaml#/components/schemas/Pet"))
+	assert.Equal(t, "/spec/pet.yaml", referenceLookupKey("/spec/root.yaml", "/spec/pet.yaml"))
+	assert.Equal(t, "https://pb33f.io/pet.yaml#/Pet", referenceLookupKey("", "https://pb33f.io/pet.yaml#/Pet"))
+	assert.Equal(t, "/spec/root.yaml#/paths/~1pets", referenceLookupKey("/spec/root.yaml", "#/paths/%7E1pets"))
+
+	// relative references have to be resolved first.
+	assert.Empty(t, referenceLookupKey("/spec/root.yaml", "pet.yaml#/components/schemas/Pet"))
+	assert.Empty(t, referenceLookupKey("/spec/root.yaml", "./pet.yaml"))
+}
+
+func TestSpecIndex_SearchIndexForReference_Lookup(t *testing.T) {
+	petstore, _ := os.ReadFile("../test_specs/petstorev3.json")
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal(petstore, &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.SpecAbsolutePath = "/spec/petstorev3.json"
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+
+	mapped := idx.mappedRefLookup["/spec/petstorev3.json#/components/schemas/Pet"]
+	require.NotNil(t, mapped)
+
+	for _, ref := range []string{
+		"#/components/schemas/Pet",
+		"/spec/petstorev3.json#/components/schemas/Pet",
+		"#/components/schemas/%50et",
+	} {
+		found, foundIdx := idx.SearchIndexForReference(ref)
+		assert.Same(t, mapped, found, ref)
+		assert.Same(t, idx, foundIdx, ref)
+	}
+
+	// once a file is reloaded, references to it are looked up again.
+	idx.remapReferencesTo("/spec/petstorev3.json")
+	assert.NotNil(t, idx.mappedRefLookup["/spec/petstorev3.json#/components/schemas/Pet"])
+}
+
+func BenchmarkSpecIndex_SearchIndexForReference_Remote(b *testing.B) {
+	const schemas = 200
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		name := strings.TrimSuffix(filepath.Base(req.URL.Path), ".yaml")
+		_, _ = fmt.Fprintf(rw, "components:\n  schemas:\n    %s:\n      type: object\n", name)
+	}))
+	defer srv.Close()
+
+	var root strings.Builder
+	root.WriteString("openapi: 3.1.0\ncomponents:\n  schemas:\n")
+	refs := make([]*Reference, schemas)
+	for i := range refs {
+		ref := fmt.Sprintf("%s/schemas/Schema%d.yaml#/components/schemas/Schema%d", srv.URL, i, i)
+		_, _ = fmt.Fprintf(&root, "    Local%d:\n      $ref: '%s'\n", i, ref)
+		refs[i] = &Reference{FullDefinition: ref}
+	}
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(root.String()), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.BaseURL, _ = url.Parse(srv.URL)
+	remoteFS, _ := NewRemoteFSWithConfig(c)
+	rolo := NewRolodex(c)
+	rolo.AddRemoteFS(srv.URL, remoteFS)
+	rolo.SetRootNode(&rootNode)
+	require.NoError(b, rolo.IndexTheRolodex())
+	idx := rolo.GetRootIndex()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		// every reference is searched for as if for the first time.
+		idx.cache = new(sync.Map)
+		for _, ref := range refs {
+			if found, _ := idx.SearchIndexForReferenceByReference(ref); found == nil {
+				b.Fatalf("unable to find %s", ref.FullDefinition)
+			}
+		}
+	}
+}
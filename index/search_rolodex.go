@@ -4,14 +4,16 @@
 package index
 
 import (
+	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
 
 // FindNodeOrigin searches all indexes for the origin of a node. If the node is found, a NodeOrigin
 // is returned, otherwise nil is returned.
 func (r *Rolodex) FindNodeOrigin(node *yaml.Node) *NodeOrigin {
-	f := make(chan *NodeOrigin)
-	d := make(chan bool)
+	// the channels are buffered, so searches still running when the node is found do not block.
+	f := make(chan *NodeOrigin, len(r.indexes))
+	d := make(chan bool, len(r.indexes))
 	findNode := func(i int, node *yaml.Node) {
 		n := r.indexes[i].FindNodeOrigin(node)
 		if n != nil {
@@ -21,7 +23,7 @@ func (r *Rolodex) FindNodeOrigin(node *yaml.Node) *NodeOrigin {
 		d <- true
 	}
 	for i := range r.indexes {
-		go findNode(i, node)
+		utils.Go(func() { findNode(i, node) })
 	}
 	searched := 0
 	for searched < len(r.indexes) {
@@ -22,7 +22,6 @@ import (
 	"sync"
 
 	"github.com/pb33f/libopenapi/utils"
-	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
 	"gopkg.in/yaml.v3"
 )
 
@@ -52,6 +51,7 @@ func NewSpecIndexWithConfig(rootNode *yaml.Node, config *SpecIndexConfig) *SpecI
 		return index
 	}
 	index.root = rootNode
+	index.sharedTree = config.SharedResolutionCache != nil && config.SharedResolutionCache.IsShared(rootNode)
 	return createNewIndex(rootNode, index, config.AvoidBuildIndex)
 }
 
@@ -75,7 +75,8 @@ func createNewIndex(rootNode *yaml.Node, index *SpecIndex, avoidBuildOut bool) *
 		return index
 	}
 	index.nodeMapCompleted = make(chan bool)
-	index.nodeMap = make(map[int]map[int]*yaml.Node)
+	// every line that holds a node is mapped, so the map is sized for them all up front.
+	index.nodeMap = make(map[int]map[int]*yaml.Node, lastLine(rootNode))
 	go index.MapNodes(rootNode) // this can run async.
 
 	index.cache = new(sync.Map)
@@ -154,6 +155,12 @@ func (index *SpecIndex) GetRootNode() *yaml.Node {
 	return index.root
 }
 
+// SharesTree returns true if the root node tree of the index is shared with other documents, and must not be changed,
+// see SpecIndexConfig.SharedResolutionCache and Rolodex.DetachSharedTrees.
+func (index *SpecIndex) SharesTree() bool {
+	return index.sharedTree
+}
+
 func (index *SpecIndex) GetRolodex() *Rolodex {
 	return index.rolodex
 }
@@ -326,7 +333,8 @@ func (index *SpecIndex) GetAllReferenceSchemas() []*Reference {
 	return index.allRefSchemaDefinitions
 }
 
-// GetAllComponentSchemas will return all schemas defined in the components section of the document.
+// GetAllComponentSchemas will return all schemas defined in the components section of the document, or the
+// definitions section of a Swagger document.
 func (index *SpecIndex) GetAllComponentSchemas() map[string]*Reference {
 	if index == nil {
 		return nil
@@ -404,12 +412,12 @@ func (index *SpecIndex) GetAllLinks() map[string]*Reference {
 	return index.allLinks
 }
 
-// GetAllParameters will return all parameters found in the document (under components)
+// GetAllParameters will return all parameters found in the document (under components, or parameters for Swagger)
 func (index *SpecIndex) GetAllParameters() map[string]*Reference {
 	return index.allParameters
 }
 
-// GetAllResponses will return all responses found in the document (under components)
+// GetAllResponses will return all responses found in the document (under components, or responses for Swagger)
 func (index *SpecIndex) GetAllResponses() map[string]*Reference {
 	return index.allResponses
 }
@@ -449,6 +457,18 @@ func (index *SpecIndex) GetParametersNode() *yaml.Node {
 	return index.parametersNode
 }
 
+// GetResponsesNode will return the node holding all reusable responses found in the spec, either
+// 'components/responses' (OpenAPI 3+) or 'responses' (Swagger).
+func (index *SpecIndex) GetResponsesNode() *yaml.Node {
+	return index.responsesNode
+}
+
+// GetSecuritySchemesNode will return the node holding all security schemes found in the spec, either
+// 'components/securitySchemes' (OpenAPI 3+) or 'securityDefinitions' (Swagger).
+func (index *SpecIndex) GetSecuritySchemesNode() *yaml.Node {
+	return index.securitySchemesNode
+}
+
 // GetReferenceIndexErrors will return any errors that occurred when indexing references
 func (index *SpecIndex) GetReferenceIndexErrors() []error {
 	return index.refErrors
@@ -469,6 +489,36 @@ func (index *SpecIndex) GetOperationTags() map[string]map[string][]*Reference {
 	return index.operationTagsRefs
 }
 
+// GetOperationIds will return references to all operationIds found in operations, keyed by path and then method.
+// The Node of each reference is the operationId value node, the ParentNode is the method key node.
+func (index *SpecIndex) GetOperationIds() map[string]map[string]*Reference {
+	return index.operationIdRefs
+}
+
+// operationIdLocation is the path and method of the operation an operationId belongs to.
+type operationIdLocation struct {
+	path   string
+	method string
+	ref    *Reference
+}
+
+// FindOperationById will locate an operation using its operationId, returning the path and method of the
+// operation, along with a reference to the operationId. If the operationId is used more than once, the first
+// operation in the document is returned (see GetDuplicateOperationIds). If no operation can be found, the
+// reference will be nil.
+func (index *SpecIndex) FindOperationById(operationId string) (string, string, *Reference) {
+	if loc, ok := index.operationIdLookup[operationId]; ok {
+		return loc.path, loc.method, loc.ref
+	}
+	return "", "", nil
+}
+
+// GetDuplicateOperationIds will return every operationId that is used by more than one operation, along with
+// references to all of them, in document order.
+func (index *SpecIndex) GetDuplicateOperationIds() map[string][]*Reference {
+	return index.operationIdDuplicates
+}
+
 // GetAllParametersFromOperations will return all paths indexed in the document
 func (index *SpecIndex) GetAllParametersFromOperations() map[string]map[string]map[string][]*Reference {
 	return index.paramOpRefs
@@ -699,11 +749,8 @@ func (index *SpecIndex) GetGlobalCallbacksCount() int {
 		for _, m := range p {
 
 			// look through method for callbacks
-			callbacks, _ := yamlpath.NewPath("$..callbacks")
-			var res []*yaml.Node
-			res, _ = callbacks.Find(m.Node)
-			if len(res) > 0 {
-				for _, callback := range res[0].Content {
+			if res := findFirstKeyValue(m.Node, "callbacks"); res != nil {
+				for _, callback := range res.Content {
 					if utils.IsNodeMap(callback) {
 
 						ref := &Reference{
@@ -745,13 +792,8 @@ func (index *SpecIndex) GetGlobalLinksCount() int {
 		for _, m := range p {
 
 			// look through method for links
-			links, _ := yamlpath.NewPath("$..links")
-			var res []*yaml.Node
-
-			res, _ = links.Find(m.Node)
-
-			if len(res) > 0 {
-				for _, link := range res[0].Content {
+			if res := findFirstKeyValue(m.Node, "links"); res != nil {
+				for _, link := range res.Content {
 					if utils.IsNodeMap(link) {
 
 						ref := &Reference{
@@ -776,6 +818,28 @@ func (index *SpecIndex) GetGlobalLinksCount() int {
 	return index.globalLinksCount
 }
 
+// findFirstKeyValue returns the value of the first key named key in node or any node below it, searching each map
+// before the nodes inside it, in document order (the first match of the JSON Path '$..key'). Aliases are not
+// followed.
+func findFirstKeyValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1]
+			}
+		}
+	}
+	for _, child := range node.Content {
+		if found := findFirstKeyValue(child, key); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
 // GetRawReferenceCount will return the number of raw references located in the document.
 func (index *SpecIndex) GetRawReferenceCount() int {
 	return len(index.rawSequencedRefs)
@@ -833,17 +897,18 @@ func (index *SpecIndex) GetComponentSchemaCount() int {
 			}
 
 			if n.Value == "components" {
-				_, schemasNode := utils.FindKeyNode("schemas", index.root.Content[0].Content[i+1].Content)
+				components := utils.NewKeyIndex(index.root.Content[0].Content[i+1])
+				_, schemasNode := components.Find("schemas")
 
 				// while we are here, go ahead and extract everything in components.
-				_, parametersNode := utils.FindKeyNode("parameters", index.root.Content[0].Content[i+1].Content)
-				_, requestBodiesNode := utils.FindKeyNode("requestBodies", index.root.Content[0].Content[i+1].Content)
-				_, responsesNode := utils.FindKeyNode("responses", index.root.Content[0].Content[i+1].Content)
-				_, securitySchemesNode := utils.FindKeyNode("securitySchemes", index.root.Content[0].Content[i+1].Content)
-				_, headersNode := utils.FindKeyNode("headers", index.root.Content[0].Content[i+1].Content)
-				_, examplesNode := utils.FindKeyNode("examples", index.root.Content[0].Content[i+1].Content)
-				_, linksNode := utils.FindKeyNode("links", index.root.Content[0].Content[i+1].Content)
-				_, callbacksNode := utils.FindKeyNode("callbacks", index.root.Content[0].Content[i+1].Content)
+				_, parametersNode := components.Find("parameters")
+				_, requestBodiesNode := components.Find("requestBodies")
+				_, responsesNode := components.Find("responses")
+				_, securitySchemesNode := components.Find("securitySchemes")
+				_, headersNode := components.Find("headers")
+				_, examplesNode := components.Find("examples")
+				_, linksNode := components.Find("links")
+				_, callbacksNode := components.Find("callbacks")
 
 				// extract schemas
 				if schemasNode != nil {
@@ -1190,6 +1255,34 @@ func (index *SpecIndex) GetOperationsParameterCount() int {
 
 									index.operationSummaryRefs[pathItemNode.Value][prop.Value] = ref
 								}
+								if httpMethodProp.Value == "operationId" {
+									opId := pathPropertyNode.Content[y+1].Content[z+1]
+									ref := &Reference{
+										Definition: opId.Value,
+										Name:       opId.Value,
+										Node:       opId,
+										KeyNode:    httpMethodProp,
+										ParentNode: prop,
+										Path:       fmt.Sprintf("$.paths['%s'].%s", pathItemNode.Value, prop.Value),
+									}
+
+									if index.operationIdRefs[pathItemNode.Value] == nil {
+										index.operationIdRefs[pathItemNode.Value] = make(map[string]*Reference)
+									}
+
+									index.operationIdRefs[pathItemNode.Value][prop.Value] = ref
+
+									if first, ok := index.operationIdLookup[opId.Value]; ok {
+										if len(index.operationIdDuplicates[opId.Value]) == 0 {
+											index.operationIdDuplicates[opId.Value] = []*Reference{first.ref}
+										}
+										index.operationIdDuplicates[opId.Value] = append(index.operationIdDuplicates[opId.Value], ref)
+									} else {
+										index.operationIdLookup[opId.Value] = operationIdLocation{
+											path: pathItemNode.Value, method: prop.Value, ref: ref,
+										}
+									}
+								}
 
 								// extract servers from method operation.
 								if httpMethodProp.Value == "servers" {
@@ -1877,3 +1877,177 @@ func TestSpecIndex_Cache(t *testing.T) {
 	assert.Nil(t, idx.GetHighCache())
 
 }
+
+func TestSpecIndex_FindOperationById(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+    post:
+      operationId: createPet
+  /pets/{id}:
+    delete:
+      operationId: deletePet`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	assert.Len(t, idx.GetOperationIds(), 2)
+	assert.Len(t, idx.GetOperationIds()["/pets"], 2)
+
+	path, method, ref := idx.FindOperationById("deletePet")
+	assert.Equal(t, "/pets/{id}", path)
+	assert.Equal(t, "delete", method)
+	assert.NotNil(t, ref)
+	assert.Equal(t, "$.paths['/pets/{id}'].delete", ref.Path)
+	assert.Equal(t, 10, ref.Node.Line)
+	assert.Equal(t, "delete", ref.ParentNode.Value)
+
+	path, method, ref = idx.FindOperationById("unknown")
+	assert.Empty(t, path)
+	assert.Empty(t, method)
+	assert.Nil(t, ref)
+}
+
+func TestSpecIndex_FindOperationById_Duplicates(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /b:
+    get:
+      operationId: getThing
+  /a:
+    get:
+      operationId: getThing
+    put:
+      operationId: putThing`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	// the first operation in the document always wins.
+	for i := 0; i < 10; i++ {
+		idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+		path, method, _ := idx.FindOperationById("getThing")
+		assert.Equal(t, "/b", path)
+		assert.Equal(t, "get", method)
+	}
+
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+	dupes := idx.GetDuplicateOperationIds()
+	assert.Len(t, dupes, 1)
+	assert.Len(t, dupes["getThing"], 2)
+	assert.Equal(t, "$.paths['/b'].get", dupes["getThing"][0].Path)
+	assert.Equal(t, "$.paths['/a'].get", dupes["getThing"][1].Path)
+}
+
+func TestSpecIndex_SwaggerDefinitions(t *testing.T) {
+	yml := `swagger: "2.0"
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: '#/parameters/limit'
+      responses:
+        "200":
+          $ref: '#/responses/Pets'
+parameters:
+  limit:
+    name: limit
+    in: query
+    type: integer
+responses:
+  Pets:
+    description: pets
+    schema:
+      $ref: '#/definitions/Pet'
+securityDefinitions:
+  key:
+    type: apiKey
+    name: key
+    in: header
+definitions:
+  Pet:
+    type: object`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	pet := index.GetAllComponentSchemas()["#/definitions/Pet"]
+	assert.NotNil(t, pet)
+	assert.Equal(t, "$.definitions['Pet']", pet.Path)
+
+	limit := index.GetAllParameters()["#/parameters/limit"]
+	assert.NotNil(t, limit)
+	assert.Equal(t, "$.parameters['limit']", limit.Path)
+	assert.Equal(t, index.GetParametersNode(), limit.ParentNode)
+
+	pets := index.GetAllResponses()["#/responses/Pets"]
+	assert.NotNil(t, pets)
+	assert.Equal(t, "$.responses['Pets']", pets.Path)
+	assert.Equal(t, index.GetResponsesNode(), pets.ParentNode)
+
+	key := index.GetAllSecuritySchemes()["#/securityDefinitions/key"]
+	assert.NotNil(t, key)
+	assert.Equal(t, "$.securityDefinitions.key", key.Path)
+	assert.Len(t, index.GetSecuritySchemesNode().Content, 2)
+
+	assert.NotNil(t, index.GetMappedReferences()["#/parameters/limit"])
+	assert.NotNil(t, index.GetMappedReferences()["#/responses/Pets"])
+	assert.NotNil(t, index.GetMappedReferences()["#/definitions/Pet"])
+}
+
+func TestSpecIndex_ComponentPaths(t *testing.T) {
+	assert.Equal(t, "$.components.schemas", componentPath("#/components/schemas/"))
+	assert.Equal(t, "$.definitions", componentPath("#/definitions/"))
+}
+
+func TestFindFirstKeyValue(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`responses:
+  "200":
+    content:
+      application/json:
+        links: {first: {}}
+    links: {second: {}}
+  "404":
+    links: {third: {}}
+callbacks: &cb {fourth: {}}
+again: *cb`), &rootNode)
+
+	// the first match of $..links, maps are searched before the nodes inside them.
+	links := findFirstKeyValue(&rootNode, "links")
+	assert.Equal(t, "second", links.Content[0].Value)
+	links = findFirstKeyValue(rootNode.Content[0].Content[1].Content[1], "links")
+	assert.Equal(t, "second", links.Content[0].Value)
+	links = findFirstKeyValue(rootNode.Content[0].Content[1].Content[1].Content[1], "links")
+	assert.Equal(t, "first", links.Content[0].Value)
+
+	callbacks := findFirstKeyValue(&rootNode, "callbacks")
+	assert.Equal(t, "fourth", callbacks.Content[0].Value)
+	// aliases are not followed.
+	assert.Nil(t, findFirstKeyValue(rootNode.Content[0].Content[5], "fourth"))
+	assert.Nil(t, findFirstKeyValue(&rootNode, "nope"))
+	assert.Nil(t, findFirstKeyValue(nil, "links"))
+}
+
+func BenchmarkNewSpecIndex(b *testing.B) {
+	for _, spec := range []string{"stripe.yaml", "docusignv3.1.json", "k8s.json", "asana.yaml", "xsoar.json"} {
+		data, err := os.ReadFile(filepath.Join("../test_specs", spec))
+		if err != nil {
+			b.Fatal(err)
+		}
+		var rootNode yaml.Node
+		_ = yaml.Unmarshal(data, &rootNode)
+
+		b.Run(spec, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+			}
+		})
+	}
+}
@@ -6,15 +6,22 @@ package index
 import (
 	"fmt"
 	"net/url"
-	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
 
+// componentPath converts a component path prefix (like '#/components/schemas/' or '#/definitions/') into the
+// JSON path of the node holding those components (like '$.components.schemas' or '$.definitions').
+func componentPath(pathPrefix string) string {
+	return "$." + strings.ReplaceAll(strings.Trim(strings.TrimPrefix(pathPrefix, "#/"), "/"), "/", ".")
+}
+
 func (index *SpecIndex) extractDefinitionsAndSchemas(schemasNode *yaml.Node, pathPrefix string) {
 	var name string
 	for i, schema := range schemasNode.Content {
@@ -23,8 +30,8 @@ func (index *SpecIndex) extractDefinitionsAndSchemas(schemasNode *yaml.Node, pat
 			continue
 		}
 
-		def := fmt.Sprintf("%s%s", pathPrefix, name)
-		fullDef := fmt.Sprintf("%s%s", index.specAbsolutePath, def)
+		def := intern(fmt.Sprintf("%s%s", pathPrefix, name))
+		fullDef := intern(fmt.Sprintf("%s%s", index.specAbsolutePath, def))
 
 		ref := &Reference{
 			FullDefinition:        fullDef,
@@ -32,7 +39,7 @@ func (index *SpecIndex) extractDefinitionsAndSchemas(schemasNode *yaml.Node, pat
 			Name:                  name,
 			KeyNode:               schemasNode,
 			Node:                  schema,
-			Path:                  fmt.Sprintf("$.components.schemas['%s']", name),
+			Path:                  fmt.Sprintf("%s['%s']", componentPath(pathPrefix), name),
 			ParentNode:            schemasNode,
 			RequiredRefProperties: extractDefinitionRequiredRefProperties(schemasNode, map[string][]string{}, fullDef, index),
 		}
@@ -91,8 +98,9 @@ func extractDefinitionRequiredRefProperties(schemaNode *yaml.Node, reqRefProps m
 	}
 
 	// Run through each of the required properties and extract _their_ required references
+	properties := utils.NewKeyIndex(propertiesMapNode)
 	for _, requiredPropertyNode := range requiredSeqNode.Content {
-		_, requiredPropDefNode := utils.FindKeyNodeTop(requiredPropertyNode.Value, propertiesMapNode.Content)
+		_, requiredPropDefNode := properties.FindFold(requiredPropertyNode.Value)
 		if requiredPropDefNode == nil {
 			continue
 		}
@@ -129,22 +137,13 @@ func extractRequiredReferenceProperties(fulldef string, requiredPropDefNode *yam
 					u, _ := url.Parse(exp[0])
 					r := strings.Split(refName, "#/")
 					if len(r) == 2 {
-						var abs string
-						if r[0] == "" {
-							abs = u.Path
-						} else {
-							abs, _ = filepath.Abs(utils.CheckPathOverlap(filepath.Dir(u.Path), r[0],
-								string(os.PathSeparator)))
+						if r[0] != "" {
+							u.Path = utils.JoinURLRefPath(path.Dir(u.Path), r[0])
 						}
-
-						u.Path = utils.ReplaceWindowsDriveWithLinuxPath(abs)
 						u.Fragment = ""
 						defPath = fmt.Sprintf("%s#/%s", u.String(), r[1])
 					} else {
-						u.Path = utils.ReplaceWindowsDriveWithLinuxPath(utils.CheckPathOverlap(filepath.Dir(u.Path),
-							r[0], string(os.PathSeparator)))
-						u.Fragment = ""
-						defPath = u.String()
+						defPath = utils.ResolveRefPath(exp[0], r[0])
 					}
 				} else {
 					r := strings.Split(refName, "#/")
@@ -153,39 +152,23 @@ func extractRequiredReferenceProperties(fulldef string, requiredPropDefNode *yam
 						if r[0] == "" {
 							abs, _ = filepath.Abs(exp[0])
 						} else {
-							abs, _ = filepath.Abs(utils.CheckPathOverlap(filepath.Dir(exp[0]), r[0],
-								string(os.PathSeparator)))
-
-							// abs, _ = filepath.Abs(filepath.Join(filepath.Dir(exp[0]), r[0],
-							//	string('J')))
+							abs = utils.ResolveRefPath(exp[0], r[0])
 						}
 
 						defPath = fmt.Sprintf("%s#/%s", abs, r[1])
 					} else {
-						defPath, _ = filepath.Abs(utils.CheckPathOverlap(filepath.Dir(exp[0]),
-							r[0], string(os.PathSeparator)))
+						defPath = utils.ResolveRefPath(exp[0], r[0])
 					}
 				}
 			} else {
 				defPath = refName
 			}
 		} else {
-			if strings.HasPrefix(exp[0], "http") {
-				u, _ := url.Parse(exp[0])
-				r := strings.Split(refName, "#/")
-				if len(r) == 2 {
-					abs, _ := filepath.Abs(utils.CheckPathOverlap(filepath.Dir(u.Path), r[0], string(os.PathSeparator)))
-					u.Path = utils.ReplaceWindowsDriveWithLinuxPath(abs)
-					u.Fragment = ""
-					defPath = fmt.Sprintf("%s#/%s", u.String(), r[1])
-				} else {
-					u.Path = utils.ReplaceWindowsDriveWithLinuxPath(utils.CheckPathOverlap(filepath.Dir(u.Path),
-						r[0], string(os.PathSeparator)))
-					u.Fragment = ""
-					defPath = u.String()
-				}
+			r := strings.Split(refName, "#/")
+			if len(r) == 2 {
+				defPath = fmt.Sprintf("%s#/%s", utils.ResolveRefPath(exp[0], r[0]), r[1])
 			} else {
-				defPath, _ = filepath.Abs(utils.CheckPathOverlap(filepath.Dir(exp[0]), refName, string(os.PathSeparator)))
+				defPath = utils.ResolveRefPath(exp[0], refName)
 			}
 		}
 	}
@@ -207,12 +190,15 @@ func (index *SpecIndex) extractComponentParameters(paramsNode *yaml.Node, pathPr
 			keyNode = param
 			continue
 		}
-		def := fmt.Sprintf("%s%s", pathPrefix, name)
+		def := intern(fmt.Sprintf("%s%s", pathPrefix, name))
 		ref := &Reference{
-			Definition: def,
-			Name:       name,
-			Node:       param,
-			KeyNode:    keyNode,
+			FullDefinition: fmt.Sprintf("%s%s", index.specAbsolutePath, def),
+			Definition:     def,
+			Name:           name,
+			Node:           param,
+			KeyNode:        keyNode,
+			Path:           fmt.Sprintf("%s['%s']", componentPath(pathPrefix), name),
+			ParentNode:     paramsNode,
 		}
 		index.allParameters[def] = ref
 	}
@@ -227,7 +213,7 @@ func (index *SpecIndex) extractComponentRequestBodies(requestBodiesNode *yaml.No
 			keyNode = reqBod
 			continue
 		}
-		def := fmt.Sprintf("%s%s", pathPrefix, name)
+		def := intern(fmt.Sprintf("%s%s", pathPrefix, name))
 		ref := &Reference{
 			Definition: def,
 			Name:       name,
@@ -247,12 +233,15 @@ func (index *SpecIndex) extractComponentResponses(responsesNode *yaml.Node, path
 			keyNode = response
 			continue
 		}
-		def := fmt.Sprintf("%s%s", pathPrefix, name)
+		def := intern(fmt.Sprintf("%s%s", pathPrefix, name))
 		ref := &Reference{
-			Definition: def,
-			Name:       name,
-			Node:       response,
-			KeyNode:    keyNode,
+			FullDefinition: fmt.Sprintf("%s%s", index.specAbsolutePath, def),
+			Definition:     def,
+			Name:           name,
+			Node:           response,
+			KeyNode:        keyNode,
+			Path:           fmt.Sprintf("%s['%s']", componentPath(pathPrefix), name),
+			ParentNode:     responsesNode,
 		}
 		index.allResponses[def] = ref
 	}
@@ -267,7 +256,7 @@ func (index *SpecIndex) extractComponentHeaders(headersNode *yaml.Node, pathPref
 			keyNode = header
 			continue
 		}
-		def := fmt.Sprintf("%s%s", pathPrefix, name)
+		def := intern(fmt.Sprintf("%s%s", pathPrefix, name))
 		ref := &Reference{
 			Definition: def,
 			Name:       name,
@@ -287,7 +276,7 @@ func (index *SpecIndex) extractComponentCallbacks(callbacksNode *yaml.Node, path
 			keyNode = callback
 			continue
 		}
-		def := fmt.Sprintf("%s%s", pathPrefix, name)
+		def := intern(fmt.Sprintf("%s%s", pathPrefix, name))
 		ref := &Reference{
 			Definition: def,
 			Name:       name,
@@ -307,7 +296,7 @@ func (index *SpecIndex) extractComponentLinks(linksNode *yaml.Node, pathPrefix s
 			keyNode = link
 			continue
 		}
-		def := fmt.Sprintf("%s%s", pathPrefix, name)
+		def := intern(fmt.Sprintf("%s%s", pathPrefix, name))
 		ref := &Reference{
 			Definition: def,
 			Name:       name,
@@ -327,7 +316,7 @@ func (index *SpecIndex) extractComponentExamples(examplesNode *yaml.Node, pathPr
 			keyNode = example
 			continue
 		}
-		def := fmt.Sprintf("%s%s", pathPrefix, name)
+		def := intern(fmt.Sprintf("%s%s", pathPrefix, name))
 		ref := &Reference{
 			Definition: def,
 			Name:       name,
@@ -347,8 +336,8 @@ func (index *SpecIndex) extractComponentSecuritySchemes(securitySchemesNode *yam
 			keyNode = schema
 			continue
 		}
-		def := fmt.Sprintf("%s%s", pathPrefix, name)
-		fullDef := fmt.Sprintf("%s%s", index.specAbsolutePath, def)
+		def := intern(fmt.Sprintf("%s%s", pathPrefix, name))
+		fullDef := intern(fmt.Sprintf("%s%s", index.specAbsolutePath, def))
 
 		ref := &Reference{
 			FullDefinition:        fullDef,
@@ -356,7 +345,7 @@ func (index *SpecIndex) extractComponentSecuritySchemes(securitySchemesNode *yam
 			Name:                  name,
 			Node:                  schema,
 			KeyNode:               keyNode,
-			Path:                  fmt.Sprintf("$.components.securitySchemes.%s", name),
+			Path:                  fmt.Sprintf("%s.%s", componentPath(pathPrefix), name),
 			ParentNode:            securitySchemesNode,
 			RequiredRefProperties: extractDefinitionRequiredRefProperties(securitySchemesNode, map[string][]string{}, fullDef, index),
 		}
@@ -425,7 +414,7 @@ func (index *SpecIndex) scanOperationParams(params []*yaml.Node, keyNode, pathIt
 				}
 
 				index.operationParamErrors = append(index.operationParamErrors, &IndexingError{
-					Err: fmt.Errorf("the `%s` operation parameter at path `%s`, "+
+					Err: datamodel.NewError(ErrInvalidParameter, nil, "the `%s` operation parameter at path `%s`, "+
 						"index %d has a duplicate ref `%s`", strings.ToUpper(method), pathItemNode.Value, i, paramRefName),
 					Node: param,
 					Path: path,
@@ -450,7 +439,7 @@ func (index *SpecIndex) scanOperationParams(params []*yaml.Node, keyNode, pathIt
 
 			if vn == nil {
 				index.operationParamErrors = append(index.operationParamErrors, &IndexingError{
-					Err: fmt.Errorf("the `%s` operation parameter at path `%s`, index %d has no `name` value",
+					Err: datamodel.NewError(ErrInvalidParameter, nil, "the `%s` operation parameter at path `%s`, index %d has no `name` value",
 						strings.ToUpper(method), pathItemNode.Value, i),
 					Node: param,
 					Path: path,
@@ -494,7 +483,7 @@ func (index *SpecIndex) scanOperationParams(params []*yaml.Node, keyNode, pathIt
 						}
 
 						index.operationParamErrors = append(index.operationParamErrors, &IndexingError{
-							Err: fmt.Errorf("the `%s` operation parameter at path `%s`, "+
+							Err: datamodel.NewError(ErrInvalidParameter, nil, "the `%s` operation parameter at path `%s`, "+
 								"index %d has a duplicate name `%s` and `in` type", strings.ToUpper(method), pathItemNode.Value, i, vn.Value),
 							Node: param,
 							Path: path,
@@ -536,10 +525,10 @@ func findIndex(index *SpecIndex, i *yaml.Node) *SpecIndex {
 
 func runIndexFunction(funcs []func() int, wg *sync.WaitGroup) {
 	for _, cFunc := range funcs {
-		go func(wg *sync.WaitGroup, cf func() int) {
-			cf()
+		utils.Go(func() {
+			cFunc()
 			wg.Done()
-		}(wg, cFunc)
+		})
 	}
 }
 
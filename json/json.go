@@ -1,8 +1,12 @@
 package json
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"reflect"
 
 	"github.com/pb33f/libopenapi/orderedmap"
@@ -10,13 +14,135 @@ import (
 )
 
 // YAMLNodeToJSON converts yaml/json stored in a yaml.Node to json ordered matching the original yaml/json
+//
+// Numbers are rendered exactly as they were written (so a version of 1.10 stays 1.10), timestamps and binary values
+// are rendered as the strings they were written as, and HTML characters in strings are not escaped.
 func YAMLNodeToJSON(node *yaml.Node, indentation string) ([]byte, error) {
-	v, err := handleYAMLNode(node)
-	if err != nil {
+	var b bytes.Buffer
+	if err := YAMLNodeToJSONWriter(&b, node, indentation); err != nil {
 		return nil, err
 	}
+	return b.Bytes(), nil
+}
 
-	return json.MarshalIndent(v, "", indentation)
+// YAMLNodeToJSONWriter is the same as YAMLNodeToJSON, except the JSON is written to w as it is encoded, so the JSON is
+// never held in memory as a complete byte slice. If an error is returned, some of the JSON may have been written already.
+func YAMLNodeToJSONWriter(w io.Writer, node *yaml.Node, indentation string) error {
+	bw := bufio.NewWriter(w)
+	if err := encodeNode(bw, node, indentation, 0); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// encodeNode writes a node as JSON, indenting every level by indentation, without converting it to values first.
+func encodeNode(b writer, node *yaml.Node, indentation string, depth int) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		return encodeNode(b, node.Content[0], indentation, depth)
+	case yaml.AliasNode:
+		return encodeNode(b, node.Alias, indentation, depth)
+	case yaml.MappingNode:
+		return encodeMappingNode(b, node, indentation, depth)
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			_, err := b.WriteString("[]")
+			return err
+		}
+		_ = b.WriteByte('[')
+		for i, n := range node.Content {
+			if i > 0 {
+				_ = b.WriteByte(',')
+			}
+			newline(b, indentation, depth+1)
+			if err := encodeNode(b, n, indentation, depth+1); err != nil {
+				return err
+			}
+		}
+		newline(b, indentation, depth)
+		return b.WriteByte(']')
+	case yaml.ScalarNode:
+		v, err := handleScalarNode(node)
+		if err != nil {
+			return err
+		}
+		return encodeValue(b, v)
+	default:
+		return fmt.Errorf("unknown node kind: %v", node.Kind)
+	}
+}
+
+// encodeMappingNode writes a mapping node as a JSON object. A key that is repeated is written where it first
+// appears, with the value it was last given.
+func encodeMappingNode(b writer, node *yaml.Node, indentation string, depth int) error {
+	keys := make([]string, 0, len(node.Content)/2)
+	last := make(map[string]int, len(node.Content)/2)
+	for i := 1; i < len(node.Content); i += 2 {
+		key, err := mappingKey(node.Content[i-1])
+		if err != nil {
+			return err
+		}
+		if _, ok := last[key]; !ok {
+			keys = append(keys, key)
+		}
+		last[key] = i
+	}
+	if len(keys) == 0 {
+		_, err := b.WriteString("{}")
+		return err
+	}
+	_ = b.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			_ = b.WriteByte(',')
+		}
+		newline(b, indentation, depth+1)
+		if err := encodeValue(b, key); err != nil {
+			return err
+		}
+		_ = b.WriteByte(':')
+		if indentation != "" {
+			_ = b.WriteByte(' ')
+		}
+		if err := encodeNode(b, node.Content[last[key]], indentation, depth+1); err != nil {
+			return err
+		}
+	}
+	newline(b, indentation, depth)
+	return b.WriteByte('}')
+}
+
+// mappingKey returns the key of a mapping as a string, keys that are not strings are written as compact JSON.
+func mappingKey(keyNode *yaml.Node) (string, error) {
+	kv, err := handleYAMLNode(keyNode)
+	if err != nil {
+		return "", err
+	}
+	if reflect.TypeOf(kv).Kind() != reflect.String {
+		var keyData bytes.Buffer
+		if err = encode(&keyData, kv, "", 0); err != nil {
+			return "", err
+		}
+		kv = keyData.String()
+	}
+	return fmt.Sprintf("%v", kv), nil
+}
+
+// writer is what JSON is written to, a bytes.Buffer or a bufio.Writer.
+type writer interface {
+	io.Writer
+	io.ByteWriter
+	io.StringWriter
+}
+
+// newline starts a new line indented to a depth, if there is any indentation.
+func newline(b writer, indentation string, depth int) {
+	if indentation != "" {
+		_ = b.WriteByte('\n')
+		for i := 0; i < depth; i++ {
+			_, _ = b.WriteString(indentation)
+		}
+	}
 }
 
 func handleYAMLNode(node *yaml.Node) (any, error) {
@@ -49,11 +175,11 @@ func handleMappingNode(node *yaml.Node) (any, error) {
 		}
 
 		if reflect.TypeOf(kv).Kind() != reflect.String {
-			keyData, err := json.Marshal(kv)
-			if err != nil {
+			var keyData bytes.Buffer
+			if err = encode(&keyData, kv, "", 0); err != nil {
 				return nil, err
 			}
-			kv = string(keyData)
+			kv = keyData.String()
 		}
 
 		vv, err := handleYAMLNode(n)
@@ -88,11 +214,90 @@ func handleSequenceNode(node *yaml.Node) (any, error) {
 }
 
 func handleScalarNode(node *yaml.Node) (any, error) {
+	switch node.ShortTag() {
+	case "!!int", "!!float":
+		// keep the number as it was written, if it's already a valid JSON number.
+		if json.Valid([]byte(node.Value)) {
+			return json.Number(node.Value), nil
+		}
+	case "!!timestamp", "!!binary":
+		return node.Value, nil
+	}
+
 	var v any
 
 	if err := node.Decode(&v); err != nil {
 		return nil, err
 	}
 
+	// JSON has no infinity or NaN, keep them as they were written.
+	if f, ok := v.(float64); ok && (math.IsInf(f, 0) || math.IsNaN(f)) {
+		return node.Value, nil
+	}
+
 	return v, nil
 }
+
+// encode writes v as JSON, indenting every level by indentation. Maps are written in order.
+func encode(b writer, v any, indentation string, depth int) error {
+	switch t := v.(type) {
+	case *orderedmap.Map[string, any]:
+		if orderedmap.Len(t) == 0 {
+			b.WriteString("{}")
+			return nil
+		}
+		b.WriteByte('{')
+		first := true
+		for k, val := range t.FromOldest() {
+			if !first {
+				b.WriteByte(',')
+			}
+			first = false
+			newline(b, indentation, depth+1)
+			if err := encodeValue(b, k); err != nil {
+				return err
+			}
+			b.WriteByte(':')
+			if indentation != "" {
+				b.WriteByte(' ')
+			}
+			if err := encode(b, val, indentation, depth+1); err != nil {
+				return err
+			}
+		}
+		newline(b, indentation, depth)
+		b.WriteByte('}')
+	case []any:
+		if len(t) == 0 {
+			b.WriteString("[]")
+			return nil
+		}
+		b.WriteByte('[')
+		for i, val := range t {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			newline(b, indentation, depth+1)
+			if err := encode(b, val, indentation, depth+1); err != nil {
+				return err
+			}
+		}
+		newline(b, indentation, depth)
+		b.WriteByte(']')
+	default:
+		return encodeValue(b, v)
+	}
+	return nil
+}
+
+// encodeValue writes a single value as compact JSON, without escaping HTML characters.
+func encodeValue(b writer, v any) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := b.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	return err
+}
@@ -1,6 +1,8 @@
 package json_test
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/pb33f/libopenapi/json"
@@ -144,3 +146,69 @@ func TestYAMLNodeToJSONInvalidNode(t *testing.T) {
 	assert.Nil(t, j)
 	assert.Error(t, err)
 }
+
+func TestYAMLNodeToJSONWriter(t *testing.T) {
+	y := `b: 1
+a:
+  - x: <y>
+    z: [1.10, true, ~]
+  - &anchor {}
+c: *anchor
+b: 2
+3: three`
+
+	var v yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(y), &v))
+
+	for _, indent := range []string{"", "  "} {
+		expected, err := json.YAMLNodeToJSON(&v, indent)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, json.YAMLNodeToJSONWriter(&b, &v, indent))
+		assert.Equal(t, string(expected), b.String())
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, json.YAMLNodeToJSONWriter(&b, &v, ""))
+	assert.Equal(t, `{"b":2,"a":[{"x":"<y>","z":[1.10,true,null]},{}],"c":{},"3":"three"}`, b.String())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestYAMLNodeToJSONWriter_Errors(t *testing.T) {
+	var v yaml.Node
+	assert.Error(t, json.YAMLNodeToJSONWriter(&bytes.Buffer{}, &v, ""))
+
+	require.NoError(t, yaml.Unmarshal([]byte("a: b"), &v))
+	assert.EqualError(t, json.YAMLNodeToJSONWriter(failingWriter{}, &v, ""), "disk full")
+}
+
+func TestYAMLNodeToJSON_Scalars(t *testing.T) {
+	y := `version: 1.10
+count: 007
+big: 12345678901234567890
+exp: 1e3
+hex: 0x1F
+inf: .inf
+html: <a href="x">&</a>
+date: 2024-01-02
+empty: {}
+none: []
+nothing: ~`
+
+	var v yaml.Node
+
+	err := yaml.Unmarshal([]byte(y), &v)
+	require.NoError(t, err)
+
+	j, err := json.YAMLNodeToJSON(&v, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"version":1.10,"count":7,"big":12345678901234567890,"exp":1e3,"hex":31,"inf":".inf",`+
+		`"html":"<a href=\"x\">&</a>","date":"2024-01-02","empty":{},"none":[],"nothing":null}`, string(j))
+}
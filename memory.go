@@ -0,0 +1,160 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// MemoryStats is the approximate memory held by a document, in bytes, see DocumentConfiguration.MemoryAccounting.
+// Memory shared by more than one part of the document is only counted once, by the first part it is found in (in
+// the order of the fields). Memory held by caches that cannot be inspected (such as sync.Map) is not counted.
+type MemoryStats struct {
+	// RawBytes is held by the bytes of the specification, and of every file it references.
+	RawBytes int64 `json:"rawBytes" yaml:"rawBytes"`
+
+	// NodeTrees is held by the node trees parsed from the specification and every file it references, along with the
+	// JSON map of the specification.
+	NodeTrees int64 `json:"nodeTrees" yaml:"nodeTrees"`
+
+	// Indexes is held by the rolodex, and the index of every file in it.
+	Indexes int64 `json:"indexes" yaml:"indexes"`
+
+	// Models is held by the models built (high-level, and the low-level models they are built from).
+	Models int64 `json:"models" yaml:"models"`
+}
+
+// Total returns the approximate memory held by the document, in bytes.
+func (m MemoryStats) Total() int64 {
+	return m.RawBytes + m.NodeTrees + m.Indexes + m.Models
+}
+
+func (d *document) memoryStats() *MemoryStats {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	stats := &MemoryStats{}
+	s := &memorySizer{stats: stats, seen: make(map[memoryKey]bool)}
+	if d.info != nil {
+		s.measure(reflect.ValueOf(d.info), &stats.NodeTrees)
+	}
+	if d.rolodex != nil {
+		// the file systems of the rolodex hold their files in a sync.Map, their sizes are the size of their bytes.
+		stats.RawBytes += d.rolodex.RolodexFileSize()
+		s.measure(reflect.ValueOf(d.rolodex), &stats.Indexes)
+	}
+	if d.highOpenAPI3Model != nil {
+		s.measure(reflect.ValueOf(d.highOpenAPI3Model), &stats.Models)
+	}
+	if d.highSwaggerModel != nil {
+		s.measure(reflect.ValueOf(d.highSwaggerModel), &stats.Models)
+	}
+	return stats
+}
+
+var (
+	yamlNodeType = reflect.TypeOf(yaml.Node{})
+	indexType    = reflect.TypeOf(index.SpecIndex{})
+	rtypeType    = reflect.TypeOf(reflect.TypeOf(0))
+)
+
+type memoryKey struct {
+	pointer unsafe.Pointer
+	typ     reflect.Type
+}
+
+// memorySizer adds up the memory reachable from values, attributing byte slices to RawBytes and nodes to NodeTrees
+// wherever they are found, and everything else to the part being measured.
+type memorySizer struct {
+	stats *MemoryStats
+	seen  map[memoryKey]bool
+}
+
+// measure adds the memory a pointer (or a map, or a slice) refers to.
+func (s *memorySizer) measure(v reflect.Value, part *int64) {
+	if v.IsValid() {
+		s.indirect(v, part)
+	}
+}
+
+// visit returns true the first time memory of a type is found at an address.
+func (s *memorySizer) visit(pointer unsafe.Pointer, typ reflect.Type) bool {
+	key := memoryKey{pointer, typ}
+	if pointer == nil || s.seen[key] {
+		return false
+	}
+	s.seen[key] = true
+	return true
+}
+
+// indirect adds the memory a value refers to, not counting the value itself.
+func (s *memorySizer) indirect(v reflect.Value, part *int64) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || !s.visit(v.UnsafePointer(), v.Type()) {
+			return
+		}
+		elem := v.Elem()
+		switch elem.Type() {
+		case yamlNodeType:
+			part = &s.stats.NodeTrees
+		case indexType:
+			part = &s.stats.Indexes
+		}
+		*part += int64(elem.Type().Size())
+		s.indirect(elem, part)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			s.indirect(v.Field(i), part)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			s.indirect(v.Index(i), part)
+		}
+	case reflect.Slice:
+		if v.IsNil() || !s.visit(v.UnsafePointer(), v.Type()) {
+			return
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			s.stats.RawBytes += int64(v.Cap())
+			return
+		}
+		*part += int64(v.Cap()) * int64(v.Type().Elem().Size())
+		for i := 0; i < v.Len(); i++ {
+			s.indirect(v.Index(i), part)
+		}
+	case reflect.String:
+		if v.Len() > 0 && s.visit(unsafe.Pointer(unsafe.StringData(v.String())), v.Type()) {
+			*part += int64(v.Len())
+		}
+	case reflect.Map:
+		if v.IsNil() || !s.visit(v.UnsafePointer(), v.Type()) {
+			return
+		}
+		// buckets are rarely full, allow for the overhead.
+		*part += int64(v.Len()) * int64(v.Type().Key().Size()+v.Type().Elem().Size()+1) * 3 / 2
+		for iter := v.MapRange(); iter.Next(); {
+			s.indirect(iter.Key(), part)
+			s.indirect(iter.Value(), part)
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		elem := v.Elem()
+		if elem.Type() == rtypeType {
+			return // type information is not held by the document.
+		}
+		switch elem.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		default:
+			// a value that is not a pointer is copied into the interface.
+			*part += int64(elem.Type().Size())
+		}
+		s.indirect(elem, part)
+	}
+}
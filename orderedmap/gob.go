@@ -0,0 +1,48 @@
+package orderedmap
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// gobMap is how a Map is encoded with encoding/gob, its keys and values in order.
+type gobMap[K comparable, V any] struct {
+	Keys   []K
+	Values []V
+}
+
+// GobEncode encodes the map with encoding/gob, keeping its order, so maps can be cached and decoded again with
+// GobDecode. The keys and values must be encodable with gob (register the concrete types of interface values with
+// gob.Register).
+func (o *Map[K, V]) GobEncode() ([]byte, error) {
+	g := gobMap[K, V]{}
+	for k, v := range o.FromOldest() {
+		g.Keys = append(g.Keys, k)
+		g.Values = append(g.Values, v)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(g); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes a map encoded by GobEncode, in the order it was encoded. Existing keys are updated in place,
+// new keys are added to the end of the map.
+func (o *Map[K, V]) GobDecode(data []byte) error {
+	var g gobMap[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&g); err != nil {
+		return err
+	}
+	if o.OrderedMap == nil {
+		*o = *New[K, V]()
+	}
+	for i, k := range g.Keys {
+		var v V
+		if i < len(g.Values) {
+			v = g.Values[i]
+		}
+		o.Set(k, v)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package orderedmap_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"slices"
+	"testing"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMap_Gob(t *testing.T) {
+	m := orderedmap.New[string, int]()
+	m.Set("pizza", 3)
+	m.Set("burger", 1)
+	m.Set("fries", 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(m))
+
+	var decoded *orderedmap.Map[string, int]
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+	assert.Equal(t, []string{"pizza", "burger", "fries"}, slices.Collect(decoded.KeysFromOldest()))
+	assert.Equal(t, []int{3, 1, 2}, slices.Collect(decoded.ValuesFromOldest()))
+}
+
+func TestMap_Gob_Struct(t *testing.T) {
+	type menu struct {
+		Name  string
+		Items *orderedmap.Map[string, []string]
+		Empty *orderedmap.Map[string, int]
+	}
+	items := orderedmap.New[string, []string]()
+	items.Set("z-pizza", []string{"cheese", "ham"})
+	items.Set("a-burger", nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(menu{Name: "lunch", Items: items}))
+
+	var decoded menu
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+	assert.Equal(t, "lunch", decoded.Name)
+	assert.Equal(t, []string{"z-pizza", "a-burger"}, slices.Collect(decoded.Items.KeysFromOldest()))
+	assert.Equal(t, []string{"cheese", "ham"}, decoded.Items.GetOrZero("z-pizza"))
+	assert.Nil(t, decoded.Empty)
+}
+
+func TestMap_Gob_Interface(t *testing.T) {
+	type topping struct {
+		Name string
+	}
+	gob.Register(topping{})
+
+	m := orderedmap.New[string, any]()
+	m.Set("size", 12)
+	m.Set("topping", topping{Name: "cheese"})
+
+	b, err := m.GobEncode()
+	require.NoError(t, err)
+
+	decoded := orderedmap.New[string, any]()
+	decoded.Set("topping", "none")
+	decoded.Set("hot", true)
+	require.NoError(t, decoded.GobDecode(b))
+	assert.Equal(t, []string{"topping", "hot", "size"}, slices.Collect(decoded.KeysFromOldest()))
+	assert.Equal(t, topping{Name: "cheese"}, decoded.GetOrZero("topping"))
+	assert.Equal(t, 12, decoded.GetOrZero("size"))
+
+	assert.Error(t, decoded.GobDecode([]byte("not gob")))
+}
@@ -0,0 +1,114 @@
+package orderedmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// UnmarshalJSON populates the map from a JSON object, in the order its keys are written. Existing keys are updated
+// in place, new keys are added to the end of the map, and null leaves the map unchanged.
+//
+// Keys are decoded into K as strings, or as JSON (so numeric keys work), or with encoding.TextUnmarshaler. Values are
+// decoded into V with encoding/json, except when V is an interface (like any): objects then become
+// *Map[string, any] instead of map[string]any, at every level, so no ordering is lost.
+func (o *Map[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return nil
+	}
+	if d, ok := t.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("unable to unmarshal %v into an ordered map, expected a JSON object", t)
+	}
+	if o.OrderedMap == nil {
+		*o = *New[K, V]()
+	}
+	ordered := reflect.TypeFor[V]().Kind() == reflect.Interface
+	for dec.More() {
+		t, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := decodeKey[K](t.(string))
+		if err != nil {
+			return err
+		}
+		var value V
+		if ordered {
+			var v any
+			if v, err = decodeOrdered(dec); err != nil {
+				return err
+			}
+			if v != nil {
+				typed, ok := v.(V)
+				if !ok {
+					return fmt.Errorf("unable to unmarshal the value of '%s' into %s", t, reflect.TypeFor[V]())
+				}
+				value = typed
+			}
+		} else if err = dec.Decode(&value); err != nil {
+			return err
+		}
+		o.Set(key, value)
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// decodeKey decodes the key of a JSON object into K.
+func decodeKey[K comparable](key string) (K, error) {
+	var k K
+	if err := json.Unmarshal([]byte(strconv.Quote(key)), &k); err == nil {
+		return k, nil
+	}
+	if err := json.Unmarshal([]byte(key), &k); err != nil {
+		return k, fmt.Errorf("unable to unmarshal key '%s' into %T: %w", key, k, err)
+	}
+	return k, nil
+}
+
+// decodeOrdered decodes the next JSON value, objects are decoded into *Map[string, any] to keep their order.
+func decodeOrdered(dec *json.Decoder) (any, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	d, ok := t.(json.Delim)
+	if !ok {
+		return t, nil
+	}
+	switch d {
+	case '{':
+		m := New[string, any]()
+		for dec.More() {
+			k, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			m.Set(k.(string), v)
+		}
+		_, err = dec.Token()
+		return m, err
+	default: // '['
+		items := []any{}
+		for dec.More() {
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		_, err = dec.Token()
+		return items, err
+	}
+}
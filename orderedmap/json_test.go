@@ -0,0 +1,63 @@
+package orderedmap_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMap_UnmarshalJSON(t *testing.T) {
+	var m *orderedmap.Map[string, int]
+	require.NoError(t, json.Unmarshal([]byte(`{"pizza": 3, "burger": 1, "fries": 2}`), &m))
+	assert.Equal(t, []string{"pizza", "burger", "fries"}, slices.Collect(m.KeysFromOldest()))
+	assert.Equal(t, []int{3, 1, 2}, slices.Collect(m.ValuesFromOldest()))
+
+	// existing keys keep their place.
+	require.NoError(t, json.Unmarshal([]byte(`{"shake": 4, "pizza": 5}`), m))
+	assert.Equal(t, []string{"pizza", "burger", "fries", "shake"}, slices.Collect(m.KeysFromOldest()))
+	assert.Equal(t, 5, m.GetOrZero("pizza"))
+
+	require.NoError(t, json.Unmarshal([]byte(`null`), m))
+	assert.Equal(t, 4, m.Len())
+}
+
+func TestMap_UnmarshalJSON_Nested(t *testing.T) {
+	spec := `{"z-pizza":{"toppings":["cheese",{"y":1,"b":2}],"size":12.5,"hot":true},"a-burger":null}`
+	m := orderedmap.New[string, any]()
+	require.NoError(t, json.Unmarshal([]byte(spec), m))
+	assert.Equal(t, []string{"z-pizza", "a-burger"}, slices.Collect(m.KeysFromOldest()))
+
+	pizza := m.GetOrZero("z-pizza").(*orderedmap.Map[string, any])
+	assert.Equal(t, []string{"toppings", "size", "hot"}, slices.Collect(pizza.KeysFromOldest()))
+	assert.Equal(t, 12.5, pizza.GetOrZero("size"))
+	assert.Equal(t, true, pizza.GetOrZero("hot"))
+	toppings := pizza.GetOrZero("toppings").([]any)
+	assert.Equal(t, "cheese", toppings[0])
+	assert.Equal(t, []string{"y", "b"}, slices.Collect(toppings[1].(*orderedmap.Map[string, any]).KeysFromOldest()))
+	assert.Nil(t, m.GetOrZero("a-burger"))
+
+	// the order survives a round trip.
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, spec, string(b))
+}
+
+func TestMap_UnmarshalJSON_Types(t *testing.T) {
+	var byNumber *orderedmap.Map[int, string]
+	require.NoError(t, json.Unmarshal([]byte(`{"2": "two", "1": "one"}`), &byNumber))
+	assert.Equal(t, []int{2, 1}, slices.Collect(byNumber.KeysFromOldest()))
+
+	var nested *orderedmap.Map[string, *orderedmap.Map[string, int]]
+	require.NoError(t, json.Unmarshal([]byte(`{"b": {"y": 1, "x": 2}, "a": {}}`), &nested))
+	assert.Equal(t, []string{"b", "a"}, slices.Collect(nested.KeysFromOldest()))
+	assert.Equal(t, []string{"y", "x"}, slices.Collect(nested.GetOrZero("b").KeysFromOldest()))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"pizza": "three"}`), &byNumber))
+	assert.Error(t, json.Unmarshal([]byte(`["pizza"]`), &byNumber))
+	assert.Error(t, json.Unmarshal([]byte(`{"one": "pizza"}`), &byNumber))
+	assert.Error(t, orderedmap.New[string, any]().UnmarshalJSON([]byte(`{"pizza": [1, }`)))
+}
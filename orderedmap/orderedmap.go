@@ -5,6 +5,7 @@
 package orderedmap
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"iter"
@@ -105,7 +106,7 @@ func (o *Map[K, V]) FromNewest() iter.Seq2[K, V] {
 	}
 }
 
-// FromNewest returns an iterator that yields the newest key-value pair in the map.
+// KeysFromOldest returns an iterator that yields the oldest key in the map.
 func (o *Map[K, V]) KeysFromOldest() iter.Seq[K] {
 	return func(yield func(K) bool) {
 		if o == nil {
@@ -163,6 +164,25 @@ func (o *Map[K, V]) ValuesFromNewest() iter.Seq[V] {
 	}
 }
 
+// All returns an iterator of the key-value pairs in the map, in the order they were added, like maps.All does for a
+// built-in map:
+//
+//	for name, schema := range components.Schemas.All() {
+//	}
+func (o *Map[K, V]) All() iter.Seq2[K, V] {
+	return o.FromOldest()
+}
+
+// Keys returns an iterator of the keys in the map, in the order they were added.
+func (o *Map[K, V]) Keys() iter.Seq[K] {
+	return o.KeysFromOldest()
+}
+
+// Values returns an iterator of the values in the map, in the order they were added.
+func (o *Map[K, V]) Values() iter.Seq[V] {
+	return o.ValuesFromOldest()
+}
+
 // From creates a new ordered map from an iterator.
 func From[K comparable, V any](iter iter.Seq2[K, V]) *Map[K, V] {
 	return &Map[K, V]{
@@ -321,3 +341,78 @@ func SortAlpha[K comparable, V any](m *Map[K, V]) *Map[K, V] {
 
 	return om
 }
+
+// SortKeys returns a copy of the map, sorted by key. Safely handles nil pointer.
+func SortKeys[K cmp.Ordered, V any](m *Map[K, V]) *Map[K, V] {
+	return SortFunc(m, func(a, b Pair[K, V]) int {
+		return cmp.Compare(a.Key(), b.Key())
+	})
+}
+
+// SortFunc returns a copy of the map, sorted with a comparison function of its pairs, which returns a negative number
+// when a sorts before b, a positive number when a sorts after b, and zero when their order should be kept.
+// Safely handles nil pointer.
+func SortFunc[K comparable, V any](m *Map[K, V], compare func(a, b Pair[K, V]) int) *Map[K, V] {
+	if m == nil {
+		return nil
+	}
+	var pairs []Pair[K, V]
+	for pair := First(m); pair != nil; pair = pair.Next() {
+		pairs = append(pairs, pair)
+	}
+	slices.SortStableFunc(pairs, compare)
+
+	om := New[K, V]()
+	for _, pair := range pairs {
+		om.Set(pair.Key(), pair.Value())
+	}
+	return om
+}
+
+// Filter returns a new map holding the pairs of the map that keep returns true for, in the same order.
+// Safely handles nil pointer.
+func Filter[K comparable, V any](m *Map[K, V], keep func(key K, value V) bool) *Map[K, V] {
+	if m == nil {
+		return nil
+	}
+	om := New[K, V]()
+	for k, v := range m.FromOldest() {
+		if keep(k, v) {
+			om.Set(k, v)
+		}
+	}
+	return om
+}
+
+// MapValues returns a new map with the same keys, in the same order, holding the values returned by transform.
+// Safely handles nil pointer.
+func MapValues[K comparable, V, R any](m *Map[K, V], transform func(key K, value V) R) *Map[K, R] {
+	if m == nil {
+		return nil
+	}
+	om := New[K, R]()
+	for k, v := range m.FromOldest() {
+		om.Set(k, transform(k, v))
+	}
+	return om
+}
+
+// Merge returns a new map holding the pairs of a, followed by the pairs of b that are not in a. When a key is in
+// both maps, the value returned by conflict is used (in the position of the key in a), if conflict is nil the value
+// of b is used. Returns nil if both maps are nil.
+func Merge[K comparable, V any](a, b *Map[K, V], conflict func(key K, a, b V) V) *Map[K, V] {
+	if a == nil && b == nil {
+		return nil
+	}
+	om := New[K, V]()
+	for k, v := range a.FromOldest() {
+		om.Set(k, v)
+	}
+	for k, v := range b.FromOldest() {
+		if existing, ok := om.Get(k); ok && conflict != nil {
+			v = conflict(k, existing, v)
+		}
+		om.Set(k, v)
+	}
+	return om
+}
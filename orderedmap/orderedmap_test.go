@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -437,6 +438,32 @@ func TestIterators(t *testing.T) {
 	}
 
 	assert.Equal(t, expectedValuesFromNewest, values)
+
+	keys, values = []int{}, []any{}
+
+	for k, v := range om.All() {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+
+	assert.Equal(t, expectedKeys, keys)
+	assert.Equal(t, expectedValues, values)
+
+	keys = []int{}
+
+	for k := range om.Keys() {
+		keys = append(keys, k)
+	}
+
+	assert.Equal(t, expectedKeys, keys)
+
+	values = []any{}
+
+	for v := range om.Values() {
+		values = append(values, v)
+	}
+
+	assert.Equal(t, expectedValues, values)
 }
 
 func TestIteratorsWithBreak(t *testing.T) {
@@ -541,6 +568,18 @@ func TestIteratorsWithNilMaps(t *testing.T) {
 	for range om.ValuesFromNewest() {
 		assert.Fail(t, "should not be called")
 	}
+
+	for range om.All() {
+		assert.Fail(t, "should not be called")
+	}
+
+	for range om.Keys() {
+		assert.Fail(t, "should not be called")
+	}
+
+	for range om.Values() {
+		assert.Fail(t, "should not be called")
+	}
 }
 
 func TestIteratorsFrom(t *testing.T) {
@@ -587,6 +626,96 @@ func TestIteratorsFrom(t *testing.T) {
 	assert.Equal(t, expectedValuesFromNewest, values)
 }
 
+func TestSortKeys(t *testing.T) {
+	om := orderedmap.FromPairs(
+		orderedmap.NewPair(10, "ten"),
+		orderedmap.NewPair(2, "two"),
+		orderedmap.NewPair(1, "one"),
+	)
+	sorted := orderedmap.SortKeys(om)
+	assert.Equal(t, []int{1, 2, 10}, slices.Collect(sorted.KeysFromOldest()))
+	assert.Equal(t, []int{10, 2, 1}, slices.Collect(om.KeysFromOldest()))
+
+	assert.Nil(t, orderedmap.SortKeys[int, string](nil))
+}
+
+func TestSortFunc(t *testing.T) {
+	om := orderedmap.FromPairs(
+		orderedmap.NewPair("pizza", 3),
+		orderedmap.NewPair("burger", 1),
+		orderedmap.NewPair("fries", 3),
+		orderedmap.NewPair("shake", 2),
+	)
+	sorted := orderedmap.SortFunc(om, func(a, b orderedmap.Pair[string, int]) int {
+		return b.Value() - a.Value()
+	})
+	// equal values keep their order.
+	assert.Equal(t, []string{"pizza", "fries", "shake", "burger"}, slices.Collect(sorted.KeysFromOldest()))
+
+	assert.Nil(t, orderedmap.SortFunc[string, int](nil, nil))
+}
+
+func TestFilter(t *testing.T) {
+	om := orderedmap.FromPairs(
+		orderedmap.NewPair("pizza", 3),
+		orderedmap.NewPair("burger", 1),
+		orderedmap.NewPair("fries", 4),
+	)
+	filtered := orderedmap.Filter(om, func(k string, v int) bool {
+		return v > 2
+	})
+	assert.Equal(t, []string{"pizza", "fries"}, slices.Collect(filtered.KeysFromOldest()))
+	assert.Equal(t, 3, orderedmap.Len(om))
+
+	empty := orderedmap.Filter(om, func(k string, v int) bool { return false })
+	assert.NotNil(t, empty)
+	assert.Equal(t, 0, orderedmap.Len(empty))
+
+	assert.Nil(t, orderedmap.Filter[string, int](nil, nil))
+}
+
+func TestMapValues(t *testing.T) {
+	om := orderedmap.FromPairs(
+		orderedmap.NewPair("pizza", 3),
+		orderedmap.NewPair("burger", 1),
+	)
+	mapped := orderedmap.MapValues(om, func(k string, v int) string {
+		return fmt.Sprintf("%d %s", v, k)
+	})
+	assert.Equal(t, []string{"pizza", "burger"}, slices.Collect(mapped.KeysFromOldest()))
+	assert.Equal(t, []string{"3 pizza", "1 burger"}, slices.Collect(mapped.ValuesFromOldest()))
+
+	assert.Nil(t, orderedmap.MapValues[string, int, string](nil, nil))
+}
+
+func TestMerge(t *testing.T) {
+	a := orderedmap.FromPairs(
+		orderedmap.NewPair("pizza", 3),
+		orderedmap.NewPair("burger", 1),
+	)
+	b := orderedmap.FromPairs(
+		orderedmap.NewPair("fries", 4),
+		orderedmap.NewPair("pizza", 5),
+	)
+
+	merged := orderedmap.Merge(a, b, nil)
+	assert.Equal(t, []string{"pizza", "burger", "fries"}, slices.Collect(merged.KeysFromOldest()))
+	assert.Equal(t, []int{5, 1, 4}, slices.Collect(merged.ValuesFromOldest()))
+
+	merged = orderedmap.Merge(a, b, func(key string, a, b int) int {
+		assert.Equal(t, "pizza", key)
+		return a + b
+	})
+	assert.Equal(t, []int{8, 1, 4}, slices.Collect(merged.ValuesFromOldest()))
+	assert.Equal(t, 3, a.GetOrZero("pizza"))
+
+	merged = orderedmap.Merge(nil, b, nil)
+	assert.Equal(t, []string{"fries", "pizza"}, slices.Collect(merged.KeysFromOldest()))
+	assert.NotSame(t, b, merged)
+
+	assert.Nil(t, orderedmap.Merge[string, int](nil, nil, nil))
+}
+
 func requireClosed[K comparable, V any](t *testing.T, c <-chan orderedmap.Pair[K, V]) {
 	select {
 	case pair := <-c:
@@ -0,0 +1,116 @@
+package orderedmap
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// Sync is an ordered map that is safe for concurrent use by multiple goroutines, without any external locking. It
+// is built for many readers and occasional writers: every write copies the map (copy-on-write), so readers never
+// wait, and never see a partly written map.
+//
+// Snapshot returns the map as it is at that moment, it never changes, so it can be read (or iterated) for as long as
+// needed while writers carry on.
+type Sync[K comparable, V any] struct {
+	writeLock sync.Mutex
+	current   atomic.Pointer[Map[K, V]]
+}
+
+// NewSync creates an empty, concurrency safe, ordered map.
+func NewSync[K comparable, V any]() *Sync[K, V] {
+	return NewSyncFrom[K, V](nil)
+}
+
+// NewSyncFrom creates a concurrency safe ordered map, holding a copy of the pairs of m (which may be nil).
+func NewSyncFrom[K comparable, V any](m *Map[K, V]) *Sync[K, V] {
+	s := &Sync[K, V]{}
+	s.current.Store(From(m.FromOldest()))
+	return s
+}
+
+// Snapshot returns the current map. The snapshot is not changed by later writes, and must not be modified.
+func (s *Sync[K, V]) Snapshot() *Map[K, V] {
+	return s.current.Load()
+}
+
+// Get returns the value of a key, and whether the key is present.
+func (s *Sync[K, V]) Get(key K) (V, bool) {
+	return s.Snapshot().Get(key)
+}
+
+// GetOrZero returns the value of a key, or the zero value if the key is not present.
+func (s *Sync[K, V]) GetOrZero(key K) V {
+	return s.Snapshot().GetOrZero(key)
+}
+
+// Len returns the number of pairs in the map.
+func (s *Sync[K, V]) Len() int {
+	return Len(s.Snapshot())
+}
+
+// Set sets the value of a key, adding it to the end of the map if it is not present. The previous value, and
+// whether there was one, is returned.
+func (s *Sync[K, V]) Set(key K, value V) (V, bool) {
+	var previous V
+	var present bool
+	s.Update(func(m *Map[K, V]) {
+		previous, present = m.Set(key, value)
+	})
+	return previous, present
+}
+
+// Delete removes a key from the map. The removed value, and whether the key was present, is returned.
+func (s *Sync[K, V]) Delete(key K) (V, bool) {
+	var previous V
+	var present bool
+	s.Update(func(m *Map[K, V]) {
+		previous, present = m.Delete(key)
+	})
+	return previous, present
+}
+
+// Update applies a batch of changes to a copy of the map, which then replaces the map. Readers see every change at
+// once, or none of them. Writers wait for each other, update must not keep the map it is given.
+func (s *Sync[K, V]) Update(update func(m *Map[K, V])) {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	m := From(s.Snapshot().FromOldest())
+	update(m)
+	s.current.Store(m)
+}
+
+// FromOldest returns an iterator of a snapshot of the map, from the oldest pair to the newest.
+func (s *Sync[K, V]) FromOldest() iter.Seq2[K, V] {
+	return s.Snapshot().FromOldest()
+}
+
+// FromNewest returns an iterator of a snapshot of the map, from the newest pair to the oldest.
+func (s *Sync[K, V]) FromNewest() iter.Seq2[K, V] {
+	return s.Snapshot().FromNewest()
+}
+
+// KeysFromOldest returns an iterator of the keys of a snapshot of the map, from the oldest to the newest.
+func (s *Sync[K, V]) KeysFromOldest() iter.Seq[K] {
+	return s.Snapshot().KeysFromOldest()
+}
+
+// ValuesFromOldest returns an iterator of the values of a snapshot of the map, from the oldest to the newest.
+func (s *Sync[K, V]) ValuesFromOldest() iter.Seq[V] {
+	return s.Snapshot().ValuesFromOldest()
+}
+
+// All returns an iterator of a snapshot of the map, in the order the pairs were added.
+func (s *Sync[K, V]) All() iter.Seq2[K, V] {
+	return s.Snapshot().All()
+}
+
+// Keys returns an iterator of the keys of a snapshot of the map, in the order they were added.
+func (s *Sync[K, V]) Keys() iter.Seq[K] {
+	return s.Snapshot().Keys()
+}
+
+// Values returns an iterator of the values of a snapshot of the map, in the order they were added.
+func (s *Sync[K, V]) Values() iter.Seq[V] {
+	return s.Snapshot().Values()
+}
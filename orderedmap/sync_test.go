@@ -0,0 +1,97 @@
+package orderedmap_test
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSync(t *testing.T) {
+	s := orderedmap.NewSync[string, int]()
+	assert.Equal(t, 0, s.Len())
+
+	_, present := s.Set("pizza", 1)
+	assert.False(t, present)
+	s.Set("burger", 2)
+	previous, present := s.Set("pizza", 3)
+	assert.True(t, present)
+	assert.Equal(t, 1, previous)
+
+	v, ok := s.Get("pizza")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	assert.Equal(t, 0, s.GetOrZero("fries"))
+	assert.Equal(t, []string{"pizza", "burger"}, slices.Collect(s.KeysFromOldest()))
+	assert.Equal(t, []int{3, 2}, slices.Collect(s.ValuesFromOldest()))
+
+	// a snapshot does not change.
+	snapshot := s.Snapshot()
+	previous, present = s.Delete("pizza")
+	assert.True(t, present)
+	assert.Equal(t, 3, previous)
+	_, present = s.Delete("pizza")
+	assert.False(t, present)
+	assert.Equal(t, 1, s.Len())
+	assert.Equal(t, 2, snapshot.Len())
+
+	s.Update(func(m *orderedmap.Map[string, int]) {
+		m.Set("fries", 4)
+		m.Set("shake", 5)
+	})
+	var keys []string
+	for k := range s.FromNewest() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"shake", "fries", "burger"}, keys)
+	for k, v := range s.FromOldest() {
+		assert.Equal(t, s.GetOrZero(k), v)
+	}
+
+	for k, v := range s.All() {
+		assert.Equal(t, s.GetOrZero(k), v)
+	}
+	assert.Equal(t, []string{"burger", "fries", "shake"}, slices.Collect(s.Keys()))
+	assert.Equal(t, []int{2, 4, 5}, slices.Collect(s.Values()))
+}
+
+func TestNewSyncFrom(t *testing.T) {
+	m := orderedmap.FromPairs(orderedmap.NewPair("pizza", 1), orderedmap.NewPair("burger", 2))
+	s := orderedmap.NewSyncFrom(m)
+	s.Set("fries", 3)
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, []string{"pizza", "burger", "fries"}, slices.Collect(s.KeysFromOldest()))
+}
+
+func TestSync_Concurrent(t *testing.T) {
+	s := orderedmap.NewSync[int, string]()
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Set(w*50+i, fmt.Sprint(i))
+			}
+		}(w)
+	}
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				count := 0
+				for k, v := range s.FromOldest() {
+					assert.Equal(t, fmt.Sprint(k%50), v)
+					count++
+				}
+				assert.LessOrEqual(t, count, 200)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 200, s.Len())
+}
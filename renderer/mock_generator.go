@@ -11,6 +11,7 @@ import (
 
 	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -76,6 +77,34 @@ func (mg *MockGenerator) DisableRequiredCheck() {
 // The name parameter is optional, if provided, the mock generator will attempt to find an example with the given name.
 // If no name is provided, the first example will be used.
 func (mg *MockGenerator) GenerateMock(mock any, name string) ([]byte, error) {
+	value, err := mg.locateMockValue(mock, name)
+	if value == nil || err != nil {
+		return nil, err
+	}
+	return mg.renderMock(value), nil
+}
+
+// GenerateMockNode operates the same way as GenerateMock, except it returns a *yaml.Node instead of a rendered
+// byte slice. This is useful when the mock needs to be embedded into another document, or encoded as YAML or JSON
+// by the caller.
+func (mg *MockGenerator) GenerateMockNode(mock any, name string) (*yaml.Node, error) {
+	value, err := mg.locateMockValue(mock, name)
+	if value == nil || err != nil {
+		return nil, err
+	}
+	if n, ok := value.(*yaml.Node); ok {
+		// a copy, so the example of the schema is not changed if the mock is.
+		return utils.CloneNode(n), nil
+	}
+	var node yaml.Node
+	if err = node.Encode(value); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// locateMockValue will find the best value to use as a mock, either an example, or a value rendered from the schema.
+func (mg *MockGenerator) locateMockValue(mock any, name string) (any, error) {
 	if mock == nil || !reflect.ValueOf(mock).IsValid() || reflect.ValueOf(mock).IsNil() {
 		return nil, nil
 	}
@@ -115,7 +144,7 @@ func (mg *MockGenerator) GenerateMock(mock any, name string) ([]byte, error) {
 		}
 		if ex != nil {
 			// try and serialize the example value
-			return mg.renderMock(ex), nil
+			return ex, nil
 		}
 	}
 
@@ -130,13 +159,13 @@ func (mg *MockGenerator) GenerateMock(mock any, name string) ([]byte, error) {
 		// if the name is not empty, try and find the example by name
 		for k, exp := range examplesMap.FromOldest() {
 			if k == name {
-				return mg.renderMock(exp.Value), nil
+				return exp.Value, nil
 			}
 		}
 
 		// if the name is empty, just return the first example
 		for exp := range examplesMap.ValuesFromOldest() {
-			return mg.renderMock(exp.Value), nil
+			return exp.Value, nil
 		}
 	}
 
@@ -167,17 +196,17 @@ func (mg *MockGenerator) GenerateMock(mock any, name string) ([]byte, error) {
 				// try and convert the example to an integer
 				if i, err := strconv.Atoi(name); err == nil {
 					if i < len(schemaValue.Examples) {
-						return mg.renderMock(schemaValue.Examples[i]), nil
+						return schemaValue.Examples[i], nil
 					}
 				}
 			}
 			// if the name is empty, just return the first example
-			return mg.renderMock(schemaValue.Examples[0]), nil
+			return schemaValue.Examples[0], nil
 		}
 
 		// check the example field
 		if schemaValue.Example != nil {
-			return mg.renderMock(schemaValue.Example), nil
+			return schemaValue.Example, nil
 		}
 
 		// render the schema as our last hope.
@@ -185,7 +214,7 @@ func (mg *MockGenerator) GenerateMock(mock any, name string) ([]byte, error) {
 		if renderMap == nil {
 			return nil, fmt.Errorf("unable to render schema for mock, it's empty")
 		}
-		return mg.renderMock(renderMap), nil
+		return renderMap, nil
 	}
 	return nil, nil
 }
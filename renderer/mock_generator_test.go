@@ -489,3 +489,44 @@ properties:
 		})
 	}
 }
+
+func TestMockGenerator_GenerateMockNode_Example(t *testing.T) {
+	mg := NewMockGenerator(JSON)
+	example := utils.CreateStringNode("hello")
+	fake := createFakeMock(simpleFakeMockSchema, nil, example)
+	node, err := mg.GenerateMockNode(fake, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", node.Value)
+
+	// the example is copied, changing the mock leaves the example alone.
+	assert.NotSame(t, example, node)
+	node.Value = "goodbye"
+	assert.Equal(t, "hello", example.Value)
+}
+
+func TestMockGenerator_GenerateMockNode_Schema(t *testing.T) {
+	mg := NewMockGenerator(JSON)
+	fake := createFakeMock(simpleFakeMockSchema, nil, nil)
+	node, err := mg.GenerateMockNode(fake, "")
+	assert.NoError(t, err)
+	assert.Equal(t, yaml.ScalarNode, node.Kind)
+	assert.Equal(t, "magic-herbs", node.Value)
+}
+
+func TestMockGenerator_GenerateMockNode_NoObject(t *testing.T) {
+	mg := NewMockGenerator(JSON)
+	var fake *fakeMockable
+	node, err := mg.GenerateMockNode(fake, "")
+	assert.NoError(t, err)
+	assert.Nil(t, node)
+}
+
+func TestMockGenerator_GenerateMockNode_BadObject(t *testing.T) {
+	type NotMockable struct {
+		pizza string
+	}
+	mg := NewMockGenerator(JSON)
+	node, err := mg.GenerateMockNode(&NotMockable{}, "")
+	assert.Error(t, err)
+	assert.Nil(t, node)
+}
@@ -16,7 +16,9 @@ import (
 
 	"github.com/lucasjones/reggen"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/json"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -91,6 +93,102 @@ func (wr *SchemaRenderer) RenderSchema(schema *base.Schema) any {
 	return structure[rootType]
 }
 
+// RenderSchemaNode takes a schema and renders it into a *yaml.Node, ready to be embedded into a document or
+// encoded as YAML or JSON.
+// Properties are rendered in the order they are defined in the schema, any other keys (from examples for
+// instance) follow in alphabetical order.
+func (wr *SchemaRenderer) RenderSchemaNode(schema *base.Schema) (*yaml.Node, error) {
+	return renderNode(wr.RenderSchema(schema), schema)
+}
+
+// RenderSchemaJSON takes a schema and renders it into a JSON byte slice. If indent is not empty, the JSON will be
+// rendered with the supplied indentation. Properties are rendered in the same order as RenderSchemaNode.
+func (wr *SchemaRenderer) RenderSchemaJSON(schema *base.Schema, indent string) ([]byte, error) {
+	node, err := wr.RenderSchemaNode(schema)
+	if err != nil {
+		return nil, err
+	}
+	return json.YAMLNodeToJSON(node, indent)
+}
+
+// renderNode converts a rendered value into a *yaml.Node, ordering the keys of objects by the properties of the
+// schema the value was rendered from.
+func renderNode(value any, schema *base.Schema) (*yaml.Node, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		properties := orderedmap.New[string, *base.SchemaProxy]()
+		collectProperties(schema, properties, make(map[*base.Schema]bool))
+		var keys []string
+		for name := range properties.KeysFromOldest() {
+			if _, ok := v[name]; ok {
+				keys = append(keys, name)
+			}
+		}
+		var rest []string
+		for k := range v {
+			if _, ok := properties.Get(k); !ok {
+				rest = append(rest, k)
+			}
+		}
+		slices.Sort(rest)
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, k := range append(keys, rest...) {
+			var propertySchema *base.Schema
+			if p := properties.GetOrZero(k); p != nil {
+				propertySchema = p.Schema()
+			}
+			n, err := renderNode(v[k], propertySchema)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k}, n)
+		}
+		return node, nil
+	case []any:
+		var itemsSchema *base.Schema
+		if schema != nil && schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil {
+			itemsSchema = schema.Items.A.Schema()
+		}
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range v {
+			n, err := renderNode(item, itemsSchema)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, n)
+		}
+		return node, nil
+	}
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// collectProperties adds the properties of a schema, and of its allOf, oneOf and anyOf schemas, to properties,
+// in the order they are defined. The first definition of a property wins.
+func collectProperties(schema *base.Schema, properties *orderedmap.Map[string, *base.SchemaProxy],
+	seen map[*base.Schema]bool,
+) {
+	if schema == nil || seen[schema] {
+		return
+	}
+	seen[schema] = true
+	for name, p := range schema.Properties.FromOldest() {
+		if _, ok := properties.Get(name); !ok {
+			properties.Set(name, p)
+		}
+	}
+	for _, proxies := range [][]*base.SchemaProxy{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, p := range proxies {
+			if p != nil {
+				collectProperties(p.Schema(), properties, seen)
+			}
+		}
+	}
+}
+
 // DisableRequiredCheck will disable the required check when rendering a schema. This means that all properties
 // will be rendered, not just the required ones.
 // https://github.com/pb33f/libopenapi/issues/200
@@ -110,6 +208,40 @@ func (wr *SchemaRenderer) DiveIntoSchema(schema *base.Schema, key string, struct
 		return
 	}
 
+	// 3.1 examples for non-scalar types are used as is, strings and numbers are handled below.
+	if len(schema.Examples) > 0 && schema.Examples[0] != nil &&
+		!slices.Contains(schema.Type, stringType) && !slices.Contains(schema.Type, numberType) &&
+		!slices.Contains(schema.Type, integerType) {
+		var example any
+		_ = schema.Examples[0].Decode(&example)
+		structure[key] = example
+		return
+	}
+
+	// a const is the only valid value, so use it.
+	if schema.Const != nil {
+		var example any
+		_ = schema.Const.Decode(&example)
+		structure[key] = example
+		return
+	}
+
+	// a default value is a sensible example, as long as there are no examples to prefer.
+	if schema.Default != nil && len(schema.Examples) == 0 {
+		var example any
+		_ = schema.Default.Decode(&example)
+		structure[key] = example
+		return
+	}
+
+	// an enum without a type can still be rendered.
+	if len(schema.Type) == 0 && len(schema.Enum) > 0 {
+		var example any
+		_ = schema.Enum[rand.Int()%len(schema.Enum)].Decode(&example)
+		structure[key] = example
+		return
+	}
+
 	// emergency break to prevent stack overflow from ever occurring
 	if depth > 100 {
 		structure[key] = "to deep to continue rendering..."
@@ -286,6 +418,12 @@ func (wr *SchemaRenderer) DiveIntoSchema(schema *base.Schema, key string, struct
 
 	// handle booleans
 	if slices.Contains(schema.Type, booleanType) {
+		if len(schema.Enum) > 0 {
+			var example any
+			_ = schema.Enum[rand.Int()%len(schema.Enum)].Decode(&example)
+			structure[key] = example
+			return
+		}
 		structure[key] = true
 	}
 
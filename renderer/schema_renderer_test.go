@@ -1350,3 +1350,142 @@ properties:
 	loopMe(root, 0)
 	return root
 }
+
+func TestRenderSchema_WithDefaults(t *testing.T) {
+	testObject := `type: [object]
+properties:
+  name:
+    type: string
+    default: pb33f
+  count:
+    type: integer
+    default: 42
+  active:
+    type: boolean
+    default: false`
+
+	compiled := getSchema([]byte(testObject))
+	wr := createSchemaRenderer()
+	wr.DisableRequiredCheck()
+	rendered, err := wr.RenderSchemaJSON(compiled, "")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"pb33f","count":42,"active":false}`, string(rendered))
+}
+
+func TestRenderSchema_WithConst(t *testing.T) {
+	testObject := `type: [object]
+properties:
+  kind:
+    type: string
+    const: pizza
+    default: burger`
+
+	compiled := getSchema([]byte(testObject))
+	wr := createSchemaRenderer()
+	rendered, err := wr.RenderSchemaJSON(compiled, "")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kind":"pizza"}`, string(rendered))
+}
+
+func TestRenderSchema_ExamplesPreferredOverDefault(t *testing.T) {
+	testObject := `type: [object]
+properties:
+  name:
+    type: string
+    default: burger
+    examples:
+      - pizza`
+
+	compiled := getSchema([]byte(testObject))
+	wr := createSchemaRenderer()
+	rendered, err := wr.RenderSchemaJSON(compiled, "")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"pizza"}`, string(rendered))
+}
+
+func TestRenderSchema_ObjectExamples(t *testing.T) {
+	testObject := `type: object
+examples:
+  - name: pizza
+    slices: 8
+properties:
+  name:
+    type: string`
+
+	compiled := getSchema([]byte(testObject))
+	wr := createSchemaRenderer()
+	rendered, err := wr.RenderSchemaJSON(compiled, "  ")
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"name\": \"pizza\",\n  \"slices\": 8\n}", string(rendered))
+}
+
+func TestRenderSchema_EnumNoType(t *testing.T) {
+	testObject := `type: [object]
+properties:
+  size:
+    enum: [3]
+  enabled:
+    type: boolean
+    enum: [false]`
+
+	compiled := getSchema([]byte(testObject))
+	wr := createSchemaRenderer()
+	rendered, err := wr.RenderSchemaJSON(compiled, "")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"size":3,"enabled":false}`, string(rendered))
+}
+
+func TestRenderSchemaNode(t *testing.T) {
+	testObject := `type: [object]
+properties:
+  name:
+    type: string
+    enum: [pb33f]`
+
+	compiled := getSchema([]byte(testObject))
+	wr := createSchemaRenderer()
+	node, err := wr.RenderSchemaNode(compiled)
+	assert.NoError(t, err)
+	assert.Equal(t, yaml.MappingNode, node.Kind)
+
+	rendered, _ := yaml.Marshal(node)
+	assert.Equal(t, "name: pb33f", strings.TrimSpace(string(rendered)))
+}
+
+func TestRenderSchemaJSON_PropertyOrder(t *testing.T) {
+	testObject := `type: object
+properties:
+  zebra:
+    type: string
+    const: stripes
+  apple:
+    type: object
+    properties:
+      seeds:
+        type: integer
+        const: 5
+      colour:
+        type: string
+        const: red
+  lists:
+    type: array
+    items:
+      type: object
+      properties:
+        b:
+          const: 2
+        a:
+          const: 1
+allOf:
+  - properties:
+      middle:
+        const: true`
+
+	compiled := getSchema([]byte(testObject))
+	wr := createSchemaRenderer()
+	wr.DisableRequiredCheck()
+	rendered, err := wr.RenderSchemaJSON(compiled, "")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"zebra":"stripes","apple":{"seeds":5,"colour":"red"},"lists":[{"b":2,"a":1}],"middle":true}`,
+		string(rendered))
+}
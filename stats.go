@@ -0,0 +1,207 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// DocumentStats is a summary of what a specification contains, see Document.GetStats.
+type DocumentStats struct {
+	// Version is the version of the specification, for example 3.1.0 or 2.0
+	Version string `json:"version" yaml:"version"`
+
+	// Paths is the number of path items in the specification.
+	Paths int `json:"paths" yaml:"paths"`
+
+	// Operations is the number of operations across every path item.
+	Operations int `json:"operations" yaml:"operations"`
+
+	// OperationsByMethod holds the number of operations for each HTTP method (in lower case), for example 'get'.
+	OperationsByMethod map[string]int `json:"operationsByMethod" yaml:"operationsByMethod"`
+
+	// Components holds the number of components for each type of component, keyed by the name used in the
+	// specification, for example 'schemas' or 'securitySchemes' (or 'definitions' and 'securityDefinitions' for
+	// Swagger). Types without any components are not included.
+	Components map[string]int `json:"components" yaml:"components"`
+
+	// References holds the number of references in the specification, and every file it references.
+	References ReferenceStats `json:"references" yaml:"references"`
+
+	// CircularReferences is the number of circular references found.
+	CircularReferences int `json:"circularReferences" yaml:"circularReferences"`
+
+	// ParseDuration is how long it took to parse the specification when the document was created.
+	ParseDuration time.Duration `json:"parseDuration" yaml:"parseDuration"`
+
+	// BuildDuration is how long it took to build the model, including indexing and resolving references.
+	BuildDuration time.Duration `json:"buildDuration" yaml:"buildDuration"`
+
+	// Memory is the approximate memory held by the document, only set when the MemoryAccounting of the
+	// configuration is set.
+	Memory *MemoryStats `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
+// ReferenceStats holds the number of references by where they point to. Every use of a reference is counted.
+type ReferenceStats struct {
+	// Local references point into the same document, for example #/components/schemas/Pet
+	Local int `json:"local" yaml:"local"`
+
+	// Remote references point to a URL, for example https://pb33f.io/pet.yaml
+	Remote int `json:"remote" yaml:"remote"`
+
+	// File references point to a file, for example pet.yaml#/Pet
+	File int `json:"file" yaml:"file"`
+}
+
+// Total returns the number of references.
+func (r ReferenceStats) Total() int {
+	return r.Local + r.Remote + r.File
+}
+
+func (d *document) GetStats() (*DocumentStats, error) {
+	if d.info == nil {
+		return nil, datamodel.NewError(ErrNoSpecification, nil, "unable to create stats, no specification has been loaded")
+	}
+	v3Model, v2Model, err := d.statsModel()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &DocumentStats{
+		Version:            d.info.Version,
+		OperationsByMethod: make(map[string]int),
+		Components:         make(map[string]int),
+	}
+	var idx *index.SpecIndex
+	if v3Model != nil {
+		idx = v3Model.Index
+		addV3Stats(stats, &v3Model.Model)
+	} else {
+		idx = v2Model.Index
+		addV2Stats(stats, &v2Model.Model)
+	}
+	if idx != nil {
+		addReferenceStats(stats, idx)
+		stats.CircularReferences = len(idx.GetCircularReferences())
+	}
+
+	d.lock.RLock()
+	stats.ParseDuration = d.parseDuration
+	stats.BuildDuration = d.buildDuration
+	accounting := d.config != nil && d.config.MemoryAccounting
+	d.lock.RUnlock()
+	if accounting {
+		stats.Memory = d.memoryStats()
+	}
+	return stats, nil
+}
+
+// statsModel returns the model that has been built for the document, building one if there isn't one.
+func (d *document) statsModel() (*DocumentModel[v3high.Document], *DocumentModel[v2high.Swagger], error) {
+	d.lock.RLock()
+	v3Model, v2Model, config := d.highOpenAPI3Model, d.highSwaggerModel, d.config
+	d.lock.RUnlock()
+	if v3Model != nil || v2Model != nil {
+		return v3Model, v2Model, nil
+	}
+
+	var errs []error
+	if d.info.SpecFormat == datamodel.OAS2 && (config == nil || !config.UpgradeSwaggerDocuments) {
+		v2Model, errs = d.BuildV2Model()
+	} else {
+		v3Model, errs = d.BuildV3Model()
+	}
+	if v3Model == nil && v2Model == nil {
+		return nil, nil, errors.Join(errs...)
+	}
+	return v3Model, v2Model, nil
+}
+
+func addV3Stats(stats *DocumentStats, doc *v3high.Document) {
+	if doc.Paths != nil {
+		for _, pathItem := range doc.Paths.PathItems.FromOldest() {
+			stats.Paths++
+			for method := range pathItem.GetOperations().KeysFromOldest() {
+				stats.Operations++
+				stats.OperationsByMethod[strings.ToLower(method)]++
+			}
+		}
+	}
+	if c := doc.Components; c != nil {
+		addComponentCount(stats, "schemas", orderedmap.Len(c.Schemas))
+		addComponentCount(stats, "responses", orderedmap.Len(c.Responses))
+		addComponentCount(stats, "parameters", orderedmap.Len(c.Parameters))
+		addComponentCount(stats, "examples", orderedmap.Len(c.Examples))
+		addComponentCount(stats, "requestBodies", orderedmap.Len(c.RequestBodies))
+		addComponentCount(stats, "headers", orderedmap.Len(c.Headers))
+		addComponentCount(stats, "securitySchemes", orderedmap.Len(c.SecuritySchemes))
+		addComponentCount(stats, "links", orderedmap.Len(c.Links))
+		addComponentCount(stats, "callbacks", orderedmap.Len(c.Callbacks))
+		addComponentCount(stats, "pathItems", orderedmap.Len(c.PathItems))
+	}
+}
+
+func addV2Stats(stats *DocumentStats, doc *v2high.Swagger) {
+	if doc.Paths != nil {
+		for _, pathItem := range doc.Paths.PathItems.FromOldest() {
+			stats.Paths++
+			for method := range pathItem.GetOperations().KeysFromOldest() {
+				stats.Operations++
+				stats.OperationsByMethod[strings.ToLower(method)]++
+			}
+		}
+	}
+	if doc.Definitions != nil {
+		addComponentCount(stats, "definitions", orderedmap.Len(doc.Definitions.Definitions))
+	}
+	if doc.Parameters != nil {
+		addComponentCount(stats, "parameters", orderedmap.Len(doc.Parameters.Definitions))
+	}
+	if doc.Responses != nil {
+		addComponentCount(stats, "responses", orderedmap.Len(doc.Responses.Definitions))
+	}
+	if doc.SecurityDefinitions != nil {
+		addComponentCount(stats, "securityDefinitions", orderedmap.Len(doc.SecurityDefinitions.Definitions))
+	}
+}
+
+func addComponentCount(stats *DocumentStats, name string, count int) {
+	if count > 0 {
+		stats.Components[name] = count
+	}
+}
+
+// addReferenceStats counts every reference in the root index, and every other index in its rolodex.
+func addReferenceStats(stats *DocumentStats, root *index.SpecIndex) {
+	indexes := []*index.SpecIndex{root}
+	if rolodex := root.GetRolodex(); rolodex != nil {
+		indexes = append(indexes, rolodex.GetIndexes()...)
+	}
+	seen := make(map[*index.SpecIndex]bool)
+	for _, idx := range indexes {
+		if idx == nil || seen[idx] {
+			continue
+		}
+		seen[idx] = true
+		for _, ref := range idx.GetRawReferencesSequenced() {
+			switch {
+			case strings.HasPrefix(ref.Definition, "#"):
+				stats.References.Local++
+			case strings.HasPrefix(ref.Definition, "http://"), strings.HasPrefix(ref.Definition, "https://"):
+				stats.References.Remote++
+			default:
+				stats.References.File++
+			}
+		}
+	}
+}
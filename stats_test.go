@@ -0,0 +1,148 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_GetStats(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(burgerShop)
+	require.NoError(t, err)
+
+	stats, err := doc.GetStats()
+	require.NoError(t, err)
+
+	assert.Equal(t, "3.1.0", stats.Version)
+	assert.Equal(t, 5, stats.Paths)
+	assert.Equal(t, 5, stats.Operations)
+	assert.Equal(t, map[string]int{"get": 4, "post": 1}, stats.OperationsByMethod)
+	assert.Equal(t, map[string]int{
+		"schemas":         6,
+		"responses":       1,
+		"parameters":      2,
+		"examples":        1,
+		"requestBodies":   1,
+		"headers":         1,
+		"securitySchemes": 3,
+		"links":           2,
+		"callbacks":       1,
+	}, stats.Components)
+	assert.Equal(t, ReferenceStats{Local: 34}, stats.References)
+	assert.Equal(t, 34, stats.References.Total())
+	assert.Zero(t, stats.CircularReferences)
+	assert.Positive(t, stats.ParseDuration)
+	assert.Positive(t, stats.BuildDuration)
+
+	// the model built for the stats is kept.
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.NotNil(t, m)
+}
+
+func TestDocument_GetStats_Swagger(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	stats, err := doc.GetStats()
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0", stats.Version)
+	assert.Equal(t, 14, stats.Paths)
+	assert.Equal(t, 20, stats.Operations)
+	assert.Equal(t, map[string]int{"get": 8, "post": 7, "put": 2, "delete": 3}, stats.OperationsByMethod)
+	assert.Equal(t, map[string]int{"definitions": 6, "parameters": 1, "securityDefinitions": 2}, stats.Components)
+	assert.Equal(t, 16, stats.References.Local)
+}
+
+func TestDocument_GetStats_References(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/nested_files/openapi.yaml")
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		BasePath:            "test_specs/nested_files",
+		AllowFileReferences: true,
+	})
+	require.NoError(t, err)
+
+	stats, err := doc.GetStats()
+	require.NoError(t, err)
+	assert.Equal(t, ReferenceStats{File: 9}, stats.References)
+	assert.Equal(t, 3, stats.Operations)
+}
+
+func TestDocument_GetStats_Circular(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/circular-tests.yaml")
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+
+	stats, err := doc.GetStats()
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.CircularReferences)
+	assert.Equal(t, 9, stats.Components["schemas"])
+}
+
+func TestDocument_GetStats_Error(t *testing.T) {
+	doc, err := NewDocumentWithTypeCheck([]byte("pizza: cake"), true)
+	require.NoError(t, err)
+
+	_, err = doc.GetStats()
+	assert.Error(t, err)
+
+	_, err = (&document{}).GetStats()
+	assert.EqualError(t, err, "unable to create stats, no specification has been loaded")
+}
+
+func TestDocument_GetStats_Memory(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(burgerShop)
+	require.NoError(t, err)
+	stats, err := doc.GetStats()
+	require.NoError(t, err)
+	assert.Nil(t, stats.Memory)
+
+	doc, err = NewDocumentWithConfiguration(burgerShop, &datamodel.DocumentConfiguration{MemoryAccounting: true})
+	require.NoError(t, err)
+	stats, err = doc.GetStats()
+	require.NoError(t, err)
+	require.NotNil(t, stats.Memory)
+
+	memory := *stats.Memory
+	assert.GreaterOrEqual(t, memory.RawBytes, int64(len(burgerShop)))
+	assert.Greater(t, memory.NodeTrees, memory.RawBytes)
+	assert.Positive(t, memory.Indexes)
+	assert.Positive(t, memory.Models)
+	assert.Equal(t, memory.RawBytes+memory.NodeTrees+memory.Indexes+memory.Models, memory.Total())
+
+	// building schemas adds to the memory held by the models, and nothing else.
+	m, _ := doc.BuildV3Model()
+	for _, schema := range m.Model.Components.Schemas.FromOldest() {
+		schema.Schema()
+	}
+	stats, err = doc.GetStats()
+	require.NoError(t, err)
+	assert.Equal(t, memory.RawBytes, stats.Memory.RawBytes)
+	assert.Equal(t, memory.NodeTrees, stats.Memory.NodeTrees)
+	assert.Greater(t, stats.Memory.Models, memory.Models)
+}
+
+func TestDocument_GetStats_MemoryFiles(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/first.yaml")
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		BasePath:         "test_specs",
+		FileFilter:       []string{"first.yaml", "second.yaml", "third.yaml", "fourth.yaml"},
+		MemoryAccounting: true,
+	})
+	require.NoError(t, err)
+	stats, err := doc.GetStats()
+	require.NoError(t, err)
+
+	// every file referenced is counted.
+	assert.Greater(t, stats.Memory.RawBytes, doc.GetRolodex().RolodexFileSize())
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// ErrTransactionDone is returned when a Transaction is used after it has been committed or rolled back.
+var ErrTransactionDone = errors.New("the transaction has already been committed or rolled back")
+
+// Transaction is an edit session of an OpenAPI document, created by Document.BeginTransaction. Any number of
+// mutations are made to the model of the transaction, which is then rendered and reloaded once by Commit, instead
+// of calling RenderAndReload after every change.
+//
+// The model of a transaction is built for the transaction, so the document (and any model built by it) is not
+// changed by the transaction. A Transaction is not safe to use from multiple goroutines.
+type Transaction struct {
+	doc   *document
+	model *DocumentModel[v3high.Document]
+	errs  []error
+	done  bool
+}
+
+func (d *document) BeginTransaction() (*Transaction, []error) {
+	config := &datamodel.DocumentConfiguration{}
+	if c := d.GetConfiguration(); c != nil {
+		copied := *c
+		config = &copied
+	}
+	config.BuildPaths = nil
+	m, _, errs := d.buildV3Model(config)
+	if m == nil {
+		return nil, errs
+	}
+	return &Transaction{doc: d, model: m}, errs
+}
+
+// Model returns the OpenAPI model of the transaction, it can be mutated directly, or through Edit.
+func (t *Transaction) Model() *v3high.Document {
+	return &t.model.Model
+}
+
+// Edit applies a mutation to the model of the transaction. If the mutation fails, its error is returned and kept,
+// and the transaction can no longer be committed.
+func (t *Transaction) Edit(mutation func(model *v3high.Document) error) error {
+	if t.done {
+		return ErrTransactionDone
+	}
+	if err := mutation(&t.model.Model); err != nil {
+		t.errs = append(t.errs, err)
+		return err
+	}
+	return nil
+}
+
+// Commit renders the model of the transaction, with every mutation made to it, and reloads it into a new Document
+// and model, in the same way as Document.RenderAndReload. The rendered bytes, the new document and its model are
+// returned, along with every error hit rendering, or rebuilding the model.
+//
+// If any Edit failed, nothing is rendered and the errors of the failed edits are returned. A transaction can only
+// be committed once.
+func (t *Transaction) Commit() ([]byte, Document, *DocumentModel[v3high.Document], []error) {
+	if t.done {
+		return nil, nil, nil, []error{ErrTransactionDone}
+	}
+	t.done = true
+	if len(t.errs) > 0 {
+		return nil, nil, nil, t.errs
+	}
+	newBytes, err := t.doc.render(&t.model.Model)
+	if err != nil {
+		return nil, nil, nil, []error{err}
+	}
+	return t.doc.reload(newBytes)
+}
+
+// Rollback discards the transaction, and every mutation made to its model.
+func (t *Transaction) Rollback() {
+	t.done = true
+}
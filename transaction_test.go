@@ -0,0 +1,101 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_BeginTransaction(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(burgerShop)
+	require.NoError(t, err)
+
+	model, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	tx, errs := doc.BeginTransaction()
+	require.Empty(t, errs)
+
+	require.NoError(t, tx.Edit(func(m *v3high.Document) error {
+		m.Info.Title = "The Pizza Shop"
+		return nil
+	}))
+	require.NoError(t, tx.Edit(func(m *v3high.Document) error {
+		m.Paths.PathItems.Delete("/burgers")
+		return nil
+	}))
+	tx.Model().Info.Version = "2.0.0"
+
+	// the document is not touched by the transaction.
+	assert.Equal(t, "Burger Shop", model.Model.Info.Title)
+	assert.NotNil(t, model.Model.Paths.PathItems.GetOrZero("/burgers"))
+
+	rendered, newDoc, newModel, errs := tx.Commit()
+	require.Empty(t, errs)
+	assert.Contains(t, string(rendered), "title: The Pizza Shop")
+	assert.Equal(t, "The Pizza Shop", newModel.Model.Info.Title)
+	assert.Equal(t, "2.0.0", newModel.Model.Info.Version)
+	assert.Nil(t, newModel.Model.Paths.PathItems.GetOrZero("/burgers"))
+	assert.Equal(t, 4, newModel.Model.Paths.PathItems.Len())
+	assert.Equal(t, doc.GetConfiguration(), newDoc.GetConfiguration())
+
+	// a transaction can only be committed once.
+	_, _, _, errs = tx.Commit()
+	assert.Equal(t, []error{ErrTransactionDone}, errs)
+	assert.ErrorIs(t, tx.Edit(func(m *v3high.Document) error { return nil }), ErrTransactionDone)
+}
+
+func TestTransaction_EditError(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(burgerShop)
+	require.NoError(t, err)
+
+	tx, errs := doc.BeginTransaction()
+	require.Empty(t, errs)
+
+	pop := errors.New("pop")
+	assert.Equal(t, pop, tx.Edit(func(m *v3high.Document) error { return pop }))
+	assert.NoError(t, tx.Edit(func(m *v3high.Document) error { return nil }))
+
+	rendered, newDoc, newModel, errs := tx.Commit()
+	assert.Equal(t, []error{pop}, errs)
+	assert.Nil(t, rendered)
+	assert.Nil(t, newDoc)
+	assert.Nil(t, newModel)
+}
+
+func TestTransaction_Rollback(t *testing.T) {
+	burgerShop, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(burgerShop)
+	require.NoError(t, err)
+
+	tx, errs := doc.BeginTransaction()
+	require.Empty(t, errs)
+	tx.Model().Info.Title = "The Pizza Shop"
+	tx.Rollback()
+
+	_, _, _, errs = tx.Commit()
+	assert.Equal(t, []error{ErrTransactionDone}, errs)
+
+	model, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, "Burger Shop", model.Model.Info.Title)
+}
+
+func TestDocument_BeginTransaction_Swagger(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	tx, errs := doc.BeginTransaction()
+	assert.Nil(t, tx)
+	assert.NotEmpty(t, errs)
+}
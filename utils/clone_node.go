@@ -0,0 +1,38 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// CloneNode creates a deep copy of a node tree, directly from the nodes (without rendering the tree and parsing it
+// again), so comments, styles, and line and column numbers are all kept. Anchors and aliases are kept as well, every
+// alias in the copy points to the copy of its anchored node.
+func CloneNode(n *yaml.Node) *yaml.Node {
+	return cloneNode(n, make(map[*yaml.Node]*yaml.Node))
+}
+
+// cloneNode copies a node, cloned holds the copy of every node that has been copied.
+func cloneNode(n *yaml.Node, cloned map[*yaml.Node]*yaml.Node) *yaml.Node {
+	if n == nil {
+		return nil
+	}
+	if c, ok := cloned[n]; ok {
+		return c
+	}
+	c := new(yaml.Node)
+	*c = *n
+	cloned[n] = c
+	if n.Alias != nil {
+		c.Alias = cloneNode(n.Alias, cloned)
+	}
+	if n.Content != nil {
+		c.Content = make([]*yaml.Node, len(n.Content))
+		for i, child := range n.Content {
+			c.Content[i] = cloneNode(child, cloned)
+		}
+	}
+	return c
+}
@@ -0,0 +1,35 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCloneNode(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`# pets
+base: &base
+  name: pizza # the name
+copy: *base
+tags: [hot, "cheese"]`), &root)
+
+	c := CloneNode(&root)
+	equal, path := NodesEqual(&root, c, NodesEqualOptions{})
+	assert.True(t, equal, path)
+	assert.Equal(t, root.Content[0].Content[1].Line, c.Content[0].Content[1].Line)
+
+	// the copy shares no nodes with the original, and its alias points to its own anchor.
+	assert.NotSame(t, root.Content[0], c.Content[0])
+	assert.NotSame(t, root.Content[0].Content[1], c.Content[0].Content[1])
+	assert.Same(t, c.Content[0].Content[1], c.Content[0].Content[3].Alias)
+
+	c.Content[0].Content[1].Content[1].Value = "burger"
+	assert.Equal(t, "pizza", root.Content[0].Content[1].Content[1].Value)
+
+	assert.Nil(t, CloneNode(nil))
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"sync/atomic"
+)
+
+var (
+	maxConcurrency    atomic.Int64
+	activeConcurrency atomic.Int64
+)
+
+// SetMaxConcurrency sets the number of goroutines that the library can run work on at the same time, across every
+// document: indexing files, locating references, checking for circular references, building models and comparing
+// documents all share it, so an application embedding the library can control how much CPU it uses. When every
+// goroutine is busy, work runs on the goroutine that asked for it, which is never blocked waiting for another.
+//
+// The default is 0, which sets no limit. The finer limits of building and comparing models (see
+// datamodel.SetTranslateWorkers and model.ComparisonOptions) still apply.
+func SetMaxConcurrency(goroutines int) {
+	maxConcurrency.Store(int64(max(goroutines, 0)))
+}
+
+// MaxConcurrency returns the number of goroutines the library can run work on at the same time, 0 means there is
+// no limit, see SetMaxConcurrency.
+func MaxConcurrency() int {
+	return int(maxConcurrency.Load())
+}
+
+// AcquireWorker takes a goroutine from the budget set by SetMaxConcurrency, it returns false if every goroutine is
+// busy. A goroutine taken must be given back with ReleaseWorker.
+func AcquireWorker() bool {
+	if activeConcurrency.Add(1) <= maxConcurrency.Load() || maxConcurrency.Load() == 0 {
+		return true
+	}
+	activeConcurrency.Add(-1)
+	return false
+}
+
+// ReleaseWorker gives back a goroutine taken with AcquireWorker.
+func ReleaseWorker() {
+	activeConcurrency.Add(-1)
+}
+
+// Go runs f on a new goroutine if the budget set by SetMaxConcurrency has one free, otherwise it runs f before
+// returning. f must not wait for the caller of Go to do anything, as it may run on the same goroutine.
+func Go(f func()) {
+	if !AcquireWorker() {
+		f()
+		return
+	}
+	go func() {
+		defer ReleaseWorker()
+		f()
+	}()
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMaxConcurrency(t *testing.T) {
+	defer SetMaxConcurrency(0)
+
+	assert.Zero(t, MaxConcurrency())
+	SetMaxConcurrency(4)
+	assert.Equal(t, 4, MaxConcurrency())
+	SetMaxConcurrency(-1)
+	assert.Zero(t, MaxConcurrency())
+}
+
+func TestAcquireWorker(t *testing.T) {
+	defer SetMaxConcurrency(0)
+
+	// no limit.
+	assert.True(t, AcquireWorker())
+	ReleaseWorker()
+
+	SetMaxConcurrency(2)
+	assert.True(t, AcquireWorker())
+	assert.True(t, AcquireWorker())
+	assert.False(t, AcquireWorker())
+	ReleaseWorker()
+	assert.True(t, AcquireWorker())
+	ReleaseWorker()
+	ReleaseWorker()
+	assert.Zero(t, activeConcurrency.Load())
+}
+
+func TestGo(t *testing.T) {
+	defer SetMaxConcurrency(0)
+	SetMaxConcurrency(1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	release := make(chan struct{})
+	Go(func() {
+		defer wg.Done()
+		<-release
+	})
+
+	// the only goroutine is busy, so this runs before returning.
+	ran := false
+	Go(func() {
+		ran = true
+	})
+	assert.True(t, ran)
+
+	close(release)
+	wg.Wait()
+	assert.Zero(t, activeConcurrency.Load())
+}
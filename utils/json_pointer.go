@@ -0,0 +1,204 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// JSON Pointer utilities, as defined by RFC 6901
+//   - https://www.rfc-editor.org/rfc/rfc6901
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// EscapeJSONPointer escapes a single reference token (a map key or an array index) for use in a JSON Pointer,
+// '~' becomes '~0' and '/' becomes '~1'.
+func EscapeJSONPointer(token string) string {
+	return pointerEscaper.Replace(token)
+}
+
+// UnescapeJSONPointer reverses EscapeJSONPointer, '~1' becomes '/' and '~0' becomes '~'. An error is returned if the
+// token holds a '~' that is not followed by '0' or '1'.
+func UnescapeJSONPointer(token string) (string, error) {
+	for i := 0; i < len(token); i++ {
+		if token[i] == '~' && (i == len(token)-1 || (token[i+1] != '0' && token[i+1] != '1')) {
+			return "", fmt.Errorf("invalid JSON pointer token '%s', '~' must be followed by '0' or '1'", token)
+		}
+	}
+	return pointerUnescaper.Replace(token), nil
+}
+
+// ParseJSONPointer splits a JSON Pointer into its unescaped reference tokens. Both the JSON string representation
+// (for example '/paths/~1pets') and the URI fragment representation (for example '#/paths/~1pets', which is also
+// percent-decoded) are supported. The empty pointer (an empty string, or '#') points to the whole document, and has
+// no tokens.
+func ParseJSONPointer(pointer string) ([]string, error) {
+	if strings.HasPrefix(pointer, "#") {
+		decoded, err := url.PathUnescape(pointer[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON pointer '%s': %w", pointer, err)
+		}
+		pointer = decoded
+	}
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer '%s', it must be empty or start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i := range tokens {
+		t, err := UnescapeJSONPointer(tokens[i])
+		if err != nil {
+			return nil, err
+		}
+		tokens[i] = t
+	}
+	return tokens, nil
+}
+
+// JoinJSONPointer builds a JSON Pointer (in its JSON string representation) from unescaped reference tokens.
+func JoinJSONPointer(tokens ...string) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(EscapeJSONPointer(t))
+	}
+	return sb.String()
+}
+
+// JSONPointerToFragment converts a JSON Pointer from its JSON string representation into its URI fragment
+// representation, for example '/paths/~1pets with space' becomes '#/paths/~1pets%20with%20space'.
+func JSONPointerToFragment(pointer string) string {
+	return "#" + (&url.URL{Fragment: pointer}).EscapedFragment()
+}
+
+// FindNodeByJSONPointer evaluates a JSON Pointer (in either representation, see ParseJSONPointer) against a node
+// tree, returning the node it points to. A document node is evaluated from its content, and aliases are followed.
+func FindNodeByJSONPointer(root *yaml.Node, pointer string) (*yaml.Node, error) {
+	tokens, err := ParseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	n, err := FindNodeByJSONPointerTokens(root, tokens)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve JSON pointer '%s': %w", pointer, err)
+	}
+	return n, nil
+}
+
+// FindNodeByJSONPointerTokens evaluates the unescaped reference tokens of a JSON Pointer against a node tree, in
+// the same way as FindNodeByJSONPointer.
+func FindNodeByJSONPointerTokens(root *yaml.Node, tokens []string) (*yaml.Node, error) {
+	n := pointerNode(root)
+	if n == nil {
+		return nil, errors.New("there is no node to evaluate")
+	}
+	for _, t := range tokens {
+		switch n.Kind {
+		case yaml.MappingNode:
+			var next *yaml.Node
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value == t {
+					next = n.Content[i+1]
+					break
+				}
+			}
+			if next == nil {
+				return nil, fmt.Errorf("key '%s' cannot be found", t)
+			}
+			n = next
+		case yaml.SequenceNode:
+			i, err := arrayIndex(t)
+			if err != nil {
+				return nil, err
+			}
+			if i >= len(n.Content) {
+				return nil, fmt.Errorf("index '%d' is out of range, the array has %d items", i, len(n.Content))
+			}
+			n = n.Content[i]
+		default:
+			return nil, fmt.Errorf("'%s' cannot be found, the parent is not a map or an array", t)
+		}
+		n = pointerNode(n)
+	}
+	return n, nil
+}
+
+// JSONPointerFromAncestry builds the JSON Pointer of the last node of an ancestry chain. The chain starts with the
+// root of the document, and every following node must be a value in the node before it (a map value, or an array
+// item). The root node alone has the empty pointer.
+func JSONPointerFromAncestry(ancestry []*yaml.Node) (string, error) {
+	var sb strings.Builder
+	for i := 1; i < len(ancestry); i++ {
+		parent, child := pointerNode(ancestry[i-1]), ancestry[i]
+		if parent == nil {
+			return "", fmt.Errorf("unable to build JSON pointer, ancestor %d is empty", i-1)
+		}
+		if parent == child {
+			// the content of a document, or the target of an alias.
+			continue
+		}
+		token, found := "", false
+		switch parent.Kind {
+		case yaml.MappingNode:
+			for j := 0; j+1 < len(parent.Content); j += 2 {
+				if parent.Content[j+1] == child {
+					token, found = EscapeJSONPointer(parent.Content[j].Value), true
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			for j, item := range parent.Content {
+				if item == child {
+					token, found = strconv.Itoa(j), true
+					break
+				}
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("unable to build JSON pointer, node %d is not a value of the node before it", i)
+		}
+		sb.WriteByte('/')
+		sb.WriteString(token)
+	}
+	return sb.String(), nil
+}
+
+// pointerNode returns the node a JSON Pointer is evaluated against, the content of a document, or the target of an
+// alias.
+func pointerNode(n *yaml.Node) *yaml.Node {
+	for n != nil {
+		switch {
+		case n.Kind == yaml.DocumentNode && len(n.Content) > 0:
+			n = n.Content[0]
+		case n.Kind == yaml.AliasNode && n.Alias != nil:
+			n = n.Alias
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// arrayIndex parses an array index token, which must be '0', or a number without leading zeros.
+func arrayIndex(token string) (int, error) {
+	if token == "-" {
+		return 0, errors.New("index '-' points past the end of the array")
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') || token[0] == '+' {
+		return 0, fmt.Errorf("'%s' is not a valid array index", token)
+	}
+	return i, nil
+}
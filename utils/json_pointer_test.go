@@ -0,0 +1,154 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestEscapeJSONPointer(t *testing.T) {
+	assert.Equal(t, "~1pets~1{id}", EscapeJSONPointer("/pets/{id}"))
+	assert.Equal(t, "a~0~1b", EscapeJSONPointer("a~/b"))
+
+	u, err := UnescapeJSONPointer("a~01b~1c")
+	require.NoError(t, err)
+	assert.Equal(t, "a~1b/c", u)
+
+	for _, bad := range []string{"a~", "a~2b", "~~0"} {
+		_, err = UnescapeJSONPointer(bad)
+		assert.ErrorContains(t, err, "must be followed by '0' or '1'", bad)
+	}
+}
+
+func TestParseJSONPointer(t *testing.T) {
+	// examples from RFC 6901, section 5 and 6.
+	for pointer, tokens := range map[string][]string{
+		"":         nil,
+		"#":        nil,
+		"/foo":     {"foo"},
+		"/foo/0":   {"foo", "0"},
+		"/":        {""},
+		"/a~1b":    {"a/b"},
+		"/m~0n":    {"m~n"},
+		"/ ":       {" "},
+		"#/c%25d":  {"c%d"},
+		"#/k%22l":  {`k"l`},
+		"#/a~1b/1": {"a/b", "1"},
+		"/c%25d":   {"c%25d"},
+	} {
+		parsed, err := ParseJSONPointer(pointer)
+		require.NoError(t, err, pointer)
+		assert.Equal(t, tokens, parsed, pointer)
+	}
+
+	for _, bad := range []string{"foo", "#foo", "/a~2", "#/%zz"} {
+		_, err := ParseJSONPointer(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestJoinJSONPointer(t *testing.T) {
+	assert.Equal(t, "", JoinJSONPointer())
+	assert.Equal(t, "/paths/~1pets~1{id}/get", JoinJSONPointer("paths", "/pets/{id}", "get"))
+	assert.Equal(t, "#/paths/~1pets%20with%20space", JSONPointerToFragment("/paths/~1pets with space"))
+	assert.Equal(t, "#", JSONPointerToFragment(""))
+
+	// a round trip gives the tokens back.
+	tokens, err := ParseJSONPointer(JSONPointerToFragment(JoinJSONPointer("a/b", "c~d", "e f%")))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a/b", "c~d", "e f%"}, tokens)
+}
+
+func TestFindNodeByJSONPointer(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`a/b:
+  - one
+  - two
+anchored: &thing
+  name: pizza
+alias: *thing
+"":
+  empty: key`), &root)
+
+	for pointer, value := range map[string]string{
+		"/a~1b/1":         "two",
+		"#/a~1b/0":        "one",
+		"/anchored/name":  "pizza",
+		"/alias/name":     "pizza",
+		"//empty":         "key",
+		"#/alias/name":    "pizza",
+		"#/a%7E1b/1":      "two",
+		"/a~1b/1/":        "",
+		"/anchored/name/": "",
+	} {
+		n, err := FindNodeByJSONPointer(&root, pointer)
+		if value == "" {
+			assert.Error(t, err, pointer)
+			continue
+		}
+		require.NoError(t, err, pointer)
+		assert.Equal(t, value, n.Value, pointer)
+	}
+
+	n, err := FindNodeByJSONPointer(&root, "")
+	require.NoError(t, err)
+	assert.Same(t, root.Content[0], n)
+
+	for pointer, msg := range map[string]string{
+		"/a~1b/2":        "index '2' is out of range",
+		"/a~1b/nope":     "'nope' is not a valid array index",
+		"/a~1b/01":       "'01' is not a valid array index",
+		"/a~1b/-":        "index '-' points past the end",
+		"/c":             "key 'c' cannot be found",
+		"/a~1b/0/deeper": "the parent is not a map or an array",
+		"nope":           "it must be empty or start with '/'",
+	} {
+		_, err = FindNodeByJSONPointer(&root, pointer)
+		assert.ErrorContains(t, err, msg, pointer)
+	}
+
+	_, err = FindNodeByJSONPointer(nil, "/a")
+	assert.ErrorContains(t, err, "there is no node to evaluate")
+}
+
+func TestJSONPointerFromAncestry(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+      - name: other
+thing: &thing
+  m~n: value
+alias: *thing`), &root)
+
+	param, err := FindNodeByJSONPointer(&root, "/paths/~1pets~1{id}/parameters/1")
+	require.NoError(t, err)
+	paths := root.Content[0].Content[1]
+	item := paths.Content[1]
+	params := item.Content[1]
+
+	pointer, err := JSONPointerFromAncestry([]*yaml.Node{&root, paths, item, params, param})
+	require.NoError(t, err)
+	assert.Equal(t, "/paths/~1pets~1{id}/parameters/1", pointer)
+
+	// ancestors that are aliases are followed.
+	alias := root.Content[0].Content[5]
+	pointer, err = JSONPointerFromAncestry([]*yaml.Node{root.Content[0], alias, alias.Alias.Content[1]})
+	require.NoError(t, err)
+	assert.Equal(t, "/alias/m~0n", pointer)
+
+	pointer, err = JSONPointerFromAncestry([]*yaml.Node{&root})
+	require.NoError(t, err)
+	assert.Equal(t, "", pointer)
+
+	_, err = JSONPointerFromAncestry([]*yaml.Node{&root, params})
+	assert.ErrorContains(t, err, "node 1 is not a value of the node before it")
+	_, err = JSONPointerFromAncestry([]*yaml.Node{nil, params})
+	assert.ErrorContains(t, err, "ancestor 0 is empty")
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// keyIndexScanLimit is the number of keys up to which a KeyIndex is scanned rather than hashed, scanning a small
+// map is faster than hashing the key.
+const keyIndexScanLimit = 12
+
+// KeyIndex is an index of the keys of a mapping node. It is built once, in a single pass over the node, and can then
+// look up any number of keys, unlike FindKeyNodeTop and FindKeyNodeFullTop which scan the node on every call. Large
+// maps are hashed the first time they are queried, so every lookup after that takes constant time.
+//
+// If a key is in the map more than once, the first one is found. Merge keys ('<<') are expanded, so the keys of
+// merged maps are found too, unless the map has a key of the same name.
+//
+// A KeyIndex does not see changes made to the node after it was built, and is not safe to query from multiple
+// goroutines.
+type KeyIndex struct {
+	pairs  []*yaml.Node
+	keys   map[string]int
+	folded map[string]int
+}
+
+// NewKeyIndex builds a KeyIndex of the keys of a mapping node (or an alias of one). Any other node has an empty index.
+func NewKeyIndex(node *yaml.Node) *KeyIndex {
+	k := &KeyIndex{}
+	if node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return k
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Tag == "!!merge" {
+			k.pairs = make([]*yaml.Node, 0, len(node.Content))
+			k.add(node, nil)
+			return k
+		}
+	}
+	// without merge keys, the content of the map is already the list of pairs.
+	k.pairs = node.Content[:len(node.Content)&^1]
+	return k
+}
+
+func (k *KeyIndex) add(node *yaml.Node, seen map[*yaml.Node]bool) {
+	var merges []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Tag == "!!merge" {
+			merges = append(merges, node.Content[i+1])
+			continue
+		}
+		k.pairs = append(k.pairs, node.Content[i], node.Content[i+1])
+	}
+	for _, m := range merges {
+		merged := []*yaml.Node{m}
+		if m.Kind == yaml.SequenceNode {
+			merged = m.Content
+		}
+		for _, mn := range merged {
+			if mn.Kind == yaml.AliasNode {
+				mn = mn.Alias
+			}
+			if mn == nil || mn.Kind != yaml.MappingNode || mn == node || seen[mn] {
+				continue
+			}
+			if seen == nil {
+				seen = map[*yaml.Node]bool{node: true}
+			}
+			seen[mn] = true
+			k.add(mn, seen)
+		}
+	}
+}
+
+// Find returns the key and value nodes of a key, or nil if the map does not have the key. Aliases are resolved in
+// the same way as FindKeyNodeTop.
+func (k *KeyIndex) Find(key string) (keyNode *yaml.Node, valueNode *yaml.Node) {
+	if i := k.find(key); i >= 0 {
+		return NodeAlias(k.pairs[i]), NodeAlias(k.pairs[i+1])
+	}
+	return nil, nil
+}
+
+// FindFold is the same as Find, but the key is matched without regard to case, in the same way as FindKeyNodeTop.
+func (k *KeyIndex) FindFold(key string) (keyNode *yaml.Node, valueNode *yaml.Node) {
+	if k == nil {
+		return nil, nil
+	}
+	if len(k.pairs) <= keyIndexScanLimit*2 {
+		for i := 0; i < len(k.pairs); i += 2 {
+			if strings.EqualFold(key, k.pairs[i].Value) {
+				return NodeAlias(k.pairs[i]), NodeAlias(k.pairs[i+1])
+			}
+		}
+		return nil, nil
+	}
+	if k.folded == nil {
+		k.folded = k.hash(strings.ToLower)
+	}
+	if i, ok := k.folded[strings.ToLower(key)]; ok {
+		return NodeAlias(k.pairs[i]), NodeAlias(k.pairs[i+1])
+	}
+	return nil, nil
+}
+
+// Has returns true if the map has the key.
+func (k *KeyIndex) Has(key string) bool {
+	return k.find(key) >= 0
+}
+
+// find returns the position of a key in pairs, or -1 if the map does not have the key.
+func (k *KeyIndex) find(key string) int {
+	if k == nil {
+		return -1
+	}
+	if len(k.pairs) <= keyIndexScanLimit*2 {
+		for i := 0; i < len(k.pairs); i += 2 {
+			if k.pairs[i].Value == key {
+				return i
+			}
+		}
+		return -1
+	}
+	if k.keys == nil {
+		k.keys = k.hash(nil)
+	}
+	if i, ok := k.keys[key]; ok {
+		return i
+	}
+	return -1
+}
+
+// hash maps every key (changed by normalize, if it is not nil) to the position of its first occurrence in pairs.
+func (k *KeyIndex) hash(normalize func(string) string) map[string]int {
+	m := make(map[string]int, len(k.pairs)/2)
+	for i := 0; i < len(k.pairs); i += 2 {
+		key := k.pairs[i].Value
+		if normalize != nil {
+			key = normalize(key)
+		}
+		if _, ok := m[key]; !ok {
+			m[key] = i
+		}
+	}
+	return m
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestKeyIndex(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`base: &base
+  name: base
+  size: 1
+extra: &extra
+  hot: true
+  size: 2
+pizza:
+  name: pizza
+  Topping: cheese
+  <<: [*base, *extra]
+alias: *base`), &root)
+
+	pizza := root.Content[0].Content[5]
+	keys := NewKeyIndex(pizza)
+
+	k, v := keys.Find("name")
+	assert.Equal(t, "name", k.Value)
+	assert.Equal(t, "pizza", v.Value)
+
+	// merged keys are found, earlier merged maps win.
+	_, v = keys.Find("size")
+	assert.Equal(t, "1", v.Value)
+	_, v = keys.Find("hot")
+	assert.Equal(t, "true", v.Value)
+	assert.True(t, keys.Has("hot"))
+
+	_, v = keys.Find("topping")
+	assert.Nil(t, v)
+	_, v = keys.FindFold("topping")
+	assert.Equal(t, "cheese", v.Value)
+	_, v = keys.FindFold("NAME")
+	assert.Equal(t, "pizza", v.Value)
+	k, v = keys.FindFold("nope")
+	assert.Nil(t, k)
+	assert.Nil(t, v)
+
+	// an alias of a map.
+	_, v = NewKeyIndex(root.Content[0].Content[7]).Find("name")
+	assert.Equal(t, "base", v.Value)
+
+	// anything else has an empty index.
+	for _, n := range []*yaml.Node{nil, v, {Kind: yaml.SequenceNode}} {
+		empty := NewKeyIndex(n)
+		assert.False(t, empty.Has("name"))
+		k, v = empty.Find("name")
+		assert.Nil(t, k)
+		k, v = empty.FindFold("name")
+		assert.Nil(t, v)
+	}
+
+	var nilIndex *KeyIndex
+	assert.False(t, nilIndex.Has("name"))
+	k, v = nilIndex.Find("name")
+	assert.Nil(t, k)
+	k, v = nilIndex.FindFold("name")
+	assert.Nil(t, v)
+}
+
+func TestKeyIndex_MatchesFindKeyNodeTop(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.1.0
+Info: {title: pizza}
+paths: {}
+paths: {duplicate: true}
+x-thing: 1`), &root)
+
+	keys := NewKeyIndex(root.Content[0])
+	for _, key := range []string{"openapi", "info", "INFO", "paths", "x-thing", "missing"} {
+		ek, ev := FindKeyNodeTop(key, root.Content[0].Content)
+		k, v := keys.FindFold(key)
+		assert.Equal(t, ek, k, key)
+		assert.Equal(t, ev, v, key)
+	}
+	// loops of merged maps are only merged once.
+	loop := &yaml.Node{Kind: yaml.MappingNode}
+	loop.Content = []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!merge", Value: "<<"},
+		{Kind: yaml.AliasNode, Alias: loop},
+		{Kind: yaml.ScalarNode, Value: "a"},
+		{Kind: yaml.ScalarNode, Value: "b"},
+	}
+	_, v := NewKeyIndex(loop).Find("a")
+	assert.Equal(t, "b", v.Value)
+}
+
+func TestKeyIndex_LargeMap(t *testing.T) {
+	var sb strings.Builder
+	for i := 0; i < keyIndexScanLimit*2; i++ {
+		sb.WriteString(fmt.Sprintf("Key%d: %d\n", i, i))
+	}
+	sb.WriteString("Key3: duplicate\n")
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(sb.String()), &root)
+
+	keys := NewKeyIndex(root.Content[0])
+	for _, key := range []string{"Key0", "key7", "KEY23", "Key3", "key3", "missing"} {
+		ek, ev := FindKeyNodeTop(key, root.Content[0].Content)
+		k, v := keys.FindFold(key)
+		assert.Equal(t, ek, k, key)
+		assert.Equal(t, ev, v, key)
+
+		ek, _, ev = FindKeyNodeFullTop(key, root.Content[0].Content)
+		k, v = keys.Find(key)
+		assert.Equal(t, ek, k, key)
+		assert.Equal(t, ev, v, key)
+	}
+	_, v := keys.Find("Key3")
+	assert.Equal(t, "3", v.Value)
+	assert.True(t, keys.Has("Key23"))
+	assert.False(t, keys.Has("key23"))
+}
+
+func BenchmarkKeyIndex(b *testing.B) {
+	// a model is built by looking up every one of its fields, a schema has around 50 of them.
+	var lookups []string
+	for i := 0; i < 50; i++ {
+		lookups = append(lookups, fmt.Sprintf("field%d", i))
+	}
+	for _, size := range []int{8, 50} {
+		var sb strings.Builder
+		for i := 0; i < size; i++ {
+			sb.WriteString(fmt.Sprintf("field%d: %d\n", i, i))
+		}
+		var root yaml.Node
+		_ = yaml.Unmarshal([]byte(sb.String()), &root)
+
+		b.Run(fmt.Sprintf("FindKeyNodeTop/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, k := range lookups {
+					FindKeyNodeTop(k, root.Content[0].Content)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("KeyIndex/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				keys := NewKeyIndex(root.Content[0])
+				for _, k := range lookups {
+					keys.FindFold(k)
+				}
+			}
+		})
+	}
+}
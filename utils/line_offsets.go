@@ -0,0 +1,253 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LineOffsets maps the line and column positions of nodes (as held by yaml.Node, 1-based and counted in characters)
+// to byte offsets in the bytes of a specification, and back. Editor tooling can use it to turn the positions found
+// by libopenapi into precise text edits.
+type LineOffsets struct {
+	data   []byte
+	starts []int
+}
+
+// NewLineOffsets builds the line offset table of the bytes of a specification, the same bytes the nodes were
+// parsed from.
+func NewLineOffsets(data []byte) *LineOffsets {
+	starts := []int{0}
+	for i, b := range data {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &LineOffsets{data: data, starts: starts}
+}
+
+// LineCount returns the number of lines.
+func (l *LineOffsets) LineCount() int {
+	return len(l.starts)
+}
+
+// Offset returns the byte offset of a line and column. The column may be one past the end of the line, which is the
+// offset of its line break (or of the end of the data).
+func (l *LineOffsets) Offset(line, column int) (int, error) {
+	if line < 1 || line > len(l.starts) {
+		return 0, fmt.Errorf("line %d is out of range, there are %d lines", line, len(l.starts))
+	}
+	if column < 1 {
+		return 0, fmt.Errorf("column %d is out of range, columns start at 1", column)
+	}
+	offset, end := l.starts[line-1], l.lineEnd(line)
+	for c := 1; c < column; c++ {
+		if offset >= end {
+			return 0, fmt.Errorf("column %d is out of range, line %d has %d characters", column, line, c-1)
+		}
+		_, size := utf8.DecodeRune(l.data[offset:end])
+		offset += size
+	}
+	return offset, nil
+}
+
+// Position returns the line and column of a byte offset. The offset may be the length of the data, which is the
+// position after the last character.
+func (l *LineOffsets) Position(offset int) (line, column int, err error) {
+	if offset < 0 || offset > len(l.data) {
+		return 0, 0, fmt.Errorf("offset %d is out of range, there are %d bytes", offset, len(l.data))
+	}
+	line = sort.Search(len(l.starts), func(i int) bool { return l.starts[i] > offset })
+	return line, utf8.RuneCount(l.data[l.starts[line-1]:offset]) + 1, nil
+}
+
+// NodeRange returns the byte range of the text of a node, from its start offset up to (but not including) its end
+// offset. The range of a map or an array covers all of its entries, and the range of a scalar covers its quotes, or
+// the header and content of a block scalar. Anchors and tags written before a node are not part of its range, and
+// neither are comments.
+func (l *LineOffsets) NodeRange(node *yaml.Node) (start, end int, err error) {
+	if node == nil {
+		return 0, 0, fmt.Errorf("unable to find the range of a nil node")
+	}
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return 0, 0, nil
+		}
+		return l.NodeRange(node.Content[0])
+	}
+	start, err = l.Offset(node.Line, node.Column)
+	if err != nil {
+		return 0, 0, err
+	}
+	start = l.skipProperties(start)
+
+	switch node.Kind {
+	case yaml.AliasNode:
+		return start, l.scanUntil(start+1, isFlowDelimiter), nil
+	case yaml.MappingNode, yaml.SequenceNode:
+		end = start
+		if len(node.Content) > 0 {
+			last := node.Content[len(node.Content)-1:]
+			if node.Kind == yaml.MappingNode && len(node.Content) > 1 {
+				last = node.Content[len(node.Content)-2:]
+			}
+			for _, child := range last {
+				_, childEnd, childErr := l.NodeRange(child)
+				if childErr != nil {
+					return 0, 0, childErr
+				}
+				end = max(end, childEnd)
+			}
+		}
+		if node.Style&yaml.FlowStyle != 0 || (start < len(l.data) && (l.data[start] == '{' || l.data[start] == '[')) {
+			end = l.closeFlow(start, end)
+		}
+		return start, end, nil
+	default:
+		return start, l.scalarEnd(node, start), nil
+	}
+}
+
+// lineEnd returns the offset of the line break at the end of a line, or the length of the data for the last line.
+func (l *LineOffsets) lineEnd(line int) int {
+	if line < len(l.starts) {
+		end := l.starts[line] - 1
+		if end > l.starts[line-1] && l.data[end-1] == '\r' {
+			end--
+		}
+		return end
+	}
+	return len(l.data)
+}
+
+// skipProperties skips the anchor and the tag written before a node.
+func (l *LineOffsets) skipProperties(offset int) int {
+	for offset < len(l.data) && (l.data[offset] == '&' || l.data[offset] == '!') {
+		// the node itself may start on the next line, as a block collection does.
+		offset = l.scanUntil(l.scanUntil(offset, unicode.IsSpace), func(r rune) bool { return !unicode.IsSpace(r) })
+	}
+	return offset
+}
+
+// scanUntil returns the offset of the first character from offset that stop returns true for.
+func (l *LineOffsets) scanUntil(offset int, stop func(rune) bool) int {
+	for offset < len(l.data) {
+		r, size := utf8.DecodeRune(l.data[offset:])
+		if stop(r) {
+			break
+		}
+		offset += size
+	}
+	return offset
+}
+
+func isFlowDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == ',' || r == ']' || r == '}'
+}
+
+// closeFlow returns the offset after the bracket that closes a flow collection, the last entry of which ends at end.
+func (l *LineOffsets) closeFlow(start, end int) int {
+	closing := byte('}')
+	if l.data[start] == '[' {
+		closing = ']'
+	}
+	if end == start {
+		end++ // past the opening bracket of an empty collection.
+	}
+	for end < len(l.data) {
+		switch l.data[end] {
+		case closing:
+			return end + 1
+		case '#':
+			// a comment, which runs to the end of the line.
+			for end < len(l.data) && l.data[end] != '\n' {
+				end++
+			}
+		default:
+			end++
+		}
+	}
+	return end
+}
+
+// scalarEnd returns the offset after the text of a scalar that starts at offset.
+func (l *LineOffsets) scalarEnd(node *yaml.Node, offset int) int {
+	switch node.Style &^ (yaml.TaggedStyle | yaml.FlowStyle) {
+	case yaml.DoubleQuotedStyle:
+		for i := offset + 1; i < len(l.data); i++ {
+			switch l.data[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1
+			}
+		}
+		return len(l.data)
+	case yaml.SingleQuotedStyle:
+		for i := offset + 1; i < len(l.data); i++ {
+			if l.data[i] == '\'' {
+				if i+1 < len(l.data) && l.data[i+1] == '\'' {
+					i++
+					continue
+				}
+				return i + 1
+			}
+		}
+		return len(l.data)
+	case yaml.LiteralStyle, yaml.FoldedStyle:
+		return l.blockScalarEnd(offset)
+	default:
+		// a plain scalar has no escapes, and folding only changes its whitespace, so every other character of its
+		// value is written as it is.
+		end := offset
+		for _, r := range node.Value {
+			if unicode.IsSpace(r) {
+				continue
+			}
+			next := l.scanUntil(end, func(c rune) bool { return !unicode.IsSpace(c) })
+			if next >= len(l.data) {
+				break
+			}
+			if c, size := utf8.DecodeRune(l.data[next:]); c == r {
+				end = next + size
+			}
+		}
+		return end
+	}
+}
+
+// blockScalarEnd returns the offset after the last line of content of a block scalar, the header of which starts at
+// offset.
+func (l *LineOffsets) blockScalarEnd(offset int) int {
+	line, _, _ := l.Position(offset)
+	end := l.lineEnd(line)
+	// the content is indented more than the line of the header.
+	indent, header := -1, 0
+	for header < end-l.starts[line-1] && l.data[l.starts[line-1]+header] == ' ' {
+		header++
+	}
+	for next := line + 1; next <= len(l.starts); next++ {
+		text := l.data[l.starts[next-1]:l.lineEnd(next)]
+		spaces := 0
+		for spaces < len(text) && text[spaces] == ' ' {
+			spaces++
+		}
+		if spaces == len(text) {
+			continue // a blank line, which may be followed by more content.
+		}
+		if indent < 0 && spaces > header {
+			indent = spaces
+		}
+		if indent < 0 || spaces < indent {
+			break
+		}
+		end = l.lineEnd(next)
+	}
+	return end
+}
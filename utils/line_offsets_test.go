@@ -0,0 +1,121 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLineOffsets_OffsetAndPosition(t *testing.T) {
+	data := []byte("a: 1\r\nb: héllo\n\nc: 3")
+	lo := NewLineOffsets(data)
+	assert.Equal(t, 4, lo.LineCount())
+
+	tests := []struct {
+		line, column, offset int
+	}{
+		{1, 1, 0},
+		{1, 5, 4}, // the end of the line, before the line break.
+		{2, 1, 6},
+		{2, 6, 12}, // 'l', after the two bytes of 'é'.
+		{2, 9, 15},
+		{3, 1, 16},
+		{4, 4, 20},
+		{4, 5, 21}, // the end of the data.
+	}
+	for _, tt := range tests {
+		offset, err := lo.Offset(tt.line, tt.column)
+		require.NoError(t, err)
+		assert.Equal(t, tt.offset, offset, "%d:%d", tt.line, tt.column)
+
+		line, column, err := lo.Position(tt.offset)
+		require.NoError(t, err)
+		assert.Equal(t, tt.line, line, "offset %d", tt.offset)
+		assert.Equal(t, tt.column, column, "offset %d", tt.offset)
+	}
+
+	_, err := lo.Offset(0, 1)
+	assert.Error(t, err)
+	_, err = lo.Offset(5, 1)
+	assert.Error(t, err)
+	_, err = lo.Offset(1, 0)
+	assert.Error(t, err)
+	_, err = lo.Offset(1, 6)
+	assert.Error(t, err)
+	_, _, err = lo.Position(-1)
+	assert.Error(t, err)
+	_, _, err = lo.Position(len(data) + 1)
+	assert.Error(t, err)
+}
+
+func TestLineOffsets_NodeRange(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: "pizza \" party"
+  summary: 'it''s hot'
+  description: |
+    line one
+
+    line two
+  version: &v 1.0.0 # comment
+tags: [one, {name: two}]
+copy: *v
+plain: this is
+  folded
+paths:
+  /pets:
+    get: {}
+empty:
+`
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	lo := NewLineOffsets([]byte(spec))
+
+	text := func(path string) string {
+		n, err := FindNodeByJSONPointer(&root, path)
+		require.NoError(t, err)
+		start, end, err := lo.NodeRange(n)
+		require.NoError(t, err)
+		return spec[start:end]
+	}
+
+	assert.Equal(t, "3.1.0", text("/openapi"))
+	assert.Equal(t, `"pizza \" party"`, text("/info/title"))
+	assert.Equal(t, `'it''s hot'`, text("/info/summary"))
+	assert.Equal(t, "|\n    line one\n\n    line two", text("/info/description"))
+	assert.Equal(t, "1.0.0", text("/info/version"))
+	assert.Equal(t, `title: "pizza \" party"
+  summary: 'it''s hot'
+  description: |
+    line one
+
+    line two
+  version: &v 1.0.0`, text("/info"))
+	assert.Equal(t, "[one, {name: two}]", text("/tags"))
+	assert.Equal(t, "{name: two}", text("/tags/1"))
+	// pointers follow aliases, so the alias is taken from its map.
+	start, end, err := lo.NodeRange(root.Content[0].Content[7])
+	require.NoError(t, err)
+	assert.Equal(t, "*v", spec[start:end])
+	assert.Equal(t, "this is\n  folded", text("/plain"))
+	assert.Equal(t, "{}", text("/paths/~1pets/get"))
+	assert.Equal(t, "", text("/empty"))
+	assert.Equal(t, spec[:len(spec)-1], text(""))
+
+	_, _, err = lo.NodeRange(nil)
+	assert.Error(t, err)
+	_, _, err = lo.NodeRange(&yaml.Node{Kind: yaml.ScalarNode, Line: 100, Column: 1})
+	assert.Error(t, err)
+
+	// ranges convert back into the positions of the nodes.
+	n, _ := FindNodeByJSONPointer(&root, "/info/version")
+	start, _, _ = lo.NodeRange(n)
+	line, column, _ := lo.Position(start)
+	assert.Equal(t, 9, line)
+	assert.Equal(t, 15, column)
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNodeNotFound is returned when a node cannot be found in the tree of a document.
+var ErrNodeNotFound = errors.New("node cannot be found in the document")
+
+// pathToken is a single step from a map or an array to one of its values.
+type pathToken struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// FindNodeAncestry returns every node from root down to (and including) target, where target is a value in the
+// tree (the root, a map value or an array item). If the target cannot be found, nil is returned. Aliases are not
+// followed, so a node held by an anchor is found where the anchor is defined.
+func FindNodeAncestry(root, target *yaml.Node) []*yaml.Node {
+	if root == nil || target == nil {
+		return nil
+	}
+	ancestors, _, keyFound := findNodePath(root, target, nil, nil)
+	if keyFound {
+		return nil
+	}
+	return ancestors
+}
+
+// JSONPointerForNode returns the JSON Pointer (in its JSON string representation) of a node in the tree of a
+// document, for example '/paths/~1pets/get'. If the node is a map key, the pointer of its entry is returned. The
+// root of the document has the empty pointer. ErrNodeNotFound is returned if the node is not in the tree.
+func JSONPointerForNode(root, node *yaml.Node) (string, error) {
+	tokens, err := nodePathTokens(root, node)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, t := range tokens {
+		sb.WriteByte('/')
+		if t.isIndex {
+			sb.WriteString(strconv.Itoa(t.index))
+		} else {
+			sb.WriteString(EscapeJSONPointer(t.key))
+		}
+	}
+	return sb.String(), nil
+}
+
+// JSONPathForNode returns the canonical JSONPath (a normalized path, as defined by RFC 9535) of a node in the tree
+// of a document, for example "$['paths']['/pets']['get']" or "$['tags'][0]". If the node is a map key, the path of
+// its entry is returned. ErrNodeNotFound is returned if the node is not in the tree.
+//   - https://www.rfc-editor.org/rfc/rfc9535#section-2.7
+func JSONPathForNode(root, node *yaml.Node) (string, error) {
+	tokens, err := nodePathTokens(root, node)
+	if err != nil {
+		return "", err
+	}
+	return normalizedPath(tokens), nil
+}
+
+// normalizedPath builds a normalized path from path tokens.
+func normalizedPath(tokens []pathToken) string {
+	var sb strings.Builder
+	sb.WriteByte('$')
+	for _, t := range tokens {
+		sb.WriteByte('[')
+		if t.isIndex {
+			sb.WriteString(strconv.Itoa(t.index))
+		} else {
+			sb.WriteByte('\'')
+			writeNormalizedPathName(&sb, t.key)
+			sb.WriteByte('\'')
+		}
+		sb.WriteByte(']')
+	}
+	return sb.String()
+}
+
+func nodePathTokens(root, node *yaml.Node) ([]pathToken, error) {
+	if root == nil || node == nil {
+		return nil, ErrNodeNotFound
+	}
+	ancestors, tokens, _ := findNodePath(root, node, nil, nil)
+	if ancestors == nil {
+		return nil, ErrNodeNotFound
+	}
+	return tokens, nil
+}
+
+// findNodePath walks the tree from n looking for target, returning the ancestry and the path tokens to it. If the
+// target is a map key, the ancestry ends at its map and keyFound is true.
+func findNodePath(n, target *yaml.Node, ancestors []*yaml.Node, tokens []pathToken) ([]*yaml.Node, []pathToken, bool) {
+	ancestors = append(ancestors, n)
+	if n == target {
+		return ancestors, tokens, false
+	}
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			if a, t, k := findNodePath(c, target, ancestors, tokens); a != nil {
+				return a, t, k
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			token := pathToken{key: n.Content[i].Value}
+			if n.Content[i] == target {
+				return ancestors, append(tokens, token), true
+			}
+			if a, t, k := findNodePath(n.Content[i+1], target, ancestors, append(tokens, token)); a != nil {
+				return a, t, k
+			}
+		}
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			if a, t, k := findNodePath(c, target, ancestors, append(tokens, pathToken{index: i, isIndex: true})); a != nil {
+				return a, t, k
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// writeNormalizedPathName escapes a member name for a normalized path.
+//   - https://www.rfc-editor.org/rfc/rfc9535#section-2.7
+func writeNormalizedPathName(sb *strings.Builder, name string) {
+	for _, r := range name {
+		switch r {
+		case '\'':
+			sb.WriteString(`\'`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestJSONPathForNode(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`paths:
+  /pets/{id}:
+    get:
+      tags: [pets, "it's"]
+      parameters:
+        - name: id
+          in: path
+"a~b\\c":
+  "line\nbreak\u0001": value
+anchored: &thing
+  name: pizza
+alias: *thing`), &root)
+
+	tag, err := FindNodeByJSONPointer(&root, "/paths/~1pets~1{id}/get/tags/1")
+	require.NoError(t, err)
+	in, err := FindNodeByJSONPointer(&root, "/paths/~1pets~1{id}/get/parameters/0/in")
+	require.NoError(t, err)
+	odd := root.Content[0].Content[3].Content[1]
+	name, err := FindNodeByJSONPointer(&root, "/alias/name")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		node          *yaml.Node
+		path, pointer string
+		key           bool
+	}{
+		{&root, "$", "", false},
+		{root.Content[0], "$", "", false},
+		{tag, "$['paths']['/pets/{id}']['get']['tags'][1]", "/paths/~1pets~1{id}/get/tags/1", false},
+		{in, "$['paths']['/pets/{id}']['get']['parameters'][0]['in']", "/paths/~1pets~1{id}/get/parameters/0/in", false},
+		{odd, `$['a~b\\c']['line\nbreak\u0001']`, "/a~0b\\c/line\nbreak\u0001", false},
+		// aliases are not followed, so an anchored node is found where it is defined.
+		{name, "$['anchored']['name']", "/anchored/name", false},
+		// the key of an entry has the path of its entry.
+		{root.Content[0].Content[0], "$['paths']", "/paths", true},
+	} {
+		path, err := JSONPathForNode(&root, tc.node)
+		require.NoError(t, err)
+		assert.Equal(t, tc.path, path)
+
+		pointer, err := JSONPointerForNode(&root, tc.node)
+		require.NoError(t, err)
+		assert.Equal(t, tc.pointer, pointer)
+
+		// the pointer finds the node (or the value of the key) again.
+		found, err := FindNodeByJSONPointer(&root, pointer)
+		require.NoError(t, err)
+		if !tc.key && tc.node != &root {
+			assert.Same(t, tc.node, found)
+		}
+	}
+
+	_, err = JSONPathForNode(&root, &yaml.Node{})
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+	_, err = JSONPointerForNode(nil, tag)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+
+	var quoted yaml.Node
+	_ = yaml.Unmarshal([]byte(`"it's": yes`), &quoted)
+	path, _ := JSONPathForNode(&quoted, quoted.Content[0].Content[1])
+	assert.Equal(t, `$['it\'s']`, path)
+}
+
+func TestFindNodeAncestry(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`a:
+  b:
+    - c
+    - d`), &root)
+
+	d, _ := FindNodeByJSONPointer(&root, "/a/b/1")
+	ancestry := FindNodeAncestry(&root, d)
+	require.Len(t, ancestry, 5)
+	assert.Same(t, &root, ancestry[0])
+	assert.Same(t, d, ancestry[4])
+
+	pointer, err := JSONPointerFromAncestry(ancestry)
+	require.NoError(t, err)
+	assert.Equal(t, "/a/b/1", pointer)
+
+	// keys, and nodes outside the tree have no ancestry.
+	assert.Nil(t, FindNodeAncestry(&root, root.Content[0].Content[0]))
+	assert.Nil(t, FindNodeAncestry(&root, &yaml.Node{}))
+	assert.Nil(t, FindNodeAncestry(nil, d))
+}
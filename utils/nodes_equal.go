@@ -0,0 +1,155 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// NodesEqualOptions controls what NodesEqual compares. Line and column numbers are never compared.
+type NodesEqualOptions struct {
+	// IgnoreStyle ignores how nodes are written: quoted, literal and folded scalars, flow and block collections,
+	// explicit tags, and anchors and aliases (an alias is compared as the node it refers to).
+	IgnoreStyle bool
+
+	// IgnoreComments ignores head, line and foot comments.
+	IgnoreComments bool
+
+	// IgnoreKeyOrder compares maps by their keys, no matter what order the keys are written in.
+	IgnoreKeyOrder bool
+}
+
+// NodesEqual deeply compares two node trees. If they are not equal, the normalized path (see JSONPathForNode) of the
+// first difference is returned as well, for example "$['paths']['/pets']". A difference in a map key is reported at
+// the path of its entry, and a missing map entry or array item at the path it is missing from. Two nil nodes are
+// equal.
+func NodesEqual(a, b *yaml.Node, opts NodesEqualOptions) (bool, string) {
+	c := &nodeComparer{opts: opts, comparing: make(map[[2]*yaml.Node]bool)}
+	if path, equal := c.compare(a, b, nil); !equal {
+		return false, normalizedPath(path)
+	}
+	return true, ""
+}
+
+type nodeComparer struct {
+	opts      NodesEqualOptions
+	comparing map[[2]*yaml.Node]bool
+}
+
+// compare returns true if two nodes are equal, or the path tokens of the first difference if they are not.
+func (c *nodeComparer) compare(a, b *yaml.Node, path []pathToken) ([]pathToken, bool) {
+	if c.opts.IgnoreStyle {
+		a, b = aliasTarget(a), aliasTarget(b)
+	}
+	if a == b {
+		return nil, true
+	}
+	if a == nil || b == nil {
+		return path, false
+	}
+	pair := [2]*yaml.Node{a, b}
+	if c.comparing[pair] {
+		// an alias inside its own anchor, which is already being compared.
+		return nil, true
+	}
+	if a.Kind != b.Kind || a.ShortTag() != b.ShortTag() || a.Value != b.Value {
+		return path, false
+	}
+	if !c.opts.IgnoreStyle && (a.Style != b.Style || a.Anchor != b.Anchor) {
+		return path, false
+	}
+	if !c.opts.IgnoreComments &&
+		(a.HeadComment != b.HeadComment || a.LineComment != b.LineComment || a.FootComment != b.FootComment) {
+		return path, false
+	}
+
+	c.comparing[pair] = true
+	defer delete(c.comparing, pair)
+
+	switch a.Kind {
+	case yaml.AliasNode:
+		return c.compare(a.Alias, b.Alias, path)
+	case yaml.MappingNode:
+		if c.opts.IgnoreKeyOrder {
+			return c.compareUnorderedMaps(a, b, path)
+		}
+		return c.compareMaps(a, b, path)
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for i := 0; i < len(a.Content) || i < len(b.Content); i++ {
+			itemPath := path
+			if a.Kind == yaml.SequenceNode {
+				itemPath = append(path, pathToken{index: i, isIndex: true})
+			}
+			if i >= len(a.Content) || i >= len(b.Content) {
+				return itemPath, false
+			}
+			if p, equal := c.compare(a.Content[i], b.Content[i], itemPath); !equal {
+				return p, false
+			}
+		}
+	}
+	return nil, true
+}
+
+// compareMaps compares the entries of two maps in order.
+func (c *nodeComparer) compareMaps(a, b *yaml.Node, path []pathToken) ([]pathToken, bool) {
+	for i := 0; i+1 < len(a.Content) || i+1 < len(b.Content); i += 2 {
+		if i+1 >= len(a.Content) {
+			return append(path, pathToken{key: b.Content[i].Value}), false
+		}
+		entryPath := append(path, pathToken{key: a.Content[i].Value})
+		if i+1 >= len(b.Content) {
+			return entryPath, false
+		}
+		if p, equal := c.compareEntries(a.Content[i:i+2], b.Content[i:i+2], entryPath); !equal {
+			return p, false
+		}
+	}
+	return nil, true
+}
+
+// compareUnorderedMaps compares the entries of two maps by their keys. If a key is in a map more than once, its
+// entries are compared in order.
+func (c *nodeComparer) compareUnorderedMaps(a, b *yaml.Node, path []pathToken) ([]pathToken, bool) {
+	entries := make(map[string][]int, len(b.Content)/2)
+	for i := 0; i+1 < len(b.Content); i += 2 {
+		entries[b.Content[i].Value] = append(entries[b.Content[i].Value], i)
+	}
+	for i := 0; i+1 < len(a.Content); i += 2 {
+		key := a.Content[i].Value
+		entryPath := append(path, pathToken{key: key})
+		if len(entries[key]) == 0 {
+			return entryPath, false
+		}
+		j := entries[key][0]
+		entries[key] = entries[key][1:]
+		if p, equal := c.compareEntries(a.Content[i:i+2], b.Content[j:j+2], entryPath); !equal {
+			return p, false
+		}
+	}
+	// anything left is only in b.
+	for i := 0; i+1 < len(b.Content); i += 2 {
+		if key := b.Content[i].Value; len(entries[key]) > 0 {
+			return append(path, pathToken{key: key}), false
+		}
+	}
+	return nil, true
+}
+
+// compareEntries compares two map entries, each a key node followed by a value node.
+func (c *nodeComparer) compareEntries(a, b []*yaml.Node, path []pathToken) ([]pathToken, bool) {
+	// a difference in a key (such as a comment) is reported at the path of its entry.
+	if _, equal := c.compare(a[0], b[0], path); !equal {
+		return path, false
+	}
+	return c.compare(a[1], b[1], path)
+}
+
+// aliasTarget returns the node an alias refers to, or the node itself if it is not an alias.
+func aliasTarget(n *yaml.Node) *yaml.Node {
+	for n != nil && n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
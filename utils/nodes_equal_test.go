@@ -0,0 +1,190 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func parseNode(t *testing.T, spec string) *yaml.Node {
+	var n yaml.Node
+	if err := yaml.Unmarshal([]byte(spec), &n); err != nil {
+		t.Fatal(err)
+	}
+	return &n
+}
+
+func TestNodesEqual(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      tags: [pets, animals]
+      summary: list pets`
+
+	equal, path := NodesEqual(parseNode(t, spec), parseNode(t, spec), NodesEqualOptions{})
+	assert.True(t, equal)
+	assert.Empty(t, path)
+
+	equal, path = NodesEqual(nil, nil, NodesEqualOptions{})
+	assert.True(t, equal)
+	assert.Empty(t, path)
+
+	equal, path = NodesEqual(parseNode(t, spec), nil, NodesEqualOptions{})
+	assert.False(t, equal)
+	assert.Equal(t, "$", path)
+
+	tests := []struct {
+		name  string
+		other string
+		path  string
+	}{
+		{"value", `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      tags: [pets, animals]
+      summary: list cats`, "$['paths']['/pets']['get']['summary']"},
+		{"array item", `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      tags: [pets, cats]
+      summary: list pets`, "$['paths']['/pets']['get']['tags'][1]"},
+		{"missing array item", `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      summary: list pets`, "$['paths']['/pets']['get']['tags'][1]"},
+		{"type", `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      tags: {pets: animals}
+      summary: list pets`, "$['paths']['/pets']['get']['tags']"},
+		{"key", `openapi: 3.1.0
+paths:
+  /cats:
+    get:
+      tags: [pets, animals]
+      summary: list pets`, "$['paths']['/pets']"},
+		{"missing entry", `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      tags: [pets, animals]`, "$['paths']['/pets']['get']['summary']"},
+		{"extra entry", `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      tags: [pets, animals]
+      summary: list pets
+      description: pets`, "$['paths']['/pets']['get']['description']"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, path := NodesEqual(parseNode(t, spec), parseNode(t, tt.other), NodesEqualOptions{
+				IgnoreStyle: true, IgnoreComments: true, IgnoreKeyOrder: true,
+			})
+			assert.False(t, equal)
+			assert.Equal(t, tt.path, path)
+		})
+	}
+}
+
+func TestNodesEqual_Options(t *testing.T) {
+	a := parseNode(t, `# pets
+name: pizza # the name
+tags: [hot, cheese]
+size: 1`)
+	styled := parseNode(t, `# pets
+name: "pizza" # the name
+tags:
+  - hot
+  - cheese
+size: 1`)
+	commented := parseNode(t, `# dogs
+name: pizza
+tags: [hot, cheese] # the tags
+size: 1`)
+	reordered := parseNode(t, `size: 1
+# pets
+name: pizza # the name
+tags: [hot, cheese]`)
+
+	equal, path := NodesEqual(a, styled, NodesEqualOptions{})
+	assert.False(t, equal)
+	assert.Equal(t, "$['name']", path)
+	equal, _ = NodesEqual(a, styled, NodesEqualOptions{IgnoreStyle: true})
+	assert.True(t, equal)
+
+	equal, path = NodesEqual(a, commented, NodesEqualOptions{})
+	assert.False(t, equal)
+	assert.Equal(t, "$['name']", path)
+	equal, _ = NodesEqual(a, commented, NodesEqualOptions{IgnoreComments: true})
+	assert.True(t, equal)
+
+	equal, path = NodesEqual(a, reordered, NodesEqualOptions{})
+	assert.False(t, equal)
+	assert.Equal(t, "$['name']", path)
+	equal, _ = NodesEqual(a, reordered, NodesEqualOptions{IgnoreKeyOrder: true})
+	assert.True(t, equal)
+
+	// a quoted number is a string, whatever the style.
+	equal, path = NodesEqual(a, parseNode(t, `# pets
+name: pizza # the name
+tags: [hot, cheese]
+size: "1"`), NodesEqualOptions{IgnoreStyle: true})
+	assert.False(t, equal)
+	assert.Equal(t, "$['size']", path)
+
+	// duplicate keys are compared in order.
+	equal, path = NodesEqual(parseNode(t, "a: 1\nb: 2\na: 3"), parseNode(t, "a: 1\na: 4\nb: 2"),
+		NodesEqualOptions{IgnoreKeyOrder: true})
+	assert.False(t, equal)
+	assert.Equal(t, "$['a']", path)
+	equal, _ = NodesEqual(parseNode(t, "a: 1\nb: 2\na: 3"), parseNode(t, "a: 1\na: 3\nb: 2"),
+		NodesEqualOptions{IgnoreKeyOrder: true})
+	assert.True(t, equal)
+}
+
+func TestNodesEqual_Aliases(t *testing.T) {
+	anchored := parseNode(t, `base: &base
+  name: pizza
+copy: *base`)
+	expanded := parseNode(t, `base:
+  name: pizza
+copy:
+  name: pizza`)
+
+	equal, path := NodesEqual(anchored, expanded, NodesEqualOptions{})
+	assert.False(t, equal)
+	assert.Equal(t, "$['base']", path)
+
+	equal, _ = NodesEqual(anchored, expanded, NodesEqualOptions{IgnoreStyle: true})
+	assert.True(t, equal)
+
+	equal, _ = NodesEqual(anchored, parseNode(t, `base: &base
+  name: pizza
+copy: *base`), NodesEqualOptions{})
+	assert.True(t, equal)
+
+	// an alias inside its own anchor.
+	loop := func() *yaml.Node {
+		n := &yaml.Node{Kind: yaml.MappingNode}
+		n.Content = []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "self"},
+			{Kind: yaml.AliasNode, Value: "loop", Alias: n},
+		}
+		return n
+	}
+	equal, _ = NodesEqual(loop(), loop(), NodesEqualOptions{})
+	assert.True(t, equal)
+	equal, _ = NodesEqual(loop(), loop(), NodesEqualOptions{IgnoreStyle: true})
+	assert.True(t, equal)
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveRefPath resolves the location of a reference (the part before the fragment) against the location of the
+// document that holds it, which may have a fragment of its own. Remote documents are URLs, and a location is
+// resolved against them with URL path semantics, so the result is always a URL with forward slashes, whatever the
+// operating system. Local documents are file paths, and a location is resolved against them with file path
+// semantics, so the result is an absolute path of the operating system. A location that is already a URL is
+// returned as it is.
+func ResolveRefPath(base, location string) string {
+	if isURLPath(location) {
+		return location
+	}
+	base, _, _ = strings.Cut(base, "#/")
+	if isURLPath(base) {
+		if u, err := url.Parse(base); err == nil {
+			u.Path = JoinURLRefPath(path.Dir(u.Path), location)
+			u.RawPath = ""
+			u.Fragment = ""
+			u.RawFragment = ""
+			return u.String()
+		}
+	}
+	return joinFilePath(filepath.Dir(base), location)
+}
+
+// JoinRefPath joins the location of a reference (the part before the fragment) to a directory, which is either a
+// URL, or a file path. The same semantics as ResolveRefPath are used.
+func JoinRefPath(dir, location string) string {
+	if isURLPath(location) {
+		return location
+	}
+	if isURLPath(dir) {
+		if u, err := url.Parse(dir); err == nil {
+			u.Path = JoinURLRefPath(u.Path, location)
+			u.RawPath = ""
+			return u.String()
+		}
+	}
+	return joinFilePath(dir, location)
+}
+
+// JoinURLRefPath joins the location of a reference to the path of a URL, using URL path semantics. The result is an
+// absolute URL path.
+func JoinURLRefPath(dir, location string) string {
+	location = filepath.ToSlash(location)
+	if strings.HasPrefix(location, "/") {
+		return path.Clean(location)
+	}
+	return path.Join("/", joinOverlap(dir, location, "/", path.Join))
+}
+
+func isURLPath(location string) bool {
+	return strings.HasPrefix(location, "http")
+}
+
+func joinFilePath(dir, location string) string {
+	if filepath.IsAbs(location) {
+		return filepath.Clean(location)
+	}
+	abs, _ := filepath.Abs(CheckPathOverlap(dir, location, string(filepath.Separator)))
+	return abs
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveRefPath_URL(t *testing.T) {
+	tests := []struct {
+		base, location, expected string
+	}{
+		{"https://pb33f.io/specs/openapi.yaml", "schemas/pet.yaml", "https://pb33f.io/specs/schemas/pet.yaml"},
+		{"https://pb33f.io/specs/openapi.yaml#/paths/~1pets", "../pet.yaml", "https://pb33f.io/pet.yaml"},
+		{"https://pb33f.io/specs/openapi.yaml", "/pet.yaml", "https://pb33f.io/pet.yaml"},
+		{"https://pb33f.io/openapi.yaml", "../../pet.yaml", "https://pb33f.io/pet.yaml"},
+		{"https://pb33f.io", "pet.yaml", "https://pb33f.io/pet.yaml"},
+		{"https://pb33f.io/specs/openapi.yaml?v=1", "pet.yaml", "https://pb33f.io/specs/pet.yaml?v=1"},
+		{"https://pb33f.io/specs/openapi.yaml", "specs/pet.yaml", "https://pb33f.io/specs/pet.yaml"},
+		{"https://pb33f.io/specs/openapi.yaml", "https://quobix.com/pet.yaml", "https://quobix.com/pet.yaml"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ResolveRefPath(tt.base, tt.location), "%s + %s", tt.base, tt.location)
+	}
+}
+
+func TestResolveRefPath_File(t *testing.T) {
+	root := string(os.PathSeparator)
+	specs := filepath.Join(root, "specs")
+	cwd, _ := os.Getwd()
+
+	assert.Equal(t, filepath.Join(specs, "schemas", "pet.yaml"),
+		ResolveRefPath(filepath.Join(specs, "openapi.yaml"), "schemas/pet.yaml"))
+	assert.Equal(t, filepath.Join(root, "pet.yaml"),
+		ResolveRefPath(filepath.Join(specs, "openapi.yaml")+"#/components", "../pet.yaml"))
+	assert.Equal(t, filepath.Join(specs, "pet.yaml"),
+		ResolveRefPath(filepath.Join(specs, "openapi.yaml"), "specs/pet.yaml"))
+	assert.Equal(t, filepath.Join(root, "other", "pet.yaml"),
+		ResolveRefPath(filepath.Join(specs, "openapi.yaml"), filepath.Join(root, "other", "pet.yaml")))
+	assert.Equal(t, filepath.Join(cwd, "pet.yaml"), ResolveRefPath("", "pet.yaml"))
+	assert.Equal(t, filepath.Join(cwd, "pet.yaml"), ResolveRefPath("#/components/schemas/Pet", "pet.yaml"))
+}
+
+func TestJoinRefPath(t *testing.T) {
+	root := string(os.PathSeparator)
+	assert.Equal(t, "https://pb33f.io/specs/schemas/pet.yaml", JoinRefPath("https://pb33f.io/specs", "schemas/pet.yaml"))
+	assert.Equal(t, "https://pb33f.io/specs/pet.yaml", JoinRefPath("https://pb33f.io/specs/", "./pet.yaml"))
+	assert.Equal(t, "https://quobix.com/pet.yaml", JoinRefPath(root, "https://quobix.com/pet.yaml"))
+	assert.Equal(t, filepath.Join(root, "specs", "pet.yaml"), JoinRefPath(filepath.Join(root, "specs"), "pet.yaml"))
+	assert.Equal(t, filepath.Join(root, "pet.yaml"), JoinRefPath(filepath.Join(root, "specs"), "../pet.yaml"))
+}
+
+func TestJoinURLRefPath(t *testing.T) {
+	assert.Equal(t, "/specs/pet.yaml", JoinURLRefPath("/specs", "pet.yaml"))
+	assert.Equal(t, "/pet.yaml", JoinURLRefPath("", "pet.yaml"))
+	assert.Equal(t, "/pet.yaml", JoinURLRefPath("/specs", "/pet.yaml"))
+	assert.Equal(t, "/pet.yaml", JoinURLRefPath("/specs", "../../pet.yaml"))
+}
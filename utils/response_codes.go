@@ -0,0 +1,53 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidateResponseCode will check a response code used by a Responses object is well-formed. A valid code is
+// either 'default', an HTTP status code between 100 and 599, or a range of codes using an uppercase wildcard
+// (1XX, 2XX, 3XX, 4XX or 5XX).
+func ValidateResponseCode(code string) error {
+	if code == "default" {
+		return nil
+	}
+	if len(code) != 3 {
+		return fmt.Errorf("response code '%s' is not valid, it must be 'default', a three digit HTTP "+
+			"status code, or a range (like 2XX)", code)
+	}
+	if code[1:] == "XX" {
+		if code[0] < '1' || code[0] > '5' {
+			return fmt.Errorf("response code range '%s' is not valid, it must be one of 1XX, 2XX, 3XX, 4XX or 5XX", code)
+		}
+		return nil
+	}
+	if strings.EqualFold(code[1:], "XX") {
+		return fmt.Errorf("response code range '%s' is not valid, the wildcard must be an uppercase 'X'", code)
+	}
+	status, err := strconv.Atoi(code)
+	if err != nil || status < 100 || status > 599 {
+		return fmt.Errorf("response code '%s' is not valid, it must be an HTTP status code between 100 and 599", code)
+	}
+	return nil
+}
+
+// MatchResponseCode will return the precedence of a response code (as used by a Responses object) for an HTTP
+// status code. An exact match returns 3, a matching range (like 2XX, the wildcard is matched in any case)
+// returns 2, 'default' returns 1, and a code that does not apply returns 0. The highest precedence code
+// available describes the response.
+func MatchResponseCode(code string, status int) int {
+	switch {
+	case code == strconv.Itoa(status):
+		return 3
+	case len(code) == 3 && strings.EqualFold(code[1:], "XX") && status/100 == int(code[0]-'0'):
+		return 2
+	case strings.EqualFold(code, "default"):
+		return 1
+	}
+	return 0
+}
@@ -38,6 +38,14 @@ const (
 	UnknownCase
 )
 
+const (
+	// Arazzo is used by all Arazzo (workflow) docs, they are detected but not supported.
+	Arazzo = "arazzo"
+
+	// JSONSchema is used by standalone JSON Schema docs, they are detected but not supported.
+	JSONSchema = "json-schema"
+)
+
 // FindNodes will find a node based on JSONPath, it accepts raw yaml/json as input.
 func FindNodes(yamlData []byte, jsonPath string) ([]*yaml.Node, error) {
 	jsonPath = FixContext(jsonPath)
@@ -672,22 +680,29 @@ var (
 	pathCharExp    = regexp.MustCompile(`[%=;~.]`)
 )
 
-func appendSegment(sb *strings.Builder, segs []string, cleaned []string, i int, wrapInQuotes bool) {
-	sb.Reset()
+func appendSegment(segs []string, cleaned []string, i int, wrapInQuotes bool) {
 	if wrapInQuotes {
-		sb.WriteString("['")
-		sb.WriteString(segs[i])
-		sb.WriteString("']")
+		cleaned[len(cleaned)-1] += "['" + segs[i] + "']"
 	} else {
-		sb.WriteString("[")
-		sb.WriteString(segs[i])
-		sb.WriteString("]")
+		cleaned[len(cleaned)-1] += "[" + segs[i] + "]"
 	}
-	c := sb.String()
-	sb.Reset()
-	sb.WriteString(cleaned[len(cleaned)-1])
-	sb.WriteString(c)
-	cleaned[len(cleaned)-1] = sb.String()
+}
+
+// isInteger returns true if s can be parsed by strconv.Atoi. Checking first avoids the error (and its copy of s)
+// that strconv.Atoi allocates for every segment that is not a number.
+func isInteger(s string) bool {
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 // ConvertComponentIdIntoFriendlyPathSearch will convert a JSON Path into a friendly path search string.
@@ -695,31 +710,21 @@ func appendSegment(sb *strings.Builder, segs []string, cleaned []string, i int,
 //
 // This function was re-written in v0.18.0 in order to fix a number of performance issues with the original
 // implementation. Allocations were high and this function is used a lot, this new implementation is much
-// lighter on string allocations by using a string builder.
+// lighter on string allocations, every segment is joined in a single concatenation.
 func ConvertComponentIdIntoFriendlyPathSearch(id string) (string, string) {
 	segs := strings.Split(id, "/")
 	name, _ := url.QueryUnescape(strings.ReplaceAll(segs[len(segs)-1], "~1", "/"))
 	cleaned := make([]string, 0, len(segs))
 
-	// use a builder to prevent many pointless string allocations.
-	var sb strings.Builder
-
 	// check for strange spaces, chars and if found, wrap them up, clean them and create a new cleaned path.
 	for i := range segs {
 		if pathCharExp.MatchString(segs[i]) {
 
 			segs[i], _ = url.QueryUnescape(strings.ReplaceAll(segs[i], "~1", "/"))
-			sb.Reset()
-			sb.WriteString("['")
-			sb.WriteString(segs[i])
-			sb.WriteString("']")
-			segs[i] = sb.String()
+			segs[i] = "['" + segs[i] + "']"
 
 			if len(cleaned) > 0 {
-				sb.Reset()
-				sb.WriteString(segs[i-1])
-				sb.WriteString(segs[i])
-				cleaned[len(cleaned)-1] = sb.String()
+				cleaned[len(cleaned)-1] = segs[i-1] + segs[i]
 				continue
 			}
 		} else {
@@ -733,31 +738,22 @@ func ConvertComponentIdIntoFriendlyPathSearch(id string) (string, string) {
 
 			// check for brackets in the name, and if found, rewire the path to encapsulate them
 			// correctly. https://github.com/pb33f/libopenapi/issues/112
-			brackets := bracketNameExp.FindStringSubmatch(segs[i])
-
-			if len(brackets) > 0 {
+			if bracketNameExp.MatchString(segs[i]) {
 				segs[i] = bracketNameExp.ReplaceAllString(segs[i], "['$1[$2]']")
 				if len(cleaned) > 0 {
-					sb.Reset()
-					sb.WriteString(segs[i-1])
-					sb.WriteString(segs[i])
-					cleaned[len(cleaned)-1] = sb.String()
+					cleaned[len(cleaned)-1] = segs[i-1] + segs[i]
 					continue
 				}
 			}
 
-			intVal, err := strconv.Atoi(segs[i])
-			if err == nil {
-				if intVal <= 99 {
+			if isInteger(segs[i]) {
+				intVal, err := strconv.Atoi(segs[i])
+				if err == nil {
 					if len(cleaned) > 0 {
-						appendSegment(&sb, segs, cleaned, i, false)
-					}
-				} else {
-					if len(cleaned) > 0 {
-						appendSegment(&sb, segs, cleaned, i, true)
+						appendSegment(segs, cleaned, i, intVal > 99)
 					}
+					continue
 				}
-				continue
 			}
 
 			// if we have a plural parent, wrap it in quotes.
@@ -766,32 +762,18 @@ func ConvertComponentIdIntoFriendlyPathSearch(id string) (string, string) {
 					cleaned = append(cleaned, segs[i])
 					continue
 				}
-				sb.Reset()
-				sb.WriteString("['")
-				sb.WriteString(segs[i])
-				sb.WriteString("']")
-				c := sb.String()
-				sb.Reset()
-				sb.WriteString(cleaned[len(cleaned)-1])
-				sb.WriteString(c)
-				cleaned[len(cleaned)-1] = sb.String()
+				cleaned[len(cleaned)-1] += "['" + segs[i] + "']"
 				continue
 			}
 
 			cleaned = append(cleaned, segs[i])
 		}
 	}
-	_, err := strconv.Atoi(name)
-	var replaced string
-	if err != nil {
-		replaced = strings.ReplaceAll(strings.Join(cleaned, "."), "#", "$")
-	} else {
-		replaced = strings.ReplaceAll(strings.Join(cleaned, "."), "#", "$")
-	}
+	replaced := strings.ReplaceAll(strings.Join(cleaned, "."), "#", "$")
 
 	if len(replaced) > 0 {
 		if replaced[0] != '$' {
-			replaced = fmt.Sprintf("$%s", replaced)
+			replaced = "$" + replaced
 		}
 	}
 	return name, replaced
@@ -929,6 +911,20 @@ func DetermineWhitespaceLength(input string) int {
 	}
 }
 
+// DetermineWhitespaceLengthBytes is the same as DetermineWhitespaceLength, for bytes.
+func DetermineWhitespaceLengthBytes(input []byte) int {
+	shortest := -1
+	for _, ws := range whitespaceExp.FindAllSubmatch(input, -1) {
+		if shortest < 0 || len(ws[1]) < shortest {
+			shortest = len(ws[1])
+		}
+	}
+	if shortest < 0 {
+		return 0
+	}
+	return shortest
+}
+
 // CheckForMergeNodes will check the top level of the schema for merge nodes. If any are found, then the merged nodes
 // will be appended to the end of the rest of the nodes in the schema.
 // Note: this is a destructive operation, so the in-memory node structure will be modified
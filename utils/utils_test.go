@@ -795,6 +795,15 @@ func TestConvertComponentIdIntoFriendlyPathSearch_HTTPCode(t *testing.T) {
 	assert.Equal(t, "404", segment)
 }
 
+func TestIsInteger(t *testing.T) {
+	for _, s := range []string{"0", "404", "-1", "+12", "99999999999999999999"} {
+		assert.True(t, isInteger(s), s)
+	}
+	for _, s := range []string{"", "-", "+", "1a", "a1", "1.5", " 1", "[1]"} {
+		assert.False(t, isInteger(s), s)
+	}
+}
+
 func TestConvertComponentIdIntoPath(t *testing.T) {
 	segment, path := ConvertComponentIdIntoPath("$.chicken.chips.pizza.cake")
 	assert.Equal(t, "#/chicken/chips/pizza/cake", path)
@@ -1003,6 +1012,12 @@ func TestDetermineJSONWhitespaceLength_None(t *testing.T) {
 	assert.Equal(t, 0, DetermineWhitespaceLength(string(someBytes)))
 }
 
+func TestDetermineWhitespaceLengthBytes(t *testing.T) {
+	someBytes, _ := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	assert.Equal(t, 2, DetermineWhitespaceLengthBytes(someBytes))
+	assert.Equal(t, 0, DetermineWhitespaceLengthBytes([]byte(`{"hello": "world"}`)))
+}
+
 func TestFindFirstKeyNode_MergeTest(t *testing.T) {
 	yml := []byte(`openapi: 3.0.3
 
@@ -1183,3 +1198,22 @@ func TestNodeMerge_NoNodes(t *testing.T) {
 	n := NodeMerge(nil)
 	assert.Nil(t, n)
 }
+
+func TestValidateResponseCode(t *testing.T) {
+	for _, code := range []string{"default", "200", "204", "100", "599", "1XX", "2XX", "5XX"} {
+		assert.NoError(t, ValidateResponseCode(code), code)
+	}
+	for _, code := range []string{"", "Default", "20", "2000", "099", "600", "abc", "2xx", "0XX", "6XX", "2X0"} {
+		assert.Error(t, ValidateResponseCode(code), code)
+	}
+}
+
+func TestMatchResponseCode(t *testing.T) {
+	assert.Equal(t, 3, MatchResponseCode("204", 204))
+	assert.Equal(t, 2, MatchResponseCode("2XX", 204))
+	assert.Equal(t, 2, MatchResponseCode("2xx", 204))
+	assert.Equal(t, 1, MatchResponseCode("default", 204))
+	assert.Equal(t, 0, MatchResponseCode("200", 204))
+	assert.Equal(t, 0, MatchResponseCode("4XX", 204))
+	assert.Equal(t, 0, MatchResponseCode("x-nope", 204))
+}
@@ -14,6 +14,11 @@ func ReplaceWindowsDriveWithLinuxPath(path string) string {
 }
 
 func CheckPathOverlap(pathA, pathB, sep string) string {
+	return joinOverlap(pathA, pathB, sep, filepath.Join)
+}
+
+// joinOverlap joins two paths with join, dropping the first segment of pathB if it is the last segment of pathA.
+func joinOverlap(pathA, pathB, sep string, join func(...string) string) string {
 	a := strings.Split(pathA, sep)
 	b := strings.Split(pathB, sep)
 	if strings.HasPrefix(a[len(a)-1], "/") && a[len(a)-1][1:] == b[0] {
@@ -22,7 +27,7 @@ func CheckPathOverlap(pathA, pathB, sep string) string {
 	if a[len(a)-1] == b[0] {
 		b = b[1:]
 	}
-	f := filepath.Join(pathA, strings.Join(b, sep))
+	f := join(pathA, strings.Join(b, sep))
 
 	return f
 }
@@ -0,0 +1,298 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxSafeInteger is the largest integer a JSON parser using float64 numbers (like JavaScript) can hold exactly.
+const maxSafeInteger = 1<<53 - 1
+
+// ConvertYAMLtoJSONLossless parses YAML (or JSON) and converts it into JSON with ConvertYAMLNodeToJSON.
+func ConvertYAMLtoJSONLossless(yamlData []byte, indentation string) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(yamlData, &node); err != nil {
+		return nil, err
+	}
+	return ConvertYAMLNodeToJSON(&node, indentation)
+}
+
+// ConvertYAMLNodeToJSON converts a yaml.Node tree into JSON, without the type drift of decoding the YAML into Go
+// values and encoding them again, as ConvertYAMLtoJSON does. Every level is indented by indentation, an empty
+// indentation renders compact JSON.
+//
+//   - keys are kept in the order they were written, and merge keys ('<<') are expanded in place.
+//   - integers stay integers and floats stay floats (1.0 is not rendered as 1). Numbers that are already valid JSON
+//     are written exactly as they were, other YAML number forms (like 0x1F, 0o17, +1 or .5) are converted.
+//   - integers too large to be held exactly by a float64 (beyond 2^53), and floats too large for a float64, are
+//     written as strings, as are infinity and NaN, which JSON cannot hold.
+//   - timestamps and binary values are written as the strings they were written as.
+//   - scalars with a custom tag are written as strings, and keys that are not strings use the value they were
+//     written as.
+//   - aliases are expanded, an alias inside its own anchor returns an error.
+func ConvertYAMLNodeToJSON(node *yaml.Node, indentation string) ([]byte, error) {
+	if node == nil {
+		return nil, errors.New("unable to convert YAML to JSON, the node is nil")
+	}
+	c := &yamlJSONConverter{indentation: indentation, expanding: make(map[*yaml.Node]bool)}
+	if err := c.write(node, 0); err != nil {
+		return nil, err
+	}
+	return c.buf.Bytes(), nil
+}
+
+type yamlJSONConverter struct {
+	buf         bytes.Buffer
+	indentation string
+	expanding   map[*yaml.Node]bool
+}
+
+func (c *yamlJSONConverter) newline(depth int) {
+	if c.indentation != "" {
+		c.buf.WriteByte('\n')
+		c.buf.WriteString(strings.Repeat(c.indentation, depth))
+	}
+}
+
+func (c *yamlJSONConverter) write(n *yaml.Node, depth int) error {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			c.buf.WriteString("null")
+			return nil
+		}
+		return c.write(n.Content[0], depth)
+	case yaml.AliasNode:
+		if n.Alias == nil {
+			return fmt.Errorf("unable to convert YAML to JSON, alias '%s' (line %d) has no anchor", n.Value, n.Line)
+		}
+		if c.expanding[n.Alias] {
+			return fmt.Errorf("unable to convert YAML to JSON, alias '%s' (line %d) is inside its own anchor",
+				n.Value, n.Line)
+		}
+		c.expanding[n.Alias] = true
+		defer delete(c.expanding, n.Alias)
+		return c.write(n.Alias, depth)
+	case yaml.MappingNode:
+		return c.writeMap(n, depth)
+	case yaml.SequenceNode:
+		if len(n.Content) == 0 {
+			c.buf.WriteString("[]")
+			return nil
+		}
+		c.buf.WriteByte('[')
+		for i, item := range n.Content {
+			if i > 0 {
+				c.buf.WriteByte(',')
+			}
+			c.newline(depth + 1)
+			if err := c.write(item, depth+1); err != nil {
+				return err
+			}
+		}
+		c.newline(depth)
+		c.buf.WriteByte(']')
+		return nil
+	case yaml.ScalarNode:
+		return c.writeScalar(n)
+	default:
+		return fmt.Errorf("unable to convert YAML to JSON, unknown node kind: %v", n.Kind)
+	}
+}
+
+// writeMap writes a map, expanding any merge keys in place. Keys written in the map win over merged keys, and
+// earlier merged maps win over later ones.
+func (c *yamlJSONConverter) writeMap(n *yaml.Node, depth int) error {
+	entries, err := c.mapEntries(n, make(map[string]bool))
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		c.buf.WriteString("{}")
+		return nil
+	}
+	c.buf.WriteByte('{')
+	for i, e := range entries {
+		if i > 0 {
+			c.buf.WriteByte(',')
+		}
+		c.newline(depth + 1)
+		writeJSONString(&c.buf, e.key)
+		c.buf.WriteByte(':')
+		if c.indentation != "" {
+			c.buf.WriteByte(' ')
+		}
+		if err = c.write(e.value, depth+1); err != nil {
+			return err
+		}
+	}
+	c.newline(depth)
+	c.buf.WriteByte('}')
+	return nil
+}
+
+type yamlJSONEntry struct {
+	key   string
+	value *yaml.Node
+}
+
+// mapEntries returns the entries of a map in order, with merge keys expanded. Keys already in seen are skipped.
+func (c *yamlJSONConverter) mapEntries(n *yaml.Node, seen map[string]bool) ([]yamlJSONEntry, error) {
+	// keys written in the map win over merged keys, wherever they are in the map.
+	own := make(map[string]bool)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if !isMergeKey(n.Content[i]) {
+			k, err := jsonKey(n.Content[i])
+			if err != nil {
+				return nil, err
+			}
+			own[k] = true
+		}
+	}
+
+	var entries []yamlJSONEntry
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		if !isMergeKey(k) {
+			key, _ := jsonKey(k)
+			if !seen[key] {
+				seen[key] = true
+				entries = append(entries, yamlJSONEntry{key: key, value: v})
+			}
+			continue
+		}
+		merged := []*yaml.Node{v}
+		if v.Kind == yaml.SequenceNode {
+			merged = v.Content
+		}
+		for _, m := range merged {
+			target := m
+			if m.Kind == yaml.AliasNode && m.Alias != nil {
+				target = m.Alias
+			}
+			if target.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("unable to convert YAML to JSON, merge key (line %d) does not merge a map", k.Line)
+			}
+			if c.expanding[target] {
+				return nil, fmt.Errorf("unable to convert YAML to JSON, merge key (line %d) merges a map into itself",
+					k.Line)
+			}
+			mergeSeen := make(map[string]bool, len(seen)+len(own))
+			for s := range seen {
+				mergeSeen[s] = true
+			}
+			for s := range own {
+				mergeSeen[s] = true
+			}
+			c.expanding[target] = true
+			mergedEntries, err := c.mapEntries(target, mergeSeen)
+			delete(c.expanding, target)
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range mergedEntries {
+				seen[e.key] = true
+			}
+			entries = append(entries, mergedEntries...)
+		}
+	}
+	return entries, nil
+}
+
+func isMergeKey(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Value == "<<" && (n.Tag == "!!merge" || n.Tag == "tag:yaml.org,2002:merge")
+}
+
+// jsonKey returns the JSON key of a map key, which must be a scalar (or an alias of one).
+func jsonKey(n *yaml.Node) (string, error) {
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	if n.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("unable to convert YAML to JSON, the key on line %d is not a scalar", n.Line)
+	}
+	return n.Value, nil
+}
+
+func (c *yamlJSONConverter) writeScalar(n *yaml.Node) error {
+	switch n.ShortTag() {
+	case "!!null":
+		c.buf.WriteString("null")
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return fmt.Errorf("unable to convert YAML to JSON, '%s' (line %d) is not a boolean", n.Value, n.Line)
+		}
+		c.buf.WriteString(strconv.FormatBool(b))
+	case "!!int":
+		c.writeInt(n)
+	case "!!float":
+		c.writeFloat(n)
+	case "!!binary":
+		if _, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(n.Value), "")); err != nil {
+			return fmt.Errorf("unable to convert YAML to JSON, '%s' (line %d) is not valid base64", n.Value, n.Line)
+		}
+		writeJSONString(&c.buf, n.Value)
+	default:
+		// strings, timestamps and custom tags.
+		writeJSONString(&c.buf, n.Value)
+	}
+	return nil
+}
+
+func (c *yamlJSONConverter) writeInt(n *yaml.Node) {
+	value := strings.ReplaceAll(n.Value, "_", "")
+	i, ok := new(big.Int).SetString(strings.TrimPrefix(value, "+"), 0)
+	if !ok {
+		// not a number, despite the tag.
+		writeJSONString(&c.buf, n.Value)
+		return
+	}
+	if i.IsInt64() && i.Int64() >= -maxSafeInteger && i.Int64() <= maxSafeInteger {
+		if json.Valid([]byte(n.Value)) {
+			c.buf.WriteString(n.Value)
+		} else {
+			c.buf.WriteString(i.String())
+		}
+		return
+	}
+	writeJSONString(&c.buf, i.String())
+}
+
+func (c *yamlJSONConverter) writeFloat(n *yaml.Node) {
+	var f float64
+	if err := n.Decode(&f); err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		// too large for a float64, infinity or NaN.
+		writeJSONString(&c.buf, n.Value)
+		return
+	}
+	if json.Valid([]byte(n.Value)) {
+		c.buf.WriteString(n.Value)
+		return
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	c.buf.WriteString(s)
+}
+
+// writeJSONString writes a JSON string, without escaping HTML characters.
+func writeJSONString(b *bytes.Buffer, s string) {
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	b.Truncate(b.Len() - 1) // the newline written by Encode.
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConvertYAMLNodeToJSON(t *testing.T) {
+	spec := `openapi: 3.1.0
+zebra: 1
+apple: 2
+version: 1.10
+float: 1.0
+exp: 1e3
+hex: 0x1F
+octal: 0o17
+plus: +7
+under: 1_000
+dot: .5
+big: 12345678901234567890
+unsafe: 9007199254740993
+huge: 1e400
+inf: .inf
+nan: .NaN
+date: 2024-01-02
+time: 2024-01-02T03:04:05Z
+binary: !!binary aGVsbG8=
+custom: !Ref thing
+yes: yes
+bool: true
+null: ~
+empty:
+html: <b>&</b>
+200: ok
+list: [1, "two", 3.0, [], {}]`
+	b, err := ConvertYAMLtoJSONLossless([]byte(spec), "")
+	require.NoError(t, err)
+	assert.Equal(t, `{"openapi":"3.1.0","zebra":1,"apple":2,"version":1.10,"float":1.0,"exp":1e3,"hex":31,"octal":15,`+
+		`"plus":7,"under":1000,"dot":0.5,"big":"12345678901234567890","unsafe":"9007199254740993","huge":"1e400",`+
+		`"inf":".inf","nan":".NaN","date":"2024-01-02","time":"2024-01-02T03:04:05Z","binary":"aGVsbG8=",`+
+		`"custom":"thing","yes":"yes","bool":true,"null":null,"empty":null,"html":"<b>&</b>","200":"ok",`+
+		`"list":[1,"two",3.0,[],{}]}`, string(b))
+	assert.True(t, json.Valid(b))
+}
+
+func TestConvertYAMLNodeToJSON_Indentation(t *testing.T) {
+	b, err := ConvertYAMLtoJSONLossless([]byte("a:\n  b: [1, 2]\n  c: {}\n"), "  ")
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "a": {
+    "b": [
+      1,
+      2
+    ],
+    "c": {}
+  }
+}`, string(b))
+}
+
+func TestConvertYAMLNodeToJSON_MergeKeys(t *testing.T) {
+	spec := `base: &base
+  name: base
+  size: 1
+extra: &extra
+  size: 2
+  hot: true
+pizza:
+  name: pizza
+  <<: [*base, *extra]
+  topping: cheese
+  size: 3
+inline:
+  <<: {a: 1}
+  b: 2
+alias: *base`
+	b, err := ConvertYAMLtoJSONLossless([]byte(spec), "")
+	require.NoError(t, err)
+	assert.Equal(t, `{"base":{"name":"base","size":1},"extra":{"size":2,"hot":true},`+
+		`"pizza":{"name":"pizza","hot":true,"topping":"cheese","size":3},"inline":{"a":1,"b":2},`+
+		`"alias":{"name":"base","size":1}}`, string(b))
+}
+
+func TestConvertYAMLNodeToJSON_Errors(t *testing.T) {
+	_, err := ConvertYAMLNodeToJSON(nil, "")
+	assert.ErrorContains(t, err, "the node is nil")
+
+	_, err = ConvertYAMLtoJSONLossless([]byte("a: [b"), "")
+	assert.Error(t, err)
+
+	_, err = ConvertYAMLtoJSONLossless([]byte("? [a]\n: b"), "")
+	assert.ErrorContains(t, err, "is not a scalar")
+
+	_, err = ConvertYAMLtoJSONLossless([]byte("a:\n  <<: [1]"), "")
+	assert.ErrorContains(t, err, "does not merge a map")
+
+	_, err = ConvertYAMLtoJSONLossless([]byte("a: !!bool nope"), "")
+	assert.ErrorContains(t, err, "is not a boolean")
+
+	_, err = ConvertYAMLtoJSONLossless([]byte("a: !!binary '*notbase64*'"), "")
+	assert.ErrorContains(t, err, "is not valid base64")
+
+	// an alias inside its own anchor.
+	loop := &yaml.Node{Kind: yaml.SequenceNode}
+	loop.Content = []*yaml.Node{{Kind: yaml.AliasNode, Value: "loop", Alias: loop}}
+	_, err = ConvertYAMLNodeToJSON(&yaml.Node{Kind: yaml.AliasNode, Value: "loop", Alias: loop}, "")
+	assert.ErrorContains(t, err, "is inside its own anchor")
+
+	selfMerge := &yaml.Node{Kind: yaml.MappingNode}
+	selfMerge.Content = []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!merge", Value: "<<"},
+		{Kind: yaml.AliasNode, Value: "self", Alias: selfMerge},
+	}
+	_, err = ConvertYAMLNodeToJSON(&yaml.Node{Kind: yaml.AliasNode, Value: "self", Alias: selfMerge}, "")
+	assert.ErrorContains(t, err, "merges a map into itself")
+
+	_, err = ConvertYAMLNodeToJSON(&yaml.Node{Kind: yaml.AliasNode, Value: "missing"}, "")
+	assert.ErrorContains(t, err, "has no anchor")
+	_, err = ConvertYAMLNodeToJSON(&yaml.Node{Kind: 99}, "")
+	assert.ErrorContains(t, err, "unknown node kind")
+
+	b, err := ConvertYAMLNodeToJSON(&yaml.Node{Kind: yaml.DocumentNode}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(b))
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"slices"
+	"strings"
+
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// AffectedOperation is an operation that uses a changed component, either directly or through other components.
+type AffectedOperation struct {
+	Path   string `json:"path" yaml:"path"`
+	Method string `json:"method" yaml:"method"`
+}
+
+// String returns the method and path of the operation, like 'GET /pets'.
+func (o *AffectedOperation) String() string {
+	return strings.ToUpper(o.Method) + " " + o.Path
+}
+
+// MapAffectedOperations will find every operation that uses a component changed between two documents, and record
+// those operations on each change made to the component (see Change.AffectedOperations). A change inside a
+// referenced schema then shows which operations it impacts, instead of only being a components change.
+//
+// The original and updated documents must be the same low-level *v3.Document or *v2.Swagger documents that were
+// compared to create the changes. Operations are found by following every reference used by an operation, through
+// the index of each document. Operations using the component in either document are affected.
+func MapAffectedOperations(changes *DocumentChanges, original, updated any) {
+	if changes == nil || changes.ComponentsChanges == nil {
+		return
+	}
+	var indexes []*index.SpecIndex
+	var swagger bool
+	for _, doc := range []any{original, updated} {
+		if idx, isSwagger := documentIndex(doc); idx != nil {
+			indexes = append(indexes, idx)
+			swagger = swagger || isSwagger
+		}
+	}
+	if len(indexes) == 0 {
+		return
+	}
+
+	usage := make(map[string][]*AffectedOperation)
+	for _, idx := range indexes {
+		for ref, ops := range operationsByReference(idx) {
+			for _, op := range ops {
+				if !slices.ContainsFunc(usage[ref], func(o *AffectedOperation) bool { return *o == *op }) {
+					usage[ref] = append(usage[ref], op)
+				}
+			}
+		}
+	}
+
+	WalkChanges(changes.ComponentsChanges, func(location *ChangeLocation, change *Change) {
+		if ref := componentReference(location, change, swagger); ref != "" {
+			change.AffectedOperations = usage[ref]
+		}
+	})
+}
+
+// componentReference returns the local reference of the component a change was made to, or an empty string if the
+// change is not for a component that can be referenced.
+func componentReference(location *ChangeLocation, change *Change, swagger bool) string {
+	if len(location.Path) >= 2 && location.Path[0] == v3.SchemasLabel {
+		if swagger {
+			return "#/" + v2.DefinitionsLabel + "/" + location.Path[1]
+		}
+		return "#/" + v3.ComponentsLabel + "/" + v3.SchemasLabel + "/" + location.Path[1]
+	}
+	if len(location.Path) > 0 || (change.ChangeType != ObjectAdded && change.ChangeType != ObjectRemoved) {
+		return ""
+	}
+	name := change.Original
+	if change.ChangeType == ObjectAdded {
+		name = change.New
+	}
+	switch change.Property {
+	case v3.SecuritySchemesLabel, v3.SecurityDefinitionLabel:
+		return ""
+	case v2.DefinitionsLabel, v3.ParametersLabel, v3.ResponsesLabel:
+		if swagger {
+			return "#/" + change.Property + "/" + name
+		}
+	}
+	return "#/" + v3.ComponentsLabel + "/" + change.Property + "/" + name
+}
+
+// operationsByReference finds every reference used by each operation in the indexed document, following
+// references held by the referenced components, and returns the operations using each reference.
+func operationsByReference(idx *index.SpecIndex) map[string][]*AffectedOperation {
+	usage := make(map[string][]*AffectedOperation)
+	root := idx.GetRootNode()
+	if root == nil {
+		return usage
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	_, paths := utils.FindKeyNodeTop(v3.PathsLabel, root.Content)
+	if paths == nil {
+		return usage
+	}
+
+	componentRefs := make(map[string][]string)
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		path, pathItem := paths.Content[i].Value, paths.Content[i+1]
+		var shared []string
+		if _, params := utils.FindKeyNodeTop(v3.ParametersLabel, pathItem.Content); params != nil {
+			shared = collectReferences(params, nil)
+		}
+		for j := 0; j+1 < len(pathItem.Content); j += 2 {
+			method := pathItem.Content[j].Value
+			if !slices.Contains(operationLabels, method) {
+				continue
+			}
+			op := &AffectedOperation{Path: path, Method: method}
+			seen := make(map[string]bool)
+			queue := collectReferences(pathItem.Content[j+1], slices.Clone(shared))
+			for len(queue) > 0 {
+				ref := queue[0]
+				queue = queue[1:]
+				if seen[ref] {
+					continue
+				}
+				seen[ref] = true
+				usage[ref] = append(usage[ref], op)
+
+				refs, ok := componentRefs[ref]
+				if !ok {
+					if found := idx.FindComponent(ref); found != nil && found.Node != nil {
+						refs = collectReferences(found.Node, nil)
+					}
+					componentRefs[ref] = refs
+				}
+				queue = append(queue, refs...)
+			}
+		}
+	}
+	return usage
+}
+
+// collectReferences appends every $ref value held by a node, and the nodes beneath it, to refs.
+func collectReferences(node *yaml.Node, refs []string) []string {
+	if node == nil {
+		return refs
+	}
+	if utils.IsNodeMap(node) {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" && utils.IsNodeStringValue(node.Content[i+1]) {
+				refs = append(refs, node.Content[i+1].Value)
+				continue
+			}
+			refs = collectReferences(node.Content[i+1], refs)
+		}
+		return refs
+	}
+	for _, n := range node.Content {
+		refs = collectReferences(n, refs)
+	}
+	return refs
+}
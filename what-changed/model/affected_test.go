@@ -0,0 +1,180 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapAffectedOperations(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pets:
+    parameters:
+      - $ref: '#/components/parameters/Trace'
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      responses:
+        "201":
+          description: created
+  /owners:
+    get:
+      responses:
+        "200":
+          description: owners
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Owner'
+components:
+  parameters:
+    Trace:
+      name: trace
+      in: header
+  schemas:
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        owner:
+          $ref: '#/components/schemas/Owner'`
+
+	right := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      responses:
+        "201":
+          description: created
+  /owners:
+    get:
+      responses:
+        "200":
+          description: owners
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Owner'
+components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+    Pet:
+      type: object
+      properties:
+        name:
+          type: integer
+        owner:
+          $ref: '#/components/schemas/Owner'`
+
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := v3.CreateDocumentFromConfig(siLeft, datamodel.NewDocumentConfiguration())
+	rDoc, _ := v3.CreateDocumentFromConfig(siRight, datamodel.NewDocumentConfiguration())
+
+	changes := CompareDocuments(lDoc, rDoc)
+	require.NotNil(t, changes)
+	MapAffectedOperations(changes, lDoc, rDoc)
+
+	affected := make(map[string][]string)
+	WalkChanges(changes.ComponentsChanges, func(location *ChangeLocation, change *Change) {
+		for _, op := range change.AffectedOperations {
+			affected[change.Property] = append(affected[change.Property], op.String())
+		}
+	})
+
+	// the Pet schema is used directly by GET /pets, and through the Owner schema by GET /owners.
+	assert.ElementsMatch(t, []string{"GET /pets", "GET /owners"}, affected[v3.TypeLabel])
+
+	// path level parameters are used by every operation on the path.
+	assert.ElementsMatch(t, []string{"GET /pets", "POST /pets"}, affected[v3.ParametersLabel])
+}
+
+func TestMapAffectedOperations_Swagger(t *testing.T) {
+	left := `swagger: "2.0"
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          schema:
+            $ref: '#/definitions/Pet'
+definitions:
+  Pet:
+    type: object`
+
+	right := `swagger: "2.0"
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          schema:
+            $ref: '#/definitions/Pet'
+definitions:
+  Pet:
+    type: string`
+
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := v2.CreateDocumentFromConfig(siLeft, datamodel.NewDocumentConfiguration())
+	rDoc, _ := v2.CreateDocumentFromConfig(siRight, datamodel.NewDocumentConfiguration())
+
+	changes := CompareDocuments(lDoc, rDoc)
+	require.NotNil(t, changes)
+	MapAffectedOperations(changes, lDoc, rDoc)
+
+	all := changes.ComponentsChanges.GetAllChanges()
+	require.Len(t, all, 1)
+	require.Len(t, all[0].AffectedOperations, 1)
+	assert.Equal(t, "GET /pets", all[0].AffectedOperations[0].String())
+
+	b, _ := all[0].MarshalJSON()
+	assert.Contains(t, string(b), `"affectedOperations":[{"path":"/pets","method":"get"}]`)
+}
+
+func TestMapAffectedOperations_NoChanges(t *testing.T) {
+	MapAffectedOperations(nil, nil, nil)
+	MapAffectedOperations(&DocumentChanges{}, nil, nil)
+}
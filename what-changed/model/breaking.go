@@ -0,0 +1,177 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"slices"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// BreakingRule classifies a kind of change as breaking, or not breaking. A rule applies to a change when the change
+// is held by one of the Objects, is for one of the Properties, is one of the ChangeTypes, and Match (if set) returns
+// true. An empty list matches anything.
+type BreakingRule struct {
+	// Id is a short, unique name for the rule, recorded on every change the rule classifies.
+	Id string `json:"id" yaml:"id"`
+
+	// Description explains what the rule looks for.
+	Description string `json:"description" yaml:"description"`
+
+	// Objects are the change models the rule applies to, without the 'Changes' suffix (like 'Schema').
+	Objects []string `json:"objects,omitempty" yaml:"objects,omitempty"`
+
+	// Properties are the property labels the rule applies to (like 'required').
+	Properties []string `json:"properties,omitempty" yaml:"properties,omitempty"`
+
+	// ChangeTypes are the types of change the rule applies to (like PropertyRemoved).
+	ChangeTypes []int `json:"changeTypes,omitempty" yaml:"changeTypes,omitempty"`
+
+	// Match is an optional check of the change itself, for when the object, property and type are not enough.
+	Match func(change *Change) bool `json:"-" yaml:"-"`
+
+	// Breaking is the classification given to the changes the rule applies to.
+	Breaking bool `json:"breaking" yaml:"breaking"`
+
+	// Severity is the severity given to the changes the rule applies to, like SeverityWarning. When set, it
+	// replaces Breaking, only changes given SeverityBreaking are breaking. If empty, the severity is
+	// SeverityBreaking for breaking rules and SeverityInfo for the rest.
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+}
+
+// Matches returns true if the rule applies to a change, held by the supplied object.
+func (r *BreakingRule) Matches(object string, change *Change) bool {
+	if len(r.Objects) > 0 && !slices.Contains(r.Objects, object) {
+		return false
+	}
+	if len(r.Properties) > 0 && !slices.Contains(r.Properties, change.Property) {
+		return false
+	}
+	if len(r.ChangeTypes) > 0 && !slices.Contains(r.ChangeTypes, change.ChangeType) {
+		return false
+	}
+	return r.Match == nil || r.Match(change)
+}
+
+var operationLabels = []string{
+	v3.GetLabel, v3.PutLabel, v3.PostLabel, v3.DeleteLabel, v3.OptionsLabel, v3.HeadLabel, v3.PatchLabel,
+	v3.TraceLabel,
+}
+
+func becameTrue(change *Change) bool {
+	return change.New == "true"
+}
+
+// BreakingRules is the default set of rules used to classify changes found when comparing documents. Changes that
+// no rule applies to, keep the classification made by the comparison that found them.
+var BreakingRules = []*BreakingRule{
+	{
+		Id:          "path-removed",
+		Description: "a path was removed, every operation it held is no longer available",
+		Objects:     []string{"Paths"},
+		Properties:  []string{v3.PathLabel},
+		ChangeTypes: []int{ObjectRemoved, PropertyRemoved},
+		Breaking:    true,
+	},
+	{
+		Id:          "operation-removed",
+		Description: "an operation was removed from a path",
+		Objects:     []string{"PathItem"},
+		Properties:  operationLabels,
+		ChangeTypes: []int{ObjectRemoved, PropertyRemoved},
+		Breaking:    true,
+	},
+	{
+		Id:          "response-removed",
+		Description: "a response was removed from an operation",
+		Objects:     []string{"Responses"},
+		Properties:  []string{v3.CodesLabel, v3.DefaultLabel},
+		ChangeTypes: []int{ObjectRemoved, PropertyRemoved},
+		Breaking:    true,
+	},
+	{
+		Id:          "required-property-added",
+		Description: "a schema property became required",
+		Objects:     []string{"Schema"},
+		Properties:  []string{v3.RequiredLabel},
+		ChangeTypes: []int{PropertyAdded},
+		Breaking:    true,
+	},
+	{
+		Id:          "property-removed",
+		Description: "a property was removed from a schema",
+		Objects:     []string{"Schema"},
+		Properties:  []string{v3.PropertiesLabel},
+		ChangeTypes: []int{ObjectRemoved, PropertyRemoved},
+		Breaking:    true,
+	},
+	{
+		Id:          "enum-narrowed",
+		Description: "a value was removed from an enum",
+		Objects:     []string{"Schema", "Parameter", "Header", "Items"},
+		Properties:  []string{v3.EnumLabel},
+		ChangeTypes: []int{PropertyRemoved},
+		Breaking:    true,
+	},
+	{
+		Id:          "type-changed",
+		Description: "the type of a value changed",
+		Objects:     []string{"Schema", "Parameter", "Header", "Items"},
+		Properties:  []string{v3.TypeLabel},
+		ChangeTypes: []int{Modified},
+		Breaking:    true,
+	},
+	{
+		Id:          "parameter-required",
+		Description: "a parameter, header or request body became required",
+		Objects:     []string{"Parameter", "Header", "RequestBody"},
+		Properties:  []string{v3.RequiredLabel},
+		ChangeTypes: []int{Modified, PropertyAdded},
+		Match:       becameTrue,
+		Breaking:    true,
+	},
+	{
+		Id:          "parameter-removed",
+		Description: "a parameter was removed from an operation or path",
+		Objects:     []string{"Operation", "PathItem"},
+		Properties:  []string{v3.ParametersLabel},
+		ChangeTypes: []int{ObjectRemoved, PropertyRemoved},
+		Breaking:    true,
+	},
+	{
+		Id:          "security-scheme-removed",
+		Description: "a security scheme was removed",
+		Objects:     []string{"Components"},
+		Properties:  []string{v3.SecuritySchemesLabel, v3.SecurityDefinitionLabel},
+		ChangeTypes: []int{ObjectRemoved, PropertyRemoved},
+		Breaking:    true,
+	},
+}
+
+// ClassifyChange returns the first of the supplied rules that applies to a change held by the supplied object
+// (see ChangeLocation.Object). If rules is nil, BreakingRules is used. If no rule applies, nil is returned.
+func ClassifyChange(object string, change *Change, rules []*BreakingRule) *BreakingRule {
+	if rules == nil {
+		rules = BreakingRules
+	}
+	for _, rule := range rules {
+		if rule.Matches(object, change) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// ApplyBreakingRules will classify every change held by a change model (like *DocumentChanges), and every change
+// model beneath it. When a rule applies to a change, the change is marked as breaking (or not) and the rule Id is
+// recorded on the change. Changes that no rule applies to keep their classification. If rules is nil,
+// BreakingRules is used. See ApplyPolicy for more control.
+func ApplyBreakingRules(changes any, rules []*BreakingRule) {
+	ApplyPolicy(changes, &Policy{Rules: rules})
+}
+
+// GetSeverity returns the severity the rule gives to changes.
+func (r *BreakingRule) GetSeverity() string {
+	return severityOf(r.Severity, r.Breaking)
+}
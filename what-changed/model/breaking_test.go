@@ -0,0 +1,139 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compareV3Documents(t *testing.T, left, right string) *DocumentChanges {
+	siLeft, err := datamodel.ExtractSpecInfo([]byte(left))
+	require.NoError(t, err)
+	siRight, err := datamodel.ExtractSpecInfo([]byte(right))
+	require.NoError(t, err)
+
+	lDoc, _ := v3.CreateDocumentFromConfig(siLeft, datamodel.NewDocumentConfiguration())
+	rDoc, _ := v3.CreateDocumentFromConfig(siRight, datamodel.NewDocumentConfiguration())
+	return CompareDocuments(lDoc, rDoc)
+}
+
+const breakingLeft = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+                  age:
+                    type: integer
+    delete:
+      responses:
+        "204":
+          description: gone
+  /owners:
+    get:
+      responses:
+        "200":
+          description: owners`
+
+const breakingRight = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          required: true
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  name:
+                    type: string`
+
+func TestCompareDocuments_BreakingRules(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	require.NotNil(t, changes)
+
+	rules := make(map[string]*Change)
+	WalkChanges(changes, func(location *ChangeLocation, change *Change) {
+		if change.Rule != "" {
+			rules[change.Rule] = change
+		}
+	})
+	for _, id := range []string{"path-removed", "operation-removed", "required-property-added",
+		"property-removed", "parameter-required"} {
+		require.Contains(t, rules, id)
+		assert.True(t, rules[id].Breaking, id)
+	}
+	assert.Equal(t, "/owners", rules["path-removed"].Original)
+	assert.Equal(t, 5, changes.TotalBreakingChanges())
+}
+
+func TestApplyBreakingRules_Custom(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	require.NotNil(t, changes)
+
+	// removing an operation is fine by us.
+	rules := append([]*BreakingRule{{
+		Id:          "operation-removed-ok",
+		Objects:     []string{"PathItem"},
+		Properties:  []string{v3.DeleteLabel},
+		ChangeTypes: []int{PropertyRemoved},
+	}}, BreakingRules...)
+	ApplyBreakingRules(changes, rules)
+	assert.Equal(t, 4, changes.TotalBreakingChanges())
+
+	var found *Change
+	WalkChanges(changes, func(location *ChangeLocation, change *Change) {
+		if change.Rule == "operation-removed-ok" {
+			found = change
+		}
+	})
+	require.NotNil(t, found)
+	assert.False(t, found.Breaking)
+}
+
+func TestClassifyChange(t *testing.T) {
+	change := &Change{Property: v3.RequiredLabel, ChangeType: Modified, New: "false"}
+	assert.Nil(t, ClassifyChange("Parameter", change, nil))
+
+	change.New = "true"
+	rule := ClassifyChange("Parameter", change, nil)
+	require.NotNil(t, rule)
+	assert.Equal(t, "parameter-required", rule.Id)
+	assert.Nil(t, ClassifyChange("Schema", change, nil))
+	assert.Nil(t, ClassifyChange("Parameter", change, []*BreakingRule{}))
+}
+
+func TestChange_MarshalJSON_Rule(t *testing.T) {
+	change := &Change{Property: v3.PathLabel, ChangeType: PropertyRemoved, Original: "/pets", Breaking: true}
+	b, _ := json.Marshal(change)
+	assert.NotContains(t, string(b), `"rule"`)
+
+	change.Rule = "path-removed"
+	b, _ = json.Marshal(change)
+	assert.Contains(t, string(b), `"rule":"path-removed"`)
+}
@@ -65,12 +65,12 @@ func CompareCallback(l, r *v3.Callback) *CallbackChanges {
 	rValues := make(map[string]low.ValueReference[*v3.PathItem])
 
 	for k, v := range l.Expression.FromOldest() {
-		lHashes[k.Value] = low.GenerateHashString(v.Value)
+		lHashes[k.Value] = hashString(v.Value)
 		lValues[k.Value] = v
 	}
 
 	for k, v := range r.Expression.FromOldest() {
-		rHashes[k.Value] = low.GenerateHashString(v.Value)
+		rHashes[k.Value] = hashString(v.Value)
 		rValues[k.Value] = v
 	}
 
@@ -86,6 +86,16 @@ type Change struct {
 	// Breaking determines if the change is a breaking one or not.
 	Breaking bool `json:"breaking" yaml:"breaking"`
 
+	// Rule is the Id of the BreakingRule that classified the change, if one applied to it.
+	Rule string `json:"rule,omitempty" yaml:"rule,omitempty"`
+
+	// Severity is how severe the change is, like SeverityWarning. Set when the change is classified by a Policy.
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	// AffectedOperations are the operations that use the component that changed, if the change was made to
+	// a component. Only set by MapAffectedOperations.
+	AffectedOperations []*AffectedOperation `json:"affectedOperations,omitempty" yaml:"affectedOperations,omitempty"`
+
 	// OriginalObject represents the original object that was changed.
 	OriginalObject any `json:"-" yaml:"-"`
 
@@ -95,31 +105,43 @@ type Change struct {
 
 // MarshalJSON is a custom JSON marshaller for the Change object.
 func (c *Change) MarshalJSON() ([]byte, error) {
-	changeType := ""
-	switch c.ChangeType {
-	case Modified:
-		changeType = "modified"
-	case PropertyAdded:
-		changeType = "property_added"
-	case ObjectAdded:
-		changeType = "object_added"
-	case ObjectRemoved:
-		changeType = "object_removed"
-	case PropertyRemoved:
-		changeType = "property_removed"
-	}
 	data := map[string]interface{}{
 		"change":     c.ChangeType,
-		"changeText": changeType,
+		"changeText": changeTypeText(c.ChangeType),
 		"context":    c.Context,
 		"property":   c.Property,
 		"original":   c.Original,
 		"new":        c.New,
 		"breaking":   c.Breaking,
 	}
+	if c.Rule != "" {
+		data["rule"] = c.Rule
+	}
+	if c.Severity != "" {
+		data["severity"] = c.Severity
+	}
+	if len(c.AffectedOperations) > 0 {
+		data["affectedOperations"] = c.AffectedOperations
+	}
 	return json.Marshal(data)
 }
 
+func changeTypeText(changeType int) string {
+	switch changeType {
+	case Modified:
+		return "modified"
+	case PropertyAdded:
+		return "property_added"
+	case ObjectAdded:
+		return "object_added"
+	case ObjectRemoved:
+		return "object_removed"
+	case PropertyRemoved:
+		return "property_removed"
+	}
+	return ""
+}
+
 // PropertyChanges holds a slice of Change pointers
 type PropertyChanges struct {
 	//Total *int `json:"total,omitempty" yaml:"total,omitempty"`
@@ -0,0 +1,285 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// Groups of changes in a Changelog, by what happened to the value that changed.
+const (
+	ChangelogAdded    = "added"
+	ChangelogRemoved  = "removed"
+	ChangelogModified = "modified"
+)
+
+// Changelog is a consumer-facing view of DocumentChanges, grouped by tag, then by operation, then by the type of
+// change. It is built for release notes, so changes are described by operation instead of by document location.
+type Changelog struct {
+	TotalChanges    int `json:"totalChanges" yaml:"totalChanges"`
+	BreakingChanges int `json:"breakingChanges" yaml:"breakingChanges"`
+
+	// Tags hold the operations that changed, by tag. An operation with more than one tag is listed under each tag,
+	// operations without tags are listed under a tag with no name, which is always last.
+	Tags []*ChangelogTag `json:"tags" yaml:"tags"`
+
+	// Other holds the changes that are not made to an operation, like changes to info, servers or components
+	// that are not used by any operation.
+	Other []*ChangelogGroup `json:"other,omitempty" yaml:"other,omitempty"`
+}
+
+// ChangelogTag holds the operations that changed, that use a tag.
+type ChangelogTag struct {
+	Name       string                `json:"name" yaml:"name"`
+	Operations []*ChangelogOperation `json:"operations" yaml:"operations"`
+}
+
+// ChangelogOperation holds the changes made to a single operation. When Method is empty, the changes were made to
+// the whole path (like a path that was added, or a parameter shared by every operation of the path).
+type ChangelogOperation struct {
+	Path            string            `json:"path" yaml:"path"`
+	Method          string            `json:"method,omitempty" yaml:"method,omitempty"`
+	BreakingChanges int               `json:"breakingChanges" yaml:"breakingChanges"`
+	Groups          []*ChangelogGroup `json:"groups" yaml:"groups"`
+}
+
+// String returns the method and path of the operation, like 'GET /pets', or only the path if there is no method.
+func (o *ChangelogOperation) String() string {
+	if o.Method == "" {
+		return o.Path
+	}
+	return strings.ToUpper(o.Method) + " " + o.Path
+}
+
+// ChangelogGroup holds changes of the same type, like ChangelogAdded. Breaking changes are listed first.
+type ChangelogGroup struct {
+	Type    string         `json:"type" yaml:"type"`
+	Changes []*ReportEntry `json:"changes" yaml:"changes"`
+}
+
+// Changelog groups every change held by DocumentChanges by tag, operation and type of change. The original and
+// updated documents must be the same low-level *v3.Document or *v2.Swagger documents that were compared to create
+// the changes, they are used to look up the tags of operations.
+//
+// Changes made to components are listed under the operations that use them, if MapAffectedOperations has been
+// used, otherwise they are listed under Other.
+func (d *DocumentChanges) Changelog(original, updated any) *Changelog {
+	log := &Changelog{Tags: []*ChangelogTag{}}
+	if d == nil {
+		return log
+	}
+	tags := make(map[string]map[string][]string)
+	for _, doc := range []any{original, updated} {
+		if idx, _ := documentIndex(doc); idx != nil {
+			collectOperationTags(idx.GetRootNode(), tags)
+		}
+	}
+
+	operations := make(map[string]*ChangelogOperation)
+	var other []*ReportEntry
+	add := func(p, method string, entry *ReportEntry) {
+		key := method + " " + p
+		op := operations[key]
+		if op == nil {
+			op = &ChangelogOperation{Path: p, Method: method}
+			operations[key] = op
+		}
+		if entry.Breaking {
+			op.BreakingChanges++
+		}
+		op.Groups = appendChangelogEntry(op.Groups, entry)
+	}
+
+	report := d.Report()
+	log.TotalChanges, log.BreakingChanges = report.TotalChanges, report.BreakingChanges
+	i := 0
+	WalkChanges(d, func(location *ChangeLocation, change *Change) {
+		entry := report.Changes[i]
+		i++
+		p, method, ok := changelogOperation(location, change)
+		switch {
+		case ok:
+			add(p, method, entry)
+		case len(change.AffectedOperations) > 0:
+			for _, op := range change.AffectedOperations {
+				add(op.Path, op.Method, entry)
+			}
+		default:
+			other = append(other, entry)
+		}
+	})
+	for _, e := range other {
+		log.Other = appendChangelogEntry(log.Other, e)
+	}
+	sortChangelogGroups(log.Other)
+
+	byTag := make(map[string]*ChangelogTag)
+	for _, op := range operations {
+		sortChangelogGroups(op.Groups)
+		var opTags []string
+		if op.Method == "" {
+			for _, t := range tags[op.Path] {
+				opTags = append(opTags, t...)
+			}
+		} else {
+			opTags = tags[op.Path][op.Method]
+		}
+		if len(opTags) == 0 {
+			opTags = []string{""}
+		}
+		for _, t := range opTags {
+			tag := byTag[t]
+			if tag == nil {
+				tag = &ChangelogTag{Name: t}
+				byTag[t] = tag
+				log.Tags = append(log.Tags, tag)
+			}
+			if !slices.Contains(tag.Operations, op) {
+				tag.Operations = append(tag.Operations, op)
+			}
+		}
+	}
+	sort.Slice(log.Tags, func(a, b int) bool {
+		l, r := log.Tags[a].Name, log.Tags[b].Name
+		if (l == "") != (r == "") {
+			return r == ""
+		}
+		return l < r
+	})
+	for _, tag := range log.Tags {
+		sort.Slice(tag.Operations, func(a, b int) bool {
+			l, r := tag.Operations[a], tag.Operations[b]
+			if l.Path != r.Path {
+				return l.Path < r.Path
+			}
+			return methodOrder(l.Method) < methodOrder(r.Method)
+		})
+	}
+	return log
+}
+
+// RenderChangelogJSON will render the Changelog of DocumentChanges as indented JSON. See Changelog.
+func (d *DocumentChanges) RenderChangelogJSON(original, updated any) ([]byte, error) {
+	return json.MarshalIndent(d.Changelog(original, updated), "", "  ")
+}
+
+// RenderChangelogMarkdown will render the Changelog of DocumentChanges as Markdown, ready to be used as release
+// notes. Breaking changes are listed first in every group, and are highlighted. See Changelog.
+func (d *DocumentChanges) RenderChangelogMarkdown(original, updated any) []byte {
+	log := d.Changelog(original, updated)
+	var b strings.Builder
+	b.WriteString("# Changelog\n\n")
+	if log.TotalChanges == 0 {
+		b.WriteString("No changes found.\n")
+		return []byte(b.String())
+	}
+	fmt.Fprintf(&b, "**%d** changes, **%d** breaking.\n", log.TotalChanges, log.BreakingChanges)
+
+	for _, tag := range log.Tags {
+		name := tag.Name
+		if name == "" {
+			name = "Untagged"
+		}
+		fmt.Fprintf(&b, "\n## %s\n", name)
+		for _, op := range tag.Operations {
+			fmt.Fprintf(&b, "\n### %s", markdownCode(op.String()))
+			if op.BreakingChanges > 0 {
+				fmt.Fprintf(&b, " (%d breaking)", op.BreakingChanges)
+			}
+			b.WriteString("\n")
+			writeChangelogGroups(&b, op.Groups)
+		}
+	}
+	if len(log.Other) > 0 {
+		b.WriteString("\n## Other Changes\n")
+		writeChangelogGroups(&b, log.Other)
+	}
+	return []byte(b.String())
+}
+
+func writeChangelogGroups(b *strings.Builder, groups []*ChangelogGroup) {
+	for _, g := range groups {
+		fmt.Fprintf(b, "\n#### %s\n\n", strings.ToUpper(g.Type[:1])+g.Type[1:])
+		for _, e := range g.Changes {
+			b.WriteString("- ")
+			if e.Breaking {
+				b.WriteString("**Breaking:** ")
+			}
+			b.WriteString(e.Describe())
+			b.WriteString("\n")
+		}
+	}
+}
+
+// changelogOperation returns the path and method of the operation a change was made to. The method is empty when
+// the change was made to the whole path.
+func changelogOperation(location *ChangeLocation, change *Change) (string, string, bool) {
+	path := location.Path
+	if len(path) == 0 || path[0] != v3.PathsLabel {
+		return "", "", false
+	}
+	if len(path) == 1 {
+		if change.Property != v3.PathLabel {
+			return "", "", false
+		}
+		if change.New != "" {
+			return change.New, "", true
+		}
+		return change.Original, "", true
+	}
+	if len(path) < 3 {
+		return "", "", false
+	}
+	if len(path) > 3 && slices.Contains(operationLabels, path[3]) {
+		return path[2], path[3], true
+	}
+	if len(path) == 3 && slices.Contains(operationLabels, change.Property) {
+		return path[2], change.Property, true
+	}
+	return path[2], "", true
+}
+
+func appendChangelogEntry(groups []*ChangelogGroup, entry *ReportEntry) []*ChangelogGroup {
+	kind := ChangelogModified
+	switch entry.ChangeType {
+	case PropertyAdded, ObjectAdded:
+		kind = ChangelogAdded
+	case PropertyRemoved, ObjectRemoved:
+		kind = ChangelogRemoved
+	}
+	for _, g := range groups {
+		if g.Type == kind {
+			g.Changes = append(g.Changes, entry)
+			return groups
+		}
+	}
+	return append(groups, &ChangelogGroup{Type: kind, Changes: []*ReportEntry{entry}})
+}
+
+// sortChangelogGroups orders groups as added, modified, removed, and lists breaking changes first in each group.
+func sortChangelogGroups(groups []*ChangelogGroup) {
+	order := []string{ChangelogAdded, ChangelogModified, ChangelogRemoved}
+	sort.Slice(groups, func(a, b int) bool {
+		return slices.Index(order, groups[a].Type) < slices.Index(order, groups[b].Type)
+	})
+	for _, g := range groups {
+		sort.SliceStable(g.Changes, func(a, b int) bool {
+			return g.Changes[a].Breaking && !g.Changes[b].Breaking
+		})
+	}
+}
+
+// methodOrder returns the position of a method in operationLabels, the whole path (no method) is first.
+func methodOrder(method string) int {
+	if method == "" {
+		return -1
+	}
+	return slices.Index(operationLabels, method)
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const changelogLeft = `openapi: 3.1.0
+info:
+  title: pets
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+      responses:
+        "200":
+          description: pets
+    delete:
+      tags: [pets, admin]
+      responses:
+        "204":
+          description: gone
+  /owners:
+    get:
+      responses:
+        "200":
+          description: owners`
+
+const changelogRight = `openapi: 3.1.0
+info:
+  title: all the pets
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+          required: true
+      responses:
+        "200":
+          description: pets
+    post:
+      tags: [pets]
+      responses:
+        "201":
+          description: created
+  /owners:
+    get:
+      responses:
+        "200":
+          description: owners
+        "404":
+          description: no owner`
+
+func compareChangelogDocuments(t *testing.T) (*DocumentChanges, *v3.Document, *v3.Document) {
+	siLeft, err := datamodel.ExtractSpecInfo([]byte(changelogLeft))
+	require.NoError(t, err)
+	siRight, err := datamodel.ExtractSpecInfo([]byte(changelogRight))
+	require.NoError(t, err)
+
+	lDoc, _ := v3.CreateDocumentFromConfig(siLeft, datamodel.NewDocumentConfiguration())
+	rDoc, _ := v3.CreateDocumentFromConfig(siRight, datamodel.NewDocumentConfiguration())
+	changes := CompareDocuments(lDoc, rDoc)
+	require.NotNil(t, changes)
+	return changes, lDoc, rDoc
+}
+
+func TestDocumentChanges_Changelog(t *testing.T) {
+	changes, l, r := compareChangelogDocuments(t)
+	log := changes.Changelog(l, r)
+	assert.Equal(t, changes.TotalChanges(), log.TotalChanges)
+	assert.Equal(t, changes.TotalBreakingChanges(), log.BreakingChanges)
+
+	require.Len(t, log.Tags, 3)
+	assert.Equal(t, "admin", log.Tags[0].Name)
+	assert.Equal(t, "pets", log.Tags[1].Name)
+	assert.Equal(t, "", log.Tags[2].Name)
+
+	require.Len(t, log.Tags[0].Operations, 1)
+	assert.Equal(t, "DELETE /pets", log.Tags[0].Operations[0].String())
+	assert.Equal(t, 1, log.Tags[0].Operations[0].BreakingChanges)
+	assert.Equal(t, ChangelogRemoved, log.Tags[0].Operations[0].Groups[0].Type)
+
+	var ops []string
+	for _, op := range log.Tags[1].Operations {
+		ops = append(ops, op.String())
+	}
+	assert.Equal(t, []string{"GET /pets", "POST /pets", "DELETE /pets"}, ops)
+	assert.Equal(t, ChangelogAdded, log.Tags[1].Operations[1].Groups[0].Type)
+
+	require.Len(t, log.Tags[2].Operations, 1)
+	assert.Equal(t, "GET /owners", log.Tags[2].Operations[0].String())
+
+	require.Len(t, log.Other, 1)
+	assert.Equal(t, ChangelogModified, log.Other[0].Type)
+	assert.Equal(t, "title", log.Other[0].Changes[0].Property)
+}
+
+func TestDocumentChanges_RenderChangelogMarkdown(t *testing.T) {
+	changes, l, r := compareChangelogDocuments(t)
+	md := string(changes.RenderChangelogMarkdown(l, r))
+	assert.Contains(t, md, "# Changelog")
+	assert.Contains(t, md, "## pets")
+	assert.Contains(t, md, "## Untagged")
+	assert.Contains(t, md, "### `DELETE /pets` (1 breaking)")
+	assert.Contains(t, md, "- **Breaking:** `delete` was removed")
+	assert.Contains(t, md, "## Other Changes")
+
+	var empty *DocumentChanges
+	assert.Contains(t, string(empty.RenderChangelogMarkdown(nil, nil)), "No changes found.")
+}
+
+func TestDocumentChanges_RenderChangelogJSON(t *testing.T) {
+	changes, l, r := compareChangelogDocuments(t)
+	b, err := changes.RenderChangelogJSON(l, r)
+	require.NoError(t, err)
+
+	var log Changelog
+	require.NoError(t, json.Unmarshal(b, &log))
+	assert.Len(t, log.Tags, 3)
+	assert.Equal(t, changes.TotalChanges(), log.TotalChanges)
+}
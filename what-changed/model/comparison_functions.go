@@ -4,7 +4,6 @@
 package model
 
 import (
-	"fmt"
 	"reflect"
 	"strings"
 	"sync"
@@ -216,10 +215,7 @@ func CheckForModification[T any](l, r *yaml.Node, label string, changes *[]*Chan
 
 		// there is no way to know how to compare the content of the array, without
 		// rendering the yaml.Node to a string and comparing the string.
-		leftBytes, _ := yaml.Marshal(l)
-		rightBytes, _ := yaml.Marshal(r)
-
-		if string(leftBytes) != string(rightBytes) {
+		if renderedYAML(l) != renderedYAML(r) {
 			CreateChange(changes, Modified, label, l, r, breaking, orig, new)
 		}
 		return
@@ -227,10 +223,7 @@ func CheckForModification[T any](l, r *yaml.Node, label string, changes *[]*Chan
 	if l != nil && utils.IsNodeMap(l) && r != nil && utils.IsNodeMap(r) {
 		// there is no way to know how to compare the content of the map, without
 		// rendering the yaml.Node to a string and comparing the string.
-		leftBytes, _ := yaml.Marshal(l)
-		rightBytes, _ := yaml.Marshal(r)
-
-		if string(leftBytes) != string(rightBytes) {
+		if renderedYAML(l) != renderedYAML(r) {
 			CreateChange(changes, Modified, label, l, r, breaking, orig, new)
 		}
 		return
@@ -265,6 +258,14 @@ func CheckMapForAdditionRemoval[T any](expLeft, expRight *orderedmap.Map[low.Key
 // bit determines if the comparison should be run or not.
 func CheckMapForChangesWithComp[T any, R any](expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
 	changes *[]*Change, label string, compareFunc func(l, r T) R, compare bool,
+) map[string]R {
+	return checkMapForChanges(expLeft, expRight, changes, label, compareFunc, compare, nil)
+}
+
+// checkMapForChanges is CheckMapForChangesWithComp, values are compared with the workers of the comparison, or one
+// at a time if workers is nil.
+func checkMapForChanges[T any, R any](expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+	changes *[]*Change, label string, compareFunc func(l, r T) R, compare bool, workers *comparisonWorkers,
 ) map[string]R {
 	// stop concurrent threads screwing up changes.
 	var chLock sync.Mutex
@@ -275,18 +276,18 @@ func CheckMapForChangesWithComp[T any, R any](expLeft, expRight *orderedmap.Map[
 	rValues := make(map[string]low.ValueReference[T])
 
 	for k, v := range expLeft.FromOldest() {
-		lHashes[k.Value] = low.GenerateHashString(v.Value)
+		lHashes[k.Value] = hashString(v.Value)
 		lValues[k.Value] = v
 	}
 
 	for k, v := range expRight.FromOldest() {
-		rHashes[k.Value] = low.GenerateHashString(v.Value)
+		rHashes[k.Value] = hashString(v.Value)
 		rValues[k.Value] = v
 	}
 
 	expChanges := make(map[string]R)
 
-	checkLeft := func(k string, doneChan chan bool, f, g map[string]string, p, h map[string]low.ValueReference[T]) {
+	checkLeft := func(k string, f, g map[string]string, p, h map[string]low.ValueReference[T]) {
 		rhash := g[k]
 		if rhash == "" {
 			chLock.Lock()
@@ -297,53 +298,47 @@ func CheckMapForChangesWithComp[T any, R any](expLeft, expRight *orderedmap.Map[
 				p[k].GetValueNode(), nil, true,
 				p[k].GetValue(), nil)
 			chLock.Unlock()
-			doneChan <- true
 			return
 		}
 		if f[k] == g[k] {
-			doneChan <- true
 			return
 		}
-		// run comparison.
+		// run comparison. The comparison itself does not need to hold the lock, it only reads the values of this
+		// map (the same as the comparisons of every other map running at the same time), and it collects its own
+		// changes. Only writing the results does, which is what caused the panics of
+		// https://github.com/pb33f/libopenapi/issues/61
 		if compare {
-			chLock.Lock()
 			ch := compareFunc(p[k].Value, h[k].Value)
-			// incorrect map results were being generated causing panics.
-			// https://github.com/pb33f/libopenapi/issues/61
 			if !reflect.ValueOf(&ch).Elem().IsZero() {
+				chLock.Lock()
 				expChanges[k] = ch
+				chLock.Unlock()
 			}
-			chLock.Unlock()
 		}
-		doneChan <- true
 	}
 
-	doneChan := make(chan bool)
-	count := 0
+	var wg sync.WaitGroup
 
 	// check left example hashes
 	for k := range lHashes {
-		count++
-		go checkLeft(k, doneChan, lHashes, rHashes, lValues, rValues)
+		if workers == nil {
+			checkLeft(k, lHashes, rHashes, lValues, rValues)
+			continue
+		}
+		workers.run(&wg, func() { checkLeft(k, lHashes, rHashes, lValues, rValues) })
 	}
 
 	// check right example hashes
 	for k := range rHashes {
-		count++
-		go checkRightValue(k, doneChan, lHashes, rValues, changes, label, &chLock)
+		checkRightValue(k, lHashes, rValues, changes, label, &chLock)
 	}
 
-	// wait for all done signals.
-	completed := 0
-	for completed < count {
-		<-doneChan
-		completed++
-
-	}
+	// wait for all comparisons to complete.
+	wg.Wait()
 	return expChanges
 }
 
-func checkRightValue[T any](k string, doneChan chan bool, f map[string]string, p map[string]low.ValueReference[T],
+func checkRightValue[T any](k string, f map[string]string, p map[string]low.ValueReference[T],
 	changes *[]*Change, label string, lock *sync.Mutex,
 ) {
 	lhash := f[k]
@@ -357,7 +352,6 @@ func checkRightValue[T any](k string, doneChan chan bool, f map[string]string, p
 			nil, p[k].GetValue())
 		lock.Unlock()
 	}
-	doneChan <- true
 }
 
 // ExtractStringValueSliceChanges will compare two low level string slices for changes.
@@ -398,16 +392,6 @@ func ExtractStringValueSliceChanges(lParam, rParam []low.ValueReference[string],
 	}
 }
 
-func toString(v any) string {
-	if y, ok := v.(*yaml.Node); ok {
-		copy := *y
-		_ = copy.Encode(&copy)
-		return fmt.Sprint(copy)
-	}
-
-	return fmt.Sprint(v)
-}
-
 // ExtractRawValueSliceChanges will compare two low level interface{} slices for changes.
 func ExtractRawValueSliceChanges[T any](lParam, rParam []low.ValueReference[T],
 	changes *[]*Change, label string, breaking bool,
@@ -46,6 +46,12 @@ type ComponentsChanges struct {
 // CompareComponents will compare OpenAPI components for any changes. Accepts Swagger Definition objects
 // like ParameterDefinitions or Definitions etc.
 func CompareComponents(l, r any) *ComponentsChanges {
+	return compareComponents(l, r, newComparisonWorkers(0))
+}
+
+// compareComponents compares two Components (or Swagger definitions) objects, comparing schemas and security schemes
+// with the workers of the comparison.
+func compareComponents(l, r any, workers *comparisonWorkers) *ComponentsChanges {
 	var changes []*Change
 
 	cc := new(ComponentsChanges)
@@ -92,7 +98,7 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		if rDef != nil {
 			b = rDef.Schemas
 		}
-		cc.SchemaChanges = CheckMapForChanges(a, b, &changes, v2.DefinitionsLabel, CompareSchemas)
+		cc.SchemaChanges = checkMapForChanges(a, b, &changes, v2.DefinitionsLabel, CompareSchemas, true, workers)
 	}
 
 	// Swagger Security Definitions
@@ -107,8 +113,8 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		if rDef != nil {
 			b = rDef.Definitions
 		}
-		cc.SecuritySchemeChanges = CheckMapForChanges(a, b, &changes,
-			v3.SecurityDefinitionLabel, CompareSecuritySchemesV2)
+		cc.SecuritySchemeChanges = checkMapForChanges(a, b, &changes,
+			v3.SecurityDefinitionLabel, CompareSecuritySchemesV2, true, workers)
 	}
 
 	// OpenAPI Components
@@ -129,55 +135,55 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		if !lComponents.Schemas.IsEmpty() || !rComponents.Schemas.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Schemas.Value, rComponents.Schemas.Value,
-				&changes, v3.SchemasLabel, CompareSchemas, doneChan)
+				&changes, v3.SchemasLabel, CompareSchemas, workers, doneChan)
 		}
 
 		if !lComponents.Responses.IsEmpty() || !rComponents.Responses.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Responses.Value, rComponents.Responses.Value,
-				&changes, v3.ResponsesLabel, CompareResponseV3, doneChan)
+				&changes, v3.ResponsesLabel, CompareResponseV3, workers, doneChan)
 		}
 
 		if !lComponents.Parameters.IsEmpty() || !rComponents.Parameters.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Parameters.Value, rComponents.Parameters.Value,
-				&changes, v3.ParametersLabel, CompareParametersV3, doneChan)
+				&changes, v3.ParametersLabel, CompareParametersV3, workers, doneChan)
 		}
 
 		if !lComponents.Examples.IsEmpty() || !rComponents.Examples.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Examples.Value, rComponents.Examples.Value,
-				&changes, v3.ExamplesLabel, CompareExamples, doneChan)
+				&changes, v3.ExamplesLabel, CompareExamples, workers, doneChan)
 		}
 
 		if !lComponents.RequestBodies.IsEmpty() || !rComponents.RequestBodies.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.RequestBodies.Value, rComponents.RequestBodies.Value,
-				&changes, v3.RequestBodiesLabel, CompareRequestBodies, doneChan)
+				&changes, v3.RequestBodiesLabel, CompareRequestBodies, workers, doneChan)
 		}
 
 		if !lComponents.Headers.IsEmpty() || !rComponents.Headers.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Headers.Value, rComponents.Headers.Value,
-				&changes, v3.HeadersLabel, CompareHeadersV3, doneChan)
+				&changes, v3.HeadersLabel, CompareHeadersV3, workers, doneChan)
 		}
 
 		if !lComponents.SecuritySchemes.IsEmpty() || !rComponents.SecuritySchemes.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.SecuritySchemes.Value, rComponents.SecuritySchemes.Value,
-				&changes, v3.SecuritySchemesLabel, CompareSecuritySchemesV3, doneChan)
+				&changes, v3.SecuritySchemesLabel, CompareSecuritySchemesV3, workers, doneChan)
 		}
 
 		if !lComponents.Links.IsEmpty() || !rComponents.Links.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Links.Value, rComponents.Links.Value,
-				&changes, v3.LinksLabel, CompareLinks, doneChan)
+				&changes, v3.LinksLabel, CompareLinks, workers, doneChan)
 		}
 
 		if !lComponents.Callbacks.IsEmpty() || !rComponents.Callbacks.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Callbacks.Value, rComponents.Callbacks.Value,
-				&changes, v3.CallbacksLabel, CompareCallback, doneChan)
+				&changes, v3.CallbacksLabel, CompareCallback, workers, doneChan)
 		}
 
 		cc.ExtensionChanges = CompareExtensions(lComponents.Extensions, rComponents.Extensions)
@@ -213,13 +219,14 @@ type componentComparison struct {
 
 // run a generic comparison in a thread which in turn splits checks into further threads.
 func runComparison[T any, R any](l, r *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
-	changes *[]*Change, label string, compareFunc func(l, r T) R, doneChan chan componentComparison,
+	changes *[]*Change, label string, compareFunc func(l, r T) R, workers *comparisonWorkers,
+	doneChan chan componentComparison,
 ) {
 	// for schemas
 	if label == v3.SchemasLabel || label == v2.DefinitionsLabel || label == v3.SecuritySchemesLabel {
 		doneChan <- componentComparison{
 			prop:   label,
-			result: CheckMapForChanges(l, r, changes, label, compareFunc),
+			result: checkMapForChanges(l, r, changes, label, compareFunc, true, workers),
 		}
 		return
 	} else {
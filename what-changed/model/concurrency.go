@@ -0,0 +1,61 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// ComparisonOptions changes how documents are compared, see CompareDocumentsWithOptions.
+type ComparisonOptions struct {
+	// Workers is the number of comparisons (like path items, or components) that can run at the same time when
+	// comparing the documents. When every worker is busy, comparisons run on the goroutine that asked for them, so a
+	// limit of 1 compares everything serially. The default (0) is runtime.GOMAXPROCS.
+	Workers int
+
+	// HashCache keeps the hashes worked out when comparing the documents, for the next comparison of either of
+	// them. Without one, hashes are only kept for the comparison, see HashCache.
+	HashCache *HashCache
+}
+
+// comparisonWorkers limits the number of comparisons running at the same time, for a single comparison of
+// documents.
+type comparisonWorkers struct {
+	max    int64
+	active atomic.Int64
+}
+
+// newComparisonWorkers creates the workers of a comparison, workers of 0 (or less) uses runtime.GOMAXPROCS.
+func newComparisonWorkers(workers int) *comparisonWorkers {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &comparisonWorkers{max: int64(workers)}
+}
+
+// run runs a comparison on a new goroutine if a worker is free, otherwise it runs the comparison before returning.
+// Either way, wg is done once the comparison is complete. Running on the calling goroutine when all workers are
+// busy means nested comparisons can never deadlock waiting for a worker.
+func (w *comparisonWorkers) run(wg *sync.WaitGroup, compare func()) {
+	wg.Add(1)
+	if w.active.Add(1) <= w.max {
+		// the concurrency of the library as a whole is limited too, see utils.SetMaxConcurrency.
+		if utils.AcquireWorker() {
+			go func() {
+				defer wg.Done()
+				defer w.active.Add(-1)
+				defer utils.ReleaseWorker()
+				compare()
+			}()
+			return
+		}
+	}
+	w.active.Add(-1)
+	compare()
+	wg.Done()
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareDocumentsWithOptions_Workers(t *testing.T) {
+	siLeft, err := datamodel.ExtractSpecInfo([]byte(breakingLeft))
+	require.NoError(t, err)
+	siRight, err := datamodel.ExtractSpecInfo([]byte(breakingRight))
+	require.NoError(t, err)
+	lDoc, _ := v3.CreateDocumentFromConfig(siLeft, datamodel.NewDocumentConfiguration())
+	rDoc, _ := v3.CreateDocumentFromConfig(siRight, datamodel.NewDocumentConfiguration())
+
+	parallel := CompareDocumentsWithOptions(lDoc, rDoc, nil)
+	require.NotNil(t, parallel)
+
+	serial := CompareDocumentsWithOptions(lDoc, rDoc, &ComparisonOptions{Workers: 1})
+	require.NotNil(t, serial)
+	assert.Equal(t, parallel.TotalChanges(), serial.TotalChanges())
+	assert.Equal(t, parallel.TotalBreakingChanges(), serial.TotalBreakingChanges())
+}
+
+func TestComparisonWorkers_Bounded(t *testing.T) {
+	w := newComparisonWorkers(2)
+
+	var running, peak atomic.Int64
+	var wg sync.WaitGroup
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		w.run(&wg, func() {
+			running.Add(1)
+			started <- struct{}{}
+			<-release
+		})
+	}
+	<-started
+	<-started
+
+	// every worker is busy, so this runs before returning.
+	ran := false
+	w.run(&wg, func() {
+		ran = true
+		peak.Store(w.active.Load())
+	})
+	assert.True(t, ran)
+	assert.LessOrEqual(t, peak.Load(), int64(2))
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int64(2), running.Load())
+	assert.Zero(t, w.active.Load())
+}
+
+func TestComparisonWorkers_Independent(t *testing.T) {
+	busy := newComparisonWorkers(1)
+	var wg sync.WaitGroup
+	release := make(chan struct{})
+	busy.run(&wg, func() { <-release })
+
+	// a comparison with free workers is not limited by another comparison.
+	other := newComparisonWorkers(1)
+	assert.Zero(t, other.active.Load())
+	done := make(chan struct{})
+	other.run(&wg, func() { close(done) })
+	<-done
+
+	close(release)
+	wg.Wait()
+}
@@ -11,7 +11,8 @@ import (
 // DiscriminatorChanges represents changes made to a Discriminator OpenAPI object
 type DiscriminatorChanges struct {
 	*PropertyChanges
-	MappingChanges []*Change `json:"mappings,omitempty" yaml:"mappings,omitempty"`
+	MappingChanges   []*Change         `json:"mappings,omitempty" yaml:"mappings,omitempty"`
+	ExtensionChanges *ExtensionChanges `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 }
 
 // TotalChanges returns a count of everything changed within the Discriminator object
@@ -23,6 +24,9 @@ func (d *DiscriminatorChanges) TotalChanges() int {
 	if k := len(d.MappingChanges); k > 0 {
 		l += k
 	}
+	if d.ExtensionChanges != nil {
+		l += d.ExtensionChanges.TotalChanges()
+	}
 	return l
 }
 
@@ -33,6 +37,9 @@ func (c *DiscriminatorChanges) GetAllChanges() []*Change {
 	if c.MappingChanges != nil {
 		changes = append(changes, c.MappingChanges...)
 	}
+	if c.ExtensionChanges != nil {
+		changes = append(changes, c.ExtensionChanges.GetAllChanges()...)
+	}
 	return changes
 }
 
@@ -88,6 +95,7 @@ func CompareDiscriminator(l, r *base.Discriminator) *DiscriminatorChanges {
 
 	dc.PropertyChanges = NewPropertyChanges(changes)
 	dc.MappingChanges = mappingChanges
+	dc.ExtensionChanges = CheckExtensions(l, r)
 	if dc.TotalChanges() <= 0 {
 		return nil
 	}
@@ -272,3 +272,30 @@ func TestCompareDiscriminator_Identical(t *testing.T) {
 	extChanges := CompareDiscriminator(&lDoc, &rDoc)
 	assert.Nil(t, extChanges)
 }
+
+func TestCompareDiscriminator_ExtensionAdded(t *testing.T) {
+
+	left := `propertyName: chicken`
+
+	right := `propertyName: chicken
+x-nuggets: crispy`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc base.Discriminator
+	var rDoc base.Discriminator
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	lDoc.Extensions = low.ExtractExtensions(lNode.Content[0])
+	rDoc.Extensions = low.ExtractExtensions(rNode.Content[0])
+
+	// compare.
+	extChanges := CompareDiscriminator(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 1)
+	assert.Equal(t, ObjectAdded, extChanges.ExtensionChanges.Changes[0].ChangeType)
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+}
@@ -145,6 +145,33 @@ func (d *DocumentChanges) TotalBreakingChanges() int {
 // CompareDocuments will compare any two OpenAPI documents (either Swagger or OpenAPI) and return a pointer to
 // DocumentChanges that outlines everything that was found to have changed.
 func CompareDocuments(l, r any) *DocumentChanges {
+	return compareDocuments(l, r, true, nil)
+}
+
+// CompareDocumentsWithOptions is the same as CompareDocuments, except the comparison is governed by the options
+// supplied. A nil options is the same as CompareDocuments.
+func CompareDocumentsWithOptions(l, r any, options *ComparisonOptions) *DocumentChanges {
+	return compareDocuments(l, r, true, options)
+}
+
+// CompareDocumentsWithHashCache is the same as CompareDocuments, except the hashes of the documents are kept by the
+// cache supplied, and any already kept by it are used. Comparing a document again (or the next revision of a document,
+// with the document itself) with the same cache does not hash the document again. The documents must not be changed
+// until the cache is cleared, see HashCache.
+func CompareDocumentsWithHashCache(l, r any, cache *HashCache) *DocumentChanges {
+	return CompareDocumentsWithOptions(l, r, &ComparisonOptions{HashCache: cache})
+}
+
+// compareDocuments compares two documents, paths are only compared when withPaths is true.
+func compareDocuments(l, r any, withPaths bool, options *ComparisonOptions) *DocumentChanges {
+	if options == nil {
+		options = new(ComparisonOptions)
+	}
+	if options.HashCache != nil {
+		options.HashCache.hold(l, r)
+	}
+	defer freezeHashes(l, r)()
+	workers := newComparisonWorkers(options.Workers)
 	var changes []*Change
 	var props []*PropertyCheck
 
@@ -186,8 +213,8 @@ func CompareDocuments(l, r any) *DocumentChanges {
 		dc.TagChanges = CompareTags(lDoc.Tags.Value, rDoc.Tags.Value)
 
 		// paths
-		if !lDoc.Paths.IsEmpty() || !rDoc.Paths.IsEmpty() {
-			dc.PathsChanges = ComparePaths(lDoc.Paths.Value, rDoc.Paths.Value)
+		if withPaths && (!lDoc.Paths.IsEmpty() || !rDoc.Paths.IsEmpty()) {
+			dc.PathsChanges = comparePaths(lDoc.Paths.Value, rDoc.Paths.Value, workers)
 		}
 
 		// external docs
@@ -207,16 +234,16 @@ func CompareDocuments(l, r any) *DocumentChanges {
 		// creating a new set of changes and then morphing them into a single changes object.
 		cc := new(ComponentsChanges)
 		cc.PropertyChanges = new(PropertyChanges)
-		if n := CompareComponents(lDoc.Definitions.Value, rDoc.Definitions.Value); n != nil {
+		if n := compareComponents(lDoc.Definitions.Value, rDoc.Definitions.Value, workers); n != nil {
 			cc.SchemaChanges = n.SchemaChanges
 		}
-		if n := CompareComponents(lDoc.SecurityDefinitions.Value, rDoc.SecurityDefinitions.Value); n != nil {
+		if n := compareComponents(lDoc.SecurityDefinitions.Value, rDoc.SecurityDefinitions.Value, workers); n != nil {
 			cc.SecuritySchemeChanges = n.SecuritySchemeChanges
 		}
-		if n := CompareComponents(lDoc.Parameters.Value, rDoc.Parameters.Value); n != nil {
+		if n := compareComponents(lDoc.Parameters.Value, rDoc.Parameters.Value, workers); n != nil {
 			cc.PropertyChanges.Changes = append(cc.PropertyChanges.Changes, n.Changes...)
 		}
-		if n := CompareComponents(lDoc.Responses.Value, rDoc.Responses.Value); n != nil {
+		if n := compareComponents(lDoc.Responses.Value, rDoc.Responses.Value, workers); n != nil {
 			cc.Changes = append(cc.Changes, n.Changes...)
 		}
 		dc.ExtensionChanges = CompareExtensions(lDoc.Extensions, rDoc.Extensions)
@@ -241,8 +268,8 @@ func CompareDocuments(l, r any) *DocumentChanges {
 		dc.TagChanges = CompareTags(lDoc.Tags.Value, rDoc.Tags.Value)
 
 		// paths
-		if !lDoc.Paths.IsEmpty() || !rDoc.Paths.IsEmpty() {
-			dc.PathsChanges = ComparePaths(lDoc.Paths.Value, rDoc.Paths.Value)
+		if withPaths && (!lDoc.Paths.IsEmpty() || !rDoc.Paths.IsEmpty()) {
+			dc.PathsChanges = comparePaths(lDoc.Paths.Value, rDoc.Paths.Value, workers)
 		}
 
 		// external docs
@@ -258,7 +285,7 @@ func CompareDocuments(l, r any) *DocumentChanges {
 
 		// compare components.
 		if !lDoc.Components.IsEmpty() && !rDoc.Components.IsEmpty() {
-			if n := CompareComponents(lDoc.Components.Value, rDoc.Components.Value); n != nil {
+			if n := compareComponents(lDoc.Components.Value, rDoc.Components.Value, workers); n != nil {
 				dc.ComponentsChanges = n
 			}
 		}
@@ -289,6 +316,7 @@ func CompareDocuments(l, r any) *DocumentChanges {
 	if dc.TotalChanges() <= 0 {
 		return nil
 	}
+	ApplyBreakingRules(dc, nil)
 	return dc
 }
 
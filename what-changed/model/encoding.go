@@ -10,7 +10,11 @@ import (
 // EncodingChanges represent all the changes made to an Encoding object
 type EncodingChanges struct {
 	*PropertyChanges
-	HeaderChanges map[string]*HeaderChanges `json:"headers,omitempty" yaml:"headers,omitempty"`
+	HeaderChanges         map[string]*HeaderChanges   `json:"headers,omitempty" yaml:"headers,omitempty"`
+	EncodingChanges       map[string]*EncodingChanges `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	ItemEncodingChanges   *EncodingChanges            `json:"itemEncoding,omitempty" yaml:"itemEncoding,omitempty"`
+	PrefixEncodingChanges []*EncodingChanges          `json:"prefixEncoding,omitempty" yaml:"prefixEncoding,omitempty"`
+	ExtensionChanges      *ExtensionChanges           `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 }
 
 // GetAllChanges returns a slice of all changes made between Encoding objects
@@ -20,6 +24,18 @@ func (e *EncodingChanges) GetAllChanges() []*Change {
 	for k := range e.HeaderChanges {
 		changes = append(changes, e.HeaderChanges[k].GetAllChanges()...)
 	}
+	for k := range e.EncodingChanges {
+		changes = append(changes, e.EncodingChanges[k].GetAllChanges()...)
+	}
+	if e.ItemEncodingChanges != nil {
+		changes = append(changes, e.ItemEncodingChanges.GetAllChanges()...)
+	}
+	for i := range e.PrefixEncodingChanges {
+		changes = append(changes, e.PrefixEncodingChanges[i].GetAllChanges()...)
+	}
+	if e.ExtensionChanges != nil {
+		changes = append(changes, e.ExtensionChanges.GetAllChanges()...)
+	}
 	return changes
 }
 
@@ -31,6 +47,18 @@ func (e *EncodingChanges) TotalChanges() int {
 			c += e.HeaderChanges[i].TotalChanges()
 		}
 	}
+	for i := range e.EncodingChanges {
+		c += e.EncodingChanges[i].TotalChanges()
+	}
+	if e.ItemEncodingChanges != nil {
+		c += e.ItemEncodingChanges.TotalChanges()
+	}
+	for i := range e.PrefixEncodingChanges {
+		c += e.PrefixEncodingChanges[i].TotalChanges()
+	}
+	if e.ExtensionChanges != nil {
+		c += e.ExtensionChanges.TotalChanges()
+	}
 	return c
 }
 
@@ -42,6 +70,15 @@ func (e *EncodingChanges) TotalBreakingChanges() int {
 			c += e.HeaderChanges[i].TotalBreakingChanges()
 		}
 	}
+	for i := range e.EncodingChanges {
+		c += e.EncodingChanges[i].TotalBreakingChanges()
+	}
+	if e.ItemEncodingChanges != nil {
+		c += e.ItemEncodingChanges.TotalBreakingChanges()
+	}
+	for i := range e.PrefixEncodingChanges {
+		c += e.PrefixEncodingChanges[i].TotalBreakingChanges()
+	}
 	return c
 }
 
@@ -91,6 +128,41 @@ func CompareEncoding(l, r *v3.Encoding) *EncodingChanges {
 
 	// headers
 	ec.HeaderChanges = CheckMapForChanges(l.Headers.Value, r.Headers.Value, &changes, v3.HeadersLabel, CompareHeadersV3)
+
+	// nested encoding (3.2+)
+	ec.EncodingChanges = CheckMapForChanges(l.Encoding.Value, r.Encoding.Value, &changes, v3.EncodingLabel, CompareEncoding)
+
+	// item encoding (3.2+)
+	if l.ItemEncoding.Value != nil && r.ItemEncoding.Value != nil {
+		ec.ItemEncodingChanges = CompareEncoding(l.ItemEncoding.Value, r.ItemEncoding.Value)
+	} else {
+		if l.ItemEncoding.Value == nil && r.ItemEncoding.Value != nil {
+			CreateChange(&changes, ObjectAdded, v3.ItemEncodingLabel,
+				nil, r.ItemEncoding.ValueNode, false, nil, r.ItemEncoding.Value)
+		}
+		if l.ItemEncoding.Value != nil && r.ItemEncoding.Value == nil {
+			CreateChange(&changes, ObjectRemoved, v3.ItemEncodingLabel,
+				l.ItemEncoding.ValueNode, nil, true, l.ItemEncoding.Value, nil)
+		}
+	}
+
+	// prefix encoding (3.2+), compared by position.
+	lp, rp := l.PrefixEncoding.Value, r.PrefixEncoding.Value
+	for i := 0; i < len(lp) || i < len(rp); i++ {
+		switch {
+		case i < len(lp) && i < len(rp):
+			if pc := CompareEncoding(lp[i].Value, rp[i].Value); pc != nil {
+				ec.PrefixEncodingChanges = append(ec.PrefixEncodingChanges, pc)
+			}
+		case i < len(rp):
+			CreateChange(&changes, ObjectAdded, v3.PrefixEncodingLabel,
+				nil, rp[i].ValueNode, false, nil, rp[i].Value)
+		default:
+			CreateChange(&changes, ObjectRemoved, v3.PrefixEncodingLabel,
+				lp[i].ValueNode, nil, true, lp[i].Value, nil)
+		}
+	}
+	ec.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
 	ec.PropertyChanges = NewPropertyChanges(changes)
 	if ec.TotalChanges() <= 0 {
 		return nil
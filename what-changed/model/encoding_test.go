@@ -156,3 +156,112 @@ allowReserved: true`
 	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
 
 }
+
+func TestCompareEncoding_NestedEncoding(t *testing.T) {
+
+	left := `encoding:
+  metadata:
+    contentType: application/json
+itemEncoding:
+  contentType: image/png
+prefixEncoding:
+  - contentType: application/json
+  - contentType: text/csv`
+
+	right := `encoding:
+  metadata:
+    contentType: application/xml
+itemEncoding:
+  contentType: image/jpeg
+prefixEncoding:
+  - contentType: application/json
+  - contentType: text/plain
+  - contentType: text/html`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Encoding
+	var rDoc v3.Encoding
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareEncoding(&lDoc, &rDoc)
+	assert.Equal(t, 4, extChanges.TotalChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 4)
+	assert.Equal(t, 3, extChanges.TotalBreakingChanges())
+	assert.Equal(t, 1, extChanges.EncodingChanges["metadata"].TotalChanges())
+	assert.Equal(t, 1, extChanges.ItemEncodingChanges.TotalChanges())
+	assert.Len(t, extChanges.PrefixEncodingChanges, 1)
+	assert.Equal(t, ObjectAdded, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.PrefixEncodingLabel, extChanges.Changes[0].Property)
+
+	// reverse
+	extChanges = CompareEncoding(&rDoc, &lDoc)
+	assert.Equal(t, 4, extChanges.TotalChanges())
+	assert.Equal(t, 4, extChanges.TotalBreakingChanges())
+	assert.Equal(t, ObjectRemoved, extChanges.Changes[0].ChangeType)
+}
+
+func TestCompareEncoding_ItemEncodingAddedRemoved(t *testing.T) {
+
+	left := `contentType: multipart/mixed`
+
+	right := `contentType: multipart/mixed
+itemEncoding:
+  contentType: image/png`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Encoding
+	var rDoc v3.Encoding
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareEncoding(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+	assert.Equal(t, ObjectAdded, extChanges.Changes[0].ChangeType)
+
+	extChanges = CompareEncoding(&rDoc, &lDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Equal(t, ObjectRemoved, extChanges.Changes[0].ChangeType)
+}
+
+func TestCompareEncoding_Extensions(t *testing.T) {
+
+	left := `contentType: multipart/mixed
+x-pizza: pie`
+
+	right := `contentType: multipart/mixed
+x-pizza: party`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	var lDoc v3.Encoding
+	var rDoc v3.Encoding
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	extChanges := CompareEncoding(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 1)
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+	assert.Equal(t, 1, extChanges.ExtensionChanges.TotalChanges())
+}
@@ -38,12 +38,12 @@ func CompareExamplesV2(l, r *v2.Examples) *ExamplesChanges {
 	rValues := make(map[string]low.ValueReference[*yaml.Node])
 
 	for k, v := range l.Values.FromOldest() {
-		lHashes[k.Value] = low.GenerateHashString(v.Value)
+		lHashes[k.Value] = hashString(v.Value)
 		lValues[k.Value] = v
 	}
 
 	for k, v := range r.Values.FromOldest() {
-		rHashes[k.Value] = low.GenerateHashString(v.Value)
+		rHashes[k.Value] = hashString(v.Value)
 		rValues[k.Value] = v
 	}
 	var changes []*Change
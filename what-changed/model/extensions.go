@@ -4,10 +4,13 @@
 package model
 
 import (
+	"path"
 	"strings"
+	"sync"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -31,22 +34,120 @@ func (e *ExtensionChanges) TotalBreakingChanges() int {
 	return 0
 }
 
+// ExtensionComparator decides if the original and updated values of an extension are the same. It is used in place
+// of the basic value check made by CompareExtensions, see RegisterExtensionComparator.
+type ExtensionComparator func(original, updated *yaml.Node) bool
+
+var (
+	extensionLock        sync.RWMutex
+	extensionComparators = make(map[string]ExtensionComparator)
+	ignoredExtensions    []string
+)
+
+// RegisterExtensionComparator will use a comparator to check the values of an extension (like 'x-rate-limit')
+// whenever extensions are compared. The name is not case-sensitive. Registering a nil comparator removes it.
+func RegisterExtensionComparator(name string, comparator ExtensionComparator) {
+	extensionLock.Lock()
+	defer extensionLock.Unlock()
+	if comparator == nil {
+		delete(extensionComparators, strings.ToLower(name))
+		return
+	}
+	extensionComparators[strings.ToLower(name)] = comparator
+}
+
+// IgnoreExtensions will stop changes to extensions that match any of the patterns from being reported. A pattern is
+// an extension name (like 'x-internal'), or a pattern using the syntax of path.Match (like 'x-amazon-*'). Patterns
+// are not case-sensitive.
+func IgnoreExtensions(patterns ...string) {
+	extensionLock.Lock()
+	defer extensionLock.Unlock()
+	for _, p := range patterns {
+		ignoredExtensions = append(ignoredExtensions, strings.ToLower(p))
+	}
+}
+
+// ResetExtensionRules removes every comparator and ignore pattern, so extensions are compared by value again.
+func ResetExtensionRules() {
+	extensionLock.Lock()
+	defer extensionLock.Unlock()
+	extensionComparators = make(map[string]ExtensionComparator)
+	ignoredExtensions = nil
+}
+
+// UnorderedExtensionComparator is an ExtensionComparator for structured extensions, where the order of keys in
+// an object does not matter. Sequences must hold the same values, in the same order.
+func UnorderedExtensionComparator(original, updated *yaml.Node) bool {
+	return unorderedEqual(original, updated)
+}
+
+func unorderedEqual(l, r *yaml.Node) bool {
+	if l == nil || r == nil {
+		return l == r
+	}
+	l, r = utils.NodeAlias(l), utils.NodeAlias(r)
+	if l.Kind != r.Kind || len(l.Content) != len(r.Content) {
+		return false
+	}
+	switch l.Kind {
+	case yaml.MappingNode:
+		right := make(map[string]*yaml.Node, len(r.Content)/2)
+		for i := 0; i < len(r.Content)-1; i += 2 {
+			right[r.Content[i].Value] = r.Content[i+1]
+		}
+		for i := 0; i < len(l.Content)-1; i += 2 {
+			rv, ok := right[l.Content[i].Value]
+			if !ok || !unorderedEqual(l.Content[i+1], rv) {
+				return false
+			}
+		}
+		return true
+	case yaml.SequenceNode, yaml.DocumentNode:
+		for i := range l.Content {
+			if !unorderedEqual(l.Content[i], r.Content[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return l.Value == r.Value && l.Tag == r.Tag
+}
+
+func extensionIgnored(name string) bool {
+	extensionLock.RLock()
+	defer extensionLock.RUnlock()
+	for _, p := range ignoredExtensions {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func extensionComparator(name string) ExtensionComparator {
+	extensionLock.RLock()
+	defer extensionLock.RUnlock()
+	return extensionComparators[name]
+}
+
 // CompareExtensions will compare a left and right map of Tag/ValueReference models for any changes to
 // anything. This function does not try and cast the value of an extension to perform checks, it
-// will perform a basic value check.
-//
-// A current limitation relates to extensions being objects and a property of the object changes,
-// there is currently no support for knowing anything changed - so it is ignored.
+// will perform a basic value check, unless a comparator has been registered for the extension (see
+// RegisterExtensionComparator). Extensions that are ignored (see IgnoreExtensions) are not compared.
 func CompareExtensions(l, r *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]) *ExtensionChanges {
 	// look at the original and then look through the new.
 	seenLeft := make(map[string]*low.ValueReference[*yaml.Node])
 	seenRight := make(map[string]*low.ValueReference[*yaml.Node])
 
 	for k, h := range l.FromOldest() {
-		seenLeft[strings.ToLower(k.Value)] = &h
+		if name := strings.ToLower(k.Value); !extensionIgnored(name) {
+			seenLeft[name] = &h
+		}
 	}
 	for k, h := range r.FromOldest() {
-		seenRight[strings.ToLower(k.Value)] = &h
+		if name := strings.ToLower(k.Value); !extensionIgnored(name) {
+			seenRight[name] = &h
+		}
 	}
 
 	var changes []*Change
@@ -55,6 +156,13 @@ func CompareExtensions(l, r *orderedmap.Map[low.KeyReference[string], low.ValueR
 		CheckForObjectAdditionOrRemoval[*yaml.Node](seenLeft, seenRight, i, &changes, false, true)
 
 		if seenRight[i] != nil {
+			if comparator := extensionComparator(i); comparator != nil {
+				if !comparator(seenLeft[i].Value, seenRight[i].Value) {
+					CreateChange(&changes, Modified, i, seenLeft[i].ValueNode, seenRight[i].ValueNode, false,
+						seenLeft[i].Value, seenRight[i].Value)
+				}
+				continue
+			}
 			var props []*PropertyCheck
 
 			props = append(props, &PropertyCheck{
@@ -100,3 +100,79 @@ func TestCompareExtensions_Identical(t *testing.T) {
 
 	assert.Nil(t, extChanges)
 }
+
+func TestCompareExtensions_Comparator(t *testing.T) {
+	defer ResetExtensionRules()
+
+	left := `x-limits:
+  rate: 10
+  burst: 20
+x-other: 1`
+	right := `x-limits:
+  burst: 20
+  rate: 10
+x-other: 1`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	lExt := low.ExtractExtensions(lNode.Content[0])
+	rExt := low.ExtractExtensions(rNode.Content[0])
+
+	// keys moved around, the basic check sees a change.
+	assert.Equal(t, 1, CompareExtensions(lExt, rExt).TotalChanges())
+
+	RegisterExtensionComparator("X-Limits", UnorderedExtensionComparator)
+	assert.Nil(t, CompareExtensions(lExt, rExt))
+
+	RegisterExtensionComparator("x-limits", func(original, updated *yaml.Node) bool {
+		return false
+	})
+	extChanges := CompareExtensions(lExt, rExt)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, "x-limits", extChanges.Changes[0].Property)
+
+	RegisterExtensionComparator("x-limits", nil)
+	assert.Equal(t, 1, CompareExtensions(lExt, rExt).TotalChanges())
+}
+
+func TestCompareExtensions_Ignore(t *testing.T) {
+	defer ResetExtensionRules()
+
+	left := `x-internal-id: 1
+x-test: 1`
+	right := `x-internal-ref: 2
+x-test: 2`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	lExt := low.ExtractExtensions(lNode.Content[0])
+	rExt := low.ExtractExtensions(rNode.Content[0])
+	assert.Equal(t, 3, CompareExtensions(lExt, rExt).TotalChanges())
+
+	IgnoreExtensions("X-Internal-*")
+	extChanges := CompareExtensions(lExt, rExt)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, "x-test", extChanges.Changes[0].Property)
+
+	IgnoreExtensions("x-test")
+	assert.Nil(t, CompareExtensions(lExt, rExt))
+}
+
+func TestUnorderedExtensionComparator(t *testing.T) {
+	parse := func(s string) *yaml.Node {
+		var n yaml.Node
+		_ = yaml.Unmarshal([]byte(s), &n)
+		return n.Content[0]
+	}
+	assert.True(t, UnorderedExtensionComparator(parse(`{a: [1, {b: 2, c: 3}], d: x}`),
+		parse(`{d: x, a: [1, {c: 3, b: 2}]}`)))
+	assert.False(t, UnorderedExtensionComparator(parse(`{a: [1, 2]}`), parse(`{a: [2, 1]}`)))
+	assert.False(t, UnorderedExtensionComparator(parse(`{a: 1}`), parse(`{b: 1}`)))
+	assert.False(t, UnorderedExtensionComparator(parse(`{a: 1}`), nil))
+	assert.True(t, UnorderedExtensionComparator(nil, nil))
+}
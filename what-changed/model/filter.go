@@ -0,0 +1,330 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ChangeFilter restricts DocumentChanges to a slice of a document, so teams that own part of a large document only
+// see the changes made to their part of it.
+//
+// When any of Paths, Tags or Components are set, only changes made to the matching paths, operations and components
+// are kept, everything else (like info or servers) is dropped. The Exclude lists remove matching paths, operations and
+// components, and are checked after the include lists.
+//
+// The Ignore rules remove single changes wherever they are found, so edits that only touch documentation, or
+// generated values, do not show up as changes.
+type ChangeFilter struct {
+	// Paths are the paths to keep, like '/pets/{id}'. A pattern can use '*' to match any characters other than '/',
+	// or end in '**' to match every path that starts with the pattern.
+	Paths []string
+
+	// ExcludePaths are the paths to remove, using the same patterns as Paths.
+	ExcludePaths []string
+
+	// Tags are the operation tags to keep. An operation is kept when it has one of the tags, in either document.
+	Tags []string
+
+	// ExcludeTags are the operation tags to remove.
+	ExcludeTags []string
+
+	// Components are the components to keep, by name ('Pet') or by type and name ('schemas/Pet').
+	// Swagger definitions, parameters and responses are treated as components.
+	Components []string
+
+	// ExcludeComponents are the components to remove, using the same names as Components.
+	ExcludeComponents []string
+
+	// IgnoreDescriptions removes changes to descriptions and summaries.
+	IgnoreDescriptions bool
+
+	// IgnoreExamples removes changes to example and examples, and to anything inside an example object.
+	IgnoreExamples bool
+
+	// IgnoreExtensions removes changes to extensions that match any of the patterns, using the syntax of path.Match
+	// (like 'x-generated-*'). Patterns are not case-sensitive.
+	IgnoreExtensions []string
+
+	// IgnorePointers removes changes made at, or beneath any of the JSON Pointers (RFC 6901), like
+	// '/paths/~1pets/get/responses'. A segment of '*' matches any key or index.
+	IgnorePointers []string
+}
+
+// FilterChanges will remove every change that does not match a ChangeFilter from DocumentChanges. The original and
+// updated documents must be the same low-level *v3.Document or *v2.Swagger documents that were compared to create
+// the changes, they are used to look up the tags of operations. The changes are modified and returned, if no changes
+// are left, nil is returned.
+func FilterChanges(changes *DocumentChanges, filter *ChangeFilter, original, updated any) *DocumentChanges {
+	if changes == nil || filter == nil {
+		return changes
+	}
+	tags := make(map[string]map[string][]string)
+	for _, doc := range []any{original, updated} {
+		if idx, _ := documentIndex(doc); idx != nil {
+			collectOperationTags(idx.GetRootNode(), tags)
+		}
+	}
+	f := &changeFilter{filter: filter, tags: tags}
+
+	if len(filter.Paths) > 0 || len(filter.Tags) > 0 || len(filter.Components) > 0 {
+		changes.PropertyChanges = NewPropertyChanges(nil)
+		changes.InfoChanges = nil
+		changes.TagChanges = nil
+		changes.ExternalDocChanges = nil
+		changes.WebhookChanges = nil
+		changes.ServerChanges = nil
+		changes.SecurityRequirementChanges = nil
+		changes.ExtensionChanges = nil
+	}
+	if changes.PathsChanges != nil {
+		changes.PathsChanges = f.filterPaths(changes.PathsChanges)
+	}
+	if changes.ComponentsChanges != nil {
+		changes.ComponentsChanges = f.filterComponents(changes.ComponentsChanges)
+	}
+	f.ignoreChanges(changes, original, updated)
+	if changes.TotalChanges() <= 0 {
+		return nil
+	}
+	return changes
+}
+
+type changeFilter struct {
+	filter *ChangeFilter
+	tags   map[string]map[string][]string // path -> method -> tags, from both documents.
+}
+
+func (f *changeFilter) filterPaths(pc *PathsChanges) *PathsChanges {
+	include := len(f.filter.Paths) > 0 || len(f.filter.Tags) > 0
+	if !include && len(f.filter.ExcludePaths) == 0 && len(f.filter.ExcludeTags) == 0 {
+		if len(f.filter.Components) > 0 {
+			return nil
+		}
+		return pc
+	}
+	if include {
+		pc.ExtensionChanges = nil
+	}
+	if pc.PropertyChanges != nil {
+		pc.Changes = slices.DeleteFunc(pc.Changes, func(c *Change) bool {
+			if c.Property != v3.PathLabel {
+				return include
+			}
+			p := c.Original
+			if c.ChangeType == ObjectAdded || c.ChangeType == PropertyAdded {
+				p = c.New
+			}
+			return !f.includePath(p)
+		})
+	}
+	for p, pic := range pc.PathItemsChanges {
+		if !f.includePath(p) {
+			delete(pc.PathItemsChanges, p)
+			continue
+		}
+		f.filterOperations(p, pic)
+		if pic.TotalChanges() <= 0 {
+			delete(pc.PathItemsChanges, p)
+		}
+	}
+	if pc.TotalChanges() <= 0 {
+		return nil
+	}
+	return pc
+}
+
+// filterOperations removes the changes made to operations of a path that do not match the tags of the filter.
+func (f *changeFilter) filterOperations(p string, pic *PathItemChanges) {
+	if len(f.filter.Tags) == 0 && len(f.filter.ExcludeTags) == 0 {
+		return
+	}
+	for method, op := range pic.operations() {
+		if *op != nil && !f.includeOperation(p, method) {
+			*op = nil
+		}
+	}
+	if pic.PropertyChanges != nil {
+		pic.Changes = slices.DeleteFunc(pic.Changes, func(c *Change) bool {
+			if slices.Contains(operationLabels, c.Property) {
+				return !f.includeOperation(p, c.Property)
+			}
+			return false
+		})
+	}
+}
+
+// includePath returns true if a path matches the filter. Tags are checked against every operation of the path,
+// at least one operation must be included for the path to be included.
+func (f *changeFilter) includePath(p string) bool {
+	if len(f.filter.Paths) > 0 && !matchesPath(f.filter.Paths, p) {
+		return false
+	}
+	if matchesPath(f.filter.ExcludePaths, p) {
+		return false
+	}
+	if len(f.filter.Tags) == 0 && len(f.filter.ExcludeTags) == 0 {
+		return true
+	}
+	for method := range f.tags[p] {
+		if f.includeOperation(p, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *changeFilter) includeOperation(p, method string) bool {
+	tags := f.tags[p][method]
+	if len(f.filter.Tags) > 0 && !slices.ContainsFunc(tags, func(t string) bool {
+		return slices.Contains(f.filter.Tags, t)
+	}) {
+		return false
+	}
+	return !slices.ContainsFunc(tags, func(t string) bool {
+		return slices.Contains(f.filter.ExcludeTags, t)
+	})
+}
+
+func (f *changeFilter) filterComponents(cc *ComponentsChanges) *ComponentsChanges {
+	if len(f.filter.Components) == 0 && len(f.filter.ExcludeComponents) == 0 {
+		if len(f.filter.Paths) > 0 || len(f.filter.Tags) > 0 {
+			return nil
+		}
+		return cc
+	}
+	if len(f.filter.Components) > 0 {
+		cc.ExtensionChanges = nil
+	}
+	for name := range cc.SchemaChanges {
+		if !f.includeComponent(v3.SchemasLabel, name) {
+			delete(cc.SchemaChanges, name)
+		}
+	}
+	for name := range cc.SecuritySchemeChanges {
+		if !f.includeComponent(v3.SecuritySchemesLabel, name) {
+			delete(cc.SecuritySchemeChanges, name)
+		}
+	}
+	if cc.PropertyChanges != nil {
+		cc.Changes = slices.DeleteFunc(cc.Changes, func(c *Change) bool {
+			name := c.Original
+			if c.ChangeType == ObjectAdded || c.ChangeType == PropertyAdded {
+				name = c.New
+			}
+			return !f.includeComponent(c.Property, name)
+		})
+	}
+	if cc.TotalChanges() <= 0 {
+		return nil
+	}
+	return cc
+}
+
+func (f *changeFilter) includeComponent(kind, name string) bool {
+	switch kind {
+	case v2.DefinitionsLabel:
+		kind = v3.SchemasLabel
+	case v3.SecurityDefinitionLabel:
+		kind = v3.SecuritySchemesLabel
+	}
+	matches := func(patterns []string) bool {
+		return slices.ContainsFunc(patterns, func(p string) bool {
+			return p == name || p == kind+"/"+name
+		})
+	}
+	if len(f.filter.Components) > 0 && !matches(f.filter.Components) {
+		return false
+	}
+	return !matches(f.filter.ExcludeComponents)
+}
+
+func matchesPath(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if pattern == p {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "**"); ok && strings.HasPrefix(p, prefix) {
+			return true
+		}
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// operations returns the operation changes of the path item, by method.
+func (p *PathItemChanges) operations() map[string]**OperationChanges {
+	return map[string]**OperationChanges{
+		v3.GetLabel:     &p.GetChanges,
+		v3.PutLabel:     &p.PutChanges,
+		v3.PostLabel:    &p.PostChanges,
+		v3.DeleteLabel:  &p.DeleteChanges,
+		v3.OptionsLabel: &p.OptionsChanges,
+		v3.HeadLabel:    &p.HeadChanges,
+		v3.PatchLabel:   &p.PatchChanges,
+		v3.TraceLabel:   &p.TraceChanges,
+	}
+}
+
+// collectOperationTags adds the tags of every operation in a document root node to tags, by path and method.
+func collectOperationTags(root *yaml.Node, tags map[string]map[string][]string) {
+	if root == nil {
+		return
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	_, paths := utils.FindKeyNodeTop(v3.PathsLabel, root.Content)
+	if paths == nil {
+		return
+	}
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		p, pathItem := paths.Content[i].Value, paths.Content[i+1]
+		for j := 0; j+1 < len(pathItem.Content); j += 2 {
+			method := pathItem.Content[j].Value
+			if !slices.Contains(operationLabels, method) {
+				continue
+			}
+			if tags[p] == nil {
+				tags[p] = make(map[string][]string)
+			}
+			if tags[p][method] == nil {
+				tags[p][method] = []string{}
+			}
+			if _, t := utils.FindKeyNodeTop(v3.TagsLabel, pathItem.Content[j+1].Content); t != nil {
+				for _, n := range t.Content {
+					if !slices.Contains(tags[p][method], n.Value) {
+						tags[p][method] = append(tags[p][method], n.Value)
+					}
+				}
+			}
+		}
+	}
+}
+
+// documentIndex returns the index of a low-level *v3.Document or *v2.Swagger document, and if the document is
+// a Swagger document.
+func documentIndex(doc any) (*index.SpecIndex, bool) {
+	switch d := doc.(type) {
+	case *v3.Document:
+		if d != nil {
+			return d.Index, false
+		}
+	case *v2.Swagger:
+		if d != nil {
+			return d.Index, true
+		}
+	}
+	return nil, false
+}
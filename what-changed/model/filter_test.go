@@ -0,0 +1,130 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const filterLeft = `openapi: 3.1.0
+info:
+  title: pets
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      description: list pets
+    post:
+      tags: [admin]
+      description: add a pet
+  /pets/{id}:
+    get:
+      tags: [pets]
+      description: get a pet
+  /owners:
+    get:
+      tags: [owners]
+      description: list owners
+components:
+  schemas:
+    Pet:
+      type: object
+    Owner:
+      type: object`
+
+const filterRight = `openapi: 3.1.0
+info:
+  title: all the pets
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      description: list all pets
+    post:
+      tags: [admin]
+      description: add a new pet
+  /pets/{id}:
+    get:
+      tags: [pets]
+      description: get one pet
+  /stores:
+    get:
+      tags: [stores]
+      description: list stores
+components:
+  schemas:
+    Pet:
+      type: string
+    Owner:
+      type: string
+    Store:
+      type: object`
+
+func compareAndFilter(t *testing.T, filter *ChangeFilter) *DocumentChanges {
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(filterLeft))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(filterRight))
+	lDoc, _ := v3.CreateDocumentFromConfig(siLeft, datamodel.NewDocumentConfiguration())
+	rDoc, _ := v3.CreateDocumentFromConfig(siRight, datamodel.NewDocumentConfiguration())
+
+	changes := CompareDocuments(lDoc, rDoc)
+	require.NotNil(t, changes)
+	return FilterChanges(changes, filter, lDoc, rDoc)
+}
+
+func TestFilterChanges_Paths(t *testing.T) {
+	changes := compareAndFilter(t, &ChangeFilter{Paths: []string{"/pets**"}})
+	require.NotNil(t, changes)
+	assert.Nil(t, changes.InfoChanges)
+	assert.Nil(t, changes.ComponentsChanges)
+	assert.Len(t, changes.PathsChanges.PathItemsChanges, 2)
+	assert.Empty(t, changes.PathsChanges.Changes)
+	assert.Equal(t, 3, changes.TotalChanges())
+
+	changes = compareAndFilter(t, &ChangeFilter{Paths: []string{"/*"}, ExcludePaths: []string{"/pets"}})
+	require.NotNil(t, changes)
+	assert.Empty(t, changes.PathsChanges.PathItemsChanges)
+	assert.Len(t, changes.PathsChanges.Changes, 2) // /owners removed, /stores added.
+}
+
+func TestFilterChanges_Tags(t *testing.T) {
+	changes := compareAndFilter(t, &ChangeFilter{Tags: []string{"pets"}})
+	require.NotNil(t, changes)
+	require.Len(t, changes.PathsChanges.PathItemsChanges, 2)
+	assert.NotNil(t, changes.PathsChanges.PathItemsChanges["/pets"].GetChanges)
+	assert.Nil(t, changes.PathsChanges.PathItemsChanges["/pets"].PostChanges)
+	assert.Equal(t, 2, changes.TotalChanges())
+
+	changes = compareAndFilter(t, &ChangeFilter{ExcludeTags: []string{"admin", "owners"}})
+	require.NotNil(t, changes)
+	assert.NotNil(t, changes.InfoChanges)
+	assert.Nil(t, changes.PathsChanges.PathItemsChanges["/pets"].PostChanges)
+	require.Len(t, changes.PathsChanges.Changes, 1)
+	assert.Equal(t, "/stores", changes.PathsChanges.Changes[0].New)
+}
+
+func TestFilterChanges_Components(t *testing.T) {
+	changes := compareAndFilter(t, &ChangeFilter{Components: []string{"schemas/Pet", "Store"}})
+	require.NotNil(t, changes)
+	assert.Nil(t, changes.PathsChanges)
+	assert.Len(t, changes.ComponentsChanges.SchemaChanges, 1)
+	assert.NotNil(t, changes.ComponentsChanges.SchemaChanges["Pet"])
+	require.Len(t, changes.ComponentsChanges.Changes, 1)
+	assert.Equal(t, "Store", changes.ComponentsChanges.Changes[0].New)
+
+	changes = compareAndFilter(t, &ChangeFilter{ExcludeComponents: []string{"Pet", "Owner", "Store"}})
+	require.NotNil(t, changes)
+	assert.Nil(t, changes.ComponentsChanges)
+	assert.NotNil(t, changes.PathsChanges)
+}
+
+func TestFilterChanges_NothingLeft(t *testing.T) {
+	assert.Nil(t, compareAndFilter(t, &ChangeFilter{Paths: []string{"/nope"}}))
+	assert.NotNil(t, compareAndFilter(t, nil))
+	assert.Nil(t, FilterChanges(nil, &ChangeFilter{}, nil, nil))
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// HashCache keeps what comparisons work out from documents (the hashes of their models, and the keys values are
+// matched by) between comparisons, so comparing a document again does not hash it again. For example, when comparing
+// every revision of a document with the one before it, each revision is only hashed once.
+//
+// Hashes are kept by each document (see low.HashScope), a HashCache keeps the documents it is used with frozen
+// until it is cleared, so none of them can be changed until then. Clear the cache before changing a document, and
+// once it's no longer needed, so the hashes can be released. A HashCache is safe for concurrent use.
+type HashCache struct {
+	lock sync.Mutex
+	held map[*low.HashScope]func()
+}
+
+// NewHashCache creates an empty HashCache.
+func NewHashCache() *HashCache {
+	return &HashCache{held: make(map[*low.HashScope]func())}
+}
+
+// Len returns the number of documents the cache is keeping hashes for.
+func (c *HashCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.held)
+}
+
+// Clear releases every document held by the cache, along with their hashes.
+func (c *HashCache) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for scope, release := range c.held {
+		release()
+		delete(c.held, scope)
+	}
+}
+
+// hold keeps the hashes of the documents until the cache is cleared.
+func (c *HashCache) hold(docs ...any) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.held == nil {
+		c.held = make(map[*low.HashScope]func())
+	}
+	for _, d := range docs {
+		if scope := hashScope(d); scope != nil {
+			if _, ok := c.held[scope]; !ok {
+				c.held[scope] = scope.Freeze()
+			}
+		}
+	}
+}
+
+// hashScope returns the HashScope of a document, or nil if it's not a document (or has no scope).
+func hashScope(doc any) *low.HashScope {
+	switch d := doc.(type) {
+	case *v2.Swagger:
+		return d.HashScope
+	case *v3.Document:
+		return d.HashScope
+	}
+	return nil
+}
+
+// freezeHashes caches the hashes of the models of the documents until the returned function is called, the
+// documents are not changed while they are compared.
+func freezeHashes(docs ...any) func() {
+	var release []func()
+	for _, d := range docs {
+		if scope := hashScope(d); scope != nil {
+			release = append(release, scope.Freeze())
+		}
+	}
+	return func() {
+		for _, r := range release {
+			r()
+		}
+	}
+}
+
+// hashString returns the hash of a value, see low.GenerateHashString.
+func hashString(v any) string {
+	return low.GenerateHashString(v)
+}
+
+// renderedYAML returns a node rendered as YAML.
+func renderedYAML(n *yaml.Node) string {
+	b, _ := yaml.Marshal(n)
+	return string(b)
+}
+
+// toString returns the key a value is matched by when comparing slices of values.
+func toString(v any) string {
+	if y, ok := v.(*yaml.Node); ok {
+		copy := *y
+		_ = copy.Encode(&copy)
+		return fmt.Sprint(copy)
+	}
+
+	return fmt.Sprint(v)
+}
+
+// scopedToString is toString, cached by the HashScope of the document the value belongs to.
+func scopedToString(scope *low.HashScope, v any) string {
+	if y, ok := v.(*yaml.Node); ok {
+		return scope.NodeValue(y, "toString", func() string { return toString(y) })
+	}
+	return toString(v)
+}
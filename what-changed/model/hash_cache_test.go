@@ -0,0 +1,123 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCompareDocumentsWithHashCache(t *testing.T) {
+	original, _ := os.ReadFile("../../test_specs/burgershop.openapi.yaml")
+	updated, _ := os.ReadFile("../../test_specs/burgershop.openapi-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(updated)
+	origDoc, _ := v3.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v3.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	expected := CompareDocuments(origDoc, modDoc)
+	require.NotNil(t, expected)
+
+	cache := NewHashCache()
+	changes := CompareDocumentsWithHashCache(origDoc, modDoc, cache)
+	require.NotNil(t, changes)
+	assert.Equal(t, expected.TotalChanges(), changes.TotalChanges())
+	assert.Equal(t, expected.TotalBreakingChanges(), changes.TotalBreakingChanges())
+	assert.Equal(t, 2, cache.Len())
+
+	// the documents stay frozen once the comparison is done, so their hashes are kept.
+	calls := 0
+	compute := func() string {
+		calls++
+		return "pizza"
+	}
+	node := &yaml.Node{}
+	origDoc.HashScope.NodeValue(node, "test", compute)
+	origDoc.HashScope.NodeValue(node, "test", compute)
+	assert.Equal(t, 1, calls)
+
+	changes = CompareDocumentsWithHashCache(origDoc, modDoc, cache)
+	assert.Equal(t, expected.TotalChanges(), changes.TotalChanges())
+	assert.Equal(t, 2, cache.Len())
+
+	// comparing other documents at the same time does not add them to the cache.
+	other := NewHashCache()
+	CompareDocumentsWithHashCache(modDoc, origDoc, other)
+	assert.Equal(t, 2, cache.Len())
+	other.Clear()
+	origDoc.HashScope.NodeValue(node, "test", compute)
+	assert.Equal(t, 1, calls)
+
+	// clearing the cache releases the documents.
+	cache.Clear()
+	assert.Zero(t, cache.Len())
+	origDoc.HashScope.NodeValue(node, "test", compute)
+	origDoc.HashScope.NodeValue(node, "test", compute)
+	assert.Equal(t, 3, calls)
+}
+
+func TestScopedToString(t *testing.T) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("enum:\n  - pizza\n  - burger"), &root))
+	node := root.Content[0].Content[1].Content[0]
+
+	// without a scope, the key is worked out again.
+	assert.Equal(t, toString(node), scopedToString(nil, node))
+	assert.Equal(t, "pizza", scopedToString(nil, "pizza"))
+
+	scope := low.NewHashScope()
+	release := scope.Freeze()
+	key := scopedToString(scope, node)
+	assert.Equal(t, toString(node), key)
+
+	// the cached key is used until the scope is released.
+	node.Value = "cake"
+	assert.Equal(t, key, scopedToString(scope, node))
+	release()
+	assert.Equal(t, toString(node), scopedToString(scope, node))
+	assert.NotEqual(t, key, scopedToString(scope, node))
+}
+
+func TestCompareDocuments_ModelChangedBetweenComparisons(t *testing.T) {
+	spec, _ := os.ReadFile("../../test_specs/burgershop.openapi.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(spec)
+	infoMod, _ := datamodel.ExtractSpecInfo(spec)
+	origDoc, _ := v3.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v3.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	assert.Nil(t, CompareDocuments(origDoc, modDoc))
+
+	// hashes cached by the first comparison are not used once it is done.
+	op := modDoc.Paths.Value.FindPath("/burgers").Value.Post.Value
+	op.Summary.Value = "a different summary"
+	op.Summary.ValueNode.Value = "a different summary"
+	changes := CompareDocuments(origDoc, modDoc)
+	require.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+}
+
+func TestCompareDocuments_Swagger_ModelChangedBetweenComparisons(t *testing.T) {
+	spec, _ := os.ReadFile("../../test_specs/petstorev2-complete.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(spec)
+	infoMod, _ := datamodel.ExtractSpecInfo(spec)
+	origDoc, _ := v2.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v2.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	assert.Nil(t, CompareDocuments(origDoc, modDoc))
+
+	op := modDoc.Paths.Value.FindPath("/pet/findByStatus").Value.Get.Value
+	op.Summary.Value = "a different summary"
+	op.Summary.ValueNode.Value = "a different summary"
+	changes := CompareDocuments(origDoc, modDoc)
+	require.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+}
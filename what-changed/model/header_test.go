@@ -309,3 +309,54 @@ x-beer: yummy`
 	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
 
 }
+
+func TestCompareHeaders_v3_SchemaToContent(t *testing.T) {
+
+	left := `description: a header
+schema:
+  type: string`
+
+	right := `description: a header
+content:
+  text/plain:
+    schema:
+      type: string`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Header
+	var rDoc v3.Header
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareHeadersV3(&lDoc, &rDoc)
+	assert.NotNil(t, extChanges)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, ObjectAdded, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.ContentLabel, extChanges.Changes[0].Property)
+	assert.Equal(t, ObjectRemoved, extChanges.SchemaChanges.Changes[0].ChangeType)
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+
+	// content media type changed.
+	right2 := `description: a header
+content:
+  application/json:
+    schema:
+      type: string`
+
+	var r2Node yaml.Node
+	_ = yaml.Unmarshal([]byte(right2), &r2Node)
+	var r2Doc v3.Header
+	_ = low.BuildModel(r2Node.Content[0], &r2Doc)
+	_ = r2Doc.Build(context.Background(), nil, r2Node.Content[0], nil)
+
+	extChanges = CompareHeadersV3(&rDoc, &r2Doc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+}
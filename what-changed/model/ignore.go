@@ -0,0 +1,183 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ignoreChanges removes every change matched by the Ignore rules of the filter.
+func (f *changeFilter) ignoreChanges(changes *DocumentChanges, original, updated any) {
+	filter := f.filter
+	if !filter.IgnoreDescriptions && !filter.IgnoreExamples && len(filter.IgnoreExtensions) == 0 &&
+		len(filter.IgnorePointers) == 0 {
+		return
+	}
+	var originalPointers, updatedPointers map[string]string
+	if len(filter.IgnorePointers) > 0 {
+		originalPointers, updatedPointers = nodePointers(original), nodePointers(updated)
+	}
+
+	ignored := make(map[*Change]bool)
+	WalkChanges(changes, func(location *ChangeLocation, change *Change) {
+		switch {
+		case filter.IgnoreDescriptions && (change.Property == v3.DescriptionLabel ||
+			change.Property == v3.SummaryLabel):
+			ignored[change] = true
+		case filter.IgnoreExamples && (change.Property == v3.ExampleLabel || change.Property == v3.ExamplesLabel ||
+			location.Object == "Example" || location.Object == "Examples"):
+			ignored[change] = true
+		case location.Object == "Extension" && matchesExtension(filter.IgnoreExtensions, change.Property):
+			ignored[change] = true
+		case len(filter.IgnorePointers) > 0:
+			if p, ok := changePointer(change, originalPointers, updatedPointers); ok &&
+				matchesPointer(filter.IgnorePointers, p) {
+				ignored[change] = true
+			}
+		}
+	})
+	if len(ignored) == 0 {
+		return
+	}
+	removeChanges(reflect.ValueOf(changes), ignored, make(map[uintptr]bool))
+	pruneChanges(reflect.ValueOf(changes), make(map[uintptr]bool))
+}
+
+func matchesExtension(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(strings.ToLower(p), strings.ToLower(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPointer returns true if the pointer is at, or beneath any of the patterns.
+func matchesPointer(patterns []string, pointer string) bool {
+	segments := strings.Split(pointer, "/")
+	for _, p := range patterns {
+		pattern := strings.Split(strings.TrimSuffix(p, "/"), "/")
+		if len(pattern) > len(segments) {
+			continue
+		}
+		match := true
+		for i, s := range pattern {
+			if s != "*" && s != segments[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// changePointer returns the JSON Pointer of the value a change was made to. Removals are found in the original
+// document, everything else in the updated document.
+func changePointer(change *Change, original, updated map[string]string) (string, bool) {
+	if change.Context == nil {
+		return "", false
+	}
+	ctx := change.Context
+	lookup := func(pointers map[string]string, line, column *int) (string, bool) {
+		if line == nil || column == nil {
+			return "", false
+		}
+		p, ok := pointers[fmt.Sprintf("%d:%d", *line, *column)]
+		return p, ok
+	}
+	if change.ChangeType != PropertyRemoved && change.ChangeType != ObjectRemoved {
+		if p, ok := lookup(updated, ctx.NewLine, ctx.NewColumn); ok {
+			return p, true
+		}
+	}
+	if p, ok := lookup(original, ctx.OriginalLine, ctx.OriginalColumn); ok {
+		return p, true
+	}
+	return lookup(updated, ctx.NewLine, ctx.NewColumn)
+}
+
+// nodePointers maps the line and column of every key and value in a document to its JSON Pointer. When a key and
+// a value share a position, the deepest pointer is kept.
+func nodePointers(doc any) map[string]string {
+	pointers := make(map[string]string)
+	idx, _ := documentIndex(doc)
+	if idx == nil {
+		return pointers
+	}
+	root := idx.GetRootNode()
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	collectPointers(root, "", pointers, make(map[*yaml.Node]bool))
+	return pointers
+}
+
+func collectPointers(n *yaml.Node, pointer string, pointers map[string]string, seen map[*yaml.Node]bool) {
+	if n == nil || seen[n] {
+		return
+	}
+	seen[n] = true
+	pointers[fmt.Sprintf("%d:%d", n.Line, n.Column)] = pointer
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i < len(n.Content)-1; i += 2 {
+			k := n.Content[i]
+			p := pointer + "/" + utils.EscapeJSONPointer(k.Value)
+			pointers[fmt.Sprintf("%d:%d", k.Line, k.Column)] = p
+			collectPointers(n.Content[i+1], p, pointers, seen)
+		}
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			collectPointers(c, pointer+"/"+strconv.Itoa(i), pointers, seen)
+		}
+	}
+}
+
+// removeChanges removes every ignored change from the tree of change models beneath v.
+func removeChanges(v reflect.Value, ignored map[*Change]bool, seen map[uintptr]bool) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct || seen[v.Pointer()] {
+		return
+	}
+	seen[v.Pointer()] = true
+	s := v.Elem()
+	for i := 0; i < s.NumField(); i++ {
+		if !s.Type().Field(i).IsExported() {
+			continue
+		}
+		f := s.Field(i)
+		switch {
+		case f.Type() == changeSliceType:
+			f.Set(reflect.ValueOf(slices.DeleteFunc(f.Interface().([]*Change), func(c *Change) bool {
+				return ignored[c]
+			})))
+		case f.Kind() == reflect.Pointer:
+			removeChanges(f, ignored, seen)
+		case f.Kind() == reflect.Slice || f.Kind() == reflect.Map:
+			for _, e := range mapOrSliceValues(f) {
+				if e.Kind() == reflect.Slice {
+					for _, ee := range mapOrSliceValues(e) {
+						removeChanges(ee, ignored, seen)
+					}
+					continue
+				}
+				removeChanges(e, ignored, seen)
+			}
+		}
+	}
+}
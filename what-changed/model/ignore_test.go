@@ -0,0 +1,115 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ignoreLeft = `openapi: 3.1.0
+info:
+  title: pets
+  description: all about pets
+paths:
+  /pets:
+    get:
+      summary: list pets
+      x-generated-id: abc
+      parameters:
+        - name: limit
+          in: query
+          example: 10
+      responses:
+        "200":
+          description: pets
+  /owners:
+    get:
+      description: owners
+      responses:
+        "200":
+          description: owners`
+
+const ignoreRight = `openapi: 3.1.0
+info:
+  title: pets
+  description: everything about pets
+paths:
+  /pets:
+    get:
+      summary: list all the pets
+      x-generated-id: def
+      parameters:
+        - name: limit
+          in: query
+          example: 20
+          required: true
+      responses:
+        "200":
+          description: some pets
+  /owners:
+    get:
+      description: all owners
+      deprecated: true
+      responses:
+        "200":
+          description: owners`
+
+func compareAndIgnore(t *testing.T, filter *ChangeFilter) *DocumentChanges {
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(ignoreLeft))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(ignoreRight))
+	lDoc, _ := v3.CreateDocumentFromConfig(siLeft, datamodel.NewDocumentConfiguration())
+	rDoc, _ := v3.CreateDocumentFromConfig(siRight, datamodel.NewDocumentConfiguration())
+
+	changes := CompareDocuments(lDoc, rDoc)
+	require.NotNil(t, changes)
+	return FilterChanges(changes, filter, lDoc, rDoc)
+}
+
+func changedProperties(changes *DocumentChanges) []string {
+	var props []string
+	WalkChanges(changes, func(location *ChangeLocation, change *Change) {
+		props = append(props, change.Property)
+	})
+	return props
+}
+
+func TestFilterChanges_IgnoreDocumentation(t *testing.T) {
+	changes := compareAndIgnore(t, &ChangeFilter{
+		IgnoreDescriptions: true,
+		IgnoreExamples:     true,
+		IgnoreExtensions:   []string{"X-Generated-*"},
+	})
+	require.NotNil(t, changes)
+	assert.ElementsMatch(t, []string{v3.DeprecatedLabel, v3.RequiredLabel}, changedProperties(changes))
+	assert.Nil(t, changes.InfoChanges)
+	assert.Equal(t, 2, changes.TotalChanges())
+}
+
+func TestFilterChanges_IgnoreDescriptions(t *testing.T) {
+	all := compareAndIgnore(t, nil)
+	changes := compareAndIgnore(t, &ChangeFilter{IgnoreDescriptions: true})
+	require.NotNil(t, changes)
+	assert.Equal(t, all.TotalChanges()-4, changes.TotalChanges())
+	assert.NotContains(t, changedProperties(changes), v3.DescriptionLabel)
+	assert.NotContains(t, changedProperties(changes), v3.SummaryLabel)
+	assert.Contains(t, changedProperties(changes), v3.ExampleLabel)
+}
+
+func TestFilterChanges_IgnorePointers(t *testing.T) {
+	changes := compareAndIgnore(t, &ChangeFilter{IgnorePointers: []string{"/paths/~1pets", "/info"}})
+	require.NotNil(t, changes)
+	assert.ElementsMatch(t, []string{v3.DescriptionLabel, v3.DeprecatedLabel}, changedProperties(changes))
+	assert.NotContains(t, changes.PathsChanges.PathItemsChanges, "/pets")
+
+	changes = compareAndIgnore(t, &ChangeFilter{IgnorePointers: []string{"/paths/*/get/parameters/*/example"}})
+	require.NotNil(t, changes)
+	assert.NotContains(t, changedProperties(changes), v3.ExampleLabel)
+
+	assert.Nil(t, compareAndIgnore(t, &ChangeFilter{IgnorePointers: []string{"/"}}))
+}
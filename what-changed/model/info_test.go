@@ -55,6 +55,69 @@ license:
 	assert.Equal(t, v3.DescriptionLabel, extChanges.Changes[0].Property)
 }
 
+func TestCompareInfo_SummaryAdded(t *testing.T) {
+
+	left := `title: a nice spec
+version: '1.2.3'`
+
+	right := `title: a nice spec
+summary: a short summary
+version: '1.2.3'`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc base.Info
+	var rDoc base.Info
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareInfo(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 1)
+	assert.Equal(t, PropertyAdded, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.SummaryLabel, extChanges.Changes[0].Property)
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+}
+
+func TestCompareInfo_SummaryModified(t *testing.T) {
+
+	left := `title: a nice spec
+summary: a short summary
+version: '1.2.3'`
+
+	right := `title: a nice spec
+summary: a shorter summary
+version: '1.2.3'`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc base.Info
+	var rDoc base.Info
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	assert.NotEqual(t, lDoc.Hash(), rDoc.Hash())
+
+	// compare.
+	extChanges := CompareInfo(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.SummaryLabel, extChanges.Changes[0].Property)
+	assert.Equal(t, "a short summary", extChanges.Changes[0].Original)
+	assert.Equal(t, "a shorter summary", extChanges.Changes[0].New)
+}
+
 func TestCompareInfo_TitleRemoved(t *testing.T) {
 
 	left := `title: a nice spec
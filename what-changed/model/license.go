@@ -58,6 +58,17 @@ func CompareLicense(l, r *base.License) *LicenseChanges {
 		New:       r,
 	})
 
+	// check identifier
+	props = append(props, &PropertyCheck{
+		LeftNode:  l.Identifier.ValueNode,
+		RightNode: r.Identifier.ValueNode,
+		Label:     v3.IdentifierLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
 	// check everything.
 	CheckProperties(props)
 
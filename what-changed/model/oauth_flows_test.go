@@ -335,3 +335,78 @@ x-coke: cherry`
 	assert.Len(t, extChanges.GetAllChanges(), 5)
 	assert.Equal(t, 4, extChanges.TotalBreakingChanges())
 }
+
+func TestCompareOAuthFlow_DeviceAuthorizationUrl(t *testing.T) {
+
+	left := `deviceAuthorizationUrl: https://pb33f.io/device
+tokenUrl: biscuits`
+
+	right := `deviceAuthorizationUrl: https://pb33f.io/device/v2
+tokenUrl: biscuits`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.OAuthFlow
+	var rDoc v3.OAuthFlow
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// compare
+	extChanges := CompareOAuthFlow(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Equal(t, v3.DeviceAuthorizationUrlLabel, extChanges.Changes[0].Property)
+	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
+}
+
+func TestCompareOAuthFlows_DeviceAuthorization(t *testing.T) {
+
+	left := `deviceAuthorization:
+  deviceAuthorizationUrl: https://pb33f.io/device
+  tokenUrl: https://pb33f.io/token
+  scopes:
+    read: read things`
+
+	right := `deviceAuthorization:
+  deviceAuthorizationUrl: https://pb33f.io/device
+  tokenUrl: https://pb33f.io/token/v2
+  scopes:
+    read: read things`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.OAuthFlows
+	var rDoc v3.OAuthFlows
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// compare
+	extChanges := CompareOAuthFlows(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 1)
+	assert.Equal(t, v3.TokenUrlLabel, extChanges.DeviceAuthorizationChanges.Changes[0].Property)
+
+	// remove the flow
+	extChanges = CompareOAuthFlows(&lDoc, &v3.OAuthFlows{})
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Equal(t, ObjectRemoved, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.DeviceAuthorizationLabel, extChanges.Changes[0].Property)
+
+	// add the flow
+	extChanges = CompareOAuthFlows(&v3.OAuthFlows{}, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+	assert.Equal(t, ObjectAdded, extChanges.Changes[0].ChangeType)
+}
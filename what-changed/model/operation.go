@@ -440,7 +440,7 @@ func checkServers(lServers, rServers low.NodeReference[[]low.ValueReference[*v3.
 			if !lServers.Value[i].Value.URL.IsEmpty() {
 				s = lServers.Value[i].Value.URL.Value
 			} else {
-				s = low.GenerateHashString(lServers.Value[i].Value)
+				s = hashString(lServers.Value[i].Value)
 			}
 			lv[s] = lServers.Value[i]
 		}
@@ -449,7 +449,7 @@ func checkServers(lServers, rServers low.NodeReference[[]low.ValueReference[*v3.
 			if !rServers.Value[i].Value.URL.IsEmpty() {
 				s = rServers.Value[i].Value.URL.Value
 			} else {
-				s = low.GenerateHashString(rServers.Value[i].Value)
+				s = hashString(rServers.Value[i].Value)
 			}
 			rv[s] = rServers.Value[i]
 		}
@@ -517,6 +517,16 @@ func checkServers(lServers, rServers low.NodeReference[[]low.ValueReference[*v3.
 func checkSecurity(lSecurity, rSecurity low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]],
 	changes *[]*Change, oc any) {
 
+	// an empty security array (security: []) disables security, which is different to no security being defined.
+	if lSecurity.IsEmpty() && !rSecurity.IsEmpty() && len(rSecurity.Value) == 0 {
+		CreateChange(changes, PropertyAdded, v3.SecurityLabel,
+			nil, rSecurity.ValueNode, false, nil, rSecurity.Value)
+	}
+	if !lSecurity.IsEmpty() && len(lSecurity.Value) == 0 && rSecurity.IsEmpty() {
+		CreateChange(changes, PropertyRemoved, v3.SecurityLabel,
+			lSecurity.ValueNode, nil, true, lSecurity.Value, nil)
+	}
+
 	lv := make(map[string]*base.SecurityRequirement, len(lSecurity.Value))
 	rv := make(map[string]*base.SecurityRequirement, len(rSecurity.Value))
 	lvn := make(map[string]*yaml.Node, len(lSecurity.Value))
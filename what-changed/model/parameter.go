@@ -334,7 +334,7 @@ func CompareParameters(l, r any) *ParameterChanges {
 
 func checkParameterExample(expLeft, expRight low.NodeReference[*yaml.Node], changes []*Change) {
 	if !expLeft.IsEmpty() && !expRight.IsEmpty() {
-		if low.GenerateHashString(expLeft.GetValue()) != low.GenerateHashString(expRight.GetValue()) {
+		if hashString(expLeft.GetValue()) != hashString(expRight.GetValue()) {
 			CreateChange(&changes, Modified, v3.ExampleLabel,
 				expLeft.GetValueNode(), expRight.GetValueNode(), false,
 				expLeft.GetValue(), expRight.GetValue())
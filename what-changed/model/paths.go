@@ -58,6 +58,11 @@ func (p *PathsChanges) TotalBreakingChanges() int {
 // ComparePaths compares a left and right Swagger or OpenAPI Paths Object for changes. If found, returns a pointer
 // to a PathsChanges instance. Returns nil if nothing is found.
 func ComparePaths(l, r any) *PathsChanges {
+	return comparePaths(l, r, newComparisonWorkers(0))
+}
+
+// comparePaths compares two Paths objects, comparing path items with the workers of the comparison.
+func comparePaths(l, r any, workers *comparisonWorkers) *PathsChanges {
 	var changes []*Change
 
 	pc := new(PathsChanges)
@@ -84,24 +89,22 @@ func ComparePaths(l, r any) *PathsChanges {
 			rKeys[k.Value] = v
 		}
 
-		// run every comparison in a thread.
+		// run every comparison using the comparison workers.
 		var mLock sync.Mutex
-		compare := func(path string, _ map[string]*PathItemChanges, l, r *v2.PathItem, doneChan chan bool) {
+		var wg sync.WaitGroup
+		compare := func(path string, l, r *v2.PathItem) {
 			if !low.AreEqual(l, r) {
+				pic := ComparePathItems(l, r)
 				mLock.Lock()
-				pathChanges[path] = ComparePathItems(l, r)
+				pathChanges[path] = pic
 				mLock.Unlock()
 			}
-			doneChan <- true
 		}
 
-		doneChan := make(chan bool)
-		pathsChecked := 0
-
 		for k := range lKeys {
 			if _, ok := rKeys[k]; ok {
-				go compare(k, pathChanges, lKeys[k].Value, rKeys[k].Value, doneChan)
-				pathsChecked++
+				l, r := lKeys[k].Value, rKeys[k].Value
+				workers.run(&wg, func() { compare(k, l, r) })
 				continue
 			}
 			g, p := lPath.FindPathAndKey(k)
@@ -120,11 +123,7 @@ func ComparePaths(l, r any) *PathsChanges {
 		}
 
 		// wait for the things to be done.
-		completedChecks := 0
-		for completedChecks < pathsChecked {
-			<-doneChan
-			completedChecks++
-		}
+		wg.Wait()
 		if len(pathChanges) > 0 {
 			pc.PathItemsChanges = pathChanges
 		}
@@ -158,24 +157,22 @@ func ComparePaths(l, r any) *PathsChanges {
 			}
 		}
 
-		// run every comparison in a thread.
+		// run every comparison using the comparison workers.
 		var mLock sync.Mutex
-		compare := func(path string, _ map[string]*PathItemChanges, l, r *v3.PathItem, doneChan chan bool) {
+		var wg sync.WaitGroup
+		compare := func(path string, l, r *v3.PathItem) {
 			if !low.AreEqual(l, r) {
+				pic := ComparePathItems(l, r)
 				mLock.Lock()
-				pathChanges[path] = ComparePathItems(l, r)
+				pathChanges[path] = pic
 				mLock.Unlock()
 			}
-			doneChan <- true
 		}
 
-		doneChan := make(chan bool)
-		pathsChecked := 0
-
 		for k := range lKeys {
 			if _, ok := rKeys[k]; ok {
-				go compare(k, pathChanges, lKeys[k].Value, rKeys[k].Value, doneChan)
-				pathsChecked++
+				l, r := lKeys[k].Value, rKeys[k].Value
+				workers.run(&wg, func() { compare(k, l, r) })
 				continue
 			}
 			g, p := lPath.FindPathAndKey(k)
@@ -193,11 +190,7 @@ func ComparePaths(l, r any) *PathsChanges {
 			}
 		}
 		// wait for the things to be done.
-		completedChecks := 0
-		for completedChecks < pathsChecked {
-			<-doneChan
-			completedChecks++
-		}
+		wg.Wait()
 		if len(pathChanges) > 0 {
 			pc.PathItemsChanges = pathChanges
 		}
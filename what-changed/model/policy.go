@@ -0,0 +1,105 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severities that can be given to a change by a Policy.
+const (
+	// SeverityInfo is for changes that are safe for consumers.
+	SeverityInfo = "info"
+
+	// SeverityWarning is for changes that may cause problems for some consumers, but are not breaking.
+	SeverityWarning = "warning"
+
+	// SeverityBreaking is for changes that break consumers. Only changes with this severity are breaking.
+	SeverityBreaking = "breaking"
+)
+
+// Policy decides the severity of changes found when comparing documents. Organizations disagree about what is
+// breaking, so a Policy can replace or extend the default BreakingRules, or classify changes with a callback.
+//
+// A Policy can be loaded from YAML or JSON using LoadPolicy, Classify can only be set in code.
+type Policy struct {
+	// Classify is an optional callback that is checked before any rules. If it returns true, the severity it returns
+	// is given to the change and no rules are checked.
+	Classify func(location *ChangeLocation, change *Change) (severity string, ok bool) `json:"-" yaml:"-"`
+
+	// Rules are checked in order, the first rule that applies to a change decides its severity. If nil,
+	// BreakingRules is used.
+	Rules []*BreakingRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+
+	// IncludeDefaults will check BreakingRules after Rules, when Rules are set.
+	IncludeDefaults bool `json:"includeDefaults,omitempty" yaml:"includeDefaults,omitempty"`
+}
+
+// LoadPolicy will parse a Policy from YAML or JSON bytes. Every rule must have an Id, and a known severity.
+func LoadPolicy(policyBytes []byte) (*Policy, error) {
+	var p Policy
+	if err := yaml.Unmarshal(policyBytes, &p); err != nil {
+		return nil, fmt.Errorf("unable to parse policy: %w", err)
+	}
+	for i, rule := range p.Rules {
+		if rule == nil || rule.Id == "" {
+			return nil, fmt.Errorf("policy rule %d has no id", i)
+		}
+		switch rule.Severity {
+		case "", SeverityInfo, SeverityWarning, SeverityBreaking:
+		default:
+			return nil, fmt.Errorf("policy rule '%s' has an unknown severity '%s'", rule.Id, rule.Severity)
+		}
+	}
+	return &p, nil
+}
+
+// GetRules returns the rules checked by the Policy, in order.
+func (p *Policy) GetRules() []*BreakingRule {
+	if p == nil || p.Rules == nil {
+		return BreakingRules
+	}
+	if p.IncludeDefaults {
+		return append(append([]*BreakingRule{}, p.Rules...), BreakingRules...)
+	}
+	return p.Rules
+}
+
+// ApplyPolicy will give every change held by a change model (like *DocumentChanges), and every change model
+// beneath it, a severity using a Policy. Changes given SeverityBreaking are marked as breaking, all others are
+// not. When a rule decides the severity, the rule Id is recorded on the change. Changes that the policy does not
+// classify keep their classification, and are given a severity to match it. If the policy is nil, the default
+// BreakingRules are used.
+func ApplyPolicy(changes any, policy *Policy) {
+	rules := policy.GetRules()
+	WalkChanges(changes, func(location *ChangeLocation, change *Change) {
+		if policy != nil && policy.Classify != nil {
+			if severity, ok := policy.Classify(location, change); ok {
+				change.Severity = severity
+				change.Breaking = severity == SeverityBreaking
+				change.Rule = ""
+				return
+			}
+		}
+		if rule := ClassifyChange(location.Object, change, rules); rule != nil {
+			change.Severity = rule.GetSeverity()
+			change.Breaking = change.Severity == SeverityBreaking
+			change.Rule = rule.Id
+			return
+		}
+		change.Severity = severityOf("", change.Breaking)
+	})
+}
+
+func severityOf(severity string, breaking bool) string {
+	if severity != "" {
+		return severity
+	}
+	if breaking {
+		return SeverityBreaking
+	}
+	return SeverityInfo
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	p, err := LoadPolicy([]byte(`includeDefaults: true
+rules:
+  - id: delete-removed
+    objects: [PathItem]
+    properties: [delete]
+    changeTypes: [5]
+    severity: warning`))
+	require.NoError(t, err)
+	require.Len(t, p.Rules, 1)
+	assert.Equal(t, SeverityWarning, p.Rules[0].GetSeverity())
+	assert.Len(t, p.GetRules(), len(BreakingRules)+1)
+
+	_, err = LoadPolicy([]byte(`rules: [{severity: warning}]`))
+	assert.Error(t, err)
+	_, err = LoadPolicy([]byte(`rules: [{id: nope, severity: fatal}]`))
+	assert.Error(t, err)
+	_, err = LoadPolicy([]byte(`rules: {`))
+	assert.Error(t, err)
+}
+
+func TestApplyPolicy_Rules(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	require.NotNil(t, changes)
+
+	ApplyPolicy(changes, &Policy{
+		IncludeDefaults: true,
+		Rules: []*BreakingRule{{
+			Id:          "delete-removed",
+			Objects:     []string{"PathItem"},
+			Properties:  []string{v3.DeleteLabel},
+			ChangeTypes: []int{PropertyRemoved},
+			Severity:    SeverityWarning,
+		}},
+	})
+	assert.Equal(t, 4, changes.TotalBreakingChanges())
+
+	report := changes.Report()
+	assert.Equal(t, 1, report.WarningChanges)
+	for _, e := range report.Changes {
+		assert.NotEmpty(t, e.Severity)
+		if e.Rule == "delete-removed" {
+			assert.Equal(t, SeverityWarning, e.Severity)
+			assert.False(t, e.Breaking)
+		}
+	}
+	md := string(changes.RenderMarkdown())
+	assert.Contains(t, md, "## Warnings")
+	assert.Contains(t, md, "**1** warnings")
+
+	html, err := changes.RenderHTML()
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "<h2>Warnings</h2>")
+}
+
+func TestApplyPolicy_Classify(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	require.NotNil(t, changes)
+
+	// nothing is breaking, everything in a schema is a warning.
+	ApplyPolicy(changes, &Policy{
+		Classify: func(location *ChangeLocation, change *Change) (string, bool) {
+			if location.Object == "Schema" {
+				return SeverityWarning, true
+			}
+			return SeverityInfo, true
+		},
+	})
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+	WalkChanges(changes, func(location *ChangeLocation, change *Change) {
+		assert.Empty(t, change.Rule)
+		if location.Object == "Schema" {
+			assert.Equal(t, SeverityWarning, change.Severity)
+		} else {
+			assert.Equal(t, SeverityInfo, change.Severity)
+		}
+	})
+}
+
+func TestApplyPolicy_Nil(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	require.NotNil(t, changes)
+
+	ApplyPolicy(changes, nil)
+	assert.Equal(t, 5, changes.TotalBreakingChanges())
+	WalkChanges(changes, func(location *ChangeLocation, change *Change) {
+		if change.Breaking {
+			assert.Equal(t, SeverityBreaking, change.Severity)
+		} else {
+			assert.Equal(t, SeverityInfo, change.Severity)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"slices"
+
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+var (
+	schemaChangesType = reflect.TypeOf(&SchemaChanges{})
+	changeCounterType = reflect.TypeOf((*interface{ TotalChanges() int })(nil)).Elem()
+)
+
+// IgnoreRefactors will remove every change from DocumentChanges that only comes from how the documents are
+// written, and not from what the API looks like. Moving an inline schema into components and referencing it
+// (or the reverse), or pointing a $ref at a different component that holds the same schema, are refactors.
+//
+// Schemas that changed between inline and referenced, or between references, are compared by the schemas they
+// resolve to, so only real changes to their shape are kept. Schemas added to, or removed from components
+// (or Swagger definitions) are removed, as the operations that use them report the change. A components object that
+// was added or removed, holding nothing but schemas, is removed too.
+//
+// The original and updated documents must be the same low-level *v3.Document or *v2.Swagger documents that were
+// compared to create the changes. The changes are modified and returned, if no changes are left, nil is returned.
+func IgnoreRefactors(changes *DocumentChanges, original, updated any) *DocumentChanges {
+	if changes == nil {
+		return nil
+	}
+	resolveRefactors(reflect.ValueOf(changes), make(map[uintptr]bool))
+
+	if changes.ComponentsChanges != nil && changes.ComponentsChanges.PropertyChanges != nil {
+		changes.ComponentsChanges.Changes = slices.DeleteFunc(changes.ComponentsChanges.Changes, func(c *Change) bool {
+			return (c.Property == v3.SchemasLabel || c.Property == v2.DefinitionsLabel) &&
+				c.ChangeType != Modified
+		})
+	}
+	if changes.PropertyChanges != nil {
+		changes.Changes = slices.DeleteFunc(changes.Changes, func(c *Change) bool {
+			if c.Property != v3.ComponentsLabel {
+				return false
+			}
+			return (c.ChangeType == PropertyRemoved && onlySchemaComponents(original)) ||
+				(c.ChangeType == PropertyAdded && onlySchemaComponents(updated))
+		})
+	}
+
+	pruneChanges(reflect.ValueOf(changes), make(map[uintptr]bool))
+	if changes.TotalChanges() <= 0 {
+		return nil
+	}
+	return changes
+}
+
+// onlySchemaComponents returns true if the components of a document hold nothing other than schemas.
+func onlySchemaComponents(doc any) bool {
+	d, ok := doc.(*v3.Document)
+	if !ok || d == nil || d.Components.Value == nil {
+		return false
+	}
+	c := d.Components.Value
+	return c.Responses.IsEmpty() && c.Parameters.IsEmpty() && c.Examples.IsEmpty() &&
+		c.RequestBodies.IsEmpty() && c.Headers.IsEmpty() && c.SecuritySchemes.IsEmpty() && c.Links.IsEmpty() &&
+		c.Callbacks.IsEmpty() && c.PathItems.IsEmpty()
+}
+
+// resolveRefactors replaces every SchemaChanges that only holds a change to a $ref, with a comparison of the
+// schemas the references resolve to.
+func resolveRefactors(v reflect.Value, seen map[uintptr]bool) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct || seen[v.Pointer()] {
+		return
+	}
+	seen[v.Pointer()] = true
+	if v.Type() == schemaChangesType {
+		sc := v.Interface().(*SchemaChanges)
+		if sc.originalRef != nil && sc.updatedRef != nil {
+			if resolved := compareSchemas(sc.originalRef, sc.updatedRef, true); resolved != nil {
+				*sc = *resolved
+			} else {
+				*sc = SchemaChanges{PropertyChanges: NewPropertyChanges(nil)}
+			}
+		}
+	}
+	s := v.Elem()
+	for i := 0; i < s.NumField(); i++ {
+		if !s.Type().Field(i).IsExported() {
+			continue
+		}
+		f := s.Field(i)
+		switch f.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			resolveRefactors(f, seen)
+		case reflect.Slice, reflect.Map:
+			iter := mapOrSliceValues(f)
+			for _, e := range iter {
+				if e.Kind() == reflect.Slice {
+					for _, ee := range mapOrSliceValues(e) {
+						resolveRefactors(ee, seen)
+					}
+					continue
+				}
+				resolveRefactors(e, seen)
+			}
+		}
+	}
+}
+
+// pruneChanges removes every change model without any changes from the tree of change models beneath v.
+func pruneChanges(v reflect.Value, seen map[uintptr]bool) {
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct || seen[v.Pointer()] {
+		return
+	}
+	seen[v.Pointer()] = true
+	s := v.Elem()
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Type().Field(i)
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		f := s.Field(i)
+		switch f.Kind() {
+		case reflect.Pointer:
+			pruneChanges(f, seen)
+			if empty(f) {
+				f.Set(reflect.Zero(f.Type()))
+			}
+		case reflect.Slice:
+			if !f.Type().Elem().Implements(changeCounterType) {
+				continue
+			}
+			kept := reflect.MakeSlice(f.Type(), 0, f.Len())
+			for j := 0; j < f.Len(); j++ {
+				pruneChanges(f.Index(j), seen)
+				if !empty(f.Index(j)) {
+					kept = reflect.Append(kept, f.Index(j))
+				}
+			}
+			if kept.Len() != f.Len() {
+				f.Set(kept)
+			}
+		case reflect.Map:
+			if f.Type().Key().Kind() != reflect.String || !f.Type().Elem().Implements(changeCounterType) {
+				continue
+			}
+			for _, k := range f.MapKeys() {
+				e := f.MapIndex(k)
+				pruneChanges(e, seen)
+				if empty(e) {
+					f.SetMapIndex(k, reflect.Value{})
+				}
+			}
+		}
+	}
+}
+
+// empty returns true if v is a change model that holds no changes.
+func empty(v reflect.Value) bool {
+	if v.Kind() != reflect.Pointer || v.IsNil() || !v.Type().Implements(changeCounterType) {
+		return false
+	}
+	return v.Interface().(interface{ TotalChanges() int }).TotalChanges() <= 0
+}
+
+func mapOrSliceValues(v reflect.Value) []reflect.Value {
+	var values []reflect.Value
+	if v.Kind() == reflect.Map {
+		for _, k := range v.MapKeys() {
+			values = append(values, v.MapIndex(k))
+		}
+		return values
+	}
+	for i := 0; i < v.Len(); i++ {
+		values = append(values, v.Index(i))
+	}
+	return values
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const refactorLeft = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  owner:
+                    type: object
+                    properties:
+                      name:
+                        type: string
+                  name:
+                    type: string`
+
+const refactorRight = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+        name:
+          type: string
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string`
+
+func buildRefactorDocuments(t *testing.T, left, right string) (*v3.Document, *v3.Document) {
+	siLeft, err := datamodel.ExtractSpecInfo([]byte(left))
+	require.NoError(t, err)
+	siRight, err := datamodel.ExtractSpecInfo([]byte(right))
+	require.NoError(t, err)
+
+	lDoc, _ := v3.CreateDocumentFromConfig(siLeft, datamodel.NewDocumentConfiguration())
+	rDoc, _ := v3.CreateDocumentFromConfig(siRight, datamodel.NewDocumentConfiguration())
+	return lDoc, rDoc
+}
+
+func TestIgnoreRefactors_MovedToComponents(t *testing.T) {
+	l, r := buildRefactorDocuments(t, refactorLeft, refactorRight)
+	changes := CompareDocuments(l, r)
+	require.NotNil(t, changes)
+	assert.Nil(t, IgnoreRefactors(changes, l, r))
+
+	// and back again.
+	changes = CompareDocuments(r, l)
+	require.NotNil(t, changes)
+	assert.Nil(t, IgnoreRefactors(changes, r, l))
+}
+
+func TestIgnoreRefactors_RealChange(t *testing.T) {
+	right := refactorRight + `
+          maxLength: 10`
+	l, r := buildRefactorDocuments(t, refactorLeft, right)
+	changes := CompareDocuments(l, r)
+	require.NotNil(t, changes)
+
+	// without the semantic mode, only the $ref change is seen.
+	var refChanged bool
+	WalkChanges(changes, func(location *ChangeLocation, change *Change) {
+		refChanged = refChanged || change.Property == v3.RefLabel
+	})
+	assert.True(t, refChanged)
+
+	changes = IgnoreRefactors(changes, l, r)
+	require.NotNil(t, changes)
+	assert.Nil(t, changes.ComponentsChanges)
+	assert.Empty(t, changes.Changes)
+
+	var found []string
+	WalkChanges(changes, func(location *ChangeLocation, change *Change) {
+		found = append(found, change.Property)
+	})
+	assert.Equal(t, []string{v3.MaxLengthLabel}, found)
+}
+
+func TestIgnoreRefactors_RenamedComponent(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: string`
+	right := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Animal'
+components:
+  schemas:
+    Animal:
+      type: string`
+	l, r := buildRefactorDocuments(t, left, right)
+	changes := CompareDocuments(l, r)
+	require.NotNil(t, changes)
+	assert.NotZero(t, changes.TotalBreakingChanges())
+	assert.Nil(t, IgnoreRefactors(changes, l, r))
+	assert.Nil(t, IgnoreRefactors(nil, l, r))
+}
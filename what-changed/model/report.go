@@ -0,0 +1,252 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// ReportEntry is a single change in a ChangeReport, flattened so it can be read without walking the change models.
+type ReportEntry struct {
+	// Path is the location of the change in the change models, see ChangeLocation.
+	Path string `json:"path" yaml:"path"`
+
+	// Object is the name of the change model that holds the change, like 'Schema' or 'Operation'.
+	Object string `json:"object" yaml:"object"`
+
+	// Property is the property name key being changed.
+	Property string `json:"property" yaml:"property"`
+
+	// ChangeType is the type of change, defined by the change constants (like Modified).
+	ChangeType int `json:"change" yaml:"change"`
+
+	// Type is the type of change, as text (like 'modified' or 'object_removed').
+	Type string `json:"type" yaml:"type"`
+
+	// Original is the original value represented as a string.
+	Original string `json:"original,omitempty" yaml:"original,omitempty"`
+
+	// New is the new value represented as a string.
+	New string `json:"new,omitempty" yaml:"new,omitempty"`
+
+	// Breaking determines if the change is a breaking one or not.
+	Breaking bool `json:"breaking" yaml:"breaking"`
+
+	// Rule is the Id of the BreakingRule that classified the change, if one applied to it.
+	Rule string `json:"rule,omitempty" yaml:"rule,omitempty"`
+
+	// Severity is how severe the change is, if it was classified by a Policy, see ApplyPolicy.
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	// Context holds the line and column positions of the original and new values.
+	Context *ChangeContext `json:"context,omitempty" yaml:"context,omitempty"`
+
+	// AffectedOperations are the operations that use the changed component, see MapAffectedOperations.
+	AffectedOperations []*AffectedOperation `json:"affectedOperations,omitempty" yaml:"affectedOperations,omitempty"`
+}
+
+// ChangeReport is a flat, structured report of every change found between two documents.
+type ChangeReport struct {
+	TotalChanges    int            `json:"totalChanges" yaml:"totalChanges"`
+	BreakingChanges int            `json:"breakingChanges" yaml:"breakingChanges"`
+	WarningChanges  int            `json:"warningChanges,omitempty" yaml:"warningChanges,omitempty"`
+	Changes         []*ReportEntry `json:"changes" yaml:"changes"`
+}
+
+// Report will flatten every change held by DocumentChanges into a ChangeReport, in the same order WalkChanges
+// visits them.
+func (d *DocumentChanges) Report() *ChangeReport {
+	report := &ChangeReport{Changes: []*ReportEntry{}}
+	if d == nil {
+		return report
+	}
+	WalkChanges(d, func(location *ChangeLocation, change *Change) {
+		report.TotalChanges++
+		if change.Breaking {
+			report.BreakingChanges++
+		} else if change.Severity == SeverityWarning {
+			report.WarningChanges++
+		}
+		report.Changes = append(report.Changes, &ReportEntry{
+			Path:       location.String(),
+			Object:     location.Object,
+			Property:   change.Property,
+			ChangeType: change.ChangeType,
+			Type:       changeTypeText(change.ChangeType),
+			Original:   change.Original,
+			New:        change.New,
+			Breaking:   change.Breaking,
+			Rule:       change.Rule,
+			Severity:   change.Severity,
+			Context:    change.Context,
+
+			AffectedOperations: change.AffectedOperations,
+		})
+	})
+	return report
+}
+
+// RenderJSON will render a ChangeReport of DocumentChanges as indented JSON.
+func (d *DocumentChanges) RenderJSON() ([]byte, error) {
+	return json.MarshalIndent(d.Report(), "", "  ")
+}
+
+// RenderMarkdown will render DocumentChanges as a Markdown changelog, with breaking changes listed first, then
+// warnings (see ApplyPolicy). The output is ready to be used in a pull request comment.
+func (d *DocumentChanges) RenderMarkdown() []byte {
+	report := d.Report()
+	var b strings.Builder
+	b.WriteString("# What Changed\n\n")
+	if report.TotalChanges == 0 {
+		b.WriteString("No changes found.\n")
+		return []byte(b.String())
+	}
+	if report.WarningChanges > 0 {
+		fmt.Fprintf(&b, "**%d** changes, **%d** breaking, **%d** warnings.\n", report.TotalChanges,
+			report.BreakingChanges, report.WarningChanges)
+	} else {
+		fmt.Fprintf(&b, "**%d** changes, **%d** breaking.\n", report.TotalChanges, report.BreakingChanges)
+	}
+
+	breaking, warning, other := report.split()
+	for _, section := range []struct {
+		title   string
+		entries []*ReportEntry
+	}{{"Breaking Changes", breaking}, {"Warnings", warning}, {"Changes", other}} {
+		if len(section.entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", section.title)
+		for _, e := range section.entries {
+			fmt.Fprintf(&b, "- %s", e.Describe())
+			if e.Path != "" {
+				fmt.Fprintf(&b, " at %s", markdownCode(e.Path))
+			}
+			if line := e.Line(); line > 0 {
+				fmt.Fprintf(&b, " (line %d)", line)
+			}
+			if len(e.AffectedOperations) > 0 {
+				ops := make([]string, len(e.AffectedOperations))
+				for i, op := range e.AffectedOperations {
+					ops[i] = markdownCode(op.String())
+				}
+				fmt.Fprintf(&b, ", affects %s", strings.Join(ops, ", "))
+			}
+			b.WriteString("\n")
+		}
+	}
+	return []byte(b.String())
+}
+
+var htmlReport = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>What Changed</title>
+</head>
+<body>
+<h1>What Changed</h1>
+{{- if eq .Report.TotalChanges 0 }}
+<p>No changes found.</p>
+{{- else }}
+<p><strong>{{ .Report.TotalChanges }}</strong> changes, <strong>{{ .Report.BreakingChanges }}</strong> breaking
+{{- with .Report.WarningChanges }}, <strong>{{ . }}</strong> warnings{{ end }}.</p>
+{{- range .Sections }}{{ if .Entries }}
+<h2>{{ .Title }}</h2>
+<table>
+<tr><th>Path</th><th>Property</th><th>Change</th><th>Original</th><th>New</th><th>Line</th></tr>
+{{- range .Entries }}
+<tr><td><code>{{ .Path }}</code></td><td>{{ .Property }}</td><td>{{ .Type }}</td><td>{{ .Original }}</td><td>{{ .New }}</td><td>{{ with .Line }}{{ . }}{{ end }}</td></tr>
+{{- end }}
+</table>
+{{- end }}{{ end }}
+{{- end }}
+</body>
+</html>
+`))
+
+// RenderHTML will render DocumentChanges as a standalone HTML changelog, with breaking changes listed first, then
+// warnings.
+// All values are escaped.
+func (d *DocumentChanges) RenderHTML() ([]byte, error) {
+	report := d.Report()
+	breaking, warning, other := report.split()
+	type section struct {
+		Title   string
+		Entries []*ReportEntry
+	}
+	var buf bytes.Buffer
+	err := htmlReport.Execute(&buf, map[string]any{
+		"Report":   report,
+		"Sections": []section{{"Breaking Changes", breaking}, {"Warnings", warning}, {"Changes", other}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Describe returns a short, readable sentence describing the change, like "`get` was removed".
+func (e *ReportEntry) Describe() string {
+	switch e.ChangeType {
+	case PropertyAdded, ObjectAdded:
+		if e.New != "" && e.New != e.Property {
+			return fmt.Sprintf("%s was added: %s", markdownCode(e.Property), markdownCode(e.New))
+		}
+		return fmt.Sprintf("%s was added", markdownCode(e.Property))
+	case PropertyRemoved, ObjectRemoved:
+		if e.Original != "" && e.Original != e.Property {
+			return fmt.Sprintf("%s was removed: %s", markdownCode(e.Property), markdownCode(e.Original))
+		}
+		return fmt.Sprintf("%s was removed", markdownCode(e.Property))
+	}
+	return fmt.Sprintf("%s changed from %s to %s", markdownCode(e.Property), markdownCode(e.Original),
+		markdownCode(e.New))
+}
+
+// Line returns the line of the change in the new document, or the line in the original document when the change
+// is a removal. Zero is returned if the position is not known.
+func (e *ReportEntry) Line() int {
+	if e.Context == nil {
+		return 0
+	}
+	if e.Context.NewLine != nil {
+		return *e.Context.NewLine
+	}
+	if e.Context.OriginalLine != nil {
+		return *e.Context.OriginalLine
+	}
+	return 0
+}
+
+func (r *ChangeReport) split() (breaking, warning, other []*ReportEntry) {
+	for _, e := range r.Changes {
+		switch {
+		case e.Breaking:
+			breaking = append(breaking, e)
+		case e.Severity == SeverityWarning:
+			warning = append(warning, e)
+		default:
+			other = append(other, e)
+		}
+	}
+	return breaking, warning, other
+}
+
+// markdownCode wraps a value as inline code, using a fence long enough for any backticks held by the value.
+func markdownCode(value string) string {
+	value = strings.ReplaceAll(value, "\n", " ")
+	fence := "`"
+	for strings.Contains(value, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(value, "`") || strings.HasSuffix(value, "`") {
+		value = " " + value + " "
+	}
+	return fence + value + fence
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentChanges_Report(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	require.NotNil(t, changes)
+
+	report := changes.Report()
+	assert.Equal(t, changes.TotalChanges(), report.TotalChanges)
+	assert.Equal(t, changes.TotalBreakingChanges(), report.BreakingChanges)
+	require.Len(t, report.Changes, report.TotalChanges)
+
+	var removed *ReportEntry
+	for _, e := range report.Changes {
+		if e.Rule == "path-removed" {
+			removed = e
+		}
+	}
+	require.NotNil(t, removed)
+	assert.Equal(t, "paths", removed.Path)
+	assert.Equal(t, "Paths", removed.Object)
+	assert.Equal(t, "object_removed", removed.Type)
+	assert.Equal(t, "/owners", removed.Original)
+	assert.Equal(t, 24, removed.Line())
+}
+
+func TestDocumentChanges_RenderJSON(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	b, err := changes.RenderJSON()
+	require.NoError(t, err)
+
+	var report ChangeReport
+	require.NoError(t, json.Unmarshal(b, &report))
+	assert.Equal(t, changes.TotalChanges(), report.TotalChanges)
+	assert.Equal(t, 5, report.BreakingChanges)
+	assert.NotEmpty(t, report.Changes[0].Path)
+
+	var empty *DocumentChanges
+	b, err = empty.RenderJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"changes": []`)
+}
+
+func TestDocumentChanges_RenderMarkdown(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	md := string(changes.RenderMarkdown())
+
+	assert.True(t, strings.HasPrefix(md, "# What Changed\n\n"))
+	assert.Contains(t, md, "## Breaking Changes")
+	assert.Contains(t, md, "- `path` was removed: `/owners` at `paths` (line 24)")
+	assert.NotContains(t, md, "## Changes")
+
+	var empty *DocumentChanges
+	assert.Contains(t, string(empty.RenderMarkdown()), "No changes found.")
+}
+
+func TestDocumentChanges_RenderHTML(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	h, err := changes.RenderHTML()
+	require.NoError(t, err)
+	assert.Contains(t, string(h), "<h2>Breaking Changes</h2>")
+	assert.Contains(t, string(h), "<td>/owners</td>")
+
+	entry := &ReportEntry{Property: "description", ChangeType: Modified, Original: "<b>", New: "</b>"}
+	report := &DocumentChanges{PropertyChanges: NewPropertyChanges([]*Change{{
+		Property: entry.Property, ChangeType: entry.ChangeType, Original: entry.Original, New: entry.New,
+	}})}
+	h, err = report.RenderHTML()
+	require.NoError(t, err)
+	assert.Contains(t, string(h), "&lt;b&gt;")
+	assert.NotContains(t, string(h), "<b>")
+}
+
+func TestReportEntry_Describe(t *testing.T) {
+	assert.Equal(t, "`type` changed from `string` to `integer`",
+		(&ReportEntry{Property: "type", ChangeType: Modified, Original: "string", New: "integer"}).Describe())
+	assert.Equal(t, "`get` was added",
+		(&ReportEntry{Property: "get", ChangeType: PropertyAdded, New: "get"}).Describe())
+	assert.Equal(t, "``a`b`` was removed",
+		(&ReportEntry{Property: "a`b", ChangeType: ObjectRemoved}).Describe())
+}
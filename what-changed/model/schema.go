@@ -46,6 +46,11 @@ type SchemaChanges struct {
 	UnevaluatedPropertiesChanges *SchemaChanges            `json:"unevaluatedProperties,omitempty" yaml:"unevaluatedProperties,omitempty"`
 	DependentSchemasChanges      map[string]*SchemaChanges `json:"dependentSchemas,omitempty" yaml:"dependentSchemas,omitempty"`
 	PatternPropertiesChanges     map[string]*SchemaChanges `json:"patternProperties,omitempty" yaml:"patternProperties,omitempty"`
+	DefsChanges                  map[string]*SchemaChanges `json:"$defs,omitempty" yaml:"$defs,omitempty"`
+
+	// the schemas compared, when the change is only to a $ref. Used by IgnoreRefactors to compare the
+	// schemas the references resolve to.
+	originalRef, updatedRef *base.SchemaProxy
 }
 
 // GetAllChanges returns a slice of all changes made between Responses objects
@@ -120,6 +125,13 @@ func (s *SchemaChanges) GetAllChanges() []*Change {
 			}
 		}
 	}
+	if s.DefsChanges != nil {
+		for n := range s.DefsChanges {
+			if s.DefsChanges[n] != nil {
+				changes = append(changes, s.DefsChanges[n].GetAllChanges()...)
+			}
+		}
+	}
 	if s.PatternPropertiesChanges != nil {
 		for n := range s.PatternPropertiesChanges {
 			if s.PatternPropertiesChanges[n] != nil {
@@ -208,6 +220,11 @@ func (s *SchemaChanges) TotalChanges() int {
 			t += s.DependentSchemasChanges[n].TotalChanges()
 		}
 	}
+	if s.DefsChanges != nil {
+		for n := range s.DefsChanges {
+			t += s.DefsChanges[n].TotalChanges()
+		}
+	}
 	if s.PatternPropertiesChanges != nil {
 		for n := range s.PatternPropertiesChanges {
 			t += s.PatternPropertiesChanges[n].TotalChanges()
@@ -290,6 +307,11 @@ func (s *SchemaChanges) TotalBreakingChanges() int {
 			t += s.DependentSchemasChanges[n].TotalBreakingChanges()
 		}
 	}
+	if s.DefsChanges != nil {
+		for n := range s.DefsChanges {
+			t += s.DefsChanges[n].TotalBreakingChanges()
+		}
+	}
 	if s.PatternPropertiesChanges != nil {
 		for n := range s.PatternPropertiesChanges {
 			t += s.PatternPropertiesChanges[n].TotalBreakingChanges()
@@ -309,6 +331,12 @@ func (s *SchemaChanges) TotalBreakingChanges() int {
 // CompareSchemas accepts a left and right SchemaProxy and checks for changes. If anything is found, returns
 // a pointer to SchemaChanges, otherwise returns nil
 func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
+	return compareSchemas(l, r, false)
+}
+
+// compareSchemas compares two schemas. When resolved is true, the schemas the proxies resolve to are compared,
+// instead of recording a change to the $ref when one or both of the proxies are references.
+func compareSchemas(l, r *base.SchemaProxy, resolved bool) *SchemaChanges {
 	sc := new(SchemaChanges)
 	var changes []*Change
 
@@ -329,7 +357,7 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 	if l != nil && r != nil {
 
 		// if left proxy is a reference and right is a reference (we won't recurse into them)
-		if l.IsReference() && r.IsReference() {
+		if !resolved && l.IsReference() && r.IsReference() {
 			// points to the same schema
 			if l.GetReference() == r.GetReference() {
 				// there is nothing to be done at this point.
@@ -340,12 +368,13 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 					l.GetValueNode().Content[1], r.GetValueNode().Content[1], true, l.GetReference(),
 					r.GetReference())
 				sc.PropertyChanges = NewPropertyChanges(changes)
+				sc.originalRef, sc.updatedRef = l, r
 				return sc
 			}
 		}
 
 		// changed from inline to ref
-		if !l.IsReference() && r.IsReference() {
+		if !resolved && !l.IsReference() && r.IsReference() {
 			// check if the referenced schema matches or not
 			// https://github.com/pb33f/libopenapi/issues/218
 			lHash := l.Schema().Hash()
@@ -354,12 +383,13 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 				CreateChange(&changes, Modified, v3.RefLabel,
 					l.GetValueNode(), r.GetValueNode().Content[1], true, l, r.GetReference())
 				sc.PropertyChanges = NewPropertyChanges(changes)
+				sc.originalRef, sc.updatedRef = l, r
 				return sc // we're done here
 			}
 		}
 
 		// changed from ref to inline
-		if l.IsReference() && !r.IsReference() {
+		if !resolved && l.IsReference() && !r.IsReference() {
 			// check if the referenced schema matches or not
 			// https://github.com/pb33f/libopenapi/issues/218
 			lHash := l.Schema().Hash()
@@ -368,10 +398,22 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 				CreateChange(&changes, Modified, v3.RefLabel,
 					l.GetValueNode().Content[1], r.GetValueNode(), true, l.GetReference(), r)
 				sc.PropertyChanges = NewPropertyChanges(changes)
+				sc.originalRef, sc.updatedRef = l, r
 				return sc // done, nothing else to do.
 			}
 		}
 
+		// boolean schemas (true / false) have nothing to recurse into, they are either the same or they are not.
+		if l.IsBoolean() || r.IsBoolean() {
+			if l.IsBoolean() && r.IsBoolean() && l.GetBoolean() == r.GetBoolean() {
+				return nil
+			}
+			CreateChange(&changes, Modified, v3.SchemaLabel,
+				l.GetValueNode(), r.GetValueNode(), true, l, r)
+			sc.PropertyChanges = NewPropertyChanges(changes)
+			return sc
+		}
+
 		lSchema := l.Schema()
 		rSchema := r.Schema()
 
@@ -401,6 +443,9 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 		patterns, patternsTotal := checkMappedSchemaOfASchema(lSchema.PatternProperties.Value, rSchema.PatternProperties.Value, &changes, doneChan)
 		sc.PatternPropertiesChanges = patterns
 
+		defs, defsTotal := checkMappedSchemaOfASchema(lSchema.Defs.Value, rSchema.Defs.Value, &changes, doneChan)
+		sc.DefsChanges = defs
+
 		// check polymorphic and multi-values async for speed.
 		go extractSchemaChanges(lSchema.OneOf.Value, rSchema.OneOf.Value, v3.OneOfLabel,
 			&sc.OneOfChanges, &changes, doneChan)
@@ -411,7 +456,7 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 		go extractSchemaChanges(lSchema.AnyOf.Value, rSchema.AnyOf.Value, v3.AnyOfLabel,
 			&sc.AnyOfChanges, &changes, doneChan)
 
-		totalChecks := totalProperties + depsTotal + patternsTotal + 3
+		totalChecks := totalProperties + depsTotal + patternsTotal + defsTotal + 3
 		completedChecks := 0
 		for completedChecks < totalChecks {
 			<-doneChan
@@ -923,13 +968,14 @@ func checkSchemaPropertyChanges(
 	}
 
 	// Enums
+	lScope, rScope := low.GetHashScope(lSchema.GetContext()), low.GetHashScope(rSchema.GetContext())
 	j = make(map[string]int)
 	k = make(map[string]int)
 	for i := range lSchema.Enum.Value {
-		j[toString(lSchema.Enum.Value[i].Value)] = i
+		j[scopedToString(lScope, lSchema.Enum.Value[i].Value)] = i
 	}
 	for i := range rSchema.Enum.Value {
-		k[toString(rSchema.Enum.Value[i].Value)] = i
+		k[scopedToString(rScope, rSchema.Enum.Value[i].Value)] = i
 	}
 	for g := range k {
 		if _, ok := j[g]; !ok {
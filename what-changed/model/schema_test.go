@@ -854,6 +854,37 @@ components:
 	assert.Equal(t, 1, changes.DependentSchemasChanges["schemaOne"].PropertyChanges.TotalChanges())
 }
 
+func TestCompareSchemas_Defs(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $defs:
+        defOne:
+          type: int`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $defs:
+        defOne:
+          type: string`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	// extract left reference schema and non reference schema.
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Len(t, changes.GetAllChanges(), 1)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	assert.Equal(t, 1, changes.DefsChanges["defOne"].PropertyChanges.TotalChanges())
+}
+
 func TestCompareSchemas_PatternProperties(t *testing.T) {
 	left := `openapi: 3.1
 components:
@@ -1591,6 +1622,43 @@ components:
 	assert.Equal(t, 1, changes.TotalBreakingChanges())
 }
 
+func TestCompareSchemas_BooleanSchemas(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      properties:
+        same: true
+        flipped: true
+        toSchema: false
+      not: false`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      properties:
+        same: true
+        flipped: false
+        toSchema:
+          type: string
+      not: false`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 2, changes.TotalBreakingChanges())
+	assert.Nil(t, changes.SchemaPropertyChanges["same"])
+	assert.Equal(t, Modified, changes.SchemaPropertyChanges["flipped"].Changes[0].ChangeType)
+	assert.Equal(t, Modified, changes.SchemaPropertyChanges["toSchema"].Changes[0].ChangeType)
+	assert.Nil(t, changes.NotChanges)
+}
+
 func TestCompareSchemas_PropertyChanged(t *testing.T) {
 	left := `openapi: 3.0
 components:
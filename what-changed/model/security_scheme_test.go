@@ -329,3 +329,31 @@ flows:
 	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
 	assert.Equal(t, Modified, extChanges.OAuthFlowChanges.ImplicitChanges.Changes[0].ChangeType)
 }
+
+func TestCompareSecuritySchemes_v3_Metadata(t *testing.T) {
+
+	left := `type: oauth2
+oauth2MetadataUrl: https://pb33f.io/.well-known/oauth-authorization-server`
+
+	right := `type: oauth2
+oauth2MetadataUrl: https://pb33f.io/.well-known/openid-configuration`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.SecurityScheme
+	var rDoc v3.SecurityScheme
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// compare
+	extChanges := CompareSecuritySchemes(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.OAuth2MetadataUrlLabel, extChanges.Changes[0].Property)
+}
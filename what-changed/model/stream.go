@@ -0,0 +1,159 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// streamPathItem is a path item of a Swagger or OpenAPI document, with the key node of its path.
+type streamPathItem struct {
+	keyNode *yaml.Node
+	value   low.Hashable
+}
+
+// StreamChanges compares two Swagger or OpenAPI documents (the same documents accepted by CompareDocuments) and
+// passes every change found to the callback, instead of returning them all as DocumentChanges.
+//
+// Each path item is compared, and its changes passed to the callback, one at a time, before the next path item is
+// compared. Changes to everything else in the document follow the paths. Only the changes of a single path item are
+// held in memory at once, so very large documents can be compared without building a complete report.
+//
+// Returning false from the callback stops the comparison, nothing else is compared and the callback is not called
+// again. Changes are classified by the default breaking rules before they are passed to the callback, so stopping
+// at the first breaking change only requires checking change.Breaking.
+//
+// StreamChanges returns false if the comparison was stopped by the callback, otherwise it returns true.
+func StreamChanges(l, r any, callback func(change *Change) bool) bool {
+	defer freezeHashes(l, r)()
+	stopped := false
+	emit := func(changes *DocumentChanges) bool {
+		if changes == nil || changes.TotalChanges() <= 0 {
+			return true
+		}
+		ApplyBreakingRules(changes, nil)
+		WalkChanges(changes, func(_ *ChangeLocation, change *Change) {
+			if !stopped && !callback(change) {
+				stopped = true
+			}
+		})
+		return !stopped
+	}
+
+	var lPaths, rPaths any
+	switch lDoc := l.(type) {
+	case *v3.Document:
+		rDoc, ok := r.(*v3.Document)
+		if !ok {
+			return true
+		}
+		if !lDoc.Paths.IsEmpty() || !rDoc.Paths.IsEmpty() {
+			lPaths, rPaths = lDoc.Paths.Value, rDoc.Paths.Value
+		}
+	case *v2.Swagger:
+		rDoc, ok := r.(*v2.Swagger)
+		if !ok {
+			return true
+		}
+		if !lDoc.Paths.IsEmpty() || !rDoc.Paths.IsEmpty() {
+			lPaths, rPaths = lDoc.Paths.Value, rDoc.Paths.Value
+		}
+	default:
+		return true
+	}
+	if lPaths != nil || rPaths != nil {
+		if !streamPaths(lPaths, rPaths, emit) {
+			return false
+		}
+	}
+	return emit(compareDocuments(l, r, false, nil))
+}
+
+// streamPaths compares every path item of two Swagger or OpenAPI Paths objects, one at a time, and emits the changes
+// of each one. Returns false as soon as emit does.
+func streamPaths(l, r any, emit func(changes *DocumentChanges) bool) bool {
+	lKeys, lItems, lExt := streamPathItems(l)
+	rKeys, rItems, rExt := streamPathItems(r)
+
+	pathChanges := func(pc *PathsChanges) *DocumentChanges {
+		return &DocumentChanges{PropertyChanges: NewPropertyChanges(nil), PathsChanges: pc}
+	}
+	for _, k := range lKeys {
+		lItem := lItems[k]
+		rItem, ok := rItems[k]
+		if !ok {
+			var changes []*Change
+			CreateChange(&changes, ObjectRemoved, v3.PathLabel, lItem.keyNode, nil, true, lItem.value, nil)
+			if !emit(pathChanges(&PathsChanges{PropertyChanges: NewPropertyChanges(changes)})) {
+				return false
+			}
+			continue
+		}
+		if low.AreEqual(lItem.value, rItem.value) {
+			continue
+		}
+		pic := ComparePathItems(lItem.value, rItem.value)
+		if pic == nil {
+			continue
+		}
+		if !emit(pathChanges(&PathsChanges{
+			PropertyChanges:  NewPropertyChanges(nil),
+			PathItemsChanges: map[string]*PathItemChanges{k: pic},
+		})) {
+			return false
+		}
+	}
+	for _, k := range rKeys {
+		if _, ok := lItems[k]; ok {
+			continue
+		}
+		var changes []*Change
+		CreateChange(&changes, ObjectAdded, v3.PathLabel, nil, rItems[k].keyNode, false, nil, rItems[k].value)
+		if !emit(pathChanges(&PathsChanges{PropertyChanges: NewPropertyChanges(changes)})) {
+			return false
+		}
+	}
+	return emit(pathChanges(&PathsChanges{
+		PropertyChanges:  NewPropertyChanges(nil),
+		ExtensionChanges: CompareExtensions(lExt, rExt),
+	}))
+}
+
+// streamPathItems returns the paths of a Swagger or OpenAPI Paths object in the order they are defined, the path
+// items by path and the extensions of the paths object.
+func streamPathItems(paths any) ([]string, map[string]streamPathItem,
+	*orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]],
+) {
+	var keys []string
+	items := make(map[string]streamPathItem)
+	switch p := paths.(type) {
+	case *v3.Paths:
+		if p == nil {
+			return nil, items, nil
+		}
+		if p.PathItems != nil {
+			for k, v := range p.PathItems.FromOldest() {
+				keys = append(keys, k.Value)
+				items[k.Value] = streamPathItem{keyNode: k.KeyNode, value: v.Value}
+			}
+		}
+		return keys, items, p.Extensions
+	case *v2.Paths:
+		if p == nil {
+			return nil, items, nil
+		}
+		if p.PathItems != nil {
+			for k, v := range p.PathItems.FromOldest() {
+				keys = append(keys, k.Value)
+				items[k.Value] = streamPathItem{keyNode: k.KeyNode, value: v.Value}
+			}
+		}
+		return keys, items, p.Extensions
+	}
+	return nil, items, nil
+}
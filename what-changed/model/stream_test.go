@@ -0,0 +1,74 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamChanges(t *testing.T) {
+	original, _ := os.ReadFile("../../test_specs/burgershop.openapi.yaml")
+	updated, _ := os.ReadFile("../../test_specs/burgershop.openapi-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(updated)
+	origDoc, _ := v3.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v3.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	all := CompareDocuments(origDoc, modDoc)
+	require.NotNil(t, all)
+
+	var streamed []*Change
+	done := StreamChanges(origDoc, modDoc, func(change *Change) bool {
+		streamed = append(streamed, change)
+		return true
+	})
+	assert.True(t, done)
+	assert.Len(t, streamed, all.TotalChanges())
+
+	count := 0
+	done = StreamChanges(origDoc, modDoc, func(change *Change) bool {
+		count++
+		return count < 3
+	})
+	assert.False(t, done)
+	assert.Equal(t, 3, count)
+}
+
+func TestStreamChanges_Swagger(t *testing.T) {
+	original, _ := os.ReadFile("../../test_specs/petstorev2-complete.yaml")
+	updated, _ := os.ReadFile("../../test_specs/petstorev2-complete-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(updated)
+	origDoc, _ := v2.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v2.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	all := CompareDocuments(origDoc, modDoc)
+	require.NotNil(t, all)
+
+	count := 0
+	assert.True(t, StreamChanges(origDoc, modDoc, func(change *Change) bool {
+		count++
+		return true
+	}))
+	assert.Equal(t, all.TotalChanges(), count)
+}
+
+func TestStreamChanges_NoChanges(t *testing.T) {
+	original, _ := os.ReadFile("../../test_specs/burgershop.openapi.yaml")
+	info, _ := datamodel.ExtractSpecInfo(original)
+	doc, _ := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+
+	assert.True(t, StreamChanges(doc, doc, func(change *Change) bool {
+		t.Fatal("no changes expected")
+		return true
+	}))
+	assert.True(t, StreamChanges(nil, nil, nil))
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Verdicts given to DocumentChanges by a ChangeSummary. Each verdict is also the semantic version bump suggested
+// for the updated document.
+const (
+	// VerdictNone means nothing changed.
+	VerdictNone = "none"
+
+	// VerdictPatch means nothing was added and nothing is breaking, like a description that changed.
+	VerdictPatch = "patch"
+
+	// VerdictMinor means something was added, or a change was given SeverityWarning, but nothing is breaking.
+	VerdictMinor = "minor"
+
+	// VerdictMajor means at least one change is breaking.
+	VerdictMajor = "major"
+)
+
+var verdictOrder = []string{VerdictNone, VerdictPatch, VerdictMinor, VerdictMajor}
+
+// ChangeSummary counts the changes held by DocumentChanges, and gives them a single verdict.
+type ChangeSummary struct {
+	TotalChanges    int `json:"totalChanges" yaml:"totalChanges"`
+	BreakingChanges int `json:"breakingChanges" yaml:"breakingChanges"`
+	Added           int `json:"added" yaml:"added"`
+	Removed         int `json:"removed" yaml:"removed"`
+	Modified        int `json:"modified" yaml:"modified"`
+
+	// Severities are the number of changes by severity, like SeverityWarning. Changes that were not classified by
+	// a Policy are counted as SeverityBreaking or SeverityInfo.
+	Severities map[string]int `json:"severities" yaml:"severities"`
+
+	// Verdict is the overall verdict, like VerdictMajor. It is also the suggested semantic version bump.
+	Verdict string `json:"verdict" yaml:"verdict"`
+}
+
+// Summary counts every change held by DocumentChanges by type and severity, and gives the changes a verdict. Any
+// breaking change is VerdictMajor, anything added or given SeverityWarning is VerdictMinor, and anything else is
+// VerdictPatch. If nothing changed, the verdict is VerdictNone.
+func (d *DocumentChanges) Summary() *ChangeSummary {
+	s := &ChangeSummary{Severities: make(map[string]int), Verdict: VerdictNone}
+	if d == nil {
+		return s
+	}
+	WalkChanges(d, func(location *ChangeLocation, change *Change) {
+		s.TotalChanges++
+		severity := severityOf(change.Severity, change.Breaking)
+		if change.Breaking {
+			s.BreakingChanges++
+			severity = SeverityBreaking
+		}
+		s.Severities[severity]++
+
+		verdict := VerdictPatch
+		switch change.ChangeType {
+		case PropertyAdded, ObjectAdded:
+			s.Added++
+			verdict = VerdictMinor
+		case PropertyRemoved, ObjectRemoved:
+			s.Removed++
+		default:
+			s.Modified++
+		}
+		if severity == SeverityWarning {
+			verdict = VerdictMinor
+		}
+		if change.Breaking {
+			verdict = VerdictMajor
+		}
+		s.Verdict = maxVerdict(s.Verdict, verdict)
+	})
+	return s
+}
+
+// Exceeds returns true if the verdict of the summary is more severe than the supplied verdict. For example, a CI
+// pipeline that allows anything but breaking changes can fail when Exceeds(VerdictMinor) is true.
+func (s *ChangeSummary) Exceeds(verdict string) bool {
+	return indexOfVerdict(s.Verdict) > indexOfVerdict(verdict)
+}
+
+// NextVersion returns the version that follows the supplied semantic version (like '1.4.2' or 'v1.4.2'), using
+// the verdict of the summary as the bump. Pre-release and build metadata are dropped. If the verdict is
+// VerdictNone, the version is returned as it is.
+func (s *ChangeSummary) NextVersion(version string) (string, error) {
+	if s.Verdict == VerdictNone {
+		return version, nil
+	}
+	prefix := ""
+	v := version
+	if strings.HasPrefix(v, "v") {
+		prefix, v = "v", v[1:]
+	}
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("unable to bump version '%s', it is not a semantic version", version)
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("unable to bump version '%s', it is not a semantic version", version)
+		}
+		nums[i] = n
+	}
+	switch s.Verdict {
+	case VerdictMajor:
+		nums[0], nums[1], nums[2] = nums[0]+1, 0, 0
+	case VerdictMinor:
+		nums[1], nums[2] = nums[1]+1, 0
+	default:
+		nums[2]++
+	}
+	return fmt.Sprintf("%s%d.%d.%d", prefix, nums[0], nums[1], nums[2]), nil
+}
+
+func indexOfVerdict(verdict string) int {
+	for i, v := range verdictOrder {
+		if v == verdict {
+			return i
+		}
+	}
+	return 0
+}
+
+func maxVerdict(a, b string) string {
+	if indexOfVerdict(b) > indexOfVerdict(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentChanges_Summary(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	require.NotNil(t, changes)
+
+	s := changes.Summary()
+	assert.Equal(t, changes.TotalChanges(), s.TotalChanges)
+	assert.Equal(t, changes.TotalBreakingChanges(), s.BreakingChanges)
+	assert.Equal(t, s.TotalChanges, s.Added+s.Removed+s.Modified)
+	assert.Equal(t, s.BreakingChanges, s.Severities[SeverityBreaking])
+	assert.Equal(t, VerdictMajor, s.Verdict)
+	assert.True(t, s.Exceeds(VerdictMinor))
+	assert.False(t, s.Exceeds(VerdictMajor))
+
+	next, err := s.NextVersion("v1.4.2-beta.1")
+	require.NoError(t, err)
+	assert.Equal(t, "v2.0.0", next)
+}
+
+func TestDocumentChanges_Summary_Policy(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	require.NotNil(t, changes)
+
+	ApplyPolicy(changes, &Policy{Classify: func(location *ChangeLocation, change *Change) (string, bool) {
+		return SeverityWarning, true
+	}})
+	s := changes.Summary()
+	assert.Equal(t, VerdictMinor, s.Verdict)
+	assert.Equal(t, s.TotalChanges, s.Severities[SeverityWarning])
+
+	next, err := s.NextVersion("1.4.2")
+	require.NoError(t, err)
+	assert.Equal(t, "1.5.0", next)
+
+	ApplyPolicy(changes, &Policy{Classify: func(location *ChangeLocation, change *Change) (string, bool) {
+		return SeverityInfo, true
+	}})
+	s = changes.Summary()
+	assert.Equal(t, VerdictMinor, s.Verdict) // something was added.
+	assert.Zero(t, s.BreakingChanges)
+}
+
+func TestChangeSummary_NextVersion(t *testing.T) {
+	s := &ChangeSummary{Verdict: VerdictPatch}
+	next, err := s.NextVersion("1.4.2")
+	require.NoError(t, err)
+	assert.Equal(t, "1.4.3", next)
+
+	_, err = s.NextVersion("1.4")
+	assert.Error(t, err)
+	_, err = s.NextVersion("1.x.2")
+	assert.Error(t, err)
+
+	s.Verdict = VerdictNone
+	next, err = s.NextVersion("anything")
+	require.NoError(t, err)
+	assert.Equal(t, "anything", next)
+
+	var d *DocumentChanges
+	assert.Equal(t, VerdictNone, d.Summary().Verdict)
+	assert.False(t, d.Summary().Exceeds(VerdictNone))
+}
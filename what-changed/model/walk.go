@@ -0,0 +1,113 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ChangeLocation describes where a Change was found in a tree of change models.
+type ChangeLocation struct {
+	// Path is the route through the change models to the change, using the same names the models are
+	// serialized with, plus map keys and slice indexes. For example [paths pathItems /pets get parameters 0].
+	Path []string
+
+	// Object is the name of the change model that holds the change, without the 'Changes' suffix.
+	// For example 'Schema', 'Operation' or 'PathItem'.
+	Object string
+}
+
+// String returns the Path of the location, joined by '/'.
+func (l *ChangeLocation) String() string {
+	return strings.Join(l.Path, "/")
+}
+
+var (
+	propertyChangesType = reflect.TypeOf(PropertyChanges{})
+	changeSliceType     = reflect.TypeOf([]*Change{})
+)
+
+// WalkChanges will visit every Change held by a change model (like *DocumentChanges or *SchemaChanges) and all
+// the change models beneath it, along with the location of the change. Map entries are visited in key order, so
+// the walk order is always the same.
+func WalkChanges(changes any, visit func(location *ChangeLocation, change *Change)) {
+	if changes == nil {
+		return
+	}
+	walkChanges(reflect.ValueOf(changes), nil, visit, make(map[uintptr]bool))
+}
+
+func walkChanges(v reflect.Value, path []string, visit func(*ChangeLocation, *Change), seen map[uintptr]bool) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	if seen[v.Pointer()] {
+		return
+	}
+	seen[v.Pointer()] = true
+	s := v.Elem()
+	loc := &ChangeLocation{Path: path, Object: strings.TrimSuffix(s.Type().Name(), "Changes")}
+
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := s.Field(i)
+		switch {
+		case field.Anonymous && field.Type.Kind() == reflect.Pointer && field.Type.Elem() == propertyChangesType:
+			if !fv.IsNil() {
+				for _, c := range fv.Elem().FieldByName("Changes").Interface().([]*Change) {
+					visit(loc, c)
+				}
+			}
+		case field.Type == changeSliceType:
+			for _, c := range fv.Interface().([]*Change) {
+				visit(&ChangeLocation{Path: appendPath(path, fieldName(field)), Object: loc.Object}, c)
+			}
+		case field.Type.Kind() == reflect.Pointer:
+			walkChanges(fv, appendPath(path, fieldName(field)), visit, seen)
+		case field.Type.Kind() == reflect.Slice:
+			walkSlice(fv, appendPath(path, fieldName(field)), visit, seen)
+		case field.Type.Kind() == reflect.Map && field.Type.Key().Kind() == reflect.String:
+			keys := fv.MapKeys()
+			slices.SortFunc(keys, func(a, b reflect.Value) int {
+				return strings.Compare(a.String(), b.String())
+			})
+			for _, k := range keys {
+				entry := fv.MapIndex(k)
+				entryPath := appendPath(path, fieldName(field), k.String())
+				if entry.Kind() == reflect.Slice {
+					walkSlice(entry, entryPath, visit, seen)
+					continue
+				}
+				walkChanges(entry, entryPath, visit, seen)
+			}
+		}
+	}
+}
+
+func walkSlice(v reflect.Value, path []string, visit func(*ChangeLocation, *Change), seen map[uintptr]bool) {
+	for i := 0; i < v.Len(); i++ {
+		walkChanges(v.Index(i), appendPath(path, strconv.Itoa(i)), visit, seen)
+	}
+}
+
+func fieldName(f reflect.StructField) string {
+	if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag != "" && tag != "-" {
+		return tag
+	}
+	return f.Name
+}
+
+func appendPath(path []string, segments ...string) []string {
+	p := make([]string, 0, len(path)+len(segments))
+	return append(append(p, path...), segments...)
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkChanges(t *testing.T) {
+	changes := compareV3Documents(t, breakingLeft, breakingRight)
+	require.NotNil(t, changes)
+
+	var visited int
+	locations := make(map[string]string)
+	WalkChanges(changes, func(location *ChangeLocation, change *Change) {
+		visited++
+		locations[location.Object+"."+change.Property] = location.String()
+	})
+	assert.Equal(t, changes.TotalChanges(), visited)
+	assert.Equal(t, "paths", locations["Paths."+v3.PathLabel])
+	assert.Equal(t, "paths/pathItems//pets", locations["PathItem."+v3.DeleteLabel])
+	assert.Equal(t, "paths/pathItems//pets/get/parameters/0", locations["Parameter."+v3.RequiredLabel])
+	assert.Equal(t, "paths/pathItems//pets/get/responses/response/200/content/application/json/schemas",
+		locations["Schema."+v3.RequiredLabel])
+}
+
+func TestWalkChanges_Nil(t *testing.T) {
+	var visited bool
+	WalkChanges(nil, func(location *ChangeLocation, change *Change) { visited = true })
+	WalkChanges((*DocumentChanges)(nil), func(location *ChangeLocation, change *Change) { visited = true })
+	assert.False(t, visited)
+}
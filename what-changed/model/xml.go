@@ -101,6 +101,17 @@ func CompareXML(l, r *base.XML) *XMLChanges {
 		New:       r,
 	})
 
+	// NodeType (breaking change)
+	props = append(props, &PropertyCheck{
+		LeftNode:  l.NodeType.ValueNode,
+		RightNode: r.NodeType.ValueNode,
+		Label:     v3.NodeTypeLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
 	// check properties
 	CheckProperties(props)
 
@@ -145,3 +145,35 @@ wrapped: true`
 	assert.Nil(t, extChanges)
 
 }
+
+func TestCompareXML_NodeTypeChanged(t *testing.T) {
+
+	left := `name: xml thing
+nodeType: element`
+
+	right := `name: xml thing
+nodeType: cdata`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc base.XML
+	var rDoc base.XML
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	assert.NotEqual(t, lDoc.Hash(), rDoc.Hash())
+
+	// compare.
+	extChanges := CompareXML(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, "nodeType", extChanges.Changes[0].Property)
+	assert.Equal(t, "element", extChanges.Changes[0].Original)
+	assert.Equal(t, "cdata", extChanges.Changes[0].New)
+}
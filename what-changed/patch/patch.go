@@ -0,0 +1,242 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package patch creates patches that describe the differences between two documents, so a stored document can be
+// updated by applying the delta, instead of replacing the whole document.
+//
+// Both RFC 6902 JSON Patch and RFC 7396 JSON Merge Patch formats are supported.
+//   - https://www.rfc-editor.org/rfc/rfc6902
+//   - https://www.rfc-editor.org/rfc/rfc7396
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// JSON Patch operations created when comparing documents.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+)
+
+// Operation is a single RFC 6902 JSON Patch operation.
+type Operation struct {
+	// Op is the operation to perform, one of OpAdd, OpRemove or OpReplace.
+	Op string `json:"op" yaml:"op"`
+
+	// Path is the JSON Pointer to the location the operation is performed on.
+	Path string `json:"path" yaml:"path"`
+
+	// Value is the value to add, or to replace the existing value with. Not used by OpRemove.
+	Value any `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// MarshalJSON is a custom JSON marshaller for the Operation, a value of null is kept for add and replace operations.
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	data := map[string]any{
+		"op":   o.Op,
+		"path": o.Path,
+	}
+	if o.Op != OpRemove {
+		data["value"] = o.Value
+	}
+	return json.Marshal(data)
+}
+
+// CreateJSONPatch will compare an original and updated document root node, and return the RFC 6902 JSON Patch
+// operations that turn the original document into the updated one. Operations are returned in the order they must
+// be applied. Sequences are compared by position.
+func CreateJSONPatch(original, updated *yaml.Node) ([]*Operation, error) {
+	l, r, err := patchRoots(original, updated)
+	if err != nil {
+		return nil, err
+	}
+	ops := []*Operation{}
+	if err = diffNodes(l, r, "", &ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// CreateMergePatch will compare an original and updated document root node, and return an RFC 7396 JSON Merge Patch
+// that turns the original document into the updated one. If nothing changed, an empty object is returned.
+//
+// A merge patch cannot set a value to null, null removes the value instead. Use CreateJSONPatch when the updated
+// document holds null values.
+func CreateMergePatch(original, updated *yaml.Node) ([]byte, error) {
+	l, r, err := patchRoots(original, updated)
+	if err != nil {
+		return nil, err
+	}
+	merge, _, err := mergeNodes(l, r)
+	if err != nil {
+		return nil, err
+	}
+	if merge == nil {
+		merge = map[string]any{}
+	}
+	return json.Marshal(merge)
+}
+
+func patchRoots(original, updated *yaml.Node) (*yaml.Node, *yaml.Node, error) {
+	if original == nil || updated == nil {
+		return nil, nil, errors.New("unable to create a patch, the original and updated documents are required")
+	}
+	return documentContent(original), documentContent(updated), nil
+}
+
+func documentContent(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return utils.NodeAlias(node.Content[0])
+	}
+	return utils.NodeAlias(node)
+}
+
+func diffNodes(l, r *yaml.Node, pointer string, ops *[]*Operation) error {
+	l, r = utils.NodeAlias(l), utils.NodeAlias(r)
+	switch {
+	case l.Kind == yaml.MappingNode && r.Kind == yaml.MappingNode:
+		rKeys := mappingKeys(r)
+		lKeys := mappingKeys(l)
+		for i := 0; i+1 < len(l.Content); i += 2 {
+			if _, ok := rKeys[l.Content[i].Value]; !ok {
+				*ops = append(*ops, &Operation{Op: OpRemove, Path: pointer + "/" + utils.EscapeJSONPointer(l.Content[i].Value)})
+			}
+		}
+		for i := 0; i+1 < len(r.Content); i += 2 {
+			key := r.Content[i].Value
+			child := pointer + "/" + utils.EscapeJSONPointer(key)
+			if li, ok := lKeys[key]; ok {
+				if err := diffNodes(l.Content[li+1], r.Content[i+1], child, ops); err != nil {
+					return err
+				}
+				continue
+			}
+			v, err := nodeValue(r.Content[i+1])
+			if err != nil {
+				return err
+			}
+			*ops = append(*ops, &Operation{Op: OpAdd, Path: child, Value: v})
+		}
+	case l.Kind == yaml.SequenceNode && r.Kind == yaml.SequenceNode:
+		shared := min(len(l.Content), len(r.Content))
+		for i := 0; i < shared; i++ {
+			if err := diffNodes(l.Content[i], r.Content[i], pointer+"/"+strconv.Itoa(i), ops); err != nil {
+				return err
+			}
+		}
+		// remove from the end, so the positions of earlier items do not move.
+		for i := len(l.Content) - 1; i >= shared; i-- {
+			*ops = append(*ops, &Operation{Op: OpRemove, Path: pointer + "/" + strconv.Itoa(i)})
+		}
+		for i := shared; i < len(r.Content); i++ {
+			v, err := nodeValue(r.Content[i])
+			if err != nil {
+				return err
+			}
+			*ops = append(*ops, &Operation{Op: OpAdd, Path: pointer + "/" + strconv.Itoa(i), Value: v})
+		}
+	default:
+		if equalScalars(l, r) {
+			return nil
+		}
+		v, err := nodeValue(r)
+		if err != nil {
+			return err
+		}
+		*ops = append(*ops, &Operation{Op: OpReplace, Path: pointer, Value: v})
+	}
+	return nil
+}
+
+// mergeNodes returns the merge patch for a pair of nodes, and false if they are the same.
+func mergeNodes(l, r *yaml.Node) (any, bool, error) {
+	l, r = utils.NodeAlias(l), utils.NodeAlias(r)
+	if l.Kind == yaml.MappingNode && r.Kind == yaml.MappingNode {
+		patch := make(map[string]any)
+		rKeys := mappingKeys(r)
+		lKeys := mappingKeys(l)
+		for i := 0; i+1 < len(l.Content); i += 2 {
+			if _, ok := rKeys[l.Content[i].Value]; !ok {
+				patch[l.Content[i].Value] = nil
+			}
+		}
+		for i := 0; i+1 < len(r.Content); i += 2 {
+			key := r.Content[i].Value
+			if li, ok := lKeys[key]; ok {
+				v, changed, err := mergeNodes(l.Content[li+1], r.Content[i+1])
+				if err != nil {
+					return nil, false, err
+				}
+				if changed {
+					patch[key] = v
+				}
+				continue
+			}
+			v, err := nodeValue(r.Content[i+1])
+			if err != nil {
+				return nil, false, err
+			}
+			patch[key] = v
+		}
+		return patch, len(patch) > 0, nil
+	}
+	if equal, _ := utils.NodesEqual(l, r,
+		utils.NodesEqualOptions{IgnoreStyle: true, IgnoreComments: true, IgnoreKeyOrder: true}); equal {
+		return nil, false, nil
+	}
+	// anything that is not an object is replaced as a whole.
+	v, err := nodeValue(r)
+	return v, true, err
+}
+
+func equalScalars(l, r *yaml.Node) bool {
+	return l.Kind == yaml.ScalarNode && r.Kind == yaml.ScalarNode && l.ShortTag() == r.ShortTag() &&
+		l.Value == r.Value
+}
+
+func mappingKeys(node *yaml.Node) map[string]int {
+	keys := make(map[string]int, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys[node.Content[i].Value] = i
+	}
+	return keys
+}
+
+// nodeValue decodes a node into a value that can be rendered as JSON, all keys of objects are rendered as strings.
+func nodeValue(node *yaml.Node) (any, error) {
+	var v any
+	if err := node.Decode(&v); err != nil {
+		return nil, fmt.Errorf("unable to decode value at line %d, column %d: %w", node.Line, node.Column, err)
+	}
+	return jsonValue(v), nil
+}
+
+func jsonValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			t[k] = jsonValue(e)
+		}
+		return t
+	case map[any]any:
+		m := make(map[string]any, len(t))
+		for k, e := range t {
+			m[fmt.Sprint(k)] = jsonValue(e)
+		}
+		return m
+	case []any:
+		for i, e := range t {
+			t[i] = jsonValue(e)
+		}
+		return t
+	}
+	return v
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func parse(t *testing.T, spec string) *yaml.Node {
+	var n yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &n))
+	return &n
+}
+
+const patchLeft = `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: owners
+  - name: stores
+paths:
+  /pets/{id}:
+    get:
+      description: get a pet
+  ~things:
+    get:
+      description: tilde`
+
+const patchRight = `openapi: 3.1.0
+info:
+  title: all the pets
+  version: 1.0.0
+  summary: pets
+tags:
+  - name: pets
+paths:
+  /pets/{id}:
+    get:
+      description: get a pet
+      deprecated: true
+x-owner: null`
+
+func TestCreateJSONPatch(t *testing.T) {
+	ops, err := CreateJSONPatch(parse(t, patchLeft), parse(t, patchRight))
+	require.NoError(t, err)
+
+	b, err := json.Marshal(ops)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+  {"op": "replace", "path": "/info/title", "value": "all the pets"},
+  {"op": "add", "path": "/info/summary", "value": "pets"},
+  {"op": "remove", "path": "/tags/2"},
+  {"op": "remove", "path": "/tags/1"},
+  {"op": "remove", "path": "/paths/~0things"},
+  {"op": "add", "path": "/paths/~1pets~1{id}/get/deprecated", "value": true},
+  {"op": "add", "path": "/x-owner", "value": null}
+]`, string(b))
+}
+
+func TestCreateJSONPatch_Identical(t *testing.T) {
+	ops, err := CreateJSONPatch(parse(t, patchLeft), parse(t, patchLeft))
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+}
+
+func TestCreateJSONPatch_TypeChange(t *testing.T) {
+	ops, err := CreateJSONPatch(parse(t, "a: 1\nb: [1]\nc: '1'"), parse(t, "a: '1'\nb: {x: 1}\nc: '1'"))
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	assert.Equal(t, &Operation{Op: OpReplace, Path: "/a", Value: "1"}, ops[0])
+	assert.Equal(t, &Operation{Op: OpReplace, Path: "/b", Value: map[string]any{"x": 1}}, ops[1])
+}
+
+func TestCreateJSONPatch_Aliases(t *testing.T) {
+	ops, err := CreateJSONPatch(parse(t, "a: &x {b: 1}\nc: *x"), parse(t, "a: {b: 1}\nc: {b: 2}"))
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	assert.Equal(t, "/c/b", ops[0].Path)
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	b, err := CreateMergePatch(parse(t, patchLeft), parse(t, patchRight))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "info": {"title": "all the pets", "summary": "pets"},
+  "tags": [{"name": "pets"}],
+  "paths": {"~things": null, "/pets/{id}": {"get": {"deprecated": true}}},
+  "x-owner": null
+}`, string(b))
+
+	b, err = CreateMergePatch(parse(t, patchLeft), parse(t, patchLeft))
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(b))
+}
+
+func TestCreatePatch_Errors(t *testing.T) {
+	_, err := CreateJSONPatch(nil, parse(t, patchRight))
+	assert.Error(t, err)
+	_, err = CreateMergePatch(parse(t, patchLeft), nil)
+	assert.Error(t, err)
+}